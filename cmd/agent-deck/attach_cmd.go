@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// handleAttach attaches to a session from the shell, starting its tmux
+// session first if it isn't running
+func handleAttach(profile string, args []string) {
+	fs := flag.NewFlagSet("attach", flag.ExitOnError)
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck attach <id|title>")
+		fmt.Println()
+		fmt.Println("Attach to a session without opening the TUI, starting it if it isn't running.")
+		fmt.Println("Press Ctrl+Q to detach.")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck attach my-project")
+		fmt.Println("  alias api='agent-deck attach api'")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	storage, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	inst, errMsg, errCode := ResolveSession(fs.Arg(0), instances)
+	if inst == nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", errMsg)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	if !inst.Exists() {
+		fmt.Printf("Starting '%s'...\n", inst.Title)
+		// A queued prompt is sent in the background while attached
+		if err := inst.Start(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to start session: %v\n", err)
+			os.Exit(1)
+		}
+		inst.PostStartSync(3 * time.Second)
+		if err := saveSessionData(storage, instances); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to save session state: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	attachSession(profile, storage, inst)
+	if inst.WaitPendingPrompt() {
+		savePromptDelivered(storage, inst)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleBackup dispatches backup subcommands
+func handleBackup(args []string) {
+	if len(args) == 0 {
+		printBackupHelp()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "now", "create":
+		handleBackupNow(args[1:])
+	case "list", "ls":
+		handleBackupList(args[1:])
+	case "help", "--help", "-h":
+		printBackupHelp()
+	default:
+		fmt.Printf("Unknown backup command: %s\n", args[0])
+		fmt.Println()
+		printBackupHelp()
+		os.Exit(1)
+	}
+}
+
+// printBackupHelp prints usage for backup commands
+func printBackupHelp() {
+	fmt.Println("Usage: agent-deck backup <command> [options]")
+	fmt.Println()
+	fmt.Println("Export every profile's sessions and config.toml to the backup directory")
+	fmt.Println("([backup] dir, default ~/.agent-deck/backups). With [backup] enabled the")
+	fmt.Println("TUI does this once a day, keeping the newest [backup] keep (default 7).")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  now       Write a backup now, whether or not [backup] is enabled")
+	fmt.Println("  list      List the backups, newest first")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  agent-deck backup now")
+	fmt.Println("  agent-deck backup list --json")
+}
+
+func handleBackupNow(args []string) {
+	fs := flag.NewFlagSet("backup now", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	result, err := session.RunBackup(session.GetBackupSettings(), time.Now())
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	msg := fmt.Sprintf("Backed up %d files to %s", result.Files, FormatPath(result.Path))
+	if result.Pruned > 0 {
+		msg += fmt.Sprintf(" (removed %d old)", result.Pruned)
+	}
+	out.Success(msg, map[string]interface{}{
+		"success": true,
+		"backup":  result,
+	})
+}
+
+func handleBackupList(args []string) {
+	fs := flag.NewFlagSet("backup list", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	dir, err := session.GetBackupSettings().GetDir()
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	backups, err := session.ListBackups(dir)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	var sb strings.Builder
+	if len(backups) == 0 {
+		sb.WriteString(fmt.Sprintf("No backups in %s. Write one with: agent-deck backup now\n", FormatPath(dir)))
+	} else {
+		sb.WriteString(fmt.Sprintf("Backups in %s:\n", FormatPath(dir)))
+	}
+	for _, b := range backups {
+		sb.WriteString(fmt.Sprintf("  %-36s %s  %d KB\n", b.Name, b.Time.Format("2006-01-02 15:04:05"), (b.Size+1023)/1024))
+	}
+	if backups == nil {
+		backups = []session.BackupInfo{}
+	}
+	out.Print(sb.String(), backups)
+}
@@ -5,10 +5,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+	"github.com/asheshgoplani/agent-deck/internal/ui"
 )
 
 // normalizeArgs reorders args so flags come before positional arguments.
@@ -80,7 +81,7 @@ func (c *CLIOutput) Success(message string, data interface{}) {
 		c.printJSON(data)
 		return
 	}
-	fmt.Printf("%s %s\n", successSymbol, message)
+	fmt.Print(cliText(fmt.Sprintf("%s %s\n", successSymbol, message)))
 }
 
 // Error prints an error message or JSON error response
@@ -93,7 +94,7 @@ func (c *CLIOutput) Error(message string, code string) {
 		})
 		return
 	}
-	fmt.Fprintf(os.Stderr, "Error: %s\n", message)
+	fmt.Fprint(os.Stderr, cliText(fmt.Sprintf("Error: %s\n", message)))
 }
 
 // Print prints data (human-readable or JSON)
@@ -105,7 +106,15 @@ func (c *CLIOutput) Print(humanOutput string, jsonData interface{}) {
 		c.printJSON(jsonData)
 		return
 	}
-	fmt.Print(humanOutput)
+	fmt.Print(cliText(humanOutput))
+}
+
+// cliText adapts human-readable output for --plain: no escapes, ASCII icons
+func cliText(s string) string {
+	if ui.PlainMode() {
+		return ui.PlainText(s)
+	}
+	return s
 }
 
 // printJSON marshals and prints JSON data
@@ -176,7 +185,7 @@ func ResolveSession(identifier string, instances []*session.Instance) (*session.
 	// Try path match - collect all sessions at this path
 	var pathMatches []*session.Instance
 	for _, inst := range instances {
-		if inst.ProjectPath == identifier {
+		if session.SamePath(inst.ProjectPath, identifier) {
 			pathMatches = append(pathMatches, inst)
 		}
 	}
@@ -206,8 +215,7 @@ func GetCurrentSessionID() string {
 	}
 
 	// Get current tmux session name
-	cmd := exec.Command("tmux", "display-message", "-p", "#S")
-	output, err := cmd.Output()
+	output, err := tmux.CurrentOutput("display-message", "-p", "#S")
 	if err != nil {
 		return ""
 	}
@@ -243,17 +251,43 @@ func ResolveSessionOrCurrent(identifier string, instances []*session.Instance) (
 	return ResolveSession(identifier, instances)
 }
 
+// loadResolvedSession resolves identifier in the profile like
+// ResolveSessionOrCurrent, for read-only commands. An exact title is looked
+// up through the storage's title index; only ID prefixes, paths and the
+// current session need the whole deck loaded.
+func loadResolvedSession(profile, identifier string) (*session.Instance, string, string, error) {
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	if identifier != "" {
+		matches, err := storage.FindByTitle(identifier)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("failed to load sessions: %w", err)
+		}
+		if len(matches) > 0 {
+			return matches[0], "", "", nil
+		}
+	}
+	instances, _, err := storage.LoadWithGroups()
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to load sessions: %w", err)
+	}
+	inst, errMsg, errCode := ResolveSessionOrCurrent(identifier, instances)
+	return inst, errMsg, errCode, nil
+}
+
 // StatusSymbol returns the symbol for a status
 func StatusSymbol(status session.Status) string {
 	switch status {
 	case session.StatusRunning:
-		return "●"
+		return cliText("●")
 	case session.StatusWaiting:
-		return "◐"
+		return cliText("◐")
 	case session.StatusIdle:
-		return "○"
+		return cliText("○")
 	case session.StatusError:
-		return "✕"
+		return cliText("✕")
 	default:
 		return "?"
 	}
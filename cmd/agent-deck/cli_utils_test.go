@@ -4,6 +4,9 @@ import (
 	"flag"
 	"reflect"
 	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/ui"
 )
 
 func TestNormalizeArgs(t *testing.T) {
@@ -210,3 +213,19 @@ func TestNormalizeArgsIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestPlainCLIOutput(t *testing.T) {
+	plain, rest := extractPlainFlag([]string{"--plain", "list", "--json"})
+	if !plain || len(rest) != 2 || rest[0] != "list" {
+		t.Fatalf("extractPlainFlag = %v, %v", plain, rest)
+	}
+
+	ui.SetPlainMode(true)
+	t.Cleanup(func() { ui.SetPlainMode(false) })
+	if got := StatusSymbol(session.StatusWaiting); got != "~" {
+		t.Errorf("StatusSymbol(waiting) = %q in plain mode", got)
+	}
+	if got := cliText(successSymbol + " done → next"); got != "+ done > next" {
+		t.Errorf("cliText = %q", got)
+	}
+}
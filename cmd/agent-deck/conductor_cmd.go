@@ -724,10 +724,10 @@ func handleConductorStatus(_ string, args []string) {
 			statusIcon = "!"
 			statusText = "no session"
 		case cs.Running:
-			statusIcon = "●"
+			statusIcon = cliText("●")
 			statusText = "running"
 		default:
-			statusIcon = "○"
+			statusIcon = cliText("○")
 			statusText = "stopped"
 		}
 
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/daemon"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// handleDaemon tracks session statuses without the TUI and serves them
+// read-only over HTTP until interrupted
+func handleDaemon(profile string, args []string) {
+	settings := session.GetDaemonSettings()
+	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+	listen := fs.String("listen", settings.GetListen(), "Address to serve the status page on")
+	interval := fs.Duration("interval", session.GetStatusSettings().GetPollInterval(), "How often to refresh session statuses")
+	token := fs.String("token", settings.Token, "Require this token (?token= or bearer) on every request")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck daemon [options]")
+		fmt.Println()
+		fmt.Println("Track session statuses in the background and serve them read-only:")
+		fmt.Println("  /          status page (refreshes itself, works on phones)")
+		fmt.Println("  /metrics   sessions and status counts as JSON")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck daemon")
+		fmt.Println("  agent-deck daemon --listen 0.0.0.0:8420 --token s3cret   # Reachable on the LAN")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	if *interval < 500*time.Millisecond {
+		fmt.Fprintln(os.Stderr, "Error: --interval must be at least 500ms")
+		os.Exit(1)
+	}
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to initialize storage: %v\n", err)
+		os.Exit(1)
+	}
+	if db := storage.GetDB(); db != nil {
+		statedb.SetGlobal(db)
+	}
+
+	d := daemon.New(storage, *interval, *token)
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	server := &http.Server{Handler: d.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go d.Run(ctx)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Serving profile '%s' on http://%s (Ctrl+C to stop)\n", storage.Profile(), listener.Addr())
+	if *token == "" && !isLoopbackAddr(listener.Addr()) {
+		fmt.Println("Warning: reachable from the network without a token; set --token or [daemon] token")
+	}
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// isLoopbackAddr reports whether addr only accepts local connections
+func isLoopbackAddr(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// demoConfig keeps the demo deck self-contained: no update prompt, no tmux
+// notification bar and no background maintenance
+const demoConfig = `default_tool = "claude"
+
+[updates]
+check_enabled = false
+
+[notifications]
+enabled = false
+
+[maintenance]
+enabled = false
+`
+
+// setupDemo points HOME at a throwaway directory holding a config and a
+// deck of synthetic sessions, and switches instances to simulation, so
+// --demo never reads or writes the real ~/.agent-deck or tmux. The returned
+// cleanup removes the directory.
+func setupDemo() (func(), error) {
+	dir, err := os.MkdirTemp("", "agent-deck-demo-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create demo directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	if err := os.Setenv("HOME", dir); err != nil {
+		cleanup()
+		return nil, err
+	}
+	deckDir := filepath.Join(dir, ".agent-deck")
+	if err := os.MkdirAll(deckDir, 0700); err != nil {
+		cleanup()
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(deckDir, session.UserConfigFileName), []byte(demoConfig), 0600); err != nil {
+		cleanup()
+		return nil, err
+	}
+	session.ClearUserConfigCache()
+	session.SetDemoMode(true)
+
+	storage, err := session.NewStorageWithProfile(session.DefaultProfile)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	defer storage.Close()
+	if err := storage.SaveStorageData(session.DemoStorageData()); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to load demo sessions: %w", err)
+	}
+	return cleanup, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleDoctor checks stored sessions for problems and optionally repairs them
+func handleDoctor(profile string, args []string) {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	fix := fs.Bool("fix", false, "Repair fixable issues and save")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Only set the exit code")
+	quietShort := fs.Bool("q", false, "Only set the exit code (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck doctor [options]")
+		fmt.Println()
+		fmt.Println("Check stored sessions for duplicate IDs, empty titles, groups that don't")
+		fmt.Println("exist, unknown tools and missing parent sessions. Exits 1 if any issue")
+		fmt.Println("remains. Also lists the installed tool versions, warning about ones")
+		fmt.Println("listed in [tool_versions] broken.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck doctor")
+		fmt.Println("  agent-deck doctor --fix")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	storage, instances, groupsData, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	var fixed []session.LintIssue
+	if *fix {
+		var tree *session.GroupTree
+		tree, fixed = session.FixStorage(instances, groupsData)
+		if len(fixed) > 0 {
+			if err := storage.SaveWithGroups(instances, tree); err != nil {
+				out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
+				os.Exit(1)
+			}
+			if _, instances, groupsData, err = loadSessionData(profile); err != nil {
+				out.Error(err.Error(), ErrCodeNotFound)
+				os.Exit(1)
+			}
+		}
+	}
+	issues := session.LintStorage(instances, groupsData)
+	tools := session.DetectToolVersions(session.VersionCheckedTools(instances))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Profile: %s (%d sessions)\n", storage.Profile(), len(instances))
+	for _, issue := range fixed {
+		fmt.Fprintf(&b, "  fixed  %s\n", issue)
+	}
+	hint := false
+	for _, issue := range issues {
+		fmt.Fprintf(&b, "  %s  %s\n", issueMark(issue), issue)
+		hint = hint || issue.Fixable
+	}
+	switch {
+	case len(issues) == 0 && len(fixed) == 0:
+		b.WriteString("No issues found\n")
+	case len(issues) == 0:
+		fmt.Fprintf(&b, "Fixed %d issue(s)\n", len(fixed))
+	case hint:
+		fmt.Fprintf(&b, "%d issue(s); run 'agent-deck doctor --fix' to repair the fixable ones\n", len(issues))
+	default:
+		fmt.Fprintf(&b, "%d issue(s) need fixing by hand (agent-deck session set / edit)\n", len(issues))
+	}
+	b.WriteString(formatToolVersions(tools, instances))
+
+	if issues == nil {
+		issues = []session.LintIssue{}
+	}
+	if fixed == nil {
+		fixed = []session.LintIssue{}
+	}
+	out.Print(b.String(), map[string]interface{}{
+		"profile": storage.Profile(),
+		"issues":  issues,
+		"fixed":   fixed,
+		"tools":   tools,
+	})
+	if len(issues) > 0 {
+		os.Exit(1)
+	}
+}
+
+// issueMark labels an issue by whether --fix can repair it
+func issueMark(issue session.LintIssue) string {
+	if issue.Fixable {
+		return "warn "
+	}
+	return "error"
+}
+
+// formatToolVersions lists the installed tools for doctor, warning about
+// known-broken versions and missing tools that sessions use
+func formatToolVersions(tools []session.ToolVersion, instances []*session.Instance) string {
+	used := make(map[string]int)
+	for _, inst := range instances {
+		used[inst.Tool]++
+	}
+	var b strings.Builder
+	b.WriteString("Tools:\n")
+	installed := 0
+	for _, v := range tools {
+		if !v.Installed() {
+			if used[v.Tool] > 0 {
+				fmt.Fprintf(&b, "  warn   %s, used by %d session(s)\n", v, used[v.Tool])
+			}
+			continue
+		}
+		installed++
+		if v.Broken != "" {
+			fmt.Fprintf(&b, "  warn   %s: %s\n", v, v.Broken)
+			continue
+		}
+		fmt.Fprintf(&b, "  ok     %s (%s)\n", v, v.Path)
+	}
+	if installed == 0 {
+		b.WriteString("  none of the supported tools are installed\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleDump saves a session's pane content (optionally the full scrollback) to a file or stdout
+func handleDump(profile string, args []string) {
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	output := fs.String("output", "", "Write to this file instead of stdout")
+	outputShort := fs.String("o", "", "Write to this file (short)")
+	history := fs.Bool("history", false, "Include the entire scrollback history, not just the visible screen")
+	jsonOutput := fs.Bool("json", false, "Output result as JSON (requires --output)")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck dump [id|title] [options]")
+		fmt.Println()
+		fmt.Println("Save a session's terminal content as plain text.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck dump my-project                         # Visible screen to stdout")
+		fmt.Println("  agent-deck dump my-project --history -o out.txt    # Entire scrollback to file")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	outPath := *output
+	if outPath == "" {
+		outPath = *outputShort
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	inst, errMsg, errCode, err := loadResolvedSession(profile, fs.Arg(0))
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	if inst == nil {
+		out.Error(errMsg, errCode)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	content, err := inst.CaptureScrollback(*history)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	if outPath == "" {
+		fmt.Print(content)
+		return
+	}
+
+	if err := session.WriteExport(outPath, content); err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	lines := strings.Count(content, "\n")
+	out.Success(fmt.Sprintf("Saved %d lines from '%s' to %s", lines, inst.Title, outPath), map[string]interface{}{
+		"success": true,
+		"id":      inst.ID,
+		"title":   inst.Title,
+		"path":    outPath,
+		"lines":   lines,
+		"history": *history,
+	})
+}
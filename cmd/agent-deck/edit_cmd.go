@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleEdit opens a session's stored record in $EDITOR and writes the
+// edited fields back after validating them
+func handleEdit(profile string, args []string) {
+	fs := flag.NewFlagSet("edit", flag.ExitOnError)
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck edit [id|title]")
+		fmt.Println()
+		fmt.Println("Edit a session's stored fields as JSON in $VISUAL or $EDITOR (default vi).")
+		fmt.Println("The record is checked when the editor exits; fix it or discard the changes.")
+		fmt.Println("The tmux session is not touched; restart the session to apply command changes.")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck edit my-project")
+		fmt.Println("  EDITOR=\"code --wait\" agent-deck edit 4f2a")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	_, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	inst, errMsg, errCode := ResolveSessionOrCurrent(fs.Arg(0), instances)
+	if inst == nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", errMsg)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	original, err := inst.Record()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	f, err := os.CreateTemp("", "agent-deck-edit-*.json")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	tmpPath := f.Name()
+	defer os.Remove(tmpPath)
+	_, err = f.Write(original)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	for {
+		if err := runEditor(tmpPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		edited, err := os.ReadFile(tmpPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if bytes.Equal(bytes.TrimSpace(edited), bytes.TrimSpace(original)) {
+			fmt.Println("No changes")
+			return
+		}
+
+		// Reload so changes made while the editor was open aren't lost
+		storage, fresh, groupsData, err := loadSessionData(profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		idx := -1
+		for i, other := range fresh {
+			if other.ID == inst.ID {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			fmt.Fprintf(os.Stderr, "Error: session '%s' was removed while editing\n", inst.Title)
+			os.Exit(1)
+		}
+
+		updated, err := session.ApplyRecord(fresh[idx], edited, fresh)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			if !confirmEditAgain() {
+				fmt.Println("Changes discarded")
+				os.Exit(1)
+			}
+			continue
+		}
+		oldTitle := fresh[idx].Title
+		fresh[idx] = updated
+		if err := storage.SaveWithGroups(fresh, session.NewGroupTreeWithGroups(fresh, groupsData)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to save: %v\n", err)
+			os.Exit(1)
+		}
+		// A new title shows in the tmux status bar, as with session set
+		if updated.Title != oldTitle {
+			updated.SyncTmuxDisplayName()
+		}
+		fmt.Printf("Saved '%s'\n", updated.Title)
+		return
+	}
+}
+
+// runEditor opens path in $VISUAL, $EDITOR or vi and waits for it to exit.
+// The variable may carry flags, e.g. "code --wait".
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	argv := append(strings.Fields(editor), path)
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %s: %w", argv[0], err)
+	}
+	return nil
+}
+
+// confirmEditAgain asks whether to reopen the editor after a failed check
+func confirmEditAgain() bool {
+	fmt.Print("Edit again? [Y/n] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "" || answer == "y" || answer == "yes"
+}
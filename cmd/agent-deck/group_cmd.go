@@ -22,8 +22,18 @@ func handleGroup(profile string, args []string) {
 		handleGroupList(profile, args[1:])
 	case "create", "new":
 		handleGroupCreate(profile, args[1:])
-	case "delete", "rm":
+	case "delete", "rm", "remove":
 		handleGroupDelete(profile, args[1:])
+	case "rename":
+		handleGroupRename(profile, args[1:])
+	case "prune", "cleanup":
+		handleGroupPrune(profile, args[1:])
+	case "merge":
+		handleGroupMerge(profile, args[1:])
+	case "hide":
+		handleGroupHide(profile, args[1:], true)
+	case "unhide", "show":
+		handleGroupHide(profile, args[1:], false)
 	case "move", "mv":
 		handleGroupMove(profile, args[1:])
 	case "help", "--help", "-h":
@@ -42,17 +52,27 @@ func printGroupHelp() {
 	fmt.Println("Usage: agent-deck group <command> [options]")
 	fmt.Println()
 	fmt.Println("Commands:")
-	fmt.Println("  list              List all groups with session counts")
-	fmt.Println("  create <name>     Create a new group")
-	fmt.Println("  delete <name>     Delete a group")
-	fmt.Println("  move <id> <group> Move session to a different group")
+	fmt.Println("  list                  List all groups with session counts")
+	fmt.Println("  create <path>         Create a group (parents are created as needed)")
+	fmt.Println("  delete <group>        Delete a group (alias: remove, rm)")
+	fmt.Println("  rename <group> <name> Rename a group (subgroups follow)")
+	fmt.Println("  prune                 Delete groups with no sessions")
+	fmt.Println("  merge <src> <dst>     Move everything from src into dst, remove src")
+	fmt.Println("  hide <group>          Hide a group from the TUI (kept in storage)")
+	fmt.Println("  unhide <group>        Show a hidden group again")
+	fmt.Println("  move <id> <group>     Move session to a different group")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  agent-deck group list")
 	fmt.Println("  agent-deck group create mobile")
 	fmt.Println("  agent-deck group create ios --parent mobile")
+	fmt.Println("  agent-deck group create clients/acme/api")
 	fmt.Println("  agent-deck group delete experiments")
 	fmt.Println("  agent-deck group delete work --force")
+	fmt.Println("  agent-deck group rename clients/acme \"Acme Corp\"")
+	fmt.Println("  agent-deck group prune --dry-run")
+	fmt.Println("  agent-deck group merge old-clients clients")
+	fmt.Println("  agent-deck group hide clients/old-corp")
 	fmt.Println("  agent-deck group move my-project work/frontend")
 	fmt.Println("  agent-deck group move my-project \"\"          # Move to root")
 }
@@ -108,6 +128,7 @@ func handleGroupList(profile string, args []string) {
 			Name         string           `json:"name"`
 			Path         string           `json:"path"`
 			SessionCount int              `json:"session_count"`
+			Hidden       bool             `json:"hidden,omitempty"`
 			Status       *groupStatusJSON `json:"status,omitempty"`
 			Children     []groupJSON      `json:"children,omitempty"`
 		}
@@ -140,6 +161,7 @@ func handleGroupList(profile string, args []string) {
 				Name:         g.Name,
 				Path:         g.Path,
 				SessionCount: sessCount,
+				Hidden:       g.Hidden,
 			}
 			if sessCount > 0 {
 				gj.Status = &status
@@ -271,11 +293,14 @@ func handleGroupList(profile string, args []string) {
 			if idle > 0 {
 				parts = append(parts, fmt.Sprintf("○ %d", idle))
 			}
-			statusStr = strings.Join(parts, " ")
+			statusStr = cliText(strings.Join(parts, " "))
 		}
 
 		name := indent + prefix + g.Name
-		sb.WriteString(fmt.Sprintf("%-20s %-10d %s\n", truncateGroupName(name, 20), sessCount, statusStr))
+		if g.Hidden {
+			statusStr = strings.TrimSpace(statusStr + " (hidden)")
+		}
+		sb.WriteString(fmt.Sprintf("%s %-10d %s\n", tableCell(name, 20), sessCount, statusStr))
 		printedPaths[g.Path] = true
 	}
 
@@ -295,9 +320,9 @@ func handleGroupCreate(profile string, args []string) {
 	quietShort := fs.Bool("q", false, "Minimal output (short)")
 
 	fs.Usage = func() {
-		fmt.Println("Usage: agent-deck group create <name> [options]")
+		fmt.Println("Usage: agent-deck group create <name|path> [options]")
 		fmt.Println()
-		fmt.Println("Create a new group.")
+		fmt.Println("Create a new group. A path like work/frontend creates missing parents.")
 		fmt.Println()
 		fmt.Println("Options:")
 		fs.PrintDefaults()
@@ -305,6 +330,7 @@ func handleGroupCreate(profile string, args []string) {
 		fmt.Println("Examples:")
 		fmt.Println("  agent-deck group create mobile")
 		fmt.Println("  agent-deck group create ios --parent mobile")
+		fmt.Println("  agent-deck group create work/frontend")
 	}
 
 	// Reorder args: move name to end so flags are parsed correctly
@@ -353,6 +379,13 @@ func handleGroupCreate(profile string, args []string) {
 		}
 		newGroup = groupTree.CreateSubgroup(parentPath, name)
 		fullPath = newGroup.Path
+	} else if strings.Contains(name, "/") {
+		newGroup = groupTree.CreateGroupPath(name)
+		if newGroup == nil {
+			out.Error(fmt.Sprintf("invalid group path '%s'", name), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		fullPath = newGroup.Path
 	} else {
 		newGroup = groupTree.CreateGroup(name)
 		fullPath = newGroup.Path
@@ -440,24 +473,12 @@ func handleGroupDelete(profile string, args []string) {
 	groupTree := session.NewGroupTreeWithGroups(instances, groups)
 
 	// Find the group
-	groupPath := normalizeGroupPath(name)
-	group, exists := groupTree.Groups[groupPath]
-	if !exists {
-		// Try finding by name match
-		for path, g := range groupTree.Groups {
-			if strings.EqualFold(g.Name, name) {
-				groupPath = path
-				group = g
-				exists = true
-				break
-			}
-		}
-	}
-
+	groupPath, exists := resolveGroupPath(groupTree, name)
 	if !exists {
 		out.Error(fmt.Sprintf("group '%s' not found", name), ErrCodeNotFound)
 		os.Exit(2)
 	}
+	group := groupTree.Groups[groupPath]
 
 	// Check if group is protected (default group)
 	if groupPath == session.DefaultGroupPath {
@@ -629,6 +650,306 @@ func handleGroupMove(profile string, args []string) {
 	})
 }
 
+// handleGroupRename renames a group, keeping its parent and moving its subgroups
+func handleGroupRename(profile string, args []string) {
+	fs := flag.NewFlagSet("group rename", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck group rename <group> <new-name>")
+		fmt.Println()
+		fmt.Println("Rename a group. It stays under the same parent; subgroups and sessions follow.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck group rename experiments archive")
+		fmt.Println("  agent-deck group rename clients/acme \"Acme Corp\"")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	name, newName := fs.Arg(0), strings.TrimSpace(fs.Arg(1))
+	if name == "" || newName == "" {
+		out.Error("group and new name are required", ErrCodeInvalidOperation)
+		fmt.Println("Usage: agent-deck group rename <group> <new-name>")
+		os.Exit(1)
+	}
+	if strings.Contains(newName, "/") {
+		out.Error("new name cannot contain '/'", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	groupTree := session.NewGroupTreeWithGroups(instances, groups)
+
+	oldPath, exists := resolveGroupPath(groupTree, name)
+	if !exists {
+		out.Error(fmt.Sprintf("group '%s' not found", name), ErrCodeNotFound)
+		os.Exit(2)
+	}
+	if oldPath == session.DefaultGroupPath {
+		out.Error("cannot rename the default group", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	if newPath := session.RenamedGroupPath(oldPath, newName); newPath != oldPath {
+		if _, clash := groupTree.Groups[newPath]; clash {
+			out.Error(fmt.Sprintf("group '%s' already exists", newPath), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	}
+
+	group := groupTree.Groups[oldPath]
+	groupTree.RenameGroup(oldPath, newName)
+
+	if err := storage.SaveWithGroups(groupTree.GetAllInstances(), groupTree); err != nil {
+		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	out.Success(fmt.Sprintf("Renamed group: %s -> %s", oldPath, group.Path), map[string]interface{}{
+		"success":  true,
+		"old_path": oldPath,
+		"path":     group.Path,
+		"name":     group.Name,
+	})
+}
+
+// handleGroupPrune deletes groups that contain no sessions
+func handleGroupPrune(profile string, args []string) {
+	fs := flag.NewFlagSet("group prune", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "List empty groups without deleting them")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck group prune [options]")
+		fmt.Println()
+		fmt.Println("Delete groups with no sessions in them or their subgroups.")
+		fmt.Println("The default group is never removed.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	groupTree := session.NewGroupTreeWithGroups(instances, groups)
+
+	var empty []string
+	if *dryRun {
+		empty = groupTree.EmptyGroups()
+	} else {
+		empty = groupTree.PruneEmptyGroups()
+	}
+	if len(empty) == 0 {
+		out.Success("No empty groups", map[string]interface{}{
+			"success": true,
+			"groups":  []string{},
+		})
+		return
+	}
+
+	if !*dryRun {
+		if err := storage.SaveWithGroups(groupTree.GetAllInstances(), groupTree); err != nil {
+			out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeNotFound)
+			os.Exit(1)
+		}
+	}
+
+	verb := "Deleted"
+	if *dryRun {
+		verb = "Would delete"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %d empty group(s):\n", verb, len(empty))
+	for _, path := range empty {
+		fmt.Fprintf(&sb, "  %s %s\n", bulletSymbol, path)
+	}
+	out.Print(sb.String(), map[string]interface{}{
+		"success": true,
+		"dry_run": *dryRun,
+		"groups":  empty,
+	})
+}
+
+// handleGroupMerge moves all sessions and subgroups of one group into another
+func handleGroupMerge(profile string, args []string) {
+	fs := flag.NewFlagSet("group merge", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck group merge <source> <target>")
+		fmt.Println()
+		fmt.Println("Move every session and subgroup from source into target, then remove source.")
+		fmt.Println("Sessions keep their order after target's own; same-named subgroups are merged.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck group merge old-clients clients")
+		fmt.Println("  agent-deck group merge work/legacy work")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	if fs.NArg() < 2 {
+		out.Error("source and target groups are required", ErrCodeInvalidOperation)
+		fmt.Println("Usage: agent-deck group merge <source> <target>")
+		os.Exit(1)
+	}
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	groupTree := session.NewGroupTreeWithGroups(instances, groups)
+
+	src, ok := resolveGroupPath(groupTree, fs.Arg(0))
+	if !ok {
+		out.Error(fmt.Sprintf("group '%s' not found", fs.Arg(0)), ErrCodeNotFound)
+		os.Exit(2)
+	}
+	dst, ok := resolveGroupPath(groupTree, fs.Arg(1))
+	if !ok {
+		out.Error(fmt.Sprintf("group '%s' not found", fs.Arg(1)), ErrCodeNotFound)
+		os.Exit(2)
+	}
+
+	moved, err := groupTree.MergeGroup(src, dst)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	if err := storage.SaveWithGroups(groupTree.GetAllInstances(), groupTree); err != nil {
+		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	out.Success(fmt.Sprintf("Merged %s into %s (%d sessions moved)", src, dst, moved), map[string]interface{}{
+		"success":        true,
+		"from":           src,
+		"to":             dst,
+		"sessions_moved": moved,
+	})
+}
+
+// handleGroupHide marks a group hidden (or visible again) in the TUI
+func handleGroupHide(profile string, args []string, hidden bool) {
+	verb := "hide"
+	if !hidden {
+		verb = "unhide"
+	}
+	fs := flag.NewFlagSet("group "+verb, flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Printf("Usage: agent-deck group %s <group>\n", verb)
+		fmt.Println()
+		if hidden {
+			fmt.Println("Hide a group, its subgroups and their sessions from the default TUI view.")
+			fmt.Println("Nothing is deleted; press '.' in the TUI to show hidden groups.")
+		} else {
+			fmt.Println("Show a hidden group in the default TUI view again.")
+		}
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	if fs.NArg() < 1 {
+		out.Error("group name is required", ErrCodeInvalidOperation)
+		fmt.Printf("Usage: agent-deck group %s <group>\n", verb)
+		os.Exit(1)
+	}
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	groupTree := session.NewGroupTreeWithGroups(instances, groups)
+
+	groupPath, ok := resolveGroupPath(groupTree, fs.Arg(0))
+	if !ok {
+		out.Error(fmt.Sprintf("group '%s' not found", fs.Arg(0)), ErrCodeNotFound)
+		os.Exit(2)
+	}
+	if hidden && groupPath == session.DefaultGroupPath {
+		out.Error("cannot hide the default group", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	groupTree.SetGroupHidden(groupPath, hidden)
+	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	msg := fmt.Sprintf("Hid group: %s", groupPath)
+	if !hidden {
+		msg = fmt.Sprintf("Unhid group: %s", groupPath)
+	}
+	out.Success(msg, map[string]interface{}{
+		"success": true,
+		"path":    groupPath,
+		"hidden":  hidden,
+	})
+}
+
+// resolveGroupPath finds a group by path or (case-insensitive) name
+func resolveGroupPath(groupTree *session.GroupTree, name string) (string, bool) {
+	groupPath := normalizeGroupPath(name)
+	if _, exists := groupTree.Groups[groupPath]; exists {
+		return groupPath, true
+	}
+	for path, g := range groupTree.Groups {
+		if strings.EqualFold(g.Name, name) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
 // getParentGroupPath returns the parent path of a group path
 func getParentGroupPath(path string) string {
 	if idx := strings.LastIndex(path, "/"); idx != -1 {
@@ -647,17 +968,6 @@ func normalizeGroupPath(name string) string {
 	return strings.ToLower(strings.ReplaceAll(name, " ", "-"))
 }
 
-// truncateGroupName shortens a group name for display
-func truncateGroupName(s string, max int) string {
-	if len(s) <= max {
-		return s
-	}
-	if max <= 3 {
-		return s[:max]
-	}
-	return s[:max-3] + "..."
-}
-
 // reorderGroupArgs reorders arguments so flags come before positional args
 // This fixes Go's flag package limitation where flags after positional args are ignored
 // e.g., "ios --parent mobile" becomes "--parent mobile ios"
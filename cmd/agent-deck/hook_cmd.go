@@ -0,0 +1,217 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleHook dispatches hook subcommands
+func handleHook(args []string) {
+	if len(args) == 0 {
+		printHookUsage()
+		return
+	}
+
+	switch args[0] {
+	case "install":
+		handleHookInstall(args[1:])
+	case "uninstall", "remove":
+		handleHookUninstall(args[1:])
+	case "status":
+		handleHookStatus(args[1:])
+	case "help", "-h", "--help":
+		printHookUsage()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown hook command: %s\n", args[0])
+		printHookUsage()
+		os.Exit(1)
+	}
+}
+
+// printHookUsage prints help for hook commands
+func printHookUsage() {
+	fmt.Println("Usage: agent-deck hook <command> [options]")
+	fmt.Println()
+	fmt.Println("Manage Claude Code hooks that report session state to agent-deck.")
+	fmt.Println("Installed hooks run 'agent-deck notify --hook' on Stop, Notification and")
+	fmt.Println("PreToolUse, so the deck sees state changes without screen-scraping.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  install      Add agent-deck hooks to Claude Code settings")
+	fmt.Println("  uninstall    Remove agent-deck hooks (other hooks are kept)")
+	fmt.Println("  status       Show which hook events are installed")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --settings <path>      Claude settings file (default: <claude config dir>/settings.json)")
+	fmt.Println("  --json                 Output as JSON")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  agent-deck hook install")
+	fmt.Println("  agent-deck hook install --dry-run")
+	fmt.Println("  agent-deck hook status")
+	fmt.Println("  agent-deck hook uninstall")
+}
+
+// hookExecutable returns how hooks should invoke agent-deck: the bare name when it
+// is on PATH (survives upgrades), otherwise the absolute path of this binary
+func hookExecutable() string {
+	if _, err := exec.LookPath("agent-deck"); err == nil {
+		return "agent-deck"
+	}
+	if exe, err := os.Executable(); err == nil {
+		return exe
+	}
+	return "agent-deck"
+}
+
+// handleHookInstall writes agent-deck hooks into Claude Code settings
+func handleHookInstall(args []string) {
+	fs := flag.NewFlagSet("hook install", flag.ExitOnError)
+	settingsPath := fs.String("settings", session.ClaudeSettingsPath(), "Claude settings file to modify")
+	dryRun := fs.Bool("dry-run", false, "Show what would be installed without writing")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck hook install [options]")
+		fmt.Println()
+		fmt.Println("Add Stop, Notification and PreToolUse hooks to Claude Code settings.")
+		fmt.Println("Existing agent-deck hooks are replaced; other hooks and settings are kept.")
+		fmt.Println("A backup of the previous file is written to <settings>.bak.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	command := session.ClaudeHookCommand(hookExecutable())
+
+	if *dryRun {
+		out.Print(fmt.Sprintf("Would install into %s:\n  events:  %s\n  command: %s\n",
+			*settingsPath, strings.Join(session.ClaudeHookEvents, ", "), command), map[string]interface{}{
+			"success":  true,
+			"dry_run":  true,
+			"settings": *settingsPath,
+			"events":   session.ClaudeHookEvents,
+			"command":  command,
+		})
+		return
+	}
+
+	changed, err := session.InstallClaudeHooks(*settingsPath, command)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to install hooks: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	msg := fmt.Sprintf("Installed Claude Code hooks in %s", *settingsPath)
+	if !changed {
+		msg = fmt.Sprintf("Claude Code hooks already installed in %s", *settingsPath)
+	}
+	out.Success(msg, map[string]interface{}{
+		"success":  true,
+		"changed":  changed,
+		"settings": *settingsPath,
+		"events":   session.ClaudeHookEvents,
+		"command":  command,
+	})
+	if changed && !*jsonOutput && !*quiet && !*quietShort {
+		fmt.Println("  Restart running Claude sessions for the hooks to take effect.")
+	}
+}
+
+// handleHookUninstall removes agent-deck hooks from Claude Code settings
+func handleHookUninstall(args []string) {
+	fs := flag.NewFlagSet("hook uninstall", flag.ExitOnError)
+	settingsPath := fs.String("settings", session.ClaudeSettingsPath(), "Claude settings file to modify")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck hook uninstall [options]")
+		fmt.Println()
+		fmt.Println("Remove agent-deck hooks from Claude Code settings. Other hooks are kept.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	removed, err := session.UninstallClaudeHooks(*settingsPath)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to uninstall hooks: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	msg := fmt.Sprintf("Removed Claude Code hooks from %s", *settingsPath)
+	if !removed {
+		msg = fmt.Sprintf("No agent-deck hooks found in %s", *settingsPath)
+	}
+	out.Success(msg, map[string]interface{}{
+		"success":  true,
+		"changed":  removed,
+		"settings": *settingsPath,
+	})
+}
+
+// handleHookStatus shows which hook events are installed
+func handleHookStatus(args []string) {
+	fs := flag.NewFlagSet("hook status", flag.ExitOnError)
+	settingsPath := fs.String("settings", session.ClaudeSettingsPath(), "Claude settings file to inspect")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck hook status [options]")
+		fmt.Println()
+		fmt.Println("Show which Claude Code hook events report to agent-deck.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, false)
+
+	installed, err := session.ClaudeHooksInstalled(*settingsPath)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Claude Code hooks (%s):\n", *settingsPath)
+	for _, event := range session.ClaudeHookEvents {
+		state := "missing"
+		if installed[event] {
+			state = "installed"
+		}
+		fmt.Fprintf(&b, "  %-14s %s\n", event, state)
+	}
+	if len(installed) < len(session.ClaudeHookEvents) {
+		b.WriteString("\nRun 'agent-deck hook install' to add missing hooks.\n")
+	}
+
+	out.Print(b.String(), map[string]interface{}{
+		"settings": *settingsPath,
+		"events":   installed,
+	})
+}
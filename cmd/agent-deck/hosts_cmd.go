@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleHosts lists the remote hosts usable with --container ssh:<host>
+func handleHosts(args []string) {
+	fs := flag.NewFlagSet("hosts", flag.ExitOnError)
+	names := fs.Bool("names", false, "Print host names only, one per line (for shell completion)")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck hosts [options]")
+		fmt.Println()
+		fmt.Println("List remote hosts for 'add --container ssh:<host>': hosts declared under")
+		fmt.Println("[hosts.<name>] in config.toml, then Host aliases from ~/.ssh/config.")
+		fmt.Println("ssh_config aliases keep their ProxyJump, Port and IdentityFile settings.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck hosts")
+		fmt.Println("  agent-deck add -c claude --container ssh:gpu --container-workdir /srv/app .")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	hosts := session.ListRemoteHosts()
+	if *names {
+		for _, h := range hosts {
+			fmt.Println(h.Name)
+		}
+		return
+	}
+
+	out := NewCLIOutput(*jsonOutput, false)
+	var sb strings.Builder
+	if len(hosts) == 0 {
+		sb.WriteString("No remote hosts. Add [hosts.<name>] to config.toml or Host entries to ~/.ssh/config.\n")
+	} else {
+		sb.WriteString("Remote hosts:\n")
+	}
+	for _, h := range hosts {
+		target := h.HostName
+		if target == "" {
+			target = h.Name
+		}
+		if h.User != "" {
+			target = h.User + "@" + target
+		}
+		if h.Port != 0 {
+			target = fmt.Sprintf("%s:%d", target, h.Port)
+		}
+		if h.ProxyJump != "" {
+			target += " via " + h.ProxyJump
+		}
+		if h.Mosh {
+			target += " [mosh]"
+		}
+		fmt.Fprintf(&sb, "  %-16s %-40s (%s) %s\n", h.Name, target, h.Source, h.Description)
+	}
+	if hosts == nil {
+		hosts = []session.RemoteHost{}
+	}
+	out.Print(sb.String(), map[string]interface{}{"hosts": hosts})
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleExport writes the profile's sessions and groups (or one group's) as
+// JSON for a teammate or another machine to import
+func handleExport(profile string, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	output := fs.String("output", "", "Write to this file instead of stdout")
+	outputShort := fs.String("o", "", "Write to this file (short)")
+	group := fs.String("group", "", "Only export this group and its subgroups")
+	includeEnv := fs.Bool("include-env", false, "Include the sessions' env vars (left out by default: they often hold API keys)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck export [options]")
+		fmt.Println()
+		fmt.Println("Write the profile's sessions and groups as JSON, to share a deck with a")
+		fmt.Println("teammate or move it to another machine ('agent-deck import' reads it).")
+		fmt.Println("Live state (status, last access, tmux sessions) is left out, and so are")
+		fmt.Println("sessions' env vars unless --include-env is given.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck export -o deck.json")
+		fmt.Println("  agent-deck export --group team/backend > backend.json")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	outPath := *output
+	if outPath == "" {
+		outPath = *outputShort
+	}
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to open storage: %v\n", err)
+		os.Exit(1)
+	}
+	defer storage.Close()
+	data, err := storage.LoadStorageData()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if prefix := strings.Trim(*group, "/"); prefix != "" {
+		inGroup := func(path string) bool { return path == prefix || strings.HasPrefix(path, prefix+"/") }
+		filtered := &session.StorageData{UpdatedAt: data.UpdatedAt}
+		for _, inst := range data.Instances {
+			if inGroup(inst.GroupPath) {
+				filtered.Instances = append(filtered.Instances, inst)
+			}
+		}
+		for _, g := range data.Groups {
+			if inGroup(g.Path) {
+				filtered.Groups = append(filtered.Groups, g)
+			}
+		}
+		if len(filtered.Instances) == 0 && len(filtered.Groups) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: group '%s' not found\n", prefix)
+			os.Exit(2)
+		}
+		data = filtered
+	}
+
+	encoded, err := session.ExportStorageData(data, *includeEnv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if outPath == "" {
+		_, _ = os.Stdout.Write(encoded)
+		return
+	}
+	if err := os.WriteFile(outPath, encoded, 0o600); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s Exported %d sessions to %s\n", successSymbol, len(data.Instances), FormatPath(outPath))
+}
+
+// handleImport adds the sessions of an exported deck to the profile,
+// remapping their paths and hosts to this machine
+func handleImport(profile string, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	remap := addRemapFlags(fs)
+	group := fs.String("group", "", "Nest the imported groups under this group")
+	yes := fs.Bool("yes", false, "Don't ask: keep unmapped paths and hosts, or use the same path under your home when it exists")
+	yesShort := fs.Bool("y", false, "Don't ask (short)")
+	dryRun := fs.Bool("dry-run", false, "Show what would be imported without saving")
+	withHooks := fs.Bool("with-hooks", false, "Keep the sessions' hooks without asking (they run shell commands here)")
+	jsonOutput := fs.Bool("json", false, "Output as JSON (implies --yes)")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck import <file> [options]")
+		fmt.Println()
+		fmt.Println("Add the sessions and groups of a deck written by 'agent-deck export'.")
+		fmt.Println("Directories and ssh hosts that don't exist here are remapped: by --map and")
+		fmt.Println("--map-host, else by asking (with the same path under your home as the")
+		fmt.Println("default). Sessions already imported are skipped; conversations aren't")
+		fmt.Println("carried over, so imported agents start fresh. The commands and hooks the")
+		fmt.Println("sessions run are shown first; hooks are dropped unless you keep them or")
+		fmt.Println("pass --with-hooks.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck import alice.json")
+		fmt.Println("  agent-deck import alice.json --map /home/alice/code=~/src --map-host gpu=gpu2 --group alice -y")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	if fs.NArg() != 1 {
+		out.Error("usage: agent-deck import <file>", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	raw, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(2)
+	}
+	data, err := session.ParseExport(raw)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	interactive := !*yes && !*yesShort && !*jsonOutput && term.IsTerminal(int(os.Stdin.Fd()))
+	askImportRemap(data, remap, interactive)
+	remapped := session.ApplyImportRemap(data, remap)
+	hooksDropped := reviewImportCommands(data, *withHooks, interactive && !*dryRun, !*jsonOutput && !*quiet && !*quietShort)
+
+	if *dryRun {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Would import %d sessions:\n", len(data.Instances))
+		for _, inst := range data.Instances {
+			fmt.Fprintf(&sb, "  %-24s %s\n", inst.Title, FormatPath(inst.ProjectPath))
+		}
+		out.Print(sb.String(), data)
+		return
+	}
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to open storage: %v", err), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	defer storage.Close()
+	result, err := storage.ImportStorageData(data, *group)
+	if err != nil {
+		out.Error(fmt.Sprintf("import failed: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	result.Remapped = remapped
+	result.HooksDropped = hooksDropped
+
+	msg := fmt.Sprintf("Imported %d sessions and %d groups into profile '%s'", result.Added, result.AddedGroups, storage.Profile())
+	if result.Skipped > 0 {
+		msg += fmt.Sprintf(" (%d already there)", result.Skipped)
+	}
+	out.Success(msg, map[string]interface{}{
+		"success": true,
+		"import":  result,
+	})
+}
+
+// addRemapFlags adds --map and --map-host to fs, filling the returned remap
+func addRemapFlags(fs *flag.FlagSet) session.ImportRemap {
+	remap := session.ImportRemap{Paths: map[string]string{}, Hosts: map[string]string{}}
+	fs.Func("map", "Remap a directory, THEIRS=MINE, e.g. /home/alice/code=~/src (can specify multiple times)", func(s string) error {
+		from, to, ok := strings.Cut(s, "=")
+		if !ok || from == "" || to == "" {
+			return fmt.Errorf("want THEIRS=MINE, got %q", s)
+		}
+		remap.MapPath(from, to)
+		return nil
+	})
+	fs.Func("map-host", "Remap an ssh host, THEIRS=MINE (can specify multiple times)", func(s string) error {
+		from, to, ok := strings.Cut(s, "=")
+		if !ok || from == "" || to == "" {
+			return fmt.Errorf("want THEIRS=MINE, got %q", s)
+		}
+		remap.Hosts[from] = to
+		return nil
+	})
+	return remap
+}
+
+// reviewImportCommands shows (when show) the commands and hooks the sessions
+// would run, then drops the hooks unless withHooks is set or the user keeps
+// them when asked. Returns how many hooks were dropped.
+func reviewImportCommands(data *session.StorageData, withHooks, interactive, show bool) int {
+	cmds := session.ImportCommands(data)
+	hooks := 0
+	for _, c := range cmds {
+		if c.Hook {
+			hooks++
+		}
+	}
+	if show && len(cmds) > 0 {
+		fmt.Println("The sessions run these commands:")
+		for _, c := range cmds {
+			fmt.Printf("  %-24s %-16s %s\n", c.Title, c.Kind, c.Command)
+		}
+	}
+	if hooks == 0 || withHooks {
+		return 0
+	}
+	if interactive {
+		fmt.Printf("Keep the %d hook(s)? They run unattended on this machine [y/N]: ", hooks)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer == "y" || answer == "yes" {
+			return 0
+		}
+	}
+	if show {
+		fmt.Printf("Dropping %d hook(s); pass --with-hooks to keep them\n", hooks)
+	}
+	return session.DropImportHooks(data)
+}
+
+// askImportRemap fills in remap for directories and hosts that don't exist
+// here: asking for each when interactive, else taking the suggestion
+func askImportRemap(data *session.StorageData, remap session.ImportRemap, interactive bool) {
+	reader := bufio.NewReader(os.Stdin)
+	ask := func(prompt, def string) string {
+		fmt.Printf("%s [%s]: ", prompt, def)
+		answer, _ := reader.ReadString('\n')
+		if answer = strings.TrimSpace(answer); answer == "" {
+			return def
+		}
+		return answer
+	}
+
+	roots := session.MissingPathRoots(data, remap)
+	if interactive && len(roots) > 0 {
+		fmt.Println("Some projects are in directories that don't exist here. Enter where they")
+		fmt.Println("are on this machine, or press Enter for the default.")
+	}
+	for _, root := range roots {
+		def := root.Path
+		if root.Suggested != "" {
+			def = FormatPath(root.Suggested)
+		}
+		to := def
+		if interactive {
+			to = ask(fmt.Sprintf("  %s (%d sessions)", root.Path, root.Sessions), def)
+		}
+		remap.MapPath(root.Path, to)
+	}
+
+	if !interactive {
+		return
+	}
+	for _, host := range session.UnknownImportHosts(data, remap) {
+		if to := ask(fmt.Sprintf("  ssh host %q isn't configured here; use host", host), host); to != host {
+			remap.Hosts[host] = to
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// primaryTimeout matches the heartbeat window used for primary election
+const primaryTimeout = 30 * time.Second
+
+// guardSingleInstance enforces [instances] allow_multiple = false. When
+// another TUI is primary for the profile, it applies on_conflict (or the
+// --takeover flag): prompt, exit, continue read-only, or take over. Returns
+// whether this instance should run read-only.
+func guardSingleInstance(db *statedb.StateDB, settings session.InstanceSettings, takeover bool) bool {
+	isFirst, err := db.ElectPrimary(primaryTimeout)
+	if err != nil || isFirst {
+		return false
+	}
+	otherPID, _ := db.PrimaryPID(primaryTimeout)
+
+	action := settings.GetOnConflict()
+	if takeover {
+		action = "takeover"
+	}
+	if action == "ask" {
+		action = promptInstanceConflict(otherPID)
+	}
+
+	switch action {
+	case "read_only":
+		return true
+	case "takeover":
+		if err := takeOverInstance(db, otherPID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return false
+	}
+	fmt.Printf("Error: agent-deck is already running for this profile (pid %d)\n", otherPID)
+	fmt.Println("Use --read-only to browse, --takeover to close it, or set")
+	fmt.Println("[instances] allow_multiple = true in config.toml to allow multiple instances")
+	os.Exit(1)
+	return false
+}
+
+// promptInstanceConflict asks what to do about the running instance.
+// Without a terminal to ask on, it exits.
+func promptInstanceConflict(otherPID int) string {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "exit"
+	}
+	fmt.Printf("agent-deck is already running for this profile (pid %d).\n", otherPID)
+	fmt.Print("[r]ead-only, [t]ake over, or [q]uit? [q]: ")
+	drainStdin()
+	var response string
+	_, _ = fmt.Scanln(&response)
+	switch strings.ToLower(strings.TrimSpace(response)) {
+	case "r", "read-only", "readonly":
+		return "read_only"
+	case "t", "takeover", "take":
+		return "takeover"
+	}
+	return "exit"
+}
+
+// takeOverInstance asks the primary instance to quit (SIGUSR2, handled by
+// saving and exiting), falling back to SIGTERM, then claims primary.
+func takeOverInstance(db *statedb.StateDB, otherPID int) error {
+	if otherPID > 0 {
+		if proc, err := os.FindProcess(otherPID); err == nil {
+			_ = proc.Signal(syscall.SIGUSR2)
+			if !waitForPrimaryExit(db, otherPID, 10*time.Second) {
+				_ = proc.Signal(syscall.SIGTERM)
+				waitForPrimaryExit(db, otherPID, 3*time.Second)
+			}
+		}
+	}
+	// A process that died without unregistering leaves is_primary set until
+	// its heartbeat goes stale; drop its row to claim primary now
+	if otherPID > 0 {
+		if err := syscall.Kill(otherPID, 0); err == syscall.ESRCH {
+			_ = db.UnregisterPID(otherPID)
+		}
+	}
+	isFirst, err := db.ElectPrimary(primaryTimeout)
+	if err != nil {
+		return fmt.Errorf("take over: %w", err)
+	}
+	if !isFirst {
+		return fmt.Errorf("instance %d did not exit; close it manually", otherPID)
+	}
+	fmt.Printf("Took over from pid %d\n", otherPID)
+	return nil
+}
+
+// waitForPrimaryExit polls until pid is no longer primary
+func waitForPrimaryExit(db *statedb.StateDB, pid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if current, err := db.PrimaryPID(primaryTimeout); err == nil && current != pid {
+			return true
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return false
+}
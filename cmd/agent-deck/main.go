@@ -22,9 +22,11 @@ import (
 	"github.com/muesli/termenv"
 
 	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/github"
 	"github.com/asheshgoplani/agent-deck/internal/logging"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	"github.com/asheshgoplani/agent-deck/internal/statedb"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
 	"github.com/asheshgoplani/agent-deck/internal/ui"
 	"github.com/asheshgoplani/agent-deck/internal/update"
 )
@@ -65,8 +67,8 @@ func printUpdateNotice() {
 	}
 
 	// Print update notice to stderr so it doesn't interfere with JSON output
-	fmt.Fprintf(os.Stderr, "\n💡 Update available: v%s → v%s (run: agent-deck update)\n",
-		info.CurrentVersion, info.LatestVersion)
+	fmt.Fprint(os.Stderr, cliText(fmt.Sprintf("\n💡 Update available: v%s → v%s (run: agent-deck update)\n",
+		info.CurrentVersion, info.LatestVersion)))
 }
 
 // promptForUpdate checks for updates and prompts user if auto_update is enabled
@@ -83,13 +85,13 @@ func promptForUpdate() bool {
 
 	// If auto_update is disabled, just show notification (don't prompt)
 	if !settings.AutoUpdate {
-		fmt.Fprintf(os.Stderr, "\n💡 Update available: v%s → v%s (run: agent-deck update)\n",
-			info.CurrentVersion, info.LatestVersion)
+		fmt.Fprint(os.Stderr, cliText(fmt.Sprintf("\n💡 Update available: v%s → v%s (run: agent-deck update)\n",
+			info.CurrentVersion, info.LatestVersion)))
 		return false
 	}
 
 	// auto_update is enabled - prompt user
-	fmt.Printf("\n⬆ Update available: v%s → v%s\n", info.CurrentVersion, info.LatestVersion)
+	fmt.Print(cliText(fmt.Sprintf("\n⬆ Update available: v%s → v%s\n", info.CurrentVersion, info.LatestVersion)))
 	fmt.Print("Update now? [Y/n]: ")
 
 	var response string
@@ -134,6 +136,12 @@ func initColorProfile() {
 		}
 	}
 
+	// NO_COLOR (https://no-color.org): any non-empty value disables color
+	if os.Getenv("NO_COLOR") != "" {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return
+	}
+
 	// Auto-detect with TrueColor preference
 	// Most modern terminals support TrueColor even if not advertised
 
@@ -184,6 +192,16 @@ func main() {
 	// Extract global -p/--profile flag before subcommand dispatch
 	profile, args := extractProfileFlag(os.Args[1:])
 
+	// --plain: ASCII-only output without ANSI styling, for the TUI and CLI
+	plain, args := extractPlainFlag(args)
+	if plain || session.GetAccessibilitySettings().Plain {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		ui.SetPlainMode(true)
+	}
+
+	// [tmux] socket_name picks the tmux server this profile's sessions use
+	tmux.SetSocketName(session.GetTmuxSettings().GetSocketName(session.GetEffectiveProfile(profile)))
+
 	// Handle subcommands
 	if len(args) > 0 {
 		switch args[0] {
@@ -196,6 +214,15 @@ func main() {
 		case "add":
 			handleAdd(profile, args[1:])
 			return
+		case "new":
+			handleNew(profile, args[1:])
+			return
+		case "hosts":
+			handleHosts(args[1:])
+			return
+		case "sync":
+			handleSync(profile, args[1:])
+			return
 		case "list", "ls":
 			handleList(profile, args[1:])
 			return
@@ -214,6 +241,66 @@ func main() {
 		case "session":
 			handleSession(profile, args[1:])
 			return
+		case "share":
+			handleShare(profile, args[1:])
+			return
+		case "dump":
+			handleDump(profile, args[1:])
+			return
+		case "snapshot":
+			handleSnapshot(profile, args[1:])
+			return
+		case "backup":
+			handleBackup(args[1:])
+			return
+		case "export":
+			handleExport(profile, args[1:])
+			return
+		case "import":
+			handleImport(profile, args[1:])
+			return
+		case "team":
+			handleTeam(profile, args[1:])
+			return
+		case "tree":
+			handleTree(profile, args[1:])
+			return
+		case "attach":
+			handleAttach(profile, args[1:])
+			return
+		case "edit":
+			handleEdit(profile, args[1:])
+			return
+		case "doctor":
+			handleDoctor(profile, args[1:])
+			return
+		case "run":
+			handleRun(profile, args[1:])
+			return
+		case "tail":
+			handleTail(profile, args[1:])
+			return
+		case "report":
+			handleReport(profile, args[1:])
+			return
+		case "stats":
+			handleStats(profile, args[1:])
+			return
+		case "daemon":
+			handleDaemon(profile, args[1:])
+			return
+		case "serve":
+			handleServe(profile, args[1:])
+			return
+		case "watch":
+			handleWatch(args[1:])
+			return
+		case "hook", "hooks":
+			handleHook(args[1:])
+			return
+		case "notify":
+			handleNotify(profile, args[1:])
+			return
 		case "mcp":
 			handleMCP(profile, args[1:])
 			return
@@ -242,9 +329,25 @@ func main() {
 		}
 	}
 
+	opts := extractTUIFlags(args)
+	readOnly := opts.readOnly
+
+	// --demo runs the TUI on synthetic sessions in a throwaway HOME
+	demo := opts.demo
+	demoCleanup := func() {}
+	if demo {
+		var err error
+		if demoCleanup, err = setupDemo(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer demoCleanup()
+		profile = session.DefaultProfile
+	}
+
 	// Block TUI launch inside a managed session to prevent infinite nesting.
 	// CLI commands (add, session start/stop, mcp attach, etc.) still work fine.
-	if isNestedSession() {
+	if !demo && isNestedSession() {
 		fmt.Fprintln(os.Stderr, "Error: Cannot launch the agent-deck TUI inside an agent-deck session.")
 		fmt.Fprintln(os.Stderr, "This would create a recursive nested session.")
 		fmt.Fprintln(os.Stderr, "")
@@ -266,13 +369,13 @@ func main() {
 	ui.InitTheme(theme)
 
 	// Check for updates and prompt user before launching TUI
-	if promptForUpdate() {
+	if !demo && promptForUpdate() {
 		// Update was performed, exit so user can restart with new version
 		return
 	}
 
 	// Check if tmux is available
-	if _, err := exec.LookPath("tmux"); err != nil {
+	if _, err := exec.LookPath("tmux"); err != nil && !demo {
 		fmt.Println("Error: tmux not found in PATH")
 		fmt.Println("\nAgent Deck requires tmux. Install with:")
 		fmt.Println("  brew install tmux")
@@ -290,16 +393,13 @@ func main() {
 
 	// Check if multiple instances are allowed (uses primary election as single-instance gate)
 	instanceSettings := session.GetInstanceSettings()
-	if !instanceSettings.GetAllowMultiple() {
+	if (!instanceSettings.GetAllowMultiple() || opts.takeover) && !readOnly {
 		if db := statedb.GetGlobal(); db != nil {
-			isFirst, electErr := db.ElectPrimary(30 * time.Second)
-			if electErr == nil && !isFirst {
-				fmt.Println("Error: agent-deck is already running for this profile")
-				fmt.Println("Set [instances] allow_multiple = true in config.toml to allow multiple instances")
-				os.Exit(1)
-			}
+			readOnly = guardSingleInstance(db, instanceSettings, opts.takeover)
 		}
 	}
+	session.SetReadOnly(readOnly)
+	session.SetActivityTracking(!readOnly)
 
 	// Set up signal handling for graceful shutdown and crash dumps
 	sigChan := make(chan os.Signal, 1)
@@ -310,6 +410,7 @@ func main() {
 			_ = db.ResignPrimary()
 			_ = db.UnregisterInstance()
 		}
+		demoCleanup()
 		os.Exit(0)
 	}()
 
@@ -388,13 +489,27 @@ func main() {
 		}()
 	}
 
+	// Screen reader mode stays out of the alternate screen so announcements
+	// remain in the terminal's scrollback
+	screenReader := opts.screenReader || session.GetAccessibilitySettings().ScreenReader
+	ui.SetScreenReaderMode(screenReader)
+	programOpts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	if screenReader {
+		programOpts = nil
+	}
+
 	// Start TUI with the specified profile
 	homeModel := ui.NewHomeWithProfileAndMode(profile)
-	p := tea.NewProgram(
-		homeModel,
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
+	p := tea.NewProgram(homeModel, programOpts...)
+
+	// SIGUSR2 is sent by an instance started with --takeover
+	usr2Chan := make(chan os.Signal, 1)
+	signal.Notify(usr2Chan, syscall.SIGUSR2)
+	go func() {
+		for range usr2Chan {
+			p.Send(ui.TakeoverMsg{})
+		}
+	}()
 
 	// Start maintenance worker (background goroutine, respects config toggle)
 	maintenanceCtx, maintenanceCancel := context.WithCancel(context.Background())
@@ -402,6 +517,7 @@ func main() {
 	session.StartMaintenanceWorker(maintenanceCtx, func(result session.MaintenanceResult) {
 		p.Send(ui.MaintenanceCompleteMsg{Result: result})
 	})
+	session.StartBackupWorker(maintenanceCtx)
 
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v\n", err)
@@ -409,6 +525,47 @@ func main() {
 	}
 }
 
+// tuiFlags are the options that only apply when starting the TUI
+type tuiFlags struct {
+	readOnly     bool
+	takeover     bool
+	demo         bool
+	screenReader bool
+}
+
+// extractTUIFlags reads TUI-only flags from args (left in place: args that
+// reach here start the TUI, which ignores them)
+func extractTUIFlags(args []string) tuiFlags {
+	var f tuiFlags
+	for _, arg := range args {
+		switch arg {
+		case "--read-only":
+			f.readOnly = true
+		case "--takeover":
+			f.takeover = true
+		case "--demo":
+			f.demo = true
+		case "--screen-reader":
+			f.screenReader = true
+		}
+	}
+	return f
+}
+
+// extractPlainFlag removes the global --plain flag from args
+func extractPlainFlag(args []string) (bool, []string) {
+	plain := false
+	remaining := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "--plain" {
+			plain = true
+			continue
+		}
+		remaining = append(remaining, arg)
+	}
+	return plain, remaining
+}
+
 // extractProfileFlag extracts -p or --profile from args, returning the profile and remaining args
 func extractProfileFlag(args []string) (string, []string) {
 	var profile string
@@ -463,6 +620,10 @@ func reorderArgsForFlagParsing(args []string) []string {
 		"-w":    true, "--worktree": true,
 		"--location":       true,
 		"--resume-session": true,
+		"--container":      true, "--container-workdir": true,
+		"--k8s-context": true, "--k8s-container": true,
+		"--clone": true, "--issue": true, "--ticket": true,
+		"--tmux-socket": true, "--template": true, "--env": true,
 	}
 
 	var flags []string
@@ -493,22 +654,10 @@ func reorderArgsForFlagParsing(args []string) []string {
 
 // isDuplicateSession checks if a session with the same title AND path already exists.
 // Returns (isDuplicate, existingInstance)
-// Paths are normalized by removing trailing slashes for comparison.
+// Paths are compared canonically (trailing slashes, ~, symlinks, case on macOS).
 func isDuplicateSession(instances []*session.Instance, title, path string) (bool, *session.Instance) {
-	// Normalize path by removing trailing slash (except for root "/")
-	normalizedPath := strings.TrimSuffix(path, "/")
-	if normalizedPath == "" {
-		normalizedPath = "/"
-	}
-
 	for _, inst := range instances {
-		// Normalize existing path for comparison
-		existingPath := strings.TrimSuffix(inst.ProjectPath, "/")
-		if existingPath == "" {
-			existingPath = "/"
-		}
-
-		if existingPath == normalizedPath && inst.Title == title {
+		if inst.Title == title && session.SamePath(inst.ProjectPath, path) {
 			return true, inst
 		}
 	}
@@ -521,7 +670,7 @@ func generateUniqueTitle(instances []*session.Instance, baseTitle, path string)
 	// Check if base title is available at this path
 	titleExists := func(title string) bool {
 		for _, inst := range instances {
-			if inst.ProjectPath == path && inst.Title == title {
+			if inst.Title == title && session.SamePath(inst.ProjectPath, path) {
 				return true
 			}
 		}
@@ -544,16 +693,28 @@ func generateUniqueTitle(instances []*session.Instance, baseTitle, path string)
 	return fmt.Sprintf("%s (%d)", baseTitle, time.Now().Unix())
 }
 
+// confirmYoloSandbox asks whether to run a new YOLO session in the sandbox
+func confirmYoloSandbox(spec *session.ContainerSpec) bool {
+	fmt.Printf("This session skips approval prompts. Run it in a container (%s) with only the project mounted? [Y/n] ", spec)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "" || answer == "y" || answer == "yes"
+}
+
 // handleAdd adds a new session from CLI
 func handleAdd(profile string, args []string) {
 	fs := flag.NewFlagSet("add", flag.ExitOnError)
-	title := fs.String("title", "", "Session title (defaults to folder name)")
+	title := fs.String("title", "", "Session title (defaults to folder name; {repo}, {owner}, {repo-name}, {dir} are filled in)")
 	titleShort := fs.String("t", "", "Session title (short)")
-	group := fs.String("group", "", "Group path (defaults to parent folder)")
+	group := fs.String("group", "", "Group path (defaults to the first matching [[group_rules]] entry, else the parent folder)")
 	groupShort := fs.String("g", "", "Group path (short)")
 	command := fs.String("cmd", "", "Command to run (e.g., 'claude', 'opencode')")
 	commandShort := fs.String("c", "", "Command to run (short)")
 	wrapper := fs.String("wrapper", "", "Wrapper command (use {command} to include tool command, e.g., 'nvim +\"terminal {command}\"')")
+	container := fs.String("container", "", "Run inside a container: image:<image>, docker:<container>, compose:<service>, devcontainer, k8s:[<ns>/]<pod>, ssh:<host>")
+	containerWorkdir := fs.String("container-workdir", "", "Working directory inside the container")
+	k8sContext := fs.String("k8s-context", "", "kubeconfig context for --container k8s:<pod>")
+	k8sContainer := fs.String("k8s-container", "", "Container within the pod for --container k8s:<pod>")
 	parent := fs.String("parent", "", "Parent session (creates sub-session, inherits group)")
 	parentShort := fs.String("p", "", "Parent session (short)")
 	quickCreate := fs.Bool("quick", false, "Auto-generate session name (adjective-noun)")
@@ -561,6 +722,16 @@ func handleAdd(profile string, args []string) {
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 	quiet := fs.Bool("quiet", false, "Minimal output")
 	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	clone := fs.String("clone", "", "Git URL to clone into [path]/<repo> (or into [path] if it doesn't exist) before adding")
+	start := fs.Bool("start", false, "Start the session after adding it (uses default_tool when -c is not given)")
+	issueFlag := fs.String("issue", "", "GitHub issue to work on (owner/repo#123, #123 or URL): kept in notes and sent as the first prompt")
+	ticketFlag := fs.String("ticket", "", "Linear/Jira ticket ID or URL to link (title and status are fetched)")
+	tmuxSocket := fs.String("tmux-socket", "", "Run the session on this tmux server (tmux -L) instead of [tmux] socket_name")
+	yolo := fs.Bool("yolo", false, "Launch the agent with approvals skipped (claude --dangerously-skip-permissions, codex/gemini --yolo, a custom tool's dangerous_flag)")
+	safe := fs.Bool("safe", false, "Launch the agent with approvals, even if the tool defaults to dangerous or YOLO mode")
+	sandbox := fs.Bool("sandbox", false, "Run a YOLO session in the [yolo_sandbox] image with the project mounted")
+	noSandbox := fs.Bool("no-sandbox", false, "Run a YOLO session on the host, even with [yolo_sandbox] mode = \"always\"")
+	templateName := fs.String("template", "", "Create from a [templates.<name>] entry in config.toml (command, tool, group, env, tmux panes)")
 
 	// Worktree flags
 	worktreeBranch := fs.String("w", "", "Create session in git worktree for branch")
@@ -576,6 +747,17 @@ func handleAdd(profile string, args []string) {
 		return nil
 	})
 
+	// Env flag - can be specified multiple times
+	sessionEnv := map[string]string{}
+	fs.Func("env", "Env var for the session, KEY=VALUE (can specify multiple times)", func(s string) error {
+		key, value, err := session.ParseEnvAssignment(s)
+		if err != nil {
+			return err
+		}
+		sessionEnv[key] = value
+		return nil
+	})
+
 	// Resume session flag
 	resumeSession := fs.String("resume-session", "", "Claude session ID to resume (skips new session creation)")
 
@@ -594,12 +776,25 @@ func handleAdd(profile string, args []string) {
 		fmt.Println("  agent-deck add                       # Use current directory")
 		fmt.Println("  agent-deck add /path/to/project")
 		fmt.Println("  agent-deck add -t \"My Project\" -g \"work\"")
+		fmt.Println("  agent-deck add -t \"{repo-name}\" -g \"{owner}\" .  # Title and group from the git remote")
 		fmt.Println("  agent-deck add -c claude .")
 		fmt.Println("  agent-deck -p work add               # Add to 'work' profile")
 		fmt.Println("  agent-deck add -t \"Sub-task\" --parent \"Main Project\"  # Create sub-session")
 		fmt.Println("  agent-deck add -t \"Research\" -c claude --mcp memory --mcp sequential-thinking /tmp/x")
 		fmt.Println("  agent-deck add -c opencode --wrapper \"nvim +'terminal {command}' +'startinsert'\" .")
 		fmt.Println("  agent-deck add --quick -c claude .   # Auto-generated name")
+		fmt.Println("  agent-deck add -c claude --container compose:app .   # Agent inside the compose 'app' service")
+		fmt.Println("  agent-deck add -c claude --container image:node:22 . # Fresh container, project mounted")
+		fmt.Println("  agent-deck add -c claude --container k8s:staging/api-7d9f --k8s-context prod .")
+		fmt.Println("  agent-deck add -c claude --container ssh:gpu --container-workdir /srv/app .  # See 'agent-deck hosts'")
+		fmt.Println("  agent-deck add --clone git@github.com:org/repo.git ~/code/ --start  # Clone, group 'org', start agent")
+		fmt.Println("  agent-deck add --issue org/repo#123 -c claude .   # Session for an issue, prompt queued")
+		fmt.Println("  agent-deck add --ticket ENG-123 -c claude .       # Linked to a Linear/Jira ticket")
+		fmt.Println("  agent-deck add --tmux-socket scratch -c claude .  # On its own tmux server")
+		fmt.Println("  agent-deck add --yolo -c codex .                  # Skip approvals for this session")
+		fmt.Println("  agent-deck add --yolo --sandbox -c claude .       # ...inside the [yolo_sandbox] image")
+		fmt.Println("  agent-deck add --template claude-review .         # From [templates.claude-review]")
+		fmt.Println("  agent-deck add -c claude --env ANTHROPIC_MODEL=claude-opus-4-1 .  # Env for this session only")
 		fmt.Println()
 		fmt.Println("Worktree Examples:")
 		fmt.Println("  agent-deck add -w feature/login .    # Create worktree for existing branch")
@@ -636,6 +831,12 @@ func handleAdd(profile string, args []string) {
 		}
 	}
 
+	// Clone first so the checks below apply to the new working copy
+	var cloneOwner string
+	if *clone != "" {
+		cloneOwner, path = cloneForAdd(*clone, path, !*jsonOutput && !*quiet && !*quietShort)
+	}
+
 	// Verify path exists and is a directory
 	info, err := os.Stat(path)
 	if err != nil {
@@ -647,6 +848,71 @@ func handleAdd(profile string, args []string) {
 		os.Exit(1)
 	}
 
+	// Fetch the issue before creating anything, so a bad reference fails fast
+	var issueRef github.IssueRef
+	var issue *github.Issue
+	if *issueFlag != "" {
+		issueRef, err = github.ParseIssueRef(*issueFlag)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		issue, err = github.FetchIssue(issueRef, path)
+		if err != nil {
+			fmt.Printf("Error: failed to fetch issue: %v\n", err)
+			os.Exit(1)
+		}
+		if issueRef.Repo == "" {
+			// Name the repository gh inferred, from the issue's URL
+			if ref, err := github.ParseIssueRef(issue.URL); err == nil {
+				issueRef = ref
+			}
+		}
+	}
+
+	var ticket *session.Ticket
+	if *ticketFlag != "" {
+		ticket, err = linkTicket(*ticketFlag, *jsonOutput || *quiet || *quietShort)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *tmuxSocket != "" {
+		if err := tmux.ValidateSocketName(*tmuxSocket); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	containerSpec, err := session.ParseContainerSpec(*container)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if containerSpec != nil {
+		containerSpec.Workdir = *containerWorkdir
+		containerSpec.Context = *k8sContext
+		containerSpec.PodContainer = *k8sContainer
+	} else if *k8sContext != "" || *k8sContainer != "" {
+		fmt.Println("Error: --k8s-context/--k8s-container need --container k8s:<pod>")
+		os.Exit(1)
+	}
+
+	var tpl *session.SessionTemplate
+	if *templateName != "" {
+		tpl = session.GetSessionTemplate(*templateName)
+		if tpl == nil {
+			msg := fmt.Sprintf("Error: template '%s' not found in config.toml", *templateName)
+			if names := session.GetSessionTemplateNames(); len(names) > 0 {
+				msg += fmt.Sprintf(" (available: %s)", strings.Join(names, ", "))
+			}
+			fmt.Println(msg)
+			os.Exit(1)
+		}
+	}
+
 	// Resolve worktree flags
 	wtBranch := *worktreeBranch
 	if *worktreeBranchLong != "" {
@@ -734,6 +1000,29 @@ func handleAdd(profile string, args []string) {
 	sessionCommand := mergeFlags(*command, *commandShort)
 	sessionParent := mergeFlags(*parent, *parentShort)
 
+	// A clone is grouped by its org or user unless a group was given
+	if sessionGroup == "" && cloneOwner != "" {
+		sessionGroup = strings.ToLower(cloneOwner)
+	}
+
+	// The template fills in what the flags didn't give
+	templateCommand := false
+	if tpl != nil {
+		if sessionGroup == "" {
+			sessionGroup = tpl.Group
+		}
+		if sessionCommand == "" && tpl.CommandLine() != "" {
+			sessionCommand = tpl.CommandLine()
+			templateCommand = true
+		}
+	}
+
+	// [[group_rules]] and [repo_labels] fill in what's still missing
+	sessionTitle, sessionGroup, remote := session.LabelNewSession(sessionTitle, sessionGroup, path)
+	if *start && sessionCommand == "" {
+		sessionCommand = session.GetDefaultTool()
+	}
+
 	// Validate --resume-session requires Claude
 	if *resumeSession != "" {
 		tool := detectTool(sessionCommand)
@@ -743,9 +1032,25 @@ func handleAdd(profile string, args []string) {
 		}
 	}
 
-	// Default title to folder name
+	if *yolo && *safe {
+		fmt.Println("Error: --yolo and --safe are mutually exclusive")
+		os.Exit(1)
+	}
+	if *sandbox && *noSandbox {
+		fmt.Println("Error: --sandbox and --no-sandbox are mutually exclusive")
+		os.Exit(1)
+	}
+	if *sandbox && !*yolo {
+		fmt.Println("Error: --sandbox is for YOLO sessions, use it with --yolo")
+		os.Exit(1)
+	}
+
+	// Default title to folder name (or issue number)
 	if sessionTitle == "" {
 		sessionTitle = filepath.Base(path)
+		if issue != nil {
+			sessionTitle = fmt.Sprintf("issue-%d", issue.Number)
+		}
 	}
 
 	// Load existing sessions with profile
@@ -805,6 +1110,10 @@ func handleAdd(profile string, args []string) {
 	} else {
 		newInstance = session.NewInstance(sessionTitle, path)
 	}
+	if !userProvidedTitle {
+		// [auto_name] may title it after its task
+		newInstance.AutoTitle = sessionTitle
+	}
 
 	// Set parent if specified (includes parent's project path for --add-dir access)
 	if parentInstance != nil {
@@ -813,19 +1122,34 @@ func handleAdd(profile string, args []string) {
 
 	// Set command if provided
 	if sessionCommand != "" {
-		newInstance.Tool = detectTool(sessionCommand)
-		// For custom tools, resolve the actual shell command (e.g. "glm" → "claude")
-		if toolDef := session.GetToolDef(newInstance.Tool); toolDef != nil {
-			newInstance.Command = toolDef.Command
-		} else {
-			newInstance.Command = sessionCommand
+		applySessionCommand(newInstance, sessionCommand)
+	}
+	if tpl != nil {
+		if templateCommand && tpl.Tool != "" {
+			newInstance.Tool = tpl.Tool
 		}
+		newInstance.Template = *templateName
 	}
 
 	// Set wrapper if provided
 	if *wrapper != "" {
 		newInstance.Wrapper = *wrapper
 	}
+	newInstance.Container = containerSpec
+	newInstance.Ticket = ticket
+	if len(sessionEnv) > 0 {
+		newInstance.Env = sessionEnv
+	}
+	newInstance.GitRemote = remote
+	if *tmuxSocket != "" {
+		newInstance.GetTmuxSession().SocketName = *tmuxSocket
+	}
+
+	// The issue goes in the notes, and its body becomes the first prompt
+	if issue != nil {
+		newInstance.Notes = issue.Notes(issueRef)
+		newInstance.PendingPrompt = issue.Prompt()
+	}
 
 	// Set worktree fields if created
 	if worktreePath != "" {
@@ -851,6 +1175,36 @@ func handleAdd(profile string, args []string) {
 		}
 	}
 
+	// --yolo/--safe override the tool's configured launch mode
+	if *yolo || *safe {
+		if err := newInstance.SetLaunchMode(*yolo); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// A YOLO session on the host may go into the [yolo_sandbox] container
+	sandboxSettings := session.GetYoloSandboxSettings()
+	if *sandbox && sandboxSettings.Image == "" {
+		fmt.Println("Error: --sandbox needs an image in [yolo_sandbox] (config.toml)")
+		os.Exit(1)
+	}
+	if spec := sandboxSettings.SandboxFor(newInstance); spec != nil && !*noSandbox {
+		useSandbox := *sandbox
+		switch sandboxSettings.GetMode() {
+		case "always":
+			useSandbox = true
+		case "ask":
+			if !useSandbox && !*jsonOutput && !*quiet && !*quietShort && term.IsTerminal(int(os.Stdin.Fd())) {
+				useSandbox = confirmYoloSandbox(spec)
+			}
+		}
+		if useSandbox {
+			newInstance.Container = spec
+			containerSpec = spec
+		}
+	}
+
 	// Add to instances
 	instances = append(instances, newInstance)
 
@@ -865,6 +1219,7 @@ func handleAdd(profile string, args []string) {
 		fmt.Printf("Error: failed to save session: %v\n", err)
 		os.Exit(1)
 	}
+	_ = session.RecordRecentDirectory(path)
 
 	// Attach MCPs if specified
 	if len(mcpFlags) > 0 {
@@ -891,9 +1246,30 @@ func handleAdd(profile string, args []string) {
 	quietMode := *quiet || *quietShort
 	out := NewCLIOutput(*jsonOutput, quietMode)
 
+	if *start {
+		startFn := newInstance.Start
+		if newInstance.PendingPrompt != "" {
+			// Send the prompt before exiting; Start would deliver it in the background
+			startFn = func() error { return newInstance.StartWithMessage("") }
+		}
+		if err := startFn(); err != nil {
+			out.Error(fmt.Sprintf("session added but failed to start: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		newInstance.PostStartSync(3 * time.Second)
+		if err := saveSessionData(storage, instances); err != nil {
+			out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	}
+
 	// Build human-readable output
 	var humanLines []string
-	humanLines = append(humanLines, fmt.Sprintf("Added session: %s", sessionTitle))
+	if *start {
+		humanLines = append(humanLines, fmt.Sprintf("Added and started session: %s", sessionTitle))
+	} else {
+		humanLines = append(humanLines, fmt.Sprintf("Added session: %s", sessionTitle))
+	}
 	humanLines = append(humanLines, fmt.Sprintf("  Profile: %s", storage.Profile()))
 	humanLines = append(humanLines, fmt.Sprintf("  Path:    %s", path))
 	humanLines = append(humanLines, fmt.Sprintf("  Group:   %s", newInstance.GroupPath))
@@ -901,9 +1277,15 @@ func handleAdd(profile string, args []string) {
 	if sessionCommand != "" {
 		humanLines = append(humanLines, fmt.Sprintf("  Cmd:     %s", sessionCommand))
 	}
+	if mode := newInstance.LaunchMode(); mode != "" {
+		humanLines = append(humanLines, fmt.Sprintf("  Mode:    %s", mode))
+	}
 	if len(mcpFlags) > 0 {
 		humanLines = append(humanLines, fmt.Sprintf("  MCPs:    %s", strings.Join(mcpFlags, ", ")))
 	}
+	if containerSpec != nil {
+		humanLines = append(humanLines, fmt.Sprintf("  Container: %s", containerSpec))
+	}
 	if parentInstance != nil {
 		humanLines = append(humanLines, fmt.Sprintf("  Parent:  %s (%s)", parentInstance.Title, parentInstance.ID[:8]))
 	}
@@ -914,9 +1296,23 @@ func handleAdd(profile string, args []string) {
 	if *resumeSession != "" {
 		humanLines = append(humanLines, fmt.Sprintf("  Resume:  %s", *resumeSession))
 	}
+	if *clone != "" {
+		humanLines = append(humanLines, fmt.Sprintf("  Remote:  %s", *clone))
+	}
+	if ticket != nil {
+		humanLines = append(humanLines, strings.TrimRight(fmt.Sprintf("  Ticket:  %s %s", ticket, ticket.Title), " "))
+	}
+	if issue != nil {
+		humanLines = append(humanLines, fmt.Sprintf("  Issue:   %s", issue.URL))
+		if !*start {
+			humanLines = append(humanLines, "  Prompt:  queued, sent when the session starts")
+		}
+	}
 	humanLines = append(humanLines, "")
 	humanLines = append(humanLines, "Next steps:")
-	humanLines = append(humanLines, fmt.Sprintf("  agent-deck session start %s   # Start the session", sessionTitle))
+	if !*start {
+		humanLines = append(humanLines, fmt.Sprintf("  agent-deck session start %s   # Start the session", sessionTitle))
+	}
 	humanLines = append(humanLines, "  agent-deck                         # Open TUI and press Enter to attach")
 
 	// Build JSON data
@@ -932,9 +1328,15 @@ func handleAdd(profile string, args []string) {
 	if sessionCommand != "" {
 		jsonData["command"] = sessionCommand
 	}
+	if mode := newInstance.LaunchMode(); mode != "" {
+		jsonData["launch_mode"] = mode
+	}
 	if len(mcpFlags) > 0 {
 		jsonData["mcps"] = mcpFlags
 	}
+	if containerSpec != nil {
+		jsonData["container"] = containerSpec
+	}
 	if parentInstance != nil {
 		jsonData["parent_id"] = parentInstance.ID
 		jsonData["parent_title"] = parentInstance.Title
@@ -947,6 +1349,20 @@ func handleAdd(profile string, args []string) {
 	if *resumeSession != "" {
 		jsonData["resume_session"] = *resumeSession
 	}
+	if *clone != "" {
+		jsonData["cloned_from"] = *clone
+	}
+	if *start {
+		jsonData["started"] = true
+	}
+	if ticket != nil {
+		jsonData["ticket"] = ticket
+	}
+	if issue != nil {
+		jsonData["issue"] = issueRef.String()
+		jsonData["issue_title"] = issue.Title
+		jsonData["issue_url"] = issue.URL
+	}
 
 	out.Success(humanLines[0], jsonData)
 	if !*jsonOutput && !quietMode {
@@ -956,11 +1372,60 @@ func handleAdd(profile string, args []string) {
 	}
 }
 
+// cloneForAdd clones remote for `add --clone` and returns the remote's owner
+// and the working copy path. target is an existing directory to clone into
+// (as target/<repo>) or the clone path itself. An existing clone at that path
+// is reused, so re-running the command just adds the session.
+func cloneForAdd(remote, target string, verbose bool) (owner, dest string) {
+	owner, repo := git.ParseRemoteURL(remote)
+	if repo == "" {
+		fmt.Fprintf(os.Stderr, "Error: cannot determine repository name from %s\n", remote)
+		os.Exit(1)
+	}
+	dest = target
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		dest = filepath.Join(target, repo)
+	}
+
+	if _, err := os.Stat(dest); err == nil {
+		if !git.IsGitRepo(dest) {
+			fmt.Fprintf(os.Stderr, "Error: %s already exists and is not a git repository\n", dest)
+			os.Exit(1)
+		}
+		if verbose {
+			fmt.Printf("Using existing clone at: %s\n", dest)
+		}
+		return owner, dest
+	}
+	if err := git.CloneRepo(remote, dest); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if verbose {
+		fmt.Printf("Cloned into: %s\n", dest)
+	}
+	return owner, dest
+}
+
+// applySessionCommand sets the tool and command for a new session from a
+// tool name or command line
+func applySessionCommand(inst *session.Instance, command string) {
+	inst.Tool = detectTool(command)
+	// For custom tools, resolve the actual shell command (e.g. "glm" → "claude")
+	if toolDef := session.GetToolDef(inst.Tool); toolDef != nil {
+		inst.Command = toolDef.Command
+	} else {
+		inst.Command = command
+	}
+}
+
 // handleList lists all sessions
 func handleList(profile string, args []string) {
 	fs := flag.NewFlagSet("list", flag.ExitOnError)
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 	allProfiles := fs.Bool("all", false, "List sessions from all profiles")
+	fullPaths := fs.Bool("full-paths", false, "Show project paths in full instead of ~/code/…/service/api")
+	quicklistOutput := fs.Bool("quicklist", false, "Output JSON for launchers (Alfred Script Filter / Raycast)")
 
 	fs.Usage = func() {
 		fmt.Println("Usage: agent-deck list [options]")
@@ -974,14 +1439,22 @@ func handleList(profile string, args []string) {
 		fmt.Println("  agent-deck list                    # List from default profile")
 		fmt.Println("  agent-deck -p work list            # List from 'work' profile")
 		fmt.Println("  agent-deck list --all              # List from all profiles")
+		fmt.Println("  agent-deck list --full-paths       # Don't shorten project paths")
+		fmt.Println("  agent-deck list --all --quicklist  # Launcher items that attach to each session")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
 		os.Exit(1)
 	}
 
+	if *quicklistOutput {
+		handleListQuicklist(profile, *allProfiles)
+		return
+	}
+
+	full := *fullPaths || session.GetPreviewSettings().FullPaths
 	if *allProfiles {
-		handleListAllProfiles(*jsonOutput)
+		handleListAllProfiles(*jsonOutput, full)
 		return
 	}
 
@@ -1008,8 +1481,10 @@ func handleList(profile string, args []string) {
 			ID        string    `json:"id"`
 			Title     string    `json:"title"`
 			Path      string    `json:"path"`
+			Repo      string    `json:"repo,omitempty"`
 			Group     string    `json:"group"`
 			Tool      string    `json:"tool"`
+			Model     string    `json:"model,omitempty"`
 			Command   string    `json:"command,omitempty"`
 			Status    string    `json:"status"`
 			Profile   string    `json:"profile"`
@@ -1022,8 +1497,10 @@ func handleList(profile string, args []string) {
 				ID:        inst.ID,
 				Title:     inst.Title,
 				Path:      inst.ProjectPath,
+				Repo:      inst.RepoLabel(),
 				Group:     inst.GroupPath,
 				Tool:      inst.Tool,
+				Model:     inst.Model(),
 				Command:   inst.Command,
 				Status:    StatusString(inst.Status),
 				Profile:   storage.Profile(),
@@ -1044,15 +1521,12 @@ func handleList(profile string, args []string) {
 	fmt.Printf("%-*s %-*s %-*s %s\n", tableColTitle, "TITLE", tableColGroup, "GROUP", tableColPath, "PATH", "ID")
 	fmt.Println(strings.Repeat("-", tableColTitle+tableColGroup+tableColPath+tableColIDDisplay+5))
 	for _, inst := range instances {
-		title := truncate(inst.Title, tableColTitle)
-		group := truncate(inst.GroupPath, tableColGroup)
-		path := truncate(inst.ProjectPath, tableColPath)
 		// Safe ID display with bounds check to prevent panic
 		idDisplay := inst.ID
 		if len(idDisplay) > tableColIDDisplay {
 			idDisplay = idDisplay[:tableColIDDisplay]
 		}
-		fmt.Printf("%-*s %-*s %-*s %s\n", tableColTitle, title, tableColGroup, group, tableColPath, path, idDisplay)
+		fmt.Printf("%s %s %s %s\n", tableCell(inst.Title, tableColTitle), tableCell(inst.GroupPath, tableColGroup), pathCell(inst.ProjectPath, full), idDisplay)
 	}
 	fmt.Printf("\nTotal: %d sessions\n", len(instances))
 
@@ -1060,8 +1534,47 @@ func handleList(profile string, args []string) {
 	printUpdateNotice()
 }
 
+// handleListQuicklist prints sessions as launcher items, from one profile or all
+func handleListQuicklist(profile string, allProfiles bool) {
+	profiles := []string{profile}
+	if allProfiles {
+		var err error
+		if profiles, err = session.ListProfiles(); err != nil {
+			fmt.Printf("Error: failed to list profiles: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	sessions := make(map[string][]*session.Instance)
+	for _, name := range profiles {
+		storage, err := session.NewStorageWithProfile(name)
+		if err != nil {
+			continue
+		}
+		instances, _, err := storage.LoadWithGroups()
+		if err != nil {
+			continue
+		}
+		for _, inst := range instances {
+			_ = inst.UpdateStatus()
+		}
+		sessions[storage.Profile()] = instances
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "agent-deck"
+	}
+	output, err := json.MarshalIndent(buildQuicklist(exe, sessions), "", "  ")
+	if err != nil {
+		fmt.Printf("Error: failed to format JSON output: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(output))
+}
+
 // handleListAllProfiles lists sessions from all profiles
-func handleListAllProfiles(jsonOutput bool) {
+func handleListAllProfiles(jsonOutput, fullPaths bool) {
 	profiles, err := session.ListProfiles()
 	if err != nil {
 		fmt.Printf("Error: failed to list profiles: %v\n", err)
@@ -1134,25 +1647,22 @@ func handleListAllProfiles(jsonOutput bool) {
 			continue
 		}
 
-		fmt.Printf("\n═══ Profile: %s ═══\n\n", profileName)
+		fmt.Print(cliText(fmt.Sprintf("\n═══ Profile: %s ═══\n\n", profileName)))
 		fmt.Printf("%-*s %-*s %-*s %s\n", tableColTitle, "TITLE", tableColGroup, "GROUP", tableColPath, "PATH", "ID")
 		fmt.Println(strings.Repeat("-", tableColTitle+tableColGroup+tableColPath+tableColIDDisplay+5))
 
 		for _, inst := range instances {
-			title := truncate(inst.Title, tableColTitle)
-			group := truncate(inst.GroupPath, tableColGroup)
-			path := truncate(inst.ProjectPath, tableColPath)
 			idDisplay := inst.ID
 			if len(idDisplay) > tableColIDDisplay {
 				idDisplay = idDisplay[:tableColIDDisplay]
 			}
-			fmt.Printf("%-*s %-*s %-*s %s\n", tableColTitle, title, tableColGroup, group, tableColPath, path, idDisplay)
+			fmt.Printf("%s %s %s %s\n", tableCell(inst.Title, tableColTitle), tableCell(inst.GroupPath, tableColGroup), pathCell(inst.ProjectPath, fullPaths), idDisplay)
 		}
 		fmt.Printf("(%d sessions)\n", len(instances))
 		totalSessions += len(instances)
 	}
 
-	fmt.Printf("\n═══════════════════════════════════════\n")
+	fmt.Print(cliText("\n═══════════════════════════════════════\n"))
 	fmt.Printf("Total: %d sessions across %d profiles\n", totalSessions, len(profiles))
 }
 
@@ -1162,11 +1672,16 @@ func handleRemove(profile string, args []string) {
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 	quiet := fs.Bool("quiet", false, "Minimal output")
 	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	keepTmux := fs.Bool("keep-tmux", false, "Only remove the record; leave the tmux session running")
 
 	fs.Usage = func() {
-		fmt.Println("Usage: agent-deck remove <id|title>")
+		fmt.Println("Usage: agent-deck remove <id|title> [options]")
 		fmt.Println()
 		fmt.Println("Remove a session by ID or title.")
+		fmt.Println("Kills its tmux session unless --keep-tmux or [confirm] delete_kills_tmux = false.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
 		fmt.Println()
 		fmt.Println("Examples:")
 		fmt.Println("  agent-deck remove abc12345")
@@ -1208,20 +1723,24 @@ func handleRemove(profile string, args []string) {
 
 	removedID := inst.ID
 	removedTitle := inst.Title
+	killTmux := !*keepTmux && session.GetConfirmSettings().GetDeleteKillsTmux()
 
 	// Always attempt to kill the tmux session, even if Exists() returns false.
 	// The saved status may be stale (e.g., "error" in DB but tmux session still alive).
 	// Kill() is safe to call on non-existent sessions (returns error which we handle).
-	if err := inst.Kill(); err != nil {
-		// Only warn if the session actually existed (ignore "not found" errors)
-		if inst.Exists() && !*jsonOutput {
-			fmt.Printf("Warning: failed to kill tmux session: %v\n", err)
-			fmt.Println("Session removed from Agent Deck but may still be running in tmux")
+	if killTmux {
+		if err := inst.Kill(); err != nil {
+			// Only warn if the session actually existed (ignore "not found" errors)
+			if inst.Exists() && !*jsonOutput {
+				fmt.Printf("Warning: failed to kill tmux session: %v\n", err)
+				fmt.Println("Session removed from Agent Deck but may still be running in tmux")
+			}
 		}
 	}
 
-	// Clean up worktree directory if this is a worktree session
-	if inst.IsWorktree() {
+	// Clean up worktree directory if this is a worktree session (left in
+	// place when the tmux session keeps running in it)
+	if killTmux && inst.IsWorktree() {
 		if err := git.RemoveWorktree(inst.WorktreeRepoRoot, inst.WorktreePath, false); err != nil {
 			if !*jsonOutput {
 				fmt.Printf("Warning: failed to remove worktree: %v\n", err)
@@ -1238,6 +1757,7 @@ func handleRemove(profile string, args []string) {
 			fmt.Printf("Warning: direct delete failed: %v\n", err)
 		}
 	}
+	_ = session.DeleteSnapshots(removedID)
 
 	// Rebuild instance list without the deleted session and save with groups
 	newInstances := make([]*session.Instance, 0, len(instances)-1)
@@ -1304,9 +1824,10 @@ func handleStatus(profile string, args []string) {
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 
 	fs.Usage = func() {
-		fmt.Println("Usage: agent-deck status [options]")
+		fmt.Println("Usage: agent-deck status [id|title] [options]")
 		fmt.Println()
-		fmt.Println("Show a summary of session statuses.")
+		fmt.Println("Show a summary of session statuses, or what tmux reports about one session:")
+		fmt.Println("whether it exists, its status, pane PID and last activity.")
 		fmt.Println()
 		fmt.Println("Options:")
 		fs.PrintDefaults()
@@ -1315,6 +1836,7 @@ func handleStatus(profile string, args []string) {
 		fmt.Println("  agent-deck status              # Quick summary")
 		fmt.Println("  agent-deck status -v           # Detailed list")
 		fmt.Println("  agent-deck status -q           # Just waiting count")
+		fmt.Println("  agent-deck status api --json   # One session's live state")
 		fmt.Println("  agent-deck -p work status      # Status for 'work' profile")
 	}
 
@@ -1335,6 +1857,27 @@ func handleStatus(profile string, args []string) {
 		os.Exit(1)
 	}
 
+	if identifier := fs.Arg(0); identifier != "" {
+		out := NewCLIOutput(*jsonOutput, false)
+		inst, errMsg, errCode := ResolveSession(identifier, instances)
+		if inst == nil {
+			out.Error(errMsg, errCode)
+			if errCode == ErrCodeNotFound {
+				os.Exit(2)
+			}
+			os.Exit(1)
+			return // unreachable, satisfies staticcheck SA5011
+		}
+		_ = inst.UpdateStatus()
+		live := liveStatuses([]*session.Instance{inst})[0]
+		if (*quiet || *quietShort) && !*jsonOutput {
+			fmt.Println(live.Status)
+			return
+		}
+		out.Print(formatLiveStatus(live, inst.Status), live)
+		return
+	}
+
 	if len(instances) == 0 {
 		if *jsonOutput {
 			fmt.Println(`{"waiting": 0, "running": 0, "idle": 0, "error": 0, "total": 0}`)
@@ -1348,27 +1891,42 @@ func handleStatus(profile string, args []string) {
 
 	// Count by status
 	counts := countByStatus(instances)
+	verboseMode := *verbose || *verboseShort
+	var live map[string]liveStatus
+	if verboseMode {
+		live = make(map[string]liveStatus, len(instances))
+		for _, l := range liveStatuses(instances) {
+			live[l.ID] = l
+		}
+	}
 
 	// Output based on flags
 	if *jsonOutput {
 		type statusJSON struct {
-			Waiting int `json:"waiting"`
-			Running int `json:"running"`
-			Idle    int `json:"idle"`
-			Error   int `json:"error"`
-			Total   int `json:"total"`
-		}
-		output, _ := json.Marshal(statusJSON{
+			Waiting  int          `json:"waiting"`
+			Running  int          `json:"running"`
+			Idle     int          `json:"idle"`
+			Error    int          `json:"error"`
+			Total    int          `json:"total"`
+			Sessions []liveStatus `json:"sessions,omitempty"` // with -v
+		}
+		summary := statusJSON{
 			Waiting: counts.waiting,
 			Running: counts.running,
 			Idle:    counts.idle,
 			Error:   counts.err,
 			Total:   counts.total,
-		})
+		}
+		for _, inst := range instances {
+			if l, ok := live[inst.ID]; ok {
+				summary.Sessions = append(summary.Sessions, l)
+			}
+		}
+		output, _ := json.Marshal(summary)
 		fmt.Println(string(output))
 	} else if *quiet || *quietShort {
 		fmt.Println(counts.waiting)
-	} else if *verbose || *verboseShort {
+	} else if verboseMode {
 		// Detailed output grouped by status
 		printStatusGroup := func(label, symbol string, status session.Status) {
 			var matching []*session.Instance
@@ -1387,7 +1945,12 @@ func handleStatus(profile string, args []string) {
 				if strings.HasPrefix(path, home) {
 					path = "~" + path[len(home):]
 				}
-				fmt.Printf("  %s %-16s %-10s %s\n", symbol, inst.Title, inst.Tool, path)
+				l := live[inst.ID]
+				pid := "-"
+				if l.PanePID > 0 {
+					pid = fmt.Sprint(l.PanePID)
+				}
+				fmt.Printf("  %s %-16s %-10s %-8s %-24s %s\n", cliText(symbol), inst.Title, inst.Tool, pid, formatLastActivity(l.LastActivity), path)
 			}
 			fmt.Println()
 		}
@@ -1400,8 +1963,8 @@ func handleStatus(profile string, args []string) {
 		fmt.Printf("Total: %d sessions in profile '%s'\n", counts.total, storage.Profile())
 	} else {
 		// Compact output
-		fmt.Printf("%d waiting • %d running • %d idle\n",
-			counts.waiting, counts.running, counts.idle)
+		fmt.Print(cliText(fmt.Sprintf("%d waiting • %d running • %d idle\n",
+			counts.waiting, counts.running, counts.idle)))
 	}
 
 	// Show update notice if available (skip for JSON/quiet output)
@@ -1620,7 +2183,7 @@ func handleUpdate(args []string) {
 		return
 	}
 
-	fmt.Printf("\n⬆ Update available: v%s → v%s\n", info.CurrentVersion, info.LatestVersion)
+	fmt.Print(cliText(fmt.Sprintf("\n⬆ Update available: v%s → v%s\n", info.CurrentVersion, info.LatestVersion)))
 	fmt.Printf("  Release: %s\n", info.ReleaseURL)
 
 	// Fetch and display changelog
@@ -1705,20 +2268,50 @@ func printHelp() {
 	fmt.Println()
 	fmt.Println("Global Options:")
 	fmt.Println("  -p, --profile <name>   Use specific profile (default: 'default')")
+	fmt.Println("  --plain                ASCII-only output without colors (also: NO_COLOR=1)")
+	fmt.Println()
+	fmt.Println("TUI Options:")
+	fmt.Println("  --read-only            Browse without saving (e.g. next to another instance)")
+	fmt.Println("  --takeover             Close the instance running for this profile, then start")
+	fmt.Println("  --demo                 Try the TUI on simulated sessions (no tmux, nothing saved)")
+	fmt.Println("  --screen-reader        Linear plain-text layout that announces the selection")
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  (none)           Start the TUI")
 	fmt.Println("  add <path>       Add a new session")
+	fmt.Println("  new --scaffold   Create a group of predefined sessions for a project")
 	fmt.Println("  try <name>       Quick experiment (create/find dated folder + session)")
 	fmt.Println("  list, ls         List all sessions")
 	fmt.Println("  remove, rm       Remove a session")
 	fmt.Println("  status           Show session status summary")
+	fmt.Println("  stats            Show deck-wide metrics (status, tools, groups, idle, logs)")
+	fmt.Println("  doctor           Check stored sessions for problems (--fix to repair)")
 	fmt.Println("  session          Manage session lifecycle")
+	fmt.Println("  attach <id>      Attach to a session from the shell (starts it if needed)")
+	fmt.Println("  edit [id]        Edit a session's stored fields as JSON in $EDITOR")
+	fmt.Println("  share [id]       Watch a session read-only (or share with a teammate)")
+	fmt.Println("  dump [id]        Save a session's terminal content/scrollback to a file")
+	fmt.Println("  snapshot         Save, list and view named pane snapshots of a session")
+	fmt.Println("  backup           Export sessions and config now, or list the daily backups")
+	fmt.Println("  export           Write sessions and groups as JSON to share or move a deck")
+	fmt.Println("  import <file>    Add an exported deck, remapping paths and hosts to this machine")
+	fmt.Println("  team             List and add sessions from shared, read-only team decks")
+	fmt.Println("  run              Send a prompt to every session in a group and collect output")
+	fmt.Println("  report           Export time and cost per session (--from, --format csv)")
+	fmt.Println("  tail [id]        Follow a session's live output (read-only)")
+	fmt.Println("  daemon           Serve a read-only status page and /metrics JSON over HTTP")
+	fmt.Println("  watch            Print status changes live from the daemon's event stream")
+	fmt.Println("  serve            Serve a JSON API (list, add, remove, attach, status, keys) locally")
+	fmt.Println("  hook             Install Claude Code hooks that report state to the deck")
+	fmt.Println("  notify [id]      Report a session's status/message to the running TUI")
 	fmt.Println("  mcp              Manage MCP servers")
 	fmt.Println("  group            Manage groups")
+	fmt.Println("  tree [group]     Print the group/session hierarchy (--format dot for Graphviz)")
+	fmt.Println("  hosts            List remote hosts for --container ssh:<host>")
 	fmt.Println("  worktree, wt     Manage git worktrees")
 	fmt.Println("  conductor        Manage conductor meta-agent orchestration")
 	fmt.Println("  profile          Manage profiles")
+	fmt.Println("  sync             Share sessions and config between machines via git")
 	fmt.Println("  update           Check for and install updates")
 	fmt.Println("  uninstall        Uninstall Agent Deck")
 	fmt.Println("  version          Show version")
@@ -1795,15 +2388,24 @@ func mergeFlags(long, short string) string {
 	return short
 }
 
-// truncate shortens a string to max length with ellipsis
+// truncate shortens a string to max display columns with ellipsis
 func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
-	}
-	if max <= 3 {
-		return s[:max]
+	return ui.TruncateWidth(s, max)
+}
+
+// pathCell formats a project path for the PATH column: shortened from the
+// middle so the project directory stays visible, or in full when requested
+func pathCell(path string, full bool) string {
+	if full {
+		return ui.PadWidth(path, tableColPath)
 	}
-	return s[:max-3] + "..."
+	return ui.PadWidth(ui.ShortenPath(path, tableColPath), tableColPath)
+}
+
+// tableCell truncates and pads s to exactly width display columns, keeping
+// table columns aligned when values contain CJK or emoji
+func tableCell(s string, width int) string {
+	return ui.PadWidth(truncate(s, width), width)
 }
 
 // detectTool determines the tool type from command
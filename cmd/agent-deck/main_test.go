@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"testing"
 
+	"github.com/mattn/go-runewidth"
+
 	"github.com/asheshgoplani/agent-deck/internal/ui"
 )
 
@@ -105,3 +107,37 @@ func TestNestedSessionAllowsCLICommands(t *testing.T) {
 		}
 	})
 }
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input    string
+		maxLen   int
+		expected string
+	}{
+		{"hello", 10, "hello"},            // No truncation needed
+		{"hello world", 10, "hello w..."}, // Truncation needed
+		{"hi", 3, "hi"},                   // Exactly at limit
+		{"hello", 3, "hel"},               // Very short max (no room for "...")
+		{"", 5, ""},                       // Empty string
+		{"日本語のタイトル", 10, "日本語..."},       // Wide runes count two columns
+		{"日本語", 5, "日..."},               // Never splits a wide rune
+		{"日本語", 3, "日"},                  // Odd width leaves a column unused
+		{"fix 🐛 bug now", 9, "fix 🐛..."}, // Emoji are two columns
+		{"👨‍👩‍👧 family", 6, "👨‍👩‍👧 ..."}, // ZWJ sequence stays whole
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := truncate(tt.input, tt.maxLen); got != tt.expected {
+				t.Errorf("truncate(%q, %d) = %q, want %q", tt.input, tt.maxLen, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTableCellAlignsWideText(t *testing.T) {
+	for _, s := range []string{"plain", "日本語のタイトルです", "🚀 launch", "naïve"} {
+		if got := runewidth.StringWidth(tableCell(s, 8)); got != 8 {
+			t.Errorf("tableCell(%q, 8) is %d columns wide, want 8", s, got)
+		}
+	}
+}
@@ -29,6 +29,8 @@ func handleMCP(profile string, args []string) {
 		handleMCPDetach(profile, args[1:])
 	case "server":
 		handleMCPServer(args[1:])
+	case "serve":
+		handleMCPServe(profile, args[1:])
 	case "help", "-h", "--help":
 		printMCPHelp()
 	default:
@@ -50,6 +52,7 @@ func printMCPHelp() {
 	fmt.Println("  attach <id> <mcp>   Attach an MCP to a session")
 	fmt.Println("  detach <id> <mcp>   Detach an MCP from a session")
 	fmt.Println("  server <cmd>        Manage HTTP MCP servers (start/stop/status)")
+	fmt.Println("  serve               Run agent-deck as an MCP server (stdio) for agents")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  agent-deck mcp list                        # List available MCPs")
@@ -60,6 +63,7 @@ func printMCPHelp() {
 	fmt.Println("  agent-deck mcp detach my-project exa       # Detach exa from my-project")
 	fmt.Println("  agent-deck mcp server status               # Show HTTP server status")
 	fmt.Println("  agent-deck mcp server start slack          # Start HTTP server for slack MCP")
+	fmt.Println("  claude mcp add agent-deck -- agent-deck mcp serve   # Let Claude manage the deck")
 }
 
 // handleMCPList lists all available MCPs from config.toml
@@ -190,16 +194,7 @@ func handleMCPList(args []string) {
 				cmdDisplay += " " + strings.Join(def.Args, " ")
 			}
 		}
-		if len(cmdDisplay) > maxCmd {
-			cmdDisplay = cmdDisplay[:maxCmd-3] + "..."
-		}
-
-		nameDisplay := name
-		if len(nameDisplay) > maxName {
-			nameDisplay = nameDisplay[:maxName-3] + "..."
-		}
-
-		fmt.Printf("%-*s %-7s %-*s %s\n", maxName, nameDisplay, transportDisplay, maxCmd, cmdDisplay, def.Description)
+		fmt.Printf("%s %-7s %s %s\n", tableCell(name, maxName), transportDisplay, tableCell(cmdDisplay, maxCmd), def.Description)
 	}
 
 	fmt.Printf("\nTotal: %d MCPs\n", len(mcps))
@@ -461,11 +456,16 @@ func handleMCPAttach(profile string, args []string) {
 			}
 		} else {
 			restarted = true
-			// Auto-continue: wait for Claude/Gemini to initialize, then send continue message
-			time.Sleep(2 * time.Second)
-			if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil {
-				// Send "continue" and Enter to resume the conversation
-				_ = tmuxSess.SendKeysAndEnter("continue")
+			if inst.WaitPendingPrompt() {
+				// The queued prompt went in instead of "continue"
+				savePromptDelivered(storage, inst)
+			} else {
+				// Auto-continue: wait for Claude/Gemini to initialize, then send continue message
+				time.Sleep(2 * time.Second)
+				if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil {
+					// Send "continue" and Enter to resume the conversation
+					_ = tmuxSess.SendKeysAndEnter("continue")
+				}
 			}
 		}
 	}
@@ -613,11 +613,16 @@ func handleMCPDetach(profile string, args []string) {
 			}
 		} else {
 			restarted = true
-			// Auto-continue: wait for Claude/Gemini to initialize, then send continue message
-			time.Sleep(2 * time.Second)
-			if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil {
-				// Send "continue" and Enter to resume the conversation
-				_ = tmuxSess.SendKeysAndEnter("continue")
+			if inst.WaitPendingPrompt() {
+				// The queued prompt went in instead of "continue"
+				savePromptDelivered(storage, inst)
+			} else {
+				// Auto-continue: wait for Claude/Gemini to initialize, then send continue message
+				time.Sleep(2 * time.Second)
+				if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil {
+					// Send "continue" and Enter to resume the conversation
+					_ = tmuxSess.SendKeysAndEnter("continue")
+				}
 			}
 		}
 	}
@@ -948,11 +953,11 @@ func handleMCPServerStatus(args []string) {
 			serverConfig = "yes"
 		}
 
-		fmt.Printf("%-15s %-10s %-12s %-35s %s\n",
-			truncateString(s.Name, 15),
+		fmt.Printf("%s %-10s %-12s %s %s\n",
+			tableCell(s.Name, 15),
 			s.Transport,
 			statusDisplay,
-			truncateString(s.URL, 35),
+			tableCell(s.URL, 35),
 			serverConfig,
 		)
 	}
@@ -0,0 +1,433 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// mcpProtocolVersions are the MCP revisions the server speaks, newest first
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// mcpOutputMaxLines caps get_session_output's lines
+const mcpOutputMaxLines = 500
+
+// JSON-RPC error codes
+const (
+	rpcParseError     = -32700
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// handleMCPServe runs agent-deck as an MCP server on stdin/stdout, so an
+// agent can list, create and prompt the deck's sessions
+func handleMCPServe(profile string, args []string) {
+	fs := flag.NewFlagSet("mcp serve", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck mcp serve")
+		fmt.Println()
+		fmt.Println("Run agent-deck as an MCP server over stdio, so an agent in one session can")
+		fmt.Println("spawn and coordinate sibling sessions. Tools: list_sessions, create_session,")
+		fmt.Println("send_prompt, get_session_output.")
+		fmt.Println()
+		fmt.Println("Add it to an agent, e.g. for Claude Code:")
+		fmt.Println("  claude mcp add agent-deck -- agent-deck mcp serve")
+		fmt.Println("or as an entry in config.toml to attach it with the MCP manager:")
+		fmt.Println("  [mcps.agent-deck]")
+		fmt.Println("  command = \"agent-deck\"")
+		fmt.Println("  args = [\"mcp\", \"serve\"]")
+	}
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	// Inside a session, serve the deck it belongs to unless -p says otherwise
+	currentID := GetCurrentSessionID()
+	if profile == "" && currentID != "" {
+		if inst, found := findSessionByTmuxAcrossProfiles(); inst != nil {
+			profile = found
+		}
+	}
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to initialize storage: %v\n", err)
+		os.Exit(1)
+	}
+	defer storage.Close()
+	if db := storage.GetDB(); db != nil {
+		statedb.SetGlobal(db)
+	}
+
+	server := &mcpServer{storage: storage, currentID: currentID, out: os.Stdout}
+	if err := server.serve(os.Stdin); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// mcpServer answers MCP requests for one profile. currentID is the session
+// the server runs in (its agent's own), which create_session takes the
+// project and group from and send_prompt won't type into.
+type mcpServer struct {
+	storage   *session.Storage
+	currentID string
+	out       io.Writer
+}
+
+type mcpRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type mcpResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *mcpError       `json:"error,omitempty"`
+}
+
+type mcpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// mcpTool describes a tool in tools/list
+type mcpTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+// mcpToolResult is the result of tools/call
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// serve reads newline-delimited JSON-RPC messages until in closes
+func (m *mcpServer) serve(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var req mcpRequest
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			m.write(mcpResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &mcpError{rpcParseError, err.Error()}})
+			continue
+		}
+		if resp := m.handle(req); resp != nil {
+			m.write(*resp)
+		}
+	}
+	return scanner.Err()
+}
+
+// write sends one message
+func (m *mcpServer) write(resp mcpResponse) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	_, _ = m.out.Write(append(data, '\n'))
+}
+
+// handle answers a request; notifications (no ID) get no response
+func (m *mcpServer) handle(req mcpRequest) *mcpResponse {
+	if len(req.ID) == 0 {
+		return nil
+	}
+	resp := &mcpResponse{JSONRPC: "2.0", ID: req.ID}
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := mcpProtocolVersions[0]
+		if slices.Contains(mcpProtocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		resp.Result = map[string]interface{}{
+			"protocolVersion": version,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "agent-deck", "version": Version},
+		}
+	case "ping":
+		resp.Result = map[string]interface{}{}
+	case "tools/list":
+		resp.Result = map[string]interface{}{"tools": mcpTools}
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			resp.Error = &mcpError{rpcInvalidParams, err.Error()}
+			break
+		}
+		text, err := m.callTool(params.Name, params.Arguments)
+		if errors.Is(err, errUnknownTool) {
+			resp.Error = &mcpError{rpcInvalidParams, err.Error()}
+			break
+		}
+		// Tool failures are results the agent can read and act on
+		result := mcpToolResult{Content: []mcpContent{{Type: "text", Text: text}}}
+		if err != nil {
+			result = mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}
+		}
+		resp.Result = result
+	default:
+		resp.Error = &mcpError{rpcMethodNotFound, fmt.Sprintf("method not found: %s", req.Method)}
+	}
+	return resp
+}
+
+var errUnknownTool = errors.New("unknown tool")
+
+// mcpTools are the tools the server offers
+var mcpTools = []mcpTool{
+	{
+		Name:        "list_sessions",
+		Description: "List the agent-deck sessions with their ID, title, project path, group, tool and status (running, waiting, idle, error). The session you run in is marked current.",
+		InputSchema: mcpSchema(map[string]interface{}{
+			"group": mcpString("Only sessions in this group and its subgroups"),
+		}),
+	},
+	{
+		Name:        "create_session",
+		Description: "Create a new agent-deck session and start it, optionally with a first prompt that is sent once the agent is ready. Defaults to the project and group of the session you run in, so it becomes a sibling.",
+		InputSchema: mcpSchema(map[string]interface{}{
+			"path":   mcpString("Absolute project directory (default: your session's)"),
+			"title":  mcpString("Session title (default: the folder name)"),
+			"group":  mcpString("Group path (default: your session's)"),
+			"tool":   mcpString("Command or tool to run: claude, codex, gemini, opencode, a custom tool (default: the configured default tool)"),
+			"prompt": mcpString("First prompt for the agent"),
+			"start":  map[string]interface{}{"type": "boolean", "description": "Start the session now (default: true)"},
+		}),
+	},
+	{
+		Name:        "send_prompt",
+		Description: "Send a prompt to another running session and press Enter, waiting first until its agent is ready for input.",
+		InputSchema: mcpSchema(map[string]interface{}{
+			"session": mcpString("Session ID, ID prefix or title"),
+			"prompt":  mcpString("The prompt"),
+			"wait":    map[string]interface{}{"type": "boolean", "description": "Wait until the agent is ready first (default: true)"},
+		}, "session", "prompt"),
+	},
+	{
+		Name:        "get_session_output",
+		Description: "Get a session's status and its agent's last response, or with lines the last lines of its terminal.",
+		InputSchema: mcpSchema(map[string]interface{}{
+			"session": mcpString("Session ID, ID prefix or title"),
+			"lines":   map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Last lines of the terminal instead of the last response (max %d)", mcpOutputMaxLines)},
+		}, "session"),
+	},
+}
+
+// mcpSchema is an object schema with properties, required ones listed
+func mcpSchema(properties map[string]interface{}, required ...string) map[string]interface{} {
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// mcpString is a string property
+func mcpString(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description}
+}
+
+// callTool runs a tool and returns its text result
+func (m *mcpServer) callTool(name string, arguments json.RawMessage) (string, error) {
+	if len(arguments) == 0 {
+		arguments = json.RawMessage("{}")
+	}
+	switch name {
+	case "list_sessions":
+		var args struct {
+			Group string `json:"group"`
+		}
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return "", err
+		}
+		return m.listSessions(args.Group)
+	case "create_session":
+		args := struct {
+			Path   string `json:"path"`
+			Title  string `json:"title"`
+			Group  string `json:"group"`
+			Tool   string `json:"tool"`
+			Prompt string `json:"prompt"`
+			Start  *bool  `json:"start"`
+		}{}
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return "", err
+		}
+		return m.createSession(apiAddRequest{
+			Path:    args.Path,
+			Title:   args.Title,
+			Group:   args.Group,
+			Command: args.Tool,
+			Prompt:  args.Prompt,
+			Start:   args.Start == nil || *args.Start,
+		})
+	case "send_prompt":
+		var args struct {
+			Session string `json:"session"`
+			Prompt  string `json:"prompt"`
+			Wait    *bool  `json:"wait"`
+		}
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return "", err
+		}
+		return m.sendPrompt(args.Session, args.Prompt, args.Wait == nil || *args.Wait)
+	case "get_session_output":
+		var args struct {
+			Session string `json:"session"`
+			Lines   int    `json:"lines"`
+		}
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return "", err
+		}
+		return m.sessionOutput(args.Session, args.Lines)
+	}
+	return "", fmt.Errorf("%w: %s", errUnknownTool, name)
+}
+
+// resolve loads the sessions and finds one by ID, ID prefix or title
+func (m *mcpServer) resolve(identifier string) (*session.Instance, []*session.Instance, error) {
+	instances, _, err := m.storage.LoadWithGroups()
+	if err != nil {
+		return nil, nil, err
+	}
+	inst, errMsg, _ := ResolveSession(identifier, instances)
+	if inst == nil {
+		return nil, nil, errors.New(errMsg)
+	}
+	return inst, instances, nil
+}
+
+// mcpJSON formats a tool result as indented JSON
+func mcpJSON(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	return string(data), err
+}
+
+// listSessions runs list_sessions
+func (m *mcpServer) listSessions(group string) (string, error) {
+	var instances []*session.Instance
+	var err error
+	if group != "" {
+		instances, err = m.storage.LoadGroup(group)
+	} else {
+		instances, _, err = m.storage.LoadWithGroups()
+	}
+	if err != nil {
+		return "", err
+	}
+	type mcpSession struct {
+		apiSession
+		Current bool `json:"current,omitempty"`
+	}
+	tmux.RefreshSessionCache()
+	sessions := []mcpSession{}
+	for _, inst := range instances {
+		_ = inst.UpdateStatus()
+		sessions = append(sessions, mcpSession{toAPISession(inst), inst.ID == m.currentID})
+	}
+	return mcpJSON(sessions)
+}
+
+// createSession runs create_session, defaulting the project and group to
+// the current session's
+func (m *mcpServer) createSession(req apiAddRequest) (string, error) {
+	if m.currentID != "" && (req.Path == "" || req.Group == "") {
+		if current, _, err := m.resolve(m.currentID); err == nil {
+			if req.Path == "" {
+				req.Path = current.ProjectPath
+			}
+			if req.Group == "" {
+				req.Group = current.GroupPath
+			}
+		}
+	}
+	if req.Path == "" {
+		return "", errors.New("path is required outside an agent-deck session")
+	}
+	inst, apiErr := addRequestedSession(m.storage, req)
+	if apiErr != nil {
+		return "", errors.New(apiErr.message)
+	}
+	return mcpJSON(toAPISession(inst))
+}
+
+// sendPrompt runs send_prompt
+func (m *mcpServer) sendPrompt(identifier, prompt string, wait bool) (string, error) {
+	if strings.TrimSpace(prompt) == "" {
+		return "", errors.New("prompt is empty")
+	}
+	inst, instances, err := m.resolve(identifier)
+	if err != nil {
+		return "", err
+	}
+	if inst.ID == m.currentID {
+		return "", errors.New("that's your own session; send prompts to other sessions")
+	}
+	if _, err := sendPrompt(m.storage, instances, inst, prompt, wait); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Sent the prompt to '%s' (%s)", inst.Title, inst.ID), nil
+}
+
+// sessionOutput runs get_session_output
+func (m *mcpServer) sessionOutput(identifier string, lines int) (string, error) {
+	inst, _, err := m.resolve(identifier)
+	if err != nil {
+		return "", err
+	}
+	_ = inst.UpdateStatus()
+	header := fmt.Sprintf("Session: %s (%s)\nStatus: %s\n---\n", inst.Title, inst.Tool, StatusString(inst.Status))
+
+	if lines <= 0 {
+		if response, err := inst.GetLastResponse(); err == nil && response.Content != "" {
+			return header + response.Content, nil
+		}
+		// Tools without a readable transcript: show the screen instead
+		lines = 50
+	}
+	tmuxSess := inst.GetTmuxSession()
+	if tmuxSess == nil || !inst.Exists() {
+		return "", fmt.Errorf("session '%s' is not running and has no response to show", inst.Title)
+	}
+	content, err := tmuxSess.CaptureFullHistory()
+	if err != nil {
+		return "", err
+	}
+	all := strings.Split(strings.TrimRight(content, "\n "), "\n")
+	lines = min(lines, mcpOutputMaxLines)
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return header + strings.Join(all, "\n"), nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// runMCP feeds lines to a fresh server and returns its responses
+func runMCP(t *testing.T, lines ...string) []mcpResponse {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	storage, err := session.NewStorageWithProfile("_test")
+	if err != nil {
+		t.Fatalf("storage: %v", err)
+	}
+	t.Cleanup(func() { _ = storage.Close() })
+
+	var out bytes.Buffer
+	server := &mcpServer{storage: storage, out: &out}
+	if err := server.serve(strings.NewReader(strings.Join(lines, "\n"))); err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	var responses []mcpResponse
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var resp mcpResponse
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("response %q: %v", line, err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// toolResult decodes a tools/call result
+func toolResult(t *testing.T, resp mcpResponse) mcpToolResult {
+	t.Helper()
+	if resp.Error != nil {
+		t.Fatalf("id %s: error %+v", resp.ID, resp.Error)
+	}
+	data, _ := json.Marshal(resp.Result)
+	var result mcpToolResult
+	if err := json.Unmarshal(data, &result); err != nil || len(result.Content) != 1 {
+		t.Fatalf("id %s: result %s", resp.ID, data)
+	}
+	return result
+}
+
+func TestMCPServeProtocol(t *testing.T) {
+	responses := runMCP(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+		`not json`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"nope"}}`,
+	)
+	if len(responses) != 5 {
+		t.Fatalf("got %d responses, want 5 (none for the notification)", len(responses))
+	}
+
+	init := responses[0].Result.(map[string]interface{})
+	if init["protocolVersion"] != "2025-03-26" {
+		t.Errorf("protocolVersion = %v, want the client's", init["protocolVersion"])
+	}
+	tools := responses[1].Result.(map[string]interface{})["tools"].([]interface{})
+	if len(tools) != len(mcpTools) || len(tools) != 4 {
+		t.Errorf("tools/list = %d tools, want 4", len(tools))
+	}
+	if responses[2].Error == nil || responses[2].Error.Code != rpcMethodNotFound {
+		t.Errorf("unknown method = %+v, want %d", responses[2].Error, rpcMethodNotFound)
+	}
+	if responses[3].Error == nil || responses[3].Error.Code != rpcParseError {
+		t.Errorf("bad JSON = %+v, want %d", responses[3].Error, rpcParseError)
+	}
+	if responses[4].Error == nil || responses[4].Error.Code != rpcInvalidParams {
+		t.Errorf("unknown tool = %+v, want %d", responses[4].Error, rpcInvalidParams)
+	}
+}
+
+func TestMCPServeTools(t *testing.T) {
+	project := t.TempDir()
+	responses := runMCP(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"create_session","arguments":{"path":"`+project+`","title":"worker","group":"team","tool":"claude","start":false}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"list_sessions","arguments":{"group":"team"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"list_sessions","arguments":{"group":"other"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"send_prompt","arguments":{"session":"worker","prompt":"hi"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"create_session","arguments":{"title":"no-path"}}}`,
+	)
+	if len(responses) != 5 {
+		t.Fatalf("got %d responses, want 5", len(responses))
+	}
+
+	var created apiSession
+	if result := toolResult(t, responses[0]); result.IsError || json.Unmarshal([]byte(result.Content[0].Text), &created) != nil {
+		t.Fatalf("create_session = %+v", result)
+	}
+	if created.Title != "worker" || created.Group != "team" || created.Path != project {
+		t.Errorf("created = %+v", created)
+	}
+
+	var listed []apiSession
+	if err := json.Unmarshal([]byte(toolResult(t, responses[1]).Content[0].Text), &listed); err != nil || len(listed) != 1 || listed[0].ID != created.ID {
+		t.Errorf("list_sessions(team) = %+v", listed)
+	}
+	if text := toolResult(t, responses[2]).Content[0].Text; text != "[]" {
+		t.Errorf("list_sessions(other) = %s, want []", text)
+	}
+
+	if result := toolResult(t, responses[3]); !result.IsError {
+		t.Errorf("send_prompt to a stopped session = %+v, want an error result", result)
+	}
+	if result := toolResult(t, responses[4]); !result.IsError || !strings.Contains(result.Content[0].Text, "path is required") {
+		t.Errorf("create_session without path = %+v", result)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleNew creates a project group from a config-defined scaffold
+func handleNew(profile string, args []string) {
+	fs := flag.NewFlagSet("new", flag.ExitOnError)
+	scaffold := fs.String("scaffold", "", "Scaffold from [scaffolds.<name>] in config.toml")
+	scaffoldShort := fs.String("s", "", "Scaffold name (short)")
+	group := fs.String("group", "", "Group path to create (default: scaffold group + directory name)")
+	groupShort := fs.String("g", "", "Group path (short)")
+	list := fs.Bool("list", false, "List available scaffolds")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck new --scaffold <name> [dir] [options]")
+		fmt.Println()
+		fmt.Println("Create a group with the predefined sessions of a scaffold, each pointed at")
+		fmt.Println("its subdirectory of dir (default: current directory). Missing directories")
+		fmt.Println("are created. Define scaffolds under [scaffolds.<name>] in config.toml.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck new --scaffold fullstack ~/src/shop")
+		fmt.Println("  agent-deck new -s fullstack -g clients/acme .")
+		fmt.Println("  agent-deck new --list")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	name := mergeFlags(*scaffold, *scaffoldShort)
+
+	if *list {
+		printScaffolds(out)
+		return
+	}
+	if name == "" {
+		out.Error("--scaffold is required (use 'agent-deck add' for a single session)", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	def := session.GetScaffold(name)
+	if def == nil {
+		msg := fmt.Sprintf("scaffold '%s' not found in config.toml", name)
+		if names := session.GetScaffoldNames(); len(names) > 0 {
+			msg += fmt.Sprintf(" (available: %s)", strings.Join(names, ", "))
+		}
+		out.Error(msg, ErrCodeNotFound)
+		os.Exit(2)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+	if err := def.Validate(); err != nil {
+		out.Error(fmt.Sprintf("scaffold '%s': %v", name, err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	dir := strings.Trim(fs.Arg(0), "'\"")
+	if dir == "" {
+		dir = "."
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to resolve path: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	groupTree := session.NewGroupTreeWithGroups(instances, groups)
+
+	groupPath := normalizeGroupPath(mergeFlags(*group, *groupShort))
+	if groupPath == "" {
+		groupPath = def.GroupPath(dir)
+	}
+	projectGroup := groupTree.CreateGroupPath(groupPath)
+	if projectGroup == nil {
+		out.Error(fmt.Sprintf("invalid group path '%s'", groupPath), ErrCodeInvalidOperation)
+		os.Exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	created := make([]*session.Instance, 0, len(def.Sessions))
+	for _, s := range def.Sessions {
+		path := filepath.Join(dir, s.Path)
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			out.Error(fmt.Sprintf("failed to create %s: %v", path, err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+
+		inst := session.NewInstanceWithGroup(generateUniqueTitle(instances, s.Title, path), path, projectGroup.Path)
+		if s.Command != "" && s.Command != "shell" {
+			applySessionCommand(inst, s.Command)
+		}
+		instances = append(instances, inst)
+		groupTree.AddSession(inst)
+		created = append(created, inst)
+	}
+
+	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+		out.Error(fmt.Sprintf("failed to save sessions: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Created %s from scaffold '%s' (%d sessions):\n", projectGroup.Path, name, len(created))
+	sessionsJSON := make([]map[string]interface{}, 0, len(created))
+	for _, inst := range created {
+		fmt.Fprintf(&sb, "  %s %-16s %-8s %s\n", bulletSymbol, inst.Title, inst.Tool, inst.ProjectPath)
+		sessionsJSON = append(sessionsJSON, map[string]interface{}{
+			"id":    inst.ID,
+			"title": inst.Title,
+			"tool":  inst.Tool,
+			"path":  inst.ProjectPath,
+		})
+	}
+	sb.WriteString("\nOpen the TUI and press Enter on a session to start it.\n")
+
+	out.Print(sb.String(), map[string]interface{}{
+		"success":  true,
+		"scaffold": name,
+		"group":    projectGroup.Path,
+		"path":     dir,
+		"sessions": sessionsJSON,
+		"profile":  storage.Profile(),
+	})
+}
+
+// printScaffolds lists the scaffolds defined in config.toml
+func printScaffolds(out *CLIOutput) {
+	names := session.GetScaffoldNames()
+	scaffolds := make([]map[string]interface{}, 0, len(names))
+	var sb strings.Builder
+	if len(names) == 0 {
+		sb.WriteString("No scaffolds defined. Add [scaffolds.<name>] to config.toml.\n")
+	} else {
+		sb.WriteString("Scaffolds:\n")
+	}
+	for _, name := range names {
+		def := session.GetScaffold(name)
+		titles := make([]string, 0, len(def.Sessions))
+		for _, s := range def.Sessions {
+			titles = append(titles, s.Title)
+		}
+		fmt.Fprintf(&sb, "  %-16s %s\n", name, def.Description)
+		fmt.Fprintf(&sb, "  %-16s sessions: %s\n", "", strings.Join(titles, ", "))
+		scaffolds = append(scaffolds, map[string]interface{}{
+			"name":        name,
+			"description": def.Description,
+			"group":       def.Group,
+			"sessions":    titles,
+		})
+	}
+	out.Print(sb.String(), map[string]interface{}{"scaffolds": scaffolds})
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// maxHookPayload caps how much hook JSON is read from stdin
+const maxHookPayload = 1 << 20
+
+// handleNotify pushes a status (and optional message) for a session to running TUIs
+func handleNotify(profile string, args []string) {
+	fs := flag.NewFlagSet("notify", flag.ExitOnError)
+	sessionFlag := fs.String("session", "", "Session ID or title (default: current session)")
+	sessionShort := fs.String("s", "", "Session ID or title (short)")
+	statusFlag := fs.String("status", "", "Status to report: running, waiting, idle")
+	message := fs.String("message", "", "Status text to show next to the session's status icon")
+	messageShort := fs.String("m", "", "Status text (short)")
+	clearText := fs.Bool("clear", false, "Clear the session's status text")
+	hookMode := fs.Bool("hook", false, "Read a Claude Code hook event from stdin (used by 'agent-deck hook install')")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck notify [id|title] [options]")
+		fmt.Println()
+		fmt.Println("Report a session's status to running agent-deck TUIs instantly.")
+		fmt.Println("The reported status overrides output-based detection until the")
+		fmt.Println("session shows new activity. Use from hooks or scripts.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck notify --session my-project --status waiting --message \"needs approval\"")
+		fmt.Println("  agent-deck notify --status running              # Inside an agent-deck session")
+		fmt.Println("  agent-deck notify -m \"blocked on API key\"       # Status text only, keep status")
+		fmt.Println("  agent-deck notify --clear                       # Remove status text")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	if *hookMode {
+		handleNotifyHook(profile)
+		return
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	identifier := mergeFlags(*sessionFlag, *sessionShort)
+	if identifier == "" {
+		identifier = fs.Arg(0)
+	}
+	msg := mergeFlags(*message, *messageShort)
+
+	var status session.Status
+	if *statusFlag != "" {
+		var err error
+		if status, err = session.ParseReportStatus(*statusFlag); err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	} else if msg == "" && !*clearText {
+		out.Error("--status, --message or --clear is required", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	storage, instances, groupsData, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	inst, errMsg, errCode := ResolveSessionOrCurrent(identifier, instances)
+	if inst == nil {
+		out.Error(errMsg, errCode)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	report := session.StatusReport{SessionID: inst.ID, Status: status, Message: msg, Clear: *clearText}
+	delivered, err := session.SendStatusReport(storage.Profile(), report)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to send status report: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	if delivered == 0 {
+		if err := saveStatusText(storage, instances, groupsData, inst, report); err != nil {
+			out.Error(fmt.Sprintf("failed to save status text: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	}
+
+	human := fmt.Sprintf("Reported '%s' to %d running agent-deck instance(s)", inst.Title, delivered)
+	if delivered == 0 {
+		human = fmt.Sprintf("No running agent-deck TUI for profile '%s'; status text saved for '%s'", storage.Profile(), inst.Title)
+	}
+	out.Success(human, map[string]interface{}{
+		"success":   true,
+		"id":        inst.ID,
+		"title":     inst.Title,
+		"status":    string(status),
+		"message":   msg,
+		"delivered": delivered,
+	})
+}
+
+// handleNotifyHook handles `notify --hook`, run by Claude Code hooks. It maps the
+// hook event on stdin to a status for the session in AGENTDECK_INSTANCE_ID (or the
+// current tmux session). Always exits 0 so a missing TUI never disturbs Claude.
+func handleNotifyHook(profile string) {
+	data, err := io.ReadAll(io.LimitReader(os.Stdin, maxHookPayload))
+	if err != nil {
+		return
+	}
+	report, ok, err := session.ParseClaudeHookPayload(data)
+	if err != nil || !ok {
+		return
+	}
+
+	identifier := os.Getenv("AGENTDECK_INSTANCE_ID")
+	if identifier == "" {
+		identifier = GetCurrentSessionID()
+	}
+	if identifier == "" {
+		return // Claude running outside agent-deck
+	}
+
+	// Hooks don't know the profile: try the explicit/effective one, then the rest
+	profiles := []string{session.GetEffectiveProfile(profile)}
+	if profile == "" {
+		if all, err := session.ListProfiles(); err == nil {
+			profiles = append(profiles, all...)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, p := range profiles {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		storage, instances, groupsData, err := loadSessionData(p)
+		if err != nil {
+			continue
+		}
+		inst, _, _ := ResolveSession(identifier, instances)
+		if inst == nil {
+			continue
+		}
+		report.SessionID = inst.ID
+		if delivered, _ := session.SendStatusReport(storage.Profile(), report); delivered == 0 {
+			_ = saveStatusText(storage, instances, groupsData, inst, report)
+		}
+		return
+	}
+}
+
+// saveStatusText persists the report's status text change when no TUI received it
+// (a running TUI applies and saves it itself)
+func saveStatusText(storage *session.Storage, instances []*session.Instance, groupsData []*session.GroupData, inst *session.Instance, report session.StatusReport) error {
+	report.Status = "" // Status is live state, only the text persists
+	if !inst.ApplyStatusReport(report) {
+		return nil
+	}
+	return storage.SaveWithGroups(instances, session.NewGroupTreeWithGroups(instances, groupsData))
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/terminal"
+)
+
+// quicklist is `list --quicklist` output for launchers. It's the shape of an
+// Alfred Script Filter, which Raycast script commands can read as well.
+type quicklist struct {
+	Items []quicklistItem `json:"items"`
+}
+
+// quicklistItem is one session in a launcher's result list
+type quicklistItem struct {
+	UID          string            `json:"uid"`
+	Title        string            `json:"title"`
+	Subtitle     string            `json:"subtitle"`
+	Arg          string            `json:"arg"` // shell command that attaches to the session
+	Match        string            `json:"match"`
+	Autocomplete string            `json:"autocomplete"`
+	Icon         quicklistIcon     `json:"icon"`
+	Emoji        string            `json:"emoji"` // the tool's icon in the TUI, for launchers without image icons
+	Text         map[string]string `json:"text"`
+	Variables    map[string]string `json:"variables"`
+}
+
+// quicklistIcon points at an image per tool, relative to the launcher's
+// workflow or extension directory (icons/claude.png, icons/shell.png, ...)
+type quicklistIcon struct {
+	Path string `json:"path"`
+}
+
+// quicklistStatusOrder puts sessions that need attention first
+var quicklistStatusOrder = map[session.Status]int{
+	session.StatusWaiting: 0,
+	session.StatusRunning: 1,
+	session.StatusIdle:    2,
+	session.StatusError:   3,
+}
+
+// buildQuicklist makes launcher items for sessions, keyed by profile, with
+// exe as the agent-deck binary in attach commands. Waiting sessions come
+// first, then running, idle and errored ones, each by title.
+func buildQuicklist(exe string, sessions map[string][]*session.Instance) quicklist {
+	type entry struct {
+		profile string
+		inst    *session.Instance
+	}
+	var entries []entry
+	for profile, instances := range sessions {
+		for _, inst := range instances {
+			entries = append(entries, entry{profile, inst})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].inst, entries[j].inst
+		if oa, ob := quicklistStatusOrder[a.Status], quicklistStatusOrder[b.Status]; oa != ob {
+			return oa < ob
+		}
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return entries[i].profile < entries[j].profile
+	})
+
+	home, _ := os.UserHomeDir()
+	ql := quicklist{Items: make([]quicklistItem, 0, len(entries))}
+	for _, e := range entries {
+		inst := e.inst
+		tool := inst.Tool
+		if tool == "" {
+			tool = "shell"
+		}
+		path := inst.ProjectPath
+		if home != "" && strings.HasPrefix(path, home) {
+			path = "~" + path[len(home):]
+		}
+		subtitle := fmt.Sprintf("%s %s · %s · %s · %s",
+			StatusSymbol(inst.Status), StatusString(inst.Status), tool, inst.GroupPath, path)
+		if len(sessions) > 1 {
+			subtitle += " · " + e.profile
+		}
+		attach := fmt.Sprintf("%s -p %s session attach %s", terminal.ShellQuote(exe), terminal.ShellQuote(e.profile), inst.ID)
+
+		ql.Items = append(ql.Items, quicklistItem{
+			UID:          e.profile + "/" + inst.ID,
+			Title:        inst.Title,
+			Subtitle:     subtitle,
+			Arg:          attach,
+			Match:        strings.Join([]string{inst.Title, inst.GroupPath, tool, inst.ProjectPath}, " "),
+			Autocomplete: inst.Title,
+			Icon:         quicklistIcon{Path: "icons/" + tool + ".png"},
+			Emoji:        session.GetToolIcon(tool),
+			Text:         map[string]string{"copy": attach, "largetype": inst.Title},
+			Variables: map[string]string{
+				"session_id": inst.ID,
+				"profile":    e.profile,
+				"tool":       tool,
+				"status":     StatusString(inst.Status),
+				"path":       inst.ProjectPath,
+			},
+		})
+	}
+	return ql
+}
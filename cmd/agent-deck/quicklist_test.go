@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestBuildQuicklist(t *testing.T) {
+	sessions := map[string][]*session.Instance{
+		"default": {
+			{ID: "id-idle", Title: "api", Tool: "claude", GroupPath: "work", ProjectPath: "/srv/api", Status: session.StatusIdle},
+			{ID: "id-wait", Title: "web", Tool: "", GroupPath: "work", ProjectPath: "/srv/web", Status: session.StatusWaiting},
+		},
+		"client": {
+			{ID: "id-run", Title: "etl", Tool: "codex", GroupPath: "data", ProjectPath: "/srv/etl", Status: session.StatusRunning},
+		},
+	}
+
+	ql := buildQuicklist("/usr/local/bin/agent-deck", sessions)
+	if len(ql.Items) != 3 {
+		t.Fatalf("got %d items, want 3", len(ql.Items))
+	}
+	// Waiting first, then running, then idle
+	for i, want := range []string{"web", "etl", "api"} {
+		if ql.Items[i].Title != want {
+			t.Errorf("item %d = %q, want %q", i, ql.Items[i].Title, want)
+		}
+	}
+
+	web := ql.Items[0]
+	if web.Arg != "/usr/local/bin/agent-deck -p default session attach id-wait" {
+		t.Errorf("attach command = %q", web.Arg)
+	}
+	if web.Icon.Path != "icons/shell.png" || web.Variables["tool"] != "shell" {
+		t.Errorf("sessions without a tool should use the shell icon, got %+v", web.Icon)
+	}
+	if web.UID != "default/id-wait" || web.Variables["profile"] != "default" {
+		t.Errorf("uid = %q, profile = %q", web.UID, web.Variables["profile"])
+	}
+	if !strings.Contains(web.Subtitle, "waiting") || !strings.HasSuffix(web.Subtitle, "· default") {
+		t.Errorf("subtitle should show status and, across profiles, the profile: %q", web.Subtitle)
+	}
+	if ql.Items[2].Icon.Path != "icons/claude.png" || ql.Items[2].Emoji == "" {
+		t.Errorf("claude icon = %+v, emoji %q", ql.Items[2].Icon, ql.Items[2].Emoji)
+	}
+}
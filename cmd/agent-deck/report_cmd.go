@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// reportDateLayout is the format of --from and --to
+const reportDateLayout = "2006-01-02"
+
+// reportRow is one session's line of a time and cost report
+type reportRow struct {
+	ID               string  `json:"id"`
+	Title            string  `json:"title"`
+	Group            string  `json:"group"`
+	Path             string  `json:"path"`
+	Tool             string  `json:"tool"`
+	AttachedHours    float64 `json:"attached_hours"`
+	RunningHours     float64 `json:"running_hours"`
+	InputTokens      int     `json:"input_tokens,omitempty"`
+	OutputTokens     int     `json:"output_tokens,omitempty"`
+	CacheReadTokens  int     `json:"cache_read_tokens,omitempty"`
+	CacheWriteTokens int     `json:"cache_write_tokens,omitempty"`
+	CostUSD          float64 `json:"estimated_cost_usd,omitempty"`
+}
+
+// handleReport exports per-session attached time, agent running time and,
+// for Claude sessions, token usage and estimated cost over a date range
+func handleReport(profile string, args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	from := fs.String("from", "", "First day to include (YYYY-MM-DD, default: all recorded history)")
+	to := fs.String("to", "", "Last day to include (YYYY-MM-DD, default: today)")
+	format := fs.String("format", "table", "Output format: table, csv or json")
+	group := fs.String("group", "", "Only sessions in this group (and its subgroups)")
+	output := fs.String("output", "", "Write to this file instead of stdout")
+	outputShort := fs.String("o", "", "Write to this file (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck report [options]")
+		fmt.Println()
+		fmt.Println("Report time and cost per session: time attached, time the agent was")
+		fmt.Println("running, and (for Claude) tokens and estimated cost. Time is recorded")
+		fmt.Println("while the TUI runs and on 'session attach'.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck report --from 2024-06-01 --format csv -o june.csv")
+		fmt.Println("  agent-deck report --from 2024-06-01 --to 2024-06-30 --group clients/acme")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	start, end, err := parseReportRange(*from, *to)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if *format != "table" && *format != "csv" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: invalid format %q (use table, csv or json)\n", *format)
+		os.Exit(1)
+	}
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to initialize storage: %v\n", err)
+		os.Exit(1)
+	}
+	// A group is looked up through the storage's group index
+	var instances []*session.Instance
+	if *group != "" {
+		instances, err = storage.LoadGroup(*group)
+	} else {
+		instances, _, err = storage.LoadWithGroups()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load sessions: %v\n", err)
+		os.Exit(1)
+	}
+	db := storage.GetDB()
+	if db == nil {
+		fmt.Fprintln(os.Stderr, "Error: no state database for this profile")
+		os.Exit(1)
+	}
+	activity, err := db.LoadActivity(start)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load activity: %v\n", err)
+		os.Exit(1)
+	}
+	totals := session.SumActivity(activity, start, end)
+
+	rows := buildReportRows(instances, totals, func(inst *session.Instance) *session.SessionAnalytics {
+		if inst.Tool != "claude" || inst.ClaudeSessionID == "" {
+			return nil
+		}
+		path := inst.GetJSONLPath()
+		if path == "" {
+			return nil
+		}
+		usage, err := session.ParseSessionJSONLRange(path, start, end)
+		if err != nil {
+			return nil
+		}
+		return usage
+	})
+
+	w := io.Writer(os.Stdout)
+	outPath := mergeFlags(*output, *outputShort)
+	if outPath != "" {
+		f, err := os.Create(outPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch *format {
+	case "csv":
+		err = writeReportCSV(w, rows)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(map[string]interface{}{
+			"profile":  storage.Profile(),
+			"from":     formatReportDay(start),
+			"to":       formatReportDay(end.Add(-time.Second)),
+			"sessions": rows,
+		})
+	default:
+		writeReportTable(w, rows)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if outPath != "" {
+		fmt.Printf("Wrote %d sessions to %s\n", len(rows), outPath)
+	}
+}
+
+// parseReportRange turns --from/--to days into [start, end): from midnight
+// of the first day to midnight after the last (local time)
+func parseReportRange(from, to string) (time.Time, time.Time, error) {
+	var start time.Time
+	if from != "" {
+		t, err := time.ParseInLocation(reportDateLayout, from, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --from %q (use YYYY-MM-DD)", from)
+		}
+		start = t
+	}
+	end := time.Now()
+	if to != "" {
+		t, err := time.ParseInLocation(reportDateLayout, to, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --to %q (use YYYY-MM-DD)", to)
+		}
+		end = t.AddDate(0, 0, 1)
+	}
+	if !start.IsZero() && !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--to is before --from")
+	}
+	return start, end, nil
+}
+
+// formatReportDay formats a range bound, "" for an open start
+func formatReportDay(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(reportDateLayout)
+}
+
+// buildReportRows makes a row per session with time or tokens in the range,
+// most running time first. usage returns a session's token usage in the
+// range, or nil when unknown.
+func buildReportRows(instances []*session.Instance, totals map[string]session.ActivityTotals, usage func(*session.Instance) *session.SessionAnalytics) []reportRow {
+	var rows []reportRow
+	overrides := session.GetPricingOverrides()
+	for _, inst := range instances {
+		t := totals[inst.ID]
+		row := reportRow{
+			ID:            inst.ID,
+			Title:         inst.Title,
+			Group:         inst.GroupPath,
+			Path:          inst.ProjectPath,
+			Tool:          inst.Tool,
+			AttachedHours: roundHours(t.Attached),
+			RunningHours:  roundHours(t.Running),
+		}
+		if u := usage(inst); u != nil {
+			row.InputTokens = u.InputTokens
+			row.OutputTokens = u.OutputTokens
+			row.CacheReadTokens = u.CacheReadTokens
+			row.CacheWriteTokens = u.CacheWriteTokens
+			model := u.Model
+			if model == "" {
+				model = inst.Model()
+			}
+			row.CostUSD = float64(int(u.CostWith(session.PricingFor(model, overrides))*100+0.5)) / 100
+		}
+		if t.Attached == 0 && t.Running == 0 && row.InputTokens+row.OutputTokens == 0 {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].RunningHours > rows[j].RunningHours })
+	return rows
+}
+
+// roundHours converts a duration to hours with two decimals, for billing
+func roundHours(d time.Duration) float64 {
+	return float64(int(d.Hours()*100+0.5)) / 100
+}
+
+// writeReportCSV writes the report as CSV with a header row
+func writeReportCSV(w io.Writer, rows []reportRow) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"id", "title", "group", "path", "tool", "attached_hours", "running_hours",
+		"input_tokens", "output_tokens", "cache_read_tokens", "cache_write_tokens", "estimated_cost_usd"})
+	for _, r := range rows {
+		_ = cw.Write([]string{
+			r.ID, r.Title, r.Group, r.Path, r.Tool,
+			strconv.FormatFloat(r.AttachedHours, 'f', 2, 64),
+			strconv.FormatFloat(r.RunningHours, 'f', 2, 64),
+			strconv.Itoa(r.InputTokens), strconv.Itoa(r.OutputTokens),
+			strconv.Itoa(r.CacheReadTokens), strconv.Itoa(r.CacheWriteTokens),
+			strconv.FormatFloat(r.CostUSD, 'f', 2, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeReportTable writes the report as an aligned table with totals
+func writeReportTable(w io.Writer, rows []reportRow) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No recorded time or token usage in this range.")
+		return
+	}
+	fmt.Fprintf(w, "%s %s %9s %9s %12s %9s\n", tableCell("TITLE", tableColTitle), tableCell("GROUP", tableColGroup),
+		"ATTACHED", "RUNNING", "TOKENS", "COST")
+	fmt.Fprintln(w, strings.Repeat("-", tableColTitle+tableColGroup+44))
+	var attached, running, cost float64
+	var tokens int
+	for _, r := range rows {
+		t := r.InputTokens + r.OutputTokens + r.CacheReadTokens + r.CacheWriteTokens
+		fmt.Fprintf(w, "%s %s %8.2fh %8.2fh %12d %9s\n", tableCell(r.Title, tableColTitle), tableCell(r.Group, tableColGroup),
+			r.AttachedHours, r.RunningHours, t, fmt.Sprintf("$%.2f", r.CostUSD))
+		attached += r.AttachedHours
+		running += r.RunningHours
+		tokens += t
+		cost += r.CostUSD
+	}
+	fmt.Fprintln(w, strings.Repeat("-", tableColTitle+tableColGroup+44))
+	fmt.Fprintf(w, "%s %s %8.2fh %8.2fh %12d %9s\n", tableCell("Total", tableColTitle), tableCell("", tableColGroup),
+		attached, running, tokens, fmt.Sprintf("$%.2f", cost))
+}
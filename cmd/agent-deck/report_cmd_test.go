@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestParseReportRange(t *testing.T) {
+	start, end, err := parseReportRange("2024-06-01", "2024-06-30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := start.Format(reportDateLayout); got != "2024-06-01" {
+		t.Errorf("start = %s, want 2024-06-01", got)
+	}
+	// --to is inclusive: the range ends at midnight after it
+	if got := end.Format(reportDateLayout); got != "2024-07-01" {
+		t.Errorf("end = %s, want 2024-07-01", got)
+	}
+
+	if _, _, err := parseReportRange("2024-06-30", "2024-06-01"); err == nil {
+		t.Error("expected error for --to before --from")
+	}
+	if _, _, err := parseReportRange("June 1", ""); err == nil {
+		t.Error("expected error for invalid --from")
+	}
+}
+
+func TestBuildReportRows(t *testing.T) {
+	busy := &session.Instance{ID: "a", Title: "busy", Tool: "claude"}
+	idle := &session.Instance{ID: "b", Title: "idle", Tool: "shell"}
+	light := &session.Instance{ID: "c", Title: "light", Tool: "shell"}
+	totals := map[string]session.ActivityTotals{
+		"a": {Attached: 90 * time.Minute, Running: 3 * time.Hour},
+		"c": {Attached: 20 * time.Minute},
+	}
+	usage := func(inst *session.Instance) *session.SessionAnalytics {
+		if inst.ID == "a" {
+			return &session.SessionAnalytics{InputTokens: 1000, OutputTokens: 500, Model: "claude-opus-4-20250514"}
+		}
+		return nil
+	}
+
+	rows := buildReportRows([]*session.Instance{light, idle, busy}, totals, usage)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2 (idle session skipped)", len(rows))
+	}
+	if rows[0].ID != "a" || rows[0].RunningHours != 3 || rows[0].AttachedHours != 1.5 {
+		t.Errorf("first row = %+v, want busy with 3h running, 1.5h attached", rows[0])
+	}
+	if rows[0].InputTokens != 1000 || rows[0].OutputTokens != 500 {
+		t.Errorf("tokens = %d/%d, want 1000/500", rows[0].InputTokens, rows[0].OutputTokens)
+	}
+	// Priced as the transcript's model, not the Sonnet default
+	if rows[0].CostUSD != 0.05 {
+		t.Errorf("cost = %v, want 0.05 at Opus prices", rows[0].CostUSD)
+	}
+	if rows[1].AttachedHours != 0.33 {
+		t.Errorf("attached = %v, want 0.33", rows[1].AttachedHours)
+	}
+
+	var buf bytes.Buffer
+	if err := writeReportCSV(&buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "id,title,group") {
+		t.Fatalf("unexpected CSV:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[1], "a,busy,,,claude,1.50,3.00,1000,500,0,0,") {
+		t.Errorf("unexpected row: %s", lines[1])
+	}
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// Batch run outcomes
+const (
+	runDone    = "done"
+	runTimeout = "timeout"
+	runFailed  = "failed"
+)
+
+// runPollInterval is how often a batch run checks each session's status
+const runPollInterval = time.Second
+
+// runResult is what happened to one session in a batch run. Capture is
+// the final screen and DiffStat the changes in the project since the
+// session's run started, committed or not; Output holds the full scrollback.
+type runResult struct {
+	ID       string        `json:"id"`
+	Title    string        `json:"title"`
+	Group    string        `json:"group"`
+	Path     string        `json:"path"`
+	Outcome  string        `json:"outcome"`
+	Error    string        `json:"error,omitempty"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"-"`
+	Seconds  float64       `json:"seconds"`
+	Output   string        `json:"output,omitempty"`
+	Capture  string        `json:"capture,omitempty"`
+	DiffStat string        `json:"diff_stat,omitempty"`
+	Branch   string        `json:"branch,omitempty"`
+}
+
+// runOptions are the settings shared by every session in a batch run
+type runOptions struct {
+	id      string
+	prompt  string
+	timeout time.Duration
+	dir     string
+	sandbox bool
+}
+
+// handleRun starts every session in a group, sends each the same prompt,
+// waits for them to go idle and saves their output, for unattended batches
+func handleRun(profile string, args []string) {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	group := fs.String("group", "", "Group whose sessions to run (subgroups included)")
+	groupShort := fs.String("g", "", "Group (short)")
+	prompt := fs.String("prompt", "", "Prompt to send to every session")
+	promptFile := fs.String("prompt-file", "", "Read the prompt from this file")
+	timeout := fs.Duration("timeout", 30*time.Minute, "Give up on a session that hasn't gone idle after this long")
+	outputDir := fs.String("output-dir", "", "Directory for captured output (default ~/.agent-deck/runs/<timestamp>)")
+	outputShort := fs.String("o", "", "Output directory (short)")
+	sandbox := fs.Bool("sandbox", false, "Work in a new worktree and branch per session and commit the changes there")
+	bulk := session.GetBulkStartSettings()
+	parallel := fs.Int("parallel", bulk.GetMaxConcurrent(), "Sessions working at once, 0 for all ([bulk_start] max_concurrent)")
+	stagger := fs.Duration("stagger", bulk.GetStagger(), "Wait between starting sessions ([bulk_start] stagger_ms)")
+	jsonOutput := fs.Bool("json", false, "Output the summary as JSON")
+	quiet := fs.Bool("quiet", false, "Only print the summary")
+	quietShort := fs.Bool("q", false, "Only print the summary (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck run --group <group> (--prompt <text> | --prompt-file <file>) [options]")
+		fmt.Println()
+		fmt.Println("Start every session in a group, send it the prompt, wait until it goes")
+		fmt.Println("idle or the timeout passes, and save its scrollback to a file. Sessions")
+		fmt.Println("beyond --parallel wait for a free slot. Exits 1 if any session timed")
+		fmt.Println("out or failed.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck run --group nightly --prompt-file fix-lint.md --timeout 30m")
+		fmt.Println("  agent-deck run -g work/api --prompt \"Run the tests and fix failures\" -o results/")
+		fmt.Println("  agent-deck run --group nightly --prompt-file upgrade-deps.md --sandbox")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	progress := !*jsonOutput && !*quiet && !*quietShort
+
+	groupName := mergeFlags(*group, *groupShort)
+	if groupName == "" {
+		out.Error("--group is required", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	text := *prompt
+	if *promptFile != "" {
+		if text != "" {
+			out.Error("use either --prompt or --prompt-file", ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		data, err := os.ReadFile(*promptFile)
+		if err != nil {
+			out.Error(fmt.Sprintf("failed to read prompt: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		text = strings.TrimSpace(string(data))
+	}
+	if text == "" {
+		out.Error("--prompt or --prompt-file is required", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	storage, instances, groupsData, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	groupPath, ok := resolveGroupPath(session.NewGroupTreeWithGroups(instances, groupsData), groupName)
+	if !ok {
+		out.Error(fmt.Sprintf("group '%s' not found", groupName), ErrCodeNotFound)
+		os.Exit(2)
+	}
+	targets := groupSessions(instances, groupPath)
+	if len(targets) == 0 {
+		out.Error(fmt.Sprintf("group '%s' has no sessions", groupPath), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	started := time.Now()
+	opts := runOptions{
+		id:      started.Format("20060102-150405"),
+		prompt:  text,
+		timeout: *timeout,
+		sandbox: *sandbox,
+	}
+	dir := mergeFlags(*outputDir, *outputShort)
+	if dir == "" {
+		base, err := session.GetAgentDeckDir()
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		dir = filepath.Join(base, "runs", opts.id)
+	}
+	opts.dir = dir
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		out.Error(fmt.Sprintf("failed to create output directory: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	if progress {
+		fmt.Printf("Running %d session(s) in %s (timeout %s, %s at a time)\n",
+			len(targets), groupPath, *timeout, parallelLabel(*parallel, len(targets)))
+	}
+	tmux.RefreshSessionCache()
+	// Worktrees are created one at a time: git locks the repo while adding one
+	sandboxes := make([]*runSandbox, len(targets))
+	sandboxErrs := make(map[int]string)
+	if opts.sandbox {
+		for idx, inst := range targets {
+			sb, err := startSandbox(inst, opts.id)
+			if err != nil {
+				sandboxErrs[idx] = err.Error()
+				continue
+			}
+			sandboxes[idx] = sb
+			instances = append(instances, sb.inst)
+		}
+	}
+	results := make([]runResult, len(targets))
+	var printMu sync.Mutex
+	session.RunBulk(len(targets), *parallel, *stagger, func(idx int) {
+		inst := targets[idx]
+		if reason, ok := sandboxErrs[idx]; ok {
+			results[idx] = runResult{ID: inst.ID, Title: inst.Title, Group: inst.GroupPath,
+				Path: inst.ProjectPath, Outcome: runFailed, Error: reason, Started: time.Now()}
+		} else {
+			results[idx] = runSession(inst, opts, sandboxes[idx])
+		}
+		if progress {
+			printMu.Lock()
+			fmt.Printf("  %-7s %s (%s)\n", results[idx].Outcome, results[idx].Title, formatRunDuration(results[idx].Duration))
+			printMu.Unlock()
+		}
+	})
+
+	// Sessions started by the run keep their new tool session IDs, and
+	// sandbox sessions are added
+	if err := storage.SaveWithGroups(instances, session.NewGroupTreeWithGroups(instances, groupsData)); err != nil {
+		out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	report := newRunReport(opts.id, dir, groupPath, text, *timeout, started, results)
+	if err := report.write(dir); err != nil {
+		out.Error(fmt.Sprintf("failed to write report: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	var b strings.Builder
+	if progress {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%d done, %d timed out, %d failed in %s\n",
+		report.Done, report.TimedOut, report.Failed, formatRunDuration(report.Finished.Sub(started)))
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(&b, "  %s: %s\n", r.Title, r.Error)
+		}
+	}
+	fmt.Fprintf(&b, "Report: %s\n", filepath.Join(dir, runReportMarkdown))
+	out.Print(b.String(), report)
+	if report.Done != len(results) {
+		os.Exit(1)
+	}
+}
+
+// parallelLabel describes how many sessions of n run at once
+func parallelLabel(parallel, n int) string {
+	if parallel <= 0 || parallel >= n {
+		return "all"
+	}
+	return fmt.Sprint(parallel)
+}
+
+// groupSessions returns the sessions in groupPath and its subgroups, in deck
+// order
+func groupSessions(instances []*session.Instance, groupPath string) []*session.Instance {
+	var out []*session.Instance
+	for _, inst := range instances {
+		if inst.GroupPath == groupPath || strings.HasPrefix(inst.GroupPath, groupPath+"/") {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+// runSession drives one session through a batch run: start it if needed,
+// wait for the agent, send the prompt, wait for it to go idle, then save its
+// scrollback under the run's directory. With a sandbox, its session does the
+// work in a worktree of its own instead of inst.
+func runSession(inst *session.Instance, opts runOptions, sandbox *runSandbox) runResult {
+	start := time.Now()
+	if sandbox != nil {
+		inst = sandbox.inst
+	}
+	result := runResult{
+		ID:      inst.ID,
+		Title:   inst.Title,
+		Group:   inst.GroupPath,
+		Path:    inst.ProjectPath,
+		Outcome: runFailed,
+		Started: start,
+	}
+	if sandbox != nil {
+		result.Branch = sandbox.branch
+	}
+	finish := func() runResult {
+		if sandbox != nil {
+			message := fmt.Sprintf("agent-deck run %s: %s", opts.id, inst.Title)
+			if err := sandbox.finish(result.Outcome != runTimeout, message); err != nil {
+				if result.Error != "" {
+					result.Error += "; "
+				}
+				result.Error += err.Error()
+			}
+		}
+		result.Duration = time.Since(start)
+		result.Seconds = result.Duration.Round(time.Second).Seconds()
+		return result
+	}
+
+	// Changes are reported against where the project started, so work the
+	// agent commits counts too
+	inRepo := git.IsGitRepo(inst.ProjectPath)
+	baseCommit := ""
+	if inRepo {
+		baseCommit = git.HeadCommit(inst.ProjectPath)
+	}
+
+	if !inst.Exists() {
+		if err := inst.Start(); err != nil {
+			result.Error = fmt.Sprintf("failed to start: %v", err)
+			return finish()
+		}
+		inst.PostStartSync(3 * time.Second)
+		// A queued prompt goes first, so the run's prompt doesn't type over it
+		inst.WaitPendingPrompt()
+	}
+	tmuxSess := inst.GetTmuxSession()
+	if tmuxSess == nil {
+		result.Error = "could not determine tmux session"
+		return finish()
+	}
+	// A plain shell never shows an agent prompt to wait for
+	if inst.Tool != "" && inst.Tool != "shell" {
+		if err := waitForAgentReady(tmuxSess, inst.Tool); err != nil {
+			result.Error = err.Error()
+			return finish()
+		}
+	}
+	if !inst.WaitOutRateLimit(start.Add(opts.timeout)) {
+		until, _ := inst.RateLimitedUntil()
+		result.Error = fmt.Sprintf("rate limited until ~%s, past the timeout", session.RateLimitResetLabel(until, time.Now()))
+		return finish()
+	}
+	if err := sendWithRetry(tmuxSess, inst.ExpandPrompt(opts.prompt)); err != nil {
+		result.Error = err.Error()
+		return finish()
+	}
+
+	if waitForRunIdle(tmuxSess, start.Add(opts.timeout)) {
+		result.Outcome = runDone
+	} else {
+		result.Outcome = runTimeout
+		result.Error = fmt.Sprintf("still busy after %s", opts.timeout)
+	}
+
+	if inRepo {
+		result.DiffStat, _ = git.GetDiffStatSince(inst.ProjectPath, baseCommit)
+	}
+	if screen, err := inst.CaptureScrollback(false); err == nil {
+		result.Capture = strings.TrimRight(screen, "\n ")
+	}
+	content, err := inst.CaptureScrollback(true)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to capture output: %v", err)
+		return finish()
+	}
+	path := filepath.Join(opts.dir, runFileName(inst))
+	if err := session.WriteExport(path, content); err != nil {
+		result.Error = err.Error()
+		return finish()
+	}
+	result.Output = path
+	return finish()
+}
+
+// waitForRunIdle waits until the agent has worked on the prompt and stopped,
+// or deadline passes. It reports whether the agent finished. An agent that
+// finishes between polls is caught by a longer quiet period.
+func waitForRunIdle(tmuxSess *tmux.Session, deadline time.Time) bool {
+	sawActive := false
+	quiet := 0
+	for time.Now().Before(deadline) {
+		time.Sleep(runPollInterval)
+		status, err := tmuxSess.GetStatus()
+		switch {
+		case err != nil:
+			quiet = 0
+		case status == "active":
+			sawActive = true
+			quiet = 0
+		case status == "inactive":
+			return sawActive // the session exited
+		default:
+			quiet++
+		}
+		if (sawActive && quiet >= 3) || quiet >= 15 {
+			return true
+		}
+	}
+	return false
+}
+
+// runFileNamePattern matches characters kept out of output file names
+var runFileNamePattern = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// runFileName is the output file for a session: its title made file-name
+// safe plus a short ID so sessions with the same title don't collide
+func runFileName(inst *session.Instance) string {
+	name := strings.Trim(runFileNamePattern.ReplaceAllString(inst.Title, "-"), "-")
+	if name == "" {
+		name = "session"
+	}
+	id := inst.ID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	return name + "-" + id + ".txt"
+}
+
+// formatRunDuration formats a run duration to the second: "45s", "12m3s"
+func formatRunDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestGroupSessions(t *testing.T) {
+	a := session.NewInstance("a", "/tmp/a")
+	a.GroupPath = "nightly"
+	b := session.NewInstance("b", "/tmp/b")
+	b.GroupPath = "nightly/lint"
+	c := session.NewInstance("c", "/tmp/c")
+	c.GroupPath = "nightly-old"
+
+	got := groupSessions([]*session.Instance{a, b, c}, "nightly")
+	if len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("groupSessions = %v, want a and b", got)
+	}
+}
+
+func TestRunFileName(t *testing.T) {
+	inst := session.NewInstance("fix: lint / api", "/tmp/api")
+	inst.ID = "0123456789abcdef"
+	if got, want := runFileName(inst), "fix-lint-api-01234567.txt"; got != want {
+		t.Errorf("runFileName = %q, want %q", got, want)
+	}
+	inst.Title = "???"
+	if got, want := runFileName(inst), "session-01234567.txt"; got != want {
+		t.Errorf("runFileName = %q, want %q", got, want)
+	}
+}
+
+func TestRunReportMarkdown(t *testing.T) {
+	started := time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC)
+	results := []runResult{
+		{Title: "api", Outcome: runDone, Duration: 90 * time.Second, Output: "/runs/x/api-1.txt",
+			DiffStat: " main.go | 2 +-", Capture: "all green"},
+		{Title: "web|ui", Outcome: runTimeout, Duration: 30 * time.Minute, Error: "still busy after 30m0s"},
+		{Title: "cli", Outcome: runFailed, Error: "failed to start: boom"},
+	}
+	report := newRunReport("20240601-020000", "/runs/20240601-020000", "nightly", "fix lint", 30*time.Minute, started, results)
+	if report.ID != "20240601-020000" || report.Done != 1 || report.TimedOut != 1 || report.Failed != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	md := report.Markdown()
+	for _, want := range []string{
+		"# Run 20240601-020000: nightly",
+		"1 done, 1 timed out, 1 failed",
+		"| api | done | 1m30s | [api-1.txt](api-1.txt) |",
+		`| web\|ui | timeout | 30m0s | - |`,
+		"failed in 0s: failed to start: boom",
+		"```\n main.go | 2 +-\n```",
+		"```\nall green\n```",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestFence(t *testing.T) {
+	if got := fence("a ``` b\n"); got != "````\na ``` b\n````\n" {
+		t.Errorf("fence = %q", got)
+	}
+}
+
+func TestRunSandbox(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@test.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@test.com")
+	for _, args := range [][]string{{"init", "-q"}, {"commit", "-q", "--allow-empty", "-m", "init"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+
+	inst := session.NewInstance("fix lint", dir)
+	inst.Tool, inst.Command = "claude", "claude"
+	branch := sandboxBranchName("20240601-020000", inst)
+	if branch != "agent-deck/run-20240601-020000/fix-lint" {
+		t.Errorf("branch = %q", branch)
+	}
+	// Uncommitted work in the main checkout stays there
+	if err := os.WriteFile(filepath.Join(dir, "wip.txt"), []byte("wip\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	original, _ := git.GetCurrentBranch(dir)
+	sb, err := startSandbox(inst, "20240601-020000")
+	if err != nil {
+		t.Fatalf("startSandbox: %v", err)
+	}
+	t.Cleanup(func() { _ = git.RemoveWorktree(dir, sb.path, true) })
+	if current, _ := git.GetCurrentBranch(dir); current != original {
+		t.Errorf("main checkout on %s, want %s", current, original)
+	}
+	if sb.branch != branch || sb.inst.ProjectPath != sb.path || sb.inst.WorktreeBranch != branch ||
+		sb.inst.Tool != "claude" || sb.inst.GroupPath != inst.GroupPath {
+		t.Errorf("sandbox session = %+v", sb.inst)
+	}
+	if current, _ := git.GetCurrentBranch(sb.path); current != branch {
+		t.Errorf("worktree on %s, want %s", current, branch)
+	}
+	if _, err := os.Stat(filepath.Join(sb.path, "wip.txt")); !os.IsNotExist(err) {
+		t.Error("uncommitted work was carried into the worktree")
+	}
+
+	if err := os.WriteFile(filepath.Join(sb.path, "fix.txt"), []byte("fixed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := sb.finish(true, "agent-deck run: fix lint"); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "fix.txt")); !os.IsNotExist(err) {
+		t.Error("the agent's change landed in the main checkout")
+	}
+	if git.ShowFile(dir, branch, "fix.txt") == nil {
+		t.Error("the agent's change was not committed to the sandbox branch")
+	}
+	if current, _ := git.GetCurrentBranch(dir); current != original {
+		t.Errorf("main checkout on %s after finish, want %s", current, original)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// Report files written to each run's output directory
+const (
+	runReportJSON     = "results.json"
+	runReportMarkdown = "results.md"
+)
+
+// runReport is the structured record of one batch run
+type runReport struct {
+	ID       string      `json:"id"`
+	Dir      string      `json:"dir"`
+	Group    string      `json:"group"`
+	Prompt   string      `json:"prompt"`
+	Started  time.Time   `json:"started"`
+	Finished time.Time   `json:"finished"`
+	Timeout  string      `json:"timeout"`
+	Done     int         `json:"done"`
+	TimedOut int         `json:"timed_out"`
+	Failed   int         `json:"failed"`
+	Sessions []runResult `json:"sessions"`
+}
+
+// newRunReport summarizes the results of a run
+func newRunReport(id, dir, group, prompt string, timeout time.Duration, started time.Time, results []runResult) *runReport {
+	r := &runReport{
+		ID:       id,
+		Dir:      dir,
+		Group:    group,
+		Prompt:   prompt,
+		Started:  started,
+		Finished: time.Now(),
+		Timeout:  timeout.String(),
+		Sessions: results,
+	}
+	for _, res := range results {
+		switch res.Outcome {
+		case runDone:
+			r.Done++
+		case runTimeout:
+			r.TimedOut++
+		default:
+			r.Failed++
+		}
+	}
+	return r
+}
+
+// write saves the report as JSON and markdown in dir
+func (r *runReport) write(dir string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := session.WriteExport(filepath.Join(dir, runReportJSON), string(data)+"\n"); err != nil {
+		return err
+	}
+	return session.WriteExport(filepath.Join(dir, runReportMarkdown), r.Markdown())
+}
+
+// Markdown renders the report: a summary table, then each session's status,
+// diff stat and final screen
+func (r *runReport) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Run %s: %s\n\n", r.ID, r.Group)
+	fmt.Fprintf(&b, "- Started: %s\n", r.Started.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "- Duration: %s (timeout %s)\n", formatRunDuration(r.Finished.Sub(r.Started)), r.Timeout)
+	fmt.Fprintf(&b, "- Result: %d done, %d timed out, %d failed\n\n", r.Done, r.TimedOut, r.Failed)
+	b.WriteString("## Prompt\n\n")
+	b.WriteString(fence(r.Prompt))
+
+	b.WriteString("\n## Sessions\n\n")
+	b.WriteString("| Session | Status | Duration | Output |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, s := range r.Sessions {
+		output := "-"
+		if s.Output != "" {
+			output = fmt.Sprintf("[%s](%s)", filepath.Base(s.Output), filepath.Base(s.Output))
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+			strings.ReplaceAll(s.Title, "|", `\|`), s.Outcome, formatRunDuration(s.Duration), output)
+	}
+
+	for _, s := range r.Sessions {
+		fmt.Fprintf(&b, "\n### %s\n\n", s.Title)
+		fmt.Fprintf(&b, "%s in %s", s.Outcome, formatRunDuration(s.Duration))
+		if s.Error != "" {
+			fmt.Fprintf(&b, ": %s", s.Error)
+		}
+		b.WriteString("\n")
+		if s.DiffStat != "" {
+			b.WriteString("\nChanges:\n\n")
+			b.WriteString(fence(s.DiffStat))
+		}
+		if s.Capture != "" {
+			b.WriteString("\nFinal screen:\n\n")
+			b.WriteString(fence(s.Capture))
+		}
+	}
+	return b.String()
+}
+
+// fence wraps text in a code block long enough not to be closed by any
+// backtick run inside it
+func fence(text string) string {
+	ticks := "```"
+	for strings.Contains(text, ticks) {
+		ticks += "`"
+	}
+	return ticks + "\n" + strings.TrimRight(text, "\n") + "\n" + ticks + "\n"
+}
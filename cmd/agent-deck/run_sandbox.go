@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// runSandbox is the worktree a session's work goes to during a run --sandbox,
+// and the session started in it. The main checkout is never touched.
+type runSandbox struct {
+	inst   *session.Instance
+	path   string
+	branch string
+}
+
+// sandboxBranchName is the branch for a session in a run:
+// agent-deck/run-<run id>/<title>
+func sandboxBranchName(runID string, inst *session.Instance) string {
+	name := git.SanitizeBranchName(inst.Title)
+	if name == "" {
+		name = inst.ID
+	}
+	return "agent-deck/run-" + runID + "/" + name
+}
+
+// startSandbox creates a worktree on a new branch from the repo's HEAD and a
+// session in it like inst (same tool, command and options), which does the
+// work instead of inst. The session stays in the deck for review, and
+// `agent-deck worktree finish` merges its branch.
+func startSandbox(inst *session.Instance, runID string) (*runSandbox, error) {
+	root, err := git.GetRepoRoot(inst.ProjectPath)
+	if err != nil {
+		return nil, fmt.Errorf("--sandbox: %s is not in a git repository", inst.ProjectPath)
+	}
+	if git.IsWorktree(root) {
+		if main, err := git.GetMainWorktreePath(root); err == nil {
+			root = main
+		}
+	}
+	branch := sandboxBranchName(runID, inst)
+	settings := session.GetWorktreeSettings()
+	path := git.WorktreePath(git.WorktreePathOptions{
+		Branch:    branch,
+		Location:  settings.DefaultLocation,
+		RepoDir:   root,
+		SessionID: git.GeneratePathID(),
+		Template:  settings.Template(),
+	})
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("--sandbox: worktree path already exists: %s", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("--sandbox: %w", err)
+	}
+	if err := git.CreateWorktree(root, path, branch); err != nil {
+		return nil, fmt.Errorf("--sandbox: %w", err)
+	}
+
+	sb := session.NewInstanceWithGroupAndTool(fmt.Sprintf("%s (run %s)", inst.Title, runID), path, inst.GroupPath, inst.Tool)
+	sb.Command = inst.Command
+	sb.Wrapper = inst.Wrapper
+	sb.ToolOptionsJSON = inst.ToolOptionsJSON
+	sb.YoloMode = inst.YoloMode
+	sb.GeminiYoloMode = inst.GeminiYoloMode
+	sb.GeminiModel = inst.GeminiModel
+	sb.Env = inst.Env
+	sb.ContextFiles = inst.ContextFiles
+	sb.WorktreePath = path
+	sb.WorktreeRepoRoot = root
+	sb.WorktreeBranch = branch
+	return &runSandbox{inst: sb, path: path, branch: branch}, nil
+}
+
+// finish commits the session's changes to the sandbox branch. A session
+// still busy (timed out) is left uncommitted, since it may still be editing.
+func (s *runSandbox) finish(done bool, message string) error {
+	if !done {
+		return fmt.Errorf("left uncommitted in %s while the agent is still busy", s.path)
+	}
+	_, err := git.CommitAll(s.path, message)
+	return err
+}
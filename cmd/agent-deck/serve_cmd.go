@@ -0,0 +1,561 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+	"github.com/asheshgoplani/agent-deck/internal/terminal"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// serveSocketName is the API socket in the profile directory
+const serveSocketName = "serve.sock"
+
+// handleServe serves a REST API for driving sessions (list, add, remove,
+// attach, status, send keys) on a unix socket or a localhost port
+func handleServe(profile string, args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	socket := fs.String("socket", "", "Unix socket to serve on (default: <profile dir>/serve.sock)")
+	listen := fs.String("listen", "", "Serve on this localhost address instead (e.g. 127.0.0.1:8421)")
+	token := fs.String("token", "", "Require this bearer token on every request (with --listen, one is generated when not given)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck serve [options]")
+		fmt.Println()
+		fmt.Println("Serve a JSON API for editor plugins and scripts until interrupted:")
+		fmt.Println("  GET    /sessions              list sessions with their status")
+		fmt.Println("  POST   /sessions              add a session {path, title, group, command, prompt, start}")
+		fmt.Println("  GET    /sessions/{id}         live status of one session (id, ID prefix or title)")
+		fmt.Println("  DELETE /sessions/{id}         remove a session (?keep_tmux=true leaves it running)")
+		fmt.Println("  GET    /sessions/{id}/attach  command that attaches to the session from a terminal")
+		fmt.Println("  POST   /sessions/{id}/keys    type into the session {text, enter}")
+		fmt.Println()
+		fmt.Println("Request bodies must be sent as application/json, and requests from web")
+		fmt.Println("pages (with an Origin header) are refused. On a --listen port every")
+		fmt.Println("request needs the bearer token, which is generated and printed unless")
+		fmt.Println("--token is given.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck serve")
+		fmt.Println("  curl --unix-socket ~/.agent-deck/profiles/default/serve.sock http://deck/sessions")
+		fmt.Println("  agent-deck serve --listen 127.0.0.1:8421 --token s3cret")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to initialize storage: %v\n", err)
+		os.Exit(1)
+	}
+	if db := storage.GetDB(); db != nil {
+		statedb.SetGlobal(db)
+	}
+
+	listener, addr, err := serveListener(storage.Profile(), *socket, *listen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	api := &apiServer{storage: storage, token: *token, tcp: *listen != ""}
+	if api.tcp && api.token == "" {
+		// Any local process or web page could reach an open port: never serve it without a token
+		if api.token, err = generateAPIToken(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Token (send as 'Authorization: Bearer <token>'): %s\n", api.token)
+	}
+	server := &http.Server{Handler: api.handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Serving the API for profile '%s' on %s (Ctrl+C to stop)\n", storage.Profile(), addr)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// serveListener listens on listen (a loopback address) if given, else on
+// the unix socket, replacing a stale one. Returns the listener and its
+// address for display.
+func serveListener(profile, socket, listen string) (net.Listener, string, error) {
+	if listen != "" {
+		listener, err := net.Listen("tcp", listen)
+		if err != nil {
+			return nil, "", err
+		}
+		// The API can type into sessions, so it must not be reachable from the network
+		if !isLoopbackAddr(listener.Addr()) {
+			listener.Close()
+			return nil, "", fmt.Errorf("--listen must be a localhost address (e.g. 127.0.0.1:8421), got %s", listen)
+		}
+		return listener, "http://" + listener.Addr().String(), nil
+	}
+
+	if socket == "" {
+		dir, err := session.GetProfileDir(profile)
+		if err != nil {
+			return nil, "", err
+		}
+		socket = filepath.Join(dir, serveSocketName)
+	}
+	if conn, err := net.Dial("unix", socket); err == nil {
+		conn.Close()
+		return nil, "", fmt.Errorf("agent-deck serve is already running on %s", socket)
+	}
+	_ = os.Remove(socket)
+	if err := os.MkdirAll(filepath.Dir(socket), 0o700); err != nil {
+		return nil, "", err
+	}
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := os.Chmod(socket, 0o600); err != nil {
+		listener.Close()
+		return nil, "", err
+	}
+	return listener, socket, nil
+}
+
+// apiServer serves a profile's sessions to agent-deck serve clients. Each
+// request loads the sessions fresh, so changes from the TUI and CLI show up
+// and saves merge with them.
+type apiServer struct {
+	storage *session.Storage
+	token   string
+	tcp     bool       // served on a loopback port rather than the unix socket
+	mu      sync.Mutex // one request at a time loads and saves
+}
+
+// generateAPIToken returns a random bearer token for a --listen port
+func generateAPIToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate a token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// apiSession is a session in API responses
+type apiSession struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Path      string    `json:"path"`
+	Group     string    `json:"group"`
+	Tool      string    `json:"tool"`
+	Command   string    `json:"command,omitempty"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// apiAddRequest is the body of POST /sessions
+type apiAddRequest struct {
+	Path    string `json:"path"`
+	Title   string `json:"title"`
+	Group   string `json:"group"`
+	Command string `json:"command"`
+	Prompt  string `json:"prompt"` // sent once the agent is ready
+	Start   bool   `json:"start"`
+}
+
+// apiKeysRequest is the body of POST /sessions/{id}/keys
+type apiKeysRequest struct {
+	Text  string `json:"text"`
+	Enter bool   `json:"enter"` // press Enter after the text (submit a prompt)
+}
+
+// handler routes the API behind checks that keep web pages out: browsers
+// can send "simple" cross-origin POSTs to localhost (and reach it by DNS
+// rebinding), so requests carrying an Origin, with a non-loopback Host on a
+// port, or with a body that isn't JSON are refused, as are requests without
+// the bearer token when one is set
+func (a *apiServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sessions", a.listSessions)
+	mux.HandleFunc("POST /sessions", a.addSession)
+	mux.HandleFunc("GET /sessions/{id}", a.sessionStatus)
+	mux.HandleFunc("DELETE /sessions/{id}", a.removeSession)
+	mux.HandleFunc("GET /sessions/{id}/attach", a.attachCommand)
+	mux.HandleFunc("POST /sessions/{id}/keys", a.sendKeys)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") != "" {
+			writeAPIError(w, http.StatusForbidden, "cross-origin requests are not allowed", ErrCodeInvalidOperation)
+			return
+		}
+		if a.tcp && !isLoopbackHost(r.Host) {
+			writeAPIError(w, http.StatusForbidden, fmt.Sprintf("host %q is not localhost", r.Host), ErrCodeInvalidOperation)
+			return
+		}
+		if a.token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+a.token)) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, "unauthorized", ErrCodeInvalidOperation)
+			return
+		}
+		if r.Method == http.MethodPost {
+			if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+				writeAPIError(w, http.StatusUnsupportedMediaType, "request body must be application/json", ErrCodeInvalidOperation)
+				return
+			}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// isLoopbackHost reports whether a request's Host names this machine:
+// localhost or a loopback IP, with or without a port
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// writeAPIJSON writes v as the JSON response
+func writeAPIJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// writeAPIError writes an error the way the CLI's --json output does
+func writeAPIError(w http.ResponseWriter, status int, message, code string) {
+	writeAPIJSON(w, status, map[string]interface{}{
+		"success": false,
+		"error":   message,
+		"code":    code,
+	})
+}
+
+// load loads the profile's sessions, writing an error response on failure
+func (a *apiServer) load(w http.ResponseWriter) ([]*session.Instance, []*session.GroupData, bool) {
+	instances, groups, err := a.storage.LoadWithGroups()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error(), ErrCodeInvalidOperation)
+		return nil, nil, false
+	}
+	return instances, groups, true
+}
+
+// resolve loads the sessions and finds the one named by the {id} path
+// value, writing an error response when there is none
+func (a *apiServer) resolve(w http.ResponseWriter, r *http.Request) (*session.Instance, []*session.Instance, []*session.GroupData, bool) {
+	instances, groups, ok := a.load(w)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	inst, errMsg, errCode := ResolveSession(r.PathValue("id"), instances)
+	if inst == nil {
+		status := http.StatusNotFound
+		if errCode == ErrCodeAmbiguous {
+			status = http.StatusConflict
+		}
+		writeAPIError(w, status, errMsg, errCode)
+		return nil, nil, nil, false
+	}
+	return inst, instances, groups, true
+}
+
+// toAPISession converts an instance whose status has been updated
+func toAPISession(inst *session.Instance) apiSession {
+	return apiSession{
+		ID:        inst.ID,
+		Title:     inst.Title,
+		Path:      inst.ProjectPath,
+		Group:     inst.GroupPath,
+		Tool:      inst.Tool,
+		Command:   inst.Command,
+		Status:    StatusString(inst.Status),
+		CreatedAt: inst.CreatedAt,
+	}
+}
+
+// listSessions serves GET /sessions
+func (a *apiServer) listSessions(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	instances, _, ok := a.load(w)
+	if !ok {
+		return
+	}
+	tmux.RefreshSessionCache()
+	sessions := make([]apiSession, len(instances))
+	for n, inst := range instances {
+		_ = inst.UpdateStatus()
+		sessions[n] = toAPISession(inst)
+	}
+	writeAPIJSON(w, http.StatusOK, map[string]interface{}{
+		"profile":  a.storage.Profile(),
+		"sessions": sessions,
+	})
+}
+
+// sessionStatus serves GET /sessions/{id} with what tmux reports right now
+func (a *apiServer) sessionStatus(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	inst, _, _, ok := a.resolve(w, r)
+	if !ok {
+		return
+	}
+	tmux.RefreshSessionCache()
+	_ = inst.UpdateStatus()
+	writeAPIJSON(w, http.StatusOK, sessionLiveStatus(inst))
+}
+
+// addSession serves POST /sessions: adds a session like agent-deck add, and
+// starts it when asked
+func (a *apiServer) addSession(w http.ResponseWriter, r *http.Request) {
+	var req apiAddRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err), ErrCodeInvalidOperation)
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	inst, apiErr := addRequestedSession(a.storage, req)
+	if apiErr != nil {
+		writeAPIError(w, apiErr.status, apiErr.message, apiErr.code)
+		return
+	}
+	writeAPIJSON(w, http.StatusCreated, toAPISession(inst))
+}
+
+// apiError is a request that failed: its HTTP status, the message and the
+// CLI's error code
+type apiError struct {
+	status  int
+	message string
+	code    string
+}
+
+// addRequestedSession adds a session like agent-deck add (for serve and
+// the MCP server) and starts it when asked, with the prompt queued
+func addRequestedSession(storage *session.Storage, req apiAddRequest) (*session.Instance, *apiError) {
+	path := filepath.Clean(req.Path)
+	if !filepath.IsAbs(path) {
+		return nil, &apiError{http.StatusBadRequest, "path must be an absolute directory", ErrCodeInvalidOperation}
+	}
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return nil, &apiError{http.StatusBadRequest, fmt.Sprintf("path is not a directory: %s", path), ErrCodeNotFound}
+	}
+
+	instances, groups, err := storage.LoadWithGroups()
+	if err != nil {
+		return nil, &apiError{http.StatusInternalServerError, err.Error(), ErrCodeInvalidOperation}
+	}
+
+	group := req.Group
+	if group == "" {
+		group = session.GroupForPath(path)
+	}
+	title := req.Title
+	if title == "" {
+		title = generateUniqueTitle(instances, filepath.Base(path), path)
+	} else if isDupe, existing := isDuplicateSession(instances, title, path); isDupe {
+		return nil, &apiError{http.StatusConflict, fmt.Sprintf("session already exists with same title and path: %s (%s)", existing.Title, existing.ID), ErrCodeAlreadyExists}
+	}
+
+	var inst *session.Instance
+	if group != "" {
+		inst = session.NewInstanceWithGroup(title, path, group)
+	} else {
+		inst = session.NewInstance(title, path)
+	}
+	if req.Title == "" {
+		// [auto_name] may title it after its task
+		inst.AutoTitle = title
+	}
+	command := req.Command
+	if req.Start && command == "" {
+		command = session.GetDefaultTool()
+	}
+	if command != "" {
+		applySessionCommand(inst, command)
+	}
+	inst.GitRemote = session.ProjectRemote(path)
+	inst.PendingPrompt = req.Prompt
+
+	instances = append(instances, inst)
+	groupTree := session.NewGroupTreeWithGroups(instances, groups)
+	if inst.GroupPath != "" {
+		groupTree.CreateGroup(inst.GroupPath)
+	}
+	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+		return nil, &apiError{http.StatusInternalServerError, fmt.Sprintf("failed to save session: %v", err), ErrCodeInvalidOperation}
+	}
+	_ = session.RecordRecentDirectory(path)
+
+	if req.Start {
+		// A queued prompt is sent in the background once the agent is ready
+		if err := inst.Start(); err != nil {
+			return nil, &apiError{http.StatusInternalServerError, fmt.Sprintf("session added but failed to start: %v", err), ErrCodeInvalidOperation}
+		}
+		inst.PostStartSync(3 * time.Second)
+		if err := saveSessionData(storage, instances); err != nil {
+			return nil, &apiError{http.StatusInternalServerError, fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation}
+		}
+		// Record the prompt as sent once the background send delivers it
+		go func() {
+			if inst.WaitPendingPrompt() {
+				savePromptDelivered(storage, inst)
+			}
+		}()
+	}
+	return inst, nil
+}
+
+// removeSession serves DELETE /sessions/{id} like agent-deck remove
+func (a *apiServer) removeSession(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	inst, instances, groups, ok := a.resolve(w, r)
+	if !ok {
+		return
+	}
+
+	killTmux := r.URL.Query().Get("keep_tmux") != "true" && session.GetConfirmSettings().GetDeleteKillsTmux()
+	if killTmux {
+		_ = inst.Kill()
+		if inst.IsWorktree() {
+			_ = git.RemoveWorktree(inst.WorktreeRepoRoot, inst.WorktreePath, false)
+			_ = git.PruneWorktrees(inst.WorktreeRepoRoot)
+		}
+	}
+	if err := a.storage.DeleteInstance(inst.ID); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("failed to delete: %v", err), ErrCodeInvalidOperation)
+		return
+	}
+	_ = session.DeleteSnapshots(inst.ID)
+	remaining := make([]*session.Instance, 0, len(instances)-1)
+	for _, s := range instances {
+		if s.ID != inst.ID {
+			remaining = append(remaining, s)
+		}
+	}
+	if err := a.storage.SaveWithGroups(remaining, session.NewGroupTreeWithGroups(remaining, groups)); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"id":      inst.ID,
+		"title":   inst.Title,
+		"removed": true,
+	})
+}
+
+// attachCommand serves GET /sessions/{id}/attach: the command an editor or
+// window manager runs in a terminal to attach (starting the session if it
+// isn't running), and the plain tmux command for running sessions
+func (a *apiServer) attachCommand(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	inst, _, _, ok := a.resolve(w, r)
+	if !ok {
+		return
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "agent-deck"
+	}
+	argv := []string{exe, "-p", a.storage.Profile(), "session", "attach", inst.ID}
+	resp := map[string]interface{}{
+		"id":      inst.ID,
+		"title":   inst.Title,
+		"argv":    argv,
+		"command": terminal.ShellJoin(argv),
+		"running": inst.Exists(),
+	}
+	if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil && inst.Exists() {
+		resp["tmux_argv"] = tmuxSess.AttachArgv()
+	}
+	writeAPIJSON(w, http.StatusOK, resp)
+}
+
+// sendKeys serves POST /sessions/{id}/keys: types text into a running
+// session as-is ({files} and friends are not expanded), then Enter if asked
+func (a *apiServer) sendKeys(w http.ResponseWriter, r *http.Request) {
+	var req apiKeysRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err), ErrCodeInvalidOperation)
+		return
+	}
+	if req.Text == "" && !req.Enter {
+		writeAPIError(w, http.StatusBadRequest, "nothing to send: give text and/or enter", ErrCodeInvalidOperation)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	inst, instances, _, ok := a.resolve(w, r)
+	if !ok {
+		return
+	}
+	tmuxSess := inst.GetTmuxSession()
+	if tmuxSess == nil || !inst.Exists() {
+		writeAPIError(w, http.StatusConflict, fmt.Sprintf("session '%s' is not running", inst.Title), ErrCodeInvalidOperation)
+		return
+	}
+
+	var err error
+	switch {
+	case req.Text == "":
+		err = tmuxSess.SendEnter()
+	case req.Enter:
+		err = sendWithRetry(tmuxSess, req.Text)
+	default:
+		err = tmuxSess.SendKeysChunked(req.Text)
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("failed to send keys: %v", err), ErrCodeInvalidOperation)
+		return
+	}
+	if req.Enter && inst.AutoNameFromPrompt(req.Text) {
+		_ = saveSessionData(a.storage, instances)
+	}
+	writeAPIJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"id":      inst.ID,
+		"title":   inst.Title,
+	})
+}
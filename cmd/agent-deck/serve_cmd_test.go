@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func newTestAPI(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	storage, err := session.NewStorageWithProfile("_test")
+	if err != nil {
+		t.Fatalf("storage: %v", err)
+	}
+	srv := httptest.NewServer((&apiServer{storage: storage, token: token, tcp: true}).handler())
+	t.Cleanup(func() {
+		srv.Close()
+		_ = storage.Close()
+	})
+	return srv
+}
+
+func apiRequest(t *testing.T, method, url, body string, v interface{}) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("%s %s: decode: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServeAPI(t *testing.T) {
+	srv := newTestAPI(t, "")
+	project := t.TempDir()
+
+	var added apiSession
+	body := `{"path": "` + project + `", "title": "api-test", "command": "claude"}`
+	if code := apiRequest(t, "POST", srv.URL+"/sessions", body, &added); code != http.StatusCreated {
+		t.Fatalf("add = %d", code)
+	}
+	if added.ID == "" || added.Title != "api-test" || added.Tool != "claude" || added.Path != project {
+		t.Errorf("added = %+v", added)
+	}
+	if code := apiRequest(t, "POST", srv.URL+"/sessions", body, nil); code != http.StatusConflict {
+		t.Errorf("duplicate add = %d, want 409", code)
+	}
+	if code := apiRequest(t, "POST", srv.URL+"/sessions", `{"path": "relative"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("relative path = %d, want 400", code)
+	}
+
+	var list struct {
+		Sessions []apiSession `json:"sessions"`
+	}
+	if code := apiRequest(t, "GET", srv.URL+"/sessions", "", &list); code != http.StatusOK || len(list.Sessions) != 1 {
+		t.Fatalf("list = %d %+v", code, list)
+	}
+
+	var live liveStatus
+	if code := apiRequest(t, "GET", srv.URL+"/sessions/api-test", "", &live); code != http.StatusOK || live.ID != added.ID || live.Exists {
+		t.Errorf("status = %d %+v", code, live)
+	}
+
+	var attach struct {
+		Command string   `json:"command"`
+		Argv    []string `json:"argv"`
+	}
+	if code := apiRequest(t, "GET", srv.URL+"/sessions/"+added.ID[:8]+"/attach", "", &attach); code != http.StatusOK {
+		t.Fatalf("attach = %d", code)
+	}
+	if !strings.HasSuffix(attach.Command, " session attach "+added.ID) || attach.Argv[len(attach.Argv)-1] != added.ID {
+		t.Errorf("attach = %+v", attach)
+	}
+
+	var apiErr struct {
+		Code string `json:"code"`
+	}
+	if code := apiRequest(t, "POST", srv.URL+"/sessions/api-test/keys", `{"text": "hi"}`, &apiErr); code != http.StatusConflict || apiErr.Code != ErrCodeInvalidOperation {
+		t.Errorf("keys to a stopped session = %d %s, want 409", code, apiErr.Code)
+	}
+
+	if code := apiRequest(t, "DELETE", srv.URL+"/sessions/"+url.PathEscape("api-test")+"?keep_tmux=true", "", nil); code != http.StatusOK {
+		t.Errorf("remove = %d", code)
+	}
+	if code := apiRequest(t, "GET", srv.URL+"/sessions/api-test", "", &apiErr); code != http.StatusNotFound || apiErr.Code != ErrCodeNotFound {
+		t.Errorf("status after remove = %d %s, want 404", code, apiErr.Code)
+	}
+}
+
+func TestServeAPIToken(t *testing.T) {
+	srv := newTestAPI(t, "s3cret")
+	if code := apiRequest(t, "GET", srv.URL+"/sessions", "", nil); code != http.StatusUnauthorized {
+		t.Errorf("without token = %d, want 401", code)
+	}
+	req, _ := http.NewRequest("GET", srv.URL+"/sessions", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("with token = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestServeAPIRefusesWebPages(t *testing.T) {
+	srv := newTestAPI(t, "")
+	body := `{"path": "` + t.TempDir() + `", "command": "echo pwned", "start": true}`
+	for name, tc := range map[string]struct {
+		header, value string
+		want          int
+	}{
+		"text/plain body": {"Content-Type", "text/plain", http.StatusUnsupportedMediaType},
+		"origin":          {"Origin", "https://evil.example", http.StatusForbidden},
+		"rebound host":    {"Host", "evil.example", http.StatusForbidden},
+	} {
+		req, _ := http.NewRequest("POST", srv.URL+"/sessions", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if tc.header == "Host" {
+			req.Host = tc.value
+		} else {
+			req.Header.Set(tc.header, tc.value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.want {
+			t.Errorf("%s = %d, want %d", name, resp.StatusCode, tc.want)
+		}
+	}
+
+	var list struct {
+		Sessions []apiSession `json:"sessions"`
+	}
+	if code := apiRequest(t, "GET", srv.URL+"/sessions", "", &list); code != http.StatusOK || len(list.Sessions) != 0 {
+		t.Errorf("list = %d %+v, want no sessions added", code, list)
+	}
+}
@@ -1,19 +1,23 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"flag"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"golang.org/x/term"
+
 	"github.com/asheshgoplani/agent-deck/internal/clipboard"
 	"github.com/asheshgoplani/agent-deck/internal/git"
 	"github.com/asheshgoplani/agent-deck/internal/profile"
 	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
 	"github.com/asheshgoplani/agent-deck/internal/tmux"
 )
 
@@ -49,6 +53,10 @@ func handleSession(profile string, args []string) {
 		handleSessionSend(profile, args[1:])
 	case "output":
 		handleSessionOutput(profile, args[1:])
+	case "relocate":
+		handleSessionRelocate(profile, args[1:])
+	case "files":
+		handleSessionFiles(profile, args[1:])
 	case "help", "--help", "-h":
 		printSessionHelp()
 	default:
@@ -75,6 +83,8 @@ func printSessionHelp() {
 	fmt.Println("  set <id> <field> <value>  Update session property")
 	fmt.Println("  send <id> <message>     Send a message to a running session")
 	fmt.Println("  output <id>             Get the last response from a session")
+	fmt.Println("  relocate <id> [path]    Find a moved project directory and update the path")
+	fmt.Println("  files <id> [add|rm|clear] [path...]  List or edit the session's key files")
 	fmt.Println("  set-parent <id> <parent>  Link session as sub-session of parent")
 	fmt.Println("  unset-parent <id>       Remove sub-session link")
 	fmt.Println()
@@ -102,6 +112,10 @@ func printSessionHelp() {
 	fmt.Println("  command            Command to run")
 	fmt.Println("  tool               Tool type (claude, gemini, shell, etc.)")
 	fmt.Println("  wrapper            Wrapper command (use {command} to include tool command)")
+	fmt.Println("  container          Run inside a container (image:<img>, docker:<name>, compose:<svc>, devcontainer, k8s:[ns/]<pod>, ssh:<host>, none)")
+	fmt.Println("  container-workdir  Working directory inside the container")
+	fmt.Println("  k8s-context        kubeconfig context for a k8s container")
+	fmt.Println("  k8s-container      Container within the pod for a k8s container")
 	fmt.Println("  claude-session-id  Claude conversation ID (for fork/resume)")
 	fmt.Println("  gemini-session-id  Gemini conversation ID (for resume)")
 	fmt.Println()
@@ -170,8 +184,9 @@ func handleSessionStart(profile string, args []string) {
 		os.Exit(1)
 	}
 
-	// Start the session (with or without initial message)
-	if initialMessage != "" {
+	// Start the session (with or without initial message). A prompt queued by
+	// `add --issue` is sent synchronously here, since the CLI exits right after.
+	if initialMessage != "" || inst.PendingPrompt != "" {
 		if err := inst.StartWithMessage(initialMessage); err != nil {
 			out.Error(fmt.Sprintf("failed to start session: %v", err), ErrCodeInvalidOperation)
 			os.Exit(1)
@@ -335,6 +350,9 @@ func handleSessionRestart(profile string, args []string) {
 		inst.PostStartSync(3 * time.Second)
 	}
 
+	// Deliver a queued prompt before exiting, so it is saved as sent
+	inst.WaitPendingPrompt()
+
 	// Save updated state
 	if err := saveSessionData(storage, instances); err != nil {
 		out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
@@ -561,7 +579,7 @@ func handleSessionAttach(profile string, args []string) {
 	identifier := fs.Arg(0)
 
 	// Load sessions
-	_, instances, _, err := loadSessionData(profile)
+	storage, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -584,7 +602,12 @@ func handleSessionAttach(profile string, args []string) {
 		os.Exit(1)
 	}
 
-	// Attach to the session
+	attachSession(profile, storage, inst)
+}
+
+// attachSession attaches the terminal to inst's tmux session, running the
+// attach hooks around it and recording the attach
+func attachSession(profile string, storage *session.Storage, inst *session.Instance) {
 	tmuxSession := inst.GetTmuxSession()
 	if tmuxSession == nil {
 		fmt.Fprintf(os.Stderr, "Error: no tmux session for '%s'\n", inst.Title)
@@ -594,10 +617,75 @@ func handleSessionAttach(profile string, args []string) {
 	// Create context for attach
 	ctx := context.Background()
 
+	if err := inst.RunPreAttachHook(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	attachedAt := time.Now()
 	if err := tmuxSession.Attach(ctx); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to attach: %v\n", err)
 		os.Exit(1)
 	}
+	if err := inst.RunPostDetachHook(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if db := storage.GetDB(); db != nil {
+		statedb.SetGlobal(db)
+		session.RecordActivity(inst.ID, session.ActivityAttached, attachedAt, time.Now())
+	}
+	if session.GetHandoffSettings().ShouldPrompt(time.Since(attachedAt)) && term.IsTerminal(int(os.Stdin.Fd())) {
+		promptHandoffNote(profile, inst)
+	}
+}
+
+// promptHandoffNote asks where work on inst was left off and saves the answer.
+// Sessions are reloaded first, since the TUI may have saved while attached.
+func promptHandoffNote(profile string, inst *session.Instance) {
+	fmt.Printf("Where did you leave off in '%s'? (Enter to skip, - to clear): ", inst.Title)
+	note, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return
+	}
+	if note == "-" {
+		note = ""
+	}
+
+	storage, instances, groupsData, err := loadSessionData(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+	for _, fresh := range instances {
+		if fresh.ID == inst.ID {
+			fresh.SetHandoffNote(note)
+			if err := storage.SaveWithGroups(instances, session.NewGroupTreeWithGroups(instances, groupsData)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to save: %v\n", err)
+			}
+			return
+		}
+	}
+}
+
+// savePromptDelivered clears inst's queued prompt in storage once it has been
+// sent. Sessions are reloaded first, since the TUI may have saved meanwhile.
+func savePromptDelivered(storage *session.Storage, inst *session.Instance) {
+	if inst.GetPendingPrompt() != "" {
+		return // not delivered; left for the next start
+	}
+	instances, groupsData, err := storage.LoadWithGroups()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load sessions: %v\n", err)
+		return
+	}
+	for _, fresh := range instances {
+		if fresh.ID == inst.ID && fresh.PendingPrompt != "" {
+			fresh.PendingPrompt = ""
+			if err := storage.SaveWithGroups(instances, session.NewGroupTreeWithGroups(instances, groupsData)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to save: %v\n", err)
+			}
+			return
+		}
+	}
 }
 
 // handleSessionShow shows session details
@@ -686,6 +774,60 @@ func handleSessionShow(profile string, args []string) {
 	if inst.Command != "" {
 		jsonData["command"] = inst.Command
 	}
+	if model := inst.Model(); model != "" {
+		jsonData["model"] = model
+	}
+	if repo := inst.RepoLabel(); repo != "" {
+		jsonData["repo"] = repo
+	}
+	if inst.Container != nil {
+		jsonData["container"] = inst.Container
+	}
+	if inst.Notes != "" {
+		jsonData["notes"] = inst.Notes
+	}
+	if len(inst.ContextFiles) > 0 {
+		jsonData["context_files"] = inst.ContextFiles
+	}
+	if inst.PendingPrompt != "" {
+		jsonData["pending_prompt"] = inst.PendingPrompt
+	}
+	if inst.Ticket != nil {
+		jsonData["ticket"] = inst.Ticket
+	}
+	if inst.AutoAttach != "" {
+		jsonData["auto_attach"] = inst.AutoAttach
+	}
+	if mode := inst.LaunchMode(); mode != "" {
+		jsonData["launch_mode"] = mode
+	}
+	if inst.HandoffNote != "" {
+		jsonData["handoff_note"] = inst.HandoffNote
+		jsonData["handoff_at"] = inst.HandoffAt.Format(time.RFC3339)
+	}
+	preAttach, postDetach := inst.AttachHooks()
+	if preAttach != "" {
+		jsonData["pre_attach"] = preAttach
+	}
+	if postDetach != "" {
+		jsonData["post_detach"] = postDetach
+	}
+	preStart, postExit := inst.LifecycleHooks()
+	if preStart != "" {
+		jsonData["pre_start"] = preStart
+	}
+	if postExit != "" {
+		jsonData["post_exit"] = postExit
+	}
+	if inst.Watch != nil {
+		jsonData["watch"] = inst.Watch
+	}
+	if inst.Template != "" {
+		jsonData["template"] = inst.Template
+	}
+	if len(inst.Env) > 0 {
+		jsonData["env"] = envNames(inst.Env)
+	}
 
 	if inst.Tool == "claude" {
 		jsonData["claude_session_id"] = inst.ClaudeSessionID
@@ -705,6 +847,9 @@ func handleSessionShow(profile string, args []string) {
 		tmuxSession := inst.GetTmuxSession()
 		if tmuxSession != nil {
 			jsonData["tmux_session"] = tmuxSession.Name
+			if tmuxSession.SocketName != "" {
+				jsonData["tmux_socket"] = tmuxSession.SocketName
+			}
 		}
 	}
 
@@ -716,6 +861,9 @@ func handleSessionShow(profile string, args []string) {
 	sb.WriteString(fmt.Sprintf("ID:      %s\n", inst.ID))
 	sb.WriteString(fmt.Sprintf("Status:  %s %s\n", StatusSymbol(inst.Status), StatusString(inst.Status)))
 	sb.WriteString(fmt.Sprintf("Path:    %s\n", FormatPath(inst.ProjectPath)))
+	if repo := inst.RepoLabel(); repo != "" {
+		sb.WriteString(fmt.Sprintf("Repo:    %s\n", repo))
+	}
 
 	if inst.GroupPath != "" {
 		sb.WriteString(fmt.Sprintf("Group:   %s\n", inst.GroupPath))
@@ -726,6 +874,26 @@ func handleSessionShow(profile string, args []string) {
 	if inst.Command != "" {
 		sb.WriteString(fmt.Sprintf("Command: %s\n", inst.Command))
 	}
+	if model := inst.Model(); model != "" {
+		sb.WriteString(fmt.Sprintf("Model:   %s\n", model))
+	}
+	if mode := inst.LaunchMode(); mode != "" {
+		sb.WriteString(fmt.Sprintf("Mode:    %s\n", mode))
+	}
+
+	if inst.Container != nil {
+		container := inst.Container.String()
+		if inst.Container.PodContainer != "" {
+			container += " -c " + inst.Container.PodContainer
+		}
+		if inst.Container.Context != "" {
+			container += " (context " + inst.Container.Context + ")"
+		}
+		if inst.Container.Workdir != "" {
+			container += " (workdir " + inst.Container.Workdir + ")"
+		}
+		sb.WriteString(fmt.Sprintf("Container: %s\n", container))
+	}
 
 	if inst.Tool == "claude" {
 		if inst.ClaudeSessionID != "" {
@@ -766,7 +934,73 @@ func handleSessionShow(profile string, args []string) {
 	if inst.Exists() {
 		tmuxSession := inst.GetTmuxSession()
 		if tmuxSession != nil {
-			sb.WriteString(fmt.Sprintf("Tmux:    %s\n", tmuxSession.Name))
+			if tmuxSession.SocketName != "" {
+				sb.WriteString(fmt.Sprintf("Tmux:    %s (tmux -L %s)\n", tmuxSession.Name, tmuxSession.SocketName))
+			} else {
+				sb.WriteString(fmt.Sprintf("Tmux:    %s\n", tmuxSession.Name))
+			}
+		}
+	}
+
+	if inst.Template != "" {
+		sb.WriteString(fmt.Sprintf("Template: %s\n", inst.Template))
+	}
+	if len(inst.Env) > 0 {
+		sb.WriteString(fmt.Sprintf("Env:     %s\n", strings.Join(envNames(inst.Env), ", ")))
+	}
+	if inst.Ticket != nil {
+		sb.WriteString(fmt.Sprintf("Ticket:  %s", inst.Ticket))
+		if inst.Ticket.Title != "" {
+			sb.WriteString(" " + inst.Ticket.Title)
+		}
+		sb.WriteString("\n")
+		if inst.Ticket.URL != "" {
+			sb.WriteString(fmt.Sprintf("         %s\n", inst.Ticket.URL))
+		}
+	}
+	if inst.PendingPrompt != "" {
+		sb.WriteString("Prompt:  queued, sent when the session starts\n")
+	}
+	if inst.HandoffNote != "" {
+		sb.WriteString(fmt.Sprintf("Left off: %s (%s)\n", inst.HandoffNote, inst.HandoffAt.Format("2006-01-02 15:04")))
+	}
+	switch inst.AutoAttach {
+	case session.AutoAttachAlways:
+		sb.WriteString("Attach:  automatically when it starts waiting\n")
+	case session.AutoAttachAsk:
+		sb.WriteString("Attach:  offered when it starts waiting\n")
+	}
+	if preAttach != "" {
+		sb.WriteString(fmt.Sprintf("Before:  %s (pre-attach)\n", preAttach))
+	}
+	if postDetach != "" {
+		sb.WriteString(fmt.Sprintf("After:   %s (post-detach)\n", postDetach))
+	}
+	if preStart != "" {
+		sb.WriteString(fmt.Sprintf("Start:   %s (pre-start)\n", preStart))
+	}
+	if postExit != "" {
+		sb.WriteString(fmt.Sprintf("Exit:    %s (post-exit)\n", postExit))
+	}
+	if w := inst.Watch; w != nil && len(w.Patterns) > 0 {
+		sb.WriteString(fmt.Sprintf("Watch:   %s\n", strings.Join(w.Patterns, ", ")))
+		if w.Hook != "" {
+			sb.WriteString(fmt.Sprintf("  hook:   %s\n", w.Hook))
+		}
+		if w.Prompt != "" {
+			sb.WriteString(fmt.Sprintf("  prompt: %s\n", w.Prompt))
+		}
+	}
+	if len(inst.ContextFiles) > 0 {
+		sb.WriteString("Files:\n")
+		for _, f := range inst.ContextFiles {
+			sb.WriteString("  " + f + "\n")
+		}
+	}
+	if inst.Notes != "" {
+		sb.WriteString("Notes:\n")
+		for _, line := range strings.Split(inst.Notes, "\n") {
+			sb.WriteString("  " + line + "\n")
 		}
 	}
 
@@ -791,8 +1025,24 @@ func handleSessionSet(profile string, args []string) {
 		fmt.Println("  command            Command to run")
 		fmt.Println("  tool               Tool type (claude, gemini, shell, etc.)")
 		fmt.Println("  wrapper            Wrapper command (use {command} to include tool command)")
+		fmt.Println("  container          Run inside a container (image:<img>, docker:<name>, compose:<svc>, devcontainer, k8s:[ns/]<pod>, ssh:<host>, none)")
+		fmt.Println("  container-workdir  Working directory inside the container")
+		fmt.Println("  k8s-context        kubeconfig context for a k8s container")
+		fmt.Println("  k8s-container      Container within the pod for a k8s container")
 		fmt.Println("  claude-session-id  Claude conversation ID")
 		fmt.Println("  gemini-session-id  Gemini conversation ID")
+		fmt.Println("  auto-checkpoint    Git checkpoint when the agent finishes (on, off, default)")
+		fmt.Println("  status-text        Text shown next to the status icon (\"\" clears)")
+		fmt.Println("  auto-attach        When it starts waiting, attach from the deck list (attach, ask, off)")
+		fmt.Println("  ticket             Linear/Jira ticket ID or URL; fetches its title and status (\"\" unlinks)")
+		fmt.Println("  pre-attach         Shell command run in the project dir before attaching (\"\" = tool default)")
+		fmt.Println("  post-detach        Shell command run in the project dir after detaching (\"\" = tool default)")
+		fmt.Println("  pre-start          Shell command run in the project dir before starting; failing aborts the start")
+		fmt.Println("  post-exit          Shell command run in the project dir after the session exits or is stopped")
+		fmt.Println("  handoff            One-line \"where I left off\" note shown when the session is selected (\"\" clears)")
+		fmt.Println("  watch              Comma-separated globs, relative to the project, watched while the TUI runs (\"\" clears)")
+		fmt.Println("  watch-hook         Shell command run in the project dir when watched files change")
+		fmt.Println("  watch-prompt       Prompt sent once the agent is waiting after watched files change ({changed} = the files)")
 		fmt.Println()
 		fmt.Println("Options:")
 		fs.PrintDefaults()
@@ -802,6 +1052,16 @@ func handleSessionSet(profile string, args []string) {
 		fmt.Println("  agent-deck session set my-project claude-session-id \"abc123-def456\"")
 		fmt.Println("  agent-deck session set my-project path /new/path/to/project")
 		fmt.Println("  agent-deck session set my-project wrapper \"nvim +'terminal {command}'\"")
+		fmt.Println("  agent-deck session set my-project container compose:app")
+		fmt.Println("  agent-deck session set my-project auto-checkpoint on")
+		fmt.Println("  agent-deck session set my-project status-text \"running tests\"")
+		fmt.Println("  agent-deck session set my-project auto-attach ask")
+		fmt.Println("  agent-deck session set my-project ticket ENG-123")
+		fmt.Println("  agent-deck session set my-project pre-attach \"git fetch --quiet\"")
+		fmt.Println("  agent-deck session set my-project pre-start \"docker compose up -d\"")
+		fmt.Println("  agent-deck session set my-project watch \"SPEC.md,docs/*.md\"")
+		fmt.Println("  agent-deck session set my-project watch-prompt \"I updated {changed}; re-read it and adjust your work\"")
+		fmt.Println("  agent-deck session set my-project env OPENAI_API_KEY=sk-...   # KEY= removes it")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
@@ -826,14 +1086,31 @@ func handleSessionSet(profile string, args []string) {
 		"command":           true,
 		"tool":              true,
 		"wrapper":           true,
+		"container":         true,
+		"container-workdir": true,
+		"k8s-context":       true,
+		"k8s-container":     true,
 		"claude-session-id": true,
 		"gemini-session-id": true,
+		"auto-checkpoint":   true,
+		"status-text":       true,
+		"auto-attach":       true,
+		"ticket":            true,
+		"pre-attach":        true,
+		"post-detach":       true,
+		"pre-start":         true,
+		"post-exit":         true,
+		"handoff":           true,
+		"watch":             true,
+		"watch-hook":        true,
+		"watch-prompt":      true,
+		"env":               true,
 	}
 
 	if !validFields[field] {
 		out.Error(
 			fmt.Sprintf(
-				"invalid field: %s\nValid fields: title, path, command, tool, wrapper, claude-session-id, gemini-session-id",
+				"invalid field: %s\nValid fields: title, path, command, tool, wrapper, container, container-workdir, k8s-context, k8s-container, claude-session-id, gemini-session-id, auto-checkpoint, status-text, auto-attach, ticket, pre-attach, post-detach, pre-start, post-exit, handoff, watch, watch-hook, watch-prompt, env",
 				field,
 			),
 			ErrCodeInvalidOperation,
@@ -880,13 +1157,46 @@ func handleSessionSet(profile string, args []string) {
 	case "wrapper":
 		oldValue = inst.Wrapper
 		inst.Wrapper = value
+	case "container":
+		oldValue = inst.Container.String()
+		spec, err := session.ParseContainerSpec(value)
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		if spec != nil && inst.Container != nil {
+			spec.Workdir = inst.Container.Workdir
+			if spec.Kind == inst.Container.Kind {
+				spec.Context = inst.Container.Context
+				spec.PodContainer = inst.Container.PodContainer
+			}
+		}
+		inst.Container = spec
+	case "container-workdir":
+		if inst.Container == nil {
+			out.Error("session has no container (set 'container' first)", ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		oldValue = inst.Container.Workdir
+		inst.Container.Workdir = value
+	case "k8s-context", "k8s-container":
+		if inst.Container == nil || inst.Container.Kind != session.ContainerKubernetes {
+			out.Error("session does not run in a pod (set 'container k8s:<pod>' first)", ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		target := &inst.Container.Context
+		if field == "k8s-container" {
+			target = &inst.Container.PodContainer
+		}
+		oldValue = *target
+		*target = value
 	case "claude-session-id":
 		oldValue = inst.ClaudeSessionID
 		inst.ClaudeSessionID = value
 		inst.ClaudeDetectedAt = time.Now()
 		// Also update tmux environment if session is running
 		if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil && tmuxSess.Exists() {
-			_ = exec.Command("tmux", "set-environment", "-t", tmuxSess.Name, "CLAUDE_SESSION_ID", value).Run()
+			_ = tmuxSess.Run("set-environment", "-t", tmuxSess.Name, "CLAUDE_SESSION_ID", value)
 		}
 	case "gemini-session-id":
 		oldValue = inst.GeminiSessionID
@@ -894,7 +1204,97 @@ func handleSessionSet(profile string, args []string) {
 		inst.GeminiDetectedAt = time.Now()
 		// Also update tmux environment if session is running
 		if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil && tmuxSess.Exists() {
-			_ = exec.Command("tmux", "set-environment", "-t", tmuxSess.Name, "GEMINI_SESSION_ID", value).Run()
+			_ = tmuxSess.Run("set-environment", "-t", tmuxSess.Name, "GEMINI_SESSION_ID", value)
+		}
+	case "auto-checkpoint":
+		oldValue = formatOverride(inst.AutoCheckpoint)
+		override, err := parseOverride(value)
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		inst.AutoCheckpoint = override
+	case "status-text":
+		oldValue = inst.StatusText
+		inst.SetStatusText(value)
+	case "auto-attach":
+		oldValue = formatAutoAttach(inst.AutoAttach)
+		mode, err := session.ParseAutoAttach(value)
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		inst.AutoAttach = mode
+	case "ticket":
+		if inst.Ticket != nil {
+			oldValue = inst.Ticket.ID
+		}
+		inst.Ticket = nil
+		if value != "" {
+			ticket, err := linkTicket(value, *jsonOutput || quietMode)
+			if err != nil {
+				out.Error(err.Error(), ErrCodeInvalidOperation)
+				os.Exit(1)
+			}
+			inst.Ticket = ticket
+		}
+	case "pre-attach":
+		oldValue = inst.PreAttachHook
+		inst.PreAttachHook = value
+	case "post-detach":
+		oldValue = inst.PostDetachHook
+		inst.PostDetachHook = value
+	case "pre-start":
+		oldValue = inst.PreStartHook
+		inst.PreStartHook = value
+	case "post-exit":
+		oldValue = inst.PostExitHook
+		inst.PostExitHook = value
+	case "handoff":
+		oldValue = inst.HandoffNote
+		inst.SetHandoffNote(value)
+	case "watch", "watch-hook", "watch-prompt":
+		watch := inst.Watch
+		if watch == nil {
+			watch = &session.FileWatch{}
+		}
+		switch field {
+		case "watch":
+			patterns, err := session.ParseWatchPatterns(value)
+			if err != nil {
+				out.Error(err.Error(), ErrCodeInvalidOperation)
+				os.Exit(1)
+			}
+			oldValue = strings.Join(watch.Patterns, ",")
+			watch.Patterns = patterns
+		case "watch-hook":
+			oldValue = watch.Hook
+			watch.Hook = value
+		case "watch-prompt":
+			oldValue = watch.Prompt
+			watch.Prompt = value
+		}
+		inst.Watch = watch
+		if watch.IsZero() {
+			inst.Watch = nil
+		}
+	case "env":
+		key, envValue, err := session.ParseEnvAssignment(value)
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		oldValue = inst.Env[key]
+		if envValue == "" {
+			delete(inst.Env, key)
+		} else {
+			if inst.Env == nil {
+				inst.Env = make(map[string]string)
+			}
+			inst.Env[key] = envValue
+		}
+		if len(inst.Env) == 0 {
+			inst.Env = nil
 		}
 	}
 
@@ -905,6 +1305,12 @@ func handleSessionSet(profile string, args []string) {
 		os.Exit(1)
 	}
 
+	// Show new status text in running TUIs right away (they'd otherwise keep theirs until reload)
+	if field == "status-text" {
+		report := session.StatusReport{SessionID: inst.ID, Message: value, Clear: value == ""}
+		_, _ = session.SendStatusReport(storage.Profile(), report)
+	}
+
 	// Output success
 	out.Success(fmt.Sprintf("Updated %s: %q -> %q", field, oldValue, value), map[string]interface{}{
 		"success":   true,
@@ -916,6 +1322,67 @@ func handleSessionSet(profile string, args []string) {
 	})
 }
 
+// linkTicket parses a ticket ID or URL and fetches its title and status. A
+// failed fetch (no credentials, offline) still links the ticket, with a
+// warning unless quiet.
+func linkTicket(ref string, quiet bool) (*session.Ticket, error) {
+	ticket, err := session.ParseTicketRef(ref, session.GetTicketSettings())
+	if err != nil {
+		return nil, err
+	}
+	if err := ticket.Fetch(); err != nil && !quiet {
+		fmt.Fprintf(os.Stderr, "Warning: linked %s without title/status: %v\n", ticket.ID, err)
+	}
+	return ticket, nil
+}
+
+// parseOverride parses an on/off/default value into an optional per-session override
+func parseOverride(value string) (*bool, error) {
+	switch strings.ToLower(value) {
+	case "on", "true", "yes", "1":
+		v := true
+		return &v, nil
+	case "off", "false", "no", "0":
+		v := false
+		return &v, nil
+	case "default", "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("invalid value: %s (use on, off, or default)", value)
+	}
+}
+
+// formatOverride renders an optional per-session override as on/off/default
+func formatOverride(v *bool) string {
+	switch {
+	case v == nil:
+		return "default"
+	case *v:
+		return "on"
+	default:
+		return "off"
+	}
+}
+
+// envNames returns the sorted names of a session's env vars (values may be
+// secrets, so they aren't shown)
+func envNames(env map[string]string) []string {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatAutoAttach renders an auto-attach mode, "off" when unset
+func formatAutoAttach(mode string) string {
+	if mode == session.AutoAttachOff {
+		return "off"
+	}
+	return mode
+}
+
 // loadSessionData loads storage and session data for a profile
 // The Storage.LoadWithGroups() method already handles tmux reconnection internally
 func loadSessionData(profile string) (*session.Storage, []*session.Instance, []*session.GroupData, error) {
@@ -928,6 +1395,10 @@ func loadSessionData(profile string) (*session.Storage, []*session.Instance, []*
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to load sessions: %w", err)
 	}
+	// Stop and restart claim post-exit hooks in the shared database
+	if db := storage.GetDB(); db != nil && statedb.GetGlobal() == nil {
+		statedb.SetGlobal(db)
+	}
 
 	// LoadWithGroups reconnects tmux sessions with lazy loading.
 	// Status uses cached values from JSON; session IDs are not synced at load time.
@@ -965,8 +1436,7 @@ func findSessionByTmuxAcrossProfiles() (*session.Instance, string) {
 // findSessionByTmux tries to find a session by matching tmux session name or working directory
 func findSessionByTmux(instances []*session.Instance) *session.Instance {
 	// Get current tmux session name
-	cmd := exec.Command("tmux", "display-message", "-p", "#{session_name}\t#{pane_current_path}")
-	output, err := cmd.Output()
+	output, err := tmux.CurrentOutput("display-message", "-p", "#{session_name}\t#{pane_current_path}")
 	if err != nil {
 		return nil
 	}
@@ -1010,7 +1480,7 @@ func findSessionByTmux(instances []*session.Instance) *session.Instance {
 
 	// Try to find by path (only for non-agentdeck tmux sessions)
 	for _, inst := range instances {
-		if inst.ProjectPath == currentPath {
+		if session.SamePath(inst.ProjectPath, currentPath) {
 			return inst
 		}
 	}
@@ -1021,9 +1491,8 @@ func findSessionByTmux(instances []*session.Instance) *session.Instance {
 // showTmuxSessionInfo shows information about the current tmux session (unregistered)
 func showTmuxSessionInfo(out *CLIOutput, jsonOutput bool) {
 	// Get tmux session info
-	cmd := exec.Command("tmux", "display-message", "-p",
+	output, err := tmux.CurrentOutput("display-message", "-p",
 		"#{session_name}\t#{pane_current_path}\t#{session_created}\t#{window_name}")
-	output, err := cmd.Output()
 	if err != nil {
 		out.Error("failed to get tmux session info", ErrCodeNotFound)
 		os.Exit(1)
@@ -1285,7 +1754,7 @@ func handleSessionSend(profile string, args []string) {
 	message := strings.Join(remaining[1:], " ")
 
 	// Load sessions
-	_, instances, _, err := loadSessionData(profile)
+	storage, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
 		os.Exit(1)
@@ -1302,40 +1771,59 @@ func handleSessionSend(profile string, args []string) {
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
-	// Check if session is running
-	if !inst.Exists() {
-		out.Error(fmt.Sprintf("session '%s' is not running", inst.Title), ErrCodeInvalidOperation)
+	message, err = sendPrompt(storage, instances, inst, message, !*noWait)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
 		os.Exit(1)
 	}
 
-	// Get tmux session
+	out.Success(fmt.Sprintf("Sent message to '%s'", inst.Title), map[string]interface{}{
+		"success":       true,
+		"session_id":    inst.ID,
+		"session_title": inst.Title,
+		"message":       message,
+	})
+}
+
+// sendPrompt sends message to a running session the way session send does:
+// once the agent is ready (with wait), not into a rate limit, and with
+// {files} and the other placeholders filled in. Names the session after it
+// under [auto_name]. Returns the message as sent.
+func sendPrompt(storage *session.Storage, instances []*session.Instance, inst *session.Instance, message string, wait bool) (string, error) {
+	if !inst.Exists() {
+		return "", fmt.Errorf("session '%s' is not running", inst.Title)
+	}
 	tmuxSess := inst.GetTmuxSession()
 	if tmuxSess == nil {
-		out.Error("could not determine tmux session", ErrCodeInvalidOperation)
-		os.Exit(1)
+		return "", fmt.Errorf("could not determine tmux session")
 	}
 
-	// Wait for agent to be ready (unless --no-wait is specified)
-	if !*noWait {
+	if wait {
 		if err := waitForAgentReady(tmuxSess, inst.Tool); err != nil {
-			out.Error(fmt.Sprintf("timeout waiting for agent: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			return "", fmt.Errorf("timeout waiting for agent: %w", err)
+		}
+	}
+
+	// [rate_limits] pause_prompts: don't send into a rate limit
+	if session.GetRateLimitSettings().PausePrompts {
+		inst.RefreshRateLimit()
+		if until, limited := inst.RateLimitedUntil(); limited {
+			return "", fmt.Errorf("session '%s' is rate limited until ~%s", inst.Title, session.RateLimitResetLabel(until, time.Now()))
 		}
 	}
 
 	// Send message atomically (text + Enter in single tmux invocation)
 	// with retry to handle rare cases where Enter is still dropped
+	message = inst.ExpandPrompt(message)
 	if err := sendWithRetry(tmuxSess, message); err != nil {
-		out.Error(fmt.Sprintf("failed to send message: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		return "", fmt.Errorf("failed to send message: %w", err)
 	}
-
-	out.Success(fmt.Sprintf("Sent message to '%s'", inst.Title), map[string]interface{}{
-		"success":       true,
-		"session_id":    inst.ID,
-		"session_title": inst.Title,
-		"message":       message,
-	})
+	if inst.AutoNameFromPrompt(message) {
+		if err := saveSessionData(storage, instances); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save the new title: %v\n", err)
+		}
+	}
+	return message, nil
 }
 
 // sendWithRetry sends a message atomically and retries Enter if the agent
@@ -1617,8 +2105,7 @@ func handleSessionCurrent(profileArg string, args []string) {
 
 // getCurrentTmuxSessionName gets the current tmux session name (single subprocess call)
 func getCurrentTmuxSessionName() (string, error) {
-	cmd := exec.Command("tmux", "display-message", "-p", "#{session_name}")
-	output, err := cmd.Output()
+	output, err := tmux.CurrentOutput("display-message", "-p", "#{session_name}")
 	if err != nil {
 		return "", err
 	}
@@ -1675,3 +2162,263 @@ func matchInstanceDataByTmuxName(instances []*session.InstanceData, tmuxSessionN
 	}
 	return nil
 }
+
+// handleSessionRelocate points a session whose project directory was moved
+// or renamed at its new location
+func handleSessionRelocate(profile string, args []string) {
+	fs := flag.NewFlagSet("session relocate", flag.ExitOnError)
+	yes := fs.Bool("yes", false, "Use the best match without asking")
+	jsonOutput := fs.Bool("json", false, "Output as JSON (lists matches unless --yes)")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck session relocate <id|title> [new-path] [options]")
+		fmt.Println()
+		fmt.Println("Update the project path of a session whose directory no longer exists.")
+		fmt.Println("Without a path, searches nearby directories, other sessions' projects and")
+		fmt.Println("your home directory for a repo with the same git remote or a directory")
+		fmt.Println("with the same name, and asks which one to use.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck session relocate my-project")
+		fmt.Println("  agent-deck session relocate my-project ~/code/my-project-renamed")
+		fmt.Println("  agent-deck session relocate my-project --yes")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	identifier, newPath := fs.Arg(0), fs.Arg(1)
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	if identifier == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	storage, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	inst, errMsg, errCode := ResolveSession(identifier, instances)
+	if inst == nil {
+		out.Error(errMsg, errCode)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	oldPath := inst.ProjectPath
+	if newPath == "" {
+		if !inst.PathMissing() {
+			out.Error(fmt.Sprintf("'%s' still exists at %s; pass a new path to move it anyway", inst.Title, oldPath), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		candidates := session.FindRelocations(inst, instances)
+		if len(candidates) == 0 {
+			out.Error(fmt.Sprintf("no match found for %s; pass the new path", oldPath), ErrCodeNotFound)
+			os.Exit(2)
+		}
+		if *jsonOutput && !*yes {
+			out.Print("", map[string]interface{}{
+				"id":         inst.ID,
+				"title":      inst.Title,
+				"path":       oldPath,
+				"candidates": candidates,
+			})
+			return
+		}
+		choice := 0
+		if !*yes {
+			if choice = promptRelocation(inst.Title, oldPath, candidates); choice < 0 {
+				fmt.Println("Aborted.")
+				return
+			}
+		}
+		newPath = candidates[choice].Path
+	}
+
+	if err := inst.Relocate(newPath); err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	if err := saveSessionData(storage, instances); err != nil {
+		out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	out.Success(fmt.Sprintf("Relocated %s: %s -> %s", inst.Title, FormatPath(oldPath), FormatPath(inst.ProjectPath)), map[string]interface{}{
+		"success":  true,
+		"id":       inst.ID,
+		"title":    inst.Title,
+		"old_path": oldPath,
+		"path":     inst.ProjectPath,
+	})
+}
+
+// promptRelocation lists the candidates and asks which to use. Returns -1
+// when cancelled or when there is no terminal to ask on.
+func promptRelocation(title, oldPath string, candidates []session.Relocation) int {
+	fmt.Printf("%s: %s no longer exists. Possible new locations:\n", title, FormatPath(oldPath))
+	for i, c := range candidates {
+		fmt.Printf("  %d) %s (%s)\n", i+1, FormatPath(c.Path), c.Reason)
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Println("Pass one as the new path, or --yes to use the first.")
+		return -1
+	}
+	fmt.Printf("Relocate to [1-%d, Enter for 1, q to cancel]: ", len(candidates))
+	var response string
+	_, _ = fmt.Scanln(&response)
+	response = strings.TrimSpace(response)
+	if response == "" {
+		return 0
+	}
+	var n int
+	if _, err := fmt.Sscanf(response, "%d", &n); err != nil || n < 1 || n > len(candidates) {
+		return -1
+	}
+	return n - 1
+}
+
+// handleSessionFiles lists or edits a session's context files: key files
+// and paths it's about, shown in the TUI preview and filled into {files}
+func handleSessionFiles(profile string, args []string) {
+	fs := flag.NewFlagSet("session files", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck session files <id|title> [add|rm|clear] [path...]")
+		fmt.Println()
+		fmt.Println("List or edit the key files of a session, bookmarks for what it's about.")
+		fmt.Println("They're shown in the TUI preview and `session show`, and {files} in a")
+		fmt.Println("prompt is replaced with them. Paths inside the project are stored")
+		fmt.Println("relative to it.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck session files my-project")
+		fmt.Println("  agent-deck session files my-project add internal/auth/ docs/auth.md")
+		fmt.Println("  agent-deck session files my-project rm docs/auth.md")
+		fmt.Println("  agent-deck session send my-project \"Review {files} for the token refresh bug\"")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	action, paths := "list", fs.Args()[1:]
+	if len(paths) > 0 {
+		action, paths = paths[0], paths[1:]
+	}
+	switch action {
+	case "list", "clear":
+		if len(paths) > 0 {
+			out.Error(fmt.Sprintf("%s takes no paths", action), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	case "add", "rm":
+		if len(paths) == 0 {
+			out.Error(fmt.Sprintf("usage: agent-deck session files <id> %s <path...>", action), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	default:
+		out.Error(fmt.Sprintf("unknown action %q (use add, rm or clear)", action), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	storage, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	inst, errMsg, errCode := ResolveSession(fs.Arg(0), instances)
+	if inst == nil {
+		out.Error(errMsg, errCode)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	var message string
+	switch action {
+	case "add":
+		added := inst.AddContextFiles(resolveContextFileArgs(paths)...)
+		message = fmt.Sprintf("Added %d file(s) to '%s'", len(added), inst.Title)
+	case "rm":
+		removed := inst.RemoveContextFiles(resolveContextFileArgs(paths)...)
+		message = fmt.Sprintf("Removed %d file(s) from '%s'", removed, inst.Title)
+	case "clear":
+		inst.ContextFiles = nil
+		message = fmt.Sprintf("Cleared the files of '%s'", inst.Title)
+	}
+	if action != "list" {
+		if err := saveSessionData(storage, instances); err != nil {
+			out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	}
+
+	files := inst.ContextFiles
+	if files == nil {
+		files = []string{}
+	}
+	jsonData := map[string]interface{}{
+		"id":    inst.ID,
+		"title": inst.Title,
+		"files": files,
+	}
+	if action != "list" {
+		out.Success(message, jsonData)
+		return
+	}
+	var sb strings.Builder
+	if len(files) == 0 {
+		sb.WriteString(fmt.Sprintf("No files for '%s'\n", inst.Title))
+	}
+	for _, f := range files {
+		sb.WriteString(f)
+		if _, err := os.Stat(inst.ContextFilePath(f)); err != nil {
+			sb.WriteString("  (missing)")
+		}
+		sb.WriteString("\n")
+	}
+	out.Print(sb.String(), jsonData)
+}
+
+// resolveContextFileArgs makes paths given on the command line absolute
+// when they exist relative to the working directory; others are taken as
+// relative to the session's project
+func resolveContextFileArgs(paths []string) []string {
+	resolved := make([]string, len(paths))
+	for n, p := range paths {
+		resolved[n] = p
+		if filepath.IsAbs(p) {
+			continue
+		}
+		if abs, err := filepath.Abs(p); err == nil {
+			if _, err := os.Stat(abs); err == nil {
+				resolved[n] = abs
+			}
+		}
+	}
+	return resolved
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// handleShare attaches a read-only client to a session, or prints the command
+// a teammate on the same host can use to watch it without being able to type.
+func handleShare(profile string, args []string) {
+	fs := flag.NewFlagSet("share", flag.ExitOnError)
+	printOnly := fs.Bool("print", false, "Print the read-only attach command instead of attaching")
+	user := fs.String("user", "", "Grant this local user read-only access to the tmux server (tmux 3.3+)")
+	jsonOutput := fs.Bool("json", false, "Output as JSON (implies --print)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck share [id|title] [options]")
+		fmt.Println()
+		fmt.Println("Watch a session read-only, or share it with a teammate on the same host.")
+		fmt.Println("Read-only clients see live output but cannot type into the session.")
+		fmt.Println("Press Ctrl+B d to stop watching.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck share my-project                 # Watch read-only here")
+		fmt.Println("  agent-deck share my-project --print         # Show command for a teammate")
+		fmt.Println("  agent-deck share my-project --user alice    # Grant alice read-only access")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, false)
+
+	_, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	inst, errMsg, errCode := ResolveSessionOrCurrent(fs.Arg(0), instances)
+	if inst == nil {
+		out.Error(errMsg, errCode)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	tmuxSession := inst.GetTmuxSession()
+	if tmuxSession == nil || !inst.Exists() {
+		out.Error(fmt.Sprintf("session '%s' is not running", inst.Title), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	if *user != "" {
+		if err := tmuxSession.GrantReadOnlyAccess(*user); err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	}
+
+	if *printOnly || *jsonOutput || *user != "" {
+		socketPath, err := tmuxSession.ServerSocketPath()
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		command := tmuxSession.ReadOnlyAttachCommand(socketPath)
+
+		human := fmt.Sprintf("Read-only attach command for '%s':\n\n  %s\n", inst.Title, command)
+		if *user != "" {
+			human += fmt.Sprintf("\nGranted %s read-only access. They also need permission to open %s\n", *user, socketPath)
+		} else {
+			human += "\nOther users need access to the socket: run with --user <name> (tmux 3.3+)\n"
+		}
+		out.Print(human, map[string]interface{}{
+			"success": true,
+			"id":      inst.ID,
+			"title":   inst.Title,
+			"tmux":    tmuxSession.Name,
+			"socket":  socketPath,
+			"command": command,
+			"user":    *user,
+		})
+		return
+	}
+
+	if err := tmuxSession.AttachReadOnly(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to attach: %v\n", err)
+		os.Exit(1)
+	}
+}
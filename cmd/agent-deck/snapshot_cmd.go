@@ -0,0 +1,233 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleSnapshot dispatches snapshot subcommands
+func handleSnapshot(profile string, args []string) {
+	if len(args) == 0 {
+		printSnapshotHelp()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "save", "take":
+		handleSnapshotSave(profile, args[1:])
+	case "list", "ls":
+		handleSnapshotList(profile, args[1:])
+	case "show", "cat":
+		handleSnapshotShow(profile, args[1:])
+	case "diff":
+		handleSnapshotDiff(profile, args[1:])
+	case "delete", "rm", "remove":
+		handleSnapshotDelete(profile, args[1:])
+	case "help", "--help", "-h":
+		printSnapshotHelp()
+	default:
+		fmt.Printf("Unknown snapshot command: %s\n", args[0])
+		fmt.Println()
+		printSnapshotHelp()
+		os.Exit(1)
+	}
+}
+
+// printSnapshotHelp prints usage for snapshot commands
+func printSnapshotHelp() {
+	fmt.Println("Usage: agent-deck snapshot <command> [options]")
+	fmt.Println()
+	fmt.Println("Save a session's pane as a named snapshot and browse them later")
+	fmt.Println("(b and B in the TUI). Stored in ~/.agent-deck/snapshots/.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  save [id|title] [name]     Snapshot the visible pane (--history: whole scrollback)")
+	fmt.Println("  list [id|title]            List the session's snapshots, newest first")
+	fmt.Println("  show <id|title> <name>     Print a snapshot (name or time prefix, e.g. 20250304-15)")
+	fmt.Println("  diff [id|title] [name]     What changed in the pane since a snapshot (default: newest)")
+	fmt.Println("  delete <id|title> <name>   Delete a snapshot (alias: rm)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  agent-deck snapshot save my-project before-refactor")
+	fmt.Println("  agent-deck snapshot show my-project before-refactor | less")
+	fmt.Println("  agent-deck snapshot diff my-project")
+}
+
+// resolveSnapshotSession loads sessions and resolves identifier (or the
+// current session), exiting on failure
+func resolveSnapshotSession(profile, identifier string, out *CLIOutput) *session.Instance {
+	_, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	inst, errMsg, errCode := ResolveSessionOrCurrent(identifier, instances)
+	if inst == nil {
+		out.Error(errMsg, errCode)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+	return inst
+}
+
+func handleSnapshotSave(profile string, args []string) {
+	fs := flag.NewFlagSet("snapshot save", flag.ExitOnError)
+	history := fs.Bool("history", false, "Include the entire scrollback history, not just the visible screen")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	inst := resolveSnapshotSession(profile, fs.Arg(0), out)
+	name := ""
+	if fs.NArg() > 1 {
+		name = strings.Join(fs.Args()[1:], " ")
+	}
+	snap, err := session.SaveSnapshot(inst, name, *history, time.Now())
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	out.Success(fmt.Sprintf("Saved snapshot '%s' of '%s' to %s", snap.Name, inst.Title, FormatPath(snap.Path)), map[string]interface{}{
+		"success":  true,
+		"id":       inst.ID,
+		"title":    inst.Title,
+		"snapshot": snap,
+	})
+}
+
+func handleSnapshotList(profile string, args []string) {
+	fs := flag.NewFlagSet("snapshot list", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	inst := resolveSnapshotSession(profile, fs.Arg(0), out)
+	snaps, err := session.ListSnapshots(inst.ID)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	var sb strings.Builder
+	if len(snaps) == 0 {
+		sb.WriteString(fmt.Sprintf("No snapshots of '%s'. Take one with: agent-deck snapshot save %q <name>\n", inst.Title, inst.Title))
+	} else {
+		sb.WriteString(fmt.Sprintf("Snapshots of '%s':\n", inst.Title))
+	}
+	for _, s := range snaps {
+		sb.WriteString(fmt.Sprintf("  %-24s %s  %d KB\n", s.Name, s.CreatedAt.Format("2006-01-02 15:04:05"), (s.Size+1023)/1024))
+	}
+	if snaps == nil {
+		snaps = []session.Snapshot{}
+	}
+	out.Print(sb.String(), snaps)
+}
+
+func handleSnapshotShow(profile string, args []string) {
+	fs := flag.NewFlagSet("snapshot show", flag.ExitOnError)
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(false, false)
+	if fs.NArg() < 2 {
+		out.Error("usage: agent-deck snapshot show <id|title> <name>", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	inst := resolveSnapshotSession(profile, fs.Arg(0), out)
+	snap, err := session.FindSnapshot(inst.ID, fs.Arg(1))
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(2)
+	}
+	content, err := snap.Content()
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	fmt.Print(content)
+}
+
+func handleSnapshotDiff(profile string, args []string) {
+	fs := flag.NewFlagSet("snapshot diff", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	inst := resolveSnapshotSession(profile, fs.Arg(0), out)
+	var snap *session.Snapshot
+	if fs.NArg() > 1 {
+		found, err := session.FindSnapshot(inst.ID, fs.Arg(1))
+		if err != nil {
+			out.Error(err.Error(), ErrCodeNotFound)
+			os.Exit(2)
+		}
+		snap = found
+	} else {
+		snaps, err := session.ListSnapshots(inst.ID)
+		if err != nil || len(snaps) == 0 {
+			out.Error(fmt.Sprintf("no snapshots of '%s'", inst.Title), ErrCodeNotFound)
+			os.Exit(2)
+		}
+		snap = &snaps[0]
+	}
+
+	diff, added, err := session.DiffSnapshot(inst, snap)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	out.Print(diff, map[string]interface{}{
+		"id":       inst.ID,
+		"title":    inst.Title,
+		"snapshot": snap.Name,
+		"added":    added,
+		"diff":     diff,
+	})
+}
+
+func handleSnapshotDelete(profile string, args []string) {
+	fs := flag.NewFlagSet("snapshot delete", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	if fs.NArg() < 2 {
+		out.Error("usage: agent-deck snapshot delete <id|title> <name>", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	inst := resolveSnapshotSession(profile, fs.Arg(0), out)
+	snap, err := session.FindSnapshot(inst.ID, fs.Arg(1))
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(2)
+	}
+	if err := snap.Delete(); err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	out.Success(fmt.Sprintf("Deleted snapshot '%s' of '%s'", snap.Name, inst.Title), map[string]interface{}{
+		"success":  true,
+		"id":       inst.ID,
+		"snapshot": snap.Name,
+	})
+}
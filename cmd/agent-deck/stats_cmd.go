@@ -0,0 +1,205 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// countEntry is one row of a stats breakdown
+type countEntry struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// idleEntry is an idle session and how long since its last output
+type idleEntry struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Group       string    `json:"group"`
+	Tool        string    `json:"tool"`
+	LastActive  time.Time `json:"last_active"`
+	IdleSeconds int64     `json:"idle_seconds"`
+}
+
+// handleStats prints deck-wide metrics for health checks and scripts
+func handleStats(profile string, args []string) {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	jsonOutput := flags.Bool("json", false, "Output as JSON")
+	limit := flags.Int("limit", 5, "Number of oldest idle sessions to list")
+
+	flags.Usage = func() {
+		fmt.Println("Usage: agent-deck stats [options]")
+		fmt.Println()
+		fmt.Println("Show deck-wide metrics: sessions by status, tool and group, the")
+		fmt.Println("sessions idle the longest, and disk used by logs.")
+		fmt.Println()
+		fmt.Println("Options:")
+		flags.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck stats")
+		fmt.Println("  agent-deck stats --json | jq .by_status")
+	}
+
+	if err := flags.Parse(normalizeArgs(flags, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	storage, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	tmux.RefreshSessionCache()
+	counts := countByStatus(instances) // also refreshes each status from tmux
+
+	byStatus := make(map[string]int)
+	byTool := make(map[string]int)
+	byGroup := make(map[string]int)
+	var idle []idleEntry
+	now := time.Now()
+	for _, inst := range instances {
+		byStatus[string(inst.Status)]++
+		tool := inst.Tool
+		if tool == "" {
+			tool = "shell"
+		}
+		byTool[tool]++
+		group := inst.GroupPath
+		if group == "" {
+			group = session.DefaultGroupPath
+		}
+		byGroup[group]++
+		if inst.Status == session.StatusIdle {
+			last := lastOutputTime(inst)
+			idle = append(idle, idleEntry{
+				ID:          inst.ID,
+				Title:       inst.Title,
+				Group:       group,
+				Tool:        tool,
+				LastActive:  last,
+				IdleSeconds: int64(now.Sub(last).Seconds()),
+			})
+		}
+	}
+	sort.Slice(idle, func(i, j int) bool { return idle[i].LastActive.Before(idle[j].LastActive) })
+	if *limit >= 0 && len(idle) > *limit {
+		idle = idle[:*limit]
+	}
+
+	logDir := tmux.LogDir()
+	logBytes, logFiles := dirUsage(logDir)
+
+	jsonData := map[string]interface{}{
+		"profile":     storage.Profile(),
+		"total":       counts.total,
+		"by_status":   byStatus,
+		"by_tool":     sortedCounts(byTool),
+		"by_group":    sortedCounts(byGroup),
+		"oldest_idle": idle,
+		"logs": map[string]interface{}{
+			"path":  logDir,
+			"bytes": logBytes,
+			"files": logFiles,
+		},
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Profile: %s (%d sessions)\n\n", storage.Profile(), counts.total)
+	fmt.Fprintf(&b, "Status:  %d waiting • %d running • %d idle • %d error\n",
+		counts.waiting, counts.running, counts.idle, counts.err)
+	b.WriteString("Tools:   " + formatCounts(sortedCounts(byTool)) + "\n")
+	b.WriteString("\nGroups:\n")
+	for _, g := range sortedCounts(byGroup) {
+		fmt.Fprintf(&b, "  %s %d\n", tableCell(g.Name, tableColTitle+tableColGroup), g.Count)
+	}
+	if len(idle) > 0 {
+		b.WriteString("\nIdle longest:\n")
+		for _, e := range idle {
+			fmt.Fprintf(&b, "  %s %s %s\n", tableCell(e.Title, tableColTitle), tableCell(e.Group, tableColGroup),
+				formatIdleDuration(now.Sub(e.LastActive)))
+		}
+	}
+	fmt.Fprintf(&b, "\nLogs:    %s in %d files (%s)\n", formatSize(logBytes), logFiles, logDir)
+
+	out.Print(b.String(), jsonData)
+}
+
+// lastOutputTime returns when the session last printed output: tmux's
+// window activity if the session is running, else when it was last attached
+// or created
+func lastOutputTime(inst *session.Instance) time.Time {
+	if ts := inst.GetTmuxSession(); ts != nil {
+		if activity := ts.GetCachedWindowActivity(); activity > 0 {
+			return time.Unix(activity, 0)
+		}
+	}
+	if inst.LastAccessedAt.After(inst.CreatedAt) {
+		return inst.LastAccessedAt
+	}
+	return inst.CreatedAt
+}
+
+// dirUsage returns the total size and number of regular files under dir
+func dirUsage(dir string) (int64, int) {
+	var total int64
+	var files int
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			total += info.Size()
+			files++
+		}
+		return nil
+	})
+	return total, files
+}
+
+// sortedCounts orders counts highest first, then by name
+func sortedCounts(counts map[string]int) []countEntry {
+	entries := make([]countEntry, 0, len(counts))
+	for name, n := range counts {
+		entries = append(entries, countEntry{Name: name, Count: n})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// formatCounts joins counts as "claude 4 • shell 2"
+func formatCounts(entries []countEntry) string {
+	parts := make([]string, len(entries))
+	for i, e := range entries {
+		parts[i] = fmt.Sprintf("%s %d", e.Name, e.Count)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatIdleDuration formats an idle time coarsely: "45m", "3h 10m", "2d 4h"
+func formatIdleDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFormatIdleDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{45 * time.Minute, "45m"},
+		{3*time.Hour + 10*time.Minute, "3h 10m"},
+		{52 * time.Hour, "2d 4h"},
+	}
+	for _, tt := range tests {
+		if got := formatIdleDuration(tt.d); got != tt.want {
+			t.Errorf("formatIdleDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestDirUsage(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "mcppool"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.log"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "mcppool", "b.log"), make([]byte, 50), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if size, files := dirUsage(dir); size != 150 || files != 2 {
+		t.Errorf("dirUsage = %d bytes in %d files, want 150 in 2", size, files)
+	}
+	if size, files := dirUsage(filepath.Join(dir, "missing")); size != 0 || files != 0 {
+		t.Errorf("missing dir should be empty, got %d/%d", size, files)
+	}
+}
+
+func TestSortedCounts(t *testing.T) {
+	got := sortedCounts(map[string]int{"shell": 2, "claude": 4, "aider": 2})
+	want := []string{"claude", "aider", "shell"}
+	for i, name := range want {
+		if got[i].Name != name {
+			t.Fatalf("sortedCounts order = %+v, want %v", got, want)
+		}
+	}
+	if s := formatCounts(got); s != "claude 4 • aider 2 • shell 2" {
+		t.Errorf("formatCounts = %q", s)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// liveStatus is what tmux reports about one session right now
+// (agent-deck status <id>, status -v)
+type liveStatus struct {
+	ID           string     `json:"id"`
+	Title        string     `json:"title"`
+	Tool         string     `json:"tool"`
+	Status       string     `json:"status"`
+	Exists       bool       `json:"exists"`
+	TmuxSession  string     `json:"tmux_session,omitempty"`
+	PanePID      int        `json:"pane_pid,omitempty"`
+	PaneCommand  string     `json:"pane_command,omitempty"`
+	LastActivity *time.Time `json:"last_activity,omitempty"`
+}
+
+// sessionLiveStatus queries tmux for inst, whose status has been updated.
+// Call tmux.RefreshSessionCache first so the pane details of every session
+// come from a single query.
+func sessionLiveStatus(inst *session.Instance) liveStatus {
+	live := liveStatus{
+		ID:     inst.ID,
+		Title:  inst.Title,
+		Tool:   inst.Tool,
+		Status: StatusString(inst.Status),
+		Exists: inst.Exists(),
+	}
+	tmuxSess := inst.GetTmuxSession()
+	if !live.Exists || tmuxSess == nil {
+		return live
+	}
+	live.TmuxSession = tmuxSess.Name
+	if info, ok := tmuxSess.CachedPaneInfo(); ok {
+		live.PanePID = info.PID
+		live.PaneCommand = info.Command
+		if info.Activity > 0 {
+			at := time.Unix(info.Activity, 0)
+			live.LastActivity = &at
+		}
+	}
+	return live
+}
+
+// liveStatuses queries tmux for every session in one batch, after their
+// statuses have been updated
+func liveStatuses(instances []*session.Instance) []liveStatus {
+	tmux.RefreshSessionCache()
+	result := make([]liveStatus, len(instances))
+	for n, inst := range instances {
+		result[n] = sessionLiveStatus(inst)
+	}
+	return result
+}
+
+// formatLastActivity formats a last activity time: "3m12s ago (15:04:05)"
+func formatLastActivity(at *time.Time) string {
+	if at == nil {
+		return "-"
+	}
+	layout := "15:04:05"
+	if time.Since(*at) > 24*time.Hour {
+		layout = "2006-01-02 15:04"
+	}
+	return fmt.Sprintf("%s ago (%s)", formatRunDuration(time.Since(*at)), at.Format(layout))
+}
+
+// formatLiveStatus renders one session's live status for the terminal
+func formatLiveStatus(live liveStatus, status session.Status) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Session:  %s\n", live.Title))
+	sb.WriteString(fmt.Sprintf("ID:       %s\n", live.ID))
+	sb.WriteString(fmt.Sprintf("Status:   %s %s\n", StatusSymbol(status), live.Status))
+	if !live.Exists {
+		sb.WriteString("Tmux:     not running\n")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("Tmux:     %s\n", live.TmuxSession))
+	if live.PanePID > 0 {
+		sb.WriteString(fmt.Sprintf("PID:      %d", live.PanePID))
+		if live.PaneCommand != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", live.PaneCommand))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("Activity: %s\n", formatLastActivity(live.LastActivity)))
+	return sb.String()
+}
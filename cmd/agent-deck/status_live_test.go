@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestFormatLiveStatus(t *testing.T) {
+	at := time.Now().Add(-90 * time.Second)
+	live := liveStatus{
+		ID:           "abc",
+		Title:        "api",
+		Status:       "waiting",
+		Exists:       true,
+		TmuxSession:  "agentdeck_api_1234",
+		PanePID:      4242,
+		PaneCommand:  "claude",
+		LastActivity: &at,
+	}
+	got := formatLiveStatus(live, session.StatusWaiting)
+	for _, want := range []string{"Tmux:     agentdeck_api_1234", "PID:      4242 (claude)", "1m30s ago"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+
+	stopped := formatLiveStatus(liveStatus{Title: "api", Status: "error"}, session.StatusError)
+	if !strings.Contains(stopped, "Tmux:     not running") || strings.Contains(stopped, "PID:") {
+		t.Errorf("stopped session:\n%s", stopped)
+	}
+}
+
+func TestFormatLastActivity(t *testing.T) {
+	if got := formatLastActivity(nil); got != "-" {
+		t.Errorf("nil = %q", got)
+	}
+	old := time.Date(2026, 1, 2, 3, 4, 0, 0, time.Local)
+	if got := formatLastActivity(&old); !strings.HasSuffix(got, "(2026-01-02 03:04)") {
+		t.Errorf("old = %q", got)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleSync shares the profile's sessions and config.toml through a git remote
+func handleSync(profile string, args []string) {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	remote := fs.String("remote", "", "Git remote URL (default: [sync] remote in config.toml)")
+	path := fs.String("path", "", "Synced folder, e.g. in Dropbox or iCloud Drive (default: [sync] path)")
+	branch := fs.String("branch", "", "Branch to sync on (default: [sync] branch or main)")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck sync [options]")
+		fmt.Println()
+		fmt.Println("Share sessions and config.toml between machines through a git remote or a")
+		fmt.Println("folder synced by Dropbox, iCloud Drive or Syncthing. Sessions are merged")
+		fmt.Println("three-way against the last sync: the later edit of a session wins, and")
+		fmt.Println("deletions stick instead of the session coming back from another machine.")
+		fmt.Println("config.toml follows whichever side changed since the last sync.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Config:")
+		fmt.Println("  [sync]")
+		fmt.Println("  remote = \"git@github.com:me/agent-deck-sync.git\"   # or:")
+		fmt.Println("  path = \"~/Dropbox/agent-deck\"")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck sync")
+		fmt.Println("  agent-deck -p work sync --json")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	settings := session.GetSyncSettings()
+	if *remote != "" {
+		settings.Remote, settings.Path = *remote, ""
+	}
+	if *path != "" {
+		settings.Path = *path
+	}
+	if *branch != "" {
+		settings.Branch = *branch
+	}
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to open storage: %v", err), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	defer storage.Close()
+
+	result, err := session.Sync(storage, settings)
+	if err != nil {
+		out.Error(fmt.Sprintf("sync failed: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	var sb strings.Builder
+	target := settings.Remote
+	if settings.Path != "" {
+		target = settings.Path
+	}
+	fmt.Fprintf(&sb, "%s Synced profile '%s' with %s\n", successSymbol, storage.Profile(), target)
+	fmt.Fprintf(&sb, "  %s Sessions: %d added, %d updated, %d deleted\n", bulletSymbol, result.Added, result.Updated, result.Deleted)
+	if result.AddedGroups+result.DeletedGroups > 0 {
+		fmt.Fprintf(&sb, "  %s Groups: %d added, %d deleted\n", bulletSymbol, result.AddedGroups, result.DeletedGroups)
+	}
+	if result.ConflictCopies > 0 {
+		fmt.Fprintf(&sb, "  %s Merged and removed %d conflicted cop(ies) left by the sync service\n", bulletSymbol, result.ConflictCopies)
+	}
+	switch {
+	case result.ConfigPulled:
+		fmt.Fprintf(&sb, "  %s config.toml updated from the remote\n", bulletSymbol)
+	case result.ConfigPushed:
+		fmt.Fprintf(&sb, "  %s config.toml pushed\n", bulletSymbol)
+	case result.ConfigConflict:
+		syncDir, _ := session.GetSyncDir()
+		fmt.Fprintf(&sb, "  %s config.toml changed here and on the remote; left both unchanged\n", bulletSymbol)
+		fmt.Fprintf(&sb, "    (compare with %s; the next sync pushes yours)\n", filepath.Join(syncDir, "config.toml"))
+	}
+	if !result.Pushed {
+		fmt.Fprintf(&sb, "  %s Remote already up to date\n", bulletSymbol)
+	}
+
+	out.Print(sb.String(), map[string]interface{}{
+		"success": true,
+		"profile": storage.Profile(),
+		"target":  target,
+		"branch":  settings.GetBranch(),
+		"result":  result,
+	})
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// handleTail streams a session's live output to stdout without attaching
+func handleTail(profile string, args []string) {
+	fs := flag.NewFlagSet("tail", flag.ExitOnError)
+	lines := fs.Int("lines", 20, "Lines of existing output to print before following")
+	linesShort := fs.Int("n", -1, "Lines of existing output (short)")
+	plain := fs.Bool("plain", false, "Strip ANSI escape sequences from the stream")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck tail [id|title] [options]")
+		fmt.Println()
+		fmt.Println("Follow a session's output in real time (read-only, never sends input).")
+		fmt.Println("Press Ctrl+C to stop.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck tail my-project")
+		fmt.Println("  agent-deck tail my-project -n 100 --plain | tee agent.log")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	n := *lines
+	if *linesShort >= 0 {
+		n = *linesShort
+	}
+
+	inst, errMsg, errCode, err := loadResolvedSession(profile, fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if inst == nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", errMsg)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	tmuxSession := inst.GetTmuxSession()
+	if tmuxSession == nil || !inst.Exists() {
+		fmt.Fprintf(os.Stderr, "Error: session '%s' is not running\n", inst.Title)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := tmuxSession.Tail(ctx, os.Stdout, tmux.TailOptions{Lines: n, Plain: *plain}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
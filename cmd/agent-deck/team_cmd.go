@@ -0,0 +1,254 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleTeam dispatches team deck subcommands
+func handleTeam(profile string, args []string) {
+	if len(args) == 0 {
+		printTeamHelp()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		handleTeamList(profile, args[1:])
+	case "pull":
+		handleTeamPull(args[1:])
+	case "add":
+		handleTeamAdd(profile, args[1:])
+	case "help", "--help", "-h":
+		printTeamHelp()
+	default:
+		fmt.Printf("Unknown team command: %s\n", args[0])
+		fmt.Println()
+		printTeamHelp()
+		os.Exit(1)
+	}
+}
+
+// printTeamHelp prints usage for team deck commands
+func printTeamHelp() {
+	fmt.Println("Usage: agent-deck team <command> [options]")
+	fmt.Println()
+	fmt.Println("Add standard sessions a team publishes in a shared deck: a file or a git")
+	fmt.Println("repository holding an 'agent-deck export', set up in config.toml. Team decks")
+	fmt.Println("are read-only; their sessions are added to your deck under team/<name>.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  list [deck]                 List the team decks' sessions, marking those added")
+	fmt.Println("  pull [deck]                 Fetch the latest version of git team decks")
+	fmt.Println("  add <deck> [session|group]  Add the deck's sessions (all, or those named)")
+	fmt.Println()
+	fmt.Println("Config:")
+	fmt.Println("  [[team_decks]]")
+	fmt.Println("  name = \"platform\"")
+	fmt.Println("  source = \"git@github.com:acme/agent-decks.git\"   # or a file path")
+	fmt.Println("  file = \"platform.json\"")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  agent-deck team list")
+	fmt.Println("  agent-deck team add platform oncall --map /home/alice/code=~/src -y")
+}
+
+// teamDecksArg returns the configured team decks, or the one named by args
+func teamDecksArg(args []string, out *CLIOutput) []session.TeamDeckDef {
+	if len(args) > 0 {
+		def, err := session.FindTeamDeck(args[0])
+		if err != nil {
+			out.Error(err.Error(), ErrCodeNotFound)
+			os.Exit(2)
+		}
+		return []session.TeamDeckDef{def}
+	}
+	decks := session.GetTeamDecks()
+	if len(decks) == 0 {
+		out.Error("no team decks configured (add a [[team_decks]] entry to config.toml)", ErrCodeNotFound)
+		os.Exit(2)
+	}
+	return decks
+}
+
+// teamSessionJSON is a team deck session in `team list --json`
+type teamSessionJSON struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Group string `json:"group"`
+	Path  string `json:"path"`
+	Tool  string `json:"tool"`
+	Added bool   `json:"added"`
+}
+
+func handleTeamList(profile string, args []string) {
+	fs := flag.NewFlagSet("team list", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+	defs := teamDecksArg(fs.Args(), out)
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to open storage: %v", err), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	defer storage.Close()
+	local, err := storage.LoadStorageData()
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	added := make(map[string]bool, len(local.Instances))
+	for _, inst := range local.Instances {
+		added[inst.ID] = true
+	}
+
+	var sb strings.Builder
+	decks := make([]map[string]interface{}, 0, len(defs))
+	for _, def := range defs {
+		deck, err := session.LoadTeamDeck(def)
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		fmt.Fprintf(&sb, "%s (%s, added under %s)\n", deck.Name, def.Source, deck.Namespace())
+		sessions := make([]teamSessionJSON, 0, len(deck.Data.Instances))
+		for _, inst := range deck.Data.Instances {
+			mark := " "
+			if added[inst.ID] {
+				mark = successSymbol
+			}
+			fmt.Fprintf(&sb, "  %s %-24s %-20s %s\n", mark, inst.Title, inst.GroupPath, FormatPath(inst.ProjectPath))
+			sessions = append(sessions, teamSessionJSON{
+				ID: inst.ID, Title: inst.Title, Group: inst.GroupPath, Path: inst.ProjectPath, Tool: inst.Tool, Added: added[inst.ID],
+			})
+		}
+		decks = append(decks, map[string]interface{}{
+			"name":      deck.Name,
+			"source":    def.Source,
+			"namespace": deck.Namespace(),
+			"sessions":  sessions,
+		})
+	}
+	out.Print(sb.String(), decks)
+}
+
+func handleTeamPull(args []string) {
+	fs := flag.NewFlagSet("team pull", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	var names []string
+	for _, def := range teamDecksArg(fs.Args(), out) {
+		if err := session.PullTeamDeck(def); err != nil {
+			out.Error(fmt.Sprintf("failed to pull team deck '%s': %v", def.Name, err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		names = append(names, def.Name)
+	}
+	out.Success(fmt.Sprintf("Pulled team decks: %s", strings.Join(names, ", ")), map[string]interface{}{
+		"success": true,
+		"decks":   names,
+	})
+}
+
+func handleTeamAdd(profile string, args []string) {
+	fs := flag.NewFlagSet("team add", flag.ExitOnError)
+	remap := addRemapFlags(fs)
+	yes := fs.Bool("yes", false, "Don't ask: keep unmapped paths and hosts, or use the same path under your home when it exists")
+	yesShort := fs.Bool("y", false, "Don't ask (short)")
+	dryRun := fs.Bool("dry-run", false, "Show what would be added without saving")
+	withHooks := fs.Bool("with-hooks", false, "Keep the sessions' hooks without asking (they run shell commands here)")
+	jsonOutput := fs.Bool("json", false, "Output as JSON (implies --yes)")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck team add <deck> [session|group...] [options]")
+		fmt.Println()
+		fmt.Println("Add a team deck's sessions to your deck under team/<deck>: all of them, or")
+		fmt.Println("those with the given titles or in the given groups. Paths and hosts are")
+		fmt.Println("remapped as by 'agent-deck import'. Sessions already added are skipped.")
+		fmt.Println("As with import, hooks are dropped unless you keep them or pass --with-hooks.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	if fs.NArg() < 1 {
+		out.Error("usage: agent-deck team add <deck> [session|group...]", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	def, err := session.FindTeamDeck(fs.Arg(0))
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(2)
+	}
+	deck, err := session.LoadTeamDeck(def)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	data, err := deck.Select(fs.Args()[1:])
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(2)
+	}
+
+	interactive := !*yes && !*yesShort && !*jsonOutput && term.IsTerminal(int(os.Stdin.Fd()))
+	askImportRemap(data, remap, interactive)
+	remapped := session.ApplyImportRemap(data, remap)
+	hooksDropped := reviewImportCommands(data, *withHooks, interactive && !*dryRun, !*jsonOutput && !*quiet && !*quietShort)
+
+	if *dryRun {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Would add %d sessions under %s:\n", len(data.Instances), deck.Namespace())
+		for _, inst := range data.Instances {
+			fmt.Fprintf(&sb, "  %-24s %s\n", inst.Title, FormatPath(inst.ProjectPath))
+		}
+		out.Print(sb.String(), data)
+		return
+	}
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to open storage: %v", err), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	defer storage.Close()
+	result, err := storage.ImportStorageData(data, deck.Namespace())
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to add team sessions: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	result.Remapped = remapped
+	result.HooksDropped = hooksDropped
+
+	msg := fmt.Sprintf("Added %d sessions from team deck '%s' under %s", result.Added, deck.Name, deck.Namespace())
+	if result.Skipped > 0 {
+		msg += fmt.Sprintf(" (%d already added)", result.Skipped)
+	}
+	out.Success(msg, map[string]interface{}{
+		"success": true,
+		"team":    result,
+	})
+}
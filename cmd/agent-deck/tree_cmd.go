@@ -0,0 +1,257 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// deckTree is the group/session hierarchy printed by `agent-deck tree`
+type deckTree struct {
+	groups   *session.GroupTree
+	children map[string][]*session.Group // parent path ("" = top level) -> subgroups
+	status   bool                        // show session statuses
+}
+
+// newDeckTree indexes the groups of tree by parent
+func newDeckTree(tree *session.GroupTree, status bool) *deckTree {
+	t := &deckTree{groups: tree, children: make(map[string][]*session.Group), status: status}
+	for _, g := range tree.GroupList {
+		parent := getParentGroupPath(g.Path)
+		t.children[parent] = append(t.children[parent], g)
+	}
+	return t
+}
+
+// roots returns the groups the tree starts from: the top-level groups, or
+// only root when one is given
+func (t *deckTree) roots(root string) []*session.Group {
+	if root != "" {
+		return []*session.Group{t.groups.Groups[root]}
+	}
+	return t.children[""]
+}
+
+// sessionChildren returns the sessions listed directly under g, with each
+// sub-session under its parent when both are in g
+func (t *deckTree) sessionChildren(g *session.Group, parentID string) []*session.Instance {
+	inGroup := make(map[string]bool, len(g.Sessions))
+	for _, inst := range g.Sessions {
+		inGroup[inst.ID] = true
+	}
+	var list []*session.Instance
+	for _, inst := range g.Sessions {
+		parent := ""
+		if inst.IsSubSession() && inGroup[inst.ParentSessionID] && inst.ParentSessionID != inst.ID {
+			parent = inst.ParentSessionID
+		}
+		if parent == parentID {
+			list = append(list, inst)
+		}
+	}
+	return list
+}
+
+// sessionLabel is a session's line in the text tree
+func (t *deckTree) sessionLabel(inst *session.Instance) string {
+	label := inst.Title + "  " + inst.Tool
+	if t.status {
+		label = StatusSymbol(inst.GetStatusThreadSafe()) + " " + label
+	}
+	return label
+}
+
+// groupLabel is a group's line in the text tree
+func (t *deckTree) groupLabel(g *session.Group) string {
+	label := fmt.Sprintf("%s (%d)", g.Name, t.groups.SessionCountForGroup(g.Path))
+	if g.Hidden {
+		label += " (hidden)"
+	}
+	return label
+}
+
+// Text renders the tree with box-drawing branches, one root per top-level
+// group
+func (t *deckTree) Text(root string) string {
+	var b strings.Builder
+	for _, g := range t.roots(root) {
+		b.WriteString(t.groupLabel(g) + "\n")
+		t.writeGroup(&b, g, "")
+	}
+	return cliText(b.String())
+}
+
+// writeGroup writes g's sessions and subgroups below it, indented by prefix
+func (t *deckTree) writeGroup(b *strings.Builder, g *session.Group, prefix string) {
+	sessions := t.sessionChildren(g, "")
+	subgroups := t.children[g.Path]
+	n := len(sessions) + len(subgroups)
+	i := 0
+	for _, inst := range sessions {
+		i++
+		branch, next := treeBranch(prefix, i == n)
+		b.WriteString(branch + t.sessionLabel(inst) + "\n")
+		t.writeSubSessions(b, g, inst, next)
+	}
+	for _, sub := range subgroups {
+		i++
+		branch, next := treeBranch(prefix, i == n)
+		b.WriteString(branch + t.groupLabel(sub) + "\n")
+		t.writeGroup(b, sub, next)
+	}
+}
+
+// writeSubSessions writes the sub-sessions of parent within g
+func (t *deckTree) writeSubSessions(b *strings.Builder, g *session.Group, parent *session.Instance, prefix string) {
+	subs := t.sessionChildren(g, parent.ID)
+	for i, inst := range subs {
+		branch, next := treeBranch(prefix, i == len(subs)-1)
+		b.WriteString(branch + t.sessionLabel(inst) + "\n")
+		t.writeSubSessions(b, g, inst, next)
+	}
+}
+
+// treeBranch returns the branch for an entry and the prefix for its children
+func treeBranch(prefix string, last bool) (branch, next string) {
+	if last {
+		return prefix + "└── ", prefix + "    "
+	}
+	return prefix + "├── ", prefix + "│   "
+}
+
+// dotStatusColors fill session nodes by status, matching the TUI's palette
+var dotStatusColors = map[session.Status]string{
+	session.StatusRunning: "#9ece6a",
+	session.StatusWaiting: "#e0af68",
+	session.StatusIdle:    "#c0caf5",
+	session.StatusError:   "#f7768e",
+}
+
+// Dot renders the tree as a Graphviz digraph: groups are folders, sessions
+// boxes filled by status
+func (t *deckTree) Dot(root string) string {
+	var b strings.Builder
+	b.WriteString("digraph agentdeck {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"Helvetica\"];\n")
+	for _, g := range t.roots(root) {
+		t.writeDotGroup(&b, g)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// writeDotGroup writes g's node, its sessions and subgroups, and the edges
+// to them
+func (t *deckTree) writeDotGroup(b *strings.Builder, g *session.Group) {
+	id := dotQuote("group:" + g.Path)
+	style := ""
+	if g.Hidden {
+		style = ", style=dashed"
+	}
+	fmt.Fprintf(b, "  %s [label=%s, shape=folder%s];\n", id, dotQuote(g.Name), style)
+
+	inGroup := make(map[string]bool, len(g.Sessions))
+	for _, inst := range g.Sessions {
+		inGroup[inst.ID] = true
+	}
+	for _, inst := range g.Sessions {
+		sid := dotQuote("session:" + inst.ID)
+		attrs := fmt.Sprintf("label=%s, shape=box", dotQuote(inst.Title+"\n"+inst.Tool))
+		if color, ok := dotStatusColors[inst.GetStatusThreadSafe()]; ok && t.status {
+			attrs += fmt.Sprintf(", style=filled, fillcolor=%s", dotQuote(color))
+		}
+		fmt.Fprintf(b, "  %s [%s];\n", sid, attrs)
+		from := id
+		if inst.IsSubSession() && inGroup[inst.ParentSessionID] && inst.ParentSessionID != inst.ID {
+			from = dotQuote("session:" + inst.ParentSessionID)
+		}
+		fmt.Fprintf(b, "  %s -> %s;\n", from, sid)
+	}
+	for _, sub := range t.children[g.Path] {
+		t.writeDotGroup(b, sub)
+		fmt.Fprintf(b, "  %s -> %s;\n", id, dotQuote("group:"+sub.Path))
+	}
+}
+
+// dotQuote quotes s as a DOT string, keeping newlines as line breaks
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+// handleTree prints the group/session hierarchy as an ASCII tree or a
+// Graphviz graph
+func handleTree(profile string, args []string) {
+	fs := flag.NewFlagSet("tree", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text or dot (Graphviz)")
+	noStatus := fs.Bool("no-status", false, "Leave out session statuses (skips checking tmux)")
+	output := fs.String("output", "", "Write to this file instead of stdout")
+	outputShort := fs.String("o", "", "Write to this file (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck tree [group] [options]")
+		fmt.Println()
+		fmt.Println("Print the group and session hierarchy, e.g. to document or share a setup.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck tree                                  # Whole deck with statuses")
+		fmt.Println("  agent-deck tree work --no-status                 # One group, structure only")
+		fmt.Println("  agent-deck tree --format dot | dot -Tsvg > deck.svg")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	if *format != "text" && *format != "dot" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use text or dot)\n", *format)
+		os.Exit(1)
+	}
+
+	_, instances, groupsData, err := loadSessionData(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	groupTree := session.NewGroupTreeWithGroups(instances, groupsData)
+
+	root := ""
+	if name := fs.Arg(0); name != "" {
+		path, ok := resolveGroupPath(groupTree, name)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: group '%s' not found\n", name)
+			os.Exit(2)
+		}
+		root = path
+	}
+
+	if !*noStatus {
+		for _, inst := range instances {
+			_ = inst.UpdateStatus()
+		}
+	}
+
+	tree := newDeckTree(groupTree, !*noStatus)
+	content := tree.Text(root)
+	if *format == "dot" {
+		content = tree.Dot(root)
+	}
+
+	outPath := mergeFlags(*output, *outputShort)
+	if outPath == "" {
+		fmt.Print(content)
+		return
+	}
+	if err := os.WriteFile(outPath, []byte(content), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Saved tree to %s\n", outPath)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func newTreeTestGroups() *session.GroupTree {
+	api := session.NewInstance("api", "/tmp/api")
+	api.ID = "api1"
+	api.Tool = "claude"
+	api.GroupPath = "work"
+	api.Status = session.StatusRunning
+	review := session.NewInstance("review", "/tmp/api")
+	review.ID = "rev1"
+	review.Tool = "claude"
+	review.GroupPath = "work"
+	review.ParentSessionID = "api1"
+	ui := session.NewInstance("ui", "/tmp/ui")
+	ui.ID = "ui1"
+	ui.Tool = "shell"
+	ui.GroupPath = "work/frontend"
+	ui.Status = session.StatusWaiting
+	return session.NewGroupTree([]*session.Instance{api, review, ui})
+}
+
+func TestDeckTreeText(t *testing.T) {
+	tree := newDeckTree(newTreeTestGroups(), true)
+	got := tree.Text("")
+	want := "work (3)\n" +
+		"├── ● api  claude\n" +
+		"│   └── ○ review  claude\n" +
+		"└── frontend (1)\n" +
+		"    └── ◐ ui  shell\n"
+	if got != want {
+		t.Errorf("Text() =\n%s\nwant\n%s", got, want)
+	}
+
+	sub := newDeckTree(newTreeTestGroups(), false).Text("work/frontend")
+	if sub != "frontend (1)\n└── ui  shell\n" {
+		t.Errorf("Text(work/frontend) without status = %q", sub)
+	}
+}
+
+func TestDeckTreeDot(t *testing.T) {
+	dot := newDeckTree(newTreeTestGroups(), true).Dot("")
+	for _, want := range []string{
+		"digraph agentdeck {",
+		`"group:work" [label="work", shape=folder];`,
+		`"session:api1" [label="api\nclaude", shape=box, style=filled, fillcolor="#9ece6a"];`,
+		`"session:api1" -> "session:rev1";`,
+		`"group:work" -> "group:work/frontend";`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("Dot() missing %s\n%s", want, dot)
+		}
+	}
+	if got := dotQuote(`say "hi"\`); got != `"say \"hi\"\\"` {
+		t.Errorf("dotQuote = %s", got)
+	}
+}
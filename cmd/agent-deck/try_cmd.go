@@ -9,6 +9,7 @@ import (
 
 	"github.com/asheshgoplani/agent-deck/internal/experiments"
 	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/ui"
 )
 
 // handleTry handles the 'try' subcommand for quick experiments
@@ -117,7 +118,7 @@ func handleTry(profile string, args []string) {
 
 	// Check if session already exists for this path
 	for _, inst := range instances {
-		if inst.ProjectPath == exp.Path {
+		if session.SamePath(inst.ProjectPath, exp.Path) {
 			// Session exists - just start it if not running
 			if !inst.Exists() {
 				if err := inst.Start(); err != nil {
@@ -231,12 +232,7 @@ func handleTryList(dir, query string, jsonOutput bool) {
 		if exp.HasDate {
 			date = exp.Date.Format("2006-01-02")
 		}
-		// Truncate path for display
-		path := exp.Path
-		if len(path) > 30 {
-			path = "..." + path[len(path)-27:]
-		}
-		fmt.Printf("%-25s %-12s %s\n", truncate(exp.Name, 25), date, path)
+		fmt.Printf("%s %-12s %s\n", tableCell(exp.Name, 25), date, ui.ShortenPath(exp.Path, 30))
 	}
 	fmt.Printf("\nTotal: %d experiments\n", len(exps))
 }
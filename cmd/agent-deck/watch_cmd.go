@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/daemon"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleWatch prints session status changes as they happen, streamed from
+// a running `agent-deck daemon`
+func handleWatch(args []string) {
+	settings := session.GetDaemonSettings()
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	url := fs.String("url", "", "Daemon address (default: from [daemon] listen)")
+	token := fs.String("token", settings.Token, "Daemon token, if it requires one")
+	jsonOutput := fs.Bool("json", false, "Print each event as a JSON line")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck watch [options]")
+		fmt.Println()
+		fmt.Println("Print session status changes in real time from the daemon's /events")
+		fmt.Println("stream. Start the daemon first with 'agent-deck daemon'.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck watch")
+		fmt.Println("  agent-deck watch --json | jq 'select(.to == \"waiting\")'")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	base := *url
+	if base == "" {
+		base = daemonURL(settings.GetListen())
+	}
+	if !strings.Contains(base, "://") {
+		base = "http://" + base
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	connected := false
+	for {
+		err := streamEvents(ctx, strings.TrimSuffix(base, "/")+"/events", *token, func(event, data string) error {
+			connected = true
+			return printWatchEvent(os.Stdout, event, data, *jsonOutput)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if !connected {
+			fmt.Fprintf(os.Stderr, "Error: %v (is 'agent-deck daemon' running?)\n", err)
+			os.Exit(1)
+		}
+		// The daemon restarted or the connection dropped: reconnect
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// daemonURL turns a listen address into one to connect to, using loopback
+// when the daemon listens on all interfaces
+func daemonURL(listen string) string {
+	host, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		return listen
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// streamEvents reads server-sent events from url until the stream ends
+func streamEvents(ctx context.Context, url, token string, fn func(event, data string) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	return readSSE(resp.Body, fn)
+}
+
+// readSSE parses a server-sent event stream, calling fn per event
+func readSSE(r io.Reader, fn func(event, data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	event := ""
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				if event == "" {
+					event = "message"
+				}
+				if err := fn(event, strings.Join(data, "\n")); err != nil {
+					return err
+				}
+			}
+			event, data = "", nil
+		case strings.HasPrefix(line, ":"):
+			// comment (keepalive)
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return scanner.Err()
+}
+
+// printWatchEvent prints one daemon event for humans or as a JSON line
+func printWatchEvent(w io.Writer, event, data string, jsonOutput bool) error {
+	if event == "snapshot" {
+		if jsonOutput {
+			return nil
+		}
+		var snap daemon.Snapshot
+		if err := json.Unmarshal([]byte(data), &snap); err != nil {
+			return err
+		}
+		_, err := fmt.Fprint(w, cliText(fmt.Sprintf("Watching %d sessions in '%s' (%d waiting • %d running • %d idle)\n",
+			len(snap.Sessions), snap.Profile, snap.Counts["waiting"], snap.Counts["running"], snap.Counts["idle"])))
+		return err
+	}
+	if jsonOutput {
+		_, err := fmt.Fprintln(w, data)
+		return err
+	}
+
+	var e daemon.Event
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		return err
+	}
+	var change string
+	switch e.Type {
+	case daemon.EventAdded:
+		change = "added (" + e.To + ")"
+	case daemon.EventRemoved:
+		change = "removed"
+	default:
+		change = e.From + " → " + e.To
+	}
+	_, err := fmt.Fprint(w, cliText(fmt.Sprintf("%s  %s %s %s\n", e.At.Local().Format("15:04:05"),
+		tableCell(e.Title, tableColTitle), tableCell(e.Group, tableColGroup), change)))
+	return err
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadSSE(t *testing.T) {
+	stream := "event: snapshot\ndata: {\"a\":1}\n\n: keepalive\n\nevent: status\ndata: line1\ndata: line2\n\ndata: plain\n\n"
+	var got []string
+	err := readSSE(strings.NewReader(stream), func(event, data string) error {
+		got = append(got, event+"="+data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`snapshot={"a":1}`, "status=line1\nline2", "message=plain"}
+	if len(got) != len(want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDaemonURL(t *testing.T) {
+	tests := map[string]string{
+		"127.0.0.1:8420": "127.0.0.1:8420",
+		"0.0.0.0:8420":   "127.0.0.1:8420",
+		":9000":          "127.0.0.1:9000",
+		"[::]:8420":      "127.0.0.1:8420",
+		"box.lan:8420":   "box.lan:8420",
+	}
+	for listen, want := range tests {
+		if got := daemonURL(listen); got != want {
+			t.Errorf("daemonURL(%q) = %q, want %q", listen, got, want)
+		}
+	}
+}
+
+func TestPrintWatchEvent(t *testing.T) {
+	var buf bytes.Buffer
+	data := `{"type":"status","id":"a","title":"api","group":"work","from":"running","to":"waiting","at":"2024-06-01T10:00:00Z"}`
+	if err := printWatchEvent(&buf, "status", data, false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "api") || !strings.Contains(buf.String(), "running → waiting") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := printWatchEvent(&buf, "status", data, true); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(buf.String()) != data {
+		t.Errorf("--json should print the event as received, got %q", buf.String())
+	}
+}
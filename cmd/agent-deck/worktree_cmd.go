@@ -10,6 +10,7 @@ import (
 
 	"github.com/asheshgoplani/agent-deck/internal/git"
 	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/ui"
 )
 
 // handleWorktree dispatches worktree subcommands
@@ -121,9 +122,9 @@ func handleWorktreeList(profile string, args []string) {
 	// Build session map: path -> session title
 	sessionByPath := make(map[string]*session.Instance)
 	for _, inst := range instances {
-		sessionByPath[inst.ProjectPath] = inst
+		sessionByPath[session.PathKey(inst.ProjectPath)] = inst
 		if inst.WorktreePath != "" {
-			sessionByPath[inst.WorktreePath] = inst
+			sessionByPath[session.PathKey(inst.WorktreePath)] = inst
 		}
 	}
 
@@ -151,7 +152,7 @@ func handleWorktreeList(profile string, args []string) {
 		}
 
 		// Find associated session
-		if inst := sessionByPath[wt.Path]; inst != nil {
+		if inst := sessionByPath[session.PathKey(wt.Path)]; inst != nil {
 			info.Session = inst.Title
 		}
 
@@ -182,11 +183,11 @@ func handleWorktreeList(profile string, args []string) {
 		if sessionStr == "" {
 			sessionStr = "-"
 		}
-		fmt.Printf("%-40s  %-20s  %-10s  %s\n",
-			truncateString(FormatPath(wt.Path), 40),
-			truncateString(wt.Branch, 20),
+		fmt.Printf("%s  %s  %-10s  %s\n",
+			ui.PadWidth(ui.ShortenPath(wt.Path, 40), 40),
+			tableCell(wt.Branch, 20),
 			wt.Type,
-			truncateString(sessionStr, 20))
+			truncate(sessionStr, 20))
 	}
 
 	fmt.Printf("\nTotal: %d worktree(s)\n", len(results))
@@ -339,9 +340,9 @@ func handleWorktreeCleanup(profile string, args []string) {
 				// Build set of paths that sessions use
 				sessionPaths := make(map[string]bool)
 				for _, inst := range instances {
-					sessionPaths[inst.ProjectPath] = true
+					sessionPaths[session.PathKey(inst.ProjectPath)] = true
 					if inst.WorktreePath != "" {
-						sessionPaths[inst.WorktreePath] = true
+						sessionPaths[session.PathKey(inst.WorktreePath)] = true
 					}
 				}
 
@@ -350,7 +351,7 @@ func handleWorktreeCleanup(profile string, args []string) {
 					if i == 0 {
 						continue // Skip main repo
 					}
-					if !sessionPaths[wt.Path] {
+					if !sessionPaths[session.PathKey(wt.Path)] {
 						orphanedWorktrees = append(orphanedWorktrees, wt)
 					}
 				}
@@ -420,17 +421,19 @@ func handleWorktreeCleanup(profile string, args []string) {
 		return
 	}
 
-	// Confirm before proceeding
-	fmt.Printf("\nThis will remove %d session(s) and %d worktree(s). Continue? [y/N]: ",
-		len(orphanedSessions), len(orphanedWorktrees))
+	// Confirm before proceeding (unless [confirm] bulk = false)
+	if session.GetConfirmSettings().GetBulk() {
+		fmt.Printf("\nThis will remove %d session(s) and %d worktree(s). Continue? [y/N]: ",
+			len(orphanedSessions), len(orphanedWorktrees))
 
-	reader := bufio.NewReader(os.Stdin)
-	response, _ := reader.ReadString('\n')
-	response = strings.TrimSpace(strings.ToLower(response))
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
 
-	if response != "y" && response != "yes" {
-		fmt.Println("Aborted.")
-		return
+		if response != "y" && response != "yes" {
+			fmt.Println("Aborted.")
+			return
+		}
 	}
 
 	// Remove orphaned sessions
@@ -691,14 +694,3 @@ func handleWorktreeFinish(profile string, args []string) {
 		fmt.Println()
 	}
 }
-
-// truncateString truncates a string to maxLen, adding "..." if truncated
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	if maxLen <= 3 {
-		return s[:maxLen]
-	}
-	return s[:maxLen-3] + "..."
-}
@@ -123,33 +123,6 @@ func TestWorktreeListWithWorktrees(t *testing.T) {
 	t.Logf("Worktree list output:\n%s", outputStr)
 }
 
-// TestTruncateString tests the truncateString helper function.
-func TestTruncateString(t *testing.T) {
-	tests := []struct {
-		input    string
-		maxLen   int
-		expected string
-	}{
-		{"hello", 10, "hello"},            // No truncation needed
-		{"hello world", 10, "hello w..."}, // Truncation needed
-		{"hi", 3, "hi"},                   // Exactly at limit
-		{"hello", 5, "hello"},             // Exactly at limit
-		{"hello", 3, "hel"},               // Very short max (no room for "...")
-		{"a", 1, "a"},                     // Single char
-		{"", 5, ""},                       // Empty string
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			result := truncateString(tt.input, tt.maxLen)
-			if result != tt.expected {
-				t.Errorf("truncateString(%q, %d) = %q, want %q",
-					tt.input, tt.maxLen, result, tt.expected)
-			}
-		})
-	}
-}
-
 // containsPath checks if the output contains the given path.
 func containsPath(output, path string) bool {
 	// Simple substring check - path should appear in output
@@ -0,0 +1,233 @@
+// Package daemon tracks session statuses without the TUI and serves them
+// read-only over HTTP: a status page for phones and browsers, and JSON for
+// scripts and dashboards.
+package daemon
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/logging"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+var daemonLog = logging.ForComponent(logging.CompHTTP)
+
+// SessionStatus is one session as the daemon reports it
+type SessionStatus struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	Group      string     `json:"group"`
+	Path       string     `json:"path"`
+	Tool       string     `json:"tool"`
+	Status     string     `json:"status"`
+	LastOutput *time.Time `json:"last_output,omitempty"`
+}
+
+// Snapshot is the daemon's view of the deck after a poll
+type Snapshot struct {
+	Profile   string          `json:"profile"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Counts    map[string]int  `json:"counts"`
+	Sessions  []SessionStatus `json:"sessions"`
+
+	// TmuxUnresponsive is set when tmux commands are timing out, so the
+	// statuses may be stale
+	TmuxUnresponsive bool `json:"tmux_unresponsive,omitempty"`
+}
+
+// Daemon polls the statuses of a profile's sessions
+type Daemon struct {
+	storage  *session.Storage
+	interval time.Duration
+	token    string
+
+	// instances persist between polls so status detection keeps its history
+	instances map[string]*session.Instance
+	loadedAt  time.Time // storage timestamp of the last load
+
+	mu          sync.RWMutex
+	snapshot    Snapshot
+	subscribers map[chan Event]struct{}
+}
+
+// New creates a daemon for storage's profile that polls every interval.
+// A non-empty token is required by the HTTP handler on every request.
+func New(storage *session.Storage, interval time.Duration, token string) *Daemon {
+	return &Daemon{
+		storage:     storage,
+		interval:    interval,
+		token:       token,
+		instances:   make(map[string]*session.Instance),
+		snapshot:    Snapshot{Profile: storage.Profile(), Counts: newCounts()},
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Run polls until ctx is done
+func (d *Daemon) Run(ctx context.Context) {
+	tmux.SetSessionCacheTTL(d.interval)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		d.poll()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Snapshot returns the result of the latest poll
+func (d *Daemon) Snapshot() Snapshot {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.snapshot
+}
+
+// poll reloads sessions when storage changed, refreshes every status from
+// tmux and publishes a new snapshot
+func (d *Daemon) poll() {
+	d.reload()
+
+	tmux.RefreshSessionCache()
+	snap := Snapshot{
+		Profile:   d.storage.Profile(),
+		UpdatedAt: time.Now(),
+		Counts:    newCounts(),
+	}
+	for _, inst := range d.instances {
+		_ = inst.UpdateStatus()
+		status := string(inst.GetStatusThreadSafe())
+		snap.Counts[status]++
+		s := SessionStatus{
+			ID:     inst.ID,
+			Title:  inst.Title,
+			Group:  inst.GroupPath,
+			Path:   inst.ProjectPath,
+			Tool:   inst.GetToolThreadSafe(),
+			Status: status,
+		}
+		if ts := inst.GetTmuxSession(); ts != nil {
+			if activity := ts.GetCachedWindowActivity(); activity > 0 {
+				t := time.Unix(activity, 0)
+				s.LastOutput = &t
+			}
+		}
+		snap.Sessions = append(snap.Sessions, s)
+	}
+	sortSessions(snap.Sessions)
+	snap.TmuxUnresponsive = tmux.Unresponsive()
+
+	d.mu.Lock()
+	events := diffSnapshots(d.snapshot, snap)
+	d.snapshot = snap
+	for _, e := range events {
+		d.publish(e)
+	}
+	d.mu.Unlock()
+}
+
+// reload loads sessions when storage has changed since the last load,
+// keeping existing instances (and their status history) for sessions still
+// running in the same tmux session
+func (d *Daemon) reload() {
+	updatedAt, err := d.storage.GetUpdatedAt()
+	if err == nil && !updatedAt.After(d.loadedAt) && len(d.instances) > 0 {
+		return
+	}
+	instances, _, err := d.storage.LoadWithGroups()
+	if err != nil {
+		daemonLog.Warn("daemon_load_failed", slog.String("error", err.Error()))
+		return
+	}
+	d.loadedAt = updatedAt
+
+	next := make(map[string]*session.Instance, len(instances))
+	for _, inst := range instances {
+		// Take the reloaded instance for sessions in a new tmux session, and
+		// for errored ones (which only recheck tmux every 30s) in case they
+		// were just started
+		if prev, ok := d.instances[inst.ID]; ok && tmuxName(prev) == tmuxName(inst) &&
+			prev.GetStatusThreadSafe() != session.StatusError {
+			prev.Title = inst.Title
+			prev.GroupPath = inst.GroupPath
+			next[inst.ID] = prev
+			continue
+		}
+		next[inst.ID] = inst
+	}
+	d.instances = next
+}
+
+// tmuxName returns the name of the instance's tmux session, "" if none
+func tmuxName(inst *session.Instance) string {
+	if ts := inst.GetTmuxSession(); ts != nil {
+		return ts.Name
+	}
+	return ""
+}
+
+// newCounts returns status counts with every status at zero, so consumers
+// can rely on the keys being present
+func newCounts() map[string]int {
+	counts := make(map[string]int, len(statusOrder))
+	for status := range statusOrder {
+		counts[status] = 0
+	}
+	return counts
+}
+
+// statusOrder sorts sessions that need attention first
+var statusOrder = map[string]int{
+	string(session.StatusWaiting):  0,
+	string(session.StatusRunning):  1,
+	string(session.StatusStarting): 2,
+	string(session.StatusError):    3,
+	string(session.StatusIdle):     4,
+}
+
+// sortSessions orders by status, then group and title
+func sortSessions(sessions []SessionStatus) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		if statusOrder[a.Status] != statusOrder[b.Status] {
+			return statusOrder[a.Status] < statusOrder[b.Status]
+		}
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		return a.Title < b.Title
+	})
+}
+
+// Handler serves the status page at /, the snapshot as JSON at /metrics and
+// a server-sent event stream of changes at /events. All are read-only.
+func (d *Daemon) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", d.serveStatusPage)
+	mux.HandleFunc("/metrics", d.serveMetrics)
+	mux.HandleFunc("/events", d.serveEvents)
+	return d.requireToken(mux)
+}
+
+// requireToken rejects requests without the daemon's token (as ?token= or a
+// bearer token) when one is configured
+func (d *Daemon) requireToken(next http.Handler) http.Handler {
+	if d.token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("token") != d.token && r.Header.Get("Authorization") != "Bearer "+d.token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,135 @@
+package daemon
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testDaemon(token string) *Daemon {
+	d := &Daemon{token: token, subscribers: make(map[chan Event]struct{})}
+	d.snapshot = Snapshot{
+		Profile:   "work",
+		UpdatedAt: time.Now(),
+		Counts:    map[string]int{"waiting": 1, "running": 1, "idle": 0, "error": 0, "starting": 0},
+		Sessions: []SessionStatus{
+			{ID: "a", Title: "<api>", Group: "backend", Tool: "claude", Status: "waiting"},
+			{ID: "b", Title: "web", Group: "frontend", Tool: "codex", Status: "running"},
+		},
+	}
+	return d
+}
+
+func TestStatusPage(t *testing.T) {
+	srv := httptest.NewServer(testDaemon("").Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Fatalf("status page: %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	for _, want := range []string{"agent-deck · work", "1 waiting", "&lt;api&gt;", `class="session running"`, "(1 waiting)"} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("page missing %q", want)
+		}
+	}
+
+	resp, err = http.Get(srv.URL + "/nope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown path = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	srv := httptest.NewServer(testDaemon("").Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var snap Snapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snap); err != nil {
+		t.Fatal(err)
+	}
+	if snap.Profile != "work" || snap.Counts["waiting"] != 1 || len(snap.Sessions) != 2 {
+		t.Errorf("unexpected snapshot: %+v", snap)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/metrics", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST /metrics = %d, want 405", resp.StatusCode)
+	}
+}
+
+func TestRequireToken(t *testing.T) {
+	srv := httptest.NewServer(testDaemon("s3cret").Handler())
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		url    string
+		header string
+		want   int
+	}{
+		{"missing", "/metrics", "", http.StatusUnauthorized},
+		{"wrong", "/metrics?token=nope", "", http.StatusUnauthorized},
+		{"query", "/metrics?token=s3cret", "", http.StatusOK},
+		{"bearer", "/metrics", "Bearer s3cret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+tt.url, nil)
+		if tt.header != "" {
+			req.Header.Set("Authorization", tt.header)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, resp.StatusCode, tt.want)
+		}
+	}
+}
+
+func TestSortSessions(t *testing.T) {
+	sessions := []SessionStatus{
+		{Title: "b", Group: "x", Status: "idle"},
+		{Title: "a", Group: "y", Status: "running"},
+		{Title: "c", Group: "x", Status: "waiting"},
+		{Title: "a", Group: "x", Status: "idle"},
+	}
+	sortSessions(sessions)
+	var got []string
+	for _, s := range sessions {
+		got = append(got, s.Status+":"+s.Group+"/"+s.Title)
+	}
+	want := "waiting:x/c running:y/a idle:x/a idle:x/b"
+	if strings.Join(got, " ") != want {
+		t.Errorf("order = %v, want %s", got, want)
+	}
+}
@@ -0,0 +1,146 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Event types streamed from /events
+const (
+	EventStatus  = "status"  // a session's status changed
+	EventAdded   = "added"   // a session was created
+	EventRemoved = "removed" // a session was deleted
+)
+
+const (
+	// subscriberBuffer is how many events a slow client may fall behind
+	// before further events are dropped for it
+	subscriberBuffer = 64
+	// keepaliveInterval keeps idle streams open through proxies
+	keepaliveInterval = 15 * time.Second
+)
+
+// Event is a change between two polls
+type Event struct {
+	Type  string    `json:"type"`
+	ID    string    `json:"id"`
+	Title string    `json:"title"`
+	Group string    `json:"group"`
+	Tool  string    `json:"tool"`
+	From  string    `json:"from,omitempty"` // previous status (status, removed)
+	To    string    `json:"to,omitempty"`   // new status (status, added)
+	At    time.Time `json:"at"`
+}
+
+// diffSnapshots returns the events that turn prev into next. The first
+// snapshot (no previous poll) produces none.
+func diffSnapshots(prev, next Snapshot) []Event {
+	if prev.UpdatedAt.IsZero() {
+		return nil
+	}
+	before := make(map[string]SessionStatus, len(prev.Sessions))
+	for _, s := range prev.Sessions {
+		before[s.ID] = s
+	}
+	var events []Event
+	for _, s := range next.Sessions {
+		e := Event{ID: s.ID, Title: s.Title, Group: s.Group, Tool: s.Tool, To: s.Status, At: next.UpdatedAt}
+		old, ok := before[s.ID]
+		delete(before, s.ID)
+		switch {
+		case !ok:
+			e.Type = EventAdded
+		case old.Status != s.Status:
+			e.Type = EventStatus
+			e.From = old.Status
+		default:
+			continue
+		}
+		events = append(events, e)
+	}
+	for _, s := range prev.Sessions {
+		if _, gone := before[s.ID]; gone {
+			events = append(events, Event{Type: EventRemoved, ID: s.ID, Title: s.Title, Group: s.Group,
+				Tool: s.Tool, From: s.Status, At: next.UpdatedAt})
+		}
+	}
+	return events
+}
+
+// Subscribe returns a channel of events and a function that ends the
+// subscription. Events are dropped for subscribers that fall behind.
+func (d *Daemon) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	d.mu.Lock()
+	d.subscribers[ch] = struct{}{}
+	d.mu.Unlock()
+	return ch, func() {
+		d.mu.Lock()
+		delete(d.subscribers, ch)
+		d.mu.Unlock()
+	}
+}
+
+// publish sends e to every subscriber without blocking. Callers hold d.mu.
+func (d *Daemon) publish(e Event) {
+	for ch := range d.subscribers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// serveEvents streams changes as server-sent events: a "snapshot" event
+// with the current state, then an event per change, named by its type
+func (d *Daemon) serveEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	events, unsubscribe := d.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	if err := writeSSE(w, "snapshot", d.Snapshot()); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	keepalive := time.NewTicker(keepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-events:
+			if err := writeSSE(w, e.Type, e); err != nil {
+				return
+			}
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSE writes one server-sent event with a JSON payload
+func writeSSE(w http.ResponseWriter, event string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
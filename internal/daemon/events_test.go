@@ -0,0 +1,101 @@
+package daemon
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	at := time.Now()
+	prev := Snapshot{UpdatedAt: at.Add(-2 * time.Second), Sessions: []SessionStatus{
+		{ID: "a", Title: "api", Status: "running"},
+		{ID: "b", Title: "web", Status: "idle"},
+		{ID: "c", Title: "old", Status: "idle"},
+	}}
+	next := Snapshot{UpdatedAt: at, Sessions: []SessionStatus{
+		{ID: "a", Title: "api", Status: "waiting"},
+		{ID: "b", Title: "web", Status: "idle"},
+		{ID: "d", Title: "new", Status: "starting"},
+	}}
+
+	events := diffSnapshots(prev, next)
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3: %+v", len(events), events)
+	}
+	if e := events[0]; e.Type != EventStatus || e.ID != "a" || e.From != "running" || e.To != "waiting" || !e.At.Equal(at) {
+		t.Errorf("status event = %+v", e)
+	}
+	if e := events[1]; e.Type != EventAdded || e.ID != "d" || e.To != "starting" {
+		t.Errorf("added event = %+v", e)
+	}
+	if e := events[2]; e.Type != EventRemoved || e.ID != "c" || e.From != "idle" {
+		t.Errorf("removed event = %+v", e)
+	}
+
+	if events := diffSnapshots(Snapshot{}, next); len(events) != 0 {
+		t.Errorf("first poll should produce no events, got %+v", events)
+	}
+}
+
+func TestServeEvents(t *testing.T) {
+	d := testDaemon("")
+	srv := httptest.NewServer(d.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	lines := bufio.NewScanner(resp.Body)
+	readEvent := func() (string, string) {
+		var event, data string
+		for lines.Scan() {
+			line := lines.Text()
+			switch {
+			case line == "":
+				return event, data
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+		return event, data
+	}
+
+	if event, data := readEvent(); event != "snapshot" || !strings.Contains(data, `"profile":"work"`) {
+		t.Fatalf("first event = %s %s, want the snapshot", event, data)
+	}
+
+	// The subscription is registered before the snapshot is written
+	d.mu.Lock()
+	d.publish(Event{Type: EventStatus, ID: "a", From: "running", To: "waiting"})
+	d.mu.Unlock()
+	if event, data := readEvent(); event != EventStatus || !strings.Contains(data, `"to":"waiting"`) {
+		t.Errorf("change event = %s %s", event, data)
+	}
+}
+
+func TestPublishDropsForSlowSubscribers(t *testing.T) {
+	d := &Daemon{subscribers: make(map[chan Event]struct{})}
+	events, unsubscribe := d.Subscribe()
+	for i := 0; i < subscriberBuffer+10; i++ {
+		d.publish(Event{Type: EventStatus}) // must not block
+	}
+	if len(events) != subscriberBuffer {
+		t.Errorf("buffered %d events, want %d", len(events), subscriberBuffer)
+	}
+	unsubscribe()
+	if len(d.subscribers) != 0 {
+		t.Error("unsubscribe should remove the subscriber")
+	}
+}
@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+// statusPageRefresh is how often the status page reloads itself, in seconds
+const statusPageRefresh = 5
+
+// statusPageTemplate is a self-contained, phone-friendly session list
+var statusPageTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"ago": formatAgo,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="{{.Refresh}}">
+<title>agent-deck{{if .Counts.waiting}} ({{.Counts.waiting}} waiting){{end}}</title>
+<style>
+body { font-family: -apple-system, system-ui, sans-serif; margin: 0; padding: 1rem; background: #1a1b26; color: #c0caf5; }
+h1 { font-size: 1.1rem; margin: 0 0 .25rem; }
+.summary { color: #787c99; font-size: .9rem; margin-bottom: 1rem; }
+.session { display: flex; align-items: center; gap: .75rem; padding: .6rem 0; border-bottom: 1px solid #292e42; }
+.dot { width: .7rem; height: .7rem; border-radius: 50%; flex: none; background: #565f89; }
+.running .dot { background: #9ece6a; }
+.waiting .dot { background: #e0af68; }
+.error .dot { background: #f7768e; }
+.starting .dot { background: #7dcfff; }
+.main { flex: 1; min-width: 0; }
+.title { font-weight: 600; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+.meta { color: #787c99; font-size: .8rem; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+.status { font-size: .8rem; color: #787c99; text-align: right; flex: none; }
+.empty { color: #787c99; }
+</style>
+</head>
+<body>
+<h1>agent-deck · {{.Profile}}</h1>
+<div class="summary">{{.Counts.waiting}} waiting · {{.Counts.running}} running · {{.Counts.idle}} idle{{if .Counts.error}} · {{.Counts.error}} error{{end}} · updated {{.UpdatedAt.Format "15:04:05"}}</div>
+{{range .Sessions}}<div class="session {{.Status}}">
+<span class="dot"></span>
+<div class="main"><div class="title">{{.Title}}</div><div class="meta">{{.Tool}} · {{.Group}} · {{.Path}}</div></div>
+<div class="status">{{.Status}}{{with .LastOutput}}<br>{{ago .}}{{end}}</div>
+</div>
+{{else}}<p class="empty">No sessions.</p>
+{{end}}</body>
+</html>
+`))
+
+// serveStatusPage renders the session list as HTML
+func (d *Daemon) serveStatusPage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	data := struct {
+		Snapshot
+		Refresh int
+	}{d.Snapshot(), statusPageRefresh}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := statusPageTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// serveMetrics writes the latest snapshot as JSON
+func (d *Daemon) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(d.Snapshot())
+}
+
+// formatAgo formats how long ago t was: "just now", "5m ago", "3h ago", "2d ago"
+func formatAgo(t *time.Time) string {
+	d := time.Since(*t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
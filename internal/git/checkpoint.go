@@ -0,0 +1,116 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// CheckpointRefPrefix is the ref namespace used for hidden checkpoint commits
+const CheckpointRefPrefix = "refs/agent-deck/checkpoints/"
+
+// CheckpointBranchPrefix is the branch namespace used when checkpoints are stored as branches
+const CheckpointBranchPrefix = "agent-deck/checkpoint/"
+
+// revParse resolves a revision to a full hash, returning "" if it does not exist
+func revParse(dir, rev string) string {
+	cmd := exec.Command("git", "-C", dir, "rev-parse", "--verify", "--quiet", rev)
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// snapshotTree writes a tree object of the full working tree (tracked changes and
+// untracked, non-ignored files) using a temporary index, leaving the real index untouched.
+func snapshotTree(dir string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "agent-deck-checkpoint-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary index dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	env := append(os.Environ(), "GIT_INDEX_FILE="+filepath.Join(tmpDir, "index"))
+	run := func(args ...string) (string, error) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return "", fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(string(output)), err)
+		}
+		return strings.TrimSpace(string(output)), nil
+	}
+
+	if hasHead(dir) {
+		if _, err := run("read-tree", "HEAD"); err != nil {
+			return "", err
+		}
+	}
+	if _, err := run("add", "-A", "--", "."); err != nil {
+		return "", err
+	}
+	return run("write-tree")
+}
+
+// CreateCheckpoint records the current working tree of dir as a commit on ref without
+// touching HEAD, the index, or any files. The previous checkpoint on ref (if any) and
+// HEAD are used as parents so `git log <ref>` shows the checkpoint history.
+// Returns the new commit hash, or "" if nothing changed since the last checkpoint or HEAD.
+func CreateCheckpoint(dir, ref, message string) (string, error) {
+	tree, err := snapshotTree(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot working tree: %w", err)
+	}
+
+	head := revParse(dir, "HEAD")
+	prev := revParse(dir, ref)
+	if prev != "" && revParse(dir, prev+"^{tree}") == tree {
+		return "", nil
+	}
+	if prev == "" && head != "" && revParse(dir, "HEAD^{tree}") == tree {
+		return "", nil
+	}
+
+	args := []string{"-C", dir, "commit-tree", tree, "-m", message}
+	if prev != "" {
+		args = append(args, "-p", prev)
+	}
+	if head != "" && head != prev {
+		args = append(args, "-p", head)
+	}
+	cmd := exec.Command("git", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to create checkpoint commit: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	commit := strings.TrimSpace(string(output))
+
+	cmd = exec.Command("git", "-C", dir, "update-ref", "-m", message, ref, commit)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to update checkpoint ref: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return commit, nil
+}
+
+// CreateStashCheckpoint stores the current tracked changes of dir as a stash entry
+// without modifying the working tree. Returns "" if there is nothing to stash.
+func CreateStashCheckpoint(dir, message string) (string, error) {
+	cmd := exec.Command("git", "-C", dir, "stash", "create", message)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to create stash: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	commit := strings.TrimSpace(string(output))
+	if commit == "" {
+		return "", nil
+	}
+
+	cmd = exec.Command("git", "-C", dir, "stash", "store", "-m", message, commit)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to store stash: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return commit, nil
+}
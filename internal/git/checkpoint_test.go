@@ -0,0 +1,105 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCreateCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	createTestRepo(t, dir)
+	ref := CheckpointRefPrefix + "test-session"
+
+	// Clean tree: nothing to checkpoint
+	commit, err := CreateCheckpoint(dir, ref, "checkpoint")
+	if err != nil {
+		t.Fatalf("CreateCheckpoint failed: %v", err)
+	}
+	if commit != "" {
+		t.Errorf("expected no checkpoint for clean tree, got %s", commit)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "untracked.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := CreateCheckpoint(dir, ref, "checkpoint 1")
+	if err != nil {
+		t.Fatalf("CreateCheckpoint failed: %v", err)
+	}
+	if first == "" {
+		t.Fatal("expected checkpoint commit for dirty tree")
+	}
+
+	// Checkpoint includes untracked files
+	out, err := exec.Command("git", "-C", dir, "show", ref+":untracked.txt").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "new" {
+		t.Errorf("checkpoint should contain untracked file, got %q (%v)", out, err)
+	}
+
+	// Working tree and index are untouched
+	status, _ := exec.Command("git", "-C", dir, "status", "--porcelain").Output()
+	if !strings.Contains(string(status), " M README.md") || !strings.Contains(string(status), "?? untracked.txt") {
+		t.Errorf("working tree state changed: %q", status)
+	}
+
+	// No new changes: no new checkpoint
+	again, err := CreateCheckpoint(dir, ref, "checkpoint 2")
+	if err != nil {
+		t.Fatalf("CreateCheckpoint failed: %v", err)
+	}
+	if again != "" {
+		t.Errorf("expected no checkpoint without new changes, got %s", again)
+	}
+
+	// Further changes chain onto the previous checkpoint
+	if err := os.WriteFile(filepath.Join(dir, "untracked.txt"), []byte("newer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	second, err := CreateCheckpoint(dir, ref, "checkpoint 3")
+	if err != nil {
+		t.Fatalf("CreateCheckpoint failed: %v", err)
+	}
+	if parent := revParse(dir, second+"^1"); parent != first {
+		t.Errorf("second checkpoint parent = %s, want %s", parent, first)
+	}
+}
+
+func TestCreateStashCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	createTestRepo(t, dir)
+
+	commit, err := CreateStashCheckpoint(dir, "checkpoint")
+	if err != nil {
+		t.Fatalf("CreateStashCheckpoint failed: %v", err)
+	}
+	if commit != "" {
+		t.Errorf("expected no stash for clean tree, got %s", commit)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	commit, err = CreateStashCheckpoint(dir, "checkpoint")
+	if err != nil {
+		t.Fatalf("CreateStashCheckpoint failed: %v", err)
+	}
+	if commit == "" {
+		t.Fatal("expected stash commit for dirty tree")
+	}
+
+	list, _ := exec.Command("git", "-C", dir, "stash", "list").Output()
+	if !strings.Contains(string(list), "checkpoint") {
+		t.Errorf("stash list should contain checkpoint, got %q", list)
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "README.md"))
+	if string(content) != "# Changed\n" {
+		t.Errorf("working tree should be untouched, got %q", content)
+	}
+}
@@ -0,0 +1,99 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// hasHead reports whether the repository at dir has at least one commit
+func hasHead(dir string) bool {
+	cmd := exec.Command("git", "-C", dir, "rev-parse", "--verify", "--quiet", "HEAD")
+	return cmd.Run() == nil
+}
+
+// diffArgs builds `git diff` arguments comparing the working tree (staged + unstaged)
+// against HEAD. Fresh repositories without commits fall back to the index.
+func diffArgs(dir string, extra ...string) []string {
+	args := []string{"-C", dir, "diff", "--no-color", "--no-ext-diff"}
+	args = append(args, extra...)
+	if hasHead(dir) {
+		args = append(args, "HEAD")
+	}
+	return args
+}
+
+// GetDiffStat returns `git diff --stat` output for uncommitted changes in dir,
+// followed by a list of untracked files (which git diff does not include).
+func GetDiffStat(dir string) (string, error) {
+	return diffStat(dir, diffArgs(dir, "--stat"))
+}
+
+// GetDiffStatSince is GetDiffStat against the commit base instead of HEAD,
+// so work committed since base counts too. An empty base means HEAD.
+func GetDiffStatSince(dir, base string) (string, error) {
+	if base == "" {
+		return GetDiffStat(dir)
+	}
+	return diffStat(dir, []string{"-C", dir, "diff", "--no-color", "--no-ext-diff", "--stat", base})
+}
+
+// HeadCommit returns the hash HEAD points to, or "" without commits
+func HeadCommit(dir string) string {
+	return revParse(dir, "HEAD")
+}
+
+// diffStat runs git with the diff args and appends the untracked files
+func diffStat(dir string, args []string) (string, error) {
+	cmd := exec.Command("git", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff stat: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	stat := strings.TrimRight(string(output), "\n")
+
+	untracked, err := GetUntrackedFiles(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(untracked) > 0 {
+		var b strings.Builder
+		if stat != "" {
+			b.WriteString(stat)
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, " %d untracked file(s):", len(untracked))
+		for _, f := range untracked {
+			b.WriteString("\n  ?? ")
+			b.WriteString(f)
+		}
+		stat = b.String()
+	}
+	return stat, nil
+}
+
+// GetDiff returns the full unified diff of uncommitted changes (staged + unstaged) in dir
+func GetDiff(dir string) (string, error) {
+	cmd := exec.Command("git", diffArgs(dir)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return strings.TrimRight(string(output), "\n"), nil
+}
+
+// GetUntrackedFiles returns paths of untracked (not ignored) files in dir
+func GetUntrackedFiles(dir string) ([]string, error) {
+	cmd := exec.Command("git", "-C", dir, "ls-files", "--others", "--exclude-standard")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
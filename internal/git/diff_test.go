@@ -0,0 +1,86 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetDiffStatAndDiff(t *testing.T) {
+	dir := t.TempDir()
+	createTestRepo(t, dir)
+
+	// Clean repo: no diff
+	stat, err := GetDiffStat(dir)
+	if err != nil {
+		t.Fatalf("GetDiffStat failed: %v", err)
+	}
+	if stat != "" {
+		t.Errorf("expected empty stat for clean repo, got %q", stat)
+	}
+
+	// Modify tracked file and add an untracked one
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stat, err = GetDiffStat(dir)
+	if err != nil {
+		t.Fatalf("GetDiffStat failed: %v", err)
+	}
+	if !strings.Contains(stat, "README.md") {
+		t.Errorf("stat should mention modified file, got %q", stat)
+	}
+	if !strings.Contains(stat, "?? new.txt") {
+		t.Errorf("stat should list untracked file, got %q", stat)
+	}
+
+	diff, err := GetDiff(dir)
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	if !strings.Contains(diff, "+# Changed") || !strings.Contains(diff, "-# Test Repo") {
+		t.Errorf("diff should contain added and removed lines, got %q", diff)
+	}
+}
+
+func TestGetDiffStatSince(t *testing.T) {
+	dir := t.TempDir()
+	createTestRepo(t, dir)
+	base := HeadCommit(dir)
+	if base == "" {
+		t.Fatal("HeadCommit returned nothing for a repo with a commit")
+	}
+
+	// A commit made since base, plus an uncommitted change
+	if err := os.WriteFile(filepath.Join(dir, "done.txt"), []byte("done\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CommitAll(dir, "work"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stat, err := GetDiffStatSince(dir, base)
+	if err != nil {
+		t.Fatalf("GetDiffStatSince failed: %v", err)
+	}
+	if !strings.Contains(stat, "done.txt") || !strings.Contains(stat, "README.md") {
+		t.Errorf("stat should cover the commit and the working tree, got %q", stat)
+	}
+	if stat, _ := GetDiffStat(dir); strings.Contains(stat, "done.txt") {
+		t.Errorf("GetDiffStat should only cover uncommitted changes, got %q", stat)
+	}
+}
+
+func TestGetDiffNotARepo(t *testing.T) {
+	if _, err := GetDiff(t.TempDir()); err == nil {
+		t.Error("expected error for non-repo directory")
+	}
+}
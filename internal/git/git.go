@@ -54,6 +54,21 @@ func BranchExists(repoDir, branchName string) bool {
 	return err == nil
 }
 
+// CheckoutBranch switches the repository at dir to branch. With create, the
+// branch is created from the current HEAD first.
+func CheckoutBranch(dir, branch string, create bool) error {
+	args := []string{"-C", dir, "checkout", "-q"}
+	if create {
+		args = append(args, "-b")
+	}
+	cmd := exec.Command("git", append(args, branch)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to check out %s: %s: %w", branch, strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
 // ValidateBranchName validates that a branch name follows git's naming rules
 func ValidateBranchName(name string) error {
 	if name == "" {
@@ -410,3 +425,47 @@ func PruneWorktrees(repoDir string) error {
 	}
 	return nil
 }
+
+// ParseRemoteURL returns the owner (org or user) and repository name of a git
+// remote URL. Handles scp-style (git@github.com:org/repo.git), ssh://, https://
+// and local paths; owner is empty when the URL has no owner segment.
+func ParseRemoteURL(remote string) (owner, repo string) {
+	s := strings.TrimSpace(remote)
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "/"), ".git")
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+		if j := strings.Index(s, "/"); j >= 0 {
+			s = s[j+1:] // Drop user@host[:port]
+		} else {
+			s = ""
+		}
+	} else if i := strings.Index(s, ":"); i >= 0 && !strings.HasPrefix(s, "/") && !strings.HasPrefix(s, ".") {
+		s = s[i+1:] // scp-style user@host:path
+	}
+	parts := strings.Split(strings.Trim(s, "/"), "/")
+	repo = parts[len(parts)-1]
+	if len(parts) > 1 {
+		owner = parts[len(parts)-2]
+	}
+	return owner, repo
+}
+
+// GetRemoteURL returns the URL of the origin remote of the repository containing dir
+func GetRemoteURL(dir string) (string, error) {
+	cmd := exec.Command("git", "-C", dir, "remote", "get-url", "origin")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("no origin remote: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// CloneRepo clones remote into dest, which must not exist yet
+func CloneRepo(remote, dest string) error {
+	cmd := exec.Command("git", "clone", "--", remote, dest)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to clone %s: %s: %w", remote, strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
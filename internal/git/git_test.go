@@ -227,6 +227,28 @@ func TestBranchExists(t *testing.T) {
 	})
 }
 
+func TestCheckoutBranch(t *testing.T) {
+	dir := t.TempDir()
+	createTestRepo(t, dir)
+	original, _ := GetCurrentBranch(dir)
+
+	if err := CheckoutBranch(dir, "sandbox/run-1", true); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if branch, _ := GetCurrentBranch(dir); branch != "sandbox/run-1" {
+		t.Errorf("on %s after create, want sandbox/run-1", branch)
+	}
+	if err := CheckoutBranch(dir, original, false); err != nil {
+		t.Fatalf("switch back: %v", err)
+	}
+	if branch, _ := GetCurrentBranch(dir); branch != original {
+		t.Errorf("on %s after switching back, want %s", branch, original)
+	}
+	if err := CheckoutBranch(dir, "sandbox/run-1", true); err == nil {
+		t.Error("creating an existing branch succeeded")
+	}
+}
+
 func TestValidateBranchName(t *testing.T) {
 	t.Run("accepts valid branch names", func(t *testing.T) {
 		validNames := []string{
@@ -946,3 +968,39 @@ func TestPruneWorktrees(t *testing.T) {
 		}
 	})
 }
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		remote      string
+		owner, repo string
+	}{
+		{"git@github.com:acme/api.git", "acme", "api"},
+		{"https://github.com/acme/api", "acme", "api"},
+		{"https://github.com/acme/api.git/", "acme", "api"},
+		{"ssh://git@gitlab.example.com:2222/group/sub/web.git", "sub", "web"},
+		{"/srv/git/tools.git", "git", "tools"},
+		{"https://example.com/solo.git", "", "solo"},
+	}
+	for _, tt := range tests {
+		owner, repo := ParseRemoteURL(tt.remote)
+		if owner != tt.owner || repo != tt.repo {
+			t.Errorf("ParseRemoteURL(%q) = %q, %q; want %q, %q", tt.remote, owner, repo, tt.owner, tt.repo)
+		}
+	}
+}
+
+func TestCloneRepo(t *testing.T) {
+	src := t.TempDir()
+	createTestRepo(t, src)
+	dest := filepath.Join(t.TempDir(), "clone")
+
+	if err := CloneRepo(src, dest); err != nil {
+		t.Fatalf("CloneRepo: %v", err)
+	}
+	if !IsGitRepo(dest) {
+		t.Error("clone is not a git repository")
+	}
+	if err := CloneRepo(src, dest); err == nil {
+		t.Error("cloning into an existing directory should fail")
+	}
+}
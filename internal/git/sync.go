@@ -0,0 +1,96 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// runIn runs git in dir and returns trimmed combined output
+func runIn(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(string(output)), err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// EnsureSyncRepo makes dir a git repository whose origin is remote,
+// creating it (or repointing origin) as needed
+func EnsureSyncRepo(dir, remote string) error {
+	if !IsGitRepo(dir) {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("failed to create sync directory: %w", err)
+		}
+		if _, err := runIn(dir, "init", "-q"); err != nil {
+			return err
+		}
+	}
+	if current, err := runIn(dir, "remote", "get-url", "origin"); err != nil {
+		_, err = runIn(dir, "remote", "add", "origin", remote)
+		return err
+	} else if current != remote {
+		_, err = runIn(dir, "remote", "set-url", "origin", remote)
+		return err
+	}
+	return nil
+}
+
+// FetchSyncBranch fetches branch from origin and checks it out, discarding
+// local changes. Returns false if the branch does not exist on origin yet,
+// in which case a local branch of that name is checked out instead.
+func FetchSyncBranch(dir, branch string) (bool, error) {
+	if _, err := runIn(dir, "fetch", "-q", "origin"); err != nil {
+		return false, err
+	}
+	if revParse(dir, "refs/remotes/origin/"+branch) == "" {
+		if BranchExists(dir, branch) || !hasHead(dir) {
+			_, err := runIn(dir, "symbolic-ref", "HEAD", "refs/heads/"+branch)
+			return false, err
+		}
+		_, err := runIn(dir, "checkout", "-q", "-f", "-B", branch)
+		return false, err
+	}
+	_, err := runIn(dir, "checkout", "-q", "-f", "-B", branch, "origin/"+branch)
+	return true, err
+}
+
+// ShowFile returns path's content at rev, or nil if it doesn't exist there
+func ShowFile(dir, rev, path string) []byte {
+	cmd := exec.Command("git", "-C", dir, "show", rev+":"+path)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return output
+}
+
+// CommitAll stages everything in dir and commits it.
+// Returns false if there was nothing to commit.
+func CommitAll(dir, message string) (bool, error) {
+	if _, err := runIn(dir, "add", "-A"); err != nil {
+		return false, err
+	}
+	if !hasHead(dir) {
+		if out, _ := runIn(dir, "status", "--porcelain"); out == "" {
+			return false, nil
+		}
+	} else if _, err := runIn(dir, "diff", "--cached", "--quiet"); err == nil {
+		return false, nil
+	}
+	args := []string{"commit", "-q", "-m", message}
+	if _, err := runIn(dir, "config", "user.email"); err != nil {
+		// Identity may be unset on a fresh machine; don't fail the sync over it
+		args = append([]string{"-c", "user.name=agent-deck", "-c", "user.email=agent-deck@localhost"}, args...)
+	}
+	_, err := runIn(dir, args...)
+	return err == nil, err
+}
+
+// PushBranch pushes branch to origin
+func PushBranch(dir, branch string) error {
+	_, err := runIn(dir, "push", "-q", "origin", "HEAD:refs/heads/"+branch)
+	return err
+}
@@ -0,0 +1,51 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestSyncRepoRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	if err := exec.Command("git", "init", "-q", "--bare", remote).Run(); err != nil {
+		t.Fatalf("failed to init bare remote: %v", err)
+	}
+
+	machineA := filepath.Join(root, "a")
+	if err := EnsureSyncRepo(machineA, remote); err != nil {
+		t.Fatalf("EnsureSyncRepo failed: %v", err)
+	}
+	exists, err := FetchSyncBranch(machineA, "main")
+	if err != nil || exists {
+		t.Fatalf("FetchSyncBranch on empty remote = %v, %v; want false, nil", exists, err)
+	}
+	if err := os.WriteFile(filepath.Join(machineA, "data.json"), []byte("{}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if committed, err := CommitAll(machineA, "first"); err != nil || !committed {
+		t.Fatalf("CommitAll = %v, %v", committed, err)
+	}
+	if committed, _ := CommitAll(machineA, "nothing"); committed {
+		t.Error("CommitAll with no changes should not commit")
+	}
+	if err := PushBranch(machineA, "main"); err != nil {
+		t.Fatalf("PushBranch failed: %v", err)
+	}
+
+	machineB := filepath.Join(root, "b")
+	if err := EnsureSyncRepo(machineB, remote); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := FetchSyncBranch(machineB, "main"); err != nil || !exists {
+		t.Fatalf("FetchSyncBranch = %v, %v; want true, nil", exists, err)
+	}
+	if got := string(ShowFile(machineB, "HEAD", "data.json")); got != "{}\n" {
+		t.Errorf("ShowFile = %q", got)
+	}
+	if ShowFile(machineB, "HEAD", "missing.json") != nil {
+		t.Error("ShowFile of a missing path should be nil")
+	}
+}
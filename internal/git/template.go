@@ -3,6 +3,7 @@ package git
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -72,6 +73,13 @@ func resolveTemplate(template string, vars templateVars) string {
 	)
 	resolved := replacer.Replace(template)
 
+	// "~" is the home directory, as in default_location
+	if resolved == "~" || strings.HasPrefix(resolved, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			resolved = filepath.Join(home, resolved[1:])
+		}
+	}
+
 	// Handle relative paths - resolve relative to repo root.
 	if !filepath.IsAbs(resolved) {
 		resolved = filepath.Join(vars.repoRoot, resolved)
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"os"
 	"path/filepath"
 	"regexp"
 	"testing"
@@ -267,6 +268,15 @@ func TestResolveTemplate(t *testing.T) {
 	}
 }
 
+func TestResolveTemplateHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	vars := templateVars{branch: "feature/x", repoName: "api", repoRoot: "/src/api", sessionID: "a1b2c3d4"}
+	require.Equal(t, filepath.Join(home, "worktrees", "api", "feature-x-a1b2c3d4"),
+		resolveTemplate("~/worktrees/{repo-name}/{branch}-{session-id}", vars))
+	require.Equal(t, "/src/api/~foo", resolveTemplate("~foo", vars))
+}
+
 func TestWorktreePath(t *testing.T) {
 	t.Parallel()
 
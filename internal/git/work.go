@@ -0,0 +1,87 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxWorkStatusFiles bounds the changed files stat'ed for their age
+const maxWorkStatusFiles = 500
+
+// WorkStatus is the work in a working copy that only exists locally
+type WorkStatus struct {
+	// Ahead counts commits not pushed: ahead of the branch's upstream, or
+	// on no remote branch when it has none (0 without remotes)
+	Ahead int
+
+	// Uncommitted is set when there are staged, unstaged or untracked changes
+	Uncommitted bool
+
+	// OldestChange is the modification time of the oldest changed file
+	// (zero when only deletions are uncommitted)
+	OldestChange time.Time
+}
+
+// GetWorkStatus reports the unpushed commits and uncommitted changes in dir
+func GetWorkStatus(dir string) (WorkStatus, error) {
+	var ws WorkStatus
+	root, err := GetRepoRoot(dir)
+	if err != nil {
+		return ws, err
+	}
+
+	if hasHead(root) {
+		ws.Ahead = countUnpushed(root)
+	}
+
+	// Not runIn: trimming would eat the first entry's leading space
+	out, err := exec.Command("git", "-C", root, "status", "--porcelain", "-z").Output()
+	if err != nil {
+		return ws, fmt.Errorf("git status: %w", err)
+	}
+	entries := strings.Split(string(out), "\x00")
+	for n := 0; n < len(entries); n++ {
+		entry := entries[n]
+		if len(entry) < 4 {
+			continue
+		}
+		ws.Uncommitted = true
+		// Renames and copies are followed by the original path
+		if entry[0] == 'R' || entry[0] == 'C' {
+			n++
+		}
+		if n >= maxWorkStatusFiles {
+			break
+		}
+		info, err := os.Lstat(filepath.Join(root, entry[3:]))
+		if err != nil {
+			continue
+		}
+		if mod := info.ModTime(); ws.OldestChange.IsZero() || mod.Before(ws.OldestChange) {
+			ws.OldestChange = mod
+		}
+	}
+	return ws, nil
+}
+
+// countUnpushed counts HEAD's commits missing from its upstream, or from
+// every remote branch when it has no upstream
+func countUnpushed(root string) int {
+	out, err := runIn(root, "rev-list", "--count", "@{upstream}..HEAD")
+	if err != nil {
+		remotes, err := runIn(root, "remote")
+		if err != nil || remotes == "" {
+			return 0
+		}
+		if out, err = runIn(root, "rev-list", "--count", "HEAD", "--not", "--remotes"); err != nil {
+			return 0
+		}
+	}
+	count, _ := strconv.Atoi(out)
+	return count
+}
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetWorkStatus(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	repo := filepath.Join(root, "repo")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if err := exec.Command("git", "init", "-q", "--bare", remote).Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := exec.Command("git", "init", "-q", repo).Run(); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, data string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ws, err := GetWorkStatus(repo)
+	if err != nil || ws.Ahead != 0 || ws.Uncommitted {
+		t.Fatalf("empty repo = %+v, %v", ws, err)
+	}
+
+	write("a.txt", "one")
+	run(repo, "add", ".")
+	run(repo, "commit", "-q", "-m", "first")
+	if ws, _ := GetWorkStatus(repo); ws.Ahead != 0 {
+		t.Errorf("without remotes nothing is unpushed, got %+v", ws)
+	}
+
+	run(repo, "remote", "add", "origin", remote)
+	if ws, _ := GetWorkStatus(repo); ws.Ahead != 1 {
+		t.Errorf("a commit on no remote = %+v, want 1 ahead", ws)
+	}
+	run(repo, "push", "-q", "-u", "origin", "HEAD")
+	write("a.txt", "two")
+	run(repo, "commit", "-q", "-am", "second")
+
+	old := time.Now().Add(-3 * time.Hour)
+	write("a.txt", "three")
+	if err := os.Chtimes(filepath.Join(repo, "a.txt"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	write("new.txt", "untracked")
+	ws, err = GetWorkStatus(filepath.Join(repo, "."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws.Ahead != 1 || !ws.Uncommitted || ws.OldestChange.Sub(old).Abs() > time.Second {
+		t.Errorf("work status = %+v, want 1 ahead and the oldest change at %v", ws, old)
+	}
+}
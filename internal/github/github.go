@@ -0,0 +1,212 @@
+// Package github fetches issues and open work for sessions' repositories,
+// through the gh CLI when it's installed and the REST API otherwise.
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// IssueRef identifies an issue. Repo is "owner/repo", or empty to use the
+// repository of the project directory.
+type IssueRef struct {
+	Repo   string
+	Number int
+}
+
+// String formats the reference as owner/repo#123 (or #123 without a repo)
+func (r IssueRef) String() string {
+	return fmt.Sprintf("%s#%d", r.Repo, r.Number)
+}
+
+// Issue is the part of a GitHub issue a session needs
+type Issue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Body   string `json:"body"`
+}
+
+var (
+	issueRefPattern = regexp.MustCompile(`^(?:([\w.-]+/[\w.-]+))?#?(\d+)$`)
+	issueURLPattern = regexp.MustCompile(`^https?://github\.com/([\w.-]+/[\w.-]+)/issues/(\d+)/?(?:[?#].*)?$`)
+)
+
+// ParseIssueRef parses "owner/repo#123", "#123", "123" or an issue URL
+func ParseIssueRef(s string) (IssueRef, error) {
+	s = strings.TrimSpace(s)
+	m := issueURLPattern.FindStringSubmatch(s)
+	if m == nil {
+		m = issueRefPattern.FindStringSubmatch(s)
+	}
+	if m == nil {
+		return IssueRef{}, fmt.Errorf("invalid issue %q: use owner/repo#123, #123 or an issue URL", s)
+	}
+	number, err := strconv.Atoi(m[2])
+	if err != nil || number <= 0 {
+		return IssueRef{}, fmt.Errorf("invalid issue number in %q", s)
+	}
+	return IssueRef{Repo: m[1], Number: number}, nil
+}
+
+// FetchIssue fetches the issue with gh, or from the REST API (authenticated
+// by GITHUB_TOKEN when set) when gh isn't installed. dir resolves a
+// reference without a repo: gh infers it, and for the API the origin remote
+// is used.
+func FetchIssue(ref IssueRef, dir string) (*Issue, error) {
+	if _, err := exec.LookPath("gh"); err == nil {
+		return fetchIssueGH(ref, dir)
+	}
+	if ref.Repo == "" {
+		remote, err := git.GetRemoteURL(dir)
+		if err != nil {
+			return nil, fmt.Errorf("issue %s has no repository and %s has no origin remote", ref, dir)
+		}
+		owner, repo := git.ParseRemoteURL(remote)
+		if owner == "" {
+			return nil, fmt.Errorf("cannot infer repository from remote %s", remote)
+		}
+		ref.Repo = owner + "/" + repo
+	}
+	return fetchIssueAPI(ref)
+}
+
+// fetchIssueGH fetches the issue with `gh issue view`
+func fetchIssueGH(ref IssueRef, dir string) (*Issue, error) {
+	args := []string{"issue", "view", strconv.Itoa(ref.Number), "--json", "number,title,url,body"}
+	if ref.Repo != "" {
+		args = append(args, "--repo", ref.Repo)
+	}
+	cmd := exec.Command("gh", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("gh issue view %s: %s", ref, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("gh issue view %s: %w", ref, err)
+	}
+	var issue Issue
+	if err := json.Unmarshal(output, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse gh output: %w", err)
+	}
+	return &issue, nil
+}
+
+// fetchIssueAPI fetches the issue from api.github.com
+func fetchIssueAPI(ref IssueRef) (*Issue, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/issues/%d", ref.Repo, ref.Number)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub API returned status %d for %s", resp.StatusCode, ref)
+	}
+
+	var body struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		HTMLURL string `json:"html_url"`
+		Body    string `json:"body"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse issue: %w", err)
+	}
+	return &Issue{Number: body.Number, Title: body.Title, URL: body.HTMLURL, Body: body.Body}, nil
+}
+
+// Notes returns the session notes for the issue: its reference and title,
+// then its URL
+func (i *Issue) Notes(ref IssueRef) string {
+	return fmt.Sprintf("%s: %s\n%s", ref, i.Title, i.URL)
+}
+
+// Prompt returns the initial prompt asking the agent to work on the issue
+func (i *Issue) Prompt() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Please work on GitHub issue #%d: %s\n%s\n", i.Number, i.Title, i.URL)
+	if body := strings.TrimSpace(i.Body); body != "" {
+		b.WriteString("\n" + body + "\n")
+	}
+	return b.String()
+}
+
+// WorkItem is an open pull request or issue
+type WorkItem struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+// OpenWork is what's open in a repository
+type OpenWork struct {
+	PullRequests []WorkItem
+	Issues       []WorkItem
+}
+
+// HasGitHubRemote reports whether dir is in a repository whose origin is on GitHub
+func HasGitHubRemote(dir string) bool {
+	remote, err := git.GetRemoteURL(dir)
+	return err == nil && strings.Contains(remote, "github.com")
+}
+
+// ListOpenWork lists up to limit open pull requests and issues of the
+// repository in dir with gh
+func ListOpenWork(dir string, limit int) (*OpenWork, error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return nil, fmt.Errorf("gh is not installed")
+	}
+	prs, err := listOpen(dir, "pr", limit)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := listOpen(dir, "issue", limit)
+	if err != nil {
+		return nil, err
+	}
+	return &OpenWork{PullRequests: prs, Issues: issues}, nil
+}
+
+// listOpen runs `gh pr list` or `gh issue list` in dir
+func listOpen(dir, kind string, limit int) ([]WorkItem, error) {
+	cmd := exec.Command("gh", kind, "list", "--state", "open",
+		"--limit", strconv.Itoa(limit), "--json", "number,title,url,author")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("gh %s list: %s", kind, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("gh %s list: %w", kind, err)
+	}
+	var items []WorkItem
+	if err := json.Unmarshal(output, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse gh output: %w", err)
+	}
+	return items, nil
+}
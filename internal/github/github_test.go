@@ -0,0 +1,49 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIssueRef(t *testing.T) {
+	tests := []struct {
+		input string
+		want  IssueRef
+	}{
+		{"org/repo#123", IssueRef{Repo: "org/repo", Number: 123}},
+		{"my-org/my.repo#7", IssueRef{Repo: "my-org/my.repo", Number: 7}},
+		{"#42", IssueRef{Number: 42}},
+		{"42", IssueRef{Number: 42}},
+		{"https://github.com/org/repo/issues/9", IssueRef{Repo: "org/repo", Number: 9}},
+		{"https://github.com/org/repo/issues/9#issuecomment-1", IssueRef{Repo: "org/repo", Number: 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseIssueRef(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, bad := range []string{"", "org/repo", "org#1x", "#0", "https://github.com/org/repo/pull/3"} {
+		_, err := ParseIssueRef(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestIssueNotesAndPrompt(t *testing.T) {
+	issue := &Issue{Number: 12, Title: "Crash on start", URL: "https://github.com/org/repo/issues/12", Body: "Steps:\n1. run it\n"}
+	ref := IssueRef{Repo: "org/repo", Number: 12}
+
+	assert.Equal(t, "org/repo#12: Crash on start\nhttps://github.com/org/repo/issues/12", issue.Notes(ref))
+
+	prompt := issue.Prompt()
+	assert.Contains(t, prompt, "#12: Crash on start")
+	assert.Contains(t, prompt, issue.URL)
+	assert.Contains(t, prompt, "1. run it")
+
+	issue.Body = ""
+	assert.NotContains(t, issue.Prompt(), "\n\n")
+}
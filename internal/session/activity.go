@@ -0,0 +1,155 @@
+package session
+
+import (
+	"log/slog"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// Activity kinds recorded in the state database
+const (
+	ActivityAttached = "attached" // The user was attached to the session
+	ActivityRunning  = "running"  // The session's agent was working
+	ActivityWaiting  = "waiting"  // The session's agent was waiting for input
+)
+
+// activityTracking enables recording running time; only the TUI observes
+// statuses continuously enough to measure it (see SetActivityTracking)
+var activityTracking atomic.Bool
+
+// SetActivityTracking turns recording of agent running time on or off. The
+// TUI turns it on unless it's read-only.
+func SetActivityTracking(on bool) {
+	activityTracking.Store(on)
+}
+
+// RecordActivity stores an activity interval for a session. Intervals under
+// a second are dropped.
+func RecordActivity(instanceID, kind string, started, ended time.Time) {
+	db := statedb.GetGlobal()
+	if db == nil || IsDemoMode() || ended.Sub(started) < time.Second {
+		return
+	}
+	row := statedb.ActivityRow{InstanceID: instanceID, Kind: kind, Started: started, Ended: ended}
+	if err := db.RecordActivity(row); err != nil {
+		sessionLog.Warn("record_activity_failed", slog.String("id", instanceID), slog.String("error", err.Error()))
+	}
+}
+
+// trackRunning records a running or waiting interval when the agent leaves
+// that status. Called with i.mu held, after each status update.
+func (i *Instance) trackRunning() {
+	if !activityTracking.Load() {
+		return
+	}
+	now := time.Now()
+	trackInterval(i.ID, ActivityRunning, i.Status == StatusRunning, &i.runningSince, now)
+	trackInterval(i.ID, ActivityWaiting, i.Status == StatusWaiting, &i.waitingSince, now)
+}
+
+// trackInterval opens *since when active starts and records the interval
+// when it ends
+func trackInterval(id, kind string, active bool, since *time.Time, now time.Time) {
+	switch {
+	case active && since.IsZero():
+		*since = now
+	case !active && !since.IsZero():
+		started := *since
+		*since = time.Time{}
+		go RecordActivity(id, kind, started, now)
+	}
+}
+
+// FlushRunning records the running and waiting intervals in progress, e.g.
+// when the TUI quits while the agent is working
+func (i *Instance) FlushRunning() {
+	i.mu.Lock()
+	open := i.openActivity(time.Now())
+	i.runningSince = time.Time{}
+	i.waitingSince = time.Time{}
+	i.mu.Unlock()
+	for _, r := range open {
+		RecordActivity(r.InstanceID, r.Kind, r.Started, r.Ended)
+	}
+}
+
+// OpenActivity returns the running or waiting interval in progress, ending
+// at now, which isn't in the state database yet
+func (i *Instance) OpenActivity(now time.Time) []statedb.ActivityRow {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.openActivity(now)
+}
+
+// openActivity is OpenActivity for callers holding i.mu
+func (i *Instance) openActivity(now time.Time) []statedb.ActivityRow {
+	var open []statedb.ActivityRow
+	if !i.runningSince.IsZero() {
+		open = append(open, statedb.ActivityRow{InstanceID: i.ID, Kind: ActivityRunning, Started: i.runningSince, Ended: now})
+	}
+	if !i.waitingSince.IsZero() {
+		open = append(open, statedb.ActivityRow{InstanceID: i.ID, Kind: ActivityWaiting, Started: i.waitingSince, Ended: now})
+	}
+	return open
+}
+
+// ActivityTotals is the attached, running and waiting time of one session
+type ActivityTotals struct {
+	Attached time.Duration
+	Running  time.Duration
+	Waiting  time.Duration
+}
+
+// SumActivity totals activity per session between from and to (zero = no
+// bound), clipping intervals to the range. Overlapping intervals of the same
+// kind, e.g. recorded by two TUIs, count once.
+func SumActivity(rows []statedb.ActivityRow, from, to time.Time) map[string]ActivityTotals {
+	type key struct{ id, kind string }
+	spans := make(map[key][]statedb.ActivityRow)
+	for _, r := range rows {
+		if !from.IsZero() && r.Started.Before(from) {
+			r.Started = from
+		}
+		if !to.IsZero() && r.Ended.After(to) {
+			r.Ended = to
+		}
+		if !r.Ended.After(r.Started) {
+			continue
+		}
+		k := key{r.InstanceID, r.Kind}
+		spans[k] = append(spans[k], r)
+	}
+
+	totals := make(map[string]ActivityTotals)
+	for k, list := range spans {
+		sort.Slice(list, func(a, b int) bool { return list[a].Started.Before(list[b].Started) })
+		var total time.Duration
+		curStart, curEnd := list[0].Started, list[0].Ended
+		for _, r := range list[1:] {
+			if !r.Started.After(curEnd) {
+				if r.Ended.After(curEnd) {
+					curEnd = r.Ended
+				}
+				continue
+			}
+			total += curEnd.Sub(curStart)
+			curStart, curEnd = r.Started, r.Ended
+		}
+		total += curEnd.Sub(curStart)
+
+		t := totals[k.id]
+		switch k.kind {
+		case ActivityAttached:
+			t.Attached += total
+		case ActivityRunning:
+			t.Running += total
+		case ActivityWaiting:
+			t.Waiting += total
+		}
+		totals[k.id] = t
+	}
+	return totals
+}
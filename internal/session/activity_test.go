@@ -0,0 +1,77 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+func TestSumActivity(t *testing.T) {
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	at := func(h float64) time.Time { return base.Add(time.Duration(h * float64(time.Hour))) }
+	rows := []statedb.ActivityRow{
+		{InstanceID: "a", Kind: ActivityAttached, Started: at(0), Ended: at(1)},
+		// Overlaps the first (a second TUI recorded it too): counts once
+		{InstanceID: "a", Kind: ActivityAttached, Started: at(0.5), Ended: at(1.5)},
+		{InstanceID: "a", Kind: ActivityRunning, Started: at(2), Ended: at(3)},
+		// Straddles the range start: clipped
+		{InstanceID: "b", Kind: ActivityRunning, Started: at(-1), Ended: at(1)},
+		// Entirely after the range
+		{InstanceID: "b", Kind: ActivityAttached, Started: at(30), Ended: at(31)},
+	}
+
+	totals := SumActivity(rows, base, at(24))
+	if got := totals["a"]; got.Attached != 90*time.Minute || got.Running != time.Hour {
+		t.Errorf("a = %+v, want 1h30m attached, 1h running", got)
+	}
+	if got := totals["b"]; got.Attached != 0 || got.Running != time.Hour {
+		t.Errorf("b = %+v, want 1h running only", got)
+	}
+
+	if got := SumActivity(rows, time.Time{}, time.Time{})["b"]; got.Running != 2*time.Hour || got.Attached != time.Hour {
+		t.Errorf("unbounded b = %+v", got)
+	}
+}
+
+func TestTrackRunning(t *testing.T) {
+	SetActivityTracking(true)
+	defer SetActivityTracking(false)
+
+	inst := NewInstance("api", "/tmp/api")
+	inst.Status = StatusRunning
+	inst.trackRunning()
+	if inst.runningSince.IsZero() {
+		t.Fatal("running status should start an interval")
+	}
+	started := inst.runningSince
+	inst.trackRunning()
+	if !inst.runningSince.Equal(started) {
+		t.Error("staying running should keep the interval's start")
+	}
+	inst.Status = StatusWaiting
+	inst.trackRunning()
+	if !inst.runningSince.IsZero() {
+		t.Error("leaving running should close the interval")
+	}
+}
+
+func TestTrackWaiting(t *testing.T) {
+	SetActivityTracking(true)
+	defer SetActivityTracking(false)
+
+	inst := NewInstance("api", "/tmp/api")
+	inst.Status = StatusWaiting
+	inst.trackRunning()
+	if inst.waitingSince.IsZero() || !inst.runningSince.IsZero() {
+		t.Fatal("waiting status should start a waiting interval only")
+	}
+	if open := inst.OpenActivity(time.Now()); len(open) != 1 || open[0].Kind != ActivityWaiting {
+		t.Errorf("OpenActivity = %+v, want one waiting interval", open)
+	}
+	inst.Status = StatusRunning
+	inst.trackRunning()
+	if !inst.waitingSince.IsZero() || inst.runningSince.IsZero() {
+		t.Error("running should close the waiting interval and open a running one")
+	}
+}
@@ -0,0 +1,245 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/logging"
+	"github.com/asheshgoplani/agent-deck/internal/platform"
+	"github.com/asheshgoplani/agent-deck/internal/terminal"
+)
+
+var alertLog = logging.ForComponent(logging.CompNotif)
+
+// Alert is one notification about a waiting session
+type Alert struct {
+	SessionID    string    `json:"id"`
+	Title        string    `json:"title"`
+	Group        string    `json:"group"`
+	Tool         string    `json:"tool"`
+	Channel      string    `json:"-"`
+	WebhookURL   string    `json:"-"`
+	Escalation   bool      `json:"escalation"`
+	WaitingSince time.Time `json:"waiting_since"`
+
+	// Digest lists the sessions held during quiet hours (digest alerts only)
+	Digest []string `json:"digest,omitempty"`
+}
+
+// Message is the alert's one-line text
+func (a Alert) Message() string {
+	if len(a.Digest) > 0 {
+		return fmt.Sprintf("%d session(s) waited during quiet hours: %s", len(a.Digest), strings.Join(a.Digest, ", "))
+	}
+	if a.Escalation {
+		return fmt.Sprintf("%s is still waiting (%s)", a.Title, time.Since(a.WaitingSince).Round(time.Minute))
+	}
+	return a.Title + " is waiting for input"
+}
+
+// alertState is what the Alerter remembers about one waiting session
+type alertState struct {
+	since     time.Time
+	escalated bool
+}
+
+// Alerter decides when to alert about waiting sessions, following each
+// session's group policy ([notifications] and [notifications.groups.*]):
+// one alert when a session starts waiting, and one escalation if it is still
+// waiting after the policy's threshold. Sessions already waiting on the
+// first check don't alert, so starting the TUI doesn't replay old alerts.
+// During quiet hours alerts are held and sent as a digest when they end.
+type Alerter struct {
+	settings NotificationsConfig
+
+	mu      sync.Mutex
+	waiting map[string]*alertState
+	primed  bool
+	held    []Alert // collected during quiet hours
+}
+
+// NewAlerter creates an alerter for settings
+func NewAlerter(settings NotificationsConfig) *Alerter {
+	if err := settings.ValidateQuietHours(); err != nil {
+		alertLog.Warn("quiet_hours_ignored", slog.String("error", err.Error()))
+	}
+	return &Alerter{settings: settings, waiting: make(map[string]*alertState)}
+}
+
+// Check records the current statuses and returns the alerts due at now
+func (a *Alerter) Check(instances []*Instance, now time.Time) []Alert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	alerts := a.check(instances, now)
+	if a.settings.InQuietHours(now) {
+		a.held = append(a.held, alerts...)
+		return nil
+	}
+	if len(a.held) > 0 {
+		alerts = append(digestAlerts(a.held), alerts...)
+		a.held = nil
+	}
+	return alerts
+}
+
+// SetSettings replaces the notification settings, keeping track of the
+// sessions already waiting so a settings change doesn't replay their alerts
+func (a *Alerter) SetSettings(settings NotificationsConfig) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.settings = settings
+}
+
+// Held returns how many alerts are being held for quiet hours
+func (a *Alerter) Held() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.held)
+}
+
+// check updates the waiting sessions and returns their due alerts. Callers
+// hold a.mu.
+func (a *Alerter) check(instances []*Instance, now time.Time) []Alert {
+	var alerts []Alert
+	seen := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		if inst.GetStatusThreadSafe() != StatusWaiting {
+			continue
+		}
+		seen[inst.ID] = true
+		policy := a.settings.PolicyFor(inst.GroupPath)
+		alert := Alert{
+			SessionID:  inst.ID,
+			Title:      inst.Title,
+			Group:      inst.GroupPath,
+			Tool:       inst.GetToolThreadSafe(),
+			WebhookURL: policy.WebhookURL,
+		}
+
+		st, tracked := a.waiting[inst.ID]
+		if !tracked {
+			a.waiting[inst.ID] = &alertState{since: now}
+			if a.primed && policy.GetChannel() != NotifyNone {
+				alert.Channel = policy.GetChannel()
+				alert.WaitingSince = now
+				alerts = append(alerts, alert)
+			}
+			continue
+		}
+		after := policy.GetEscalateAfter()
+		if st.escalated || after == 0 || now.Sub(st.since) < after {
+			continue
+		}
+		st.escalated = true
+		if policy.GetEscalateTo() != NotifyNone {
+			alert.Channel = policy.GetEscalateTo()
+			alert.Escalation = true
+			alert.WaitingSince = st.since
+			alerts = append(alerts, alert)
+		}
+	}
+	for id := range a.waiting {
+		if !seen[id] {
+			delete(a.waiting, id)
+		}
+	}
+	a.primed = true
+	return alerts
+}
+
+// digestAlerts folds held alerts into one per channel and webhook, listing
+// each session once, in the order they started waiting
+func digestAlerts(held []Alert) []Alert {
+	var digests []Alert
+	index := make(map[string]int)
+	listed := make(map[string]bool)
+	for _, h := range held {
+		key := h.Channel + "\x00" + h.WebhookURL
+		i, ok := index[key]
+		if !ok {
+			i = len(digests)
+			index[key] = i
+			digests = append(digests, Alert{Channel: h.Channel, WebhookURL: h.WebhookURL, WaitingSince: h.WaitingSince})
+		}
+		if id := key + "\x00" + h.SessionID; !listed[id] {
+			listed[id] = true
+			digests[i].Digest = append(digests[i].Digest, h.Title)
+		}
+	}
+	return digests
+}
+
+// SendAlert delivers an alert on its channel
+func SendAlert(a Alert) error {
+	switch a.Channel {
+	case NotifyDesktop:
+		return sendDesktopAlert(a)
+	case NotifyWebhook:
+		return sendWebhookAlert(a)
+	case NotifyNone:
+		return nil
+	}
+	return fmt.Errorf("unknown notification channel %q (use desktop, webhook or none)", a.Channel)
+}
+
+// SendAlerts delivers alerts in the background, logging failures
+func SendAlerts(alerts []Alert) {
+	for _, a := range alerts {
+		a := a
+		go func() {
+			if err := SendAlert(a); err != nil {
+				alertLog.Warn("alert_failed", slog.String("channel", a.Channel), slog.String("title", a.Title), slog.String("error", err.Error()))
+			}
+		}()
+	}
+}
+
+// sendDesktopAlert shows a desktop notification (macOS Notification Center
+// or notify-send on Linux)
+func sendDesktopAlert(a Alert) error {
+	var cmd *exec.Cmd
+	switch platform.Detect() {
+	case platform.PlatformMacOS:
+		script := fmt.Sprintf("display notification %s with title %s", terminal.AppleScriptQuote(a.Message()), terminal.AppleScriptQuote(desktopTitle(a)))
+		cmd = exec.Command("osascript", "-e", script)
+	case platform.PlatformLinux:
+		cmd = exec.Command("notify-send", desktopTitle(a), a.Message())
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", platform.Detect())
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", cmd.Args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// desktopTitle is the notification's title: agent-deck and the session's
+// group, so alerts from different projects can be told apart
+func desktopTitle(a Alert) string {
+	if a.Group == "" || len(a.Digest) > 0 {
+		return "agent-deck"
+	}
+	return "agent-deck · " + a.Group
+}
+
+// sendWebhookAlert POSTs the alert as JSON, with a "text" field for chat
+// webhooks (Slack, Mattermost) that show it as is
+func sendWebhookAlert(a Alert) error {
+	if a.WebhookURL == "" {
+		return fmt.Errorf("no webhook_url configured")
+	}
+	body, err := json.Marshal(struct {
+		Alert
+		Text string `json:"text"`
+	}{a, a.Message()})
+	if err != nil {
+		return err
+	}
+	return WebhookDelivery{URL: a.WebhookURL, ContentType: "application/json", Body: body}.Send()
+}
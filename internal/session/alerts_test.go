@@ -0,0 +1,197 @@
+package session
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestNotificationPolicyFor(t *testing.T) {
+	content := `
+[notifications]
+channel = "desktop"
+escalate_after_minutes = 10
+
+[notifications.groups."clients"]
+channel = "webhook"
+webhook_url = "https://hooks.example.com/a"
+
+[notifications.groups."clients/acme"]
+escalate_after_minutes = 2
+
+[notifications.groups."experiments"]
+channel = "none"
+`
+	var config UserConfig
+	if _, err := toml.Decode(content, &config); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	n := config.Notifications
+
+	if p := n.PolicyFor("work"); p.GetChannel() != NotifyDesktop || p.GetEscalateAfter() != 10*time.Minute {
+		t.Errorf("ungrouped policy = %+v, want the default", p)
+	}
+	p := n.PolicyFor("clients/acme/api")
+	if p.GetChannel() != NotifyWebhook || p.WebhookURL != "https://hooks.example.com/a" {
+		t.Errorf("subgroup should inherit its parent's channel, got %+v", p)
+	}
+	if p.GetEscalateAfter() != 2*time.Minute || p.GetEscalateTo() != NotifyWebhook {
+		t.Errorf("escalation = %v to %s, want 2m to webhook", p.GetEscalateAfter(), p.GetEscalateTo())
+	}
+	if n.PolicyFor("experiments").GetChannel() != NotifyNone {
+		t.Error("experiments should be silent")
+	}
+	if (NotificationsConfig{}).PolicyFor("x").GetChannel() != NotifyNone {
+		t.Error("alerts should be off by default")
+	}
+}
+
+func TestAlerterCheck(t *testing.T) {
+	n := NotificationsConfig{
+		NotificationPolicy: NotificationPolicy{Channel: NotifyDesktop, EscalateAfterMinutes: 5, EscalateTo: NotifyWebhook},
+		Groups:             map[string]NotificationPolicy{"quiet": {Channel: NotifyNone, EscalateTo: NotifyNone}},
+	}
+	loud := NewInstance("loud", "/tmp/loud")
+	quiet := NewInstance("quiet", "/tmp/quiet")
+	quiet.GroupPath = "quiet"
+	instances := []*Instance{loud, quiet}
+	a := NewAlerter(n)
+	now := time.Now()
+
+	loud.SetStatusThreadSafe(StatusWaiting)
+	if alerts := a.Check(instances, now); len(alerts) != 0 {
+		t.Fatalf("sessions waiting on the first check should not alert, got %v", alerts)
+	}
+	loud.SetStatusThreadSafe(StatusRunning)
+	a.Check(instances, now)
+
+	loud.SetStatusThreadSafe(StatusWaiting)
+	quiet.SetStatusThreadSafe(StatusWaiting)
+	alerts := a.Check(instances, now)
+	if len(alerts) != 1 || alerts[0].SessionID != loud.ID || alerts[0].Channel != NotifyDesktop || alerts[0].Escalation {
+		t.Fatalf("alerts = %+v, want one desktop alert for loud", alerts)
+	}
+	if alerts := a.Check(instances, now.Add(time.Minute)); len(alerts) != 0 {
+		t.Errorf("no repeat before the threshold, got %+v", alerts)
+	}
+	alerts = a.Check(instances, now.Add(5*time.Minute))
+	if len(alerts) != 1 || !alerts[0].Escalation || alerts[0].Channel != NotifyWebhook {
+		t.Fatalf("alerts = %+v, want one webhook escalation", alerts)
+	}
+	if alerts := a.Check(instances, now.Add(time.Hour)); len(alerts) != 0 {
+		t.Errorf("escalation should be sent once, got %+v", alerts)
+	}
+}
+
+func TestDesktopTitle(t *testing.T) {
+	tests := []struct {
+		alert Alert
+		want  string
+	}{
+		{Alert{Title: "api", Group: "work/backend"}, "agent-deck · work/backend"},
+		{Alert{Title: "api"}, "agent-deck"},
+		{Alert{Group: "work", Digest: []string{"api", "web"}}, "agent-deck"},
+	}
+	for _, tt := range tests {
+		if got := desktopTitle(tt.alert); got != tt.want {
+			t.Errorf("desktopTitle(%+v) = %q, want %q", tt.alert, got, tt.want)
+		}
+	}
+}
+
+func TestAlerterSetSettings(t *testing.T) {
+	inst := NewInstance("api", "/tmp/api")
+	instances := []*Instance{inst}
+	a := NewAlerter(NotificationsConfig{})
+	now := time.Now()
+	a.Check(instances, now)
+
+	inst.SetStatusThreadSafe(StatusWaiting)
+	if alerts := a.Check(instances, now); len(alerts) != 0 {
+		t.Fatalf("channel none should not alert, got %+v", alerts)
+	}
+	a.SetSettings(NotificationsConfig{NotificationPolicy: NotificationPolicy{Channel: NotifyDesktop}})
+	if alerts := a.Check(instances, now); len(alerts) != 0 {
+		t.Errorf("turning desktop on should not replay a session already waiting, got %+v", alerts)
+	}
+	inst.SetStatusThreadSafe(StatusRunning)
+	a.Check(instances, now)
+	inst.SetStatusThreadSafe(StatusWaiting)
+	if alerts := a.Check(instances, now); len(alerts) != 1 || alerts[0].Channel != NotifyDesktop {
+		t.Errorf("alerts = %+v, want one desktop alert", alerts)
+	}
+}
+
+func TestSendWebhookAlert(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	err := SendAlert(Alert{Title: "api", Group: "clients", Channel: NotifyWebhook, WebhookURL: srv.URL})
+	if err != nil {
+		t.Fatalf("SendAlert: %v", err)
+	}
+	if got["title"] != "api" || got["group"] != "clients" || got["text"] != "api is waiting for input" {
+		t.Errorf("payload = %v", got)
+	}
+	if err := SendAlert(Alert{Channel: NotifyWebhook}); err == nil {
+		t.Error("webhook without a URL should fail")
+	}
+	if err := SendAlert(Alert{Channel: "pager"}); err == nil {
+		t.Error("unknown channel should fail")
+	}
+}
+
+func TestInQuietHours(t *testing.T) {
+	at := func(hhmm string) time.Time {
+		t, _ := time.Parse("15:04", hhmm)
+		return t
+	}
+	overnight := NotificationsConfig{QuietHours: "22:00–08:00"}
+	for hhmm, want := range map[string]bool{"21:59": false, "22:00": true, "03:00": true, "07:59": true, "08:00": false} {
+		if got := overnight.InQuietHours(at(hhmm)); got != want {
+			t.Errorf("22:00-08:00 at %s = %v, want %v", hhmm, got, want)
+		}
+	}
+	daytime := NotificationsConfig{QuietHours: "12:00-13:00"}
+	if !daytime.InQuietHours(at("12:30")) || daytime.InQuietHours(at("13:30")) {
+		t.Error("12:00-13:00 window is wrong")
+	}
+	bad := NotificationsConfig{QuietHours: "late"}
+	if bad.ValidateQuietHours() == nil || bad.InQuietHours(at("23:00")) {
+		t.Error("an invalid window should fail validation and never be quiet")
+	}
+}
+
+func TestAlerterHoldsDuringQuietHours(t *testing.T) {
+	n := NotificationsConfig{
+		NotificationPolicy: NotificationPolicy{Channel: NotifyDesktop},
+		QuietHours:         "22:00-08:00",
+	}
+	one := NewInstance("one", "/tmp/one")
+	two := NewInstance("two", "/tmp/two")
+	instances := []*Instance{one, two}
+	night := time.Date(2026, 1, 1, 23, 0, 0, 0, time.Local)
+	a := NewAlerter(n)
+	a.Check(instances, night)
+
+	one.SetStatusThreadSafe(StatusWaiting)
+	two.SetStatusThreadSafe(StatusWaiting)
+	if alerts := a.Check(instances, night); len(alerts) != 0 || a.Held() != 2 {
+		t.Fatalf("alerts = %v, held = %d; want none sent and 2 held", alerts, a.Held())
+	}
+
+	alerts := a.Check(instances, night.Add(10*time.Hour))
+	if len(alerts) != 1 || len(alerts[0].Digest) != 2 || alerts[0].Channel != NotifyDesktop {
+		t.Fatalf("alerts = %+v, want one desktop digest of 2 sessions", alerts)
+	}
+	if a.Held() != 0 {
+		t.Error("digest should clear held alerts")
+	}
+}
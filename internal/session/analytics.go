@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -35,6 +36,9 @@ type SessionAnalytics struct {
 	// Cost estimation
 	EstimatedCost float64 `json:"estimated_cost"`
 
+	// Model of the last assistant turn, for pricing (see PricingFor)
+	Model string `json:"model,omitempty"`
+
 	// 5-hour billing blocks
 	BillingBlocks []BillingBlock `json:"billing_blocks"`
 }
@@ -77,10 +81,10 @@ func (a *SessionAnalytics) ContextPercent(modelLimit int) float64 {
 
 // ModelPricing holds pricing per million tokens for a model
 type ModelPricing struct {
-	Input      float64
-	Output     float64
-	CacheRead  float64
-	CacheWrite float64
+	Input      float64 `toml:"input"`
+	Output     float64 `toml:"output"`
+	CacheRead  float64 `toml:"cache_read"`
+	CacheWrite float64 `toml:"cache_write"`
 }
 
 // modelPricing contains pricing per million tokens for each model (as of Jan 2025)
@@ -89,27 +93,21 @@ var modelPricing = map[string]ModelPricing{
 	"claude-opus-4-20250514":   {Input: 15.0, Output: 75.0, CacheRead: 1.50, CacheWrite: 18.75},
 	"claude-3-5-sonnet":        {Input: 3.0, Output: 15.0, CacheRead: 0.30, CacheWrite: 3.75},
 	"claude-3-5-haiku":         {Input: 0.80, Output: 4.0, CacheRead: 0.08, CacheWrite: 1.0},
+	// Prefixes for later dated releases (see PricingFor)
+	"claude-opus-4":   {Input: 15.0, Output: 75.0, CacheRead: 1.50, CacheWrite: 18.75},
+	"claude-sonnet-4": {Input: 3.0, Output: 15.0, CacheRead: 0.30, CacheWrite: 3.75},
 	// Default fallback uses Sonnet pricing
 	"default": {Input: 3.0, Output: 15.0, CacheRead: 0.30, CacheWrite: 3.75},
 }
 
 // CalculateCost estimates session cost based on token usage and model pricing
 func (a *SessionAnalytics) CalculateCost(model string) float64 {
-	pricing, ok := modelPricing[model]
-	if !ok {
-		pricing = modelPricing["default"]
-	}
-
-	// Convert to millions
-	inputM := float64(a.InputTokens) / 1_000_000
-	outputM := float64(a.OutputTokens) / 1_000_000
-	cacheReadM := float64(a.CacheReadTokens) / 1_000_000
-	cacheWriteM := float64(a.CacheWriteTokens) / 1_000_000
+	return a.CostWith(PricingFor(model, nil))
+}
 
-	return inputM*pricing.Input +
-		outputM*pricing.Output +
-		cacheReadM*pricing.CacheRead +
-		cacheWriteM*pricing.CacheWrite
+// CostWith prices the session's token usage at pricing
+func (a *SessionAnalytics) CostWith(pricing ModelPricing) float64 {
+	return pricing.cost(a.InputTokens, a.OutputTokens, a.CacheReadTokens, a.CacheWriteTokens)
 }
 
 // jsonlEntry represents a single line in a Claude session JSONL file
@@ -117,6 +115,7 @@ type jsonlEntry struct {
 	Type      string    `json:"type"`
 	Timestamp time.Time `json:"timestamp"`
 	Message   struct {
+		Model string `json:"model"`
 		Usage struct {
 			InputTokens              int `json:"input_tokens"`
 			OutputTokens             int `json:"output_tokens"`
@@ -133,6 +132,12 @@ type jsonlEntry struct {
 
 // ParseSessionJSONL parses a Claude session JSONL file and returns analytics
 func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
+	return ParseSessionJSONLRange(path, time.Time{}, time.Time{})
+}
+
+// ParseSessionJSONLRange is ParseSessionJSONL counting only the turns between
+// from and to (zero = no bound), e.g. for a billing period
+func ParseSessionJSONLRange(path string, from, to time.Time) (*SessionAnalytics, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -160,6 +165,9 @@ func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
 		if entry.Type != "assistant" {
 			continue
 		}
+		if (!from.IsZero() && entry.Timestamp.Before(from)) || (!to.IsZero() && !entry.Timestamp.Before(to)) {
+			continue
+		}
 
 		// Track timing
 		if !entry.Timestamp.IsZero() {
@@ -182,6 +190,11 @@ func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
 		analytics.CurrentContextTokens = entry.Message.Usage.InputTokens +
 			entry.Message.Usage.CacheReadInputTokens
 
+		// Errors and interruptions are logged as model "<synthetic>"
+		if m := entry.Message.Model; m != "" && !strings.HasPrefix(m, "<") {
+			analytics.Model = m
+		}
+
 		// Count turn
 		analytics.TotalTurns++
 
@@ -154,6 +154,25 @@ func TestParseJSONL_WithTimestamps(t *testing.T) {
 	assert.Equal(t, 10*time.Minute, analytics.Duration)
 }
 
+func TestParseJSONLRange(t *testing.T) {
+	dir := t.TempDir()
+	jsonlPath := filepath.Join(dir, "session.jsonl")
+
+	jsonl := `{"type":"assistant","timestamp":"2025-01-09T23:00:00Z","message":{"usage":{"input_tokens":100,"output_tokens":50}}}
+{"type":"assistant","timestamp":"2025-01-10T10:05:00Z","message":{"usage":{"input_tokens":200,"output_tokens":100}}}
+{"type":"assistant","timestamp":"2025-01-11T00:00:00Z","message":{"usage":{"input_tokens":150,"output_tokens":75}}}`
+	require.NoError(t, os.WriteFile(jsonlPath, []byte(jsonl), 0644))
+
+	from := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	analytics, err := ParseSessionJSONLRange(jsonlPath, from, from.Add(24*time.Hour))
+	require.NoError(t, err)
+
+	// Only the turn on Jan 10; the range end is exclusive
+	assert.Equal(t, 1, analytics.TotalTurns)
+	assert.Equal(t, 200, analytics.InputTokens)
+	assert.Equal(t, 100, analytics.OutputTokens)
+}
+
 func TestParseJSONL_WithCacheTokens(t *testing.T) {
 	dir := t.TempDir()
 	jsonlPath := filepath.Join(dir, "session.jsonl")
@@ -0,0 +1,193 @@
+package session
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// attachHookTimeout bounds a pre-attach or post-detach command, so a hung
+// hook can't keep the user out of the session
+const attachHookTimeout = 60 * time.Second
+
+// exitHooksWatched is set in the process that runs post-exit hooks for
+// sessions that end on their own (see WatchSessionExits)
+var exitHooksWatched atomic.Bool
+
+// WatchSessionExits makes UpdateStatus run the post-exit hook when a
+// session it saw running is gone from tmux. The TUI calls it when not
+// read-only, so a session ending on its own is cleaned up once.
+func WatchSessionExits() {
+	exitHooksWatched.Store(true)
+}
+
+// AttachHooks returns the commands to run before attaching and after
+// detaching: the session's own, else its tool's pre_attach / post_detach,
+// else [hooks]
+func (i *Instance) AttachHooks() (preAttach, postDetach string) {
+	preAttach, postDetach = i.PreAttachHook, i.PostDetachHook
+	if def := GetToolDef(i.Tool); def != nil {
+		preAttach = cmp.Or(preAttach, def.PreAttach)
+		postDetach = cmp.Or(postDetach, def.PostDetach)
+	}
+	hooks := GetHookSettings()
+	return cmp.Or(preAttach, hooks.PreAttach), cmp.Or(postDetach, hooks.PostDetach)
+}
+
+// LifecycleHooks returns the commands to run before starting and after
+// exiting, falling back like AttachHooks (none in demo mode, which starts
+// nothing)
+func (i *Instance) LifecycleHooks() (preStart, postExit string) {
+	if IsDemoMode() {
+		return "", ""
+	}
+	preStart, postExit = i.PreStartHook, i.PostExitHook
+	if def := GetToolDef(i.Tool); def != nil {
+		preStart = cmp.Or(preStart, def.PreStart)
+		postExit = cmp.Or(postExit, def.PostExit)
+	}
+	hooks := GetHookSettings()
+	return cmp.Or(preStart, hooks.PreStart), cmp.Or(postExit, hooks.PostExit)
+}
+
+// RunPreStartHook runs the pre-start command, if any. Its error ends with
+// the last line the command printed, as the start is aborted.
+func (i *Instance) RunPreStartHook() error {
+	pre, _ := i.LifecycleHooks()
+	var out bytes.Buffer
+	err := i.runAttachHook("pre-start", pre, &out)
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); err != nil && lines[len(lines)-1] != "" {
+		err = fmt.Errorf("%w: %s", err, lines[len(lines)-1])
+	}
+	return err
+}
+
+// RunPostExitHook runs the post-exit command, if any
+func (i *Instance) RunPostExitHook(out io.Writer) error {
+	_, post := i.LifecycleHooks()
+	return i.runAttachHook("post-exit", post, out)
+}
+
+// exitConfirmDelay is how long a session UpdateStatus found gone is given
+// to show up again before it counts as ended (the session cache can miss
+// one briefly)
+var exitConfirmDelay = 2 * time.Second
+
+// noticeTmuxExists records whether the tmux session exists, checking in the
+// background whether one seen running has ended outside a start, kill or
+// restart (see confirmExit). Called by UpdateStatus with i.mu held.
+func (i *Instance) noticeTmuxExists(exists bool) {
+	if exists {
+		i.seenAlive = true
+		return
+	}
+	if !i.seenAlive || i.operation != "" {
+		return
+	}
+	i.seenAlive = false
+	if exitHooksWatched.Load() && i.tmuxSession != nil {
+		go i.confirmExit(i.tmuxSession)
+	}
+}
+
+// confirmExit runs the post-exit hook for a tmux session UpdateStatus found
+// gone, once tmux confirms it is and unless a stop or restart (in this or
+// another process) has claimed its end
+func (i *Instance) confirmExit(s *tmux.Session) {
+	time.Sleep(exitConfirmDelay)
+	if s.ExistsNow() || i.Operation() != "" || i.GetTmuxSession() != s {
+		return
+	}
+	if claimExitHook(s.Name) {
+		_ = i.RunPostExitHook(io.Discard)
+	}
+}
+
+// claimExitHook reports whether this process handles the end of the tmux
+// session name. A CLI stop and a TUI watching the session both see it end,
+// so the first to claim it in the state database runs the post-exit hook.
+// Without a database there is nobody to share with, and the caller runs it.
+func claimExitHook(name string) bool {
+	db := statedb.GetGlobal()
+	if db == nil {
+		return true
+	}
+	claimed, err := db.ClaimExitHook(name)
+	if err != nil {
+		sessionLog.Warn("claim_exit_hook_failed", slog.String("session", name), slog.String("error", err.Error()))
+		return true
+	}
+	return claimed
+}
+
+// releaseExitHook undoes claimExitHook when the session did not end after all
+func releaseExitHook(name string) {
+	if db := statedb.GetGlobal(); db != nil {
+		_ = db.ReleaseExitHook(name)
+	}
+}
+
+// RunPreAttachHook runs the pre-attach command, if any, writing its output
+// to out (the terminal, which the TUI hands over while attaching)
+func (i *Instance) RunPreAttachHook(out io.Writer) error {
+	pre, _ := i.AttachHooks()
+	return i.runAttachHook("pre-attach", pre, out)
+}
+
+// RunPostDetachHook runs the post-detach command, if any
+func (i *Instance) RunPostDetachHook(out io.Writer) error {
+	_, post := i.AttachHooks()
+	return i.runAttachHook("post-detach", post, out)
+}
+
+// runAttachHook runs command with sh in the project directory. The session
+// is described by AGENTDECK_INSTANCE_ID, AGENTDECK_SESSION_TITLE,
+// AGENTDECK_SESSION_PATH, AGENTDECK_SESSION_GROUP, AGENTDECK_TOOL and
+// AGENTDECK_HOOK (pre-start, pre-attach, post-detach, post-exit or
+// file-watch), plus any env.
+func (i *Instance) runAttachHook(kind, command string, out io.Writer, env ...string) error {
+	if command == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), attachHookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	if info, err := os.Stat(i.ProjectPath); err == nil && info.IsDir() {
+		cmd.Dir = i.ProjectPath
+	}
+	cmd.Env = append(os.Environ(),
+		"AGENTDECK_INSTANCE_ID="+i.ID,
+		"AGENTDECK_SESSION_TITLE="+i.Title,
+		"AGENTDECK_SESSION_PATH="+i.ProjectPath,
+		"AGENTDECK_SESSION_GROUP="+i.GroupPath,
+		"AGENTDECK_TOOL="+i.Tool,
+		"AGENTDECK_HOOK="+kind,
+	)
+	cmd.Env = append(cmd.Env, env...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	start := time.Now()
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %s", attachHookTimeout)
+	}
+	if err != nil {
+		sessionLog.Warn("attach_hook_failed", slog.String("hook", kind), slog.String("title", i.Title), slog.String("error", err.Error()))
+		return fmt.Errorf("%s hook: %w", kind, err)
+	}
+	sessionLog.Debug("attach_hook_ran", slog.String("hook", kind), slog.String("title", i.Title), slog.Duration("took", time.Since(start)))
+	return nil
+}
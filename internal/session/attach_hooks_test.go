@@ -0,0 +1,119 @@
+package session
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func TestAttachHooks(t *testing.T) {
+	userConfigCacheMu.Lock()
+	origCache := userConfigCache
+	userConfigCache = &UserConfig{Tools: map[string]ToolDef{
+		"claude": {PreAttach: "echo tool-pre", PostDetach: "echo tool-post"},
+	}}
+	userConfigCacheMu.Unlock()
+	defer func() {
+		userConfigCacheMu.Lock()
+		userConfigCache = origCache
+		userConfigCacheMu.Unlock()
+	}()
+
+	dir := t.TempDir()
+	inst := &Instance{ID: "abc", Title: "web", Tool: "claude", ProjectPath: dir}
+
+	pre, post := inst.AttachHooks()
+	if pre != "echo tool-pre" || post != "echo tool-post" {
+		t.Errorf("tool defaults = %q, %q", pre, post)
+	}
+
+	inst.PreAttachHook = `echo "$AGENTDECK_HOOK $AGENTDECK_SESSION_TITLE $AGENTDECK_INSTANCE_ID" > hook.txt`
+	var out bytes.Buffer
+	if err := inst.RunPreAttachHook(&out); err != nil {
+		t.Fatalf("RunPreAttachHook: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "hook.txt"))
+	if err != nil || strings.TrimSpace(string(got)) != "pre-attach web abc" {
+		t.Errorf("session hook should run in the project dir with its env, got %q, %v", got, err)
+	}
+
+	out.Reset()
+	if err := inst.RunPostDetachHook(&out); err != nil || strings.TrimSpace(out.String()) != "tool-post" {
+		t.Errorf("post-detach should fall back to the tool's, got %q, %v", out.String(), err)
+	}
+
+	inst.PostDetachHook = "exit 3"
+	if err := inst.RunPostDetachHook(&out); err == nil || !strings.Contains(err.Error(), "post-detach") {
+		t.Errorf("failing hook error = %v", err)
+	}
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	userConfigCacheMu.Lock()
+	origCache := userConfigCache
+	userConfigCache = &UserConfig{
+		Tools: map[string]ToolDef{"claude": {PostExit: "echo tool-exit"}},
+		Hooks: HookSettings{PreStart: "echo global-start", PreAttach: "echo global-attach", PostExit: "echo global-exit"},
+	}
+	userConfigCacheMu.Unlock()
+	defer func() {
+		userConfigCacheMu.Lock()
+		userConfigCache = origCache
+		userConfigCacheMu.Unlock()
+	}()
+
+	dir := t.TempDir()
+	inst := &Instance{ID: "abc", Title: "web", Tool: "claude", ProjectPath: dir, GroupPath: "work"}
+	if pre, post := inst.LifecycleHooks(); pre != "echo global-start" || post != "echo tool-exit" {
+		t.Errorf("defaults = %q, %q, want [hooks] pre_start and the tool's post_exit", pre, post)
+	}
+	if pre, _ := inst.AttachHooks(); pre != "echo global-attach" {
+		t.Errorf("pre-attach = %q, want the [hooks] fallback", pre)
+	}
+
+	inst.PreStartHook = `echo "$AGENTDECK_HOOK $AGENTDECK_SESSION_GROUP $AGENTDECK_TOOL $AGENTDECK_SESSION_PATH" > start.txt`
+	if err := inst.RunPreStartHook(); err != nil {
+		t.Fatalf("RunPreStartHook: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "start.txt"))
+	if err != nil || strings.TrimSpace(string(got)) != "pre-start work claude "+dir {
+		t.Errorf("pre-start env = %q, %v", got, err)
+	}
+
+	inst.PreStartHook = "echo starting; echo 'compose: no such service' >&2; exit 1"
+	if err := inst.RunPreStartHook(); err == nil || !strings.HasSuffix(err.Error(), ": compose: no such service") {
+		t.Errorf("failing pre-start error = %v, want its last output line", err)
+	}
+
+	// A session seen running that ends on its own runs post-exit once,
+	// only where exits are watched
+	inst.PostExitHook = "echo exited >> exit.txt"
+	inst.tmuxSession = tmux.NewSession(fmt.Sprintf("agentdeck_exit_test_%d", time.Now().UnixNano()), dir)
+	defer exitHooksWatched.Store(false)
+	exitHooksWatched.Store(true)
+	defer func(d time.Duration) { exitConfirmDelay = d }(exitConfirmDelay)
+	exitConfirmDelay = 0
+	inst.noticeTmuxExists(false)
+	inst.noticeTmuxExists(true)
+	inst.noticeTmuxExists(false)
+	inst.noticeTmuxExists(false)
+	exitFile := filepath.Join(dir, "exit.txt")
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		got, _ = os.ReadFile(exitFile)
+		if len(got) > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got, _ = os.ReadFile(exitFile); string(got) != "exited\n" {
+		t.Errorf("post-exit output = %q, want one run", got)
+	}
+}
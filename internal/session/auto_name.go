@@ -0,0 +1,163 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// What a session's title was last named from (see Instance.AutoTitleFrom)
+const (
+	autoTitleFromPrompt  = "prompt"
+	autoTitleFromSummary = "summary"
+)
+
+var (
+	// titleLeadPattern strips requests and greetings from the start of a
+	// prompt ("please", "can you", "I want you to")
+	titleLeadPattern = regexp.MustCompile(`(?i)^(?:(?:please|pls|hey|hi|ok|okay|so|now|can you|could you|would you|will you|i want you to|i'd like you to|i need you to|help me|let's|lets)\b[\s,]*)+`)
+
+	// titleArticles are dropped from prompt titles
+	titleArticles = map[string]bool{"a": true, "an": true, "the": true}
+)
+
+// TitleFromPrompt turns a prompt into a short session title: its first
+// sentence without requests or articles, cut at a word to maxLen runes, so
+// "Please fix the flaky auth tests." is "fix flaky auth tests". Slash
+// commands and empty prompts give "".
+func TitleFromPrompt(prompt string, maxLen int) string {
+	var line string
+	for _, l := range strings.Split(prompt, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			line = l
+			break
+		}
+	}
+	if line == "" || strings.HasPrefix(line, "/") {
+		return ""
+	}
+	line = strings.NewReplacer("**", "", "__", "", "`", "", "#", "").Replace(line)
+	if loc := summarySentenceEnd.FindStringIndex(line); loc != nil {
+		line = line[:loc[0]]
+	}
+	line = titleLeadPattern.ReplaceAllString(strings.TrimSpace(line), "")
+
+	var words []string
+	for _, w := range strings.Fields(line) {
+		if !titleArticles[strings.ToLower(w)] {
+			words = append(words, w)
+		}
+	}
+	title := shortenTitle(words, maxLen)
+	// "Fix ..." reads as a task like the rest of the list, "API ..." stays
+	if r, size := utf8.DecodeRuneInString(title); unicode.IsUpper(r) {
+		if next, _ := utf8.DecodeRuneInString(title[size:]); !unicode.IsUpper(next) {
+			title = string(unicode.ToLower(r)) + title[size:]
+		}
+	}
+	return title
+}
+
+// shortenTitle joins words up to maxLen runes, cutting a single long word
+func shortenTitle(words []string, maxLen int) string {
+	var title string
+	for _, w := range words {
+		next := w
+		if title != "" {
+			next = title + " " + w
+		}
+		if utf8.RuneCountInString(next) > maxLen {
+			if title == "" {
+				title = string([]rune(w)[:maxLen])
+			}
+			break
+		}
+		title = next
+	}
+	return strings.TrimRight(title, ",.:;!?-")
+}
+
+// AutoNameFromPrompt names the session after prompt if automatic naming is
+// on and its title is still the generated one it hasn't been named from
+// yet. Returns whether the title changed.
+func (i *Instance) AutoNameFromPrompt(prompt string) bool {
+	if i.AutoTitleFrom != "" || !i.autoNameable() {
+		return false
+	}
+	settings := GetAutoNameSettings()
+	if !settings.Enabled {
+		return false
+	}
+	return i.applyAutoTitle(TitleFromPrompt(prompt, settings.GetMaxLength()), autoTitleFromPrompt)
+}
+
+// autoNameable reports whether the title is one agent-deck generated (not
+// chosen or edited by hand)
+func (i *Instance) autoNameable() bool {
+	return i.AutoTitle != "" && i.Title == i.AutoTitle
+}
+
+// maybeAutoName renames the session after its task from what the last
+// status update saw: the agent's own summary, else the first prompt.
+// Called from UpdateStatus with i.mu held.
+func (i *Instance) maybeAutoName() {
+	if !i.autoNameable() || i.AutoTitleFrom == autoTitleFromSummary {
+		return
+	}
+	settings := GetAutoNameSettings()
+	if !settings.Enabled {
+		return
+	}
+	if settings.GetFromSummary() && i.agentSummary != "" {
+		i.applyAutoTitle(shortenTitle(strings.Fields(i.agentSummary), settings.GetMaxLength()), autoTitleFromSummary)
+		return
+	}
+	if i.AutoTitleFrom == "" && i.LatestPrompt != "" {
+		i.applyAutoTitle(TitleFromPrompt(i.LatestPrompt, settings.GetMaxLength()), autoTitleFromPrompt)
+	}
+}
+
+// applyAutoTitle sets an automatic title, remembering it so a later rename
+// by hand stops automatic naming
+func (i *Instance) applyAutoTitle(title, from string) bool {
+	if title == "" {
+		return false
+	}
+	i.AutoTitleFrom = from
+	if title == i.Title {
+		return false
+	}
+	sessionLog.Info("auto_named", slog.String("session_id", i.ID), slog.String("old_title", i.Title), slog.String("title", title), slog.String("from", from))
+	i.Title = title
+	i.AutoTitle = title
+	i.SyncTmuxDisplayName()
+	return true
+}
+
+// parseClaudeSummary returns the last task summary Claude recorded in JSONL
+// data ({"type":"summary","summary":"..."})
+func parseClaudeSummary(data []byte) string {
+	var latest string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !bytes.Contains(line, []byte(`"summary"`)) {
+			continue
+		}
+		var record struct {
+			Type    string `json:"type"`
+			Summary string `json:"summary"`
+		}
+		if err := json.Unmarshal(line, &record); err == nil && record.Type == "summary" && strings.TrimSpace(record.Summary) != "" {
+			latest = strings.TrimSpace(record.Summary)
+		}
+	}
+	return latest
+}
@@ -0,0 +1,77 @@
+package session
+
+import "testing"
+
+func TestTitleFromPrompt(t *testing.T) {
+	tests := []struct {
+		prompt string
+		want   string
+	}{
+		{"Please fix the flaky auth tests.", "fix flaky auth tests"},
+		{"Can you add a retry flag to the uploader? It keeps timing out", "add retry flag to uploader"},
+		{"\n\n  **Refactor** `storage.go` to use the new API\nmore details", "refactor storage.go to use new API"},
+		{"API keys leak into the logs", "API keys leak into logs"},
+		{"/clear", ""},
+		{"   ", ""},
+		{"implement the websocket reconnection logic with exponential backoff and jitter", "implement websocket reconnection logic"},
+		{"supercalifragilisticexpialidocious-and-then-some-more-words-glued", "supercalifragilisticexpialidocious-and-t"},
+	}
+	for _, tt := range tests {
+		if got := TitleFromPrompt(tt.prompt, 40); got != tt.want {
+			t.Errorf("TitleFromPrompt(%q) = %q, want %q", tt.prompt, got, tt.want)
+		}
+	}
+}
+
+func TestAutoName(t *testing.T) {
+	userConfigCacheMu.Lock()
+	origCache := userConfigCache
+	userConfigCache = &UserConfig{AutoName: AutoNameSettings{Enabled: true}}
+	userConfigCacheMu.Unlock()
+	defer func() {
+		userConfigCacheMu.Lock()
+		userConfigCache = origCache
+		userConfigCacheMu.Unlock()
+	}()
+
+	inst := &Instance{Title: "api (3)", AutoTitle: "api (3)"}
+	if !inst.AutoNameFromPrompt("Please fix the flaky auth tests") || inst.Title != "fix flaky auth tests" {
+		t.Fatalf("first prompt: title = %q", inst.Title)
+	}
+	if inst.AutoNameFromPrompt("now update the changelog") {
+		t.Errorf("a later prompt renamed it to %q", inst.Title)
+	}
+
+	// The agent's summary replaces the prompt title, once
+	inst.agentSummary = "Fix flaky auth middleware tests"
+	inst.maybeAutoName()
+	if inst.Title != "Fix flaky auth middleware tests" || inst.AutoTitleFrom != autoTitleFromSummary {
+		t.Errorf("summary: title = %q from %q", inst.Title, inst.AutoTitleFrom)
+	}
+	inst.agentSummary = "Something else"
+	inst.maybeAutoName()
+	if inst.Title != "Fix flaky auth middleware tests" {
+		t.Errorf("second summary renamed it to %q", inst.Title)
+	}
+
+	// Titles chosen or edited by hand are kept
+	manual := &Instance{Title: "auth work", AutoTitle: "api", LatestPrompt: "fix the tests"}
+	manual.maybeAutoName()
+	if manual.Title != "auth work" {
+		t.Errorf("renamed a hand-picked title to %q", manual.Title)
+	}
+	userTitle := &Instance{Title: "auth work"}
+	if userTitle.AutoNameFromPrompt("fix the tests") {
+		t.Errorf("renamed a title given at creation to %q", userTitle.Title)
+	}
+}
+
+func TestParseClaudeSummary(t *testing.T) {
+	data := []byte(`{"type":"summary","summary":"Old task","leafUuid":"a"}
+{"type":"user","message":{"role":"user","content":"summary of the docs please"}}
+{"type":"summary","summary":"Fix flaky auth tests","leafUuid":"b"}
+`)
+	if got := parseClaudeSummary(data); got != "Fix flaky auth tests" {
+		t.Errorf("parseClaudeSummary() = %q", got)
+	}
+}
@@ -0,0 +1,338 @@
+package session
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+const (
+	// backupInterval is how old the newest backup gets before the next
+	backupInterval = 24 * time.Hour
+
+	// backupPrefix and backupTimeFormat name each backup, sortable by time
+	backupPrefix     = "agent-deck-"
+	backupTimeFormat = "20060102-150405"
+)
+
+// BackupInfo is one backup in the backup directory
+type BackupInfo struct {
+	Name string    `json:"name"`
+	Path string    `json:"path"`
+	Time time.Time `json:"time"`
+	Size int64     `json:"size"` // bytes, summed over the files of a directory backup
+}
+
+// BackupResult is the outcome of a backup run
+type BackupResult struct {
+	Path   string `json:"path"`
+	Files  int    `json:"files"`
+	Pruned int    `json:"pruned"`
+}
+
+// StartBackupWorker exports the deck once a day ([backup] enabled), checking
+// hourly whether the newest backup is a day old
+func StartBackupWorker(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			settings := GetBackupSettings()
+			if settings.Enabled && BackupDue(settings, time.Now()) {
+				result, err := RunBackup(settings, time.Now())
+				if err != nil {
+					maintLog.Warn("backup_failed", slog.String("error", err.Error()))
+				} else {
+					maintLog.Info("backup_written", slog.String("path", result.Path), slog.Int("files", result.Files), slog.Int("pruned", result.Pruned))
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// BackupDue reports whether the newest backup is older than a day
+func BackupDue(settings BackupSettings, now time.Time) bool {
+	dir, err := settings.GetDir()
+	if err != nil {
+		return false
+	}
+	backups, err := ListBackups(dir)
+	if err != nil || len(backups) == 0 {
+		return true
+	}
+	return now.Sub(backups[0].Time) >= backupInterval
+}
+
+// ListBackups returns the backups in dir, newest first
+func ListBackups(dir string) ([]BackupInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var backups []BackupInfo
+	for _, entry := range entries {
+		name := entry.Name()
+		stamp, ok := strings.CutPrefix(name, backupPrefix)
+		if !ok {
+			continue
+		}
+		if !entry.IsDir() {
+			if stamp, ok = strings.CutSuffix(stamp, ".tar.gz"); !ok {
+				continue
+			}
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		backups = append(backups, BackupInfo{Name: name, Path: path, Time: t, Size: pathSize(path)})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Time.After(backups[j].Time) })
+	return backups, nil
+}
+
+// pathSize sums the sizes of the files at or under path
+func pathSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// RunBackup exports every profile's sessions and config.toml (and the logs
+// with include_logs) to a new backup, then deletes the ones past keep
+func RunBackup(settings BackupSettings, now time.Time) (BackupResult, error) {
+	var result BackupResult
+	deckDir, err := GetAgentDeckDir()
+	if err != nil {
+		return result, err
+	}
+	dir, err := settings.GetDir()
+	if err != nil {
+		return result, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return result, fmt.Errorf("creating backup dir: %w", err)
+	}
+
+	name := backupPrefix + now.Format(backupTimeFormat)
+	// The staging directory also keeps two instances from backing up at once
+	stage := filepath.Join(dir, "."+name)
+	if err := os.Mkdir(stage, 0700); err != nil {
+		return result, fmt.Errorf("creating %s: %w", stage, err)
+	}
+	defer os.RemoveAll(stage)
+
+	if result.Files, err = stageBackup(deckDir, stage, settings.IncludeLogs); err != nil {
+		return result, err
+	}
+
+	if settings.GetTarball() {
+		result.Path = filepath.Join(dir, name+".tar.gz")
+		tmp := result.Path + ".tmp"
+		if err := writeTarball(stage, tmp); err != nil {
+			_ = os.Remove(tmp)
+			return result, err
+		}
+		if err := os.Rename(tmp, result.Path); err != nil {
+			return result, err
+		}
+	} else {
+		result.Path = filepath.Join(dir, name)
+		if err := os.Rename(stage, result.Path); err != nil {
+			return result, err
+		}
+	}
+
+	result.Pruned = pruneBackups(dir, settings.GetKeep())
+	return result, nil
+}
+
+// stageBackup copies what is backed up from deckDir into stage, returning
+// the number of files
+func stageBackup(deckDir, stage string, includeLogs bool) (int, error) {
+	files := 0
+	profiles, _ := filepath.Glob(filepath.Join(deckDir, "profiles", "*"))
+	for _, profileDir := range profiles {
+		entries, err := os.ReadDir(profileDir)
+		if err != nil {
+			continue
+		}
+		dest := filepath.Join(stage, "profiles", filepath.Base(profileDir))
+		if err := os.MkdirAll(dest, 0700); err != nil {
+			return files, err
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.Type().IsRegular() || skipBackupFile(name) {
+				continue
+			}
+			src := filepath.Join(profileDir, name)
+			if name == "state.db" {
+				err = backupStateDB(src, filepath.Join(dest, name))
+			} else {
+				err = copyBackupFile(src, filepath.Join(dest, name))
+			}
+			if err != nil {
+				return files, fmt.Errorf("backing up %s: %w", src, err)
+			}
+			files++
+		}
+	}
+
+	config := filepath.Join(deckDir, UserConfigFileName)
+	if err := copyBackupFile(config, filepath.Join(stage, UserConfigFileName)); err == nil {
+		files++
+	} else if !os.IsNotExist(err) {
+		return files, fmt.Errorf("backing up %s: %w", config, err)
+	}
+
+	if includeLogs {
+		logs, _ := filepath.Glob(filepath.Join(deckDir, "debug.log*"))
+		logs = append(logs, filepath.Join(deckDir, "logs"))
+		for _, src := range logs {
+			_ = filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+				if err != nil || !d.Type().IsRegular() {
+					return nil
+				}
+				rel, _ := filepath.Rel(deckDir, path)
+				if copyBackupFile(path, filepath.Join(stage, rel)) == nil {
+					files++
+				}
+				return nil
+			})
+		}
+	}
+	return files, nil
+}
+
+// skipBackupFile reports whether a file in a profile directory is left out:
+// the SQLite side files (the copy is made through the database), locks, and
+// the per-save .bak rotation
+func skipBackupFile(name string) bool {
+	return strings.HasPrefix(name, "state.db-") || strings.HasSuffix(name, ".lock") || strings.Contains(name, ".bak")
+}
+
+// backupStateDB copies a profile's database, consistent even while the TUI
+// or the CLI is writing it
+func backupStateDB(src, dest string) error {
+	db, err := statedb.Open(src)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.BackupTo(dest)
+}
+
+// copyBackupFile copies src to dest, creating dest's directory
+func copyBackupFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// writeTarball writes the files under dir to a gzipped tarball at path,
+// named relative to dir
+func writeTarball(dir, path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	err = filepath.WalkDir(dir, func(file string, d os.DirEntry, err error) error {
+		if err != nil || file == dir {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, file)
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		in, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		_, err = io.Copy(tw, in)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// pruneBackups deletes the backups in dir past the newest keep
+func pruneBackups(dir string, keep int) int {
+	backups, err := ListBackups(dir)
+	if err != nil {
+		return 0
+	}
+	pruned := 0
+	for i := keep; i < len(backups); i++ {
+		if err := os.RemoveAll(backups[i].Path); err != nil {
+			maintLog.Warn("backup_remove_failed", slog.String("path", backups[i].Path), slog.String("error", err.Error()))
+			continue
+		}
+		pruned++
+	}
+	return pruned
+}
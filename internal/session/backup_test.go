@@ -0,0 +1,108 @@
+package session
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+func TestRunBackup(t *testing.T) {
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	deckDir := filepath.Join(tmpHome, ".agent-deck")
+	profileDir := filepath.Join(deckDir, "profiles", "work")
+
+	db, err := statedb.Open(filepath.Join(profileDir, "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveInstance(&statedb.InstanceRow{ID: "s1", Title: "api", ProjectPath: "/tmp/api", Tool: "claude"}); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for name, content := range map[string]string{
+		filepath.Join(profileDir, "sessions.json.bak.1"): "old",
+		filepath.Join(deckDir, "config.toml"):            "[backup]\n",
+		filepath.Join(deckDir, "debug.log"):              "{}\n",
+	} {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := filepath.Join(t.TempDir(), "backups")
+	settings := BackupSettings{Dir: dir, Keep: 2}
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	if !BackupDue(settings, start) {
+		t.Fatal("a backup is due when there are none")
+	}
+
+	result, err := RunBackup(settings, start)
+	if err != nil {
+		t.Fatalf("RunBackup: %v", err)
+	}
+	if result.Path != filepath.Join(dir, "agent-deck-20260301-090000") || result.Files != 2 {
+		t.Fatalf("result = %+v, want the database and config.toml", result)
+	}
+	copied, err := statedb.Open(filepath.Join(result.Path, "profiles", "work", "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer copied.Close()
+	if rows, err := copied.LoadInstances(); err != nil || len(rows) != 1 || rows[0].Title != "api" {
+		t.Errorf("backed up sessions = %+v (%v)", rows, err)
+	}
+	if BackupDue(settings, start.Add(23*time.Hour)) || !BackupDue(settings, start.Add(24*time.Hour)) {
+		t.Error("the next backup should be due a day later")
+	}
+
+	settings.Format = "tar.gz"
+	settings.IncludeLogs = true
+	for day := 1; day <= 2; day++ {
+		if result, err = RunBackup(settings, start.AddDate(0, 0, day)); err != nil {
+			t.Fatalf("RunBackup day %d: %v", day, err)
+		}
+	}
+	if result.Pruned != 1 {
+		t.Errorf("pruned = %d, want the first backup past keep = 2", result.Pruned)
+	}
+	backups, err := ListBackups(dir)
+	if err != nil || len(backups) != 2 || backups[0].Name != "agent-deck-20260303-090000.tar.gz" {
+		t.Fatalf("backups = %+v (%v)", backups, err)
+	}
+
+	f, err := os.Open(backups[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			names = append(names, hdr.Name)
+		}
+	}
+	sort.Strings(names)
+	want := []string{"config.toml", "debug.log", "profiles/work/state.db"}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] || names[2] != want[2] {
+		t.Errorf("tarball files = %v, want %v", names, want)
+	}
+}
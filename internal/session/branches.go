@@ -0,0 +1,74 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// branchInterval is how often the sessions' checked-out branches are read
+const branchInterval = 10 * time.Second
+
+// GitBranches tracks the branch checked out in each session's project, for
+// the list row: with several agents on one repo, which is on which branch.
+// Branches are read in the background every 10 seconds.
+type GitBranches struct {
+	mu        sync.Mutex
+	branches  map[string]string // project path -> branch
+	lastCheck time.Time
+	checking  bool
+}
+
+// NewGitBranches creates an empty branch tracker
+func NewGitBranches() *GitBranches {
+	return &GitBranches{branches: make(map[string]string)}
+}
+
+// Check starts a background read of the sessions' branches when one is due
+func (b *GitBranches) Check(instances []*Instance, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.checking || now.Sub(b.lastCheck) < branchInterval {
+		return
+	}
+	seen := make(map[string]bool, len(instances))
+	paths := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		if inst.ProjectPath != "" && !seen[inst.ProjectPath] {
+			seen[inst.ProjectPath] = true
+			paths = append(paths, inst.ProjectPath)
+		}
+	}
+	b.lastCheck = now
+	b.checking = true
+	go b.refresh(paths)
+}
+
+// refresh reads the branch of each path, leaving out those not in a repo
+// or on a branch not yet committed to
+func (b *GitBranches) refresh(paths []string) {
+	branches := make(map[string]string, len(paths))
+	for _, path := range paths {
+		branch, err := git.GetCurrentBranch(path)
+		if err != nil || branch == "" {
+			continue
+		}
+		if branch == "HEAD" {
+			branch = "detached"
+		}
+		branches[path] = branch
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.branches = branches
+	b.checking = false
+}
+
+// Branch returns the branch checked out in the session's project, "" if
+// unknown
+func (b *GitBranches) Branch(inst *Instance) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.branches[inst.ProjectPath]
+}
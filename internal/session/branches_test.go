@@ -0,0 +1,36 @@
+package session
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestGitBranchesRefresh(t *testing.T) {
+	repo := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=t", "-c", "user.email=t@t"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-q", "-b", "main")
+	run("commit", "-q", "--allow-empty", "-m", "init")
+	run("checkout", "-q", "-b", "feature/login")
+
+	b := NewGitBranches()
+	plain := t.TempDir()
+	b.refresh([]string{repo, plain})
+	if got := b.Branch(NewInstance("s", repo)); got != "feature/login" {
+		t.Errorf("branch = %q, want feature/login", got)
+	}
+	if got := b.Branch(NewInstance("s", plain)); got != "" {
+		t.Errorf("branch outside a repo = %q", got)
+	}
+
+	run("checkout", "-q", "--detach")
+	b.refresh([]string{repo})
+	if got := b.Branch(NewInstance("s", repo)); got != "detached" {
+		t.Errorf("detached branch = %q", got)
+	}
+}
@@ -0,0 +1,30 @@
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+// RunBulk calls fn for 0..n-1, each in its own goroutine, with at most
+// maxConcurrent running at once (0 = no limit) and at least stagger between
+// consecutive calls starting. It returns when every call has returned.
+func RunBulk(n, maxConcurrent int, stagger time.Duration, fn func(i int)) {
+	if maxConcurrent <= 0 || maxConcurrent > n {
+		maxConcurrent = n
+	}
+	slots := make(chan struct{}, maxConcurrent)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		slots <- struct{}{}
+		if i > 0 && stagger > 0 {
+			time.Sleep(stagger)
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,62 @@
+package session
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunBulkLimitsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	running, peak := 0, 0
+	called := make([]bool, 10)
+	RunBulk(len(called), 3, 0, func(i int) {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		called[i] = true
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+	if peak > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", peak)
+	}
+	for i, ok := range called {
+		if !ok {
+			t.Errorf("fn(%d) not called", i)
+		}
+	}
+}
+
+func TestRunBulkStaggers(t *testing.T) {
+	var mu sync.Mutex
+	var starts []time.Time
+	RunBulk(3, 0, 20*time.Millisecond, func(int) {
+		mu.Lock()
+		starts = append(starts, time.Now())
+		mu.Unlock()
+	})
+	if len(starts) != 3 {
+		t.Fatalf("%d calls, want 3", len(starts))
+	}
+	if gap := starts[2].Sub(starts[0]); gap < 40*time.Millisecond {
+		t.Errorf("first to last start %s apart, want at least 40ms", gap)
+	}
+}
+
+func TestBulkStartSettingsDefaults(t *testing.T) {
+	var s BulkStartSettings
+	if s.GetMaxConcurrent() != 4 || s.GetStagger() != time.Second {
+		t.Errorf("defaults = %d, %s", s.GetMaxConcurrent(), s.GetStagger())
+	}
+	zero := 0
+	s = BulkStartSettings{MaxConcurrent: &zero, StaggerMs: &zero}
+	if s.GetMaxConcurrent() != 0 || s.GetStagger() != 0 {
+		t.Errorf("zero = %d, %s", s.GetMaxConcurrent(), s.GetStagger())
+	}
+}
@@ -0,0 +1,84 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// AutoCheckpointEnabled reports whether auto-checkpoints are on for this session,
+// honoring the per-session override before the global [checkpoint] setting.
+func (i *Instance) AutoCheckpointEnabled() bool {
+	if i.AutoCheckpoint != nil {
+		return *i.AutoCheckpoint
+	}
+	return GetCheckpointSettings().Enabled
+}
+
+// CheckpointRef returns the git ref checkpoints for this session are written to
+// (empty for stash mode, which uses the stash list).
+func (i *Instance) CheckpointRef(mode string) string {
+	return checkpointRef(i.ID, mode)
+}
+
+// checkpointRef maps a session ID and checkpoint mode to the ref to update
+func checkpointRef(id, mode string) string {
+	switch mode {
+	case "branch":
+		return "refs/heads/" + git.CheckpointBranchPrefix + id
+	case "stash":
+		return ""
+	default:
+		return git.CheckpointRefPrefix + id
+	}
+}
+
+// Checkpoint snapshots the session's project working tree using the configured mode.
+// Returns the checkpoint commit hash, or "" if there was nothing new to record.
+func (i *Instance) Checkpoint() (string, error) {
+	return createCheckpoint(i.ID, i.Title, i.ProjectPath)
+}
+
+// createCheckpoint does the git work for Checkpoint on copied fields,
+// so it can run in a goroutine without holding the instance lock.
+func createCheckpoint(id, title, projectPath string) (string, error) {
+	if !git.IsGitRepo(projectPath) {
+		return "", fmt.Errorf("%s is not a git repository", projectPath)
+	}
+	mode := GetCheckpointSettings().GetMode()
+	message := fmt.Sprintf("agent-deck checkpoint: %s (%s)", title, time.Now().Format(time.RFC3339))
+	if mode == "stash" {
+		return git.CreateStashCheckpoint(projectPath, message)
+	}
+	return git.CreateCheckpoint(projectPath, checkpointRef(id, mode), message)
+}
+
+// maybeAutoCheckpoint starts a background checkpoint if enabled for this session.
+// Called from UpdateStatus and ApplyStatusReport with i.mu held, so it only
+// copies the fields it needs; all git work, even the repo check, runs in a
+// goroutine without the lock.
+func (i *Instance) maybeAutoCheckpoint() {
+	if !i.AutoCheckpointEnabled() {
+		return
+	}
+	if !i.checkpointRunning.CompareAndSwap(false, true) {
+		return // Previous checkpoint still in flight
+	}
+	id, title, projectPath := i.ID, i.Title, i.ProjectPath
+	go func() {
+		defer i.checkpointRunning.Store(false)
+		if !git.IsGitRepo(projectPath) {
+			return
+		}
+		commit, err := createCheckpoint(id, title, projectPath)
+		if err != nil {
+			sessionLog.Warn("auto_checkpoint_failed", slog.String("title", title), slog.String("error", err.Error()))
+			return
+		}
+		if commit != "" {
+			sessionLog.Info("auto_checkpoint_created", slog.String("title", title), slog.String("commit", commit))
+		}
+	}()
+}
@@ -0,0 +1,70 @@
+package session
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointRef(t *testing.T) {
+	inst := &Instance{ID: "abc-123"}
+	if got := inst.CheckpointRef("ref"); got != "refs/agent-deck/checkpoints/abc-123" {
+		t.Errorf("ref mode: %q", got)
+	}
+	if got := inst.CheckpointRef("branch"); got != "refs/heads/agent-deck/checkpoint/abc-123" {
+		t.Errorf("branch mode: %q", got)
+	}
+	if got := inst.CheckpointRef("stash"); got != "" {
+		t.Errorf("stash mode: %q", got)
+	}
+}
+
+func TestAutoCheckpointOverride(t *testing.T) {
+	on, off := true, false
+	if !(&Instance{AutoCheckpoint: &on}).AutoCheckpointEnabled() {
+		t.Error("per-session on should enable checkpoints")
+	}
+	if (&Instance{AutoCheckpoint: &off}).AutoCheckpointEnabled() {
+		t.Error("per-session off should disable checkpoints")
+	}
+}
+
+func TestInstanceCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test User"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	inst := &Instance{ID: "ckpt-test", Title: "ckpt", ProjectPath: dir}
+	commit, err := inst.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	if commit == "" {
+		t.Fatal("expected a checkpoint commit for new files")
+	}
+
+	out, err := exec.Command("git", "-C", dir, "rev-parse", inst.CheckpointRef("ref")).Output()
+	if err != nil {
+		t.Fatalf("checkpoint ref missing: %v", err)
+	}
+	if got := string(out[:len(out)-1]); got != commit {
+		t.Errorf("ref points to %s, want %s", got, commit)
+	}
+
+	if _, err := (&Instance{ProjectPath: t.TempDir()}).Checkpoint(); err == nil {
+		t.Error("expected error for non-repo path")
+	}
+}
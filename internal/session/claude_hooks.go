@@ -0,0 +1,198 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/terminal"
+)
+
+// ClaudeHookEvents are the Claude Code hook events agent-deck listens to:
+// Stop (turn finished), Notification (permission prompt / idle input) and
+// PreToolUse (agent is working).
+var ClaudeHookEvents = []string{"Stop", "Notification", "PreToolUse"}
+
+// claudeHookMarker identifies hook commands owned by agent-deck
+const claudeHookMarker = "notify --hook"
+
+// ClaudeSettingsPath returns the Claude Code user settings file that holds hooks
+func ClaudeSettingsPath() string {
+	return filepath.Join(GetClaudeConfigDir(), "settings.json")
+}
+
+// ClaudeHookCommand returns the hook command line for the given agent-deck executable
+func ClaudeHookCommand(executable string) string {
+	return terminal.ShellQuote(executable) + " " + claudeHookMarker
+}
+
+// readClaudeSettings loads settings.json as a generic map so unknown keys survive a rewrite
+func readClaudeSettings(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]any{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	settings := map[string]any{}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return settings, nil
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// writeClaudeSettings writes settings.json atomically, keeping a .bak of the previous file
+func writeClaudeSettings(path string, settings map[string]any) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if prev, err := os.ReadFile(path); err == nil {
+		_ = os.WriteFile(path+".bak", prev, 0600)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
+
+// hookEntries returns settings.hooks[event] as a slice of matcher groups
+func hookEntries(settings map[string]any, event string) []any {
+	hooks, _ := settings["hooks"].(map[string]any)
+	if hooks == nil {
+		return nil
+	}
+	entries, _ := hooks[event].([]any)
+	return entries
+}
+
+// isAgentDeckHookGroup reports whether a matcher group only runs agent-deck hooks
+func isAgentDeckHookGroup(group any) bool {
+	m, ok := group.(map[string]any)
+	if !ok {
+		return false
+	}
+	inner, _ := m["hooks"].([]any)
+	if len(inner) == 0 {
+		return false
+	}
+	for _, h := range inner {
+		hm, ok := h.(map[string]any)
+		if !ok {
+			return false
+		}
+		cmd, _ := hm["command"].(string)
+		if !strings.Contains(cmd, claudeHookMarker) {
+			return false
+		}
+	}
+	return true
+}
+
+// InstallClaudeHooks adds (or replaces) agent-deck hooks for ClaudeHookEvents in the
+// settings file at path. Other hooks and settings are left untouched.
+// Returns false if the file already contained exactly these hooks.
+func InstallClaudeHooks(path, command string) (bool, error) {
+	settings, err := readClaudeSettings(path)
+	if err != nil {
+		return false, err
+	}
+	before, _ := json.Marshal(settings)
+
+	hooks, _ := settings["hooks"].(map[string]any)
+	if hooks == nil {
+		hooks = map[string]any{}
+	}
+	for _, event := range ClaudeHookEvents {
+		var kept []any
+		for _, group := range hookEntries(settings, event) {
+			if !isAgentDeckHookGroup(group) {
+				kept = append(kept, group)
+			}
+		}
+		group := map[string]any{
+			"hooks": []any{map[string]any{"type": "command", "command": command}},
+		}
+		if event == "PreToolUse" {
+			group["matcher"] = "*"
+		}
+		hooks[event] = append(kept, group)
+	}
+	settings["hooks"] = hooks
+
+	after, _ := json.Marshal(settings)
+	if string(before) == string(after) {
+		return false, nil
+	}
+	return true, writeClaudeSettings(path, settings)
+}
+
+// UninstallClaudeHooks removes agent-deck hooks from the settings file at path.
+// Returns false if none were installed.
+func UninstallClaudeHooks(path string) (bool, error) {
+	settings, err := readClaudeSettings(path)
+	if err != nil {
+		return false, err
+	}
+	hooks, _ := settings["hooks"].(map[string]any)
+	if hooks == nil {
+		return false, nil
+	}
+	removed := false
+	for event := range hooks {
+		entries := hookEntries(settings, event)
+		if entries == nil {
+			continue
+		}
+		var kept []any
+		for _, group := range entries {
+			if isAgentDeckHookGroup(group) {
+				removed = true
+				continue
+			}
+			kept = append(kept, group)
+		}
+		if len(kept) == 0 {
+			delete(hooks, event)
+		} else {
+			hooks[event] = kept
+		}
+	}
+	if !removed {
+		return false, nil
+	}
+	if len(hooks) == 0 {
+		delete(settings, "hooks")
+	}
+	return true, writeClaudeSettings(path, settings)
+}
+
+// ClaudeHooksInstalled reports which ClaudeHookEvents have an agent-deck hook in path
+func ClaudeHooksInstalled(path string) (map[string]bool, error) {
+	settings, err := readClaudeSettings(path)
+	if err != nil {
+		return nil, err
+	}
+	installed := make(map[string]bool, len(ClaudeHookEvents))
+	for _, event := range ClaudeHookEvents {
+		for _, group := range hookEntries(settings, event) {
+			if isAgentDeckHookGroup(group) {
+				installed[event] = true
+			}
+		}
+	}
+	return installed, nil
+}
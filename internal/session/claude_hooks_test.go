@@ -0,0 +1,127 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readSettingsForTest(t *testing.T, path string) map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read settings: %v", err)
+	}
+	var settings map[string]any
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("parse settings: %v", err)
+	}
+	return settings
+}
+
+func TestInstallClaudeHooks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	existing := `{
+  "model": "opus",
+  "hooks": {
+    "Stop": [{"hooks": [{"type": "command", "command": "say done"}]}]
+  }
+}`
+	if err := os.WriteFile(path, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	command := ClaudeHookCommand("agent-deck")
+	changed, err := InstallClaudeHooks(path, command)
+	if err != nil {
+		t.Fatalf("InstallClaudeHooks: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected first install to change settings")
+	}
+
+	settings := readSettingsForTest(t, path)
+	if settings["model"] != "opus" {
+		t.Errorf("unrelated setting lost: %v", settings["model"])
+	}
+	if stop := hookEntries(settings, "Stop"); len(stop) != 2 {
+		t.Errorf("Stop groups = %d, want user hook + agent-deck hook", len(stop))
+	}
+	pre := hookEntries(settings, "PreToolUse")
+	if len(pre) != 1 || pre[0].(map[string]any)["matcher"] != "*" {
+		t.Errorf("PreToolUse = %v, want one group with matcher *", pre)
+	}
+	if _, err := os.Stat(path + ".bak"); err != nil {
+		t.Errorf("expected backup of previous settings: %v", err)
+	}
+
+	// Second install is a no-op
+	changed, err = InstallClaudeHooks(path, command)
+	if err != nil {
+		t.Fatalf("InstallClaudeHooks (again): %v", err)
+	}
+	if changed {
+		t.Error("expected repeated install to be a no-op")
+	}
+
+	// A different executable replaces rather than duplicates
+	if _, err := InstallClaudeHooks(path, ClaudeHookCommand("/opt/agent deck/bin")); err != nil {
+		t.Fatal(err)
+	}
+	if stop := hookEntries(readSettingsForTest(t, path), "Stop"); len(stop) != 2 {
+		t.Errorf("Stop groups after reinstall = %d, want 2", len(stop))
+	}
+}
+
+func TestUninstallClaudeHooks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(path, []byte(`{"hooks":{"Stop":[{"hooks":[{"type":"command","command":"say done"}]}]}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InstallClaudeHooks(path, ClaudeHookCommand("agent-deck")); err != nil {
+		t.Fatal(err)
+	}
+
+	installed, err := ClaudeHooksInstalled(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, event := range ClaudeHookEvents {
+		if !installed[event] {
+			t.Errorf("%s not reported as installed", event)
+		}
+	}
+
+	removed, err := UninstallClaudeHooks(path)
+	if err != nil {
+		t.Fatalf("UninstallClaudeHooks: %v", err)
+	}
+	if !removed {
+		t.Fatal("expected hooks to be removed")
+	}
+
+	settings := readSettingsForTest(t, path)
+	if stop := hookEntries(settings, "Stop"); len(stop) != 1 {
+		t.Errorf("Stop groups = %d, want only the user hook", len(stop))
+	}
+	if hookEntries(settings, "PreToolUse") != nil {
+		t.Error("PreToolUse should be removed entirely")
+	}
+
+	removed, err = UninstallClaudeHooks(path)
+	if err != nil || removed {
+		t.Errorf("second uninstall = %v, %v; want false, nil", removed, err)
+	}
+}
+
+func TestClaudeHooksMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "settings.json")
+	installed, err := ClaudeHooksInstalled(path)
+	if err != nil || len(installed) != 0 {
+		t.Errorf("ClaudeHooksInstalled = %v, %v; want empty, nil", installed, err)
+	}
+	if _, err := InstallClaudeHooks(path, ClaudeHookCommand("agent-deck")); err != nil {
+		t.Fatalf("install into missing dir: %v", err)
+	}
+}
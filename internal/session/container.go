@@ -0,0 +1,255 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/terminal"
+)
+
+// Container kinds for ContainerSpec.Kind
+const (
+	ContainerImage        = "image"        // docker run --rm -it <image>, project mounted at the same path
+	ContainerDocker       = "docker"       // docker exec -it <container> (already running)
+	ContainerCompose      = "compose"      // docker compose exec <service> (compose file from the project dir)
+	ContainerDevcontainer = "devcontainer" // devcontainer exec --workspace-folder <project>
+	ContainerKubernetes   = "k8s"          // kubectl exec -it <pod> (for agents that must run in-cluster)
+	ContainerSSH          = "ssh"          // ssh -t <host> ([hosts.<name>] or a ~/.ssh/config alias), or mosh if the host sets it
+)
+
+// ContainerSpec runs a session's command inside a container instead of on the
+// host. The container CLI runs in the session's tmux pane, so status detection,
+// attach and send work unchanged.
+type ContainerSpec struct {
+	Kind    string `json:"kind"`
+	Target  string `json:"target,omitempty"`  // Image, container name, compose service, pod or host
+	Workdir string `json:"workdir,omitempty"` // Working directory inside the container
+
+	// Kubernetes only
+	Namespace    string `json:"namespace,omitempty"`
+	Context      string `json:"context,omitempty"`       // kubeconfig context (default: current)
+	PodContainer string `json:"pod_container,omitempty"` // Container within the pod (default: kubectl's choice)
+
+	// passEnv names the session env vars docker forwards by name (-e NAME),
+	// taking the values from the tmux environment (not serialized)
+	passEnv []string
+}
+
+// ParseContainerSpec parses "image:<image>", "docker:<container>",
+// "compose:<service>", "devcontainer", "k8s:[<namespace>/]<pod>" or "ssh:<host>".
+// "" and "none" return nil (host).
+func ParseContainerSpec(s string) (*ContainerSpec, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "none" {
+		return nil, nil
+	}
+	kind, target, _ := strings.Cut(s, ":")
+	spec := &ContainerSpec{Kind: strings.ToLower(kind), Target: strings.TrimSpace(target)}
+	if spec.Kind == ContainerKubernetes {
+		if ns, pod, ok := strings.Cut(spec.Target, "/"); ok {
+			spec.Namespace, spec.Target = ns, pod
+		}
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Validate checks that the kind is known and has the target it needs
+func (c *ContainerSpec) Validate() error {
+	switch c.Kind {
+	case ContainerImage, ContainerDocker, ContainerCompose, ContainerKubernetes, ContainerSSH:
+		if c.Target == "" {
+			return fmt.Errorf("container %s needs a target (e.g. %s:name)", c.Kind, c.Kind)
+		}
+	case ContainerDevcontainer:
+	default:
+		return fmt.Errorf("invalid container %q (valid: image:<image>, docker:<container>, compose:<service>, devcontainer, k8s:<pod>, ssh:<host>)", c.Kind)
+	}
+	return nil
+}
+
+// String returns the spec in ParseContainerSpec form
+func (c *ContainerSpec) String() string {
+	if c == nil {
+		return "none"
+	}
+	switch {
+	case c.Target == "":
+		return c.Kind
+	case c.Namespace != "":
+		return c.Kind + ":" + c.Namespace + "/" + c.Target
+	}
+	return c.Kind + ":" + c.Target
+}
+
+// clone returns a copy of the spec (nil-safe)
+func (c *ContainerSpec) clone() *ContainerSpec {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	return &cp
+}
+
+// WrapCommand returns the host command that runs command inside the container.
+// When binary (the agent CLI, e.g. "claude") appears in command, only that
+// binary is redirected into the container through a shell function, so host-side
+// setup in the command (session ID capture via tmux) keeps working. Otherwise
+// the whole command runs in the container; empty starts an interactive shell.
+func (c *ContainerSpec) WrapCommand(command, binary, projectPath string) string {
+	if c.Kind == ContainerSSH && MoshArgs(c.Target) == nil {
+		return c.wrapSSH(command, binary)
+	}
+	prefix := c.execPrefix(projectPath)
+	if prefix == "" {
+		return command
+	}
+	switch {
+	case command == "":
+		return prefix + " sh"
+	case binary != "" && containsWord(command, binary):
+		shim := fmt.Sprintf(`%s() { %s %s "$@"; }; %s`, binary, prefix, binary, command)
+		return "bash -c " + terminal.ShellQuote(shim)
+	default:
+		return prefix + " sh -c " + terminal.ShellQuote(command)
+	}
+}
+
+// wrapSSH is WrapCommand for ssh hosts. ssh hands the remote side a single
+// string for its shell, so everything after the host is quoted once more.
+func (c *ContainerSpec) wrapSSH(command, binary string) string {
+	args := SSHArgs(c.Target)
+	for i, arg := range args {
+		args[i] = terminal.ShellQuote(arg)
+	}
+	prefix := "ssh -t " + strings.Join(args, " ")
+	cd := ""
+	if c.Workdir != "" {
+		cd = "cd " + terminal.ShellQuote(c.Workdir) + " && "
+	}
+	switch {
+	case command == "" && cd == "":
+		return prefix
+	case command == "":
+		return prefix + " " + terminal.ShellQuote(cd+`exec "$SHELL" -l`)
+	case binary != "" && containsWord(command, binary):
+		// printf %q re-quotes the agent's arguments for the remote shell
+		shim := fmt.Sprintf(`%s() { %s "%s%s $(printf '%%q ' "$@")"; }; %s`, binary, prefix, cd, binary, command)
+		return "bash -c " + terminal.ShellQuote(shim)
+	default:
+		return prefix + " " + terminal.ShellQuote(cd+command)
+	}
+}
+
+// execPrefix returns the command prefix that runs a program in the container
+func (c *ContainerSpec) execPrefix(projectPath string) string {
+	var parts []string
+	switch c.Kind {
+	case ContainerImage:
+		workdir := c.Workdir
+		if workdir == "" {
+			workdir = projectPath
+		}
+		parts = []string{"docker run --rm -it -v", terminal.ShellQuote(projectPath + ":" + projectPath),
+			"-w", terminal.ShellQuote(workdir)}
+		parts = append(parts, c.envFlags()...)
+		parts = append(parts, terminal.ShellQuote(c.Target))
+	case ContainerDocker, ContainerCompose:
+		parts = []string{"docker exec -it"}
+		if c.Kind == ContainerCompose {
+			parts = []string{"docker compose exec"}
+		}
+		if c.Workdir != "" {
+			parts = append(parts, "-w", terminal.ShellQuote(c.Workdir))
+		}
+		parts = append(parts, c.envFlags()...)
+		parts = append(parts, terminal.ShellQuote(c.Target))
+	case ContainerDevcontainer:
+		parts = []string{"devcontainer exec --workspace-folder", terminal.ShellQuote(projectPath)}
+	case ContainerKubernetes:
+		parts = []string{"kubectl"}
+		if c.Context != "" {
+			parts = append(parts, "--context", terminal.ShellQuote(c.Context))
+		}
+		parts = append(parts, "exec -it")
+		if c.Namespace != "" {
+			parts = append(parts, "-n", terminal.ShellQuote(c.Namespace))
+		}
+		parts = append(parts, terminal.ShellQuote(c.Target))
+		if c.PodContainer != "" {
+			parts = append(parts, "-c", terminal.ShellQuote(c.PodContainer))
+		}
+		parts = append(parts, "--")
+		// kubectl exec has no workdir flag
+		if c.Workdir != "" {
+			parts = append(parts, cdThenExec(c.Workdir))
+		}
+	case ContainerSSH:
+		// Only mosh hosts get here; unlike ssh, mosh passes the command as argv
+		parts = []string{"mosh"}
+		for _, arg := range MoshArgs(c.Target) {
+			parts = append(parts, terminal.ShellQuote(arg))
+		}
+		parts = append(parts, "--")
+		if c.Workdir != "" {
+			parts = append(parts, cdThenExec(c.Workdir))
+		}
+	default:
+		return ""
+	}
+	return strings.Join(parts, " ")
+}
+
+// envFlags returns docker -e flags forwarding the passEnv names
+func (c *ContainerSpec) envFlags() []string {
+	flags := make([]string, 0, 2*len(c.passEnv))
+	for _, name := range c.passEnv {
+		flags = append(flags, "-e", name)
+	}
+	return flags
+}
+
+// cdThenExec returns a prefix that changes to dir, then execs the program
+// and arguments that follow it
+func cdThenExec(dir string) string {
+	return "sh -c " + terminal.ShellQuote("cd "+terminal.ShellQuote(dir)+` && exec "$0" "$@"`)
+}
+
+// containsWord reports whether word appears in s as a whole shell word
+func containsWord(s, word string) bool {
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == ';' || r == '&' || r == '|' || r == '(' || r == ')' || r == '\t'
+	}) {
+		if field == word {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalContainerSpec encodes a spec for the tool_data blob (nil stays empty)
+func marshalContainerSpec(c *ContainerSpec) json.RawMessage {
+	if c == nil {
+		return nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// unmarshalContainerSpec decodes a spec from the tool_data blob
+func unmarshalContainerSpec(data json.RawMessage) *ContainerSpec {
+	if len(data) == 0 {
+		return nil
+	}
+	var c ContainerSpec
+	if err := json.Unmarshal(data, &c); err != nil || c.Kind == "" {
+		return nil
+	}
+	return &c
+}
@@ -0,0 +1,107 @@
+package session
+
+import "testing"
+
+func TestParseContainerSpec(t *testing.T) {
+	spec, err := ParseContainerSpec("image:node:22")
+	if err != nil || spec.Kind != ContainerImage || spec.Target != "node:22" {
+		t.Fatalf("ParseContainerSpec(image:node:22) = %+v, %v", spec, err)
+	}
+	if spec, err := ParseContainerSpec("devcontainer"); err != nil || spec.Kind != ContainerDevcontainer {
+		t.Errorf("devcontainer = %+v, %v", spec, err)
+	}
+	if spec, err := ParseContainerSpec("none"); err != nil || spec != nil {
+		t.Errorf("none = %+v, %v; want nil, nil", spec, err)
+	}
+	for _, bad := range []string{"podman:x", "docker", "compose:", "k8s:"} {
+		if _, err := ParseContainerSpec(bad); err == nil {
+			t.Errorf("ParseContainerSpec(%q) should fail", bad)
+		}
+	}
+	if got := spec.String(); got != "image:node:22" {
+		t.Errorf("String() = %q", got)
+	}
+
+	pod, err := ParseContainerSpec("k8s:staging/api-7d9f")
+	if err != nil || pod.Namespace != "staging" || pod.Target != "api-7d9f" {
+		t.Fatalf("ParseContainerSpec(k8s:staging/api-7d9f) = %+v, %v", pod, err)
+	}
+	if got := pod.String(); got != "k8s:staging/api-7d9f" {
+		t.Errorf("k8s String() = %q", got)
+	}
+}
+
+func TestContainerWrapCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    ContainerSpec
+		command string
+		binary  string
+		want    string
+	}{
+		{
+			name:    "exec raw command",
+			spec:    ContainerSpec{Kind: ContainerDocker, Target: "dev", Workdir: "/work"},
+			command: "npm test",
+			want:    `docker exec -it -w /work dev sh -c 'npm test'`,
+		},
+		{
+			name: "compose shell",
+			spec: ContainerSpec{Kind: ContainerCompose, Target: "app"},
+			want: "docker compose exec app sh",
+		},
+		{
+			name:    "image mounts project",
+			spec:    ContainerSpec{Kind: ContainerImage, Target: "node:22"},
+			command: "ls",
+			want:    `docker run --rm -it -v /src/app:/src/app -w /src/app node:22 sh -c ls`,
+		},
+		{
+			name:    "session env forwarded by name",
+			spec:    ContainerSpec{Kind: ContainerImage, Target: "node:22", passEnv: []string{"API_KEY", "REGION"}},
+			command: "ls",
+			want:    `docker run --rm -it -v /src/app:/src/app -w /src/app -e API_KEY -e REGION node:22 sh -c ls`,
+		},
+		{
+			name:    "k8s pod with context, container and workdir",
+			spec:    ContainerSpec{Kind: ContainerKubernetes, Target: "api", Namespace: "staging", Context: "prod", PodContainer: "app", Workdir: "/w"},
+			command: "make",
+			want:    `kubectl --context prod exec -it -n staging api -c app -- sh -c 'cd /w && exec "$0" "$@"' sh -c make`,
+		},
+		{
+			name:    "agent binary shimmed, setup stays on host",
+			spec:    ContainerSpec{Kind: ContainerDevcontainer},
+			command: `tmux set-environment X 1; claude --session-id "$id"`,
+			binary:  "claude",
+			want: `bash -c 'claude() { devcontainer exec --workspace-folder /src/app claude "$@"; }; ` +
+				`tmux set-environment X 1; claude --session-id "$id"'`,
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.spec.WrapCommand(tt.command, tt.binary, "/src/app"); got != tt.want {
+			t.Errorf("%s:\n got  %s\n want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyWrapperWithContainer(t *testing.T) {
+	inst := NewInstance("c", "/src/app")
+	inst.Tool = "shell"
+	inst.Container = &ContainerSpec{Kind: ContainerDocker, Target: "dev"}
+	inst.Wrapper = "nvim +'terminal {command}'"
+
+	got, err := inst.applyWrapper("make")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Container wraps the command, the editor wrapper stays outside on the host
+	if want := "nvim +'terminal docker exec -it dev sh -c make'"; got != want {
+		t.Errorf("applyWrapper = %q, want %q", got, want)
+	}
+
+	forked := &Instance{Container: inst.Container.clone()}
+	forked.Container.Target = "other"
+	if inst.Container.Target != "dev" {
+		t.Error("clone shares state with the original")
+	}
+}
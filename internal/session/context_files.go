@@ -0,0 +1,74 @@
+package session
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// contextFilesMax caps the context files kept with a session; they are
+// bookmarks, not an index of the project
+const contextFilesMax = 20
+
+// normalizeContextFile cleans path and makes it relative to the session's
+// project when it's inside it, so the list reads the way the agent sees it
+func (i *Instance) normalizeContextFile(path string) string {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return ""
+	}
+	path = filepath.Clean(expandTilde(path))
+	if filepath.IsAbs(path) && i.ProjectPath != "" {
+		if rel, err := filepath.Rel(i.ProjectPath, path); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			return rel
+		}
+	}
+	return path
+}
+
+// ContextFilePath returns the absolute path of a context file entry
+func (i *Instance) ContextFilePath(file string) string {
+	if filepath.IsAbs(file) || i.ProjectPath == "" {
+		return file
+	}
+	return filepath.Join(i.ProjectPath, file)
+}
+
+// AddContextFiles adds paths to the session's context files, skipping ones
+// already listed, and returns the entries added. Paths past the cap are
+// dropped.
+func (i *Instance) AddContextFiles(paths ...string) []string {
+	var added []string
+	for _, p := range paths {
+		p = i.normalizeContextFile(p)
+		if p == "" || slices.Contains(i.ContextFiles, p) || len(i.ContextFiles) >= contextFilesMax {
+			continue
+		}
+		i.ContextFiles = append(i.ContextFiles, p)
+		added = append(added, p)
+	}
+	return added
+}
+
+// RemoveContextFiles removes paths from the session's context files and
+// returns how many were listed
+func (i *Instance) RemoveContextFiles(paths ...string) int {
+	removed := 0
+	for _, p := range paths {
+		p = i.normalizeContextFile(p)
+		if n := slices.Index(i.ContextFiles, p); n >= 0 {
+			i.ContextFiles = slices.Delete(i.ContextFiles, n, n+1)
+			removed++
+		}
+	}
+	if len(i.ContextFiles) == 0 {
+		i.ContextFiles = nil
+	}
+	return removed
+}
+
+// ContextFilesText returns the context files as one line, space separated,
+// for {files} in prompts and for typing into the session
+func (i *Instance) ContextFilesText() string {
+	return strings.Join(i.ContextFiles, " ")
+}
@@ -0,0 +1,31 @@
+package session
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestContextFiles(t *testing.T) {
+	inst := &Instance{ProjectPath: "/work/api"}
+
+	added := inst.AddContextFiles("/work/api/internal/auth/", "docs/auth.md", "  ", "/etc/hosts", "internal/auth")
+	if want := []string{"internal/auth", "docs/auth.md", "/etc/hosts"}; !reflect.DeepEqual(added, want) {
+		t.Fatalf("AddContextFiles() = %v, want %v", added, want)
+	}
+	if got := inst.ContextFilePath("docs/auth.md"); got != "/work/api/docs/auth.md" {
+		t.Errorf("ContextFilePath() = %q", got)
+	}
+	if got := inst.ExpandPrompt("Review {files} for the bug"); got != "Review internal/auth docs/auth.md /etc/hosts for the bug" {
+		t.Errorf("ExpandPrompt() = %q", got)
+	}
+
+	if n := inst.RemoveContextFiles("/work/api/docs/auth.md", "missing.go"); n != 1 {
+		t.Errorf("RemoveContextFiles() = %d, want 1", n)
+	}
+	if n := inst.RemoveContextFiles("internal/auth", "/etc/hosts"); n != 2 || inst.ContextFiles != nil {
+		t.Errorf("removing the rest: %d, left %v", n, inst.ContextFiles)
+	}
+	if got := inst.ExpandPrompt("Review {files}"); got != "Review " {
+		t.Errorf("ExpandPrompt() without files = %q", got)
+	}
+}
@@ -0,0 +1,322 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// aiderHistoryFile is the chat log aider keeps in the project directory
+const aiderHistoryFile = ".aider.chat.history.md"
+
+// CostEntry is one session's estimated API spend with one model on one day
+type CostEntry struct {
+	InstanceID string
+	Title      string
+	Group      string
+	Tool       string
+	Model      string
+	Day        time.Time // local midnight
+	Cost       float64
+}
+
+// CostBucket is a labelled total of a cost breakdown
+type CostBucket struct {
+	Label string
+	Cost  float64
+}
+
+// CostSummary aggregates cost entries for the stats view
+type CostSummary struct {
+	Today    float64
+	Week     float64 // since Monday
+	Month    float64 // last 30 days, including today
+	Total    float64
+	ByDay    []CostBucket // oldest first, one per day including empty ones
+	ByWeek   []CostBucket // oldest first, labelled with the week's Monday
+	ByGroup  []CostBucket // highest first
+	ByModel  []CostBucket // highest first
+	Sessions int          // sessions with any spend
+}
+
+// PricingFor returns the prices for model: a configured override, then a
+// built-in price, each matched exactly or by the longest model name prefix
+// ("claude-sonnet-4" matches "claude-sonnet-4-5-20250929"), then the default
+func PricingFor(model string, overrides map[string]ModelPricing) ModelPricing {
+	if p, ok := matchPricing(model, overrides); ok {
+		return p
+	}
+	if p, ok := matchPricing(model, modelPricing); ok {
+		return p
+	}
+	return modelPricing["default"]
+}
+
+// matchPricing looks model up in prices exactly, then by longest prefix
+func matchPricing(model string, prices map[string]ModelPricing) (ModelPricing, bool) {
+	if model == "" {
+		return ModelPricing{}, false
+	}
+	if p, ok := prices[model]; ok {
+		return p, true
+	}
+	best := ""
+	for name := range prices {
+		if strings.HasPrefix(model, name) && len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return ModelPricing{}, false
+	}
+	return prices[best], true
+}
+
+// cost prices a token count in USD
+func (p ModelPricing) cost(input, output, cacheRead, cacheWrite int) float64 {
+	return (float64(input)*p.Input +
+		float64(output)*p.Output +
+		float64(cacheRead)*p.CacheRead +
+		float64(cacheWrite)*p.CacheWrite) / 1_000_000
+}
+
+// dayModel keys spend by day and model while parsing a transcript
+type dayModel struct {
+	day   time.Time
+	model string
+}
+
+// CollectCosts estimates the API spend of claude and aider sessions from
+// their transcripts: Claude's session JSONL, priced per model, and aider's
+// chat history, using the cost aider reports unless an override prices its
+// model. Sessions sharing a project directory share aider's history, so it's
+// counted once, for the first of them.
+func CollectCosts(instances []*Instance, overrides map[string]ModelPricing) []CostEntry {
+	var entries []CostEntry
+	aiderSeen := make(map[string]bool)
+	for _, inst := range instances {
+		var spend map[dayModel]float64
+		switch inst.Tool {
+		case "claude":
+			if path := inst.GetJSONLPath(); path != "" {
+				spend = claudeSpend(path, overrides)
+			}
+		case "aider":
+			key := PathKey(inst.ProjectPath)
+			if aiderSeen[key] {
+				continue
+			}
+			aiderSeen[key] = true
+			spend = aiderSpend(filepath.Join(inst.ProjectPath, aiderHistoryFile), overrides)
+		}
+		for k, cost := range spend {
+			entries = append(entries, CostEntry{
+				InstanceID: inst.ID,
+				Title:      inst.Title,
+				Group:      inst.GroupPath,
+				Tool:       inst.Tool,
+				Model:      k.model,
+				Day:        k.day,
+				Cost:       cost,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Day.Before(entries[j].Day) })
+	return entries
+}
+
+// claudeSpend prices each assistant turn of a Claude session JSONL
+func claudeSpend(path string, overrides map[string]ModelPricing) map[dayModel]float64 {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	spend := make(map[dayModel]float64)
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 1024*1024)
+	scanner.Buffer(buf, 10*1024*1024)
+	for scanner.Scan() {
+		var entry jsonlEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Type != "assistant" {
+			continue
+		}
+		if entry.Timestamp.IsZero() {
+			continue
+		}
+		u := entry.Message.Usage
+		cost := PricingFor(entry.Message.Model, overrides).cost(
+			u.InputTokens, u.OutputTokens, u.CacheReadInputTokens, u.CacheCreationInputTokens)
+		if cost == 0 {
+			continue
+		}
+		model := entry.Message.Model
+		if model == "" {
+			model = "claude"
+		}
+		spend[dayModel{day: startOfDay(entry.Timestamp), model: model}] += cost
+	}
+	return spend
+}
+
+var (
+	// # aider chat started at 2024-06-01 10:00:00
+	aiderStartedPattern = regexp.MustCompile(`^# aider chat started at (\d{4}-\d{2}-\d{2})`)
+	// > Main model: claude-3-5-sonnet-20241022 with diff edit format
+	aiderModelPattern = regexp.MustCompile(`^> (?:Main model|Model): (\S+)`)
+	// > Tokens: 4.6k sent, 1.1k cache write, 212 received. Cost: $0.02 message, $0.05 session.
+	aiderTokensPattern = regexp.MustCompile(`^> Tokens: ([\d.]+[kM]?) sent.*?, ([\d.]+[kM]?) received\. Cost: \$([\d.]+) message`)
+)
+
+// aiderSpend sums the per-message costs in an aider chat history
+func aiderSpend(path string, overrides map[string]ModelPricing) map[dayModel]float64 {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	spend := make(map[dayModel]float64)
+	var day time.Time
+	model := "aider"
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 1024*1024)
+	scanner.Buffer(buf, 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := aiderStartedPattern.FindStringSubmatch(line); m != nil {
+			if t, err := time.ParseInLocation("2006-01-02", m[1], time.Local); err == nil {
+				day = t
+			}
+			continue
+		}
+		if m := aiderModelPattern.FindStringSubmatch(line); m != nil {
+			model = m[1]
+			continue
+		}
+		m := aiderTokensPattern.FindStringSubmatch(line)
+		if m == nil || day.IsZero() {
+			continue
+		}
+		cost, _ := strconv.ParseFloat(m[3], 64)
+		if p, ok := matchPricing(model, overrides); ok {
+			cost = p.cost(parseTokenCount(m[1]), parseTokenCount(m[2]), 0, 0)
+		}
+		spend[dayModel{day: day, model: model}] += cost
+	}
+	return spend
+}
+
+// parseTokenCount parses aider's abbreviated counts: 212, 4.6k, 1.2M
+func parseTokenCount(s string) int {
+	mult := 1.0
+	switch {
+	case strings.HasSuffix(s, "k"):
+		mult, s = 1_000, strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "M"):
+		mult, s = 1_000_000, strings.TrimSuffix(s, "M")
+	}
+	n, _ := strconv.ParseFloat(s, 64)
+	return int(n * mult)
+}
+
+// startOfDay returns local midnight of t's day
+func startOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// startOfWeek returns local midnight of the Monday of t's week
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+	return day.AddDate(0, 0, -offset)
+}
+
+// SummarizeCosts totals entries for today, this week, the last 30 days and
+// overall, with the last days days, the last weeks weeks, and all-time
+// totals per group and per model
+func SummarizeCosts(entries []CostEntry, now time.Time, days, weeks int) CostSummary {
+	var s CostSummary
+	today := startOfDay(now)
+	week := startOfWeek(now)
+	month := today.AddDate(0, 0, -29)
+	firstDay := today.AddDate(0, 0, -(days - 1))
+	firstWeek := week.AddDate(0, 0, -7*(weeks-1))
+
+	byDay := make([]float64, days)
+	byWeek := make([]float64, weeks)
+	byGroup := make(map[string]float64)
+	byModel := make(map[string]float64)
+	sessions := make(map[string]bool)
+	for _, e := range entries {
+		s.Total += e.Cost
+		if !e.Day.Before(today) {
+			s.Today += e.Cost
+		}
+		if !e.Day.Before(week) {
+			s.Week += e.Cost
+		}
+		if !e.Day.Before(month) {
+			s.Month += e.Cost
+		}
+		if !e.Day.Before(firstDay) {
+			if i := daysBetween(firstDay, e.Day); i < days {
+				byDay[i] += e.Cost
+			}
+		}
+		if !e.Day.Before(firstWeek) {
+			if i := daysBetween(firstWeek, e.Day) / 7; i < weeks {
+				byWeek[i] += e.Cost
+			}
+		}
+		group := e.Group
+		if group == "" {
+			group = DefaultGroupPath
+		}
+		byGroup[group] += e.Cost
+		byModel[e.Model] += e.Cost
+		if e.Cost > 0 {
+			sessions[e.InstanceID] = true
+		}
+	}
+
+	for i, cost := range byDay {
+		s.ByDay = append(s.ByDay, CostBucket{Label: firstDay.AddDate(0, 0, i).Format("Mon Jan 02"), Cost: cost})
+	}
+	for i, cost := range byWeek {
+		s.ByWeek = append(s.ByWeek, CostBucket{Label: "Week of " + firstWeek.AddDate(0, 0, 7*i).Format("Jan 02"), Cost: cost})
+	}
+	s.ByGroup = sortedBuckets(byGroup)
+	s.ByModel = sortedBuckets(byModel)
+	s.Sessions = len(sessions)
+	return s
+}
+
+// daysBetween counts calendar days from a to b (both local midnights),
+// rounding so DST changes don't shift the result
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours()/24 + 0.5)
+}
+
+// sortedBuckets turns totals into buckets, highest first
+func sortedBuckets(totals map[string]float64) []CostBucket {
+	buckets := make([]CostBucket, 0, len(totals))
+	for label, cost := range totals {
+		buckets = append(buckets, CostBucket{Label: label, Cost: cost})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Cost != buckets[j].Cost {
+			return buckets[i].Cost > buckets[j].Cost
+		}
+		return buckets[i].Label < buckets[j].Label
+	})
+	return buckets
+}
@@ -0,0 +1,119 @@
+package session
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPricingFor(t *testing.T) {
+	overrides := map[string]ModelPricing{
+		"claude-opus-4-5": {Input: 5, Output: 25},
+		"gpt-4o":          {Input: 2.5, Output: 10},
+	}
+	tests := []struct {
+		model string
+		want  float64 // input price
+	}{
+		{"claude-opus-4-5-20251101", 5},   // override prefix beats built-in
+		{"claude-opus-4-1-20250805", 15},  // built-in prefix
+		{"claude-3-5-haiku", 0.80},        // built-in exact
+		{"gpt-4o", 2.5},                   // override exact
+		{"some-unknown-model", 3.0},       // default
+		{"", 3.0},                         // default
+		{"claude-sonnet-4-5-20250929", 3}, // built-in prefix
+	}
+	for _, tt := range tests {
+		if got := PricingFor(tt.model, overrides).Input; got != tt.want {
+			t.Errorf("PricingFor(%q).Input = %v, want %v", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestClaudeSpend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	content := `{"type":"assistant","timestamp":"2024-06-01T10:00:00Z","message":{"model":"claude-opus-4-1-20250805","usage":{"input_tokens":1000000,"output_tokens":0}}}
+{"type":"user","timestamp":"2024-06-01T10:01:00Z","message":{}}
+{"type":"assistant","timestamp":"2024-06-01T10:02:00Z","message":{"model":"claude-sonnet-4-5","usage":{"input_tokens":0,"output_tokens":1000000}}}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	spend := claudeSpend(path, nil)
+	day := startOfDay(time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC))
+	if got := spend[dayModel{day: day, model: "claude-opus-4-1-20250805"}]; got != 15 {
+		t.Errorf("opus spend = %v, want 15", got)
+	}
+	if got := spend[dayModel{day: day, model: "claude-sonnet-4-5"}]; got != 15 {
+		t.Errorf("sonnet spend = %v, want 15", got)
+	}
+}
+
+func TestAiderSpend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), aiderHistoryFile)
+	content := `
+# aider chat started at 2024-06-01 09:00:00
+
+> Main model: gpt-4o with diff edit format
+> Tokens: 4.6k sent, 212 received. Cost: $0.02 message, $0.02 session.
+
+# aider chat started at 2024-06-02 09:00:00
+
+> Model: claude-3-5-sonnet-20241022 with diff edit format
+> Tokens: 2.5k sent, 1.1k cache write, 227 received. Cost: $0.01 message, $0.01 session.
+> Tokens: 3k sent, 300 received. Cost: $0.03 message, $0.04 session.
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	june1 := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+	june2 := time.Date(2024, 6, 2, 0, 0, 0, 0, time.Local)
+
+	spend := aiderSpend(path, nil)
+	if got := spend[dayModel{day: june1, model: "gpt-4o"}]; math.Abs(got-0.02) > 1e-9 {
+		t.Errorf("gpt-4o spend = %v, want aider's 0.02", got)
+	}
+	if got := spend[dayModel{day: june2, model: "claude-3-5-sonnet-20241022"}]; math.Abs(got-0.04) > 1e-9 {
+		t.Errorf("sonnet spend = %v, want 0.04", got)
+	}
+
+	// A configured price replaces aider's own: 4600 in, 212 out at $1/$2 per M
+	spend = aiderSpend(path, map[string]ModelPricing{"gpt-4o": {Input: 1, Output: 2}})
+	if got := spend[dayModel{day: june1, model: "gpt-4o"}]; math.Abs(got-0.005024) > 1e-9 {
+		t.Errorf("overridden gpt-4o spend = %v, want 0.005024", got)
+	}
+}
+
+func TestSummarizeCosts(t *testing.T) {
+	now := time.Date(2024, 6, 12, 15, 0, 0, 0, time.Local) // a Wednesday
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 0, 0, 0, 0, time.Local) }
+	entries := []CostEntry{
+		{InstanceID: "a", Group: "work", Model: "m1", Day: day(12), Cost: 1},
+		{InstanceID: "a", Group: "work", Model: "m2", Day: day(10), Cost: 2}, // Monday
+		{InstanceID: "b", Group: "", Model: "m1", Day: day(9), Cost: 4},
+		{InstanceID: "b", Group: "", Model: "m1", Day: day(1), Cost: 8},
+	}
+
+	s := SummarizeCosts(entries, now, 7, 2)
+	if s.Today != 1 || s.Week != 3 || s.Month != 15 || s.Total != 15 {
+		t.Errorf("totals = %v/%v/%v/%v, want 1/3/15/15", s.Today, s.Week, s.Month, s.Total)
+	}
+	if len(s.ByDay) != 7 || s.ByDay[6].Cost != 1 || s.ByDay[4].Cost != 2 || s.ByDay[3].Cost != 4 {
+		t.Errorf("ByDay = %+v", s.ByDay)
+	}
+	if len(s.ByWeek) != 2 || s.ByWeek[0].Cost != 4 || s.ByWeek[1].Cost != 3 {
+		t.Errorf("ByWeek = %+v", s.ByWeek)
+	}
+	if s.ByWeek[1].Label != "Week of Jun 10" {
+		t.Errorf("week label = %q", s.ByWeek[1].Label)
+	}
+	if len(s.ByGroup) != 2 || s.ByGroup[0].Label != DefaultGroupPath || s.ByGroup[0].Cost != 12 {
+		t.Errorf("ByGroup = %+v", s.ByGroup)
+	}
+	if s.ByModel[0].Label != "m1" || s.ByModel[0].Cost != 13 || s.Sessions != 2 {
+		t.Errorf("ByModel = %+v, sessions = %d", s.ByModel, s.Sessions)
+	}
+}
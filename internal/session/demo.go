@@ -0,0 +1,144 @@
+package session
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// demoMode replaces tmux with simulated sessions for this process (see SetDemoMode)
+var demoMode atomic.Bool
+
+// SetDemoMode makes instances simulate their status and terminal output
+// instead of talking to tmux. Used by `agent-deck --demo` for screenshots,
+// teaching and trying UI changes without touching real sessions.
+func SetDemoMode(on bool) {
+	demoMode.Store(on)
+}
+
+// IsDemoMode reports whether SetDemoMode(true) is in effect
+func IsDemoMode() bool {
+	return demoMode.Load()
+}
+
+// demoSession describes one synthetic session in the demo deck
+type demoSession struct {
+	title, group, tool, project, prompt string
+	stopped                             bool // Stays in error, like a session whose tmux is gone
+}
+
+var demoSessions = []demoSession{
+	{"api-refactor", "work/backend", "claude", "~/src/api", "Split the billing handler into smaller services", false},
+	{"flaky-tests", "work/backend", "codex", "~/src/api", "Find why TestCheckout fails on CI only", false},
+	{"migrations", "work/backend", "claude", "~/src/api", "Write a migration for the orders index", true},
+	{"dashboard", "work/frontend", "claude", "~/src/web", "Add dark mode to the dashboard", false},
+	{"a11y-audit", "work/frontend", "gemini", "~/src/web", "Audit the settings page for screen readers", false},
+	{"release-notes", "work", "opencode", "~/src/web", "Draft release notes for v2.4", false},
+	{"dotfiles", "personal", "shell", "~/dotfiles", "", false},
+	{"blog-post", "personal", "claude", "~/writing/blog", "Tighten the intro of the tmux post", false},
+}
+
+// demoGroupNames names the demo groups (paths are lowercase already)
+var demoGroupNames = map[string]string{
+	"work":          "Work",
+	"work/backend":  "Backend",
+	"work/frontend": "Frontend",
+	"personal":      "Personal",
+}
+
+// DemoStorageData returns the synthetic sessions and groups that --demo loads
+func DemoStorageData() *StorageData {
+	now := time.Now()
+	data := &StorageData{UpdatedAt: now}
+	order := map[string]int{}
+	for path, name := range demoGroupNames {
+		data.Groups = append(data.Groups, &GroupData{Name: name, Path: path, Expanded: true})
+	}
+	for i, d := range demoSessions {
+		id := fmt.Sprintf("demo%04d-0000-4000-8000-%012d", i+1, i+1)
+		inst := &InstanceData{
+			ID:           id,
+			Title:        d.title,
+			ProjectPath:  expandHomePath(d.project),
+			GroupPath:    d.group,
+			Order:        order[d.group],
+			Command:      d.tool,
+			Tool:         d.tool,
+			Status:       demoStatus(id, d.stopped, now),
+			CreatedAt:    now.Add(-time.Duration(i+1) * 37 * time.Minute),
+			LatestPrompt: d.prompt,
+			TmuxSession:  "agentdeck_demo_" + d.title,
+		}
+		if d.tool == "shell" {
+			inst.Command = ""
+		}
+		order[d.group]++
+		data.Instances = append(data.Instances, inst)
+	}
+	return data
+}
+
+// demoFor returns the demo definition for an instance, if it is one
+func demoFor(inst *Instance) (demoSession, bool) {
+	for _, d := range demoSessions {
+		if d.title == inst.Title {
+			return d, true
+		}
+	}
+	return demoSession{}, false
+}
+
+// demoStatus returns the simulated status of session id at now. Each session
+// cycles running → waiting → idle on its own period and phase, so the deck
+// shows a realistic mix that keeps changing.
+func demoStatus(id string, stopped bool, now time.Time) Status {
+	if stopped {
+		return StatusError
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	seed := h.Sum32()
+	period := 20 + int64(seed%25) // seconds
+	phase := (now.Unix() + int64(seed>>8)) % period
+	switch {
+	case phase < period/2:
+		return StatusRunning
+	case phase < period*3/4:
+		return StatusWaiting
+	default:
+		return StatusIdle
+	}
+}
+
+// updateDemoStatus is UpdateStatus in demo mode (caller holds i.mu)
+func (i *Instance) updateDemoStatus() {
+	d, _ := demoFor(i)
+	i.Status = demoStatus(i.ID, d.stopped, time.Now())
+}
+
+// demoPane returns simulated terminal output for the instance
+func (i *Instance) demoPane() string {
+	d, _ := demoFor(i)
+	var b strings.Builder
+	if i.Tool == "shell" {
+		fmt.Fprintf(&b, "%s $ git status --short\n M zshrc\n M tmux.conf\n%s $ ", d.project, d.project)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "> %s\n\n", i.LatestPrompt)
+	switch i.Status {
+	case StatusRunning:
+		b.WriteString("Reading files in " + d.project + "...\n")
+		b.WriteString("  ✓ Read 12 files\n  ✓ Ran go test ./...\n")
+		b.WriteString("✻ Working… (esc to interrupt)\n")
+	case StatusWaiting:
+		b.WriteString("I've made the changes and the tests pass.\n\n")
+		b.WriteString("Do you want to apply these edits?\n  ❯ 1. Yes\n    2. No, keep going\n")
+	case StatusError:
+		b.WriteString("(session stopped; in a real deck, restart it with R)\n")
+	default:
+		b.WriteString("Done. Anything else?\n\n> ")
+	}
+	return b.String()
+}
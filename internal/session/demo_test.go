@@ -0,0 +1,60 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDemoStorageData(t *testing.T) {
+	data := DemoStorageData()
+	groups := make(map[string]bool)
+	for _, g := range data.Groups {
+		groups[g.Path] = true
+	}
+	ids := make(map[string]bool)
+	for _, inst := range data.Instances {
+		if !groups[inst.GroupPath] {
+			t.Errorf("session %q is in undeclared group %q", inst.Title, inst.GroupPath)
+		}
+		if ids[inst.ID] {
+			t.Errorf("duplicate demo ID %q", inst.ID)
+		}
+		ids[inst.ID] = true
+	}
+}
+
+func TestDemoStatusCycles(t *testing.T) {
+	if got := demoStatus("any", true, time.Now()); got != StatusError {
+		t.Errorf("stopped demo session status = %q, want error", got)
+	}
+	seen := make(map[Status]bool)
+	start := time.Unix(1_700_000_000, 0)
+	for s := 0; s < 60; s++ {
+		seen[demoStatus("demo0001", false, start.Add(time.Duration(s)*time.Second))] = true
+	}
+	for _, want := range []Status{StatusRunning, StatusWaiting, StatusIdle} {
+		if !seen[want] {
+			t.Errorf("demo status never reached %q in a minute: %v", want, seen)
+		}
+	}
+}
+
+func TestDemoModeInstance(t *testing.T) {
+	SetDemoMode(true)
+	t.Cleanup(func() { SetDemoMode(false) })
+
+	// No tmux session: outside demo mode this would be an error status
+	inst := &Instance{ID: "demo-x", Title: "migrations", Tool: "claude", LatestPrompt: "Write a migration"}
+	if err := inst.UpdateStatus(); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if inst.Status != StatusError {
+		t.Errorf("stopped demo session status = %q, want error", inst.Status)
+	}
+	if !inst.Exists() {
+		t.Error("demo sessions should report that they exist")
+	}
+	if preview, err := inst.Preview(); err != nil || preview == "" {
+		t.Errorf("Preview = %q, %v", preview, err)
+	}
+}
@@ -95,7 +95,42 @@ func GroupByProject(instances []*Instance) map[string][]*Instance {
 	return groups
 }
 
-// FilterByQuery filters sessions by title, project path, tool, or status
+// MatchField names the instance field a search query matched
+type MatchField string
+
+const (
+	MatchNone  MatchField = ""
+	MatchTitle MatchField = "title"
+	MatchPath  MatchField = "path"
+	MatchTmux  MatchField = "tmux"
+	MatchID    MatchField = "id"
+	MatchTool  MatchField = "tool"
+)
+
+// minIDPrefixLen keeps short queries from matching half the deck by ID
+const minIDPrefixLen = 4
+
+// MatchQuery returns the first field of inst that matches query: title,
+// project path, tmux session name, ID prefix, then tool. query must already
+// be lowercased and trimmed.
+func MatchQuery(inst *Instance, query string) MatchField {
+	switch {
+	case strings.Contains(strings.ToLower(inst.Title), query):
+		return MatchTitle
+	case strings.Contains(strings.ToLower(inst.ProjectPath), query):
+		return MatchPath
+	case inst.TmuxName() != "" && strings.Contains(strings.ToLower(inst.TmuxName()), query):
+		return MatchTmux
+	case len(query) >= minIDPrefixLen && strings.HasPrefix(strings.ToLower(inst.ID), query):
+		return MatchID
+	case strings.Contains(strings.ToLower(inst.Tool), query):
+		return MatchTool
+	}
+	return MatchNone
+}
+
+// FilterByQuery filters sessions by title, project path, tmux session name,
+// ID prefix, tool, or status
 // Supports status filters: "waiting", "running", "idle", "error"
 func FilterByQuery(instances []*Instance, query string) []*Instance {
 	if query == "" {
@@ -117,13 +152,11 @@ func FilterByQuery(instances []*Instance, query string) []*Instance {
 		return filterByStatus(instances, status)
 	}
 
-	// Regular fuzzy search on title, path, tool
+	// Regular substring search on title, path, tmux name, ID, tool
 	filtered := make([]*Instance, 0)
 
 	for _, inst := range instances {
-		if strings.Contains(strings.ToLower(inst.Title), query) ||
-			strings.Contains(strings.ToLower(inst.ProjectPath), query) ||
-			strings.Contains(strings.ToLower(inst.Tool), query) {
+		if MatchQuery(inst, query) != MatchNone {
 			filtered = append(filtered, inst)
 		}
 	}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"os/exec"
+	"strings"
 	"testing"
 )
 
@@ -82,6 +83,35 @@ func TestFilterByQueryCaseInsensitive(t *testing.T) {
 	}
 }
 
+func TestMatchQuery(t *testing.T) {
+	inst := NewInstance("api-refactor", "/home/user/src/billing-service")
+	inst.Tool = "claude"
+	inst.ID = "3f9a2c10-aaaa-4bbb-8ccc-000000000001"
+
+	tests := []struct {
+		query string
+		want  MatchField
+	}{
+		{"refactor", MatchTitle},
+		{"billing", MatchPath},
+		{strings.ToLower(inst.TmuxName()), MatchTmux},
+		{"3f9a", MatchID},
+		{"3f9", MatchNone}, // Too short for an ID prefix
+		{"2c10", MatchNone},
+		{"claude", MatchTool},
+		{"nothing", MatchNone},
+	}
+	for _, tt := range tests {
+		if got := MatchQuery(inst, tt.query); got != tt.want {
+			t.Errorf("MatchQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+
+	if got := FilterByQuery([]*Instance{inst}, "3F9A2C"); len(got) != 1 {
+		t.Errorf("FilterByQuery by ID prefix returned %d results, want 1", len(got))
+	}
+}
+
 func TestDetectToolFromName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 )
@@ -14,7 +15,10 @@ import (
 //  1. Global [shell].env_files (in order)
 //  2. [shell].init_script (for direnv, nvm, etc.)
 //  3. Tool-specific env_file ([claude].env_file, [gemini].env_file, [tools.X].env_file)
-//  4. Inline env vars from [tools.X].env (highest priority)
+//  4. Inline env vars from [tools.X].env
+//
+// The session's own env (see sessionEnv) is not exported here: it is set in
+// the tmux session's environment, so its values never reach the pane.
 func (i *Instance) buildEnvSourceCommand() string {
 	var sources []string
 	config, _ := LoadUserConfig()
@@ -49,7 +53,7 @@ func (i *Instance) buildEnvSourceCommand() string {
 		sources = append(sources, buildSourceCmd(resolved, ignoreMissing))
 	}
 
-	// 4. Inline env vars from [tools.X].env (highest priority)
+	// 4. Inline env vars from [tools.X].env
 	if inlineEnv := i.getToolInlineEnv(); inlineEnv != "" {
 		sources = append(sources, inlineEnv)
 	}
@@ -62,6 +66,12 @@ func (i *Instance) buildEnvSourceCommand() string {
 	return strings.Join(sources, " && ") + " && "
 }
 
+// buildRawCommand returns the command of a session that isn't a known or
+// configured tool ("" for a plain shell)
+func (i *Instance) buildRawCommand() string {
+	return i.Command
+}
+
 // buildSourceCmd creates a shell command to source a file.
 // If ignoreMissing is true, wraps in a file existence check.
 func buildSourceCmd(path string, ignoreMissing bool) string {
@@ -113,25 +123,77 @@ func isFilePath(s string) bool {
 }
 
 // getToolInlineEnv returns shell export commands for inline env vars from [tools.X].env.
-// Returns empty string if the tool has no inline env vars defined.
-// Keys are sorted for deterministic output. Single quotes in values are escaped.
+// Returns empty string if the tool has no inline env vars defined. Vars the
+// session sets itself are left out, so its values (from tmux) win.
 func (i *Instance) getToolInlineEnv() string {
 	def := GetToolDef(i.Tool)
-	if def == nil || len(def.Env) == 0 {
+	if def == nil {
 		return ""
 	}
+	own := i.sessionEnv()
+	if len(own) == 0 {
+		return exportEnvVars(def.Env)
+	}
+	env := make(map[string]string, len(def.Env))
+	for k, v := range def.Env {
+		if _, ok := own[k]; !ok {
+			env[k] = v
+		}
+	}
+	return exportEnvVars(env)
+}
 
-	// Sort keys for deterministic ordering
-	keys := make([]string, 0, len(def.Env))
-	for k := range def.Env {
-		keys = append(keys, k)
+// sessionEnv returns the session template's env overlaid with the
+// session's own Env. Returns nil if neither sets any. It goes into the tmux
+// session's environment (tmux.Session.Env) rather than onto the command line,
+// since it may hold secrets such as API keys.
+func (i *Instance) sessionEnv() map[string]string {
+	var tplEnv map[string]string
+	if i.Template != "" {
+		if tpl := GetSessionTemplate(i.Template); tpl != nil {
+			tplEnv = tpl.Env
+		}
+	}
+	if len(tplEnv) == 0 {
+		return i.Env
+	}
+	env := make(map[string]string, len(tplEnv)+len(i.Env))
+	for k, v := range tplEnv {
+		env[k] = v
+	}
+	for k, v := range i.Env {
+		env[k] = v
+	}
+	return env
+}
+
+// envNameRe matches a valid environment variable name
+var envNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseEnvAssignment splits a KEY=VALUE argument, checking the name
+func ParseEnvAssignment(s string) (key, value string, err error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return "", "", fmt.Errorf("env must be KEY=VALUE, got %q", s)
+	}
+	if !envNameRe.MatchString(key) {
+		return "", "", fmt.Errorf("invalid env var name %q", key)
+	}
+	return key, value, nil
+}
+
+// exportEnvVars returns export commands for env joined with &&.
+// Keys are sorted for deterministic output. Single quotes in values are escaped.
+func exportEnvVars(env map[string]string) string {
+	if len(env) == 0 {
+		return ""
 	}
-	sort.Strings(keys)
 
 	// Build export statements with single-quote escaping
+	keys := envKeys(env)
 	exports := make([]string, 0, len(keys))
 	for _, k := range keys {
-		v := def.Env[k]
+		v := env[k]
 		// Escape single quotes: replace ' with '\'' (end quote, escaped quote, start quote)
 		escaped := strings.ReplaceAll(v, "'", "'\\''")
 		exports = append(exports, fmt.Sprintf("export %s='%s'", k, escaped))
@@ -140,6 +202,16 @@ func (i *Instance) getToolInlineEnv() string {
 	return strings.Join(exports, " && ")
 }
 
+// envKeys returns the names in env, sorted
+func envKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // getToolEnvFile returns the env_file setting for the current tool.
 func (i *Instance) getToolEnvFile() string {
 	config, _ := LoadUserConfig()
@@ -239,3 +239,107 @@ func TestShellSettings_GetIgnoreMissingEnvFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionTemplateEnvAndPanes(t *testing.T) {
+	userConfigCacheMu.Lock()
+	origCache := userConfigCache
+	userConfigCache = &UserConfig{
+		Templates: map[string]SessionTemplate{
+			"review": {
+				Tool:   "claude",
+				Env:    map[string]string{"REVIEW_BASE": "main"},
+				Panes:  []string{"go test ./...", ""},
+				Layout: "main-vertical",
+			},
+			"bare": {Command: "htop"},
+		},
+		MCPs: make(map[string]MCPDef),
+	}
+	userConfigCacheMu.Unlock()
+	defer func() {
+		userConfigCacheMu.Lock()
+		userConfigCache = origCache
+		userConfigCacheMu.Unlock()
+	}()
+
+	if names := GetSessionTemplateNames(); strings.Join(names, ",") != "bare,review" {
+		t.Errorf("GetSessionTemplateNames() = %v", names)
+	}
+	if got := GetSessionTemplate("review").CommandLine(); got != "claude" {
+		t.Errorf("CommandLine() = %q, want the tool", got)
+	}
+
+	inst := NewInstance("review", "/tmp/project")
+	inst.Template = "review"
+	if got := exportEnvVars(inst.sessionEnv()); got != "export REVIEW_BASE='main'" {
+		t.Errorf("sessionEnv() = %q", got)
+	}
+	if got := inst.buildEnvSourceCommand(); strings.Contains(got, "REVIEW_BASE") {
+		t.Errorf("buildEnvSourceCommand() = %q, want the template env left to tmux", got)
+	}
+	inst.applyTemplatePanes()
+	tmuxSess := inst.GetTmuxSession()
+	if len(tmuxSess.ExtraPanes) != 2 || tmuxSess.ExtraPanes[0] != "go test ./..." || tmuxSess.ExtraPanes[1] != "" {
+		t.Errorf("ExtraPanes = %q", tmuxSess.ExtraPanes)
+	}
+	if tmuxSess.Layout != "main-vertical" {
+		t.Errorf("Layout = %q", tmuxSess.Layout)
+	}
+
+	inst.Template = "removed"
+	if got := inst.sessionEnv(); got != nil {
+		t.Errorf("a template no longer in config should add no env, got %v", got)
+	}
+}
+
+func TestSessionEnv(t *testing.T) {
+	userConfigCacheMu.Lock()
+	origCache := userConfigCache
+	userConfigCache = &UserConfig{
+		Templates: map[string]SessionTemplate{
+			"api": {Env: map[string]string{"MODEL": "small", "REGION": "eu"}},
+		},
+		MCPs: make(map[string]MCPDef),
+	}
+	userConfigCacheMu.Unlock()
+	defer func() {
+		userConfigCacheMu.Lock()
+		userConfigCache = origCache
+		userConfigCacheMu.Unlock()
+	}()
+
+	inst := NewInstance("api", "/tmp/project")
+	if got := inst.buildEnvSourceCommand(); got != "" {
+		t.Errorf("no env should add nothing, got %q", got)
+	}
+	// Values stay off the command line typed into the pane
+	inst.Env = map[string]string{"OPENAI_API_KEY": "sk-1"}
+	if got := inst.buildEnvSourceCommand(); got != "" {
+		t.Errorf("buildEnvSourceCommand() = %q, want the env left to tmux", got)
+	}
+	if got := inst.buildRawCommand(); got != "" {
+		t.Errorf("plain shell = %q, want no command", got)
+	}
+	inst.Command = "htop"
+	if got := inst.buildRawCommand(); got != "htop" {
+		t.Errorf("raw command = %q", got)
+	}
+
+	inst.Template = "api"
+	inst.Env["MODEL"] = "large"
+	want := "export MODEL='large' && export OPENAI_API_KEY='sk-1' && export REGION='eu'"
+	if got := exportEnvVars(inst.sessionEnv()); got != want {
+		t.Errorf("session env = %q, want the session's values over the template's", got)
+	}
+
+	for arg, wantKey := range map[string]string{"A=b=c": "A", "_X=": "_X"} {
+		if key, _, err := ParseEnvAssignment(arg); err != nil || key != wantKey {
+			t.Errorf("ParseEnvAssignment(%q) = %q, %v", arg, key, err)
+		}
+	}
+	for _, bad := range []string{"NOVALUE", "1X=a", "A-B=c", "=v"} {
+		if _, _, err := ParseEnvAssignment(bad); err == nil {
+			t.Errorf("ParseEnvAssignment(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CaptureScrollback returns the session's pane content as plain text.
+// With fullHistory, the entire tmux scrollback is included; otherwise only the visible screen.
+func (i *Instance) CaptureScrollback(fullHistory bool) (string, error) {
+	if i.tmuxSession == nil || !i.tmuxSession.Exists() {
+		return "", fmt.Errorf("session '%s' is not running", i.Title)
+	}
+	content, err := i.tmuxSession.CaptureScrollback(fullHistory)
+	if err != nil {
+		return "", err
+	}
+	// tmux pads the visible screen with blank lines; trim them but keep one final newline
+	return strings.TrimRight(content, "\n \t") + "\n", nil
+}
+
+// GetExportsDir returns the directory where TUI scrollback exports are written
+func GetExportsDir() (string, error) {
+	dir, err := GetAgentDeckDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "exports"), nil
+}
+
+// DefaultExportPath returns ~/.agent-deck/exports/<title>-<timestamp>.txt for the session
+func DefaultExportPath(inst *Instance, now time.Time) (string, error) {
+	dir, err := GetExportsDir()
+	if err != nil {
+		return "", err
+	}
+	name := strings.ReplaceAll(sanitizeGroupName(inst.Title), " ", "-")
+	if name == "" {
+		name = inst.ID
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s-%s.txt", name, now.Format("20060102-150405"))), nil
+}
+
+// WriteExport writes content to path, creating parent directories as needed
+func WriteExport(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
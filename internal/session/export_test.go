@@ -0,0 +1,34 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultExportPath(t *testing.T) {
+	inst := &Instance{ID: "abc", Title: "my/app: fix"}
+	path, err := DefaultExportPath(inst, time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(filepath.Dir(path)) != "exports" {
+		t.Errorf("export should live in exports dir, got %s", path)
+	}
+	if base := filepath.Base(path); base != "my-app-fix-20250304-050607.txt" {
+		t.Errorf("export file name = %s", base)
+	}
+}
+
+func TestWriteExportCreatesDirs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "out.txt")
+	if err := WriteExport(path, "hello\n"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "hello") {
+		t.Errorf("export content = %q (%v)", data, err)
+	}
+}
@@ -0,0 +1,256 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/asheshgoplani/agent-deck/internal/platform"
+)
+
+// fileActivityWindow is how recently a project must have been written to
+// for a session whose terminal went quiet to still count as running
+const fileActivityWindow = 5 * time.Second
+
+// maxActivityDirs bounds the directories watched per project: inotify
+// watches one directory at a time, and the default per-user limit is 8192
+const maxActivityDirs = 1000
+
+// FileActivity watches the project directories of running sessions with
+// inotify, as a second signal next to pane output: an agent whose terminal
+// is quiet while it edits files is still working. Only directories are
+// watched (not .git, node_modules, vendor or other hidden directories), and
+// only while a session in them is running.
+type FileActivity struct {
+	watcher *fsnotify.Watcher
+
+	mu    sync.Mutex
+	roots map[string]*activityRoot // project path -> its watch
+	dirs  map[string]int           // watched directory -> projects using it
+}
+
+// activityRoot is one watched project
+type activityRoot struct {
+	refs      int
+	dirs      []string
+	lastWrite time.Time
+}
+
+var (
+	fileActivityMu sync.Mutex
+	fileActivity   *FileActivity
+)
+
+// StartFileActivity starts the shared watcher ([status] file_activity). The
+// TUI calls it when not read-only; it needs inotify, so Linux only.
+func StartFileActivity() error {
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("file activity detection needs inotify (Linux)")
+	}
+	fileActivityMu.Lock()
+	defer fileActivityMu.Unlock()
+	if fileActivity != nil {
+		return nil
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	fileActivity = &FileActivity{watcher: watcher, roots: make(map[string]*activityRoot), dirs: make(map[string]int)}
+	go fileActivity.loop()
+	return nil
+}
+
+// StopFileActivity closes the shared watcher
+func StopFileActivity() {
+	fileActivityMu.Lock()
+	defer fileActivityMu.Unlock()
+	if fileActivity != nil {
+		_ = fileActivity.watcher.Close()
+		fileActivity = nil
+	}
+}
+
+// getFileActivity returns the shared watcher, nil when it isn't running
+func getFileActivity() *FileActivity {
+	fileActivityMu.Lock()
+	defer fileActivityMu.Unlock()
+	return fileActivity
+}
+
+// skipActivityDir reports whether writes under a directory say nothing
+// about the agent's work
+func skipActivityDir(name string) bool {
+	return name == "node_modules" || name == "vendor" || (strings.HasPrefix(name, ".") && len(name) > 1)
+}
+
+// acquire counts a running session in root, and starts watching it in the
+// background for the first
+func (fa *FileActivity) acquire(root string) {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	if r, ok := fa.roots[root]; ok {
+		r.refs++
+		return
+	}
+	r := &activityRoot{refs: 1}
+	fa.roots[root] = r
+	go fa.watchRoot(root, r)
+}
+
+// watchRoot adds the watches for root's directories
+func (fa *FileActivity) watchRoot(root string, r *activityRoot) {
+	if warning := platform.CheckFsnotifySupport(root); warning != "" {
+		sessionLog.Debug("file_activity_unsupported", slog.String("path", root), slog.String("reason", warning))
+		return
+	}
+	var dirs []string
+	_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if path != root && skipActivityDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		if len(dirs) >= maxActivityDirs {
+			return filepath.SkipAll
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	if fa.roots[root] != r { // released while walking
+		return
+	}
+	for _, dir := range dirs {
+		fa.addDir(r, dir)
+	}
+}
+
+// addDir watches dir for root. Callers hold fa.mu.
+func (fa *FileActivity) addDir(r *activityRoot, dir string) {
+	if fa.dirs[dir] == 0 {
+		if err := fa.watcher.Add(dir); err != nil {
+			sessionLog.Debug("file_activity_watch_failed", slog.String("path", dir), slog.String("error", err.Error()))
+			return
+		}
+	}
+	fa.dirs[dir]++
+	r.dirs = append(r.dirs, dir)
+}
+
+// release stops watching root once no running session is in it
+func (fa *FileActivity) release(root string) {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	r, ok := fa.roots[root]
+	if !ok {
+		return
+	}
+	if r.refs--; r.refs > 0 {
+		return
+	}
+	delete(fa.roots, root)
+	for _, dir := range r.dirs {
+		if fa.dirs[dir]--; fa.dirs[dir] <= 0 {
+			delete(fa.dirs, dir)
+			_ = fa.watcher.Remove(dir)
+		}
+	}
+}
+
+// LastWrite returns when a file under root last changed, zero if unknown
+func (fa *FileActivity) LastWrite(root string) time.Time {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	if r, ok := fa.roots[root]; ok {
+		return r.lastWrite
+	}
+	return time.Time{}
+}
+
+// loop records writes against every watched project containing them, and
+// follows new directories
+func (fa *FileActivity) loop() {
+	for {
+		select {
+		case event, ok := <-fa.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			fa.record(event, time.Now())
+		case err, ok := <-fa.watcher.Errors:
+			if !ok {
+				return
+			}
+			sessionLog.Debug("file_activity_error", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// record marks the projects containing the event's file as written at now
+func (fa *FileActivity) record(event fsnotify.Event, now time.Time) {
+	var isDir bool
+	if event.Op&fsnotify.Create != 0 {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			isDir = !skipActivityDir(filepath.Base(event.Name))
+		}
+	}
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	for dir := filepath.Dir(event.Name); ; dir = filepath.Dir(dir) {
+		if r, ok := fa.roots[dir]; ok {
+			r.lastWrite = now
+			if isDir && len(r.dirs) < maxActivityDirs {
+				fa.addDir(r, event.Name)
+			}
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+}
+
+// trackFileActivity watches the project while the session runs. Called with
+// i.mu held, after each status update.
+func (i *Instance) trackFileActivity() {
+	fa := getFileActivity()
+	if fa == nil {
+		return
+	}
+	running := i.Status == StatusRunning
+	switch {
+	case running && i.activityRoot == "":
+		if info, err := os.Stat(i.ProjectPath); err != nil || !info.IsDir() {
+			return
+		}
+		i.activityRoot = i.ProjectPath
+		fa.acquire(i.activityRoot)
+	case !running && i.activityRoot != "":
+		fa.release(i.activityRoot)
+		i.activityRoot = ""
+	}
+}
+
+// filesRecentlyWritten reports whether the session's project changed within
+// fileActivityWindow
+func (i *Instance) filesRecentlyWritten(now time.Time) bool {
+	fa := getFileActivity()
+	if fa == nil || i.activityRoot == "" {
+		return false
+	}
+	last := fa.LastWrite(i.activityRoot)
+	return !last.IsZero() && now.Sub(last) < fileActivityWindow
+}
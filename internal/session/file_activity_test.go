@@ -0,0 +1,73 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFileActivity(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("file activity detection needs inotify")
+	}
+	if err := StartFileActivity(); err != nil {
+		t.Fatalf("StartFileActivity: %v", err)
+	}
+	defer StopFileActivity()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	inst := NewInstance("quiet", root)
+	inst.Status = StatusRunning
+	inst.trackFileActivity()
+	fa := getFileActivity()
+
+	// Wait for the background walk to add the watches
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(3 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+	waitFor("watches", func() bool {
+		fa.mu.Lock()
+		defer fa.mu.Unlock()
+		return fa.dirs[filepath.Join(root, "src")] == 1
+	})
+	fa.mu.Lock()
+	gitWatched := fa.dirs[filepath.Join(root, ".git")] != 0
+	fa.mu.Unlock()
+	if gitWatched {
+		t.Error(".git should not be watched")
+	}
+
+	if inst.filesRecentlyWritten(time.Now()) {
+		t.Fatal("no writes yet")
+	}
+	if err := os.WriteFile(filepath.Join(root, "src", "main.go"), []byte("package main"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("the write", func() bool { return inst.filesRecentlyWritten(time.Now()) })
+	if inst.filesRecentlyWritten(time.Now().Add(fileActivityWindow)) {
+		t.Error("a write older than the window should not count")
+	}
+
+	inst.Status = StatusWaiting
+	inst.trackFileActivity()
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	if len(fa.roots) != 0 || len(fa.dirs) != 0 {
+		t.Errorf("watches should be released when the session stops running: roots %v dirs %v", fa.roots, fa.dirs)
+	}
+}
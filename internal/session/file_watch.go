@@ -0,0 +1,294 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileWatchInterval is how often watched files are checked. Polling (like
+// the storage watcher) works on filesystems where fsnotify doesn't: 9p, NFS,
+// WSL mounts.
+const fileWatchInterval = 2 * time.Second
+
+// maxWatchedFiles bounds how many files one session's patterns may match,
+// so a broad pattern like "*/*/*" over a large tree can't stall the status
+// loop. Patterns are filepath.Glob's: "*" never crosses a directory and
+// "**" is just "*".
+const maxWatchedFiles = 1000
+
+// FileWatch re-triggers a session when files in its project change: a hook
+// command runs, a prompt is sent, or both
+type FileWatch struct {
+	// Patterns are filepath.Glob patterns relative to the project path
+	Patterns []string `json:"patterns,omitempty"`
+
+	// Hook is a shell command run in the project dir after a change, with
+	// the changed files in AGENTDECK_CHANGED_FILES (one per line)
+	Hook string `json:"hook,omitempty"`
+
+	// Prompt is sent to the agent after a change, once it is waiting for
+	// input. {changed} expands to the changed files.
+	Prompt string `json:"prompt,omitempty"`
+}
+
+// IsZero reports whether the watch has nothing set
+func (w *FileWatch) IsZero() bool {
+	return w == nil || (len(w.Patterns) == 0 && w.Hook == "" && w.Prompt == "")
+}
+
+// Active reports whether the watch has patterns and something to trigger
+func (w *FileWatch) Active() bool {
+	return w != nil && len(w.Patterns) > 0 && (w.Hook != "" || w.Prompt != "")
+}
+
+// ParseWatchPatterns splits a comma-separated pattern list, checking each
+// pattern's syntax
+func ParseWatchPatterns(value string) ([]string, error) {
+	var patterns []string
+	for _, p := range strings.Split(value, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if filepath.IsAbs(p) {
+			return nil, fmt.Errorf("watch pattern %q must be relative to the project path", p)
+		}
+		if _, err := filepath.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid watch pattern %q: %w", p, err)
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// marshalFileWatch encodes a watch for the tool_data blob
+func marshalFileWatch(w *FileWatch) json.RawMessage {
+	if w.IsZero() {
+		return nil
+	}
+	data, err := json.Marshal(w)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// unmarshalFileWatch decodes a watch from the tool_data blob
+func unmarshalFileWatch(data json.RawMessage) *FileWatch {
+	if len(data) == 0 {
+		return nil
+	}
+	var w FileWatch
+	if err := json.Unmarshal(data, &w); err != nil || w.IsZero() {
+		return nil
+	}
+	return &w
+}
+
+// FileWatchEvent is one trigger for a session: its hook to run or its
+// prompt to send, for the files that changed
+type FileWatchEvent struct {
+	Instance *Instance
+	Changed  []string // relative to the project path
+	Hook     string
+	Prompt   string
+}
+
+// fileWatchState is what the FileWatcher remembers about one session
+type fileWatchState struct {
+	patterns string               // the patterns the snapshot was taken for
+	files    map[string]time.Time // matched file -> modification time
+	changed  map[string]bool      // changes not yet settled
+	queued   map[string]bool      // changes behind a prompt waiting for the agent
+	overflow int                  // matches when over maxWatchedFiles, as last logged
+}
+
+// FileWatcher polls the files each session watches (Instance.Watch). A
+// change fires once it settles (no further change on the next check), so an
+// editor's save-rename-write counts as one. Hooks run right away; prompts
+// are held until the agent is waiting or idle, so they never interrupt it.
+// The first check only records the files, like the Alerter's priming.
+type FileWatcher struct {
+	mu        sync.Mutex
+	sessions  map[string]*fileWatchState
+	lastCheck time.Time
+}
+
+// NewFileWatcher creates an empty watcher
+func NewFileWatcher() *FileWatcher {
+	return &FileWatcher{sessions: make(map[string]*fileWatchState)}
+}
+
+// Check scans the watched files, at most every fileWatchInterval, and
+// returns the triggers due at now
+func (w *FileWatcher) Check(instances []*Instance, now time.Time) []FileWatchEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if now.Sub(w.lastCheck) < fileWatchInterval {
+		return nil
+	}
+	w.lastCheck = now
+
+	var events []FileWatchEvent
+	seen := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		watch := inst.Watch
+		if !watch.Active() {
+			continue
+		}
+		seen[inst.ID] = true
+		key := strings.Join(watch.Patterns, ",") + "\x00" + inst.ProjectPath
+		files, matched := scanWatchedFiles(inst.ProjectPath, watch.Patterns)
+
+		st, ok := w.sessions[inst.ID]
+		if !ok || st.patterns != key {
+			st = &fileWatchState{patterns: key, files: files, changed: map[string]bool{}, queued: map[string]bool{}}
+			w.sessions[inst.ID] = st
+			st.noteOverflow(inst, matched)
+			continue
+		}
+		st.noteOverflow(inst, matched)
+		diff := diffWatchedFiles(st.files, files)
+		st.files = files
+		for _, f := range diff {
+			st.changed[f] = true
+		}
+
+		if len(diff) == 0 && len(st.changed) > 0 {
+			changed := sortedKeys(st.changed)
+			st.changed = map[string]bool{}
+			if watch.Hook != "" {
+				events = append(events, FileWatchEvent{Instance: inst, Changed: changed, Hook: watch.Hook})
+			}
+			if watch.Prompt != "" {
+				for _, f := range changed {
+					st.queued[f] = true
+				}
+			}
+		}
+
+		if len(st.queued) > 0 && watch.Prompt != "" {
+			status := inst.GetStatusThreadSafe()
+			if status == StatusWaiting || status == StatusIdle {
+				events = append(events, FileWatchEvent{Instance: inst, Changed: sortedKeys(st.queued), Prompt: watch.Prompt})
+				st.queued = map[string]bool{}
+			}
+		}
+	}
+	for id := range w.sessions {
+		if !seen[id] {
+			delete(w.sessions, id)
+		}
+	}
+	return events
+}
+
+// noteOverflow warns when the session's patterns match more than
+// maxWatchedFiles, once per change in the number matched rather than on
+// every check
+func (st *fileWatchState) noteOverflow(inst *Instance, matched int) {
+	if matched <= maxWatchedFiles {
+		st.overflow = 0
+		return
+	}
+	if matched != st.overflow {
+		sessionLog.Warn("file_watch_truncated", slog.String("title", inst.Title), slog.String("path", inst.ProjectPath),
+			slog.Int("matched", matched), slog.Int("max", maxWatchedFiles))
+		st.overflow = matched
+	}
+}
+
+// scanWatchedFiles returns the modification time of each regular file the
+// patterns match under dir, keyed by its path relative to dir, at most
+// maxWatchedFiles of them, and how many paths the patterns matched
+func scanWatchedFiles(dir string, patterns []string) (map[string]time.Time, int) {
+	files := make(map[string]time.Time)
+	matched := 0
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			continue
+		}
+		matched += len(matches)
+		for _, match := range matches {
+			if len(files) >= maxWatchedFiles {
+				break
+			}
+			info, err := os.Stat(match)
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			rel, err := filepath.Rel(dir, match)
+			if err != nil {
+				rel = match
+			}
+			files[rel] = info.ModTime()
+		}
+	}
+	return files, matched
+}
+
+// diffWatchedFiles returns the files added, removed or modified between two
+// scans
+func diffWatchedFiles(before, after map[string]time.Time) []string {
+	var changed []string
+	for f, mod := range after {
+		if prev, ok := before[f]; !ok || !prev.Equal(mod) {
+			changed = append(changed, f)
+		}
+	}
+	for f := range before {
+		if _, ok := after[f]; !ok {
+			changed = append(changed, f)
+		}
+	}
+	return changed
+}
+
+// sortedKeys returns the set's members in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RunFileWatchEvents runs the triggers in the background, logging failures
+func RunFileWatchEvents(events []FileWatchEvent) {
+	for _, ev := range events {
+		ev := ev
+		go func() {
+			if err := ev.Run(); err != nil {
+				sessionLog.Warn("file_watch_failed", slog.String("title", ev.Instance.Title), slog.String("error", err.Error()))
+			}
+		}()
+	}
+}
+
+// Run runs the event's hook or sends its prompt
+func (ev FileWatchEvent) Run() error {
+	i := ev.Instance
+	if ev.Hook != "" {
+		return i.runAttachHook("file-watch", ev.Hook, io.Discard, "AGENTDECK_CHANGED_FILES="+strings.Join(ev.Changed, "\n"))
+	}
+	if ev.Prompt == "" {
+		return nil
+	}
+	tmuxSess := i.GetTmuxSession()
+	if tmuxSess == nil || !tmuxSess.Exists() {
+		return fmt.Errorf("session is not running")
+	}
+	prompt := strings.ReplaceAll(ev.Prompt, "{changed}", strings.Join(ev.Changed, ", "))
+	sessionLog.Info("file_watch_prompt", slog.String("title", i.Title), slog.Int("changed", len(ev.Changed)))
+	return tmuxSess.SendKeysAndEnter(i.ExpandPrompt(prompt))
+}
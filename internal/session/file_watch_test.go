@@ -0,0 +1,129 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWatchPatterns(t *testing.T) {
+	patterns, err := ParseWatchPatterns(" SPEC.md, docs/*.md ,")
+	if err != nil || strings.Join(patterns, "|") != "SPEC.md|docs/*.md" {
+		t.Errorf("ParseWatchPatterns = %v, %v", patterns, err)
+	}
+	if patterns, err := ParseWatchPatterns(""); err != nil || patterns != nil {
+		t.Errorf("empty value = %v, %v, want no patterns", patterns, err)
+	}
+	for _, bad := range []string{"/etc/passwd", "docs/[.md"} {
+		if _, err := ParseWatchPatterns(bad); err == nil {
+			t.Errorf("ParseWatchPatterns(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFileWatchRoundTrip(t *testing.T) {
+	w := &FileWatch{Patterns: []string{"SPEC.md"}, Prompt: "re-read {changed}"}
+	got := unmarshalFileWatch(marshalFileWatch(w))
+	if got == nil || got.Patterns[0] != "SPEC.md" || got.Prompt != w.Prompt {
+		t.Errorf("round trip = %+v", got)
+	}
+	if data := marshalFileWatch(&FileWatch{}); data != nil {
+		t.Errorf("empty watch = %s, want nothing stored", data)
+	}
+	if patternsOnly := (&FileWatch{Patterns: []string{"a"}}); patternsOnly.IsZero() || patternsOnly.Active() {
+		t.Error("a watch with patterns but no hook or prompt is set but not active")
+	}
+	if got := unmarshalFileWatch(nil); got != nil {
+		t.Errorf("no data = %+v, want nil", got)
+	}
+}
+
+func TestFileWatcherCheck(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "SPEC.md")
+	if err := os.WriteFile(spec, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	inst := NewInstance("impl", dir)
+	inst.Watch = &FileWatch{Patterns: []string{"*.md"}, Hook: "true", Prompt: "re-read {changed}"}
+	inst.SetStatusThreadSafe(StatusRunning)
+	instances := []*Instance{inst}
+
+	w := NewFileWatcher()
+	now := time.Now()
+	tick := func() []FileWatchEvent {
+		now = now.Add(fileWatchInterval)
+		return w.Check(instances, now)
+	}
+	if events := tick(); len(events) != 0 {
+		t.Fatalf("first check should only record the files, got %+v", events)
+	}
+	if events := w.Check(instances, now); events != nil {
+		t.Errorf("checks closer than the interval should be skipped, got %+v", events)
+	}
+
+	mod := time.Now().Add(time.Minute)
+	if err := os.Chtimes(spec, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+	if events := tick(); len(events) != 0 {
+		t.Fatalf("a change should wait to settle, got %+v", events)
+	}
+	events := tick()
+	if len(events) != 1 || events[0].Hook != "true" || strings.Join(events[0].Changed, ",") != "SPEC.md" {
+		t.Fatalf("events = %+v, want the hook for SPEC.md", events)
+	}
+	if events := tick(); len(events) != 0 {
+		t.Errorf("the prompt should be held while the agent runs, got %+v", events)
+	}
+
+	inst.SetStatusThreadSafe(StatusWaiting)
+	events = tick()
+	if len(events) != 1 || events[0].Prompt == "" || events[0].Changed[0] != "SPEC.md" {
+		t.Fatalf("events = %+v, want the held prompt", events)
+	}
+	if events := tick(); len(events) != 0 {
+		t.Errorf("the prompt should be sent once, got %+v", events)
+	}
+}
+
+func TestScanWatchedFilesLimit(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < maxWatchedFiles+5; i++ {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%d.txt", i)), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, matched := scanWatchedFiles(dir, []string{"*.txt"})
+	if len(files) != maxWatchedFiles || matched != maxWatchedFiles+5 {
+		t.Fatalf("got %d files of %d matched, want %d of %d", len(files), matched, maxWatchedFiles, maxWatchedFiles+5)
+	}
+
+	// The warning is remembered until the count changes
+	st := &fileWatchState{}
+	inst := NewInstance("big", dir)
+	st.noteOverflow(inst, matched)
+	if st.overflow != matched {
+		t.Errorf("overflow = %d, want %d", st.overflow, matched)
+	}
+	st.noteOverflow(inst, 10)
+	if st.overflow != 0 {
+		t.Errorf("overflow = %d after dropping under the limit, want 0", st.overflow)
+	}
+}
+
+func TestFileWatchEventHook(t *testing.T) {
+	dir := t.TempDir()
+	inst := NewInstance("impl", dir)
+	ev := FileWatchEvent{Instance: inst, Changed: []string{"a.md", "b.md"}, Hook: `printf '%s' "$AGENTDECK_CHANGED_FILES" > out.txt`}
+	if err := ev.Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	if err != nil || string(data) != "a.md\nb.md" {
+		t.Errorf("AGENTDECK_CHANGED_FILES = %q, %v", data, err)
+	}
+}
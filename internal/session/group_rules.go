@@ -0,0 +1,92 @@
+package session
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+)
+
+// GroupForPath returns the group of the first [[group_rules]] entry whose
+// path glob matches projectPath, or "" when none does. Repo placeholders in
+// the group ({owner}, {repo-name}, ...) are filled in from the project.
+func GroupForPath(projectPath string) string {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil || len(config.GroupRules) == 0 || projectPath == "" {
+		return ""
+	}
+	return matchGroupRules(config.GroupRules, projectPath)
+}
+
+// matchGroupRules is GroupForPath for the given rules
+func matchGroupRules(rules []GroupRule, projectPath string) string {
+	candidates := []string{filepath.Clean(expandTilde(projectPath))}
+	if canonical := CanonicalPath(projectPath); canonical != candidates[0] {
+		candidates = append(candidates, canonical)
+	}
+	for _, rule := range rules {
+		group := strings.Trim(rule.Group, "/ ")
+		if rule.Path == "" || group == "" {
+			continue
+		}
+		pattern := filepath.Clean(expandTilde(rule.Path))
+		for _, path := range candidates {
+			ok, err := matchPathGlob(pattern, path)
+			if err != nil {
+				sessionLog.Warn("group_rule_invalid", slog.String("path", rule.Path), slog.String("error", err.Error()))
+				break
+			}
+			if ok {
+				remote := ""
+				if HasRepoPlaceholder(group) {
+					remote = ProjectRemote(path)
+				}
+				return ExpandRepoTemplate(group, path, remote)
+			}
+		}
+	}
+	return ""
+}
+
+// matchPathGlob matches a slash-separated path against a glob where "**"
+// spans any number of directories (including none) and other segments use
+// filepath.Match syntax. Comparison ignores case on case-insensitive
+// filesystems.
+func matchPathGlob(pattern, path string) (bool, error) {
+	if caseInsensitiveFS() {
+		pattern, path = strings.ToLower(pattern), strings.ToLower(path)
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(path, "/"))
+}
+
+// matchSegments matches path segments against pattern segments
+func matchSegments(pattern, path []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			for i := 0; i <= len(path); i++ {
+				if ok, err := matchSegments(rest, path[i:]); ok || err != nil {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+		if len(path) == 0 {
+			return false, nil
+		}
+		ok, err := filepath.Match(pattern[0], path[0])
+		if err != nil || !ok {
+			return false, err
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0, nil
+}
+
+// defaultGroupPath is the group of a new session created without one: the
+// first matching group rule, else the project's parent folder
+func defaultGroupPath(projectPath string) string {
+	if group := GroupForPath(projectPath); group != "" {
+		return group
+	}
+	return extractGroupPath(projectPath)
+}
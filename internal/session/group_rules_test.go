@@ -0,0 +1,53 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchPathGlob(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/home/u/work/**", "/home/u/work/api", true},
+		{"/home/u/work/**", "/home/u/work/team/api", true},
+		{"/home/u/work/**", "/home/u/work", true},
+		{"/home/u/work/**", "/home/u/workshop/api", false},
+		{"/home/u/*/api", "/home/u/oss/api", true},
+		{"/home/u/*/api", "/home/u/oss/x/api", false},
+		{"/home/**/api-*", "/home/u/code/api-v2", true},
+		{"/home/u/work", "/home/u/work/api", false},
+	}
+	for _, tt := range tests {
+		got, err := matchPathGlob(tt.pattern, tt.path)
+		if err != nil || got != tt.want {
+			t.Errorf("matchPathGlob(%q, %q) = %v, %v; want %v", tt.pattern, tt.path, got, err, tt.want)
+		}
+	}
+	if _, err := matchPathGlob("/home/[", "/home/x"); err == nil {
+		t.Error("a malformed glob should return an error")
+	}
+}
+
+func TestMatchGroupRules(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	rules := []GroupRule{
+		{Path: "~/work/clients/**", Group: "clients/{dir}"},
+		{Path: "~/work/**", Group: "work"},
+		{Path: "~/oss/**", Group: "/oss/"},
+		{Path: "", Group: "ignored"},
+	}
+	tests := map[string]string{
+		filepath.Join(home, "work", "clients", "acme"): "clients/acme",
+		filepath.Join(home, "work", "api"):             "work",
+		"~/oss/agent-deck":                             "oss",
+		filepath.Join(home, "personal", "notes"):       "",
+	}
+	for path, want := range tests {
+		if got := matchGroupRules(rules, path); got != want {
+			t.Errorf("matchGroupRules(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"unicode"
@@ -42,6 +43,7 @@ type Group struct {
 	Sessions    []*Instance
 	Order       int
 	DefaultPath string // Most recent project path used for sessions in this group
+	Hidden      bool   // Left out of the default TUI view (kept in storage)
 }
 
 // GroupTree manages hierarchical session organization
@@ -120,6 +122,7 @@ func NewGroupTreeWithGroups(instances []*Instance, storedGroups []*GroupData) *G
 			Sessions:    []*Instance{},
 			Order:       gd.Order,
 			DefaultPath: gd.DefaultPath,
+			Hidden:      gd.Hidden,
 		}
 		tree.Groups[gd.Path] = group
 		tree.Expanded[gd.Path] = gd.Expanded
@@ -709,6 +712,33 @@ func (t *GroupTree) CreateSubgroup(parentPath, name string) *Group {
 	return group
 }
 
+// CreateGroupPath creates a group from a slash-separated path ("work/frontend"),
+// creating any missing parents. Returns the leaf group.
+func (t *GroupTree) CreateGroupPath(path string) *Group {
+	var group *Group
+	for _, part := range strings.Split(strings.Trim(path, "/"), "/") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		if group == nil {
+			group = t.CreateGroup(part)
+		} else {
+			group = t.CreateSubgroup(group.Path, part)
+		}
+	}
+	return group
+}
+
+// RenamedGroupPath returns the path a group at oldPath gets when renamed to newName
+// (same parent, sanitized and normalized leaf)
+func RenamedGroupPath(oldPath, newName string) string {
+	newBasePath := strings.ToLower(strings.ReplaceAll(sanitizeGroupName(newName), " ", "-"))
+	if parentPath := getParentPath(oldPath); parentPath != "" {
+		return parentPath + "/" + newBasePath
+	}
+	return newBasePath
+}
+
 // RenameGroup renames a group and updates all subgroups
 func (t *GroupTree) RenameGroup(oldPath, newName string) {
 	group, exists := t.Groups[oldPath]
@@ -718,16 +748,7 @@ func (t *GroupTree) RenameGroup(oldPath, newName string) {
 
 	// Sanitize name to prevent path traversal and security issues
 	sanitizedName := sanitizeGroupName(newName)
-	newBasePath := strings.ToLower(strings.ReplaceAll(sanitizedName, " ", "-"))
-
-	// Preserve parent path for subgroups
-	parentPath := getParentPath(oldPath)
-	var newPath string
-	if parentPath != "" {
-		newPath = parentPath + "/" + newBasePath
-	} else {
-		newPath = newBasePath
-	}
+	newPath := RenamedGroupPath(oldPath, newName)
 
 	if newPath == oldPath {
 		group.Name = sanitizedName
@@ -831,6 +852,140 @@ func (t *GroupTree) DeleteGroup(path string) []*Instance {
 	return allMovedSessions
 }
 
+// EmptyGroups returns the paths of groups with no sessions in them or their
+// subgroups (the default group is never included), deepest first.
+func (t *GroupTree) EmptyGroups() []string {
+	var paths []string
+	for path := range t.Groups {
+		if path != DefaultGroupPath && t.SessionCountForGroup(path) == 0 {
+			paths = append(paths, path)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if li, lj := GetGroupLevel(paths[i]), GetGroupLevel(paths[j]); li != lj {
+			return li > lj
+		}
+		return paths[i] < paths[j]
+	})
+	return paths
+}
+
+// PruneEmptyGroups deletes every group returned by EmptyGroups.
+// Returns the deleted paths.
+func (t *GroupTree) PruneEmptyGroups() []string {
+	pruned := t.EmptyGroups()
+	for _, path := range pruned {
+		if _, exists := t.Groups[path]; exists {
+			t.DeleteGroup(path)
+		}
+	}
+	return pruned
+}
+
+// MergeGroup moves every session and subgroup of src into dst and removes src.
+// Sessions are appended after dst's own in their original order; subgroups keep
+// their relative order and are merged into same-named subgroups of dst.
+// Returns the number of sessions moved.
+func (t *GroupTree) MergeGroup(src, dst string) (int, error) {
+	switch {
+	case src == dst:
+		return 0, fmt.Errorf("cannot merge a group into itself")
+	case src == DefaultGroupPath:
+		return 0, fmt.Errorf("cannot merge the default group")
+	case strings.HasPrefix(dst, src+"/"):
+		return 0, fmt.Errorf("cannot merge a group into its own subgroup")
+	}
+	if _, exists := t.Groups[src]; !exists {
+		return 0, fmt.Errorf("group '%s' not found", src)
+	}
+	if _, exists := t.Groups[dst]; !exists {
+		return 0, fmt.Errorf("group '%s' not found", dst)
+	}
+
+	// The source and its subgroups, parents first, siblings in display order
+	var subtree []*Group
+	for _, g := range t.GroupList {
+		if g.Path == src || strings.HasPrefix(g.Path, src+"/") {
+			subtree = append(subtree, g)
+		}
+	}
+
+	// Detach the source first so a target path can't resolve to a group being merged
+	for _, g := range subtree {
+		delete(t.Groups, g.Path)
+		delete(t.Expanded, g.Path)
+	}
+
+	moved := 0
+	for _, g := range subtree {
+		targetPath := dst + g.Path[len(src):]
+		target, exists := t.Groups[targetPath]
+		if !exists {
+			siblings := 0
+			parent := getParentPath(targetPath)
+			for p := range t.Groups {
+				if getParentPath(p) == parent {
+					siblings++
+				}
+			}
+			target = &Group{
+				Name:        g.Name,
+				Path:        targetPath,
+				Expanded:    g.Expanded,
+				Sessions:    []*Instance{},
+				Order:       siblings,
+				DefaultPath: g.DefaultPath,
+				Hidden:      g.Hidden,
+			}
+			t.Groups[targetPath] = target
+			t.Expanded[targetPath] = g.Expanded
+		}
+		for _, sess := range g.Sessions {
+			sess.GroupPath = targetPath
+			sess.Order = len(target.Sessions)
+			target.Sessions = append(target.Sessions, sess)
+			moved++
+		}
+	}
+
+	t.rebuildGroupList()
+	t.updateGroupDefaultPath(dst)
+	return moved, nil
+}
+
+// SetGroupHidden hides or unhides a group. Hidden groups (and everything in
+// them) are left out of the default TUI view but stay in storage.
+// Returns false if the group doesn't exist.
+func (t *GroupTree) SetGroupHidden(path string, hidden bool) bool {
+	group, exists := t.Groups[path]
+	if !exists {
+		return false
+	}
+	group.Hidden = hidden
+	return true
+}
+
+// IsGroupHidden reports whether a group is hidden itself or sits inside a hidden group
+func (t *GroupTree) IsGroupHidden(path string) bool {
+	for p := path; p != ""; p = getParentPath(p) {
+		if group, exists := t.Groups[p]; exists && group.Hidden {
+			return true
+		}
+	}
+	return false
+}
+
+// HiddenGroupCount returns the number of groups marked hidden
+func (t *GroupTree) HiddenGroupCount() int {
+	count := 0
+	for _, group := range t.Groups {
+		if group.Hidden {
+			count++
+		}
+	}
+	return count
+}
+
 // GetAllInstances returns all instances in order
 func (t *GroupTree) GetAllInstances() []*Instance {
 	instances := []*Instance{}
@@ -1015,6 +1170,7 @@ func (t *GroupTree) ShallowCopyForSave() *GroupTree {
 			Expanded:    g.Expanded,
 			Order:       g.Order,
 			DefaultPath: g.DefaultPath,
+			Hidden:      g.Hidden,
 			// Don't copy Sessions - not needed for save, only metadata is saved
 		}
 	}
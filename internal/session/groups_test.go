@@ -1,6 +1,7 @@
 package session
 
 import (
+	"reflect"
 	"testing"
 	"time"
 )
@@ -563,6 +564,156 @@ func TestDeleteDefaultGroup(t *testing.T) {
 	}
 }
 
+func TestCreateGroupPath(t *testing.T) {
+	tree := NewGroupTree([]*Instance{})
+
+	leaf := tree.CreateGroupPath("Clients/Acme Corp/api")
+	if leaf == nil || leaf.Path != "clients/acme-corp/api" {
+		t.Fatalf("leaf = %+v, want path clients/acme-corp/api", leaf)
+	}
+	for _, path := range []string{"clients", "clients/acme-corp"} {
+		if tree.Groups[path] == nil {
+			t.Errorf("parent %q was not created", path)
+		}
+	}
+
+	// Existing levels are reused
+	if again := tree.CreateGroupPath("clients/acme-corp/api"); again != leaf {
+		t.Error("expected existing group to be returned")
+	}
+	if tree.CreateGroupPath("/") != nil {
+		t.Error("empty path should create nothing")
+	}
+}
+
+func TestPruneEmptyGroups(t *testing.T) {
+	tree := NewGroupTree([]*Instance{
+		{ID: "1", Title: "api", GroupPath: "work/backend"},
+	})
+	tree.CreateGroupPath("work/frontend")
+	tree.CreateGroupPath("old/client/site")
+
+	want := []string{"old/client/site", "old/client", "work/frontend", "old"}
+	if got := tree.EmptyGroups(); !reflect.DeepEqual(got, want) {
+		t.Errorf("EmptyGroups() = %v, want %v", got, want)
+	}
+
+	tree.PruneEmptyGroups()
+	for _, path := range want {
+		if tree.Groups[path] != nil {
+			t.Errorf("group %q should be pruned", path)
+		}
+	}
+	// "work" holds a session via its subgroup, so it stays
+	if tree.Groups["work"] == nil || tree.Groups["work/backend"] == nil {
+		t.Error("groups with sessions must be kept")
+	}
+}
+
+func TestGroupHidden(t *testing.T) {
+	tree := NewGroupTree([]*Instance{})
+	tree.CreateGroupPath("clients/acme")
+	tree.CreateGroup("work")
+
+	if !tree.SetGroupHidden("clients", true) {
+		t.Fatal("SetGroupHidden on existing group should succeed")
+	}
+	if tree.SetGroupHidden("missing", true) {
+		t.Error("SetGroupHidden on a missing group should fail")
+	}
+	if !tree.IsGroupHidden("clients") || !tree.IsGroupHidden("clients/acme") {
+		t.Error("group and its subgroups should be hidden")
+	}
+	if tree.IsGroupHidden("work") {
+		t.Error("unrelated group should not be hidden")
+	}
+	if got := tree.HiddenGroupCount(); got != 1 {
+		t.Errorf("HiddenGroupCount = %d, want 1", got)
+	}
+
+	// Hidden flag survives a save/load round trip through GroupData
+	var stored []*GroupData
+	for _, g := range tree.ShallowCopyForSave().GroupList {
+		stored = append(stored, &GroupData{Name: g.Name, Path: g.Path, Expanded: g.Expanded, Order: g.Order, Hidden: g.Hidden})
+	}
+	if !NewGroupTreeWithGroups(nil, stored).IsGroupHidden("clients/acme") {
+		t.Error("hidden flag lost when rebuilding tree from stored groups")
+	}
+}
+
+func TestMergeGroup(t *testing.T) {
+	instances := []*Instance{
+		{ID: "1", Title: "a1", GroupPath: "clients", Order: 0},
+		{ID: "2", Title: "b1", GroupPath: "old", Order: 0},
+		{ID: "3", Title: "b2", GroupPath: "old", Order: 1},
+		{ID: "4", Title: "acme-old", GroupPath: "old/acme", Order: 0},
+		{ID: "5", Title: "acme", GroupPath: "clients/acme", Order: 0},
+		{ID: "6", Title: "misc", GroupPath: "old/misc", Order: 0},
+	}
+	tree := NewGroupTree(instances)
+
+	moved, err := tree.MergeGroup("old", "clients")
+	if err != nil {
+		t.Fatalf("MergeGroup: %v", err)
+	}
+	if moved != 4 {
+		t.Errorf("moved = %d, want 4", moved)
+	}
+	for _, path := range []string{"old", "old/acme", "old/misc"} {
+		if tree.Groups[path] != nil {
+			t.Errorf("source group %q should be removed", path)
+		}
+	}
+
+	var titles []string
+	for _, s := range tree.Groups["clients"].Sessions {
+		titles = append(titles, s.Title)
+	}
+	if want := []string{"a1", "b1", "b2"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("clients sessions = %v, want %v", titles, want)
+	}
+	if got := len(tree.Groups["clients/acme"].Sessions); got != 2 {
+		t.Errorf("clients/acme sessions = %d, want 2 (merged subgroup)", got)
+	}
+	if tree.Groups["clients/misc"] == nil || instances[5].GroupPath != "clients/misc" {
+		t.Error("subgroup should be re-parented under target")
+	}
+}
+
+func TestMergeGroupErrors(t *testing.T) {
+	tree := NewGroupTree([]*Instance{})
+	tree.CreateGroupPath("a/b")
+	tree.CreateGroup("c")
+
+	if _, err := tree.MergeGroup("a", "a/b"); err == nil {
+		t.Error("merging into own subgroup should fail")
+	}
+	if _, err := tree.MergeGroup("c", "c"); err == nil {
+		t.Error("merging into itself should fail")
+	}
+	if _, err := tree.MergeGroup("missing", "c"); err == nil {
+		t.Error("missing source should fail")
+	}
+
+	// Child into parent: a/b's contents land directly in a
+	tree.CreateSubgroup("a/b", "b")
+	if _, err := tree.MergeGroup("a/b", "a"); err != nil {
+		t.Fatalf("MergeGroup child into parent: %v", err)
+	}
+	if tree.Groups["a/b"] == nil || tree.Groups["a/b/b"] != nil {
+		t.Error("a/b/b should become a/b")
+	}
+}
+
+func TestRenamedGroupPath(t *testing.T) {
+	if got := RenamedGroupPath("clients/acme", "Acme Corp"); got != "clients/acme-corp" {
+		t.Errorf("got %q", got)
+	}
+	if got := RenamedGroupPath("old", "Archive"); got != "archive" {
+		t.Errorf("got %q", got)
+	}
+}
+
 func TestMoveSessionToGroup(t *testing.T) {
 	instances := []*Instance{
 		{ID: "1", Title: "session-1", GroupPath: "source"},
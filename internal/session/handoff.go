@@ -0,0 +1,25 @@
+package session
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// handoffMaxLen caps a handoff note's display width; it is meant to be one line
+const handoffMaxLen = 200
+
+// SetHandoffNote records where work on the session was left off. The note
+// is trimmed to one line; an empty note clears it.
+func (i *Instance) SetHandoffNote(note string) {
+	note = strings.TrimSpace(strings.SplitN(note, "\n", 2)[0])
+	// Cut whole characters: a byte cut can split one into invalid UTF-8
+	note = strings.TrimSpace(runewidth.Truncate(note, handoffMaxLen, ""))
+	i.HandoffNote = note
+	if note == "" {
+		i.HandoffAt = time.Time{}
+		return
+	}
+	i.HandoffAt = time.Now()
+}
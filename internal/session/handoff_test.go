@@ -0,0 +1,45 @@
+package session
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestSetHandoffNote(t *testing.T) {
+	inst := &Instance{}
+	inst.SetHandoffNote("  wired the API\nsecond line ignored ")
+	if inst.HandoffNote != "wired the API" || inst.HandoffAt.IsZero() {
+		t.Errorf("note = %q at %v", inst.HandoffNote, inst.HandoffAt)
+	}
+	inst.SetHandoffNote(strings.Repeat("x", 300))
+	if len(inst.HandoffNote) != handoffMaxLen {
+		t.Errorf("long note kept %d chars", len(inst.HandoffNote))
+	}
+	inst.SetHandoffNote(strings.Repeat("é", 150) + strings.Repeat("日", 100))
+	if !utf8.ValidString(inst.HandoffNote) || runewidth.StringWidth(inst.HandoffNote) > handoffMaxLen {
+		t.Errorf("multibyte note cut to %q", inst.HandoffNote)
+	}
+	inst.SetHandoffNote("")
+	if inst.HandoffNote != "" || !inst.HandoffAt.IsZero() {
+		t.Error("empty note should clear")
+	}
+}
+
+func TestHandoffSettingsShouldPrompt(t *testing.T) {
+	if (HandoffSettings{}).ShouldPrompt(time.Hour) {
+		t.Error("prompting is off by default")
+	}
+	on := HandoffSettings{PromptOnDetach: true}
+	if on.ShouldPrompt(10*time.Second) || !on.ShouldPrompt(time.Minute) {
+		t.Error("default minimum attach is 30s")
+	}
+	zero := 0
+	on.MinAttachSeconds = &zero
+	if !on.ShouldPrompt(0) {
+		t.Error("min_attach_seconds = 0 should always prompt")
+	}
+}
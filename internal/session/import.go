@@ -0,0 +1,321 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// ImportRemap rewrites an exported deck for this machine: project paths by
+// prefix (their ~/code to my ~/src) and ssh hosts by name
+type ImportRemap struct {
+	Paths map[string]string // Their directory -> ours
+	Hosts map[string]string // Their ssh container host -> ours
+}
+
+// MapPath remaps the directory from (theirs) to to (ours), ~ allowed in both
+func (r ImportRemap) MapPath(from, to string) {
+	from, to = filepath.Clean(expandTilde(from)), filepath.Clean(expandTilde(to))
+	if from != to {
+		r.Paths[from] = to
+	}
+}
+
+// ImportResult counts what an import added
+type ImportResult struct {
+	Added        int `json:"added"`
+	Skipped      int `json:"skipped"` // Already in the profile (imported before)
+	AddedGroups  int `json:"added_groups"`
+	Remapped     int `json:"remapped"`                // Sessions whose path or host was rewritten
+	HooksDropped int `json:"hooks_dropped,omitempty"` // See DropImportHooks
+}
+
+// ImportPathRoot is a directory of exported projects that doesn't exist
+// here, to be remapped
+type ImportPathRoot struct {
+	Path     string
+	Sessions int
+	// Suggested is the same path under this user's home, when it exists
+	Suggested string
+}
+
+// ExportStorageData renders sessions and groups for another user or machine
+// to import, without live state, tombstones or tmux session names. Session
+// env vars are left out unless includeEnv is set: they often hold API keys.
+func ExportStorageData(data *StorageData, includeEnv bool) ([]byte, error) {
+	shared := *data
+	shared.Tombstones = nil
+	shared.Instances = make([]*InstanceData, len(data.Instances))
+	for i, inst := range data.Instances {
+		cp := *inst
+		cp.TmuxSession, cp.TmuxSocket = "", ""
+		if !includeEnv {
+			cp.Env = nil
+		}
+		shared.Instances[i] = &cp
+	}
+	return encodeSyncData(&shared)
+}
+
+// ParseExport reads a deck written by `agent-deck export` (or a
+// sessions.json from sync)
+func ParseExport(data []byte) (*StorageData, error) {
+	var sd StorageData
+	if err := json.Unmarshal(data, &sd); err != nil {
+		return nil, fmt.Errorf("not an agent-deck export: %w", err)
+	}
+	return &sd, nil
+}
+
+// MissingPathRoots returns the parent directories of exported projects that
+// don't exist here and aren't remapped yet, most sessions first
+func MissingPathRoots(data *StorageData, remap ImportRemap) []ImportPathRoot {
+	counts := make(map[string]int)
+	for _, inst := range data.Instances {
+		path := inst.ProjectPath
+		if path == "" || remapPath(path, remap.Paths) != path || pathExists(path) {
+			continue
+		}
+		counts[filepath.Dir(path)]++
+	}
+	roots := make([]ImportPathRoot, 0, len(counts))
+	for dir, n := range counts {
+		roots = append(roots, ImportPathRoot{Path: dir, Sessions: n, Suggested: suggestHomePath(dir)})
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		if roots[i].Sessions != roots[j].Sessions {
+			return roots[i].Sessions > roots[j].Sessions
+		}
+		return roots[i].Path < roots[j].Path
+	})
+	return roots
+}
+
+// UnknownImportHosts returns the ssh container hosts of exported sessions
+// that aren't among this machine's remote hosts and aren't remapped yet
+func UnknownImportHosts(data *StorageData, remap ImportRemap) []string {
+	known := make(map[string]bool)
+	for _, h := range ListRemoteHosts() {
+		known[h.Name] = true
+	}
+	seen := make(map[string]bool)
+	var hosts []string
+	for _, inst := range data.Instances {
+		c := inst.Container
+		if c == nil || c.Kind != ContainerSSH || known[c.Target] || seen[c.Target] {
+			continue
+		}
+		if _, ok := remap.Hosts[c.Target]; ok {
+			continue
+		}
+		seen[c.Target] = true
+		hosts = append(hosts, c.Target)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// suggestHomePath maps a path under another user's home (/home/alice/code,
+// /Users/alice/code) to the same path under ours, "" when that doesn't exist
+func suggestHomePath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	// "", "home" or "Users", the user, the rest
+	if len(parts) < 3 || parts[0] != "" || (parts[1] != "home" && parts[1] != "Users") {
+		return ""
+	}
+	ours := filepath.Join(append([]string{home}, parts[3:]...)...)
+	if ours == path || !pathExists(ours) {
+		return ""
+	}
+	return ours
+}
+
+// pathExists reports whether path exists on this machine
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// remapPath rewrites path by the longest matching directory prefix in paths
+func remapPath(path string, paths map[string]string) string {
+	best, to := "", ""
+	for from, dest := range paths {
+		from = filepath.Clean(from)
+		if (path == from || strings.HasPrefix(path, from+string(filepath.Separator))) && len(from) > len(best) {
+			best, to = from, dest
+		}
+	}
+	if best == "" {
+		return path
+	}
+	return filepath.Join(to, strings.TrimPrefix(path, best))
+}
+
+// ApplyImportRemap rewrites the exported sessions and groups for this
+// machine, returning how many sessions changed
+func ApplyImportRemap(data *StorageData, remap ImportRemap) int {
+	remapped := 0
+	for _, inst := range data.Instances {
+		before := *inst
+		inst.ProjectPath = remapPath(inst.ProjectPath, remap.Paths)
+		if inst.WorktreePath != "" {
+			inst.WorktreePath = remapPath(inst.WorktreePath, remap.Paths)
+		}
+		if inst.WorktreeRepoRoot != "" {
+			inst.WorktreeRepoRoot = remapPath(inst.WorktreeRepoRoot, remap.Paths)
+		}
+		changed := inst.ProjectPath != before.ProjectPath || inst.WorktreePath != before.WorktreePath || inst.WorktreeRepoRoot != before.WorktreeRepoRoot
+		if c := inst.Container; c != nil && c.Kind == ContainerSSH {
+			if to, ok := remap.Hosts[c.Target]; ok && to != c.Target {
+				spec := *c
+				spec.Target = to
+				inst.Container = &spec
+				changed = true
+			}
+		}
+		if changed {
+			remapped++
+		}
+	}
+	for _, g := range data.Groups {
+		if g.DefaultPath != "" {
+			g.DefaultPath = remapPath(g.DefaultPath, remap.Paths)
+		}
+	}
+	return remapped
+}
+
+// ImportCommand is a shell command an exported session runs: its launch
+// command or wrapper, or one of its hooks
+type ImportCommand struct {
+	Title   string `json:"title"`
+	Kind    string `json:"kind"` // command, wrapper, pre_start_hook, post_exit_hook, pre_attach_hook, post_detach_hook or watch_hook
+	Command string `json:"command"`
+	Hook    bool   `json:"hook"`
+}
+
+// ImportCommands lists the commands and hooks the exported sessions would
+// run here, to be shown before they are added
+func ImportCommands(data *StorageData) []ImportCommand {
+	var cmds []ImportCommand
+	for _, inst := range data.Instances {
+		add := func(kind, command string, hook bool) {
+			if command != "" {
+				cmds = append(cmds, ImportCommand{Title: inst.Title, Kind: kind, Command: command, Hook: hook})
+			}
+		}
+		add("command", inst.Command, false)
+		add("wrapper", inst.Wrapper, false)
+		add("pre_start_hook", inst.PreStartHook, true)
+		add("post_exit_hook", inst.PostExitHook, true)
+		add("pre_attach_hook", inst.PreAttachHook, true)
+		add("post_detach_hook", inst.PostDetachHook, true)
+		if inst.Watch != nil {
+			add("watch_hook", inst.Watch.Hook, true)
+		}
+	}
+	return cmds
+}
+
+// DropImportHooks clears the exported sessions' hooks, which would run
+// someone else's shell commands unattended, returning how many it dropped.
+// A watch keeps its patterns and prompt.
+func DropImportHooks(data *StorageData) int {
+	dropped := 0
+	for _, inst := range data.Instances {
+		for _, hook := range []*string{&inst.PreStartHook, &inst.PostExitHook, &inst.PreAttachHook, &inst.PostDetachHook} {
+			if *hook != "" {
+				*hook = ""
+				dropped++
+			}
+		}
+		if w := inst.Watch; w != nil && w.Hook != "" {
+			watch := *w
+			watch.Hook = ""
+			inst.Watch = &watch
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// ImportStorageData adds the exported sessions and groups to the profile,
+// nested under group when it isn't empty. Sessions already in the profile
+// are skipped, so importing the same deck twice adds nothing. Conversation
+// IDs and tmux sessions are dropped: they live on the exporting machine. So
+// is a queued prompt, which would be sent on the first start here.
+func (s *Storage) ImportStorageData(data *StorageData, group string) (ImportResult, error) {
+	var result ImportResult
+	local, err := s.LoadStorageData()
+	if err != nil {
+		return result, err
+	}
+	group = strings.Trim(group, "/")
+	nest := func(path string) string {
+		if group == "" {
+			return path
+		}
+		if path == "" {
+			return group
+		}
+		return group + "/" + path
+	}
+
+	haveInst := make(map[string]bool, len(local.Instances))
+	for _, inst := range local.Instances {
+		haveInst[inst.ID] = true
+	}
+	haveGroup := make(map[string]bool, len(local.Groups))
+	for _, g := range local.Groups {
+		haveGroup[g.Path] = true
+	}
+	addGroup := func(g GroupData) {
+		if g.Path == "" || haveGroup[g.Path] {
+			return
+		}
+		haveGroup[g.Path] = true
+		local.Groups = append(local.Groups, &g)
+		result.AddedGroups++
+	}
+	if group != "" {
+		addGroup(GroupData{Name: filepath.Base(group), Path: group, Expanded: true})
+	}
+	for _, g := range data.Groups {
+		cp := *g
+		cp.Path = nest(g.Path)
+		addGroup(cp)
+	}
+
+	for _, inst := range data.Instances {
+		if haveInst[inst.ID] {
+			result.Skipped++
+			continue
+		}
+		haveInst[inst.ID] = true
+		cp := *inst
+		cp.GroupPath = nest(inst.GroupPath)
+		cp.Status = StatusError
+		cp.ClaudeSessionID, cp.GeminiSessionID, cp.OpenCodeSessionID, cp.CodexSessionID = "", "", "", ""
+		cp.PendingPrompt = ""
+		// A fresh tmux session: reusing the exporter's name would attach this
+		// copy to their live session (on the same machine), and kill it on remove
+		fresh := tmux.NewSession(cp.Title, cp.ProjectPath)
+		cp.TmuxSession, cp.TmuxSocket = fresh.Name, fresh.SocketName
+		local.Instances = append(local.Instances, &cp)
+		delete(local.Tombstones, cp.ID)
+		result.Added++
+	}
+	if result.Added == 0 && result.AddedGroups == 0 {
+		return result, nil
+	}
+	return result, s.SaveStorageData(local)
+}
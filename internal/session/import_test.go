@@ -0,0 +1,137 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportRemap(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, "code", "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	data := &StorageData{
+		Instances: []*InstanceData{
+			{ID: "a1", Title: "api", ProjectPath: "/home/alice/code/api", GroupPath: "backend", Tool: "claude", ClaudeSessionID: "conv-1", UpdatedAt: now,
+				TmuxSession: "agentdeck_api_1234", TmuxSocket: "alice", Env: map[string]string{"ANTHROPIC_API_KEY": "sk-secret"}},
+			{ID: "a2", Title: "web", ProjectPath: "/home/alice/code/web", GroupPath: "backend/web", Tool: "shell", UpdatedAt: now,
+				PendingPrompt: "deploy it"},
+			{ID: "a3", Title: "gpu", ProjectPath: "/srv/train", Tool: "claude", Container: &ContainerSpec{Kind: ContainerSSH, Target: "alice-gpu"}, UpdatedAt: now},
+		},
+		Groups: []*GroupData{
+			{Name: "backend", Path: "backend", DefaultPath: "/home/alice/code"},
+			{Name: "web", Path: "backend/web"},
+		},
+	}
+	withEnv, err := ExportStorageData(data, true)
+	if err != nil || !strings.Contains(string(withEnv), "sk-secret") {
+		t.Errorf("export with env = %v, want the env var kept", err)
+	}
+	encoded, err := ExportStorageData(data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{"sk-secret", "ANTHROPIC_API_KEY", "agentdeck_api_1234"} {
+		if strings.Contains(string(encoded), leaked) {
+			t.Errorf("export contains %q", leaked)
+		}
+	}
+	if data, err = ParseExport(encoded); err != nil {
+		t.Fatalf("ParseExport: %v", err)
+	}
+	data.Instances[0].TmuxSession = "agentdeck_api_1234" // As in a sessions.json from sync
+
+	remap := ImportRemap{Paths: map[string]string{}, Hosts: map[string]string{}}
+	roots := MissingPathRoots(data, remap)
+	if len(roots) != 2 || roots[0].Path != "/home/alice/code" || roots[0].Sessions != 2 || roots[0].Suggested != filepath.Join(home, "code") {
+		t.Fatalf("roots = %+v, want /home/alice/code first, suggesting ~/code", roots)
+	}
+	if hosts := UnknownImportHosts(data, remap); len(hosts) != 1 || hosts[0] != "alice-gpu" {
+		t.Errorf("unknown hosts = %v", hosts)
+	}
+
+	remap.MapPath("/home/alice/code", "~/code")
+	remap.MapPath("/srv", "/srv") // Same path: not a remap
+	remap.Hosts["alice-gpu"] = "gpu"
+	if n := ApplyImportRemap(data, remap); n != 3 {
+		t.Errorf("remapped = %d, want 3", n)
+	}
+	if got := data.Instances[1].ProjectPath; got != filepath.Join(home, "code", "web") {
+		t.Errorf("web path = %q", got)
+	}
+	if data.Instances[2].ProjectPath != "/srv/train" || data.Instances[2].Container.Target != "gpu" {
+		t.Errorf("gpu session = %s on %s", data.Instances[2].ProjectPath, data.Instances[2].Container.Target)
+	}
+	if data.Groups[0].DefaultPath != filepath.Join(home, "code") {
+		t.Errorf("group default path = %q", data.Groups[0].DefaultPath)
+	}
+
+	s := newTestStorage(t)
+	result, err := s.ImportStorageData(data, "alice")
+	if err != nil {
+		t.Fatalf("ImportStorageData: %v", err)
+	}
+	if result.Added != 3 || result.AddedGroups != 3 {
+		t.Errorf("result = %+v, want 3 sessions and alice, alice/backend, alice/backend/web", result)
+	}
+	imported, err := s.LoadStorageData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := map[string]*InstanceData{}
+	for _, inst := range imported.Instances {
+		byID[inst.ID] = inst
+	}
+	if api := byID["a1"]; api == nil || api.GroupPath != "alice/backend" || api.ClaudeSessionID != "" || api.ProjectPath != filepath.Join(home, "code", "api") {
+		t.Errorf("imported api = %+v", api)
+	}
+	if web := byID["a2"]; web == nil || web.PendingPrompt != "" {
+		t.Errorf("imported web = %+v, want its queued prompt dropped", web)
+	}
+	if api := byID["a1"]; api != nil && (api.TmuxSession == "" || api.TmuxSession == "agentdeck_api_1234") {
+		t.Errorf("imported api has tmux session %q, want a fresh one", api.TmuxSession)
+	}
+
+	again, err := s.ImportStorageData(data, "alice")
+	if err != nil || again.Added != 0 || again.Skipped != 3 || again.AddedGroups != 0 {
+		t.Errorf("importing twice = %+v (%v), want everything skipped", again, err)
+	}
+}
+
+func TestImportCommandsAndHooks(t *testing.T) {
+	data := &StorageData{Instances: []*InstanceData{
+		{ID: "a1", Title: "api", Command: "claude", PreStartHook: "make deps", PostExitHook: "notify done",
+			Watch: &FileWatch{Patterns: []string{"*.go"}, Hook: "go vet ./...", Prompt: "fix {changed}"}},
+		{ID: "a2", Title: "web", Wrapper: "nice {command}", PreAttachHook: "clear", PostDetachHook: "true"},
+	}}
+	cmds := ImportCommands(data)
+	var kinds []string
+	hooks := 0
+	for _, c := range cmds {
+		kinds = append(kinds, c.Title+":"+c.Kind)
+		if c.Hook {
+			hooks++
+		}
+	}
+	want := "api:command api:pre_start_hook api:post_exit_hook api:watch_hook web:wrapper web:pre_attach_hook web:post_detach_hook"
+	if got := strings.Join(kinds, " "); got != want || hooks != 5 {
+		t.Errorf("commands = %s (%d hooks), want %s (5 hooks)", got, hooks, want)
+	}
+
+	if n := DropImportHooks(data); n != 5 {
+		t.Errorf("dropped %d hooks, want 5", n)
+	}
+	api, web := data.Instances[0], data.Instances[1]
+	if api.PreStartHook != "" || api.PostExitHook != "" || web.PreAttachHook != "" || web.PostDetachHook != "" || api.Watch.Hook != "" {
+		t.Errorf("hooks left after drop: %+v %+v", api, web)
+	}
+	if api.Command != "claude" || web.Wrapper == "" || api.Watch.Prompt == "" || len(api.Watch.Patterns) != 1 {
+		t.Errorf("drop should keep commands and the watch's patterns and prompt: %+v", api)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -16,6 +17,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/asheshgoplani/agent-deck/internal/logging"
@@ -59,6 +61,7 @@ type Instance struct {
 	Status         Status    `json:"status"`
 	CreatedAt      time.Time `json:"created_at"`
 	LastAccessedAt time.Time `json:"last_accessed_at,omitempty"` // When user last attached
+	UpdatedAt      time.Time `json:"updated_at,omitempty"`       // Last change to synced fields (set by storage)
 
 	// Claude Code integration
 	ClaudeSessionID  string    `json:"claude_session_id,omitempty"`
@@ -89,6 +92,8 @@ type Instance struct {
 	lastJSONLSize int64
 	lastJSONLPath string
 	cachedPrompt  string
+	detectedModel string // model of the last assistant turn (see model.go)
+	agentSummary  string // task summary the tool recorded (see auto_name.go)
 
 	// MCP tracking - which MCPs were loaded when session started/restarted
 	// Used to detect pending MCPs (added after session start) and stale MCPs (removed but still running)
@@ -98,6 +103,83 @@ type Instance struct {
 	// JSON structure: {"tool": "claude", "options": {...}}
 	ToolOptionsJSON json.RawMessage `json:"tool_options,omitempty"`
 
+	// AutoCheckpoint overrides [checkpoint].enabled for this session (nil = use global config)
+	AutoCheckpoint *bool `json:"auto_checkpoint,omitempty"`
+
+	// StatusText is a free-form status shown next to the status icon ("running tests").
+	// Set manually, by `agent-deck notify`, or by hooks; kept until cleared.
+	// StatusTextFromHook marks text set by a hook, cleared by the hook's next report.
+	StatusText         string `json:"status_text,omitempty"`
+	StatusTextFromHook bool   `json:"status_text_hook,omitempty"`
+
+	// Container runs the session's command inside a container (nil = on the host)
+	Container *ContainerSpec `json:"container,omitempty"`
+
+	// Notes is free-form text kept with the session, e.g. the issue it works on
+	Notes string `json:"notes,omitempty"`
+
+	// PendingPrompt is sent to the agent the next time the session starts, then
+	// cleared (e.g. the issue body queued by `add --issue`)
+	PendingPrompt string `json:"pending_prompt,omitempty"`
+
+	// AutoAttach makes the TUI attach ("attach") or offer to attach ("ask")
+	// when the session starts waiting while the deck list is shown ("" = off)
+	AutoAttach string `json:"auto_attach,omitempty"`
+
+	// Ticket links the session to a Linear or Jira ticket (nil = none)
+	Ticket *Ticket `json:"ticket,omitempty"`
+
+	// GitRemote is the origin URL of the project's repo, recorded when the
+	// session starts so a moved checkout can be found again (see Relocate)
+	GitRemote string `json:"git_remote,omitempty"`
+
+	// PreAttachHook and PostDetachHook are shell commands run in the project
+	// directory just before attaching (e.g. "git fetch") and right after
+	// detaching. Empty falls back to the tool's pre_attach / post_detach in
+	// config.toml (see AttachHooks).
+	PreAttachHook  string `json:"pre_attach_hook,omitempty"`
+	PostDetachHook string `json:"post_detach_hook,omitempty"`
+
+	// PreStartHook and PostExitHook are shell commands run in the project
+	// directory before the session starts (e.g. "docker compose up -d") and
+	// after it exits or is stopped. Empty falls back to the tool's
+	// pre_start / post_exit, then to [hooks] (see LifecycleHooks).
+	PreStartHook string `json:"pre_start_hook,omitempty"`
+	PostExitHook string `json:"post_exit_hook,omitempty"`
+
+	// HandoffNote is a one-line "where I left off" note, asked for on detach
+	// when [handoff] prompt_on_detach is on and shown when the session is
+	// next selected. HandoffAt is when it was written.
+	HandoffNote string    `json:"handoff_note,omitempty"`
+	HandoffAt   time.Time `json:"handoff_at,omitempty"`
+
+	// ContextFiles are key files and paths the session is about, shown in
+	// the preview and insertable into prompts
+	ContextFiles []string `json:"context_files,omitempty"`
+
+	// AutoTitle is the title agent-deck generated for the session; while the
+	// title is still this, automatic naming ([auto_name]) may replace it.
+	// AutoTitleFrom is what it was last named from ("prompt", "summary").
+	AutoTitle     string `json:"auto_title,omitempty"`
+	AutoTitleFrom string `json:"auto_title_from,omitempty"`
+
+	// YoloMode overrides a custom tool's dangerous_mode for this session
+	// (nil = the tool's); see LaunchMode
+	YoloMode *bool `json:"yolo_mode,omitempty"`
+
+	// Watch re-triggers the session when files in its project change (a
+	// hook, a prompt); see FileWatcher
+	Watch *FileWatch `json:"watch,omitempty"`
+
+	// Template is the [templates.*] entry the session was created from; its
+	// env and tmux panes apply each time the session starts
+	Template string `json:"template,omitempty"`
+
+	// Env is exported before the session's command (and in its template's
+	// panes), after every config env source: per-project API keys, model
+	// settings and the like
+	Env map[string]string `json:"env,omitempty"`
+
 	tmuxSession *tmux.Session // Internal tmux session
 
 	// mu protects fields written by backgroundStatusUpdate and read by the TUI goroutine.
@@ -105,6 +187,11 @@ type Instance struct {
 	// UpdateStatus() acquires the write lock internally.
 	mu sync.RWMutex
 
+	// opMu serializes Start, Kill and Restart; operation names the one in
+	// progress (guarded by mu) so the TUI can show it and refuse repeats
+	opMu      sync.Mutex
+	operation string
+
 	// lastErrorCheck tracks when we last confirmed the session doesn't exist
 	// Used to skip expensive Exists() checks for ghost sessions (sessions in JSON but not in tmux)
 	// Not serialized - resets on load, but that's fine since we'll recheck on first poll
@@ -114,6 +201,46 @@ type Instance struct {
 	lastIdleCheck     time.Time // When we last did a full check for an idle session
 	lastKnownActivity int64     // Last window_activity timestamp seen
 
+	// checkpointRunning guards against overlapping auto-checkpoints (not serialized)
+	checkpointRunning atomic.Bool
+
+	// Last response summary shown in the session list (not serialized, see summary.go)
+	lastSummary    string
+	lastSummaryAt  time.Time
+	summaryRunning atomic.Bool
+
+	// Closed when the background send of PendingPrompt finishes (nil when
+	// none is in flight, see sendPendingPrompt)
+	promptDone chan struct{}
+
+	// Title the tool set on its tmux pane, refreshed on poll when [tmux]
+	// sync_titles is on (not serialized)
+	paneTitle string
+
+	// Provider rate limit shown in the pane, and the message of the last one
+	// to expire (not serialized, see rate_limit.go)
+	rateLimit        RateLimit
+	rateLimitCleared string
+	rateLimitScanned int64 // window_activity of the pane last scanned for one
+
+	// Status reported by hooks/notify (not serialized, see status_report.go)
+	reportedStatus Status
+	reportedAt     time.Time
+
+	// runningSince is when the agent was first seen running in the current
+	// stretch of running (not serialized, see activity.go)
+	runningSince time.Time
+	// waitingSince is the same for waiting on the user
+	waitingSince time.Time
+
+	// activityRoot is the project path watched for file writes while the
+	// agent runs (not serialized, see file_activity.go)
+	activityRoot string
+
+	// seenAlive is set once UpdateStatus finds the tmux session, so its
+	// disappearing runs the post-exit hook (not serialized, see attach_hooks.go)
+	seenAlive bool
+
 	// lastStartTime tracks when Start() was called
 	// Used to provide grace period for tmux session creation (prevents error flash)
 	// Not serialized - only relevant for current TUI session
@@ -209,6 +336,14 @@ func (inst *Instance) SetParentWithPath(parentID, parentProjectPath string) {
 	inst.ParentProjectPath = parentProjectPath
 }
 
+// GetPaneTitle returns the title the tool set on its tmux pane, "" when
+// [tmux] sync_titles is off or the tool hasn't set one
+func (i *Instance) GetPaneTitle() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.paneTitle
+}
+
 // ClearParent removes the parent session link
 func (inst *Instance) ClearParent() {
 	inst.ParentSessionID = ""
@@ -225,7 +360,7 @@ func NewInstance(title, projectPath string) *Instance {
 		ID:          id,
 		Title:       title,
 		ProjectPath: projectPath,
-		GroupPath:   extractGroupPath(projectPath), // Auto-assign group from path
+		GroupPath:   defaultGroupPath(projectPath), // Auto-assign group from rules or path
 		Tool:        "shell",
 		Status:      StatusIdle,
 		CreatedAt:   time.Now(),
@@ -250,7 +385,7 @@ func NewInstanceWithTool(title, projectPath, tool string) *Instance {
 		ID:          id,
 		Title:       title,
 		ProjectPath: projectPath,
-		GroupPath:   extractGroupPath(projectPath),
+		GroupPath:   defaultGroupPath(projectPath),
 		Tool:        tool,
 		Status:      StatusIdle,
 		CreatedAt:   time.Now(),
@@ -438,6 +573,9 @@ func (i *Instance) buildClaudeExtraFlags(opts *ClaudeOptions) string {
 		if opts.UseChrome {
 			flags = append(flags, "--chrome")
 		}
+		if opts.Model != "" {
+			flags = append(flags, "--model "+opts.Model)
+		}
 	}
 
 	if len(flags) == 0 {
@@ -589,6 +727,14 @@ func (i *Instance) resolveCodexYoloFlag() string {
 	return ""
 }
 
+// codexModelFlag returns " -m <model>" for a model chosen for the session, or ""
+func (i *Instance) codexModelFlag() string {
+	if opts := i.GetCodexOptions(); opts != nil && opts.Model != "" {
+		return " -m " + opts.Model
+	}
+	return ""
+}
+
 // Codex stores sessions in ~/.codex/sessions/YYYY/MM/DD/*.jsonl
 // Resume: codex resume <session-id> or codex resume --last
 // Also sources .env files from [shell].env_files
@@ -599,7 +745,7 @@ func (i *Instance) buildCodexCommand(baseCommand string) string {
 
 	envPrefix := i.buildEnvSourceCommand()
 
-	yoloFlag := i.resolveCodexYoloFlag()
+	yoloFlag := i.resolveCodexYoloFlag() + i.codexModelFlag()
 
 	// If baseCommand is just "codex", handle specially
 	if baseCommand == "codex" {
@@ -740,13 +886,10 @@ func (i *Instance) queryOpenCodeSession() string {
 			sessDir = sess.Path
 		}
 
-		normalizedSessDir := normalizePath(sessDir)
-		normalizedProjectPath := normalizePath(projectPath)
-
 		sessionLog.Debug("opencode_session_compare", slog.String("session_id", sess.ID), slog.String("sess_dir", sessDir), slog.String("project_path", projectPath), slog.Int64("created", sess.Created), slog.Int64("updated", sess.Updated))
 
-		// Normalize both paths for comparison
-		if sessDir == "" || normalizedSessDir != normalizedProjectPath {
+		// Compare canonical paths (symlinks, ~, case on macOS)
+		if sessDir == "" || !SamePath(sessDir, projectPath) {
 			sessionLog.Debug("opencode_session_dir_mismatch", slog.String("session_id", sess.ID))
 			continue
 		}
@@ -769,26 +912,6 @@ func (i *Instance) queryOpenCodeSession() string {
 	return bestMatch
 }
 
-// normalizePath normalizes a file path for comparison
-func normalizePath(p string) string {
-	// Expand home directory
-	if strings.HasPrefix(p, "~") {
-		if home, err := os.UserHomeDir(); err == nil {
-			p = strings.Replace(p, "~", home, 1)
-		}
-	}
-
-	// Clean the path
-	p = filepath.Clean(p)
-
-	// Resolve symlinks if possible
-	if resolved, err := filepath.EvalSymlinks(p); err == nil {
-		p = resolved
-	}
-
-	return p
-}
-
 // DetectCodexSession is the public wrapper for async Codex session detection
 // Call this for restored sessions that don't have a session ID yet
 func (i *Instance) DetectCodexSession() {
@@ -946,6 +1069,7 @@ func (i *Instance) UpdateCodexSession(excludeIDs map[string]bool) {
 //   - output_format_flag: flag to get JSON output (e.g., "--output-format json")
 //   - dangerous_flag: flag to skip confirmations (e.g., "--auto-approve")
 //   - dangerous_mode: whether to enable dangerous flag by default
+//   - safe_flag: flag added instead when dangerous mode is off
 //   - env_file: .env file to source for this tool
 func (i *Instance) buildGenericCommand(baseCommand string) string {
 	envPrefix := i.buildEnvSourceCommand()
@@ -957,11 +1081,8 @@ func (i *Instance) buildGenericCommand(baseCommand string) string {
 
 	// Check if tool supports session resume (needs both resume_flag and session_id_env)
 	if toolDef.ResumeFlag == "" || toolDef.SessionIDEnv == "" {
-		// No session resume support, just add dangerous flag if configured
-		if toolDef.DangerousMode && toolDef.DangerousFlag != "" {
-			return envPrefix + fmt.Sprintf("%s %s", baseCommand, toolDef.DangerousFlag)
-		}
-		return envPrefix + baseCommand
+		// No session resume support, just add the launch mode's flag
+		return envPrefix + baseCommand + i.launchModeFlag(toolDef)
 	}
 
 	// Get existing session ID from tmux environment (for restart/resume)
@@ -972,27 +1093,21 @@ func (i *Instance) buildGenericCommand(baseCommand string) string {
 		}
 	}
 
-	// Build dangerous flag if enabled
-	dangerousFlag := ""
-	if toolDef.DangerousMode && toolDef.DangerousFlag != "" {
-		dangerousFlag = " " + toolDef.DangerousFlag
-	}
+	// Dangerous or safe flag for the session's launch mode
+	modeFlag := i.launchModeFlag(toolDef)
 
 	// If we have an existing session ID, just resume
 	if existingSessionID != "" {
 		return envPrefix + fmt.Sprintf("tmux set-environment %s %s && %s %s %s%s",
 			toolDef.SessionIDEnv, existingSessionID,
-			baseCommand, toolDef.ResumeFlag, existingSessionID, dangerousFlag)
+			baseCommand, toolDef.ResumeFlag, existingSessionID, modeFlag)
 	}
 
 	// No existing session ID - need to capture it on first run
 	// This requires output_format_flag and session_id_json_path
 	if toolDef.OutputFormatFlag == "" || toolDef.SessionIDJsonPath == "" {
 		// Can't capture session ID, just start normally
-		if dangerousFlag != "" {
-			return envPrefix + baseCommand + dangerousFlag
-		}
-		return envPrefix + baseCommand
+		return envPrefix + baseCommand + modeFlag
 	}
 
 	// Build capture-resume command similar to Claude/Gemini
@@ -1010,8 +1125,8 @@ func (i *Instance) buildGenericCommand(baseCommand string) string {
 			`else %s%s; fi`,
 		baseCommand, toolDef.OutputFormatFlag, toolDef.SessionIDJsonPath,
 		toolDef.SessionIDEnv,
-		baseCommand, toolDef.ResumeFlag, dangerousFlag,
-		baseCommand, dangerousFlag)
+		baseCommand, toolDef.ResumeFlag, modeFlag,
+		baseCommand, modeFlag)
 }
 
 // GetGenericSessionID gets session ID from tmux environment for a custom tool
@@ -1045,6 +1160,18 @@ func (i *Instance) CanRestartGeneric() bool {
 }
 
 func (i *Instance) applyWrapper(command string) (string, error) {
+	// The container wraps the tool command; a user wrapper (editor, etc.) stays on the host
+	if i.Container != nil {
+		binary := ""
+		switch i.Tool {
+		case "claude", "gemini", "opencode", "codex":
+			binary = i.Tool
+		}
+		spec := i.Container.clone()
+		spec.passEnv = envKeys(i.sessionEnv())
+		command = spec.WrapCommand(command, binary, i.ProjectPath)
+	}
+
 	wrapper := i.Wrapper
 	if wrapper == "" {
 		if toolDef := GetToolDef(i.Tool); toolDef != nil {
@@ -1060,6 +1187,21 @@ func (i *Instance) applyWrapper(command string) (string, error) {
 	return wrapper, nil
 }
 
+// applyTemplatePanes sets the tmux session's extra panes and layout from the
+// session's [templates.*] entry. The panes inherit the session's env from the
+// tmux session's environment.
+func (i *Instance) applyTemplatePanes() {
+	if i.Template == "" {
+		return
+	}
+	tpl := GetSessionTemplate(i.Template)
+	if tpl == nil {
+		return
+	}
+	i.tmuxSession.ExtraPanes = append([]string(nil), tpl.Panes...)
+	i.tmuxSession.Layout = tpl.Layout
+}
+
 // loadCustomPatternsFromConfig loads detection patterns from built-in defaults + config.toml
 // overrides, and sets them on the tmux session for status detection and tool auto-detection.
 // Works for ALL tools: built-in (claude, gemini, opencode, codex) and custom.
@@ -1086,8 +1228,11 @@ func (i *Instance) loadCustomPatternsFromConfig() {
 	}
 }
 
-// Start starts the session in tmux
-func (i *Instance) Start() error {
+// start starts the session in tmux; see Start
+func (i *Instance) start() error {
+	if IsDemoMode() {
+		return nil
+	}
 	if i.tmuxSession == nil {
 		return fmt.Errorf("tmux session not initialized")
 	}
@@ -1113,7 +1258,7 @@ func (i *Instance) Start() error {
 		if toolDef := GetToolDef(i.Tool); toolDef != nil {
 			command = i.buildGenericCommand(i.Command)
 		} else {
-			command = i.Command
+			command = i.buildRawCommand()
 		}
 	}
 
@@ -1131,6 +1276,12 @@ func (i *Instance) Start() error {
 		i.tmuxSession.OptionOverrides = tmuxCfg.Options
 	}
 
+	// Extra panes and layout from the session's template
+	i.applyTemplatePanes()
+
+	// The session's env goes into the tmux environment, not the command line
+	i.tmuxSession.Env = i.sessionEnv()
+
 	// Start the tmux session
 	if err := i.tmuxSession.Start(command); err != nil {
 		return fmt.Errorf("failed to start tmux session: %w", err)
@@ -1145,6 +1296,9 @@ func (i *Instance) Start() error {
 	// Capture MCPs that are now loaded (for sync tracking)
 	i.CaptureLoadedMCPs()
 
+	// Remember the repo's remote so the checkout can be found if it moves
+	i.recordGitRemote()
+
 	// Record start time for grace period (prevents error flash during tmux startup)
 	i.lastStartTime = time.Now()
 
@@ -1166,14 +1320,104 @@ func (i *Instance) Start() error {
 		go i.detectCodexSessionAsync()
 	}
 
+	i.sendPendingPrompt()
+
 	return nil
 }
 
-// StartWithMessage starts the session and sends an initial message when ready
-// The message is sent synchronously after detecting the agent's prompt
-// This approach is more reliable than embedding send logic in the tmux command
-// Works for Claude, Gemini, OpenCode, and other agents
-func (i *Instance) StartWithMessage(message string) error {
+// GetPendingPrompt returns the queued initial prompt (thread-safe)
+func (i *Instance) GetPendingPrompt() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.PendingPrompt
+}
+
+// clearPendingPrompt drops the queued prompt once it has been delivered,
+// unless another prompt was queued meanwhile
+func (i *Instance) clearPendingPrompt(sent string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.PendingPrompt == sent {
+		i.PendingPrompt = ""
+	}
+}
+
+// sendPendingPrompt delivers the queued initial prompt in the background once
+// the agent is ready for input. The prompt stays queued until it is sent, so
+// a process that exits first leaves it for the next start; callers that save
+// and exit call WaitPendingPrompt first.
+func (i *Instance) sendPendingPrompt() {
+	prompt := i.GetPendingPrompt()
+	if prompt == "" {
+		return
+	}
+	i.mu.Lock()
+	if i.promptDone != nil {
+		// Already on its way (e.g. restarted before the agent was ready)
+		i.mu.Unlock()
+		return
+	}
+	done := make(chan struct{})
+	i.promptDone = done
+	i.mu.Unlock()
+
+	i.AutoNameFromPrompt(i.ExpandPrompt(prompt))
+	go func() {
+		defer func() {
+			i.mu.Lock()
+			i.promptDone = nil
+			i.mu.Unlock()
+			close(done)
+		}()
+		// Held while a rate limit from before a restart still shows
+		i.WaitOutRateLimit(time.Time{})
+		if err := i.sendMessageWhenReady(prompt); err != nil {
+			sessionLog.Warn("pending_prompt_failed", slog.String("id", i.ID), slog.String("error", err.Error()))
+			return
+		}
+		i.clearPendingPrompt(prompt)
+	}()
+}
+
+// WaitPendingPrompt blocks until a queued prompt being sent in the background
+// has been delivered or has failed. Returns true if one was in flight.
+func (i *Instance) WaitPendingPrompt() bool {
+	i.mu.RLock()
+	done := i.promptDone
+	i.mu.RUnlock()
+	if done == nil {
+		return false
+	}
+	<-done
+	return true
+}
+
+// Auto-attach modes (see Instance.AutoAttach)
+const (
+	AutoAttachOff    = ""
+	AutoAttachAlways = "attach" // attach straight away
+	AutoAttachAsk    = "ask"    // show a prompt offering to attach
+)
+
+// ParseAutoAttach parses an auto-attach mode: attach (or on), ask, or off
+func ParseAutoAttach(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case "attach", "on", "true", "yes":
+		return AutoAttachAlways, nil
+	case "ask", "prompt":
+		return AutoAttachAsk, nil
+	case "off", "false", "no", "":
+		return AutoAttachOff, nil
+	default:
+		return "", fmt.Errorf("invalid value: %s (use attach, ask, or off)", value)
+	}
+}
+
+// startWithMessage starts the session and sends message; see StartWithMessage
+func (i *Instance) startWithMessage(message string) error {
+	if IsDemoMode() {
+		return nil
+	}
 	if i.tmuxSession == nil {
 		return fmt.Errorf("tmux session not initialized")
 	}
@@ -1191,7 +1435,7 @@ func (i *Instance) StartWithMessage(message string) error {
 		if toolDef := GetToolDef(i.Tool); toolDef != nil {
 			command = i.buildGenericCommand(i.Command)
 		} else {
-			command = i.Command
+			command = i.buildRawCommand()
 		}
 	}
 
@@ -1209,6 +1453,12 @@ func (i *Instance) StartWithMessage(message string) error {
 		i.tmuxSession.OptionOverrides = tmuxCfg.Options
 	}
 
+	// Extra panes and layout from the session's template
+	i.applyTemplatePanes()
+
+	// The session's env goes into the tmux environment, not the command line
+	i.tmuxSession.Env = i.sessionEnv()
+
 	// Start the tmux session
 	if err := i.tmuxSession.Start(command); err != nil {
 		return fmt.Errorf("failed to start tmux session: %w", err)
@@ -1223,16 +1473,26 @@ func (i *Instance) StartWithMessage(message string) error {
 	// Capture MCPs that are now loaded (for sync tracking)
 	i.CaptureLoadedMCPs()
 
+	// Remember the repo's remote so the checkout can be found if it moves
+	i.recordGitRemote()
+
 	// Record start time for grace period (prevents error flash during tmux startup)
 	i.lastStartTime = time.Now()
 
 	// New sessions start as STARTING
 	i.Status = StatusStarting
 
-	// Send message synchronously (CLI will wait)
+	// Send message synchronously (CLI will wait); a queued prompt stands in
+	// when no message is given and is cleared once it is delivered
 	if message != "" {
 		return i.sendMessageWhenReady(message)
 	}
+	if prompt := i.GetPendingPrompt(); prompt != "" {
+		if err := i.sendMessageWhenReady(prompt); err != nil {
+			return err
+		}
+		i.clearPendingPrompt(prompt)
+	}
 
 	return nil
 }
@@ -1252,6 +1512,7 @@ func (i *Instance) sendMessageWhenReady(message string) error {
 	if i.tmuxSession == nil {
 		return fmt.Errorf("tmux session not initialized")
 	}
+	message = i.ExpandPrompt(message)
 
 	// Track state transitions: we need to see "active" before accepting "waiting"
 	// This ensures we don't send the message during initial startup (false "waiting")
@@ -1312,6 +1573,13 @@ const errorRecheckInterval = 30 * time.Second
 func (i *Instance) UpdateStatus() error {
 	i.mu.Lock()
 	defer i.mu.Unlock()
+	defer i.trackRunning() // Runs before the unlock above
+	defer i.trackFileActivity()
+
+	if IsDemoMode() {
+		i.updateDemoStatus()
+		return nil
+	}
 
 	// Short grace period for tmux initialization (not Claude startup)
 	// Use lastStartTime for accuracy on restarts, fallback to CreatedAt
@@ -1346,7 +1614,9 @@ func (i *Instance) UpdateStatus() error {
 	}
 
 	// Check if tmux session exists
-	if !i.tmuxSession.Exists() {
+	exists := i.tmuxSession.Exists()
+	i.noticeTmuxExists(exists)
+	if !exists {
 		i.Status = StatusError
 		i.lastErrorCheck = time.Now() // Record when we confirmed error
 		return nil
@@ -1368,9 +1638,27 @@ func (i *Instance) UpdateStatus() error {
 	}
 
 	// Release lock for potentially slow tmux calls (GetStatus calls CapturePane)
+	syncTitle := GetTmuxSettings().SyncTitles
+	// The pane is only scanned for a rate limit again once it has changed
+	// (activity 0 means the tmux cache doesn't know)
+	activity := i.tmuxSession.GetCachedWindowActivity()
+	checkRateLimit := i.Tool != "shell" && (activity == 0 || activity != i.rateLimitScanned)
 	i.mu.Unlock()
 	status, err := i.tmuxSession.GetStatus()
+	paneTitle := ""
+	if syncTitle && err == nil {
+		paneTitle = i.tmuxSession.PaneTitle()
+	}
+	// A rate limited agent stops working, so a busy pane isn't scanned
+	content := ""
+	checkRateLimit = checkRateLimit && err == nil && status != "active"
+	if checkRateLimit {
+		var captureErr error
+		content, captureErr = i.tmuxSession.CapturePane()
+		checkRateLimit = captureErr == nil
+	}
 	i.mu.Lock()
+	i.paneTitle = paneTitle
 
 	if err != nil {
 		i.Status = StatusError
@@ -1378,6 +1666,7 @@ func (i *Instance) UpdateStatus() error {
 	}
 
 	// Map tmux status to instance status
+	prevStatus := i.Status
 	switch status {
 	case "active":
 		i.Status = StatusRunning
@@ -1389,6 +1678,30 @@ func (i *Instance) UpdateStatus() error {
 		i.Status = StatusError
 	}
 
+	// A quiet pane while the agent keeps writing project files is still work
+	if prevStatus == StatusRunning && (i.Status == StatusWaiting || i.Status == StatusIdle) && i.filesRecentlyWritten(time.Now()) {
+		i.Status = StatusRunning
+	}
+
+	// A status reported by hooks wins over screen-scraping until the pane changes
+	if i.reportedStatus != "" && i.Status != StatusError {
+		if i.reportedStatusHolds(i.tmuxSession.GetCachedWindowActivity()) {
+			i.Status = i.reportedStatus
+		} else {
+			i.reportedStatus = ""
+		}
+	}
+
+	// Provider rate limit on screen (see rate_limit.go)
+	if i.Status == StatusRunning {
+		i.clearRateLimit()
+	} else if checkRateLimit {
+		i.trackRateLimit(content, time.Now())
+		i.rateLimitScanned = activity
+	} else if i.rateLimit.Line != "" && !time.Now().Before(i.rateLimit.Until) {
+		i.clearRateLimit() // Expired on an unchanged screen
+	}
+
 	// Update tool detection dynamically (enables fork when Claude starts)
 	if detectedTool := i.tmuxSession.DetectTool(); detectedTool != "" {
 		i.Tool = detectedTool
@@ -1410,6 +1723,13 @@ func (i *Instance) UpdateStatus() error {
 		}
 	}
 
+	// Agent finished a burst of work: snapshot its edits (async, best-effort)
+	if prevStatus == StatusRunning && (i.Status == StatusWaiting || i.Status == StatusIdle) {
+		i.maybeAutoCheckpoint()
+	}
+	i.maybeRefreshSummary(prevStatus)
+	i.maybeAutoName()
+
 	return nil
 }
 
@@ -1625,6 +1945,9 @@ func (i *Instance) PostStartSync(maxWait time.Duration) {
 
 // Preview returns the last 3 lines of terminal output
 func (i *Instance) Preview() (string, error) {
+	if IsDemoMode() {
+		return lastLines(i.demoPane(), 3), nil
+	}
 	if i.tmuxSession == nil {
 		return "", fmt.Errorf("tmux session not initialized")
 	}
@@ -1634,16 +1957,23 @@ func (i *Instance) Preview() (string, error) {
 		return "", err
 	}
 
+	return lastLines(content, 3), nil
+}
+
+// lastLines returns the last n lines of content, ignoring trailing blank lines
+func lastLines(content string, n int) string {
 	lines := strings.Split(strings.TrimSpace(content), "\n")
-	if len(lines) > 3 {
-		lines = lines[len(lines)-3:]
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
 	}
-
-	return strings.Join(lines, "\n"), nil
+	return strings.Join(lines, "\n")
 }
 
 // PreviewFull returns all terminal output
 func (i *Instance) PreviewFull() (string, error) {
+	if IsDemoMode() {
+		return i.demoPane(), nil
+	}
 	if i.tmuxSession == nil {
 		return "", fmt.Errorf("tmux session not initialized")
 	}
@@ -2011,6 +2341,12 @@ func (i *Instance) readJSONLTail(path string) string {
 		}
 	}
 
+	if model := parseClaudeLatestModel(data); model != "" {
+		i.detectedModel = model
+	}
+	if summary := parseClaudeSummary(data); summary != "" {
+		i.agentSummary = summary
+	}
 	prompt, err := parseClaudeLatestUserPrompt(data)
 	if err != nil || prompt == "" {
 		// Update cache even on empty result to avoid re-reading
@@ -2262,8 +2598,11 @@ func parseGenericOutput(content, tool string) (*ResponseOutput, error) {
 	}, nil
 }
 
-// Kill terminates the tmux session
-func (i *Instance) Kill() error {
+// kill terminates the tmux session; see Kill
+func (i *Instance) kill() error {
+	if IsDemoMode() {
+		return nil
+	}
 	if i.tmuxSession == nil {
 		return fmt.Errorf("tmux session not initialized")
 	}
@@ -2275,10 +2614,11 @@ func (i *Instance) Kill() error {
 	return nil
 }
 
-// Restart restarts the Claude session
-// For Claude sessions with known ID: sends Ctrl+C twice and resume command to existing session
-// For dead sessions or unknown ID: recreates the tmux session
-func (i *Instance) Restart() error {
+// restart restarts the session; see Restart
+func (i *Instance) restart() error {
+	if IsDemoMode() {
+		return nil
+	}
 	mcpLog.Debug("restart_called", slog.String("tool", i.Tool), slog.String("claude_session_id", i.ClaudeSessionID), slog.Bool("tmux_session", i.tmuxSession != nil), slog.Bool("tmux_exists", i.tmuxSession != nil && i.tmuxSession.Exists()))
 
 	// Clear flag immediately to prevent it staying set if restart fails
@@ -2296,6 +2636,11 @@ func (i *Instance) Restart() error {
 		mcpLog.Debug("mcp_regen_skipped", slog.String("reason", "flag_set_by_apply"))
 	}
 
+	// Respawned panes get the session's current env (see tmux.Session.Env)
+	if i.tmuxSession != nil {
+		i.tmuxSession.Env = i.sessionEnv()
+	}
+
 	// If Claude session with known ID AND tmux session exists, use respawn-pane
 	if i.Tool == "claude" && i.ClaudeSessionID != "" && i.tmuxSession != nil && i.tmuxSession.Exists() {
 		// Build the resume command with proper config
@@ -2364,11 +2709,11 @@ func (i *Instance) Restart() error {
 		var resumeCmd string
 		if i.OpenCodeSessionID != "" {
 			// Resume with known session ID
-			resumeCmd = fmt.Sprintf("tmux set-environment OPENCODE_SESSION_ID %s && opencode -s %s",
-				i.OpenCodeSessionID, i.OpenCodeSessionID)
+			resumeCmd = fmt.Sprintf("tmux set-environment OPENCODE_SESSION_ID %s && opencode -s %s%s",
+				i.OpenCodeSessionID, i.OpenCodeSessionID, i.buildOpenCodeExtraFlags())
 		} else {
 			// No session ID yet, start fresh (will detect ID async)
-			resumeCmd = "opencode"
+			resumeCmd = "opencode" + i.buildOpenCodeExtraFlags()
 			// Re-record start time for async detection
 			i.OpenCodeStartedAt = time.Now().UnixMilli()
 		}
@@ -2410,7 +2755,7 @@ func (i *Instance) Restart() error {
 			}
 		}
 
-		codexYolo := i.resolveCodexYoloFlag()
+		codexYolo := i.resolveCodexYoloFlag() + i.codexModelFlag()
 		var resumeCmd string
 		if i.CodexSessionID != "" {
 			// Resume with known session ID
@@ -2449,16 +2794,9 @@ func (i *Instance) Restart() error {
 		sessionID := i.GetGenericSessionID()
 
 		// Build resume command for custom tool
-		var resumeCmd string
-		if toolDef.DangerousMode && toolDef.DangerousFlag != "" {
-			resumeCmd = fmt.Sprintf("tmux set-environment %s %s && %s %s %s %s",
-				toolDef.SessionIDEnv, sessionID,
-				i.Command, toolDef.ResumeFlag, sessionID, toolDef.DangerousFlag)
-		} else {
-			resumeCmd = fmt.Sprintf("tmux set-environment %s %s && %s %s %s",
-				toolDef.SessionIDEnv, sessionID,
-				i.Command, toolDef.ResumeFlag, sessionID)
-		}
+		resumeCmd := fmt.Sprintf("tmux set-environment %s %s && %s %s %s%s",
+			toolDef.SessionIDEnv, sessionID,
+			i.Command, toolDef.ResumeFlag, sessionID, i.launchModeFlag(toolDef))
 		resumeCmd, err := i.applyWrapper(resumeCmd)
 		if err != nil {
 			return err
@@ -2479,17 +2817,26 @@ func (i *Instance) Restart() error {
 
 	mcpLog.Debug("restart_fallback_recreate")
 
-	// Kill old tmux session to prevent orphans before recreating (#138)
+	// Kill old tmux session to prevent orphans before recreating (#138).
+	// Its end is claimed first: being replaced isn't an exit, so a TUI
+	// watching it doesn't run the post-exit hook.
 	if i.tmuxSession != nil && i.tmuxSession.Exists() {
 		mcpLog.Debug("restart_killing_old_session", slog.String("session_name", i.tmuxSession.Name))
+		claimExitHook(i.tmuxSession.Name)
 		if killErr := i.tmuxSession.Kill(); killErr != nil {
 			mcpLog.Warn("restart_kill_old_session_failed", slog.String("error", killErr.Error()))
 		}
 	}
 
-	// Fallback: recreate tmux session (for dead sessions or unknown ID)
+	// Fallback: recreate tmux session (for dead sessions or unknown ID),
+	// on the same tmux server as before
+	socket := tmux.SocketName()
+	if i.tmuxSession != nil {
+		socket = i.tmuxSession.SocketName
+	}
 	i.tmuxSession = tmux.NewSession(i.Title, i.ProjectPath)
 	i.tmuxSession.InstanceID = i.ID // Pass instance ID for activity hooks
+	i.tmuxSession.SocketName = socket
 
 	var command string
 	if i.Tool == "claude" && i.ClaudeSessionID != "" {
@@ -2498,12 +2845,12 @@ func (i *Instance) Restart() error {
 		command = i.buildGeminiCommand("gemini")
 	} else if i.Tool == "opencode" && i.OpenCodeSessionID != "" {
 		// Set OPENCODE_SESSION_ID in tmux env so detection works after restart
-		command = fmt.Sprintf("tmux set-environment OPENCODE_SESSION_ID %s && opencode -s %s",
-			i.OpenCodeSessionID, i.OpenCodeSessionID)
+		command = fmt.Sprintf("tmux set-environment OPENCODE_SESSION_ID %s && opencode -s %s%s",
+			i.OpenCodeSessionID, i.OpenCodeSessionID, i.buildOpenCodeExtraFlags())
 	} else if i.Tool == "codex" && i.CodexSessionID != "" {
 		// Set CODEX_SESSION_ID in tmux env so detection works after restart
 		command = fmt.Sprintf("tmux set-environment CODEX_SESSION_ID %s; codex%s resume %s",
-			i.CodexSessionID, i.resolveCodexYoloFlag(), i.CodexSessionID)
+			i.CodexSessionID, i.resolveCodexYoloFlag()+i.codexModelFlag(), i.CodexSessionID)
 	} else {
 		// Route to appropriate command builder based on tool
 		switch i.Tool {
@@ -2524,7 +2871,7 @@ func (i *Instance) Restart() error {
 			if toolDef := GetToolDef(i.Tool); toolDef != nil {
 				command = i.buildGenericCommand(i.Command)
 			} else {
-				command = i.Command
+				command = i.buildRawCommand()
 			}
 		}
 	}
@@ -2541,6 +2888,12 @@ func (i *Instance) Restart() error {
 		i.tmuxSession.OptionOverrides = tmuxCfg.Options
 	}
 
+	// Extra panes and layout from the session's template
+	i.applyTemplatePanes()
+
+	// The session's env goes into the tmux environment, not the command line
+	i.tmuxSession.Env = i.sessionEnv()
+
 	mcpLog.Debug("restart_starting_new_session", slog.String("command", command))
 
 	if err := i.tmuxSession.Start(command); err != nil {
@@ -2570,6 +2923,8 @@ func (i *Instance) Restart() error {
 		go i.detectCodexSessionAsync()
 	}
 
+	i.sendPendingPrompt()
+
 	// Start as WAITING - will go GREEN on next tick if Claude shows busy indicator
 	if command != "" {
 		i.Status = StatusWaiting
@@ -2619,6 +2974,9 @@ func (i *Instance) buildClaudeResumeCommand() string {
 	} else if allowDangerousMode {
 		dangerousFlag = " --allow-dangerously-skip-permissions"
 	}
+	if opts.Model != "" {
+		dangerousFlag += " --model " + opts.Model
+	}
 
 	// Build the command with tmux environment update
 	// This ensures CLAUDE_SESSION_ID is set in tmux env after restart,
@@ -2732,6 +3090,7 @@ func (i *Instance) ForkWithOptions(newTitle, newGroupPath string, opts *ClaudeOp
 	if !i.CanFork() {
 		return "", fmt.Errorf("cannot fork: no active Claude session")
 	}
+	opts = i.forkClaudeOptions(opts)
 
 	workDir := i.ProjectPath
 	if opts != nil && opts.WorkDir != "" {
@@ -2793,6 +3152,7 @@ func (i *Instance) CreateForkedInstance(newTitle, newGroupPath string) (*Instanc
 
 // CreateForkedInstanceWithOptions creates a new Instance configured for forking with custom options
 func (i *Instance) CreateForkedInstanceWithOptions(newTitle, newGroupPath string, opts *ClaudeOptions) (*Instance, string, error) {
+	opts = i.forkClaudeOptions(opts) // Keep the parent's model
 	cmd, err := i.ForkWithOptions(newTitle, newGroupPath, opts)
 	if err != nil {
 		return nil, "", err
@@ -2811,6 +3171,8 @@ func (i *Instance) CreateForkedInstanceWithOptions(newTitle, newGroupPath string
 	}
 	forked.Command = cmd
 	forked.Tool = "claude"
+	forked.Container = i.Container.clone() // Same environment as the parent
+	forked.Env = maps.Clone(i.Env)
 
 	// Store options in the new instance for persistence
 	if opts != nil {
@@ -2844,6 +3206,7 @@ func (i *Instance) ForkOpenCodeWithOptions(newTitle, newGroupPath string, opts *
 	if !i.CanForkOpenCode() {
 		return "", fmt.Errorf("cannot fork: no active OpenCode session")
 	}
+	opts = i.forkOpenCodeOptions(opts)
 
 	workDir := i.ProjectPath
 	envPrefix := i.buildEnvSourceCommand()
@@ -2928,6 +3291,7 @@ func (i *Instance) CreateForkedOpenCodeInstance(newTitle, newGroupPath string) (
 
 // CreateForkedOpenCodeInstanceWithOptions creates a new Instance configured for forking with custom options
 func (i *Instance) CreateForkedOpenCodeInstanceWithOptions(newTitle, newGroupPath string, opts *OpenCodeOptions) (*Instance, string, error) {
+	opts = i.forkOpenCodeOptions(opts) // Keep the parent's model
 	cmd, err := i.ForkOpenCodeWithOptions(newTitle, newGroupPath, opts)
 	if err != nil {
 		return nil, "", err
@@ -2941,6 +3305,8 @@ func (i *Instance) CreateForkedOpenCodeInstanceWithOptions(newTitle, newGroupPat
 	}
 	forked.Command = cmd
 	forked.Tool = "opencode"
+	forked.Container = i.Container.clone()
+	forked.Env = maps.Clone(i.Env)
 
 	// Store options in the new instance for persistence
 	if opts != nil {
@@ -2954,6 +3320,9 @@ func (i *Instance) CreateForkedOpenCodeInstanceWithOptions(newTitle, newGroupPat
 
 // Exists checks if the tmux session still exists
 func (i *Instance) Exists() bool {
+	if IsDemoMode() {
+		return true
+	}
 	if i.tmuxSession == nil {
 		return false
 	}
@@ -2965,6 +3334,14 @@ func (i *Instance) GetTmuxSession() *tmux.Session {
 	return i.tmuxSession
 }
 
+// TmuxName returns the tmux session name, or "" when there is none
+func (i *Instance) TmuxName() string {
+	if i.tmuxSession == nil {
+		return ""
+	}
+	return i.tmuxSession.Name
+}
+
 // SetAcknowledgedFromShared applies an acknowledgment from another TUI instance
 // (read from SQLite). This transitions a YELLOW (waiting) session to GRAY (idle)
 // without requiring the user to interact with this specific TUI instance.
@@ -0,0 +1,115 @@
+package session
+
+import "io"
+
+// Operations that change whether a session's tmux session runs. Only one
+// runs at a time per session; Operation reports it while in progress.
+const (
+	OpStarting   = "starting"
+	OpKilling    = "killing"
+	OpRestarting = "restarting"
+)
+
+// Start starts the session in tmux, after its pre-start hook
+func (i *Instance) Start() error {
+	defer i.beginOperation(OpStarting)()
+	if err := i.RunPreStartHook(); err != nil {
+		return err
+	}
+	return i.start()
+}
+
+// StartWithMessage starts the session and sends an initial message when ready
+// The message is sent synchronously after detecting the agent's prompt
+// This approach is more reliable than embedding send logic in the tmux command
+// Works for Claude, Gemini, OpenCode, and other agents
+func (i *Instance) StartWithMessage(message string) error {
+	defer i.beginOperation(OpStarting)()
+	if err := i.RunPreStartHook(); err != nil {
+		return err
+	}
+	return i.startWithMessage(message)
+}
+
+// Kill terminates the tmux session, then runs its post-exit hook. The end
+// is claimed first (see claimExitHook), so a TUI that sees the session go
+// doesn't run the hook again.
+func (i *Instance) Kill() error {
+	defer i.beginOperation(OpKilling)()
+	name := i.TmuxName()
+	runHook := name == "" || claimExitHook(name)
+	if err := i.kill(); err != nil {
+		if runHook && name != "" {
+			releaseExitHook(name)
+		}
+		return err
+	}
+	i.mu.Lock()
+	i.seenAlive = false
+	i.mu.Unlock()
+	if runHook {
+		_ = i.RunPostExitHook(io.Discard)
+	}
+	return nil
+}
+
+// Restart restarts the Claude session
+// For Claude sessions with known ID: sends Ctrl+C twice and resume command to existing session
+// For dead sessions or unknown ID: recreates the tmux session, after the
+// pre-start hook
+func (i *Instance) Restart() error {
+	defer i.beginOperation(OpRestarting)()
+	if i.tmuxSession == nil || !i.tmuxSession.Exists() {
+		if err := i.RunPreStartHook(); err != nil {
+			return err
+		}
+	}
+	return i.restart()
+}
+
+// Operation returns the start, kill or restart in progress ("starting",
+// "killing", "restarting"), claimed or running, or "" when there is none
+func (i *Instance) Operation() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.operation
+}
+
+// ClaimOperation marks op as in progress before it is dispatched, so a
+// repeated key press can be refused while the first is still queued. It
+// returns false if another operation is already claimed or running; the
+// claim ends when the Start, Kill or Restart it was made for returns.
+func (i *Instance) ClaimOperation(op string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.operation != "" {
+		return false
+	}
+	i.operation = op
+	return true
+}
+
+// ReleaseOperation ends a claim of op that turned out to need no Start, Kill
+// or Restart, e.g. an undo whose tmux session is still running
+func (i *Instance) ReleaseOperation(op string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.operation == op {
+		i.operation = ""
+	}
+}
+
+// beginOperation waits for any other operation on the session to finish,
+// marks op as in progress and returns the func that ends it
+func (i *Instance) beginOperation(op string) func() {
+	i.opMu.Lock()
+	i.mu.Lock()
+	i.operation = op
+	i.mu.Unlock()
+	return func() {
+		i.mu.Lock()
+		i.operation = ""
+		i.mu.Unlock()
+		i.opMu.Unlock()
+	}
+}
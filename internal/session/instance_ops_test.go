@@ -0,0 +1,67 @@
+package session
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClaimOperation(t *testing.T) {
+	inst := NewInstance("ops", "/tmp/ops")
+	if !inst.ClaimOperation(OpRestarting) {
+		t.Fatal("first claim refused")
+	}
+	if inst.ClaimOperation(OpKilling) {
+		t.Error("second claim accepted while restarting")
+	}
+	if got := inst.Operation(); got != OpRestarting {
+		t.Errorf("Operation() = %q, want %q", got, OpRestarting)
+	}
+
+	// The claim ends when the operation returns, even if it fails
+	inst.tmuxSession = nil
+	if err := inst.Kill(); err == nil {
+		t.Fatal("Kill without a tmux session succeeded")
+	}
+	if got := inst.Operation(); got != "" {
+		t.Errorf("Operation() after Kill = %q, want none", got)
+	}
+	if !inst.ClaimOperation(OpStarting) {
+		t.Error("claim refused after the operation finished")
+	}
+
+	// A claim that needed no operation is released, but only for its op
+	inst.ReleaseOperation(OpKilling)
+	if got := inst.Operation(); got != OpStarting {
+		t.Errorf("Operation() after releasing another op = %q, want %q", got, OpStarting)
+	}
+	inst.ReleaseOperation(OpStarting)
+	if got := inst.Operation(); got != "" {
+		t.Errorf("Operation() after release = %q, want none", got)
+	}
+}
+
+func TestOperationsSerialize(t *testing.T) {
+	inst := NewInstance("ops", "/tmp/ops")
+	end := inst.beginOperation(OpStarting)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer inst.beginOperation(OpKilling)()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("second operation ran while the first was in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+	end()
+	wg.Wait()
+	if got := inst.Operation(); got != "" {
+		t.Errorf("Operation() = %q, want none", got)
+	}
+}
@@ -1756,3 +1756,41 @@ func TestBuildClaudeExtraFlags_NilOpts(t *testing.T) {
 		t.Errorf("nil opts should not add permission flags, got %q", flags)
 	}
 }
+
+func TestParseAutoAttach(t *testing.T) {
+	tests := map[string]string{
+		"attach": AutoAttachAlways,
+		"on":     AutoAttachAlways,
+		"ask":    AutoAttachAsk,
+		"off":    AutoAttachOff,
+		"":       AutoAttachOff,
+	}
+	for in, want := range tests {
+		got, err := ParseAutoAttach(in)
+		if err != nil || got != want {
+			t.Errorf("ParseAutoAttach(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseAutoAttach("sometimes"); err == nil {
+		t.Error("unknown mode should be an error")
+	}
+}
+
+func TestPendingPromptClearedOnlyWhenSent(t *testing.T) {
+	inst := NewInstance("queued", "/tmp")
+	inst.PendingPrompt = "first"
+	if inst.WaitPendingPrompt() {
+		t.Error("WaitPendingPrompt should return false with nothing in flight")
+	}
+
+	// A prompt queued while the first was being sent is kept
+	inst.PendingPrompt = "second"
+	inst.clearPendingPrompt("first")
+	if got := inst.GetPendingPrompt(); got != "second" {
+		t.Errorf("PendingPrompt = %q, want second", got)
+	}
+	inst.clearPendingPrompt("second")
+	if got := inst.GetPendingPrompt(); got != "" {
+		t.Errorf("PendingPrompt = %q after delivery, want empty", got)
+	}
+}
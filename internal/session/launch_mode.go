@@ -0,0 +1,117 @@
+package session
+
+import "fmt"
+
+// Launch modes: whether a tool starts with its approval prompts (safe) or
+// with them skipped (yolo)
+const (
+	LaunchModeSafe = "safe"
+	LaunchModeYolo = "yolo"
+)
+
+// SupportsLaunchMode reports whether tool has safe and yolo variants: the
+// built-in agents with a skip-approvals flag and custom tools that set
+// dangerous_flag
+func SupportsLaunchMode(tool string) bool {
+	switch tool {
+	case "claude", "gemini", "codex":
+		return true
+	}
+	def := GetToolDef(tool)
+	return def != nil && def.DangerousFlag != ""
+}
+
+// DefaultYoloMode is whether tool starts in yolo mode unless a session says
+// otherwise: [claude] dangerous_mode, [gemini]/[codex] yolo_mode or the
+// custom tool's dangerous_mode
+func DefaultYoloMode(tool string) bool {
+	config, _ := LoadUserConfig()
+	switch tool {
+	case "claude":
+		return config != nil && config.Claude.GetDangerousMode()
+	case "gemini":
+		return config != nil && config.Gemini.YoloMode
+	case "codex":
+		return config != nil && config.Codex.YoloMode
+	}
+	def := GetToolDef(tool)
+	return def != nil && def.DangerousMode
+}
+
+// LaunchMode returns the mode the session starts in, "" for tools without
+// variants (shells, custom tools without dangerous_flag)
+func (i *Instance) LaunchMode() string {
+	if !SupportsLaunchMode(i.Tool) {
+		return ""
+	}
+	if i.yoloMode() {
+		return LaunchModeYolo
+	}
+	return LaunchModeSafe
+}
+
+// yoloMode resolves the session's choice against the tool's default
+func (i *Instance) yoloMode() bool {
+	switch i.Tool {
+	case "claude":
+		if opts := i.GetClaudeOptions(); opts != nil {
+			return opts.SkipPermissions
+		}
+	case "gemini":
+		if i.GeminiYoloMode != nil {
+			return *i.GeminiYoloMode
+		}
+	case "codex":
+		if opts := i.GetCodexOptions(); opts != nil && opts.YoloMode != nil {
+			return *opts.YoloMode
+		}
+	default:
+		if i.YoloMode != nil {
+			return *i.YoloMode
+		}
+	}
+	return DefaultYoloMode(i.Tool)
+}
+
+// SetLaunchMode chooses safe or yolo for the session; it applies from the
+// next start or restart
+func (i *Instance) SetLaunchMode(yolo bool) error {
+	if !SupportsLaunchMode(i.Tool) {
+		return fmt.Errorf("%s has no safe/yolo launch modes (custom tools need dangerous_flag)", i.Tool)
+	}
+	switch i.Tool {
+	case "claude":
+		opts := i.GetClaudeOptions()
+		if opts == nil {
+			config, _ := LoadUserConfig()
+			opts = NewClaudeOptions(config)
+		}
+		opts.SkipPermissions = yolo
+		return i.SetClaudeOptions(opts)
+	case "gemini":
+		i.GeminiYoloMode = &yolo
+	case "codex":
+		opts := i.GetCodexOptions()
+		if opts == nil {
+			opts = &CodexOptions{}
+		}
+		opts.YoloMode = &yolo
+		return i.SetCodexOptions(opts)
+	default:
+		i.YoloMode = &yolo
+	}
+	return nil
+}
+
+// launchModeFlag returns the custom tool's flag for the session's mode,
+// with a leading space, or ""
+func (i *Instance) launchModeFlag(def *ToolDef) string {
+	flag := def.SafeFlag
+	if def.DangerousFlag != "" && i.yoloMode() {
+		flag = def.DangerousFlag
+	}
+	if flag == "" {
+		return ""
+	}
+	return " " + flag
+}
@@ -0,0 +1,96 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLaunchMode(t *testing.T) {
+	dangerous := true
+	userConfigCacheMu.Lock()
+	origCache := userConfigCache
+	userConfigCache = &UserConfig{
+		Claude: ClaudeSettings{DangerousMode: &dangerous},
+		Tools: map[string]ToolDef{
+			"agentx": {Command: "agentx", DangerousFlag: "--auto-approve", SafeFlag: "--ask"},
+			"plain":  {Command: "plain"},
+		},
+	}
+	userConfigCacheMu.Unlock()
+	defer func() {
+		userConfigCacheMu.Lock()
+		userConfigCache = origCache
+		userConfigCacheMu.Unlock()
+	}()
+
+	tests := []struct {
+		tool string
+		want string
+	}{
+		{"claude", LaunchModeYolo}, // [claude] dangerous_mode
+		{"codex", LaunchModeSafe},
+		{"agentx", LaunchModeSafe}, // dangerous_mode unset
+		{"plain", ""},              // no dangerous_flag
+		{"shell", ""},
+	}
+	for _, tt := range tests {
+		inst := &Instance{Tool: tt.tool}
+		if got := inst.LaunchMode(); got != tt.want {
+			t.Errorf("LaunchMode(%s) = %q, want %q", tt.tool, got, tt.want)
+		}
+	}
+
+	for _, tool := range []string{"claude", "gemini", "codex", "agentx"} {
+		inst := &Instance{Tool: tool}
+		for _, yolo := range []bool{true, false} {
+			if err := inst.SetLaunchMode(yolo); err != nil {
+				t.Fatalf("SetLaunchMode(%s, %v): %v", tool, yolo, err)
+			}
+			want := LaunchModeSafe
+			if yolo {
+				want = LaunchModeYolo
+			}
+			if got := inst.LaunchMode(); got != want {
+				t.Errorf("%s after SetLaunchMode(%v) = %q, want %q", tool, yolo, got, want)
+			}
+		}
+	}
+	if err := (&Instance{Tool: "plain"}).SetLaunchMode(true); err == nil {
+		t.Error("SetLaunchMode on a tool without dangerous_flag should fail")
+	}
+
+	inst := &Instance{Tool: "agentx", Command: "agentx"}
+	if cmd := inst.buildGenericCommand("agentx"); !strings.HasSuffix(cmd, "agentx --ask") {
+		t.Errorf("safe command = %q, want the safe flag", cmd)
+	}
+	_ = inst.SetLaunchMode(true)
+	if cmd := inst.buildGenericCommand("agentx"); !strings.HasSuffix(cmd, "agentx --auto-approve") {
+		t.Errorf("yolo command = %q, want the dangerous flag", cmd)
+	}
+}
+
+func TestYoloSandbox(t *testing.T) {
+	yolo, safe := true, false
+	settings := YoloSandboxSettings{Image: "agents:latest"}
+	if got := settings.GetMode(); got != "ask" {
+		t.Errorf("GetMode() = %q, want ask by default", got)
+	}
+	if got := (YoloSandboxSettings{Mode: "always"}).GetMode(); got != "off" {
+		t.Errorf("GetMode() without image = %q, want off", got)
+	}
+
+	spec := settings.SandboxFor(&Instance{Tool: "gemini", GeminiYoloMode: &yolo})
+	if spec == nil || spec.String() != "image:agents:latest" {
+		t.Errorf("SandboxFor(yolo) = %v, want image:agents:latest", spec)
+	}
+	if spec := settings.SandboxFor(&Instance{Tool: "gemini", GeminiYoloMode: &safe}); spec != nil {
+		t.Errorf("SandboxFor(safe) = %v, want nil", spec)
+	}
+	inContainer := &Instance{Tool: "gemini", GeminiYoloMode: &yolo, Container: &ContainerSpec{Kind: ContainerCompose, Target: "app"}}
+	if spec := settings.SandboxFor(inContainer); spec != nil {
+		t.Errorf("SandboxFor(already in a container) = %v, want nil", spec)
+	}
+	if !settings.IsSandbox(spec) || settings.IsSandbox(inContainer.Container) || settings.IsSandbox(nil) {
+		t.Error("IsSandbox should only match the sandbox image")
+	}
+}
@@ -0,0 +1,225 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// shortFlagModelTools take -m as the short form of --model; for others
+// (aider, claude) -m means something else
+var shortFlagModelTools = map[string]bool{
+	"codex":    true,
+	"gemini":   true,
+	"opencode": true,
+}
+
+// ModelFromCommand returns the model a command line asks for with --model
+// (or -m for codex, gemini and opencode), or "" when it doesn't say
+func ModelFromCommand(tool, command string) string {
+	fields := strings.Fields(command)
+	for n, f := range fields {
+		if v, ok := strings.CutPrefix(f, "--model="); ok {
+			return strings.Trim(v, `"'`)
+		}
+		if (f == "--model" || (f == "-m" && shortFlagModelTools[tool])) && n+1 < len(fields) {
+			return strings.Trim(fields[n+1], `"'`)
+		}
+	}
+	return ""
+}
+
+// modelDateSuffix is the release date on model IDs like claude-sonnet-4-5-20250929
+var modelDateSuffix = regexp.MustCompile(`-\d{8}$`)
+
+// ShortModelName trims a model ID for display: the provider prefix, "claude-"
+// and a date suffix, so "anthropic/claude-sonnet-4-5-20250929" is "sonnet-4-5"
+func ShortModelName(model string) string {
+	if n := strings.LastIndexByte(model, '/'); n >= 0 {
+		model = model[n+1:]
+	}
+	model = strings.TrimPrefix(model, "claude-")
+	return modelDateSuffix.ReplaceAllString(model, "")
+}
+
+// defaultModelChoices are offered by the model switcher for tools without a
+// [models] list; Gemini's come from its API
+var defaultModelChoices = map[string][]string{
+	"claude": {"opus", "sonnet", "haiku"},
+	"codex":  {"gpt-5-codex", "gpt-5"},
+}
+
+// SupportsModelSwitch reports whether SetModel can change a tool's model
+func SupportsModelSwitch(tool string) bool {
+	switch tool {
+	case "claude", "codex", "gemini", "opencode":
+		return true
+	}
+	return false
+}
+
+// GetModelChoices returns the models to offer for tool: the [models] list,
+// else the built-in one (Gemini: its API, with a fallback list on error)
+func GetModelChoices(tool string) ([]string, error) {
+	if config, err := LoadUserConfig(); err == nil && config != nil && len(config.Models[tool]) > 0 {
+		return config.Models[tool], nil
+	}
+	if tool == "gemini" {
+		return GetAvailableGeminiModels()
+	}
+	return defaultModelChoices[tool], nil
+}
+
+// Model returns the model the session uses: the one seen in the tool's
+// transcript, else the one chosen for it (SetModel, the Gemini model,
+// --model in its command), or "" when neither says
+func (i *Instance) Model() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.detectedModel != "" {
+		return i.detectedModel
+	}
+	if model := i.chosenModel(); model != "" {
+		return model
+	}
+	return ModelFromCommand(i.Tool, i.Command)
+}
+
+// chosenModel returns the model set in the session's launch options
+func (i *Instance) chosenModel() string {
+	switch i.Tool {
+	case "gemini":
+		return i.GeminiModel
+	case "claude":
+		if opts := i.GetClaudeOptions(); opts != nil {
+			return opts.Model
+		}
+	case "codex":
+		if opts := i.GetCodexOptions(); opts != nil {
+			return opts.Model
+		}
+	case "opencode":
+		if opts := i.GetOpenCodeOptions(); opts != nil {
+			return opts.Model
+		}
+	}
+	return ""
+}
+
+// SetModel switches the session to model, keeping everything else, and
+// restarts it if running so the agent picks it up (resuming its
+// conversation where the tool supports that). Sessions running a custom
+// command keep it, so their model can't be switched here.
+func (i *Instance) SetModel(model string) error {
+	if !SupportsModelSwitch(i.Tool) {
+		return fmt.Errorf("switching models isn't supported for %s sessions", i.Tool)
+	}
+	if i.Tool != "gemini" && i.Command != "" && i.Command != i.Tool {
+		return fmt.Errorf("'%s' runs a custom command; edit it to change the model", i.Title)
+	}
+
+	config, _ := LoadUserConfig()
+	var err error
+	switch i.Tool {
+	case "gemini":
+		i.GeminiModel = model
+	case "claude":
+		opts := i.GetClaudeOptions()
+		if opts == nil {
+			opts = NewClaudeOptions(config)
+		}
+		opts.Model = model
+		err = i.SetClaudeOptions(opts)
+	case "codex":
+		opts := i.GetCodexOptions()
+		if opts == nil {
+			opts = NewCodexOptions(config)
+		}
+		opts.Model = model
+		err = i.SetCodexOptions(opts)
+	case "opencode":
+		opts := i.GetOpenCodeOptions()
+		if opts == nil {
+			opts = NewOpenCodeOptions(config)
+		}
+		opts.Model = model
+		err = i.SetOpenCodeOptions(opts)
+	}
+	if err != nil {
+		return err
+	}
+	i.mu.Lock()
+	i.detectedModel = "" // until the transcript shows the new model
+	i.mu.Unlock()
+	sessionLog.Info("model_set", slog.String("model", model), slog.String("session_id", i.ID), slog.String("title", i.Title))
+
+	if i.Exists() {
+		return i.Restart()
+	}
+	return nil
+}
+
+// forkClaudeOptions returns the options for a Claude fork of i: the model
+// chosen for i (SetModel) carries over unless opts names one. opts itself is
+// left unchanged.
+func (i *Instance) forkClaudeOptions(opts *ClaudeOptions) *ClaudeOptions {
+	parent := i.GetClaudeOptions()
+	if parent == nil || parent.Model == "" || (opts != nil && opts.Model != "") {
+		return opts
+	}
+	if opts == nil {
+		config, _ := LoadUserConfig()
+		opts = NewClaudeOptions(config)
+	} else {
+		copied := *opts
+		opts = &copied
+	}
+	opts.Model = parent.Model
+	return opts
+}
+
+// forkOpenCodeOptions is forkClaudeOptions for OpenCode forks. The model
+// chosen for i also beats [opencode] default_model.
+func (i *Instance) forkOpenCodeOptions(opts *OpenCodeOptions) *OpenCodeOptions {
+	parent := i.GetOpenCodeOptions()
+	if parent == nil || parent.Model == "" || (opts != nil && opts.Model != "") {
+		return opts
+	}
+	if opts == nil {
+		config, _ := LoadUserConfig()
+		opts = NewOpenCodeOptions(config)
+	} else {
+		copied := *opts
+		opts = &copied
+	}
+	opts.Model = parent.Model
+	return opts
+}
+
+// parseClaudeLatestModel returns the model of the last assistant turn in
+// Claude JSONL data
+func parseClaudeLatestModel(data []byte) string {
+	var latest string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !bytes.Contains(line, []byte(`"model"`)) {
+			continue
+		}
+		var entry jsonlEntry
+		if err := json.Unmarshal(line, &entry); err != nil || entry.Type != "assistant" {
+			continue
+		}
+		// Errors and interruptions are logged as model "<synthetic>"
+		if m := entry.Message.Model; m != "" && !strings.HasPrefix(m, "<") {
+			latest = m
+		}
+	}
+	return latest
+}
@@ -0,0 +1,118 @@
+package session
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestModelFromCommand(t *testing.T) {
+	tests := []struct {
+		tool, command, want string
+	}{
+		{"claude", "claude --model opus", "opus"},
+		{"claude", "claude --model=claude-sonnet-4-5-20250929 --verbose", "claude-sonnet-4-5-20250929"},
+		{"codex", "codex -m gpt-5-codex --yolo", "gpt-5-codex"},
+		{"gemini", `gemini -m "gemini-2.5-pro"`, "gemini-2.5-pro"},
+		{"aider", "aider -m 'fix the tests'", ""},
+		{"claude", "claude", ""},
+		{"claude", "claude --model", ""},
+	}
+	for _, tt := range tests {
+		if got := ModelFromCommand(tt.tool, tt.command); got != tt.want {
+			t.Errorf("ModelFromCommand(%q, %q) = %q, want %q", tt.tool, tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestShortModelName(t *testing.T) {
+	tests := map[string]string{
+		"claude-opus-4-1-20250805":             "opus-4-1",
+		"anthropic/claude-sonnet-4-5-20250929": "sonnet-4-5",
+		"models/gemini-2.5-pro":                "gemini-2.5-pro",
+		"gpt-5-codex":                          "gpt-5-codex",
+		"opus":                                 "opus",
+	}
+	for in, want := range tests {
+		if got := ShortModelName(in); got != want {
+			t.Errorf("ShortModelName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseClaudeLatestModel(t *testing.T) {
+	data := []byte(`{"type":"user","message":{"role":"user","content":"hi"}}
+{"type":"assistant","message":{"model":"claude-sonnet-4-5-20250929","content":[]}}
+{"type":"assistant","message":{"model":"claude-opus-4-1-20250805","content":[]}}
+{"type":"assistant","message":{"model":"<synthetic>","content":[]}}
+`)
+	if got := parseClaudeLatestModel(data); got != "claude-opus-4-1-20250805" {
+		t.Errorf("parseClaudeLatestModel() = %q", got)
+	}
+}
+
+func TestInstanceModel(t *testing.T) {
+	inst := &Instance{Tool: "claude", Command: "claude --model opus"}
+	if got := inst.Model(); got != "opus" {
+		t.Errorf("from command: %q", got)
+	}
+	inst.detectedModel = "claude-opus-4-1-20250805"
+	if got := inst.Model(); got != "claude-opus-4-1-20250805" {
+		t.Errorf("transcript should win: %q", got)
+	}
+
+	gemini := &Instance{Tool: "gemini", GeminiModel: "gemini-2.5-flash"}
+	if got := gemini.Model(); got != "gemini-2.5-flash" {
+		t.Errorf("gemini: %q", got)
+	}
+}
+
+func TestSetModel(t *testing.T) {
+	origHome := os.Getenv("HOME")
+	os.Setenv("HOME", t.TempDir())
+	ClearUserConfigCache()
+	t.Cleanup(func() {
+		os.Setenv("HOME", origHome)
+		ClearUserConfigCache()
+	})
+
+	if got, _ := GetModelChoices("claude"); len(got) == 0 || got[0] != "opus" {
+		t.Errorf("GetModelChoices(claude) = %v", got)
+	}
+
+	inst := NewInstanceWithTool("model-test", t.TempDir(), "claude")
+	if err := inst.SetModel("sonnet"); err != nil {
+		t.Fatalf("SetModel() error = %v", err)
+	}
+	if got := inst.Model(); got != "sonnet" {
+		t.Errorf("Model() = %q, want sonnet", got)
+	}
+	if opts := inst.GetClaudeOptions(); opts == nil || opts.Model != "sonnet" {
+		t.Errorf("claude options = %+v", opts)
+	}
+
+	// Forks keep the model
+	inst.ClaudeSessionID = "abc-123"
+	inst.ClaudeDetectedAt = time.Now()
+	forked, cmd, err := inst.CreateForkedInstance("model-fork", "")
+	if err != nil {
+		t.Fatalf("CreateForkedInstance() error = %v", err)
+	}
+	if got := forked.Model(); got != "sonnet" {
+		t.Errorf("fork Model() = %q, want sonnet", got)
+	}
+	if !strings.Contains(cmd, "--model sonnet") {
+		t.Errorf("fork command = %q, want --model sonnet", cmd)
+	}
+
+	custom := NewInstanceWithTool("custom", t.TempDir(), "claude")
+	custom.Command = "claude --model opus --verbose"
+	if err := custom.SetModel("sonnet"); err == nil {
+		t.Error("SetModel() on a custom command should fail")
+	}
+	shell := NewInstanceWithTool("shell", t.TempDir(), "shell")
+	if err := shell.SetModel("sonnet"); err == nil {
+		t.Error("SetModel() on a shell session should fail")
+	}
+}
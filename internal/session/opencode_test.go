@@ -122,10 +122,7 @@ func findBestOpenCodeSession(sessions []struct {
 			sessDir = sess.Path
 		}
 
-		normalizedSessDir := normalizePath(sessDir)
-		normalizedProjectPath := normalizePath(projectPath)
-
-		if sessDir == "" || normalizedSessDir != normalizedProjectPath {
+		if sessDir == "" || !SamePath(sessDir, projectPath) {
 			continue
 		}
 
@@ -0,0 +1,65 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// CanonicalPath returns p as an absolute, clean path with ~ expanded and
+// symlinks resolved, for comparing project paths; stored paths keep the form
+// the user gave. When p doesn't exist its nearest existing parent is
+// resolved, so a deleted directory under a symlink still compares equal.
+func CanonicalPath(p string) string {
+	if p == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(expandTilde(p))
+	if err != nil {
+		return filepath.Clean(p)
+	}
+	return resolveExisting(abs)
+}
+
+// resolveExisting resolves symlinks in the longest existing prefix of abs
+func resolveExisting(abs string) string {
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+	parent := filepath.Dir(abs)
+	if parent == abs {
+		return abs
+	}
+	return filepath.Join(resolveExisting(parent), filepath.Base(abs))
+}
+
+// PathKey returns a map key for p that is the same for every spelling of
+// one directory: canonical, and case-folded on macOS and Windows, whose
+// default filesystems ignore case.
+func PathKey(p string) string {
+	c := CanonicalPath(p)
+	if caseInsensitiveFS() {
+		return strings.ToLower(c)
+	}
+	return c
+}
+
+// SamePath reports whether a and b name the same directory, e.g.
+// ~/code/foo and /Users/me/code/foo, or a symlink and its target
+func SamePath(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	if PathKey(a) == PathKey(b) {
+		return true
+	}
+	infoA, errA := os.Stat(expandTilde(a))
+	infoB, errB := os.Stat(expandTilde(b))
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// caseInsensitiveFS reports whether paths on this OS usually ignore case
+func caseInsensitiveFS() bool {
+	return runtime.GOOS == "darwin" || runtime.GOOS == "windows"
+}
@@ -0,0 +1,43 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSamePath(t *testing.T) {
+	root := t.TempDir()
+	real := filepath.Join(root, "code", "foo")
+	if err := os.MkdirAll(real, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(root, "foo-link")
+	if err := os.Symlink(real, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{real, real + "/", true},
+		{real, link, true},
+		{filepath.Join(link, "gone"), filepath.Join(real, "gone"), true},
+		{real, filepath.Join(root, "code", "bar"), false},
+		{"", real, false},
+	}
+	for _, c := range cases {
+		if got := SamePath(c.a, c.b); got != c.want {
+			t.Errorf("SamePath(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+	if PathKey(link) != PathKey(real) {
+		t.Errorf("PathKey differs for a symlink and its target: %q vs %q", PathKey(link), PathKey(real))
+	}
+
+	home, err := os.UserHomeDir()
+	if err == nil && !SamePath("~/", home) {
+		t.Errorf("~/ should match %s", home)
+	}
+}
@@ -0,0 +1,247 @@
+package session
+
+import (
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// RateLimit is a provider rate limit message found in a session's output
+type RateLimit struct {
+	Line  string    // the line the message was found on
+	Until time.Time // when the limit resets; zero if the message doesn't say
+}
+
+// rateLimitLines is how many of the last non-blank lines of the pane are
+// searched: a limit further up has been answered by later output
+const rateLimitLines = 15
+
+// rateLimitFallback is the reset assumed for a message without a time
+const rateLimitFallback = 5 * time.Minute
+
+// rateLimitPatterns match the rate and usage limit messages of the providers
+var rateLimitPatterns = []*regexp.Regexp{
+	// Claude: "Claude usage limit reached", "5-hour limit reached ∙ resets 3pm",
+	// "API Error: 429 {... "rate_limit_error" ...}"
+	regexp.MustCompile(`(?i)usage limit reached|\b(?:5-hour|session|weekly|opus) limit reached|rate_limit_error`),
+	// Gemini: "RESOURCE_EXHAUSTED", "Quota exceeded for quota metric ..."
+	regexp.MustCompile(`(?i)resource_exhausted|quota exceeded|reached your daily quota`),
+	// OpenAI / Codex: "Rate limit reached for gpt-4o ...", "You've hit your
+	// usage limit", "last status: 429 Too Many Requests"
+	regexp.MustCompile(`(?i)rate limit reached|hit your usage limit|429 too many requests`),
+}
+
+var (
+	// "Claude AI usage limit reached|1760450400"
+	rateLimitEpoch = regexp.MustCompile(`\|(\d{10})\b`)
+	// "try again in 20s", "Please retry in 35.2s", "resets in 1 hour 5 minutes"
+	rateLimitIn = regexp.MustCompile(`(?i)(?:try again|retry|resets?) in ((?:\d+(?:\.\d+)?\s*[a-z]+[ ,]*(?:and )?)+)`)
+	// "resets 3pm", "will reset at 3:30pm (Europe/Berlin)", "Try again at 5:01 PM"
+	rateLimitAt = regexp.MustCompile(`(?i)(?:resets?|try again)\s+(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)?\b(?:\s*\(([A-Za-z_]+/[A-Za-z_/]+)\))?`)
+	// one "<number><unit>" of a rateLimitIn duration
+	rateLimitPart = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*([a-z]+)`)
+)
+
+// DetectRateLimit looks for a rate limit message near the end of a pane's
+// content. A message whose reset time has already passed is ignored.
+func DetectRateLimit(content string, now time.Time) (RateLimit, bool) {
+	lines := strings.Split(tmux.StripANSI(content), "\n")
+	seen := 0
+	for n := len(lines) - 1; n >= 0 && seen < rateLimitLines; n-- {
+		line := strings.TrimSpace(lines[n])
+		if line == "" {
+			continue
+		}
+		seen++
+		if !matchesRateLimit(line) {
+			continue
+		}
+		// The reset time may be on the same line or wrapped onto the next ones
+		text := strings.Join(lines[n:min(n+3, len(lines))], " ")
+		until, known := parseRateLimitReset(text, now)
+		if known && !until.After(now) {
+			return RateLimit{}, false
+		}
+		return RateLimit{Line: line, Until: until}, true
+	}
+	return RateLimit{}, false
+}
+
+func matchesRateLimit(line string) bool {
+	for _, re := range rateLimitPatterns {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRateLimitReset reads when a limit resets from its message: a Unix
+// time, a wait ("in 20s") or a clock time ("at 3pm"), which is taken as the
+// nearest such time, so one a few hours past is reported as past rather than
+// as tomorrow's
+func parseRateLimitReset(text string, now time.Time) (time.Time, bool) {
+	if m := rateLimitEpoch.FindStringSubmatch(text); m != nil {
+		secs, _ := strconv.ParseInt(m[1], 10, 64)
+		return time.Unix(secs, 0), true
+	}
+	if m := rateLimitIn.FindStringSubmatch(text); m != nil {
+		if d, ok := parseRateLimitWait(m[1]); ok {
+			return now.Add(d), true
+		}
+	}
+	if m := rateLimitAt.FindStringSubmatch(text); m != nil && (m[2] != "" || m[3] != "") {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		switch strings.ToLower(m[3]) {
+		case "am":
+			if hour == 12 {
+				hour = 0
+			}
+		case "pm":
+			if hour < 12 {
+				hour += 12
+			}
+		}
+		if hour > 23 || minute > 59 {
+			return time.Time{}, false
+		}
+		loc := now.Location()
+		if m[4] != "" {
+			if l, err := time.LoadLocation(m[4]); err == nil {
+				loc = l
+			}
+		}
+		local := now.In(loc)
+		at := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+		if at.Before(now.Add(-12 * time.Hour)) {
+			at = at.AddDate(0, 0, 1)
+		} else if at.After(now.Add(12 * time.Hour)) {
+			at = at.AddDate(0, 0, -1)
+		}
+		return at, true
+	}
+	return time.Time{}, false
+}
+
+// parseRateLimitWait parses waits like "20s", "450ms", "1m30s" or
+// "2 days 3 hours 5 minutes"
+func parseRateLimitWait(text string) (time.Duration, bool) {
+	var total time.Duration
+	for _, m := range rateLimitPart.FindAllStringSubmatch(text, -1) {
+		n, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, false
+		}
+		var unit time.Duration
+		switch u := strings.ToLower(m[2]); {
+		case u == "ms" || strings.HasPrefix(u, "millisecond"):
+			unit = time.Millisecond
+		case u == "s" || strings.HasPrefix(u, "sec"):
+			unit = time.Second
+		case u == "m" || strings.HasPrefix(u, "min"):
+			unit = time.Minute
+		case u == "h" || strings.HasPrefix(u, "hour") || strings.HasPrefix(u, "hr"):
+			unit = time.Hour
+		case u == "d" || strings.HasPrefix(u, "day"):
+			unit = 24 * time.Hour
+		default:
+			return total, total > 0
+		}
+		total += time.Duration(n * float64(unit))
+	}
+	return total, total > 0
+}
+
+// RateLimitResetLabel formats a reset time for display: "15:04", with the
+// weekday when it isn't today
+func RateLimitResetLabel(until, now time.Time) string {
+	until = until.In(now.Location())
+	if y, m, d := until.Date(); y != now.Year() || m != now.Month() || d != now.Day() {
+		return until.Format("Mon 15:04")
+	}
+	return until.Format("15:04")
+}
+
+// RateLimitedUntil returns when the provider rate limit shown in the
+// session's pane resets (estimated if the message doesn't say), or false if
+// the session isn't rate limited
+func (i *Instance) RateLimitedUntil() (time.Time, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.rateLimit.Until, i.rateLimit.Line != ""
+}
+
+// RefreshRateLimit reads the pane again for a rate limit, for callers not
+// served by the status worker (the CLI)
+func (i *Instance) RefreshRateLimit() {
+	i.mu.RLock()
+	tmuxSession := i.tmuxSession
+	i.mu.RUnlock()
+	if tmuxSession == nil {
+		return
+	}
+	content, err := tmuxSession.CapturePane()
+	if err != nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.trackRateLimit(content, time.Now())
+}
+
+// WaitOutRateLimit holds a prompt about to be sent while the session is rate
+// limited and [rate_limits] pause_prompts is on. It returns false if the
+// limit lasts past deadline (zero = no deadline).
+func (i *Instance) WaitOutRateLimit(deadline time.Time) bool {
+	if !GetRateLimitSettings().PausePrompts {
+		return true
+	}
+	for {
+		i.RefreshRateLimit()
+		until, limited := i.RateLimitedUntil()
+		if !limited {
+			return true
+		}
+		if !deadline.IsZero() && until.After(deadline) {
+			return false
+		}
+		sessionLog.Info("prompt_held_for_rate_limit", slog.String("id", i.ID), slog.String("until", until.Format(time.RFC3339)))
+		// Wake at the reset, or sooner to notice the message going away
+		time.Sleep(min(time.Until(until)+time.Second, 30*time.Second))
+	}
+}
+
+// trackRateLimit updates the session's rate limit from its pane content.
+// A message keeps its first estimate while it stays on screen, since a wait
+// it gives ("try again in 20s") counts from when it was printed. Once a
+// limit has expired its message is not taken up again. Caller holds mu.
+func (i *Instance) trackRateLimit(content string, now time.Time) {
+	rl, ok := DetectRateLimit(content, now)
+	switch {
+	case !ok || rl.Line == i.rateLimitCleared:
+		i.clearRateLimit()
+	case rl.Line == i.rateLimit.Line:
+		// Same message still showing
+	default:
+		if rl.Until.IsZero() {
+			rl.Until = now.Add(rateLimitFallback)
+		}
+		i.rateLimit = rl
+	}
+	if i.rateLimit.Line != "" && !now.Before(i.rateLimit.Until) {
+		i.clearRateLimit()
+	}
+}
+
+// clearRateLimit ends the session's rate limit. Caller holds mu.
+func (i *Instance) clearRateLimit() {
+	if i.rateLimit.Line != "" {
+		i.rateLimitCleared = i.rateLimit.Line
+	}
+	i.rateLimit = RateLimit{}
+}
@@ -0,0 +1,95 @@
+package session
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectRateLimit(t *testing.T) {
+	now := time.Date(2026, 10, 14, 13, 0, 0, 0, time.Local)
+	at := func(h, m int) time.Time { return time.Date(2026, 10, 14, h, m, 0, 0, time.Local) }
+
+	tests := []struct {
+		name    string
+		content string
+		ok      bool
+		until   time.Time
+	}{
+		{"claude clock", "some output\n\n  5-hour limit reached ∙ resets 3pm\n  /upgrade to increase your usage limit.\n", true, at(15, 0)},
+		{"claude wrapped", "Claude usage limit reached. Your limit will\nreset at 2:30pm\n", true, at(14, 30)},
+		{"claude epoch", "Claude AI usage limit reached|" + strconv.FormatInt(at(16, 0).Unix(), 10) + "\n", true, at(16, 0)},
+		{"claude api error", `API Error: 429 {"type":"error","error":{"type":"rate_limit_error"}}`, true, time.Time{}},
+		{"gemini retry", "[API Error: RESOURCE_EXHAUSTED] Quota exceeded. Please retry in 35s.", true, now.Add(35 * time.Second)},
+		{"openai wait", "Rate limit reached for gpt-4o. Please try again in 1m30s.", true, now.Add(90 * time.Second)},
+		{"codex words", "You've hit your usage limit. Try again in 2 hours 5 minutes.", true, now.Add(2*time.Hour + 5*time.Minute)},
+		{"codex clock", "You've hit your usage limit. Try again at 5:01 PM.", true, at(17, 1)},
+		{"past reset", "5-hour limit reached ∙ resets 11am", false, time.Time{}},
+		{"no limit", "Refactored the auth middleware\n> ", false, time.Time{}},
+		{"approaching", "Approaching usage limit · resets at 3pm", false, time.Time{}},
+		{"scrolled away", "Rate limit reached. Try again in 20s.\n" + strings.Repeat("working...\n", 20), false, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectRateLimit(tt.content, now)
+			if ok != tt.ok {
+				t.Fatalf("DetectRateLimit() ok = %v, want %v", ok, tt.ok)
+			}
+			if !got.Until.Equal(tt.until) {
+				t.Errorf("Until = %v, want %v", got.Until, tt.until)
+			}
+		})
+	}
+
+	// Late in the evening, a reset after midnight is tomorrow's
+	late := at(23, 0)
+	got, ok := DetectRateLimit("5-hour limit reached ∙ resets 1am", late)
+	if want := at(1, 0).AddDate(0, 0, 1); !ok || !got.Until.Equal(want) {
+		t.Errorf("after midnight: ok=%v until=%v, want %v", ok, got.Until, want)
+	}
+}
+
+func TestTrackRateLimit(t *testing.T) {
+	now := time.Now()
+	msg := "Rate limit reached for gpt-4o. Please try again in 20s.\n"
+	inst := &Instance{}
+
+	inst.trackRateLimit(msg, now)
+	until, limited := inst.RateLimitedUntil()
+	if !limited || !until.Equal(now.Add(20*time.Second)) {
+		t.Fatalf("first sight: limited=%v until=%v", limited, until)
+	}
+
+	// The wait counts from when the message was printed, not each poll
+	inst.trackRateLimit(msg, now.Add(10*time.Second))
+	if until, _ := inst.RateLimitedUntil(); !until.Equal(now.Add(20 * time.Second)) {
+		t.Errorf("estimate moved to %v", until)
+	}
+
+	// Expired, and the same message is not taken up again
+	inst.trackRateLimit(msg, now.Add(21*time.Second))
+	if _, limited := inst.RateLimitedUntil(); limited {
+		t.Error("still limited after the reset")
+	}
+	inst.trackRateLimit(msg, now.Add(25*time.Second))
+	if _, limited := inst.RateLimitedUntil(); limited {
+		t.Error("expired message was taken up again")
+	}
+
+	// No time in the message: the fallback estimate
+	inst.trackRateLimit("Claude usage limit reached.\n", now)
+	if until, limited := inst.RateLimitedUntil(); !limited || !until.Equal(now.Add(rateLimitFallback)) {
+		t.Errorf("fallback: limited=%v until=%v", limited, until)
+	}
+}
+
+func TestRateLimitResetLabel(t *testing.T) {
+	now := time.Date(2026, 10, 14, 13, 0, 0, 0, time.Local)
+	if got := RateLimitResetLabel(now.Add(2*time.Hour), now); got != "15:00" {
+		t.Errorf("today = %q", got)
+	}
+	if got := RateLimitResetLabel(now.Add(12*time.Hour), now); got != "Thu 01:00" {
+		t.Errorf("tomorrow = %q", got)
+	}
+}
@@ -0,0 +1,163 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RecentDirsFileName is the recent-directories cache in ~/.agent-deck, shared
+// by all profiles
+const RecentDirsFileName = "recent-dirs.json"
+
+// zoxideTimeout bounds the zoxide query so a slow database never delays the
+// new session dialog
+const zoxideTimeout = 500 * time.Millisecond
+
+// recentDir is one entry of the recent-directories cache
+type recentDir struct {
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// recentDirsPath returns the path of the recent-directories cache
+func recentDirsPath() (string, error) {
+	dir, err := GetAgentDeckDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, RecentDirsFileName), nil
+}
+
+// loadRecentDirs reads the recent-directories cache; a missing or corrupt
+// file is an empty cache
+func loadRecentDirs() map[string]recentDir {
+	dirs := make(map[string]recentDir)
+	path, err := recentDirsPath()
+	if err != nil {
+		return dirs
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return dirs
+	}
+	_ = json.Unmarshal(data, &dirs)
+	return dirs
+}
+
+// RecordRecentDirectory notes that a session was created in dir, so it ranks
+// higher in the new session dialog's suggestions
+func RecordRecentDirectory(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	path, err := recentDirsPath()
+	if err != nil {
+		return err
+	}
+	dirs := loadRecentDirs()
+	entry := dirs[dir]
+	entry.Count++
+	entry.LastUsed = time.Now()
+	dirs[dir] = entry
+
+	data, err := json.MarshalIndent(dirs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// frecency scores a cache entry the way zoxide does: use count, weighted by
+// how recently the directory was last used
+func frecency(d recentDir, now time.Time) float64 {
+	age := now.Sub(d.LastUsed)
+	weight := 0.25
+	switch {
+	case age < time.Hour:
+		weight = 4
+	case age < 24*time.Hour:
+		weight = 2
+	case age < 7*24*time.Hour:
+		weight = 0.5
+	}
+	return float64(d.Count) * weight
+}
+
+// SuggestDirectories returns frequently used directories, best first: from
+// zoxide's database when it's installed and enabled, otherwise from the
+// recent-directories cache. Directories that no longer exist are skipped.
+func SuggestDirectories(settings SuggestionSettings) []string {
+	var dirs []string
+	if settings.GetZoxide() && !IsDemoMode() { // Keep real directories out of demo screenshots
+		dirs = zoxideDirectories()
+	}
+	if len(dirs) == 0 {
+		dirs = cachedDirectories()
+	}
+
+	result := make([]string, 0, settings.GetMax())
+	for _, dir := range dirs {
+		if len(result) == settings.GetMax() {
+			break
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			result = append(result, dir)
+		}
+	}
+	return result
+}
+
+// cachedDirectories returns the cached directories ranked by frecency
+func cachedDirectories() []string {
+	cache := loadRecentDirs()
+	now := time.Now()
+	dirs := make([]string, 0, len(cache))
+	for dir := range cache {
+		dirs = append(dirs, dir)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		si, sj := frecency(cache[dirs[i]], now), frecency(cache[dirs[j]], now)
+		if si != sj {
+			return si > sj
+		}
+		return dirs[i] < dirs[j]
+	})
+	return dirs
+}
+
+// zoxideDirectories lists zoxide's directories by score, or nil when zoxide
+// isn't installed or fails
+func zoxideDirectories() []string {
+	bin, err := exec.LookPath("zoxide")
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), zoxideTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, bin, "query", "--list").Output()
+	if err != nil {
+		return nil
+	}
+	return parseZoxideList(output)
+}
+
+// parseZoxideList parses `zoxide query --list` output: one path per line,
+// highest score first
+func parseZoxideList(output []byte) []string {
+	var dirs []string
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			dirs = append(dirs, line)
+		}
+	}
+	return dirs
+}
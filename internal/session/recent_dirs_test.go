@@ -0,0 +1,53 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecentDirectoriesRankedByFrecency(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	daily := t.TempDir()
+	once := t.TempDir()
+	gone := filepath.Join(t.TempDir(), "deleted")
+
+	for i := 0; i < 3; i++ {
+		if err := RecordRecentDirectory(daily); err != nil {
+			t.Fatalf("RecordRecentDirectory: %v", err)
+		}
+	}
+	_ = RecordRecentDirectory(once)
+	_ = RecordRecentDirectory(gone)
+
+	off := false
+	got := SuggestDirectories(SuggestionSettings{Zoxide: &off})
+	if len(got) != 2 || got[0] != daily || got[1] != once {
+		t.Errorf("SuggestDirectories = %v, want [%s %s]", got, daily, once)
+	}
+
+	limited := SuggestDirectories(SuggestionSettings{Zoxide: &off, Max: 1})
+	if len(limited) != 1 {
+		t.Errorf("Max = 1 returned %d directories", len(limited))
+	}
+	if _, err := os.Stat(filepath.Join(os.Getenv("HOME"), ".agent-deck", RecentDirsFileName)); err != nil {
+		t.Errorf("cache file not written: %v", err)
+	}
+}
+
+func TestFrecencyPrefersRecentUse(t *testing.T) {
+	now := time.Now()
+	stale := recentDir{Count: 6, LastUsed: now.Add(-30 * 24 * time.Hour)}
+	fresh := recentDir{Count: 1, LastUsed: now.Add(-time.Minute)}
+	if frecency(fresh, now) <= frecency(stale, now) {
+		t.Error("a directory used a minute ago should outrank one used a month ago")
+	}
+}
+
+func TestParseZoxideList(t *testing.T) {
+	got := parseZoxideList([]byte("/home/me/code/api\n/home/me/dotfiles\n\n"))
+	if len(got) != 2 || got[0] != "/home/me/code/api" || got[1] != "/home/me/dotfiles" {
+		t.Errorf("parseZoxideList = %v", got)
+	}
+}
@@ -0,0 +1,80 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Record returns the session's stored fields as indented JSON, for editing
+// by hand (agent-deck edit)
+func (i *Instance) Record() ([]byte, error) {
+	data, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// ApplyRecord parses an edited Record of inst and returns the session it
+// describes, keeping inst's tmux session. Unknown fields are rejected so a
+// typo isn't silently dropped; the ID can't change, and the title, project
+// path, status, auto-attach mode and parent must make sense among others.
+func ApplyRecord(inst *Instance, data []byte, others []*Instance) (*Instance, error) {
+	edited := &Instance{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(edited); err != nil {
+		return nil, fmt.Errorf("invalid record: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid record: unexpected data after the closing brace")
+	}
+
+	if edited.ID != inst.ID {
+		return nil, fmt.Errorf("id cannot be changed (was %s)", inst.ID)
+	}
+	edited.Title = strings.TrimSpace(edited.Title)
+	if edited.Title == "" {
+		return nil, fmt.Errorf("title cannot be empty")
+	}
+	if strings.TrimSpace(edited.ProjectPath) == "" {
+		return nil, fmt.Errorf("project_path cannot be empty")
+	}
+	if edited.Tool == "" {
+		edited.Tool = "shell"
+	}
+	edited.GroupPath = strings.Trim(edited.GroupPath, "/")
+	if edited.GroupPath == "" {
+		edited.GroupPath = DefaultGroupPath
+	}
+	switch edited.Status {
+	case StatusRunning, StatusWaiting, StatusIdle, StatusError, StatusStarting:
+	default:
+		return nil, fmt.Errorf("invalid status %q (use running, waiting, idle, error or starting)", edited.Status)
+	}
+	switch edited.AutoAttach {
+	case AutoAttachOff, AutoAttachAlways, AutoAttachAsk:
+	default:
+		return nil, fmt.Errorf("invalid auto_attach %q (use \"attach\", \"ask\" or \"\")", edited.AutoAttach)
+	}
+	if p := edited.ParentSessionID; p != "" {
+		if p == edited.ID {
+			return nil, fmt.Errorf("a session cannot be its own parent")
+		}
+		found := false
+		for _, other := range others {
+			if other.ID == p {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("parent_session_id %s does not match any session", p)
+		}
+	}
+
+	edited.tmuxSession = inst.tmuxSession
+	return edited, nil
+}
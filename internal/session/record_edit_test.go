@@ -0,0 +1,93 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// editRecord returns inst's record with change applied to its JSON fields
+func editRecord(t *testing.T, inst *Instance, change func(map[string]any)) []byte {
+	t.Helper()
+	data, err := inst.Record()
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("unmarshal record: %v", err)
+	}
+	change(fields)
+	out, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("marshal record: %v", err)
+	}
+	return out
+}
+
+func TestApplyRecordChangesFields(t *testing.T) {
+	inst := NewInstance("api", "/tmp/api")
+	data := editRecord(t, inst, func(f map[string]any) {
+		f["title"] = "  api-v2 "
+		f["group_path"] = "/work/backend/"
+		f["notes"] = "migrated"
+	})
+
+	got, err := ApplyRecord(inst, data, []*Instance{inst})
+	if err != nil {
+		t.Fatalf("ApplyRecord: %v", err)
+	}
+	if got.Title != "api-v2" || got.GroupPath != "work/backend" || got.Notes != "migrated" {
+		t.Errorf("got title %q group %q notes %q", got.Title, got.GroupPath, got.Notes)
+	}
+	if got.ID != inst.ID || got.ProjectPath != inst.ProjectPath {
+		t.Errorf("unchanged fields lost: id %q path %q", got.ID, got.ProjectPath)
+	}
+	if got.tmuxSession != inst.tmuxSession {
+		t.Error("tmux session not carried over")
+	}
+}
+
+func TestApplyRecordUnchangedRoundTrips(t *testing.T) {
+	inst := NewInstance("api", "/tmp/api")
+	data, _ := inst.Record()
+	got, err := ApplyRecord(inst, data, []*Instance{inst})
+	if err != nil {
+		t.Fatalf("ApplyRecord: %v", err)
+	}
+	again, _ := got.Record()
+	if !bytes.Equal(data, again) {
+		t.Errorf("record changed on round trip:\n%s\nvs\n%s", data, again)
+	}
+}
+
+func TestApplyRecordRejects(t *testing.T) {
+	inst := NewInstance("api", "/tmp/api")
+	tests := []struct {
+		name   string
+		change func(map[string]any)
+		want   string
+	}{
+		{"id change", func(f map[string]any) { f["id"] = "other" }, "id cannot be changed"},
+		{"unknown field", func(f map[string]any) { f["titel"] = "typo" }, "unknown field"},
+		{"empty title", func(f map[string]any) { f["title"] = " " }, "title cannot be empty"},
+		{"empty path", func(f map[string]any) { f["project_path"] = "" }, "project_path"},
+		{"bad status", func(f map[string]any) { f["status"] = "busy" }, "invalid status"},
+		{"bad auto_attach", func(f map[string]any) { f["auto_attach"] = "always" }, "invalid auto_attach"},
+		{"own parent", func(f map[string]any) { f["parent_session_id"] = inst.ID }, "own parent"},
+		{"missing parent", func(f map[string]any) { f["parent_session_id"] = "nope" }, "does not match"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyRecord(inst, editRecord(t, inst, tt.change), []*Instance{inst})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ApplyRecord(inst, []byte("{} {}"), nil); err == nil {
+		t.Error("trailing data accepted")
+	}
+}
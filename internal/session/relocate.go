@@ -0,0 +1,209 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// Search limits for FindRelocations, so a large home directory can't stall it
+const (
+	relocateMaxDepth        = 3    // levels below each search root
+	relocateMaxDirs         = 5000 // directories visited in total
+	relocateMaxRemoteChecks = 200  // repos whose remote is compared
+)
+
+// Relocation is a directory a session's missing project may have moved to
+type Relocation struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"` // what matched: git remote and/or name
+}
+
+// PathMissing reports whether the session's project directory no longer
+// exists, e.g. because the repo was moved or renamed
+func (i *Instance) PathMissing() bool {
+	if i.ProjectPath == "" {
+		return false
+	}
+	_, err := os.Stat(i.ProjectPath)
+	return os.IsNotExist(err)
+}
+
+// recordGitRemote remembers the origin remote of the project's repo
+func (i *Instance) recordGitRemote() {
+	if remote, err := git.GetRemoteURL(i.ProjectPath); err == nil {
+		i.GitRemote = remote
+	}
+}
+
+// Relocate points the session at newPath, which must be an existing
+// directory. Worktree paths that pointed at the old directory follow it; the
+// tmux session starts there on its next restart.
+func (i *Instance) Relocate(newPath string) error {
+	abs, err := filepath.Abs(expandTilde(newPath))
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", newPath)
+	}
+	old := i.ProjectPath
+	i.ProjectPath = abs
+	if i.WorktreePath == old {
+		i.WorktreePath = abs
+	}
+	if i.WorktreeRepoRoot == old {
+		i.WorktreeRepoRoot = abs
+	}
+	if i.tmuxSession != nil && i.tmuxSession.WorkDir == old {
+		i.tmuxSession.WorkDir = abs
+	}
+	i.recordGitRemote()
+	return nil
+}
+
+// FindRelocations looks for where inst's missing project directory went:
+// repos with the same git remote (recorded when the session last started)
+// and directories with the same name. It searches a few levels below the
+// missing path's nearest existing parent, the home directory, frequently
+// used directories and the parents of other sessions' projects. Remote
+// matches come first.
+func FindRelocations(inst *Instance, others []*Instance) []Relocation {
+	base := filepath.Base(inst.ProjectPath)
+	s := relocationSearch{
+		base:    base,
+		remote:  inst.GitRemote,
+		visited: make(map[string]bool),
+		found:   make(map[string]*Relocation),
+	}
+	for _, root := range relocationRoots(inst, others) {
+		s.walk(root, 0)
+	}
+
+	var byRemote, byName []Relocation
+	for _, path := range s.order {
+		r := *s.found[path]
+		if strings.Contains(r.Reason, "remote") {
+			byRemote = append(byRemote, r)
+		} else {
+			byName = append(byName, r)
+		}
+	}
+	return append(byRemote, byName...)
+}
+
+// relocationRoots returns the directories FindRelocations searches, most
+// likely first
+func relocationRoots(inst *Instance, others []*Instance) []string {
+	var roots []string
+	seen := make(map[string]bool)
+	add := func(dir string) {
+		dir = filepath.Clean(dir)
+		if dir == "" || dir == "." || dir == "/" || seen[dir] {
+			return
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return
+		}
+		seen[dir] = true
+		roots = append(roots, dir)
+	}
+
+	// The nearest existing parent of the old path, and its parent (a rename
+	// stays put; a move often goes one level up or sideways)
+	parent := filepath.Dir(inst.ProjectPath)
+	for parent != "/" && parent != "." {
+		if _, err := os.Stat(parent); err == nil {
+			break
+		}
+		parent = filepath.Dir(parent)
+	}
+	add(parent)
+	add(filepath.Dir(parent))
+
+	for _, other := range others {
+		if other.ID != inst.ID && other.ProjectPath != "" {
+			add(filepath.Dir(other.ProjectPath))
+		}
+	}
+	for _, dir := range SuggestDirectories(GetSuggestionSettings()) {
+		add(filepath.Dir(dir))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		add(home)
+	}
+	return roots
+}
+
+// relocationSearch is the state of one FindRelocations walk
+type relocationSearch struct {
+	base         string
+	remote       string
+	visited      map[string]bool
+	remoteChecks int
+	found        map[string]*Relocation
+	order        []string
+}
+
+// walk visits dir and its subdirectories down to relocateMaxDepth,
+// recording matches. Hidden directories, dependency folders and the insides
+// of repos are skipped.
+func (s *relocationSearch) walk(dir string, depth int) {
+	if s.visited[dir] || len(s.visited) >= relocateMaxDirs {
+		return
+	}
+	s.visited[dir] = true
+
+	isRepo := false
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		isRepo = true
+	}
+	if filepath.Base(dir) == s.base {
+		s.add(dir, "same name")
+	}
+	if isRepo && s.remote != "" && s.remoteChecks < relocateMaxRemoteChecks {
+		s.remoteChecks++
+		if remote, err := git.GetRemoteURL(dir); err == nil && sameRemote(remote, s.remote) {
+			s.add(dir, "same git remote")
+		}
+	}
+	if isRepo || depth >= relocateMaxDepth {
+		return
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() || strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor" {
+			continue
+		}
+		s.walk(filepath.Join(dir, name), depth+1)
+	}
+}
+
+// add records a match, merging reasons when a directory matches twice
+func (s *relocationSearch) add(path, reason string) {
+	if r, ok := s.found[path]; ok {
+		r.Reason = "same git remote and name"
+		return
+	}
+	s.found[path] = &Relocation{Path: path, Reason: reason}
+	s.order = append(s.order, path)
+}
+
+// sameRemote reports whether two remote URLs name the same repo, so
+// git@github.com:o/r.git and https://github.com/o/r match
+func sameRemote(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ownerA, repoA := git.ParseRemoteURL(a)
+	ownerB, repoB := git.ParseRemoteURL(b)
+	return repoA != "" && strings.EqualFold(ownerA, ownerB) && strings.EqualFold(repoA, repoB)
+}
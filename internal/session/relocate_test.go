@@ -0,0 +1,70 @@
+package session
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindRelocations(t *testing.T) {
+	root := t.TempDir()
+	moved := filepath.Join(root, "work", "proj")
+	renamed := filepath.Join(root, "work", "proj-v2")
+	for _, dir := range []string{moved, renamed, filepath.Join(root, "old")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := exec.LookPath("git"); err == nil {
+		for _, args := range [][]string{{"init", "-q"}, {"remote", "add", "origin", "git@github.com:acme/proj.git"}} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = renamed
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v: %v: %s", args, err, out)
+			}
+		}
+	}
+
+	inst := &Instance{ID: "s1", ProjectPath: filepath.Join(root, "old", "proj"), GitRemote: "https://github.com/acme/proj"}
+	if !inst.PathMissing() {
+		t.Fatal("PathMissing should be true for a removed directory")
+	}
+
+	found := FindRelocations(inst, nil)
+	index := func(path string) int {
+		for i, r := range found {
+			if r.Path == path {
+				return i
+			}
+		}
+		return -1
+	}
+	if index(moved) < 0 {
+		t.Errorf("same-name directory %s not found in %+v", moved, found)
+	}
+	if _, err := exec.LookPath("git"); err == nil {
+		if i := index(renamed); i != 0 || found[i].Reason != "same git remote" {
+			t.Errorf("repo with the same remote should come first, got %+v", found)
+		}
+	}
+
+	if err := inst.Relocate(moved); err != nil {
+		t.Fatalf("Relocate: %v", err)
+	}
+	if inst.ProjectPath != moved || inst.PathMissing() {
+		t.Errorf("after Relocate ProjectPath = %q", inst.ProjectPath)
+	}
+	if err := inst.Relocate(filepath.Join(root, "nope")); err == nil {
+		t.Error("Relocate to a missing directory should fail")
+	}
+}
+
+func TestSameRemote(t *testing.T) {
+	if !sameRemote("git@github.com:Acme/proj.git", "https://github.com/acme/proj") {
+		t.Error("scp and https forms of one repo should match")
+	}
+	if sameRemote("git@github.com:acme/proj.git", "git@github.com:acme/other.git") {
+		t.Error("different repos should not match")
+	}
+}
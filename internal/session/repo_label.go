@@ -0,0 +1,95 @@
+package session
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// RepoLabel returns the owner/name of the repo a remote URL points at, e.g.
+// "acme/api" for git@github.com:acme/api.git, or "" when it has no owner
+func RepoLabel(remote string) string {
+	if remote == "" {
+		return ""
+	}
+	owner, repo := git.ParseRemoteURL(remote)
+	if owner == "" || repo == "" {
+		return ""
+	}
+	return owner + "/" + repo
+}
+
+// RepoLabel returns the owner/name of the project's repo, from the origin
+// remote recorded when the session was created or last started
+func (i *Instance) RepoLabel() string {
+	return RepoLabel(i.GitRemote)
+}
+
+// HasRepoPlaceholder reports whether s uses any of the placeholders
+// ExpandRepoTemplate fills in
+func HasRepoPlaceholder(s string) bool {
+	for _, p := range []string{"{repo}", "{owner}", "{repo-name}", "{dir}"} {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpandRepoTemplate fills in a title or group template for a project:
+// {repo} is owner/name from the git remote, {owner} and {repo-name} its
+// parts, and {dir} the project folder's name. Without a remote, {repo} and
+// {repo-name} fall back to the folder name and {owner} is empty.
+func ExpandRepoTemplate(tmpl, projectPath, remote string) string {
+	if !HasRepoPlaceholder(tmpl) {
+		return tmpl
+	}
+	dir := filepath.Base(projectPath)
+	owner, name := git.ParseRemoteURL(remote)
+	if remote == "" || name == "" {
+		owner, name = "", dir
+	}
+	label := name
+	if owner != "" {
+		label = owner + "/" + name
+	}
+	out := strings.NewReplacer(
+		"{repo-name}", name,
+		"{repo}", label,
+		"{owner}", owner,
+		"{dir}", dir,
+	).Replace(tmpl)
+	// An empty {owner} shouldn't leave "/name" or a trailing separator
+	return strings.Trim(strings.ReplaceAll(out, "//", "/"), "/ ")
+}
+
+// LabelNewSession names and groups a new session in path, for every way of
+// creating one: without a group, [[group_rules]] and then [repo_labels]
+// group_template pick it; without a title, title_template names it; and
+// {repo}, {owner}, {repo-name} and {dir} are filled in from the git remote,
+// which is returned too. It runs git, so keep it off the UI goroutine.
+func LabelNewSession(title, group, path string) (string, string, string) {
+	remote := ProjectRemote(path)
+	labels := GetRepoLabelSettings()
+	if group == "" {
+		group = GroupForPath(path)
+	}
+	if group == "" {
+		group = labels.GroupTemplate
+	}
+	if title == "" {
+		title = labels.TitleTemplate
+	}
+	return ExpandRepoTemplate(title, path, remote), ExpandRepoTemplate(group, path, remote), remote
+}
+
+// ProjectRemote returns the origin remote URL of the repo containing path,
+// or "" when it isn't a repo or has no origin
+func ProjectRemote(path string) string {
+	remote, err := git.GetRemoteURL(path)
+	if err != nil {
+		return ""
+	}
+	return remote
+}
@@ -0,0 +1,40 @@
+package session
+
+import "testing"
+
+func TestRepoLabel(t *testing.T) {
+	tests := map[string]string{
+		"git@github.com:acme/api.git":           "acme/api",
+		"https://gitlab.com/acme/tools/cli.git": "tools/cli",
+		"https://github.com/acme/api/":          "acme/api",
+		"api":                                   "",
+		"":                                      "",
+	}
+	for remote, want := range tests {
+		if got := RepoLabel(remote); got != want {
+			t.Errorf("RepoLabel(%q) = %q, want %q", remote, got, want)
+		}
+	}
+}
+
+func TestExpandRepoTemplate(t *testing.T) {
+	const remote = "git@github.com:acme/api.git"
+	tests := []struct {
+		tmpl, remote, want string
+	}{
+		{"{repo}", remote, "acme/api"},
+		{"{owner}", remote, "acme"},
+		{"{repo-name} ({dir})", remote, "api (api-main)"},
+		{"clients/{owner}", remote, "clients/acme"},
+		// Without a remote the repo falls back to the folder
+		{"{repo}", "", "api-main"},
+		{"{owner}/{repo-name}", "", "api-main"},
+		{"{owner}", "", ""},
+		{"plain title", remote, "plain title"},
+	}
+	for _, tt := range tests {
+		if got := ExpandRepoTemplate(tt.tmpl, "/code/api-main", tt.remote); got != tt.want {
+			t.Errorf("ExpandRepoTemplate(%q, remote %q) = %q, want %q", tt.tmpl, tt.remote, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffMaxCells bounds the line-matching table; larger inputs (after the
+// common start and end are trimmed) are shown as a full replacement
+const diffMaxCells = 2_000_000
+
+// diffContext is the number of unchanged lines kept around each change
+const diffContext = 3
+
+// DiffOp says whether a diff line was kept, added or removed
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffAdd
+	DiffDelete
+)
+
+// DiffLine is one line of a line diff
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffLines returns the line diff turning old into new
+func DiffLines(old, new string) []DiffLine {
+	a := splitLines(old)
+	b := splitLines(new)
+
+	// Trim the common start and end: panes mostly grow at the bottom
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var out []DiffLine
+	for _, l := range a[:prefix] {
+		out = append(out, DiffLine{DiffEqual, l})
+	}
+	out = append(out, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, l := range a[len(a)-suffix:] {
+		out = append(out, DiffLine{DiffEqual, l})
+	}
+	return out
+}
+
+// diffMiddle diffs a and b by longest common subsequence
+func diffMiddle(a, b []string) []DiffLine {
+	var out []DiffLine
+	if len(a)*len(b) > diffMaxCells || len(a) == 0 || len(b) == 0 {
+		for _, l := range a {
+			out = append(out, DiffLine{DiffDelete, l})
+		}
+		for _, l := range b {
+			out = append(out, DiffLine{DiffAdd, l})
+		}
+		return out
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	cols := len(b) + 1
+	lcs := make([]int32, (len(a)+1)*cols)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*cols+j] = lcs[(i+1)*cols+j+1] + 1
+			} else {
+				lcs[i*cols+j] = max(lcs[(i+1)*cols+j], lcs[i*cols+j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, DiffLine{DiffEqual, a[i]})
+			i++
+			j++
+		case lcs[(i+1)*cols+j] >= lcs[i*cols+j+1]:
+			out = append(out, DiffLine{DiffDelete, a[i]})
+			i++
+		default:
+			out = append(out, DiffLine{DiffAdd, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, DiffLine{DiffDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, DiffLine{DiffAdd, b[j]})
+	}
+	return out
+}
+
+// splitLines splits text into lines, ignoring trailing blank lines
+func splitLines(s string) []string {
+	s = strings.TrimRight(s, "\n \t")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// FormatDiff renders diff lines with "+ " / "- " / "  " prefixes, keeping
+// diffContext unchanged lines around changes and marking skipped runs with
+// "@@" lines
+func FormatDiff(lines []DiffLine) string {
+	keep := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.Op == DiffEqual {
+			continue
+		}
+		for k := max(0, i-diffContext); k <= min(len(lines)-1, i+diffContext); k++ {
+			keep[k] = true
+		}
+	}
+
+	var sb strings.Builder
+	skipped := 0
+	flush := func() {
+		if skipped > 0 {
+			sb.WriteString(fmt.Sprintf("@@ %d unchanged lines @@\n", skipped))
+			skipped = 0
+		}
+	}
+	for i, l := range lines {
+		if !keep[i] {
+			skipped++
+			continue
+		}
+		flush()
+		switch l.Op {
+		case DiffAdd:
+			sb.WriteString("+ ")
+		case DiffDelete:
+			sb.WriteString("- ")
+		default:
+			sb.WriteString("  ")
+		}
+		sb.WriteString(l.Text)
+		sb.WriteString("\n")
+	}
+	flush()
+	return sb.String()
+}
+
+// DiffSnapshot compares the session's pane now with snap and returns the
+// formatted diff and the number of added lines (see diffSince)
+func DiffSnapshot(inst *Instance, snap *Snapshot) (string, int, error) {
+	old, err := snap.Content()
+	if err != nil {
+		return "", 0, err
+	}
+	current, err := inst.CaptureScrollback(true)
+	if err != nil {
+		return "", 0, err
+	}
+
+	lines, added := diffSince(old, current)
+	header := fmt.Sprintf("--- snapshot %s (%s)\n+++ now\n", snap.Name, snap.CreatedAt.Format("Jan 2 15:04"))
+	if !hasChanges(lines) {
+		return header + "No changes since the snapshot\n", 0, nil
+	}
+	return header + FormatDiff(lines), added, nil
+}
+
+// diffSince diffs a snapshot against the current scrollback. Scrollback
+// from above the snapshot's window shows up as leading additions and is
+// left out, so the result is what changed since the snapshot was taken.
+func diffSince(old, current string) ([]DiffLine, int) {
+	lines := DiffLines(old, current)
+	first := 0
+	for first < len(lines) && lines[first].Op == DiffAdd {
+		first++
+	}
+	if first < len(lines) {
+		lines = lines[first:]
+	}
+
+	added := 0
+	for _, l := range lines {
+		if l.Op == DiffAdd {
+			added++
+		}
+	}
+	return lines, added
+}
+
+// hasChanges reports whether any line was added or removed
+func hasChanges(lines []DiffLine) bool {
+	for _, l := range lines {
+		if l.Op != DiffEqual {
+			return true
+		}
+	}
+	return false
+}
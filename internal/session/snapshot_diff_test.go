@@ -0,0 +1,41 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	lines := DiffLines("a\nb\nc\n", "a\nB\nc\nd\n")
+	var got []string
+	for _, l := range lines {
+		got = append(got, map[DiffOp]string{DiffEqual: " ", DiffAdd: "+", DiffDelete: "-"}[l.Op]+l.Text)
+	}
+	if want := " a,-b,+B, c,+d"; strings.Join(got, ",") != want {
+		t.Errorf("DiffLines = %v, want %s", got, want)
+	}
+}
+
+func TestDiffSinceSkipsEarlierHistory(t *testing.T) {
+	snapshot := "$ make test\nok\n> "
+	current := "old history\nmore history\n$ make test\nok\n> fix the bug\nworking...\ndone\n> "
+	lines, added := diffSince(snapshot, current)
+	if added != 3 {
+		t.Errorf("added = %d, want 3 (history above the snapshot is left out)", added)
+	}
+	out := FormatDiff(lines)
+	if strings.Contains(out, "old history") || !strings.Contains(out, "+ working...") {
+		t.Errorf("diff =\n%s", out)
+	}
+}
+
+func TestFormatDiffCollapsesUnchanged(t *testing.T) {
+	var old []string
+	for i := 0; i < 20; i++ {
+		old = append(old, "line")
+	}
+	out := FormatDiff(DiffLines(strings.Join(old, "\n"), strings.Join(old, "\n")+"\nnew"))
+	if !strings.HasPrefix(out, "@@ 17 unchanged lines @@\n") || !strings.HasSuffix(out, "+ new\n") {
+		t.Errorf("FormatDiff =\n%s", out)
+	}
+}
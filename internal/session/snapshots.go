@@ -0,0 +1,144 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshotTimeFormat prefixes snapshot file names so they sort by time
+const snapshotTimeFormat = "20060102-150405"
+
+// Snapshot is a saved capture of a session's pane, kept as a text file in
+// ~/.agent-deck/snapshots/<session-id>/<time>_<name>.txt. Lighter than
+// transcript logging: only what was on screen when it was taken.
+type Snapshot struct {
+	SessionID string    `json:"session_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+}
+
+// GetSnapshotsDir returns the directory holding a session's snapshots
+func GetSnapshotsDir(sessionID string) (string, error) {
+	dir, err := GetAgentDeckDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "snapshots", sessionID), nil
+}
+
+// DeleteSnapshots removes all of a session's snapshots, when the session is
+// deleted
+func DeleteSnapshots(sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	dir, err := GetSnapshotsDir(sessionID)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// snapshotSlug turns a snapshot name into a file name part
+func snapshotSlug(name string) string {
+	return strings.Trim(strings.ReplaceAll(sanitizeGroupName(name), " ", "-"), "-")
+}
+
+// SaveSnapshot captures the session's pane (the visible screen, or the whole
+// scrollback with fullHistory) as a snapshot called name. An empty name
+// uses the time.
+func SaveSnapshot(inst *Instance, name string, fullHistory bool, now time.Time) (*Snapshot, error) {
+	content, err := inst.CaptureScrollback(fullHistory)
+	if err != nil {
+		return nil, err
+	}
+	return writeSnapshot(inst.ID, name, content, now)
+}
+
+// writeSnapshot stores content as a snapshot of the session sessionID
+func writeSnapshot(sessionID, name, content string, now time.Time) (*Snapshot, error) {
+	if strings.TrimSpace(name) == "" {
+		name = now.Format("15-04-05")
+	}
+	slug := snapshotSlug(name)
+	dir, err := GetSnapshotsDir(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, now.Format(snapshotTimeFormat)+"_"+slug+".txt")
+	if err := WriteExport(path, content); err != nil {
+		return nil, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return &Snapshot{SessionID: sessionID, Name: slug, CreatedAt: now, Path: path, Size: int64(len(content))}, nil
+}
+
+// ListSnapshots returns the session's snapshots, newest first
+func ListSnapshots(sessionID string) ([]Snapshot, error) {
+	dir, err := GetSnapshotsDir(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snaps []Snapshot
+	for _, e := range entries {
+		stamp, slug, ok := strings.Cut(strings.TrimSuffix(e.Name(), ".txt"), "_")
+		if e.IsDir() || !ok || !strings.HasSuffix(e.Name(), ".txt") {
+			continue
+		}
+		created, err := time.ParseInLocation(snapshotTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		snap := Snapshot{SessionID: sessionID, Name: slug, CreatedAt: created, Path: filepath.Join(dir, e.Name())}
+		if info, err := e.Info(); err == nil {
+			snap.Size = info.Size()
+		}
+		snaps = append(snaps, snap)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].CreatedAt.After(snaps[j].CreatedAt) })
+	return snaps, nil
+}
+
+// FindSnapshot returns the newest snapshot of the session whose name (or
+// time prefix, e.g. 20250304-0506) matches ref
+func FindSnapshot(sessionID, ref string) (*Snapshot, error) {
+	snaps, err := ListSnapshots(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	slug := snapshotSlug(ref)
+	for i, s := range snaps {
+		if strings.EqualFold(s.Name, slug) ||
+			(ref != "" && strings.HasPrefix(s.CreatedAt.Format(snapshotTimeFormat), ref)) {
+			return &snaps[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no snapshot named '%s'", ref)
+}
+
+// Content reads the snapshot's text
+func (s Snapshot) Content() (string, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Delete removes the snapshot
+func (s Snapshot) Delete() error {
+	return os.Remove(s.Path)
+}
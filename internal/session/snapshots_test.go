@@ -0,0 +1,59 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnapshots(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	older := time.Date(2025, 3, 4, 15, 0, 0, 0, time.Local)
+	if _, err := writeSnapshot("s1", "Before refactor!", "old screen\n", older); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := writeSnapshot("s1", "", "new screen\n", older.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Name != "16-00-00" {
+		t.Errorf("unnamed snapshot should be named by time, got %q", snap.Name)
+	}
+
+	snaps, err := ListSnapshots("s1")
+	if err != nil || len(snaps) != 2 {
+		t.Fatalf("ListSnapshots = %v, %v", snaps, err)
+	}
+	if snaps[0].Name != "16-00-00" || snaps[1].Name != "Before-refactor" || !snaps[1].CreatedAt.Equal(older) {
+		t.Errorf("snapshots should be newest first with names and times, got %+v", snaps)
+	}
+	if other, _ := ListSnapshots("s2"); len(other) != 0 {
+		t.Errorf("snapshots are per session, got %+v", other)
+	}
+
+	found, err := FindSnapshot("s1", "before refactor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := found.Content(); content != "old screen\n" {
+		t.Errorf("content = %q", content)
+	}
+	if found, err := FindSnapshot("s1", "20250304-15"); err != nil || found.Name != "Before-refactor" {
+		t.Errorf("time prefix lookup = %+v, %v", found, err)
+	}
+
+	if err := found.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindSnapshot("s1", "before-refactor"); err == nil {
+		t.Error("deleted snapshot still found")
+	}
+
+	// Deleting the session takes the rest with it
+	if err := DeleteSnapshots("s1"); err != nil {
+		t.Fatal(err)
+	}
+	if snaps, _ := ListSnapshots("s1"); len(snaps) != 0 {
+		t.Errorf("snapshots left after DeleteSnapshots: %+v", snaps)
+	}
+}
@@ -0,0 +1,208 @@
+package session
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/terminal"
+)
+
+// Sources for RemoteHost.Source
+const (
+	HostSourceConfig    = "config"     // [hosts.<name>] in config.toml
+	HostSourceSSHConfig = "ssh_config" // Host entry in ~/.ssh/config
+)
+
+// RemoteHost is a host sessions can run on with `--container ssh:<name>`
+type RemoteHost struct {
+	Name        string `json:"name"`
+	HostName    string `json:"hostname,omitempty"`
+	User        string `json:"user,omitempty"`
+	Port        int    `json:"port,omitempty"`
+	ProxyJump   string `json:"proxy_jump,omitempty"`
+	Mosh        bool   `json:"mosh,omitempty"`
+	Description string `json:"description,omitempty"`
+	Source      string `json:"source"`
+}
+
+// ParseSSHConfig returns the concrete Host aliases in an ssh_config file.
+// Wildcard and negated patterns are skipped; like ssh, the first value of a
+// key wins. Include directives are returned separately for the caller.
+func ParseSSHConfig(r io.Reader) (hosts []RemoteHost, includes []string) {
+	var current []int // Indexes into hosts for the active Host block
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value := splitSSHConfigLine(line)
+		switch key {
+		case "host":
+			current = current[:0]
+			for _, pattern := range strings.Fields(value) {
+				if strings.ContainsAny(pattern, "*?!") {
+					continue
+				}
+				current = append(current, len(hosts))
+				hosts = append(hosts, RemoteHost{Name: pattern, Source: HostSourceSSHConfig})
+			}
+		case "match":
+			current = current[:0]
+		case "include":
+			includes = append(includes, strings.Fields(value)...)
+		}
+		for _, idx := range current {
+			h := &hosts[idx]
+			switch key {
+			case "hostname":
+				if h.HostName == "" {
+					h.HostName = value
+				}
+			case "user":
+				if h.User == "" {
+					h.User = value
+				}
+			case "port":
+				if h.Port == 0 {
+					h.Port, _ = strconv.Atoi(value)
+				}
+			case "proxyjump":
+				if h.ProxyJump == "" {
+					h.ProxyJump = value
+				}
+			}
+		}
+	}
+	return hosts, includes
+}
+
+// splitSSHConfigLine splits "Key value" or "Key=value" into a lowercased key and value
+func splitSSHConfigLine(line string) (string, string) {
+	idx := strings.IndexAny(line, " \t=")
+	if idx < 0 {
+		return strings.ToLower(line), ""
+	}
+	key := strings.ToLower(line[:idx])
+	value := strings.TrimLeft(line[idx:], " \t=")
+	return key, strings.Trim(strings.TrimSpace(value), `"`)
+}
+
+// LoadSSHConfigHosts reads ~/.ssh/config and the files it includes
+func LoadSSHConfigHosts() []RemoteHost {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	sshDir := filepath.Join(home, ".ssh")
+	seen := make(map[string]bool)
+	var load func(path string, depth int) []RemoteHost
+	load = func(path string, depth int) []RemoteHost {
+		if seen[path] || depth > 5 {
+			return nil
+		}
+		seen[path] = true
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		hosts, includes := ParseSSHConfig(f)
+		f.Close()
+		for _, inc := range includes {
+			inc = expandHomePath(inc)
+			if !filepath.IsAbs(inc) {
+				inc = filepath.Join(sshDir, inc) // Relative includes are under ~/.ssh
+			}
+			matches, _ := filepath.Glob(inc)
+			for _, m := range matches {
+				hosts = append(hosts, load(m, depth+1)...)
+			}
+		}
+		return hosts
+	}
+	return load(filepath.Join(sshDir, "config"), 0)
+}
+
+// ListRemoteHosts returns hosts declared in config.toml followed by
+// ~/.ssh/config aliases, sorted by name. A config.toml host shadows an
+// ssh_config alias of the same name.
+func ListRemoteHosts() []RemoteHost {
+	var hosts []RemoteHost
+	seen := make(map[string]bool)
+	for _, name := range GetHostNames() {
+		def := GetHost(name)
+		seen[name] = true
+		hosts = append(hosts, RemoteHost{
+			Name:        name,
+			HostName:    def.HostName(name),
+			User:        def.User,
+			Port:        def.Port,
+			ProxyJump:   def.ProxyJump,
+			Mosh:        def.Mosh,
+			Description: def.Description,
+			Source:      HostSourceConfig,
+		})
+	}
+	var fromSSH []RemoteHost
+	for _, h := range LoadSSHConfigHosts() {
+		if !seen[h.Name] {
+			seen[h.Name] = true
+			fromSSH = append(fromSSH, h)
+		}
+	}
+	sort.Slice(fromSSH, func(i, j int) bool { return fromSSH[i].Name < fromSSH[j].Name })
+	return append(hosts, fromSSH...)
+}
+
+// SSHArgs returns the ssh arguments that reach host. Names declared in
+// config.toml expand to their settings; anything else is passed to ssh as is,
+// so ~/.ssh/config (ProxyJump, Port, IdentityFile) applies.
+func SSHArgs(host string) []string {
+	def := GetHost(host)
+	if def == nil {
+		return []string{host}
+	}
+	var args []string
+	if def.Port != 0 {
+		args = append(args, "-p", strconv.Itoa(def.Port))
+	}
+	if def.ProxyJump != "" {
+		args = append(args, "-J", def.ProxyJump)
+	}
+	if def.IdentityFile != "" {
+		args = append(args, "-i", expandHomePath(def.IdentityFile))
+	}
+	target := def.HostName(host)
+	if def.User != "" {
+		target = def.User + "@" + target
+	}
+	return append(args, target)
+}
+
+// MoshArgs returns the mosh arguments that reach host, or nil unless host is
+// declared in config.toml with mosh = true. ssh settings move into --ssh, as
+// mosh still uses ssh to start mosh-server.
+func MoshArgs(host string) []string {
+	def := GetHost(host)
+	if def == nil || !def.Mosh {
+		return nil
+	}
+	sshArgs := SSHArgs(host)
+	target := sshArgs[len(sshArgs)-1]
+	var args []string
+	if opts := sshArgs[:len(sshArgs)-1]; len(opts) > 0 {
+		for i, opt := range opts {
+			opts[i] = terminal.ShellQuote(opt)
+		}
+		args = append(args, "--ssh=ssh "+strings.Join(opts, " "))
+	}
+	if def.MoshPort != "" {
+		args = append(args, "-p", def.MoshPort)
+	}
+	return append(args, target)
+}
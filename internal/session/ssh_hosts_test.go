@@ -0,0 +1,87 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSSHConfig(t *testing.T) {
+	config := `
+# Personal hosts
+Host bastion
+    HostName bastion.example.com
+    User ops
+
+Host gpu gpu-alias
+    HostName=10.0.0.5
+    Port 2222
+    ProxyJump bastion
+    Port 22
+
+Host *.internal !skip
+    User nobody
+
+Include config.d/*
+`
+	hosts, includes := ParseSSHConfig(strings.NewReader(config))
+	if len(hosts) != 3 {
+		t.Fatalf("hosts = %+v, want bastion, gpu, gpu-alias", hosts)
+	}
+	if hosts[0].Name != "bastion" || hosts[0].HostName != "bastion.example.com" || hosts[0].User != "ops" {
+		t.Errorf("bastion = %+v", hosts[0])
+	}
+	gpu := hosts[1]
+	if gpu.HostName != "10.0.0.5" || gpu.Port != 2222 || gpu.ProxyJump != "bastion" || gpu.User != "" {
+		t.Errorf("gpu = %+v (first Port wins, no User from wildcard block)", gpu)
+	}
+	if hosts[2].Name != "gpu-alias" || hosts[2].Port != 2222 {
+		t.Errorf("gpu-alias = %+v", hosts[2])
+	}
+	if len(includes) != 1 || includes[0] != "config.d/*" {
+		t.Errorf("includes = %v", includes)
+	}
+}
+
+func TestContainerWrapCommandSSH(t *testing.T) {
+	spec := &ContainerSpec{Kind: ContainerSSH, Target: "devbox-not-in-config", Workdir: "/srv/app"}
+
+	if got, want := spec.WrapCommand("npm test", "", "/local"), `ssh -t devbox-not-in-config 'cd /srv/app && npm test'`; got != want {
+		t.Errorf("raw command:\n got  %s\n want %s", got, want)
+	}
+	want := `bash -c 'claude() { ssh -t devbox-not-in-config "cd /srv/app && claude $(printf '\''%q '\'' "$@")"; }; claude --resume x'`
+	if got := spec.WrapCommand("claude --resume x", "claude", "/local"); got != want {
+		t.Errorf("agent shim:\n got  %s\n want %s", got, want)
+	}
+	spec.Workdir = ""
+	if got := spec.WrapCommand("", "", "/local"); got != "ssh -t devbox-not-in-config" {
+		t.Errorf("shell = %q", got)
+	}
+}
+
+func TestContainerWrapCommandMosh(t *testing.T) {
+	userConfigCacheMu.Lock()
+	origCache := userConfigCache
+	userConfigCache = &UserConfig{Hosts: map[string]HostDef{
+		"flaky": {Host: "10.0.0.9", User: "dev", Port: 2222, Mosh: true, MoshPort: "60001"},
+		"plain": {Host: "10.0.0.8"},
+	}}
+	userConfigCacheMu.Unlock()
+	defer func() {
+		userConfigCacheMu.Lock()
+		userConfigCache = origCache
+		userConfigCacheMu.Unlock()
+	}()
+
+	if args := MoshArgs("plain"); args != nil {
+		t.Errorf("MoshArgs(plain) = %v, want nil", args)
+	}
+	spec := &ContainerSpec{Kind: ContainerSSH, Target: "flaky", Workdir: "/srv/app"}
+	want := `mosh '--ssh=ssh -p 2222' -p 60001 dev@10.0.0.9 -- sh -c 'cd /srv/app && exec "$0" "$@"' sh -c 'npm test'`
+	if got := spec.WrapCommand("npm test", "", "/local"); got != want {
+		t.Errorf("mosh:\n got  %s\n want %s", got, want)
+	}
+	spec.Target = "plain"
+	if got := spec.WrapCommand("npm test", "", "/local"); got != `ssh -t 10.0.0.8 'cd /srv/app && npm test'` {
+		t.Errorf("ssh host = %s", got)
+	}
+}
@@ -0,0 +1,169 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// StatusReport is an externally reported session state, sent by
+// `agent-deck notify` (hooks, scripts) to running TUIs over a unix socket.
+type StatusReport struct {
+	SessionID string `json:"session_id"`
+	Status    Status `json:"status"`
+	Message   string `json:"message,omitempty"` // New status text
+	Clear     bool   `json:"clear,omitempty"`   // Remove the status text
+	Hook      bool   `json:"hook,omitempty"`    // Sent by a Claude Code hook
+}
+
+// reportedStatusHold bounds how long a reported status overrides detection
+// when the pane shows no new activity
+const reportedStatusHold = 5 * time.Minute
+
+// ParseReportStatus validates a status name accepted by notify
+func ParseReportStatus(s string) (Status, error) {
+	switch Status(strings.ToLower(strings.TrimSpace(s))) {
+	case StatusRunning:
+		return StatusRunning, nil
+	case StatusWaiting:
+		return StatusWaiting, nil
+	case StatusIdle:
+		return StatusIdle, nil
+	}
+	return "", fmt.Errorf("invalid status %q (valid: running, waiting, idle)", s)
+}
+
+// claudeHookPayload is the subset of the Claude Code hook stdin JSON we use
+type claudeHookPayload struct {
+	HookEventName string `json:"hook_event_name"`
+	Message       string `json:"message"`
+}
+
+// ParseClaudeHookPayload maps a Claude Code hook event to a status report
+// (without SessionID). Returns ok=false for events that don't change state.
+func ParseClaudeHookPayload(data []byte) (report StatusReport, ok bool, err error) {
+	var p claudeHookPayload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return StatusReport{}, false, fmt.Errorf("invalid hook payload: %w", err)
+	}
+	switch p.HookEventName {
+	case "Stop", "SubagentStop":
+		return StatusReport{Status: StatusWaiting, Hook: true}, true, nil
+	case "Notification":
+		return StatusReport{Status: StatusWaiting, Message: strings.TrimSpace(p.Message), Hook: true}, true, nil
+	case "PreToolUse", "UserPromptSubmit":
+		return StatusReport{Status: StatusRunning, Hook: true}, true, nil
+	}
+	return StatusReport{}, false, nil
+}
+
+// NotifySocketDir returns the directory holding one notify socket per running TUI
+func NotifySocketDir(profile string) (string, error) {
+	dir, err := GetProfileDir(profile)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "notify"), nil
+}
+
+// NotifySocketPath returns the notify socket path for this process
+func NotifySocketPath(profile string) (string, error) {
+	dir, err := NotifySocketDir(profile)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("%d.sock", os.Getpid())), nil
+}
+
+// SendStatusReport delivers report to every TUI listening for the profile.
+// Returns how many received it; sockets of exited TUIs are removed.
+func SendStatusReport(profile string, report StatusReport) (int, error) {
+	dir, err := NotifySocketDir(profile)
+	if err != nil {
+		return 0, err
+	}
+	sockets, _ := filepath.Glob(filepath.Join(dir, "*.sock"))
+	data, err := json.Marshal(report)
+	if err != nil {
+		return 0, err
+	}
+	data = append(data, '\n')
+
+	delivered := 0
+	for _, path := range sockets {
+		conn, err := net.DialTimeout("unix", path, 500*time.Millisecond)
+		if err != nil {
+			_ = os.Remove(path) // Stale: TUI exited without cleanup
+			continue
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(500 * time.Millisecond))
+		if _, err := conn.Write(data); err == nil {
+			delivered++
+		}
+		conn.Close()
+	}
+	return delivered, nil
+}
+
+// ApplyStatusReport applies an externally reported status and status text.
+// The status overrides screen-scraped detection until the pane shows new
+// activity; an empty status leaves it alone. A message replaces StatusText,
+// Clear removes it, and a hook report without a message clears text set by an
+// earlier hook. Returns true if StatusText changed (and needs saving).
+func (i *Instance) ApplyStatusReport(report StatusReport) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	prevText := i.StatusText
+	switch {
+	case report.Message != "":
+		i.StatusText = report.Message
+		i.StatusTextFromHook = report.Hook
+	case report.Clear, report.Hook && i.StatusTextFromHook:
+		i.StatusText = ""
+		i.StatusTextFromHook = false
+	}
+
+	if report.Status != "" {
+		prevStatus := i.Status
+		i.Status = report.Status
+		i.reportedStatus = report.Status
+		i.reportedAt = time.Now()
+
+		// Same end-of-turn work UpdateStatus does when it sees the transition
+		if prevStatus == StatusRunning && (report.Status == StatusWaiting || report.Status == StatusIdle) {
+			i.maybeAutoCheckpoint()
+		}
+		i.maybeRefreshSummary(prevStatus)
+	}
+	return i.StatusText != prevText
+}
+
+// GetStatusText returns the custom status text ("" if none)
+func (i *Instance) GetStatusText() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.StatusText
+}
+
+// SetStatusText sets (or clears, with "") the custom status text manually
+func (i *Instance) SetStatusText(text string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.StatusText = text
+	i.StatusTextFromHook = false
+}
+
+// reportedStatusHolds reports whether the reported status still applies given
+// the pane's last activity (unix seconds, 0 if unknown). Must hold i.mu.
+func (i *Instance) reportedStatusHolds(activity int64) bool {
+	if i.reportedStatus == "" || time.Since(i.reportedAt) > reportedStatusHold {
+		return false
+	}
+	// Allow a second for the agent's own redraw after firing the hook
+	return activity == 0 || activity <= i.reportedAt.Unix()+1
+}
@@ -0,0 +1,112 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseClaudeHookPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    StatusReport
+		ok      bool
+	}{
+		{"stop", `{"hook_event_name":"Stop","stop_hook_active":false}`, StatusReport{Status: StatusWaiting, Hook: true}, true},
+		{"notification", `{"hook_event_name":"Notification","message":" Claude needs your permission to use Bash "}`,
+			StatusReport{Status: StatusWaiting, Message: "Claude needs your permission to use Bash", Hook: true}, true},
+		{"pre tool use", `{"hook_event_name":"PreToolUse","tool_name":"Bash"}`, StatusReport{Status: StatusRunning, Hook: true}, true},
+		{"ignored event", `{"hook_event_name":"SessionStart"}`, StatusReport{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := ParseClaudeHookPayload([]byte(tt.payload))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("got %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	if _, _, err := ParseClaudeHookPayload([]byte("not json")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestParseReportStatus(t *testing.T) {
+	if s, err := ParseReportStatus(" Waiting "); err != nil || s != StatusWaiting {
+		t.Errorf("ParseReportStatus(Waiting) = %q, %v", s, err)
+	}
+	if _, err := ParseReportStatus("error"); err == nil {
+		t.Error("expected error for status that can't be reported")
+	}
+}
+
+func TestApplyStatusReport(t *testing.T) {
+	inst := &Instance{Status: StatusIdle, lastSummaryAt: time.Now()}
+
+	if !inst.ApplyStatusReport(StatusReport{Status: StatusWaiting, Message: "needs approval"}) {
+		t.Error("expected status text change")
+	}
+	if inst.Status != StatusWaiting || inst.GetStatusText() != "needs approval" {
+		t.Fatalf("status = %s, text = %q", inst.Status, inst.GetStatusText())
+	}
+
+	// Status-only report keeps the text
+	if inst.ApplyStatusReport(StatusReport{Status: StatusRunning}) {
+		t.Error("status-only report should not change text")
+	}
+	if inst.Status != StatusRunning || inst.GetStatusText() != "needs approval" {
+		t.Errorf("status = %s, text = %q", inst.Status, inst.GetStatusText())
+	}
+
+	inst.ApplyStatusReport(StatusReport{Clear: true})
+	if inst.GetStatusText() != "" || inst.Status != StatusRunning {
+		t.Errorf("after clear: status = %s, text = %q", inst.Status, inst.GetStatusText())
+	}
+}
+
+func TestApplyStatusReportHookText(t *testing.T) {
+	inst := &Instance{Status: StatusRunning, lastSummaryAt: time.Now()}
+
+	// Text from a Notification hook is cleared by the next hook event
+	inst.ApplyStatusReport(StatusReport{Status: StatusWaiting, Message: "Claude needs your permission", Hook: true})
+	inst.ApplyStatusReport(StatusReport{Status: StatusRunning, Hook: true})
+	if got := inst.GetStatusText(); got != "" {
+		t.Errorf("hook text should clear on next hook report, got %q", got)
+	}
+
+	// Manual text survives hook reports
+	inst.SetStatusText("running tests")
+	inst.ApplyStatusReport(StatusReport{Status: StatusWaiting, Hook: true})
+	if got := inst.GetStatusText(); got != "running tests" {
+		t.Errorf("manual text = %q, want it kept", got)
+	}
+}
+
+func TestReportedStatusHolds(t *testing.T) {
+	inst := &Instance{}
+	if inst.reportedStatusHolds(0) {
+		t.Error("no report should not hold")
+	}
+
+	inst.reportedStatus = StatusWaiting
+	inst.reportedAt = time.Now()
+	reported := inst.reportedAt.Unix()
+	if !inst.reportedStatusHolds(reported - 10) {
+		t.Error("report should hold while pane is unchanged")
+	}
+	if !inst.reportedStatusHolds(reported + 1) {
+		t.Error("report should survive the agent's own redraw")
+	}
+	if inst.reportedStatusHolds(reported + 5) {
+		t.Error("new pane activity should end the report")
+	}
+
+	inst.reportedAt = time.Now().Add(-reportedStatusHold - time.Second)
+	if inst.reportedStatusHolds(0) {
+		t.Error("expired report should not hold")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/asheshgoplani/agent-deck/internal/logging"
@@ -53,6 +54,9 @@ type StorageData struct {
 	Instances []*InstanceData `json:"instances"`
 	Groups    []*GroupData    `json:"groups,omitempty"` // Persist empty groups
 	UpdatedAt time.Time       `json:"updated_at"`
+
+	// Tombstones maps deleted session IDs to when they were deleted (sync only)
+	Tombstones map[string]time.Time `json:"tombstones,omitempty"`
 }
 
 // InstanceData represents the serializable session data
@@ -69,6 +73,7 @@ type InstanceData struct {
 	Status          Status    `json:"status"`
 	CreatedAt       time.Time `json:"created_at"`
 	LastAccessedAt  time.Time `json:"last_accessed_at,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at,omitempty"`
 	TmuxSession     string    `json:"tmux_session"`
 
 	// Worktree support
@@ -102,6 +107,61 @@ type InstanceData struct {
 
 	// MCP tracking (persisted for sync status display)
 	LoadedMCPNames []string `json:"loaded_mcp_names,omitempty"`
+
+	// Auto-checkpoint override (nil = use global config)
+	AutoCheckpoint *bool `json:"auto_checkpoint,omitempty"`
+
+	// Custom status text (see Instance.StatusText)
+	StatusText         string `json:"status_text,omitempty"`
+	StatusTextFromHook bool   `json:"status_text_hook,omitempty"`
+
+	// Container execution (see Instance.Container)
+	Container *ContainerSpec `json:"container,omitempty"`
+
+	// Notes and queued first prompt (see Instance.Notes, Instance.PendingPrompt)
+	Notes         string `json:"notes,omitempty"`
+	PendingPrompt string `json:"pending_prompt,omitempty"`
+
+	// Auto-attach when waiting (see Instance.AutoAttach)
+	AutoAttach string `json:"auto_attach,omitempty"`
+
+	// Linked Linear/Jira ticket (see Instance.Ticket)
+	Ticket *Ticket `json:"ticket,omitempty"`
+
+	// tmux server the session runs on (see tmux.Session.SocketName)
+	TmuxSocket string `json:"tmux_socket,omitempty"`
+
+	// Origin remote of the project repo (see Instance.GitRemote)
+	GitRemote string `json:"git_remote,omitempty"`
+
+	// Attach and lifecycle hooks (see Instance.PreAttachHook, PreStartHook)
+	PreAttachHook  string `json:"pre_attach_hook,omitempty"`
+	PostDetachHook string `json:"post_detach_hook,omitempty"`
+	PreStartHook   string `json:"pre_start_hook,omitempty"`
+	PostExitHook   string `json:"post_exit_hook,omitempty"`
+
+	// Where-I-left-off note (see Instance.HandoffNote)
+	HandoffNote string    `json:"handoff_note,omitempty"`
+	HandoffAt   time.Time `json:"handoff_at,omitempty"`
+
+	// Key files and paths for the session (see Instance.ContextFiles)
+	ContextFiles []string `json:"context_files,omitempty"`
+
+	// Generated title that automatic naming may replace (see Instance.AutoTitle)
+	AutoTitle     string `json:"auto_title,omitempty"`
+	AutoTitleFrom string `json:"auto_title_from,omitempty"`
+
+	// YoloMode overrides a custom tool's dangerous_mode (nil = the tool's)
+	YoloMode *bool `json:"yolo_mode,omitempty"`
+
+	// File watch triggers (see Instance.Watch)
+	Watch *FileWatch `json:"watch,omitempty"`
+
+	// Template is the [templates.*] entry the session was created from
+	Template string `json:"template,omitempty"`
+
+	// Env is exported before the session's command
+	Env map[string]string `json:"env,omitempty"`
 }
 
 // GroupData represents serializable group data
@@ -111,6 +171,7 @@ type GroupData struct {
 	Expanded    bool   `json:"expanded"`
 	Order       int    `json:"order"`
 	DefaultPath string `json:"default_path,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty"`
 }
 
 // Storage handles persistence of session data via SQLite.
@@ -121,6 +182,13 @@ type Storage struct {
 	dbPath  string     // Path to state.db (for change detection)
 	profile string     // The profile this storage is for
 	mu      sync.Mutex // Protects operations during transition
+	linted  bool       // Storage lint warnings were logged on first load
+
+	// Sessions in the database as of the last load or save, and when that
+	// was: saves only delete sessions this storage knew of (see
+	// statedb.MergeInstances), so concurrent writers don't lose each other's
+	known    map[string]bool
+	syncedAt time.Time
 }
 
 // NewStorageWithProfile creates a storage instance for a specific profile.
@@ -198,6 +266,20 @@ func NewStorageWithProfile(profile string) (*Storage, error) {
 	}, nil
 }
 
+// readOnly makes every Storage in this process skip writes (see SetReadOnly)
+var readOnly atomic.Bool
+
+// SetReadOnly turns saves and deletes into no-ops for this process, so a TUI
+// started next to another instance can browse without clobbering its saves.
+func SetReadOnly(ro bool) {
+	readOnly.Store(ro)
+}
+
+// IsReadOnly reports whether SetReadOnly(true) is in effect
+func IsReadOnly() bool {
+	return readOnly.Load()
+}
+
 // Profile returns the profile name this storage is using
 func (s *Storage) Profile() string {
 	return s.profile
@@ -228,8 +310,14 @@ func (s *Storage) Save(instances []*Instance) error {
 }
 
 // SaveWithGroups persists instances and groups to SQLite.
-// Converts Instance objects to database rows, then batch-inserts in a transaction.
+// Converts Instance objects to database rows, then merges them in a
+// transaction under the cross-process write lock: sessions another process
+// added since this storage last loaded are kept, and ones it deleted are not
+// brought back.
 func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) error {
+	if IsReadOnly() {
+		return nil
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -240,20 +328,50 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 	// Convert instances to database rows
 	rows := make([]*statedb.InstanceRow, len(instances))
 	for i, inst := range instances {
-		tmuxName := ""
+		tmuxName, tmuxSocket := "", ""
 		if inst.tmuxSession != nil {
 			tmuxName = inst.tmuxSession.Name
+			tmuxSocket = inst.tmuxSession.SocketName
 		}
 
-		toolData := statedb.MarshalToolData(
-			inst.ClaudeSessionID, inst.ClaudeDetectedAt,
-			inst.GeminiSessionID, inst.GeminiDetectedAt,
-			inst.GeminiYoloMode, inst.GeminiModel,
-			inst.OpenCodeSessionID, inst.OpenCodeDetectedAt,
-			inst.CodexSessionID, inst.CodexDetectedAt,
-			inst.LatestPrompt, inst.LoadedMCPNames,
-			inst.ToolOptionsJSON,
-		)
+		toolData := statedb.MarshalToolData(&statedb.ToolData{
+			ClaudeSessionID:    inst.ClaudeSessionID,
+			ClaudeDetectedAt:   inst.ClaudeDetectedAt,
+			GeminiSessionID:    inst.GeminiSessionID,
+			GeminiDetectedAt:   inst.GeminiDetectedAt,
+			GeminiYoloMode:     inst.GeminiYoloMode,
+			GeminiModel:        inst.GeminiModel,
+			OpenCodeSessionID:  inst.OpenCodeSessionID,
+			OpenCodeDetectedAt: inst.OpenCodeDetectedAt,
+			CodexSessionID:     inst.CodexSessionID,
+			CodexDetectedAt:    inst.CodexDetectedAt,
+			LatestPrompt:       inst.LatestPrompt,
+			LoadedMCPNames:     inst.LoadedMCPNames,
+			ToolOptions:        inst.ToolOptionsJSON,
+			AutoCheckpoint:     inst.AutoCheckpoint,
+			StatusText:         inst.StatusText,
+			StatusTextFromHook: inst.StatusTextFromHook,
+			Container:          marshalContainerSpec(inst.Container),
+			Notes:              inst.Notes,
+			PendingPrompt:      inst.GetPendingPrompt(),
+			AutoAttach:         inst.AutoAttach,
+			Ticket:             marshalTicket(inst.Ticket),
+			TmuxSocket:         tmuxSocket,
+			GitRemote:          inst.GitRemote,
+			PreAttachHook:      inst.PreAttachHook,
+			PostDetachHook:     inst.PostDetachHook,
+			PreStartHook:       inst.PreStartHook,
+			PostExitHook:       inst.PostExitHook,
+			HandoffNote:        inst.HandoffNote,
+			HandoffAt:          inst.HandoffAt,
+			ContextFiles:       inst.ContextFiles,
+			AutoTitle:          inst.AutoTitle,
+			AutoTitleFrom:      inst.AutoTitleFrom,
+			YoloMode:           inst.YoloMode,
+			Watch:              marshalFileWatch(inst.Watch),
+			Template:           inst.Template,
+			Env:                inst.Env,
+		})
 
 		rows[i] = &statedb.InstanceRow{
 			ID:              inst.ID,
@@ -268,6 +386,7 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 			TmuxSession:     tmuxName,
 			CreatedAt:       inst.CreatedAt,
 			LastAccessed:    inst.LastAccessedAt,
+			UpdatedAt:       inst.UpdatedAt,
 			ParentSessionID: inst.ParentSessionID,
 			WorktreePath:    inst.WorktreePath,
 			WorktreeRepo:    inst.WorktreeRepoRoot,
@@ -276,9 +395,31 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 		}
 	}
 
-	if err := s.db.SaveInstances(rows); err != nil {
+	unlock, err := s.db.LockWrites()
+	if err != nil {
 		return fmt.Errorf("failed to save instances: %w", err)
 	}
+	defer unlock()
+	syncedAt := time.Now()
+	kept, dropped, err := s.db.MergeInstances(rows, s.known, s.syncedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save instances: %w", err)
+	}
+	if len(kept) > 0 || len(dropped) > 0 {
+		storageLog.Info("save_merged_concurrent_changes",
+			slog.Int("kept_added", len(kept)),
+			slog.Int("skipped_deleted", len(dropped)))
+	}
+	// Only rows this process wrote become known: kept rows were added by
+	// another writer and stay undeletable until this process loads them
+	known := make(map[string]bool, len(rows))
+	for _, r := range rows {
+		known[r.ID] = true
+	}
+	for _, id := range dropped {
+		delete(known, id)
+	}
+	s.known, s.syncedAt = known, syncedAt
 
 	// Save groups (including empty ones)
 	if groupTree != nil {
@@ -290,6 +431,7 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 				Expanded:    g.Expanded,
 				Order:       g.Order,
 				DefaultPath: g.DefaultPath,
+				Hidden:      g.Hidden,
 			})
 		}
 		if err := s.db.SaveGroups(groupRows); err != nil {
@@ -303,9 +445,21 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 	return nil
 }
 
+// markLoaded records the sessions just loaded as known (see Storage.known)
+func (s *Storage) markLoaded(rows []*statedb.InstanceRow, at time.Time) {
+	s.known = make(map[string]bool, len(rows))
+	for _, r := range rows {
+		s.known[r.ID] = true
+	}
+	s.syncedAt = at
+}
+
 // DeleteInstance removes a single instance from the database by ID.
 // This ensures the row is immediately removed, preventing resurrection on reload.
 func (s *Storage) DeleteInstance(id string) error {
+	if IsReadOnly() {
+		return nil
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -316,6 +470,8 @@ func (s *Storage) DeleteInstance(id string) error {
 	if err := s.db.DeleteInstance(id); err != nil {
 		return fmt.Errorf("failed to delete instance %s: %w", id, err)
 	}
+	// Deleted here, so saving it again (undo) restores it
+	delete(s.known, id)
 
 	_ = s.db.Touch()
 	return nil
@@ -325,6 +481,9 @@ func (s *Storage) DeleteInstance(id string) error {
 // This is a lightweight save for visual state like group expanded/collapsed.
 // It does NOT call Touch() to avoid triggering StorageWatcher reloads on other instances.
 func (s *Storage) SaveGroupsOnly(groupTree *GroupTree) error {
+	if IsReadOnly() {
+		return nil
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -344,6 +503,7 @@ func (s *Storage) SaveGroupsOnly(groupTree *GroupTree) error {
 			Expanded:    g.Expanded,
 			Order:       g.Order,
 			DefaultPath: g.DefaultPath,
+			Hidden:      g.Hidden,
 		})
 	}
 
@@ -373,10 +533,12 @@ func (s *Storage) LoadLite() ([]*InstanceData, []*GroupData, error) {
 	}
 
 	// Load from SQLite
+	loadedAt := time.Now()
 	dbRows, err := s.db.LoadInstances()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load instances: %w", err)
 	}
+	s.markLoaded(dbRows, loadedAt)
 
 	dbGroups, err := s.db.LoadGroups()
 	if err != nil {
@@ -386,45 +548,7 @@ func (s *Storage) LoadLite() ([]*InstanceData, []*GroupData, error) {
 	// Convert to InstanceData format (for backward compat with CLI commands)
 	instances := make([]*InstanceData, len(dbRows))
 	for i, r := range dbRows {
-		claudeSID, claudeAt,
-			geminiSID, geminiAt,
-			geminiYolo, geminiModel,
-			opencodeSID, opencodeAt,
-			codexSID, codexAt,
-			latestPrompt, loadedMCPs,
-			toolOpts := statedb.UnmarshalToolData(r.ToolData)
-
-		instances[i] = &InstanceData{
-			ID:                 r.ID,
-			Title:              r.Title,
-			ProjectPath:        r.ProjectPath,
-			GroupPath:          r.GroupPath,
-			Order:              r.Order,
-			ParentSessionID:    r.ParentSessionID,
-			Command:            r.Command,
-			Wrapper:            r.Wrapper,
-			Tool:               r.Tool,
-			Status:             Status(r.Status),
-			CreatedAt:          r.CreatedAt,
-			LastAccessedAt:     r.LastAccessed,
-			TmuxSession:        r.TmuxSession,
-			WorktreePath:       r.WorktreePath,
-			WorktreeRepoRoot:   r.WorktreeRepo,
-			WorktreeBranch:     r.WorktreeBranch,
-			ClaudeSessionID:    claudeSID,
-			ClaudeDetectedAt:   claudeAt,
-			GeminiSessionID:    geminiSID,
-			GeminiDetectedAt:   geminiAt,
-			GeminiYoloMode:     geminiYolo,
-			GeminiModel:        geminiModel,
-			OpenCodeSessionID:  opencodeSID,
-			OpenCodeDetectedAt: opencodeAt,
-			CodexSessionID:     codexSID,
-			CodexDetectedAt:    codexAt,
-			LatestPrompt:       latestPrompt,
-			ToolOptionsJSON:    toolOpts,
-			LoadedMCPNames:     loadedMCPs,
-		}
+		instances[i] = rowToInstanceData(r)
 	}
 
 	// Convert groups
@@ -436,12 +560,107 @@ func (s *Storage) LoadLite() ([]*InstanceData, []*GroupData, error) {
 			Expanded:    g.Expanded,
 			Order:       g.Order,
 			DefaultPath: g.DefaultPath,
+			Hidden:      g.Hidden,
 		}
 	}
 
 	return instances, groups, nil
 }
 
+// rowToInstanceData converts a database row to the InstanceData format
+// shared with CLI commands
+func rowToInstanceData(r *statedb.InstanceRow) *InstanceData {
+	td := statedb.UnmarshalToolData(r.ToolData)
+	return &InstanceData{
+		ID:                 r.ID,
+		Title:              r.Title,
+		ProjectPath:        r.ProjectPath,
+		GroupPath:          r.GroupPath,
+		Order:              r.Order,
+		ParentSessionID:    r.ParentSessionID,
+		Command:            r.Command,
+		Wrapper:            r.Wrapper,
+		Tool:               r.Tool,
+		Status:             Status(r.Status),
+		CreatedAt:          r.CreatedAt,
+		LastAccessedAt:     r.LastAccessed,
+		UpdatedAt:          r.UpdatedAt,
+		TmuxSession:        r.TmuxSession,
+		WorktreePath:       r.WorktreePath,
+		WorktreeRepoRoot:   r.WorktreeRepo,
+		WorktreeBranch:     r.WorktreeBranch,
+		ClaudeSessionID:    td.ClaudeSessionID,
+		ClaudeDetectedAt:   td.ClaudeDetectedAt,
+		GeminiSessionID:    td.GeminiSessionID,
+		GeminiDetectedAt:   td.GeminiDetectedAt,
+		GeminiYoloMode:     td.GeminiYoloMode,
+		GeminiModel:        td.GeminiModel,
+		OpenCodeSessionID:  td.OpenCodeSessionID,
+		OpenCodeDetectedAt: td.OpenCodeDetectedAt,
+		CodexSessionID:     td.CodexSessionID,
+		CodexDetectedAt:    td.CodexDetectedAt,
+		LatestPrompt:       td.LatestPrompt,
+		ToolOptionsJSON:    td.ToolOptions,
+		LoadedMCPNames:     td.LoadedMCPNames,
+		AutoCheckpoint:     td.AutoCheckpoint,
+		StatusText:         td.StatusText,
+		StatusTextFromHook: td.StatusTextFromHook,
+		Container:          unmarshalContainerSpec(td.Container),
+		Notes:              td.Notes,
+		PendingPrompt:      td.PendingPrompt,
+		AutoAttach:         td.AutoAttach,
+		Ticket:             unmarshalTicket(td.Ticket),
+		TmuxSocket:         td.TmuxSocket,
+		GitRemote:          td.GitRemote,
+		PreAttachHook:      td.PreAttachHook,
+		PostDetachHook:     td.PostDetachHook,
+		PreStartHook:       td.PreStartHook,
+		PostExitHook:       td.PostExitHook,
+		HandoffNote:        td.HandoffNote,
+		HandoffAt:          td.HandoffAt,
+		ContextFiles:       td.ContextFiles,
+		AutoTitle:          td.AutoTitle,
+		AutoTitleFrom:      td.AutoTitleFrom,
+		YoloMode:           td.YoloMode,
+		Watch:              unmarshalFileWatch(td.Watch),
+		Template:           td.Template,
+		Env:                td.Env,
+	}
+}
+
+// FindByTitle loads the sessions titled exactly title through the title
+// index, for commands that need one session rather than the whole deck
+func (s *Storage) FindByTitle(title string) ([]*Instance, error) {
+	return s.loadMatching(func() ([]*statedb.InstanceRow, error) { return s.db.FindInstancesByTitle(title) })
+}
+
+// LoadGroup loads the sessions in groupPath and its subgroups, in deck
+// order, through the group index
+func (s *Storage) LoadGroup(groupPath string) ([]*Instance, error) {
+	return s.loadMatching(func() ([]*statedb.InstanceRow, error) { return s.db.LoadGroupInstances(groupPath) })
+}
+
+// loadMatching converts the rows query returns to instances. They don't
+// count as loaded for SaveWithGroups, which needs the full list.
+func (s *Storage) loadMatching(query func() ([]*statedb.InstanceRow, error)) ([]*Instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return []*Instance{}, nil
+	}
+	rows, err := query()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load instances: %w", err)
+	}
+	data := &StorageData{Instances: make([]*InstanceData, len(rows))}
+	for i, r := range rows {
+		data.Instances[i] = rowToInstanceData(r)
+	}
+	instances, _, err := s.convertToInstances(data)
+	return instances, err
+}
+
 // LoadWithGroups reads instances and groups from SQLite, reconnects tmux sessions.
 func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 	s.mu.Lock()
@@ -453,10 +672,12 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 	}
 
 	// Load from SQLite
+	loadedAt := time.Now()
 	dbRows, err := s.db.LoadInstances()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load instances: %w", err)
 	}
+	s.markLoaded(dbRows, loadedAt)
 
 	dbGroups, err := s.db.LoadGroups()
 	if err != nil {
@@ -468,45 +689,7 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 		Instances: make([]*InstanceData, len(dbRows)),
 	}
 	for i, r := range dbRows {
-		claudeSID, claudeAt,
-			geminiSID, geminiAt,
-			geminiYolo, geminiModel,
-			opencodeSID, opencodeAt,
-			codexSID, codexAt,
-			latestPrompt, loadedMCPs,
-			toolOpts := statedb.UnmarshalToolData(r.ToolData)
-
-		data.Instances[i] = &InstanceData{
-			ID:                 r.ID,
-			Title:              r.Title,
-			ProjectPath:        r.ProjectPath,
-			GroupPath:          r.GroupPath,
-			Order:              r.Order,
-			ParentSessionID:    r.ParentSessionID,
-			Command:            r.Command,
-			Wrapper:            r.Wrapper,
-			Tool:               r.Tool,
-			Status:             Status(r.Status),
-			CreatedAt:          r.CreatedAt,
-			LastAccessedAt:     r.LastAccessed,
-			TmuxSession:        r.TmuxSession,
-			WorktreePath:       r.WorktreePath,
-			WorktreeRepoRoot:   r.WorktreeRepo,
-			WorktreeBranch:     r.WorktreeBranch,
-			ClaudeSessionID:    claudeSID,
-			ClaudeDetectedAt:   claudeAt,
-			GeminiSessionID:    geminiSID,
-			GeminiDetectedAt:   geminiAt,
-			GeminiYoloMode:     geminiYolo,
-			GeminiModel:        geminiModel,
-			OpenCodeSessionID:  opencodeSID,
-			OpenCodeDetectedAt: opencodeAt,
-			CodexSessionID:     codexSID,
-			CodexDetectedAt:    codexAt,
-			LatestPrompt:       latestPrompt,
-			ToolOptionsJSON:    toolOpts,
-			LoadedMCPNames:     loadedMCPs,
-		}
+		data.Instances[i] = rowToInstanceData(r)
 	}
 
 	// Convert groups
@@ -518,10 +701,21 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 			Expanded:    g.Expanded,
 			Order:       g.Order,
 			DefaultPath: g.DefaultPath,
+			Hidden:      g.Hidden,
 		}
 	}
 
-	return s.convertToInstances(data)
+	instances, groups, err := s.convertToInstances(data)
+	if err == nil && !s.linted {
+		s.linted = true
+		for _, issue := range LintStorage(instances, groups) {
+			storageLog.Warn("storage_lint",
+				slog.String("kind", issue.Kind),
+				slog.String("id", issue.InstanceID),
+				slog.String("issue", issue.Message))
+		}
+	}
+	return instances, groups, err
 }
 
 // GetDBPathForProfile returns the path to the state.db file for a specific profile.
@@ -618,6 +812,7 @@ func (s *Storage) convertToInstances(data *StorageData) ([]*Instance, []*GroupDa
 			)
 			// Pass instance ID for activity hooks (enables real-time status updates)
 			tmuxSess.InstanceID = instData.ID
+			tmuxSess.SocketName = instData.TmuxSocket
 			// Note: EnableMouseMode is now deferred to EnsureConfigured()
 			// Called automatically when user attaches to session
 		}
@@ -644,6 +839,7 @@ func (s *Storage) convertToInstances(data *StorageData) ([]*Instance, []*GroupDa
 			Status:             instData.Status,
 			CreatedAt:          instData.CreatedAt,
 			LastAccessedAt:     instData.LastAccessedAt,
+			UpdatedAt:          instData.UpdatedAt,
 			WorktreePath:       instData.WorktreePath,
 			WorktreeRepoRoot:   instData.WorktreeRepoRoot,
 			WorktreeBranch:     instData.WorktreeBranch,
@@ -660,6 +856,28 @@ func (s *Storage) convertToInstances(data *StorageData) ([]*Instance, []*GroupDa
 			ToolOptionsJSON:    instData.ToolOptionsJSON,
 			LatestPrompt:       instData.LatestPrompt,
 			LoadedMCPNames:     instData.LoadedMCPNames,
+			AutoCheckpoint:     instData.AutoCheckpoint,
+			StatusText:         instData.StatusText,
+			StatusTextFromHook: instData.StatusTextFromHook,
+			Container:          instData.Container,
+			Notes:              instData.Notes,
+			PendingPrompt:      instData.PendingPrompt,
+			AutoAttach:         instData.AutoAttach,
+			Ticket:             instData.Ticket,
+			GitRemote:          instData.GitRemote,
+			PreAttachHook:      instData.PreAttachHook,
+			PostDetachHook:     instData.PostDetachHook,
+			PreStartHook:       instData.PreStartHook,
+			PostExitHook:       instData.PostExitHook,
+			HandoffNote:        instData.HandoffNote,
+			HandoffAt:          instData.HandoffAt,
+			ContextFiles:       instData.ContextFiles,
+			AutoTitle:          instData.AutoTitle,
+			AutoTitleFrom:      instData.AutoTitleFrom,
+			YoloMode:           instData.YoloMode,
+			Watch:              instData.Watch,
+			Template:           instData.Template,
+			Env:                instData.Env,
 			tmuxSession:        tmuxSess,
 		}
 
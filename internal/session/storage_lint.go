@@ -0,0 +1,121 @@
+package session
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Storage lint issue kinds
+const (
+	LintDuplicateID   = "duplicate_id"
+	LintEmptyTitle    = "empty_title"
+	LintMissingGroup  = "missing_group"
+	LintUnknownTool   = "unknown_tool"
+	LintMissingParent = "missing_parent"
+)
+
+// builtinTools are the tools agent-deck knows without a [tools] entry
+var builtinTools = map[string]bool{
+	"claude": true, "gemini": true, "opencode": true,
+	"codex": true, "shell": true, "cursor": true, "aider": true,
+}
+
+// LintIssue is a problem found in stored sessions. Fixable issues are
+// repaired by FixStorage (agent-deck doctor --fix).
+type LintIssue struct {
+	Kind       string `json:"kind"`
+	InstanceID string `json:"instance_id"`
+	Title      string `json:"title"`
+	Message    string `json:"message"`
+	Fixable    bool   `json:"fixable"`
+}
+
+// String formats the issue for logs and doctor output
+func (l LintIssue) String() string {
+	name := l.Title
+	if name == "" {
+		name = l.InstanceID
+	}
+	return fmt.Sprintf("%s: %s", name, l.Message)
+}
+
+// LintStorage checks stored sessions for duplicate IDs, empty titles, group
+// paths without a stored group, tools that are neither built in nor defined
+// in [tools], and parents that no longer exist
+func LintStorage(instances []*Instance, groups []*GroupData) []LintIssue {
+	groupPaths := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		groupPaths[g.Path] = true
+	}
+	ids := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		ids[inst.ID] = true
+	}
+
+	var issues []LintIssue
+	seen := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		issue := func(kind string, fixable bool, format string, args ...any) {
+			issues = append(issues, LintIssue{
+				Kind:       kind,
+				InstanceID: inst.ID,
+				Title:      inst.Title,
+				Message:    fmt.Sprintf(format, args...),
+				Fixable:    fixable,
+			})
+		}
+
+		if seen[inst.ID] {
+			issue(LintDuplicateID, true, "id %s is used by another session", inst.ID)
+		}
+		seen[inst.ID] = true
+
+		if strings.TrimSpace(inst.Title) == "" {
+			issue(LintEmptyTitle, true, "title is empty")
+		}
+		if group := inst.GroupPath; group != "" && !groupPaths[group] {
+			issue(LintMissingGroup, true, "group %q does not exist", group)
+		}
+		if tool := inst.Tool; tool != "" && !builtinTools[tool] && GetToolDef(tool) == nil {
+			issue(LintUnknownTool, false, "tool %q is not built in or defined in [tools]", tool)
+		}
+		if p := inst.ParentSessionID; p != "" && (p == inst.ID || !ids[p]) {
+			issue(LintMissingParent, true, "parent session %s does not exist", p)
+		}
+	}
+	return issues
+}
+
+// FixStorage repairs the fixable issues in place: later duplicates get a new
+// ID, empty titles become the project folder's name and dangling parents are
+// cleared. Missing groups are created in the returned tree, which is what
+// gets saved. It returns the issues it fixed.
+func FixStorage(instances []*Instance, groups []*GroupData) (*GroupTree, []LintIssue) {
+	var fixed []LintIssue
+	for _, issue := range LintStorage(instances, groups) {
+		if issue.Fixable {
+			fixed = append(fixed, issue)
+		}
+	}
+
+	ids := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		if ids[inst.ID] {
+			inst.ID = generateID()
+		}
+		ids[inst.ID] = true
+	}
+	for _, inst := range instances {
+		if strings.TrimSpace(inst.Title) == "" {
+			inst.Title = filepath.Base(inst.ProjectPath)
+			if inst.Title == "." || inst.Title == "/" {
+				inst.Title = "untitled"
+			}
+		}
+		if p := inst.ParentSessionID; p != "" && (p == inst.ID || !ids[p]) {
+			inst.ParentSessionID = ""
+		}
+	}
+	return NewGroupTreeWithGroups(instances, groups), fixed
+}
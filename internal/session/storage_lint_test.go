@@ -0,0 +1,82 @@
+package session
+
+import "testing"
+
+func lintKinds(issues []LintIssue) map[string]int {
+	kinds := make(map[string]int)
+	for _, issue := range issues {
+		kinds[issue.Kind]++
+	}
+	return kinds
+}
+
+func TestLintStorage(t *testing.T) {
+	a := NewInstance("a", "/code/a")
+	a.GroupPath = "work"
+	b := NewInstance("b", "/code/b")
+	b.GroupPath = "work"
+	groups := []*GroupData{{Path: "work", Name: "work"}}
+
+	if issues := LintStorage([]*Instance{a, b}, groups); len(issues) != 0 {
+		t.Fatalf("clean storage reported %v", issues)
+	}
+
+	dup := NewInstance("dup", "/code/dup")
+	dup.ID = a.ID
+	dup.GroupPath = "work"
+	b.Title = " "
+	b.GroupPath = "gone"
+	b.Tool = "no-such-tool"
+	b.ParentSessionID = "missing"
+
+	kinds := lintKinds(LintStorage([]*Instance{a, b, dup}, groups))
+	want := map[string]int{
+		LintDuplicateID:   1,
+		LintEmptyTitle:    1,
+		LintMissingGroup:  1,
+		LintUnknownTool:   1,
+		LintMissingParent: 1,
+	}
+	for kind, n := range want {
+		if kinds[kind] != n {
+			t.Errorf("%s issues = %d, want %d (all: %v)", kind, kinds[kind], n, kinds)
+		}
+	}
+}
+
+func TestFixStorage(t *testing.T) {
+	a := NewInstance("a", "/code/a")
+	dup := NewInstance("dup", "/code/dup")
+	dup.ID = a.ID
+	dup.Title = ""
+	dup.GroupPath = "new/group"
+	dup.ParentSessionID = "missing"
+	dup.Tool = "no-such-tool"
+	instances := []*Instance{a, dup}
+
+	tree, fixed := FixStorage(instances, []*GroupData{{Path: a.GroupPath, Name: a.GroupPath}})
+	if len(fixed) != 4 {
+		t.Errorf("fixed %d issues, want 4: %v", len(fixed), fixed)
+	}
+	if dup.ID == a.ID {
+		t.Error("duplicate ID not reassigned")
+	}
+	if dup.Title != "dup" {
+		t.Errorf("empty title fixed to %q, want the folder name", dup.Title)
+	}
+	if dup.ParentSessionID != "" {
+		t.Errorf("dangling parent kept: %q", dup.ParentSessionID)
+	}
+	if _, ok := tree.Groups["new/group"]; !ok {
+		t.Error("missing group not created")
+	}
+
+	var groups []*GroupData
+	for _, g := range tree.GroupList {
+		groups = append(groups, &GroupData{Path: g.Path, Name: g.Name})
+	}
+	kinds := lintKinds(LintStorage(instances, groups))
+	if len(kinds) != 1 || kinds[LintUnknownTool] != 1 {
+		t.Errorf("after fix = %v, want only the unknown tool left", kinds)
+	}
+}
@@ -184,3 +184,165 @@ func TestLoadLiteEmptyDB(t *testing.T) {
 		t.Errorf("Expected empty groups, got %d", len(groupData))
 	}
 }
+
+func TestStorageReadOnly(t *testing.T) {
+	s := newTestStorage(t)
+	inst := &Instance{ID: "ro-1", Title: "Kept", ProjectPath: "/tmp/ro", Tool: "shell", CreatedAt: time.Now()}
+	if err := s.SaveWithGroups([]*Instance{inst}, nil); err != nil {
+		t.Fatalf("SaveWithGroups failed: %v", err)
+	}
+
+	SetReadOnly(true)
+	t.Cleanup(func() { SetReadOnly(false) })
+
+	inst.Title = "Changed"
+	if err := s.SaveWithGroups([]*Instance{inst}, nil); err != nil {
+		t.Fatalf("read-only SaveWithGroups should succeed silently: %v", err)
+	}
+	if err := s.DeleteInstance("ro-1"); err != nil {
+		t.Fatalf("read-only DeleteInstance should succeed silently: %v", err)
+	}
+
+	instData, _, err := s.LoadLite()
+	if err != nil {
+		t.Fatalf("LoadLite failed: %v", err)
+	}
+	if len(instData) != 1 || instData[0].Title != "Kept" {
+		t.Errorf("read-only storage wrote changes: %+v", instData)
+	}
+}
+
+// TestSaveWithGroupsConcurrentWriters verifies that a storage saving a stale
+// session list neither drops sessions another writer added nor brings back
+// ones it deleted (TUI open while running agent-deck add/remove).
+func TestSaveWithGroupsConcurrentWriters(t *testing.T) {
+	tui := newTestStorage(t)
+	db, err := statedb.Open(tui.dbPath)
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	cli := &Storage{db: db, dbPath: tui.dbPath, profile: "_test"}
+
+	inst := func(id string) *Instance {
+		return &Instance{ID: id, Title: id, ProjectPath: "/tmp", GroupPath: "grp", Tool: "shell", CreatedAt: time.Now()}
+	}
+	if err := tui.SaveWithGroups([]*Instance{inst("a"), inst("b")}, nil); err != nil {
+		t.Fatalf("SaveWithGroups: %v", err)
+	}
+	tuiInstances, _, err := tui.LoadWithGroups()
+	if err != nil {
+		t.Fatalf("LoadWithGroups: %v", err)
+	}
+
+	// The CLI adds c and removes b
+	cliInstances, _, err := cli.LoadWithGroups()
+	if err != nil {
+		t.Fatalf("LoadWithGroups: %v", err)
+	}
+	cliInstances = append(cliInstances[:1], inst("c"))
+	if err := cli.SaveWithGroups(cliInstances, nil); err != nil {
+		t.Fatalf("SaveWithGroups: %v", err)
+	}
+
+	// The TUI saves its stale list
+	if err := tui.SaveWithGroups(tuiInstances, nil); err != nil {
+		t.Fatalf("SaveWithGroups: %v", err)
+	}
+
+	rows, _, err := tui.LoadLite()
+	if err != nil {
+		t.Fatalf("LoadLite: %v", err)
+	}
+	var ids []string
+	for _, r := range rows {
+		ids = append(ids, r.ID)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "c" {
+		t.Errorf("sessions = %v, want [a c]", ids)
+	}
+
+	// A removal by the TUI still applies once it has loaded the session
+	if _, _, err := tui.LoadWithGroups(); err != nil {
+		t.Fatalf("LoadWithGroups: %v", err)
+	}
+	if err := tui.SaveWithGroups([]*Instance{inst("a")}, nil); err != nil {
+		t.Fatalf("SaveWithGroups: %v", err)
+	}
+	rows, _, _ = tui.LoadLite()
+	if len(rows) != 1 || rows[0].ID != "a" {
+		t.Errorf("after removing c: %d sessions, want [a]", len(rows))
+	}
+}
+
+// TestSaveWithGroupsRepeatedStaleSaves verifies that a session another writer
+// added survives any number of saves from a storage that never loaded it.
+func TestSaveWithGroupsRepeatedStaleSaves(t *testing.T) {
+	tui := newTestStorage(t)
+	db, err := statedb.Open(tui.dbPath)
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	cli := &Storage{db: db, dbPath: tui.dbPath, profile: "_test"}
+
+	inst := func(id string) *Instance {
+		return &Instance{ID: id, Title: id, ProjectPath: "/tmp", GroupPath: "grp", Tool: "shell", CreatedAt: time.Now()}
+	}
+	if err := tui.SaveWithGroups([]*Instance{inst("a")}, nil); err != nil {
+		t.Fatalf("SaveWithGroups: %v", err)
+	}
+	tuiInstances, _, err := tui.LoadWithGroups()
+	if err != nil {
+		t.Fatalf("LoadWithGroups: %v", err)
+	}
+
+	// The CLI adds c
+	cliInstances, _, err := cli.LoadWithGroups()
+	if err != nil {
+		t.Fatalf("LoadWithGroups: %v", err)
+	}
+	if err := cli.SaveWithGroups(append(cliInstances, inst("c")), nil); err != nil {
+		t.Fatalf("SaveWithGroups: %v", err)
+	}
+
+	// The TUI saves its stale list twice
+	for i := 0; i < 2; i++ {
+		if err := tui.SaveWithGroups(tuiInstances, nil); err != nil {
+			t.Fatalf("SaveWithGroups #%d: %v", i+1, err)
+		}
+	}
+
+	rows, _, err := tui.LoadLite()
+	if err != nil {
+		t.Fatalf("LoadLite: %v", err)
+	}
+	var ids []string
+	for _, r := range rows {
+		ids = append(ids, r.ID)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "c" {
+		t.Errorf("sessions = %v, want [a c]", ids)
+	}
+}
+
+func TestStorageIndexedLookups(t *testing.T) {
+	s := newTestStorage(t)
+	inst := func(id, title, group string, order int) *Instance {
+		return &Instance{ID: id, Title: title, ProjectPath: "/tmp", GroupPath: group, Order: order, Tool: "shell", CreatedAt: time.Now()}
+	}
+	if err := s.SaveWithGroups([]*Instance{
+		inst("a", "api", "work", 0), inst("b", "web", "work/front", 1), inst("c", "api", "home", 2),
+	}, nil); err != nil {
+		t.Fatalf("SaveWithGroups: %v", err)
+	}
+
+	found, err := s.FindByTitle("api")
+	if err != nil || len(found) != 2 || found[0].ID != "a" || found[1].ID != "c" {
+		t.Fatalf("FindByTitle = %v, %v", found, err)
+	}
+	group, err := s.LoadGroup("work")
+	if err != nil || len(group) != 2 || group[0].ID != "a" || group[1].ID != "b" {
+		t.Fatalf("LoadGroup = %v, %v", group, err)
+	}
+}
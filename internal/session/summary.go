@@ -0,0 +1,124 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// summaryMaxLen caps the one-line summary length (in runes) before the file count
+const summaryMaxLen = 80
+
+var (
+	// summaryFilePattern matches file paths with an extension (e.g. internal/auth/middleware.go)
+	summaryFilePattern = regexp.MustCompile(`(?:[\w.@-]+/)*[\w@-][\w.@-]*\.[A-Za-z][A-Za-z0-9]{0,7}\b`)
+
+	// summaryLeaderPattern strips list markers, headings, quotes and agent bullets from a line
+	summaryLeaderPattern = regexp.MustCompile(`^(?:[#>*+\-•⏺●✻│]+|\d+[.)])\s*`)
+
+	// summarySentenceEnd finds the end of the first sentence
+	summarySentenceEnd = regexp.MustCompile(`[.!?](?:\s|$)`)
+
+	// summarySourceExts are extensions counted as files even without a directory
+	summarySourceExts = map[string]bool{
+		"go": true, "py": true, "js": true, "jsx": true, "ts": true, "tsx": true, "rs": true,
+		"rb": true, "java": true, "kt": true, "swift": true, "c": true, "h": true, "cc": true,
+		"cpp": true, "cs": true, "php": true, "sh": true, "sql": true, "md": true, "json": true,
+		"yaml": true, "yml": true, "toml": true, "html": true, "css": true, "scss": true, "vue": true,
+	}
+)
+
+// SummarizeResponse reduces an agent response to a one-line summary: the first
+// sentence of prose (skipping code blocks and markdown markers), followed by the
+// number of distinct files mentioned, e.g. "Refactored auth middleware, 3 files".
+func SummarizeResponse(content string) string {
+	var first string
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || trimmed == "" {
+			continue
+		}
+		cleaned := summaryLeaderPattern.ReplaceAllString(trimmed, "")
+		cleaned = strings.NewReplacer("**", "", "__", "", "`", "").Replace(cleaned)
+		cleaned = strings.TrimSpace(cleaned)
+		if cleaned == "" {
+			continue
+		}
+		first = cleaned
+		break
+	}
+	if first == "" {
+		return ""
+	}
+
+	if loc := summarySentenceEnd.FindStringIndex(first); loc != nil {
+		first = first[:loc[0]]
+	}
+	first = strings.TrimRight(first, ":;, ")
+	if utf8.RuneCountInString(first) > summaryMaxLen {
+		runes := []rune(first)
+		first = strings.TrimSpace(string(runes[:summaryMaxLen-1])) + "…"
+	}
+
+	files := make(map[string]bool)
+	for _, f := range summaryFilePattern.FindAllString(content, -1) {
+		// Bare names need a source-like extension ("e.g." and "v1.2" in prose are not files)
+		ext := strings.ToLower(f[strings.LastIndex(f, ".")+1:])
+		if strings.Contains(f, "/") || summarySourceExts[ext] {
+			files[f] = true
+		}
+	}
+	switch len(files) {
+	case 0:
+		return first
+	case 1:
+		return first + ", 1 file"
+	default:
+		return fmt.Sprintf("%s, %d files", first, len(files))
+	}
+}
+
+// GetLastSummary returns the one-line summary of the agent's last response
+// ("" until the session has finished a turn at least once).
+func (i *Instance) GetLastSummary() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.lastSummary
+}
+
+// maybeRefreshSummary re-extracts the last response in the background.
+// Called from UpdateStatus with i.mu held when the agent stops working, or
+// on the first poll of a session that is already waiting/idle.
+func (i *Instance) maybeRefreshSummary(prevStatus Status) {
+	if i.Status != StatusWaiting && i.Status != StatusIdle {
+		return
+	}
+	if prevStatus != StatusRunning && !i.lastSummaryAt.IsZero() {
+		return
+	}
+	if !i.summaryRunning.CompareAndSwap(false, true) {
+		return
+	}
+	i.lastSummaryAt = time.Now()
+	title := i.Title
+	go func() {
+		defer i.summaryRunning.Store(false)
+		resp, err := i.GetLastResponse()
+		if err != nil || resp == nil {
+			sessionLog.Debug("summary_unavailable", slog.String("title", title), slog.Any("error", err))
+			return
+		}
+		summary := SummarizeResponse(resp.Content)
+		i.mu.Lock()
+		i.lastSummary = summary
+		i.mu.Unlock()
+	}()
+}
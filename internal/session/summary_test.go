@@ -0,0 +1,57 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSummarizeResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "first sentence with file count",
+			content: "Done: refactored auth middleware. I touched `internal/auth/middleware.go`, internal/auth/session.go and main.go.\n\nAll tests pass.",
+			want:    "Done: refactored auth middleware, 3 files",
+		},
+		{
+			name:    "markdown heading and bullets",
+			content: "## **Summary**\n- Fixed the flaky test\n- Updated docs",
+			want:    "Summary",
+		},
+		{
+			name:    "skips code blocks",
+			content: "```go\nfunc main() {}\n```\n⏺ Added a main function to cmd/app/main.go",
+			want:    "Added a main function to cmd/app/main.go, 1 file",
+		},
+		{
+			name:    "version numbers are not files",
+			content: "Bumped the client to v1.2 and regenerated stubs",
+			want:    "Bumped the client to v1.2 and regenerated stubs",
+		},
+		{
+			name:    "empty",
+			content: "\n  \n",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SummarizeResponse(tt.content); got != tt.want {
+				t.Errorf("SummarizeResponse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeResponseTruncates(t *testing.T) {
+	got := SummarizeResponse(strings.Repeat("word ", 60))
+	if !strings.HasSuffix(got, "…") {
+		t.Errorf("long summary should end with an ellipsis: %q", got)
+	}
+	if n := len([]rune(got)); n > summaryMaxLen {
+		t.Errorf("summary has %d runes, want <= %d", n, summaryMaxLen)
+	}
+}
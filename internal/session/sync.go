@@ -0,0 +1,479 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// Paths inside the sync repository or folder
+const (
+	syncRepoSessionsFile = "sessions.json"
+	syncRepoConfigFile   = "config.toml"
+)
+
+// SyncResult summarizes an `agent-deck sync` run
+type SyncResult struct {
+	MergeStats
+	ConfigPulled bool `json:"config_pulled"` // config.toml replaced by the remote's
+	ConfigPushed bool `json:"config_pushed"` // Local config.toml sent to the remote
+	// Both sides changed config.toml since the last sync; neither was touched
+	ConfigConflict bool `json:"config_conflict"`
+	Pushed         bool `json:"pushed"`          // The remote was updated
+	ConflictCopies int  `json:"conflict_copies"` // Sync-service conflict files merged and removed
+}
+
+// MergeStats counts what a merge took from the remote side
+type MergeStats struct {
+	Added         int `json:"added"`          // Sessions new on the remote
+	Updated       int `json:"updated"`        // Sessions changed later on the remote
+	Deleted       int `json:"deleted"`        // Sessions deleted on the remote
+	AddedGroups   int `json:"added_groups"`   // Groups new on the remote
+	DeletedGroups int `json:"deleted_groups"` // Groups deleted on the remote
+}
+
+// Changed reports whether the merge changes local data
+func (m MergeStats) Changed() bool {
+	return m.Added+m.Updated+m.Deleted+m.AddedGroups+m.DeletedGroups > 0
+}
+
+// GetSyncDir returns the local checkout of the sync remote (~/.agent-deck/sync)
+func GetSyncDir() (string, error) {
+	dir, err := GetAgentDeckDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sync"), nil
+}
+
+// getSyncBaseDir returns where folder sync keeps the last synced copies,
+// the base of its three-way merges (~/.agent-deck/sync-base)
+func getSyncBaseDir() (string, error) {
+	dir, err := GetAgentDeckDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sync-base"), nil
+}
+
+// MergeStorageData three-way merges local and remote against base, the data
+// as of the last sync (nil if there was none):
+//   - a session on both sides keeps the version with the later UpdatedAt;
+//     machine-specific fields (status, tmux session, last access) stay local
+//   - a session on one side only is new, unless the other side deleted it
+//     (tombstone, or present in base) without it changing since
+//   - a group on one side only is new unless base had it, then it was deleted
+//
+// Tombstones are unioned so deletions keep propagating to other machines.
+func MergeStorageData(base, local, remote *StorageData) (*StorageData, MergeStats) {
+	if base == nil {
+		base = &StorageData{}
+	}
+	var stats MergeStats
+	now := time.Now()
+	merged := &StorageData{UpdatedAt: now, Tombstones: make(map[string]time.Time)}
+	cutoff := now.Add(-statedb.TombstoneRetention)
+	for _, side := range []map[string]time.Time{local.Tombstones, remote.Tombstones} {
+		for id, t := range side {
+			if t.After(cutoff) && t.After(merged.Tombstones[id]) {
+				merged.Tombstones[id] = t
+			}
+		}
+	}
+
+	baseByID := make(map[string]*InstanceData, len(base.Instances))
+	for _, inst := range base.Instances {
+		baseByID[inst.ID] = inst
+	}
+	remoteByID := make(map[string]*InstanceData, len(remote.Instances))
+	for _, inst := range remote.Instances {
+		remoteByID[inst.ID] = inst
+	}
+	// deletedElsewhere reports whether the side lacking inst deleted it after
+	// inst last changed
+	deletedElsewhere := func(inst *InstanceData) bool {
+		if t, ok := merged.Tombstones[inst.ID]; ok {
+			return !inst.UpdatedAt.After(t)
+		}
+		// No tombstone (older agent-deck or pruned): it was synced, so it was deleted
+		if b, ok := baseByID[inst.ID]; ok && !inst.UpdatedAt.After(b.UpdatedAt) {
+			merged.Tombstones[inst.ID] = now
+			return true
+		}
+		return false
+	}
+
+	seen := make(map[string]bool, len(local.Instances)+len(remote.Instances))
+	for _, l := range local.Instances {
+		seen[l.ID] = true
+		r, onRemote := remoteByID[l.ID]
+		switch {
+		case onRemote && r.UpdatedAt.After(l.UpdatedAt):
+			taken := *r
+			taken.Status, taken.TmuxSession, taken.LastAccessedAt = l.Status, l.TmuxSession, l.LastAccessedAt
+			merged.Instances = append(merged.Instances, &taken)
+			stats.Updated++
+		case onRemote || !deletedElsewhere(l):
+			merged.Instances = append(merged.Instances, l)
+		default:
+			stats.Deleted++
+		}
+	}
+	for _, r := range remote.Instances {
+		if seen[r.ID] {
+			continue
+		}
+		seen[r.ID] = true
+		if !deletedElsewhere(r) {
+			merged.Instances = append(merged.Instances, r)
+			stats.Added++
+		}
+	}
+	for _, inst := range merged.Instances {
+		delete(merged.Tombstones, inst.ID)
+	}
+
+	baseGroups := make(map[string]bool, len(base.Groups))
+	for _, g := range base.Groups {
+		baseGroups[g.Path] = true
+	}
+	remoteGroups := make(map[string]bool, len(remote.Groups))
+	for _, g := range remote.Groups {
+		remoteGroups[g.Path] = true
+	}
+	localGroups := make(map[string]bool, len(local.Groups))
+	for _, g := range local.Groups {
+		localGroups[g.Path] = true
+		if !remoteGroups[g.Path] && baseGroups[g.Path] && g.Path != DefaultGroupPath {
+			stats.DeletedGroups++
+			continue
+		}
+		merged.Groups = append(merged.Groups, g)
+	}
+	for _, g := range remote.Groups {
+		if !localGroups[g.Path] && !baseGroups[g.Path] {
+			merged.Groups = append(merged.Groups, g)
+			stats.AddedGroups++
+		}
+	}
+	return merged, stats
+}
+
+// LoadStorageData returns the profile's sessions, groups and tombstones in
+// the JSON form used for sync
+func (s *Storage) LoadStorageData() (*StorageData, error) {
+	instances, groups, err := s.LoadLite()
+	if err != nil {
+		return nil, err
+	}
+	tombstones, err := s.db.LoadTombstones()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tombstones: %w", err)
+	}
+	updatedAt, _ := s.GetUpdatedAt()
+	return &StorageData{Instances: instances, Groups: groups, UpdatedAt: updatedAt, Tombstones: tombstones}, nil
+}
+
+// SaveStorageData replaces the profile's sessions and groups with data and
+// records its tombstones
+func (s *Storage) SaveStorageData(data *StorageData) error {
+	instances, groups, err := s.convertToInstances(data)
+	if err != nil {
+		return err
+	}
+	if err := s.SaveWithGroups(instances, NewGroupTreeWithGroups(instances, groups)); err != nil {
+		return err
+	}
+	return s.db.SaveTombstones(data.Tombstones)
+}
+
+// decodeSyncData parses a synced sessions file; nil data (no file) returns nil
+func decodeSyncData(data []byte) (*StorageData, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var sd StorageData
+	if err := json.Unmarshal(data, &sd); err != nil {
+		return nil, err
+	}
+	return &sd, nil
+}
+
+// encodeSyncData renders data with sessions sorted by ID so diffs between
+// syncs stay small. UpdatedAt becomes the latest change in data, so syncing
+// unchanged data writes the same bytes.
+func encodeSyncData(data *StorageData) ([]byte, error) {
+	sorted := *data
+	sorted.UpdatedAt = time.Time{}
+	for _, inst := range data.Instances {
+		if inst.UpdatedAt.After(sorted.UpdatedAt) {
+			sorted.UpdatedAt = inst.UpdatedAt
+		}
+	}
+	for _, t := range data.Tombstones {
+		if t.After(sorted.UpdatedAt) {
+			sorted.UpdatedAt = t
+		}
+	}
+	// Status and access time are live, per-machine state: leave them out so
+	// they don't cause a commit every sync (another machine sees the session
+	// as not running until it's started there)
+	sorted.Instances = make([]*InstanceData, len(data.Instances))
+	for i, inst := range data.Instances {
+		cp := *inst
+		cp.Status, cp.LastAccessedAt = StatusError, time.Time{}
+		sorted.Instances[i] = &cp
+	}
+	sort.Slice(sorted.Instances, func(i, j int) bool { return sorted.Instances[i].ID < sorted.Instances[j].ID })
+	sorted.Groups = append([]*GroupData(nil), data.Groups...)
+	sort.Slice(sorted.Groups, func(i, j int) bool { return sorted.Groups[i].Path < sorted.Groups[j].Path })
+	out, err := json.MarshalIndent(&sorted, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// mergeRemotes merges each remote version into local storage in turn and
+// returns the encoded result to publish
+func mergeRemotes(storage *Storage, base *StorageData, remotes []*StorageData, result *SyncResult) ([]byte, error) {
+	merged, err := storage.LoadStorageData()
+	if err != nil {
+		return nil, err
+	}
+	changed := false
+	for _, remote := range remotes {
+		var stats MergeStats
+		merged, stats = MergeStorageData(base, merged, remote)
+		changed = changed || stats.Changed()
+		result.Added += stats.Added
+		result.Updated += stats.Updated
+		result.Deleted += stats.Deleted
+		result.AddedGroups += stats.AddedGroups
+		result.DeletedGroups += stats.DeletedGroups
+	}
+	if changed {
+		if err := storage.SaveStorageData(merged); err != nil {
+			return nil, fmt.Errorf("failed to save merged sessions: %w", err)
+		}
+	}
+	return encodeSyncData(merged)
+}
+
+// Sync merges the profile's sessions with other machines' through the git
+// remote or shared folder in settings, then publishes the result (and
+// config.toml).
+func Sync(storage *Storage, settings SyncSettings) (*SyncResult, error) {
+	switch {
+	case settings.Path != "":
+		return syncFolder(storage, settings)
+	case settings.Remote != "":
+		return syncGit(storage, settings)
+	}
+	return nil, fmt.Errorf("nothing to sync with (set [sync] remote or path in config.toml)")
+}
+
+// syncGit syncs through a git remote. The remote branch is checked out as-is
+// every time, so the sync repo never holds git conflicts: sessions are
+// merged structurally instead, with our previous commit as the base.
+func syncGit(storage *Storage, settings SyncSettings) (*SyncResult, error) {
+	dir, err := GetSyncDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := git.EnsureSyncRepo(dir, settings.Remote); err != nil {
+		return nil, err
+	}
+
+	sessionsRel := filepath.Join("profiles", storage.Profile(), syncRepoSessionsFile)
+	lastConfig := git.ShowFile(dir, "HEAD", syncRepoConfigFile) // As of our previous sync
+	base, err := decodeSyncData(git.ShowFile(dir, "HEAD", filepath.ToSlash(sessionsRel)))
+	if err != nil {
+		base = nil // Unreadable base: merge two-way (tombstones still apply)
+	}
+	branch := settings.GetBranch()
+	if _, err := git.FetchSyncBranch(dir, branch); err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{}
+	sessionsPath := filepath.Join(dir, sessionsRel)
+	var remotes []*StorageData
+	if data, err := os.ReadFile(sessionsPath); err == nil {
+		remote, err := decodeSyncData(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in sync repo: %w", sessionsRel, err)
+		}
+		remotes = append(remotes, remote)
+	}
+	encoded, err := mergeRemotes(storage, base, remotes, result)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(sessionsPath, encoded); err != nil {
+		return nil, err
+	}
+
+	if settings.GetConfig() {
+		if err := syncConfigFileIn(dir, lastConfig, result); err != nil {
+			return nil, err
+		}
+	}
+
+	host, _ := os.Hostname()
+	committed, err := git.CommitAll(dir, fmt.Sprintf("agent-deck sync from %s (%s)", host, storage.Profile()))
+	if err != nil {
+		return nil, err
+	}
+	if committed {
+		if err := git.PushBranch(dir, branch); err != nil {
+			return result, fmt.Errorf("push failed (another machine may have synced meanwhile; run sync again): %w", err)
+		}
+		result.Pushed = true
+	}
+	return result, nil
+}
+
+// syncFolder syncs through a folder shared by a file sync service (Dropbox,
+// iCloud Drive, Syncthing). The last synced copies are kept locally as the
+// merge base, and the conflicted copies such services create when two
+// machines write at once are merged in and removed.
+func syncFolder(storage *Storage, settings SyncSettings) (*SyncResult, error) {
+	dir := expandHomePath(settings.Path)
+	baseDir, err := getSyncBaseDir()
+	if err != nil {
+		return nil, err
+	}
+	sessionsRel := filepath.Join("profiles", storage.Profile(), syncRepoSessionsFile)
+	basePath := filepath.Join(baseDir, sessionsRel)
+	baseData, _ := os.ReadFile(basePath)
+	base, err := decodeSyncData(baseData)
+	if err != nil {
+		base = nil
+	}
+
+	result := &SyncResult{}
+	sessionsPath := filepath.Join(dir, sessionsRel)
+	var remotes []*StorageData
+	if data, err := os.ReadFile(sessionsPath); err == nil {
+		remote, err := decodeSyncData(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", sessionsPath, err)
+		}
+		remotes = append(remotes, remote)
+	}
+	var conflictCopies []string
+	for _, path := range findConflictCopies(sessionsPath) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if remote, err := decodeSyncData(data); err == nil && remote != nil {
+			remotes = append(remotes, remote)
+			conflictCopies = append(conflictCopies, path)
+		}
+	}
+
+	encoded, err := mergeRemotes(storage, base, remotes, result)
+	if err != nil {
+		return nil, err
+	}
+	if current, _ := os.ReadFile(sessionsPath); !bytes.Equal(current, encoded) {
+		if err := writeFileAtomic(sessionsPath, encoded); err != nil {
+			return nil, err
+		}
+		result.Pushed = true
+	}
+	for _, path := range conflictCopies {
+		if os.Remove(path) == nil {
+			result.ConflictCopies++
+		}
+	}
+	if err := writeFileAtomic(basePath, encoded); err != nil {
+		return nil, err
+	}
+
+	if settings.GetConfig() {
+		baseConfigPath := filepath.Join(baseDir, syncRepoConfigFile)
+		lastConfig, _ := os.ReadFile(baseConfigPath)
+		if err := syncConfigFileIn(dir, lastConfig, result); err != nil {
+			return nil, err
+		}
+		if synced, err := os.ReadFile(filepath.Join(dir, syncRepoConfigFile)); err == nil && !result.ConfigConflict {
+			if err := writeFileAtomic(baseConfigPath, synced); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// findConflictCopies returns the conflict files sync services leave next to
+// path, e.g. "sessions (conflicted copy).json" (Dropbox), "sessions 2.json"
+// (iCloud) or "sessions.sync-conflict-*.json" (Syncthing)
+func findConflictCopies(path string) []string {
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+	matches, _ := filepath.Glob(stem + "*" + ext)
+	copies := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if m != path {
+			copies = append(copies, m)
+		}
+	}
+	return copies
+}
+
+// writeFileAtomic writes data via a temp file and rename, so sync services
+// and other machines never see a partial file
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// syncConfigFileIn reconciles config.toml with the sync repo or folder,
+// three-way against the version from the last sync: whichever side changed
+// wins; if both did, both are left alone and the result reports a conflict.
+func syncConfigFileIn(dir string, lastSynced []byte, result *SyncResult) error {
+	localPath, err := GetUserConfigPath()
+	if err != nil {
+		return err
+	}
+	localCfg, err := os.ReadFile(localPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	repoPath := filepath.Join(dir, syncRepoConfigFile)
+	repoCfg, _ := os.ReadFile(repoPath)
+
+	switch {
+	case bytes.Equal(localCfg, repoCfg):
+		return nil
+	case localCfg == nil || (lastSynced != nil && bytes.Equal(localCfg, lastSynced)):
+		if err := writeFileAtomic(localPath, repoCfg); err != nil {
+			return err
+		}
+		ClearUserConfigCache()
+		result.ConfigPulled = true
+		return nil
+	case repoCfg == nil || bytes.Equal(repoCfg, lastSynced):
+		result.ConfigPushed = true
+		return writeFileAtomic(repoPath, localCfg)
+	}
+	result.ConfigConflict = true
+	return nil
+}
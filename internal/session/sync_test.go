@@ -0,0 +1,127 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeStorageData(t *testing.T) {
+	t0 := time.Now().Add(-time.Hour)
+	t1 := t0.Add(time.Minute)
+	t2 := t1.Add(time.Minute)
+
+	base := &StorageData{
+		Instances: []*InstanceData{
+			{ID: "kept", UpdatedAt: t0},
+			{ID: "edited", Title: "old", UpdatedAt: t0},
+			{ID: "gone-remote", UpdatedAt: t0},
+		},
+		Groups: []*GroupData{{Path: "work"}, {Path: "old"}},
+	}
+	local := &StorageData{
+		Instances: []*InstanceData{
+			{ID: "kept", UpdatedAt: t0},
+			{ID: "edited", Title: "old", Status: StatusRunning, TmuxSession: "agentdeck_edited", UpdatedAt: t0},
+			{ID: "gone-remote", UpdatedAt: t0},
+			{ID: "new-local", UpdatedAt: t1},
+			{ID: "revived", Title: "edited after delete", UpdatedAt: t2},
+		},
+		Groups: []*GroupData{{Path: "work"}, {Path: "old"}},
+	}
+	remote := &StorageData{
+		Instances: []*InstanceData{
+			{ID: "kept", UpdatedAt: t0},
+			{ID: "edited", Title: "new", Status: StatusError, UpdatedAt: t1},
+			{ID: "new-remote", UpdatedAt: t1},
+			{ID: "zombie", UpdatedAt: t0},
+		},
+		Groups: []*GroupData{{Path: "work"}, {Path: "home"}},
+		// zombie was deleted locally long ago; revived was deleted remotely, then edited here
+		Tombstones: map[string]time.Time{"revived": t1},
+	}
+	local.Tombstones = map[string]time.Time{"zombie": t1}
+
+	merged, stats := MergeStorageData(base, local, remote)
+	want := MergeStats{Added: 1, Updated: 1, Deleted: 1, AddedGroups: 1, DeletedGroups: 1}
+	if stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+
+	byID := make(map[string]*InstanceData)
+	for _, inst := range merged.Instances {
+		byID[inst.ID] = inst
+	}
+	for _, id := range []string{"kept", "edited", "new-local", "new-remote", "revived"} {
+		if byID[id] == nil {
+			t.Errorf("session %q missing from merge", id)
+		}
+	}
+	for _, id := range []string{"gone-remote", "zombie"} {
+		if byID[id] != nil {
+			t.Errorf("deleted session %q was resurrected", id)
+		}
+	}
+	if e := byID["edited"]; e != nil {
+		if e.Title != "new" {
+			t.Errorf("edited.Title = %q, want newer remote value", e.Title)
+		}
+		if e.Status != StatusRunning || e.TmuxSession != "agentdeck_edited" {
+			t.Errorf("edited kept remote machine state: status=%q tmux=%q", e.Status, e.TmuxSession)
+		}
+	}
+
+	if _, ok := merged.Tombstones["zombie"]; !ok {
+		t.Error("zombie tombstone should carry over")
+	}
+	if _, ok := merged.Tombstones["gone-remote"]; !ok {
+		t.Error("deletion inferred from base should record a tombstone")
+	}
+	if _, ok := merged.Tombstones["revived"]; ok {
+		t.Error("tombstone for a surviving session should be dropped")
+	}
+
+	groups := make(map[string]bool)
+	for _, g := range merged.Groups {
+		groups[g.Path] = true
+	}
+	if !groups["work"] || !groups["home"] || groups["old"] {
+		t.Errorf("groups = %v, want work and home", groups)
+	}
+}
+
+func TestMergeStorageDataNoBase(t *testing.T) {
+	local := &StorageData{Instances: []*InstanceData{{ID: "a"}}}
+	remote := &StorageData{Instances: []*InstanceData{{ID: "b"}}}
+
+	merged, stats := MergeStorageData(nil, local, remote)
+	if len(merged.Instances) != 2 || stats.Added != 1 || stats.Deleted != 0 {
+		t.Errorf("first sync must be a union: %d sessions, stats %+v", len(merged.Instances), stats)
+	}
+}
+
+func TestEncodeSyncDataStable(t *testing.T) {
+	at := time.Now().Add(-time.Minute).Truncate(time.Second)
+	data := &StorageData{
+		Instances: []*InstanceData{
+			{ID: "b", Status: StatusRunning, LastAccessedAt: time.Now(), UpdatedAt: at},
+			{ID: "a", Status: StatusWaiting, UpdatedAt: at},
+		},
+	}
+	first, err := encodeSyncData(data)
+	if err != nil {
+		t.Fatalf("encodeSyncData: %v", err)
+	}
+	data.Instances[0].Status = StatusIdle
+	data.Instances[0].LastAccessedAt = time.Now()
+	second, _ := encodeSyncData(data)
+	if string(first) != string(second) {
+		t.Error("status and access time changes must not change the sync file")
+	}
+	if data.Instances[0].Status != StatusIdle {
+		t.Error("encodeSyncData must not modify its input")
+	}
+	decoded, err := decodeSyncData(first)
+	if err != nil || len(decoded.Instances) != 2 || decoded.Instances[0].ID != "a" {
+		t.Errorf("decoded = %+v, %v", decoded, err)
+	}
+}
@@ -0,0 +1,164 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// teamDeckGroup is the root group team deck sessions are added under, keeping
+// them apart from the member's own groups
+const teamDeckGroup = "team"
+
+// TeamDeck is a team deck as last fetched: standard sessions and groups a
+// team publishes for its members to add to their own decks. Agent-deck never
+// writes it back.
+type TeamDeck struct {
+	Name string
+	Data *StorageData
+}
+
+// Namespace returns the group the deck's sessions are added under,
+// team/<name>
+func (d *TeamDeck) Namespace() string {
+	return teamDeckGroup + "/" + d.Name
+}
+
+// FindTeamDeck returns the configured team deck called name
+func FindTeamDeck(name string) (TeamDeckDef, error) {
+	decks := GetTeamDecks()
+	for _, def := range decks {
+		if strings.EqualFold(def.Name, name) {
+			return def, nil
+		}
+	}
+	if len(decks) == 0 {
+		return TeamDeckDef{}, fmt.Errorf("no team decks configured (add a [[team_decks]] entry to config.toml)")
+	}
+	return TeamDeckDef{}, fmt.Errorf("team deck '%s' not found", name)
+}
+
+// teamDeckName returns def's name as a group path component
+func teamDeckName(def TeamDeckDef) (string, error) {
+	if strings.TrimSpace(def.Name) == "" || def.Source == "" {
+		return "", fmt.Errorf("team deck needs a name and a source")
+	}
+	return strings.ToLower(strings.ReplaceAll(sanitizeGroupName(def.Name), " ", "-")), nil
+}
+
+// isGitSource reports whether a team deck source is a git URL rather than a
+// file path
+func isGitSource(source string) bool {
+	return strings.Contains(source, "://") || strings.HasPrefix(source, "git@") || strings.HasSuffix(source, ".git")
+}
+
+// getTeamDeckDir returns the local checkout of a git team deck
+// (~/.agent-deck/team-decks/<name>)
+func getTeamDeckDir(name string) (string, error) {
+	dir, err := GetAgentDeckDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "team-decks", name), nil
+}
+
+// PullTeamDeck fetches the latest version of a git team deck into its local
+// checkout. File decks are read in place and need no pull.
+func PullTeamDeck(def TeamDeckDef) error {
+	name, err := teamDeckName(def)
+	if err != nil {
+		return err
+	}
+	if !isGitSource(def.Source) {
+		return nil
+	}
+	dir, err := getTeamDeckDir(name)
+	if err != nil {
+		return err
+	}
+	if err := git.EnsureSyncRepo(dir, def.Source); err != nil {
+		return err
+	}
+	found, err := git.FetchSyncBranch(dir, def.GetBranch())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("branch '%s' not found in %s", def.GetBranch(), def.Source)
+	}
+	return nil
+}
+
+// LoadTeamDeck reads a team deck, fetching a git source the first time. Later
+// loads read the checkout as of the last PullTeamDeck.
+func LoadTeamDeck(def TeamDeckDef) (*TeamDeck, error) {
+	name, err := teamDeckName(def)
+	if err != nil {
+		return nil, err
+	}
+	path := expandHomePath(def.Source)
+	if isGitSource(def.Source) {
+		dir, err := getTeamDeckDir(name)
+		if err != nil {
+			return nil, err
+		}
+		if !git.IsGitRepo(dir) {
+			if err := PullTeamDeck(def); err != nil {
+				return nil, fmt.Errorf("failed to fetch team deck '%s': %w", def.Name, err)
+			}
+		}
+		path = filepath.Join(dir, def.GetFile())
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("team deck '%s': %w", def.Name, err)
+	}
+	data, err := ParseExport(raw)
+	if err != nil {
+		return nil, fmt.Errorf("team deck '%s': %w", def.Name, err)
+	}
+	return &TeamDeck{Name: name, Data: data}, nil
+}
+
+// Select returns copies of the deck's sessions matching selectors (a session
+// title or ID, or a group path with its subgroups), all of them when none are
+// given, with the groups they're in
+func (d *TeamDeck) Select(selectors []string) (*StorageData, error) {
+	inGroup := func(path, group string) bool { return path == group || strings.HasPrefix(path, group+"/") }
+	matched := make([]bool, len(selectors))
+	picked := &StorageData{UpdatedAt: d.Data.UpdatedAt}
+	groups := make(map[string]bool)
+	for _, inst := range d.Data.Instances {
+		take := len(selectors) == 0
+		for i, sel := range selectors {
+			if inst.ID == sel || strings.EqualFold(inst.Title, sel) || inGroup(inst.GroupPath, strings.Trim(sel, "/")) {
+				matched[i], take = true, true
+			}
+		}
+		if !take {
+			continue
+		}
+		cp := *inst
+		picked.Instances = append(picked.Instances, &cp)
+		for path := inst.GroupPath; path != ""; path = getParentPath(path) {
+			groups[path] = true
+		}
+	}
+	for i, sel := range selectors {
+		if !matched[i] {
+			return nil, fmt.Errorf("nothing in team deck '%s' matches '%s'", d.Name, sel)
+		}
+	}
+	for _, g := range d.Data.Groups {
+		if groups[g.Path] {
+			cp := *g
+			picked.Groups = append(picked.Groups, &cp)
+		}
+	}
+	sort.Slice(picked.Groups, func(i, j int) bool { return picked.Groups[i].Path < picked.Groups[j].Path })
+	return picked, nil
+}
@@ -0,0 +1,91 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTeamDeck(t *testing.T) {
+	now := time.Now()
+	encoded, err := ExportStorageData(&StorageData{
+		Instances: []*InstanceData{
+			{ID: "t1", Title: "api", ProjectPath: "/srv/api", GroupPath: "backend", Tool: "claude", UpdatedAt: now},
+			{ID: "t2", Title: "worker", ProjectPath: "/srv/worker", GroupPath: "backend/jobs", Tool: "claude", UpdatedAt: now},
+			{ID: "t3", Title: "oncall", ProjectPath: "/srv/runbooks", GroupPath: "ops", Tool: "shell", UpdatedAt: now},
+		},
+		Groups: []*GroupData{
+			{Name: "backend", Path: "backend"},
+			{Name: "jobs", Path: "backend/jobs"},
+			{Name: "ops", Path: "ops"},
+		},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deckFile := filepath.Join(t.TempDir(), "platform.json")
+	if err := os.WriteFile(deckFile, encoded, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	userConfigCacheMu.Lock()
+	origCache := userConfigCache
+	userConfigCache = &UserConfig{TeamDecks: []TeamDeckDef{{Name: "Platform", Source: deckFile}}}
+	userConfigCacheMu.Unlock()
+	defer func() {
+		userConfigCacheMu.Lock()
+		userConfigCache = origCache
+		userConfigCacheMu.Unlock()
+	}()
+
+	def, err := FindTeamDeck("platform")
+	if err != nil {
+		t.Fatalf("FindTeamDeck: %v", err)
+	}
+	if _, err := FindTeamDeck("design"); err == nil {
+		t.Error("an unconfigured deck should not be found")
+	}
+	if err := PullTeamDeck(def); err != nil {
+		t.Errorf("pulling a file deck: %v", err)
+	}
+	deck, err := LoadTeamDeck(def)
+	if err != nil {
+		t.Fatalf("LoadTeamDeck: %v", err)
+	}
+	if deck.Namespace() != "team/platform" || len(deck.Data.Instances) != 3 {
+		t.Fatalf("deck %s has %d sessions", deck.Namespace(), len(deck.Data.Instances))
+	}
+
+	picked, err := deck.Select([]string{"backend/jobs", "ONCALL"})
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if len(picked.Instances) != 2 || len(picked.Groups) != 3 {
+		t.Fatalf("picked %d sessions in %d groups, want worker and oncall in backend, backend/jobs, ops", len(picked.Instances), len(picked.Groups))
+	}
+	if _, err := deck.Select([]string{"frontend"}); err == nil {
+		t.Error("a selector matching nothing should fail")
+	}
+
+	s := newTestStorage(t)
+	if _, err := s.ImportStorageData(picked, deck.Namespace()); err != nil {
+		t.Fatalf("ImportStorageData: %v", err)
+	}
+	local, err := s.LoadStorageData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := map[string]bool{}
+	for _, inst := range local.Instances {
+		groups[inst.GroupPath] = true
+	}
+	if !groups["team/platform/backend/jobs"] || !groups["team/platform/ops"] {
+		t.Errorf("session groups = %v, want them under team/platform", groups)
+	}
+
+	// The deck itself is left as published
+	if raw, err := os.ReadFile(deckFile); err != nil || string(raw) != string(encoded) {
+		t.Error("the team deck file changed")
+	}
+}
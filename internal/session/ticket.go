@@ -0,0 +1,252 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Ticket providers
+const (
+	TicketLinear = "linear"
+	TicketJira   = "jira"
+)
+
+// linearAPIURL is Linear's GraphQL endpoint (a variable so tests can stub it)
+var linearAPIURL = "https://api.linear.app/graphql"
+
+// ticketIDPattern matches Linear and Jira issue keys like ENG-123
+var ticketIDPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-\d+$`)
+
+// Ticket links a session to a Linear or Jira ticket. Title and Status are
+// cached from the tracker when the link is set, for display and prompts.
+type Ticket struct {
+	Provider  string    `json:"provider"`
+	ID        string    `json:"id"`
+	URL       string    `json:"url,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	FetchedAt time.Time `json:"fetched_at,omitempty"`
+}
+
+// String returns the ticket's ID, with its status when known: "ENG-123 (In Progress)"
+func (t *Ticket) String() string {
+	if t == nil {
+		return ""
+	}
+	if t.Status != "" {
+		return fmt.Sprintf("%s (%s)", t.ID, t.Status)
+	}
+	return t.ID
+}
+
+// ParseTicketRef parses a ticket ID (ENG-123) or URL: a linear.app issue
+// URL, or a Jira /browse/ URL. Bare IDs use the configured provider.
+func ParseTicketRef(ref string, settings TicketSettings) (*Ticket, error) {
+	ref = strings.TrimSpace(ref)
+	if ticketIDPattern.MatchString(ref) {
+		t := &Ticket{Provider: settings.GetProvider(), ID: strings.ToUpper(ref)}
+		if t.Provider == TicketJira {
+			if settings.JiraURL == "" {
+				return nil, fmt.Errorf("set [tickets] jira_url in config.toml to link Jira ticket %s", t.ID)
+			}
+			t.URL = strings.TrimSuffix(settings.JiraURL, "/") + "/browse/" + t.ID
+		}
+		return t, nil
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid ticket %q: use an ID like ENG-123 or a Linear/Jira URL", ref)
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		id := segments[i+1]
+		if !ticketIDPattern.MatchString(id) {
+			continue
+		}
+		switch {
+		case u.Host == "linear.app" && segments[i] == "issue":
+			return &Ticket{Provider: TicketLinear, ID: strings.ToUpper(id), URL: ref}, nil
+		case segments[i] == "browse":
+			base := u.Scheme + "://" + u.Host
+			if i > 0 {
+				base += "/" + strings.Join(segments[:i], "/") // Jira under a context path
+			}
+			return &Ticket{Provider: TicketJira, ID: strings.ToUpper(id), URL: base + "/browse/" + strings.ToUpper(id)}, nil
+		}
+	}
+	return nil, fmt.Errorf("no ticket ID in %q", ref)
+}
+
+// Fetch fills in the ticket's title, status and URL from the tracker
+func (t *Ticket) Fetch() error {
+	var err error
+	switch t.Provider {
+	case TicketLinear:
+		err = t.fetchLinear()
+	case TicketJira:
+		err = t.fetchJira()
+	default:
+		err = fmt.Errorf("unknown ticket provider %q", t.Provider)
+	}
+	if err == nil {
+		t.FetchedAt = time.Now()
+	}
+	return err
+}
+
+// fetchLinear queries Linear's GraphQL API, which accepts issue keys as IDs
+func (t *Ticket) fetchLinear() error {
+	key := os.Getenv("LINEAR_API_KEY")
+	if key == "" {
+		return fmt.Errorf("LINEAR_API_KEY is not set")
+	}
+	query, _ := json.Marshal(map[string]interface{}{
+		"query":     `query($id: String!) { issue(id: $id) { identifier title url state { name } } }`,
+		"variables": map[string]string{"id": t.ID},
+	})
+	req, err := http.NewRequest(http.MethodPost, linearAPIURL, bytes.NewReader(query))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", key)
+
+	var body struct {
+		Data struct {
+			Issue *struct {
+				Title string `json:"title"`
+				URL   string `json:"url"`
+				State struct {
+					Name string `json:"name"`
+				} `json:"state"`
+			} `json:"issue"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := doTicketRequest(req, &body); err != nil {
+		return err
+	}
+	if len(body.Errors) > 0 {
+		return fmt.Errorf("linear: %s", body.Errors[0].Message)
+	}
+	if body.Data.Issue == nil {
+		return fmt.Errorf("linear: ticket %s not found", t.ID)
+	}
+	t.Title = body.Data.Issue.Title
+	t.Status = body.Data.Issue.State.Name
+	if body.Data.Issue.URL != "" {
+		t.URL = body.Data.Issue.URL
+	}
+	return nil
+}
+
+// fetchJira reads the ticket from Jira's REST API. With JIRA_EMAIL set the
+// token is sent as basic auth (Jira Cloud), otherwise as a bearer token
+// (Jira Data Center personal access tokens).
+func (t *Ticket) fetchJira() error {
+	token := os.Getenv("JIRA_API_TOKEN")
+	if token == "" {
+		return fmt.Errorf("JIRA_API_TOKEN is not set")
+	}
+	// The site is the part of the ticket's /browse/ URL before the path
+	base := strings.TrimSuffix(t.URL, "/browse/"+t.ID)
+	req, err := http.NewRequest(http.MethodGet, base+"/rest/api/2/issue/"+url.PathEscape(t.ID)+"?fields=summary,status", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if email := os.Getenv("JIRA_EMAIL"); email != "" {
+		req.SetBasicAuth(email, token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	var body struct {
+		Fields struct {
+			Summary string `json:"summary"`
+			Status  struct {
+				Name string `json:"name"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := doTicketRequest(req, &body); err != nil {
+		return err
+	}
+	t.Title = body.Fields.Summary
+	t.Status = body.Fields.Status.Name
+	return nil
+}
+
+// doTicketRequest sends a tracker API request and decodes the JSON response
+func doTicketRequest(req *http.Request, v interface{}) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch ticket: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse ticket: %w", err)
+	}
+	return nil
+}
+
+// ExpandPrompt fills in the session's placeholders in a prompt: {files}
+// (its context files) and the ticket's {ticket}, {ticket-title},
+// {ticket-url} and {ticket-status}. Without a linked ticket those expand to
+// nothing.
+func (i *Instance) ExpandPrompt(prompt string) string {
+	if strings.Contains(prompt, "{files}") {
+		prompt = strings.ReplaceAll(prompt, "{files}", i.ContextFilesText())
+	}
+	if !strings.Contains(prompt, "{ticket") {
+		return prompt
+	}
+	t := i.Ticket
+	if t == nil {
+		t = &Ticket{}
+	}
+	return strings.NewReplacer(
+		"{ticket}", t.ID,
+		"{ticket-title}", t.Title,
+		"{ticket-url}", t.URL,
+		"{ticket-status}", t.Status,
+	).Replace(prompt)
+}
+
+// marshalTicket encodes a ticket for the tool_data blob
+func marshalTicket(t *Ticket) json.RawMessage {
+	if t == nil {
+		return nil
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// unmarshalTicket decodes a ticket from the tool_data blob
+func unmarshalTicket(data json.RawMessage) *Ticket {
+	if len(data) == 0 {
+		return nil
+	}
+	var t Ticket
+	if err := json.Unmarshal(data, &t); err != nil || t.ID == "" {
+		return nil
+	}
+	return &t
+}
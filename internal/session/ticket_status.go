@@ -0,0 +1,92 @@
+package session
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ticketStatusInterval is how often linked tickets are fetched again
+const ticketStatusInterval = 5 * time.Minute
+
+// TicketStatuses keeps the linked tickets' title and status current for the
+// preview: they change in the tracker, not in agent-deck, so the copy cached
+// when the link was set goes stale. Tickets are fetched in the background
+// every five minutes.
+type TicketStatuses struct {
+	mu        sync.Mutex
+	tickets   map[string]*Ticket // provider:ID -> last fetch
+	lastCheck time.Time
+	checking  bool
+}
+
+// NewTicketStatuses creates an empty ticket tracker
+func NewTicketStatuses() *TicketStatuses {
+	return &TicketStatuses{tickets: make(map[string]*Ticket)}
+}
+
+// ticketKey identifies a ticket across the sessions linked to it
+func ticketKey(t *Ticket) string {
+	return t.Provider + ":" + t.ID
+}
+
+// Check starts a background fetch of the sessions' tickets when one is due
+func (s *TicketStatuses) Check(instances []*Instance, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checking || now.Sub(s.lastCheck) < ticketStatusInterval {
+		return
+	}
+	seen := make(map[string]bool)
+	var tickets []Ticket
+	for _, inst := range instances {
+		if t := inst.Ticket; t != nil && !seen[ticketKey(t)] {
+			seen[ticketKey(t)] = true
+			tickets = append(tickets, *t)
+		}
+	}
+	s.lastCheck = now
+	if len(tickets) == 0 {
+		return
+	}
+	s.checking = true
+	go s.refresh(tickets)
+}
+
+// refresh fetches each ticket, keeping the last result for those that fail
+// (offline, no API key) and forgetting tickets no session links anymore
+func (s *TicketStatuses) refresh(tickets []Ticket) {
+	fetched := make(map[string]*Ticket, len(tickets))
+	for _, t := range tickets {
+		if err := t.Fetch(); err != nil {
+			sessionLog.Debug("ticket_refresh_failed", slog.String("ticket", t.ID), slog.String("error", err.Error()))
+			continue
+		}
+		fetched[ticketKey(&t)] = &t
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range tickets {
+		key := ticketKey(&t)
+		if _, ok := fetched[key]; !ok {
+			if last, ok := s.tickets[key]; ok {
+				fetched[key] = last
+			}
+		}
+	}
+	s.tickets = fetched
+	s.checking = false
+}
+
+// Current returns the ticket as last fetched, or t until it has been
+func (s *TicketStatuses) Current(t *Ticket) *Ticket {
+	if t == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if fresh, ok := s.tickets[ticketKey(t)]; ok {
+		return fresh
+	}
+	return t
+}
@@ -0,0 +1,144 @@
+package session
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTicketRef(t *testing.T) {
+	linear := TicketSettings{}
+	jira := TicketSettings{JiraURL: "https://acme.atlassian.net/"}
+
+	tk, err := ParseTicketRef("eng-123", linear)
+	if err != nil || tk.Provider != TicketLinear || tk.ID != "ENG-123" || tk.URL != "" {
+		t.Errorf("bare ID with Linear default = %+v, %v", tk, err)
+	}
+	tk, err = ParseTicketRef("OPS-7", jira)
+	if err != nil || tk.Provider != TicketJira || tk.URL != "https://acme.atlassian.net/browse/OPS-7" {
+		t.Errorf("bare ID with jira_url = %+v, %v", tk, err)
+	}
+	if _, err := ParseTicketRef("OPS-7", TicketSettings{Provider: TicketJira}); err == nil {
+		t.Error("Jira ID without jira_url should fail")
+	}
+
+	tk, err = ParseTicketRef("https://linear.app/acme/issue/ENG-42/fix-login", jira)
+	if err != nil || tk.Provider != TicketLinear || tk.ID != "ENG-42" {
+		t.Errorf("Linear URL = %+v, %v", tk, err)
+	}
+	tk, err = ParseTicketRef("https://jira.example.com/jira/browse/ops-9", linear)
+	if err != nil || tk.Provider != TicketJira || tk.ID != "OPS-9" || tk.URL != "https://jira.example.com/jira/browse/OPS-9" {
+		t.Errorf("Jira URL = %+v, %v", tk, err)
+	}
+
+	for _, bad := range []string{"", "123", "https://example.com/nothing"} {
+		if _, err := ParseTicketRef(bad, linear); err == nil {
+			t.Errorf("ParseTicketRef(%q) should fail", bad)
+		}
+	}
+}
+
+func TestTicketFetchLinear(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "lin_key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			Variables map[string]string `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Variables["id"] != "ENG-1" {
+			t.Errorf("queried id %q", req.Variables["id"])
+		}
+		_, _ = w.Write([]byte(`{"data":{"issue":{"title":"Fix login","url":"https://linear.app/acme/issue/ENG-1/fix-login","state":{"name":"In Progress"}}}}`))
+	}))
+	defer server.Close()
+	old := linearAPIURL
+	linearAPIURL = server.URL
+	defer func() { linearAPIURL = old }()
+	t.Setenv("LINEAR_API_KEY", "lin_key")
+
+	tk := &Ticket{Provider: TicketLinear, ID: "ENG-1"}
+	if err := tk.Fetch(); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if tk.Title != "Fix login" || tk.Status != "In Progress" || tk.URL == "" || tk.FetchedAt.IsZero() {
+		t.Errorf("fetched ticket = %+v", tk)
+	}
+	if got := tk.String(); got != "ENG-1 (In Progress)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestTicketStatusesRefresh(t *testing.T) {
+	status := "In Progress"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"issue":{"title":"Fix login","state":{"name":"` + status + `"}}}}`))
+	}))
+	defer server.Close()
+	old := linearAPIURL
+	linearAPIURL = server.URL
+	defer func() { linearAPIURL = old }()
+	t.Setenv("LINEAR_API_KEY", "lin_key")
+
+	linked := &Ticket{Provider: TicketLinear, ID: "ENG-1", Status: "Todo"}
+	s := NewTicketStatuses()
+	if got := s.Current(linked); got != linked {
+		t.Errorf("before a refresh Current() = %+v, want the linked ticket", got)
+	}
+	s.refresh([]Ticket{*linked})
+	if got := s.Current(linked).Status; got != "In Progress" {
+		t.Errorf("status = %q, want In Progress", got)
+	}
+
+	// A failed fetch keeps the last result
+	t.Setenv("LINEAR_API_KEY", "")
+	s.refresh([]Ticket{*linked})
+	if got := s.Current(linked).Status; got != "In Progress" {
+		t.Errorf("status after a failed fetch = %q, want In Progress", got)
+	}
+	if linked.Status != "Todo" {
+		t.Errorf("the session's ticket was changed: %+v", linked)
+	}
+}
+
+func TestTicketFetchJira(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/OPS-9" {
+			t.Errorf("requested %s", r.URL.Path)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "me@acme.dev" || pass != "tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"fields":{"summary":"Rotate keys","status":{"name":"To Do"}}}`))
+	}))
+	defer server.Close()
+	t.Setenv("JIRA_EMAIL", "me@acme.dev")
+	t.Setenv("JIRA_API_TOKEN", "tok")
+
+	tk := &Ticket{Provider: TicketJira, ID: "OPS-9", URL: server.URL + "/browse/OPS-9"}
+	if err := tk.Fetch(); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if tk.Title != "Rotate keys" || tk.Status != "To Do" {
+		t.Errorf("fetched ticket = %+v", tk)
+	}
+}
+
+func TestExpandPrompt(t *testing.T) {
+	inst := NewInstance("api", "/tmp/api")
+	if got := inst.ExpandPrompt("work on {ticket}"); got != "work on " {
+		t.Errorf("without a ticket = %q", got)
+	}
+	inst.Ticket = &Ticket{ID: "ENG-5", Title: "Add retries", URL: "https://linear.app/a/issue/ENG-5", Status: "Todo"}
+	got := inst.ExpandPrompt("Fix {ticket}: {ticket-title} ({ticket-status}) {ticket-url}")
+	if want := "Fix ENG-5: Add retries (Todo) https://linear.app/a/issue/ENG-5"; got != want {
+		t.Errorf("ExpandPrompt = %q, want %q", got, want)
+	}
+	if tk := unmarshalTicket(marshalTicket(inst.Ticket)); tk == nil || *tk != *inst.Ticket {
+		t.Errorf("tool_data round trip = %+v", tk)
+	}
+}
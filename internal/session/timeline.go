@@ -0,0 +1,107 @@
+package session
+
+import (
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// TimelineLane is one session's swimlane in the activity timeline
+type TimelineLane struct {
+	InstanceID string
+	// Slots holds the activity kind that filled most of each equal slice of
+	// the range ("" for none)
+	Slots []string
+	// Waiting is the total time spent waiting for input, LongestWait the
+	// longest single stretch
+	Waiting     time.Duration
+	LongestWait time.Duration
+}
+
+// timelinePriority breaks ties between kinds covering a slot equally; a
+// session is attached while it runs, so running shows over attached
+var timelinePriority = map[string]int{
+	ActivityAttached: 1,
+	ActivityRunning:  2,
+	ActivityWaiting:  3,
+}
+
+// BuildTimeline splits from..to into slots and returns a lane for every
+// session with activity in the range, keyed by instance ID
+func BuildTimeline(rows []statedb.ActivityRow, from, to time.Time, slots int) map[string]*TimelineLane {
+	lanes := make(map[string]*TimelineLane)
+	if slots <= 0 || !to.After(from) {
+		return lanes
+	}
+	slotLen := to.Sub(from) / time.Duration(slots)
+	if slotLen <= 0 {
+		return lanes
+	}
+
+	// Time each kind covers in each slot, per session
+	coverage := make(map[string]map[string][]time.Duration)
+	for _, r := range rows {
+		if r.Started.Before(from) {
+			r.Started = from
+		}
+		if r.Ended.After(to) {
+			r.Ended = to
+		}
+		if !r.Ended.After(r.Started) {
+			continue
+		}
+		lane, ok := lanes[r.InstanceID]
+		if !ok {
+			lane = &TimelineLane{InstanceID: r.InstanceID, Slots: make([]string, slots)}
+			lanes[r.InstanceID] = lane
+			coverage[r.InstanceID] = make(map[string][]time.Duration)
+		}
+		if r.Kind == ActivityWaiting {
+			if d := r.Ended.Sub(r.Started); d > lane.LongestWait {
+				lane.LongestWait = d
+			}
+		}
+		kinds := coverage[r.InstanceID]
+		if kinds[r.Kind] == nil {
+			kinds[r.Kind] = make([]time.Duration, slots)
+		}
+		first := int(r.Started.Sub(from) / slotLen)
+		for s := first; s < slots; s++ {
+			slotStart := from.Add(time.Duration(s) * slotLen)
+			slotEnd := slotStart.Add(slotLen)
+			if !r.Ended.After(slotStart) {
+				break
+			}
+			start, end := r.Started, r.Ended
+			if start.Before(slotStart) {
+				start = slotStart
+			}
+			if end.After(slotEnd) {
+				end = slotEnd
+			}
+			kinds[r.Kind][s] += end.Sub(start)
+		}
+	}
+
+	for id, lane := range lanes {
+		for s := range lane.Slots {
+			var best time.Duration
+			for kind, cov := range coverage[id] {
+				d := cov[s]
+				if d == 0 {
+					continue
+				}
+				if d > best || (d == best && timelinePriority[kind] > timelinePriority[lane.Slots[s]]) {
+					best = d
+					lane.Slots[s] = kind
+				}
+			}
+		}
+	}
+	for id, totals := range SumActivity(rows, from, to) {
+		if lane, ok := lanes[id]; ok {
+			lane.Waiting = totals.Waiting
+		}
+	}
+	return lanes
+}
@@ -0,0 +1,45 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h float64) time.Time { return from.Add(time.Duration(h * float64(time.Hour))) }
+	rows := []statedb.ActivityRow{
+		{InstanceID: "a", Kind: ActivityRunning, Started: at(0), Ended: at(2)},
+		// Attached while running: running wins the tie
+		{InstanceID: "a", Kind: ActivityAttached, Started: at(0), Ended: at(2)},
+		{InstanceID: "a", Kind: ActivityWaiting, Started: at(2), Ended: at(5)},
+		// A short wait inside a mostly running slot doesn't take it
+		{InstanceID: "a", Kind: ActivityRunning, Started: at(5), Ended: at(6)},
+		{InstanceID: "a", Kind: ActivityWaiting, Started: at(5.9), Ended: at(6)},
+		// Before the range
+		{InstanceID: "b", Kind: ActivityRunning, Started: at(-3), Ended: at(-1)},
+	}
+
+	lanes := BuildTimeline(rows, from, at(8), 8)
+	if _, ok := lanes["b"]; ok {
+		t.Error("b has no activity in range and should have no lane")
+	}
+	a := lanes["a"]
+	if a == nil {
+		t.Fatal("missing lane for a")
+	}
+	want := []string{ActivityRunning, ActivityRunning, ActivityWaiting, ActivityWaiting, ActivityWaiting, ActivityRunning, "", ""}
+	for s, kind := range want {
+		if a.Slots[s] != kind {
+			t.Errorf("slot %d = %q, want %q", s, a.Slots[s], kind)
+		}
+	}
+	if a.Waiting != 3*time.Hour+6*time.Minute {
+		t.Errorf("Waiting = %v, want 3h6m", a.Waiting)
+	}
+	if a.LongestWait != 3*time.Hour {
+		t.Errorf("LongestWait = %v, want 3h", a.LongestWait)
+	}
+}
@@ -0,0 +1,238 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// toolVersionTimeout bounds `<tool> --version`; some CLIs check for updates
+// before printing it
+const toolVersionTimeout = 5 * time.Second
+
+// versionPattern finds the version in --version output ("2.1.3 (Claude
+// Code)", "codex-cli 0.46.0", "aider 0.86.1")
+var versionPattern = regexp.MustCompile(`\d+\.\d+(?:\.\d+)?(?:-[0-9A-Za-z.]+)?`)
+
+// versionCheckedTools are the built-in tools whose CLI is named after them
+var versionCheckedTools = []string{"claude", "gemini", "opencode", "codex", "aider"}
+
+// ToolVersion is what's installed for a tool
+type ToolVersion struct {
+	Tool    string `json:"tool"`
+	Binary  string `json:"binary"`
+	Path    string `json:"path,omitempty"`    // in PATH, "" when not installed
+	Version string `json:"version,omitempty"` // "" when --version didn't say
+	Broken  string `json:"broken,omitempty"`  // why the version is known not to work
+}
+
+// Installed reports whether the tool's binary is in PATH
+func (v ToolVersion) Installed() bool {
+	return v.Path != ""
+}
+
+// String formats the version for display: "claude 2.1.3"
+func (v ToolVersion) String() string {
+	switch {
+	case !v.Installed():
+		return v.Binary + " not installed"
+	case v.Version == "":
+		return v.Binary + " (unknown version)"
+	}
+	return v.Binary + " " + v.Version
+}
+
+var (
+	toolVersionMu    sync.Mutex
+	toolVersionCache = make(map[string]ToolVersion) // by binary
+)
+
+// toolBinary returns the binary a tool runs: the first word of a custom
+// tool's command, else the tool's own name. "" for shells.
+func toolBinary(tool string) string {
+	if def := GetToolDef(tool); def != nil {
+		if fields := strings.Fields(def.Command); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	if tool == "" || tool == "shell" {
+		return ""
+	}
+	return tool
+}
+
+// VersionCheckedTools returns the tools to check: the built-in ones,
+// custom [tools] and whatever else sessions use, without shells
+func VersionCheckedTools(instances []*Instance) []string {
+	seen := make(map[string]bool)
+	var tools []string
+	add := func(tool string) {
+		if toolBinary(tool) != "" && !seen[tool] {
+			seen[tool] = true
+			tools = append(tools, tool)
+		}
+	}
+	for _, tool := range versionCheckedTools {
+		add(tool)
+	}
+	for _, tool := range GetCustomToolNames() {
+		add(tool)
+	}
+	for _, inst := range instances {
+		add(inst.Tool)
+	}
+	sort.Strings(tools[len(versionCheckedTools):])
+	return tools
+}
+
+// DetectToolVersion runs the tool's binary with --version, once per binary
+// and process, and checks the result against [tool_versions] broken
+func DetectToolVersion(tool string) ToolVersion {
+	binary := toolBinary(tool)
+	toolVersionMu.Lock()
+	v, ok := toolVersionCache[binary]
+	toolVersionMu.Unlock()
+	if !ok {
+		v = readToolVersion(binary)
+		toolVersionMu.Lock()
+		toolVersionCache[binary] = v
+		toolVersionMu.Unlock()
+	}
+	v.Tool = tool
+	v.Broken = brokenToolVersion(tool, v.Version, GetToolVersionSettings().Broken)
+	return v
+}
+
+// DetectToolVersions detects the versions of tools in parallel
+func DetectToolVersions(tools []string) []ToolVersion {
+	versions := make([]ToolVersion, len(tools))
+	var wg sync.WaitGroup
+	for n, tool := range tools {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			versions[n] = DetectToolVersion(tool)
+		}()
+	}
+	wg.Wait()
+	return versions
+}
+
+// CachedToolVersion returns the tool's version if it has been detected,
+// without running anything
+func CachedToolVersion(tool string) (ToolVersion, bool) {
+	binary := toolBinary(tool)
+	toolVersionMu.Lock()
+	v, ok := toolVersionCache[binary]
+	toolVersionMu.Unlock()
+	if !ok {
+		return ToolVersion{}, false
+	}
+	v.Tool = tool
+	v.Broken = brokenToolVersion(tool, v.Version, GetToolVersionSettings().Broken)
+	return v, true
+}
+
+// readToolVersion finds binary in PATH and reads its version
+func readToolVersion(binary string) ToolVersion {
+	v := ToolVersion{Binary: binary}
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return v
+	}
+	v.Path = path
+
+	ctx, cancel := context.WithTimeout(context.Background(), toolVersionTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, path, "--version").CombinedOutput()
+	if err != nil {
+		sessionLog.Debug("tool_version_failed", slog.String("binary", binary), slog.String("error", err.Error()))
+	}
+	v.Version = versionPattern.FindString(string(output))
+	return v
+}
+
+// brokenToolVersion returns the reason version of tool is listed as broken,
+// "" when it isn't (or the version is unknown)
+func brokenToolVersion(tool, version string, broken []BrokenToolVersion) string {
+	if version == "" {
+		return ""
+	}
+	for _, b := range broken {
+		if b.Tool != tool || !versionMatches(version, b.Versions) {
+			continue
+		}
+		if b.Reason != "" {
+			return b.Reason
+		}
+		return fmt.Sprintf("listed as broken (%s)", b.Versions)
+	}
+	return ""
+}
+
+// versionMatches reports whether version is in spec: an exact version, a
+// release line ("2.0" matches 2.0.x) or a bound with <, <=, > or >=
+func versionMatches(version, spec string) bool {
+	spec = strings.TrimSpace(spec)
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		bound, ok := strings.CutPrefix(spec, op)
+		if !ok {
+			continue
+		}
+		cmp := compareVersions(version, strings.TrimSpace(bound))
+		switch op {
+		case "<=":
+			return cmp <= 0
+		case ">=":
+			return cmp >= 0
+		case "<":
+			return cmp < 0
+		default:
+			return cmp > 0
+		}
+	}
+	return version == spec || strings.HasPrefix(version, spec+".")
+}
+
+// compareVersions compares dotted versions numerically, ignoring
+// pre-release suffixes; missing parts count as 0
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for n := 0; n < max(len(pa), len(pb)); n++ {
+		var x, y int
+		if n < len(pa) {
+			x = pa[n]
+		}
+		if n < len(pb) {
+			y = pb[n]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionParts splits "2.1.3-beta" into [2 1 3]
+func versionParts(version string) []int {
+	version, _, _ = strings.Cut(version, "-")
+	var parts []int
+	for _, p := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
@@ -0,0 +1,70 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVersionMatches(t *testing.T) {
+	tests := []struct {
+		version, spec string
+		want          bool
+	}{
+		{"2.0.3", "2.0.3", true},
+		{"2.0.30", "2.0.3", false},
+		{"2.0.3", "2.0", true},
+		{"2.1.0", "2.0", false},
+		{"1.0.23", "<1.0.24", true},
+		{"1.0.24", "<1.0.24", false},
+		{"1.0.24", "<=1.0.24", true},
+		{"3.0.0", ">=3", true},
+		{"2.9.9", ">2", true},
+		{"2.0.0", ">2", false},
+		{"2.1.0-beta.1", "<2.1.1", true},
+	}
+	for _, tt := range tests {
+		if got := versionMatches(tt.version, tt.spec); got != tt.want {
+			t.Errorf("versionMatches(%q, %q) = %v, want %v", tt.version, tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestBrokenToolVersion(t *testing.T) {
+	broken := []BrokenToolVersion{
+		{Tool: "claude", Versions: "2.0.3", Reason: "prompt not detected"},
+		{Tool: "codex", Versions: "<0.40"},
+	}
+	if got := brokenToolVersion("claude", "2.0.3", broken); got != "prompt not detected" {
+		t.Errorf("claude 2.0.3 = %q", got)
+	}
+	if got := brokenToolVersion("claude", "2.0.4", broken); got != "" {
+		t.Errorf("claude 2.0.4 = %q, want not broken", got)
+	}
+	if got := brokenToolVersion("codex", "0.39.1", broken); got != "listed as broken (<0.40)" {
+		t.Errorf("codex 0.39.1 = %q", got)
+	}
+	if got := brokenToolVersion("gemini", "2.0.3", broken); got != "" {
+		t.Errorf("other tool = %q, want not broken", got)
+	}
+	if got := brokenToolVersion("claude", "", broken); got != "" {
+		t.Errorf("unknown version = %q, want not broken", got)
+	}
+}
+
+func TestReadToolVersion(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho '2.1.3 (Claude Code)'\n"
+	if err := os.WriteFile(filepath.Join(dir, "fake-agent"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	v := readToolVersion("fake-agent")
+	if !v.Installed() || v.Version != "2.1.3" || v.String() != "fake-agent 2.1.3" {
+		t.Errorf("readToolVersion = %+v", v)
+	}
+	if v := readToolVersion("missing-agent"); v.Installed() || v.String() != "missing-agent not installed" {
+		t.Errorf("missing binary = %+v", v)
+	}
+}
@@ -27,6 +27,8 @@ type ClaudeOptions struct {
 	AllowSkipPermissions bool `json:"allow_skip_permissions,omitempty"`
 	// UseChrome adds --chrome flag
 	UseChrome bool `json:"use_chrome,omitempty"`
+	// Model adds --model (e.g., "opus", "claude-sonnet-4-5-20250929")
+	Model string `json:"model,omitempty"`
 
 	// Transient fields for worktree fork (not persisted)
 	WorkDir          string `json:"-"`
@@ -64,6 +66,9 @@ func (o *ClaudeOptions) ToArgs() []string {
 	if o.UseChrome {
 		args = append(args, "--chrome")
 	}
+	if o.Model != "" {
+		args = append(args, "--model", o.Model)
+	}
 
 	return args
 }
@@ -81,6 +86,9 @@ func (o *ClaudeOptions) ToArgsForFork() []string {
 	if o.UseChrome {
 		args = append(args, "--chrome")
 	}
+	if o.Model != "" {
+		args = append(args, "--model", o.Model)
+	}
 
 	return args
 }
@@ -102,6 +110,8 @@ type CodexOptions struct {
 	// YoloMode enables --yolo flag (bypass approvals and sandbox)
 	// nil = inherit from global config, true/false = explicit override
 	YoloMode *bool `json:"yolo_mode,omitempty"`
+	// Model adds -m (e.g., "gpt-5-codex")
+	Model string `json:"model,omitempty"`
 }
 
 // ToolName returns "codex"
@@ -115,6 +125,9 @@ func (o *CodexOptions) ToArgs() []string {
 	if o.YoloMode != nil && *o.YoloMode {
 		args = append(args, "--yolo")
 	}
+	if o.Model != "" {
+		args = append(args, "-m", o.Model)
+	}
 	return args
 }
 
@@ -67,6 +67,14 @@ func TestClaudeOptions_ToArgs(t *testing.T) {
 			},
 			expected: []string{"--chrome"},
 		},
+		{
+			name: "model",
+			opts: ClaudeOptions{
+				UseChrome: true,
+				Model:     "opus",
+			},
+			expected: []string{"--chrome", "--model", "opus"},
+		},
 		{
 			name: "all flags",
 			opts: ClaudeOptions{
@@ -380,6 +388,11 @@ func TestCodexOptions_ToArgs(t *testing.T) {
 			opts:     CodexOptions{YoloMode: boolPtr(false)},
 			expected: nil,
 		},
+		{
+			name:     "model",
+			opts:     CodexOptions{YoloMode: boolPtr(true), Model: "gpt-5-codex"},
+			expected: []string{"--yolo", "-m", "gpt-5-codex"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,110 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// unpushedInterval is how often the sessions' repos are checked
+const unpushedInterval = time.Minute
+
+// UnpushedWork tracks work that only exists in the sessions' working copies,
+// for a badge nudging to review and push it before it rots: commits ahead
+// of upstream, and changes uncommitted for longer than [unpushed]
+// uncommitted_hours. Repos are checked in the background once a minute.
+type UnpushedWork struct {
+	mu        sync.Mutex
+	settings  UnpushedSettings
+	status    map[string]git.WorkStatus // project path -> last check
+	lastCheck time.Time
+	checking  bool
+}
+
+// NewUnpushedWork creates a tracker with the [unpushed] settings
+func NewUnpushedWork(settings UnpushedSettings) *UnpushedWork {
+	return &UnpushedWork{settings: settings, status: make(map[string]git.WorkStatus)}
+}
+
+// SetSettings replaces the settings (after the config is edited)
+func (u *UnpushedWork) SetSettings(settings UnpushedSettings) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.settings = settings
+}
+
+// Check starts a background check of the sessions' repos when one is due
+func (u *UnpushedWork) Check(instances []*Instance, now time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !u.settings.GetEnabled() || u.checking || now.Sub(u.lastCheck) < unpushedInterval {
+		return
+	}
+	seen := make(map[string]bool, len(instances))
+	paths := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		if inst.ProjectPath != "" && !seen[inst.ProjectPath] {
+			seen[inst.ProjectPath] = true
+			paths = append(paths, inst.ProjectPath)
+		}
+	}
+	u.lastCheck = now
+	u.checking = true
+	go u.refresh(paths)
+}
+
+// refresh checks paths, forgetting the ones that aren't (or no longer are)
+// used by a session or in a git repo
+func (u *UnpushedWork) refresh(paths []string) {
+	status := make(map[string]git.WorkStatus, len(paths))
+	for _, path := range paths {
+		if !git.IsGitRepo(path) {
+			continue
+		}
+		ws, err := git.GetWorkStatus(path)
+		if err != nil {
+			sessionLog.Debug("unpushed_check_failed", slog.String("path", path), slog.String("error", err.Error()))
+			continue
+		}
+		status[path] = ws
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.status = status
+	u.checking = false
+}
+
+// Badge returns the label for the session's local work, "" for none
+func (u *UnpushedWork) Badge(inst *Instance, now time.Time) string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !u.settings.GetEnabled() {
+		return ""
+	}
+	ws, ok := u.status[inst.ProjectPath]
+	if !ok {
+		return ""
+	}
+	var parts []string
+	if ws.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("%d unpushed", ws.Ahead))
+	}
+	if ws.Uncommitted && !ws.OldestChange.IsZero() {
+		if age := now.Sub(ws.OldestChange); age >= u.settings.GetUncommittedAge() {
+			parts = append(parts, "uncommitted "+shortAge(age))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// shortAge formats a duration of hours or more as "5h" or "3d"
+func shortAge(d time.Duration) string {
+	if d >= 48*time.Hour {
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
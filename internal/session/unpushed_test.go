@@ -0,0 +1,63 @@
+package session
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+func TestUnpushedWorkBadge(t *testing.T) {
+	now := time.Now()
+	u := NewUnpushedWork(UnpushedSettings{UncommittedHours: 2})
+	u.status = map[string]git.WorkStatus{
+		"/p/ahead": {Ahead: 3},
+		"/p/fresh": {Uncommitted: true, OldestChange: now.Add(-30 * time.Minute)},
+		"/p/stale": {Ahead: 1, Uncommitted: true, OldestChange: now.Add(-5 * time.Hour)},
+		"/p/old":   {Uncommitted: true, OldestChange: now.Add(-72 * time.Hour)},
+	}
+	for path, want := range map[string]string{
+		"/p/ahead": "3 unpushed",
+		"/p/fresh": "",
+		"/p/stale": "1 unpushed, uncommitted 5h",
+		"/p/old":   "uncommitted 3d",
+		"/p/other": "",
+	} {
+		if got := u.Badge(NewInstance("s", path), now); got != want {
+			t.Errorf("Badge(%s) = %q, want %q", path, got, want)
+		}
+	}
+
+	off := false
+	u.SetSettings(UnpushedSettings{Enabled: &off})
+	if got := u.Badge(NewInstance("s", "/p/ahead"), now); got != "" {
+		t.Errorf("disabled badge = %q", got)
+	}
+}
+
+func TestUnpushedWorkRefresh(t *testing.T) {
+	repo := t.TempDir()
+	if err := exec.Command("git", "init", "-q", repo).Run(); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(repo, "notes.md")
+	if err := os.WriteFile(file, []byte("draft"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-6 * time.Hour)
+	if err := os.Chtimes(file, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	u := NewUnpushedWork(UnpushedSettings{})
+	u.refresh([]string{repo, t.TempDir()})
+	if len(u.status) != 1 {
+		t.Fatalf("status = %+v, want only the git repo", u.status)
+	}
+	if got := u.Badge(NewInstance("s", repo), time.Now()); got != "uncommitted 6h" {
+		t.Errorf("Badge = %q, want uncommitted 6h", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/BurntSushi/toml"
 
@@ -92,6 +93,124 @@ type UserConfig struct {
 
 	// Tmux defines tmux option overrides applied to every session
 	Tmux TmuxSettings `toml:"tmux"`
+
+	// Checkpoint defines automatic git checkpoints when sessions finish working
+	Checkpoint CheckpointSettings `toml:"checkpoint"`
+
+	// Terminal defines how sessions are opened in external terminal windows
+	Terminal TerminalSettings `toml:"terminal"`
+
+	// Scaffolds defines multi-session project layouts for `agent-deck new --scaffold`
+	Scaffolds map[string]ScaffoldDef `toml:"scaffolds"`
+
+	// Templates defines reusable session setups for `agent-deck add --template`
+	// and the new-session dialog
+	Templates map[string]SessionTemplate `toml:"templates"`
+
+	// Hosts declares remote hosts by friendly name for `--container ssh:<name>`
+	// (hosts in ~/.ssh/config work without being declared here)
+	Hosts map[string]HostDef `toml:"hosts"`
+
+	// Sync defines where `agent-deck sync` shares sessions: a git remote or a synced folder
+	Sync SyncSettings `toml:"sync"`
+
+	// Accessibility defines settings for screen readers and limited terminals
+	Accessibility AccessibilitySettings `toml:"accessibility"`
+
+	// Suggestions configures path suggestions in the new session dialog
+	Suggestions SuggestionSettings `toml:"suggestions"`
+
+	// Tickets configures linking sessions to Linear or Jira tickets
+	Tickets TicketSettings `toml:"tickets"`
+
+	// Pricing overrides the per-model prices (USD per million tokens) used to
+	// estimate API spend, keyed by model name or prefix
+	Pricing map[string]ModelPricing `toml:"pricing"`
+
+	// Daemon configures `agent-deck daemon`, which serves session statuses over HTTP
+	Daemon DaemonSettings `toml:"daemon"`
+
+	// Confirm controls which destructive actions ask for confirmation
+	Confirm ConfirmSettings `toml:"confirm"`
+
+	// Handoff configures the "where I left off" note asked for on detach
+	Handoff HandoffSettings `toml:"handoff"`
+
+	// RepoLabels fills titles and groups of new sessions from the git remote
+	RepoLabels RepoLabelSettings `toml:"repo_labels"`
+
+	// GroupRules pick the group of new sessions by project path
+	GroupRules []GroupRule `toml:"group_rules"`
+
+	// BulkStart limits how many sessions start at once in batch runs
+	BulkStart BulkStartSettings `toml:"bulk_start"`
+
+	// RateLimits controls what happens while a provider rate-limits a session
+	RateLimits RateLimitSettings `toml:"rate_limits"`
+
+	// AutoName titles sessions after their task instead of the folder name
+	AutoName AutoNameSettings `toml:"auto_name"`
+
+	// Models lists the models offered by the model switcher, per tool
+	// (e.g. claude = ["opus", "sonnet"]); see model.go for the defaults
+	Models map[string][]string `toml:"models"`
+
+	// ToolVersions checks the versions of installed tools against ones
+	// known not to work
+	ToolVersions ToolVersionSettings `toml:"tool_versions"`
+
+	// YoloSandbox runs YOLO sessions in a container with the project
+	// mounted instead of on the host
+	YoloSandbox YoloSandboxSettings `toml:"yolo_sandbox"`
+
+	// Webhooks post session status changes to your own endpoints
+	Webhooks []WebhookDef `toml:"webhooks"`
+
+	// Unpushed badges sessions whose repo has work only on this machine
+	Unpushed UnpushedSettings `toml:"unpushed"`
+
+	// Backup exports the deck's storage to a backup directory once a day
+	Backup BackupSettings `toml:"backup"`
+
+	// Hooks are the default pre-start, pre-attach, post-detach and post-exit
+	// commands for sessions whose tool and session set none
+	Hooks HookSettings `toml:"hooks"`
+
+	// TeamDecks are shared, read-only decks whose sessions members add locally
+	TeamDecks []TeamDeckDef `toml:"team_decks"`
+}
+
+// SyncSettings configures `agent-deck sync`, which shares sessions and
+// config.toml between machines through a git remote or a synced folder
+type SyncSettings struct {
+	// Remote is the git URL to sync with, e.g. a private repo
+	Remote string `toml:"remote"`
+
+	// Path is a folder kept in sync by Dropbox, iCloud Drive, Syncthing etc.,
+	// used instead of Remote when set
+	Path string `toml:"path"`
+
+	// Branch to sync on (default: "main")
+	Branch string `toml:"branch"`
+
+	// Config also syncs config.toml (default: true)
+	Config *bool `toml:"config"`
+}
+
+// GetBranch returns the sync branch, defaulting to "main"
+func (s SyncSettings) GetBranch() string {
+	if s.Branch == "" {
+		return "main"
+	}
+	return s.Branch
+}
+
+// GetConfig returns whether config.toml is synced, defaulting to true
+func (s SyncSettings) GetConfig() bool {
+	if s.Config == nil {
+		return true
+	}
+	return *s.Config
 }
 
 // MCPPoolSettings defines HTTP MCP pool configuration
@@ -216,6 +335,43 @@ type PreviewSettings struct {
 
 	// Analytics configures which sections to show in the analytics panel
 	Analytics AnalyticsDisplaySettings `toml:"analytics"`
+
+	// FullPaths shows project paths in full instead of eliding the middle
+	// (~/code/…/service/api). Default: false
+	FullPaths bool `toml:"full_paths"`
+
+	// Highlight colors matching text in the preview pane and log viewer
+	// Default: true (pointer to distinguish "not set" from "explicitly false")
+	Highlight *bool `toml:"highlight"`
+
+	// HighlightRules replaces the built-in highlight rules when non-empty.
+	// Rules are applied in order; the first rule matching a span wins.
+	//
+	// Example config.toml:
+	//
+	//	[[preview.highlight_rules]]
+	//	pattern = '(?i)\bdeprecated\b'
+	//	style = "yellow,italic"
+	HighlightRules []HighlightRule `toml:"highlight_rules"`
+}
+
+// HighlightRule styles every match of a regular expression in preview output
+type HighlightRule struct {
+	// Pattern is a Go regular expression (RE2 syntax)
+	Pattern string `toml:"pattern"`
+
+	// Style is a comma-separated list of colors and attributes, e.g. "red,bold".
+	// Colors: red, green, yellow, cyan, purple, orange, accent, dim, or "#rrggbb" / ANSI number.
+	// Attributes: bold, italic, underline, faint, reverse.
+	Style string `toml:"style"`
+}
+
+// DefaultHighlightRules are applied when no highlight_rules are configured:
+// errors in red, file paths underlined, approval prompts in yellow.
+var DefaultHighlightRules = []HighlightRule{
+	{Pattern: `(?i)\b(error|errors|failed|failure|fatal|panic|exception|traceback)\b`, Style: "red,bold"},
+	{Pattern: `(?i)waiting for (your )?(approval|permission|input)|do you want to (proceed|continue|make this edit)`, Style: "yellow,bold"},
+	{Pattern: `(?:~|\.{1,2})?(?:/[\w.@+-]+)+\.\w+(?::\d+){0,2}|\b[\w.@+-]+(?:/[\w.@+-]+)+\.\w+(?::\d+){0,2}`, Style: "underline"},
 }
 
 // AnalyticsDisplaySettings configures which analytics sections to display
@@ -252,13 +408,421 @@ type ExperimentsSettings struct {
 	DefaultTool string `toml:"default_tool"`
 }
 
-// NotificationsConfig configures the waiting session notification bar
+// NotificationsConfig configures the waiting session notification bar and
+// the alerts sent when a session starts waiting
 type NotificationsConfig struct {
 	// Enabled shows notification bar in tmux status (default: true)
 	Enabled bool `toml:"enabled"`
 
 	// MaxShown is the maximum number of sessions shown in the bar (default: 6)
 	MaxShown int `toml:"max_shown"`
+
+	// NotificationPolicy is the default alert policy
+	NotificationPolicy
+
+	// Groups overrides the policy per group path. A group's policy also
+	// applies to its subgroups; unset keys inherit from the parent.
+	//
+	// Example config.toml:
+	//
+	//	[notifications.groups."clients"]
+	//	channel = "webhook"
+	//	escalate_after_minutes = 5
+	//
+	//	[notifications.groups."experiments"]
+	//	channel = "none"
+	Groups map[string]NotificationPolicy `toml:"groups"`
+
+	// QuietHours is a daily local-time window like "22:00-08:00" during which
+	// alerts are held, then sent as one digest per channel when it ends
+	QuietHours string `toml:"quiet_hours"`
+}
+
+// parseQuietHours parses "HH:MM-HH:MM" into minutes after midnight
+func parseQuietHours(s string) (start, end int, err error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "–", "-")
+	from, to, ok := strings.Cut(s, "-")
+	if ok {
+		var fromT, toT time.Time
+		if fromT, err = time.Parse("15:04", strings.TrimSpace(from)); err == nil {
+			if toT, err = time.Parse("15:04", strings.TrimSpace(to)); err == nil {
+				return fromT.Hour()*60 + fromT.Minute(), toT.Hour()*60 + toT.Minute(), nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid quiet_hours %q: use HH:MM-HH:MM, e.g. 22:00-08:00", s)
+}
+
+// ValidateQuietHours checks quiet_hours ("" is valid and means none)
+func (n NotificationsConfig) ValidateQuietHours() error {
+	if n.QuietHours == "" {
+		return nil
+	}
+	_, _, err := parseQuietHours(n.QuietHours)
+	return err
+}
+
+// InQuietHours reports whether t falls in the quiet hours window. The window
+// may wrap past midnight; an invalid or empty window is never quiet.
+func (n NotificationsConfig) InQuietHours(t time.Time) bool {
+	if n.QuietHours == "" {
+		return false
+	}
+	start, end, err := parseQuietHours(n.QuietHours)
+	if err != nil || start == end {
+		return false
+	}
+	m := t.Hour()*60 + t.Minute()
+	if start < end {
+		return m >= start && m < end
+	}
+	return m >= start || m < end
+}
+
+// Notification channels
+const (
+	NotifyNone    = "none"
+	NotifyDesktop = "desktop"
+	NotifyWebhook = "webhook"
+)
+
+// NotificationPolicy says how to alert when a session starts waiting
+type NotificationPolicy struct {
+	// Channel is desktop, webhook or none (default: none)
+	Channel string `toml:"channel"`
+
+	// WebhookURL receives a JSON POST per alert (channel or escalate_to webhook)
+	WebhookURL string `toml:"webhook_url"`
+
+	// EscalateAfterMinutes sends a second alert when the session is still
+	// waiting this long after the first (0 inherits, negative disables)
+	EscalateAfterMinutes int `toml:"escalate_after_minutes"`
+
+	// EscalateTo is the channel for the escalation alert (default: channel)
+	EscalateTo string `toml:"escalate_to"`
+}
+
+// GetChannel returns the alert channel, defaulting to none
+func (p NotificationPolicy) GetChannel() string {
+	if p.Channel == "" {
+		return NotifyNone
+	}
+	return p.Channel
+}
+
+// GetEscalateAfter returns how long a session waits before the escalation
+// alert, 0 for no escalation
+func (p NotificationPolicy) GetEscalateAfter() time.Duration {
+	if p.EscalateAfterMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(p.EscalateAfterMinutes) * time.Minute
+}
+
+// GetEscalateTo returns the escalation channel, defaulting to the channel
+func (p NotificationPolicy) GetEscalateTo() string {
+	if p.EscalateTo == "" {
+		return p.GetChannel()
+	}
+	return p.EscalateTo
+}
+
+// merge returns p with the keys set in override replaced
+func (p NotificationPolicy) merge(override NotificationPolicy) NotificationPolicy {
+	if override.Channel != "" {
+		p.Channel = override.Channel
+	}
+	if override.WebhookURL != "" {
+		p.WebhookURL = override.WebhookURL
+	}
+	if override.EscalateAfterMinutes != 0 {
+		p.EscalateAfterMinutes = override.EscalateAfterMinutes
+	}
+	if override.EscalateTo != "" {
+		p.EscalateTo = override.EscalateTo
+	}
+	return p
+}
+
+// PolicyFor returns the alert policy for a session in groupPath: the
+// default, overridden by each configured ancestor group from the root down
+func (n NotificationsConfig) PolicyFor(groupPath string) NotificationPolicy {
+	policy := n.NotificationPolicy
+	if len(n.Groups) == 0 {
+		return policy
+	}
+	parts := strings.Split(strings.Trim(groupPath, "/"), "/")
+	for i := range parts {
+		if override, ok := n.Groups[strings.Join(parts[:i+1], "/")]; ok {
+			policy = policy.merge(override)
+		}
+	}
+	return policy
+}
+
+// UnpushedSettings controls the badge for agent work that only exists
+// locally: commits ahead of upstream, or changes left uncommitted.
+//
+// Example config.toml:
+//
+//	[unpushed]
+//	enabled = true
+//	uncommitted_hours = 4
+type UnpushedSettings struct {
+	// Enabled shows the badge (default: true)
+	Enabled *bool `toml:"enabled"`
+
+	// UncommittedHours is how old uncommitted changes must be before the
+	// badge shows them (default: 4). Unpushed commits show right away.
+	UncommittedHours int `toml:"uncommitted_hours"`
+}
+
+// GetEnabled returns whether the badge is shown, defaulting to true
+func (s UnpushedSettings) GetEnabled() bool {
+	if s.Enabled == nil {
+		return true
+	}
+	return *s.Enabled
+}
+
+// GetUncommittedAge returns how long changes may stay uncommitted before
+// they are badged, defaulting to 4 hours
+func (s UnpushedSettings) GetUncommittedAge() time.Duration {
+	if s.UncommittedHours <= 0 {
+		return 4 * time.Hour
+	}
+	return time.Duration(s.UncommittedHours) * time.Hour
+}
+
+// BackupSettings configures the daily export of every profile's sessions
+// and config.toml (see backup.go), kept apart from the sessions.json.bak
+// files rotated on each save.
+//
+// Example config.toml:
+//
+//	[backup]
+//	enabled = true
+//	dir = "~/Backups/agent-deck"
+//	format = "tar.gz"
+//	keep = 14
+//	include_logs = false
+type BackupSettings struct {
+	// Enabled exports once a day while the TUI runs (default: false)
+	Enabled bool `toml:"enabled"`
+
+	// Dir is where backups are written (default: ~/.agent-deck/backups)
+	Dir string `toml:"dir"`
+
+	// Format is "dir" for a plain directory per backup or "tar.gz" for a
+	// tarball (default: "dir")
+	Format string `toml:"format"`
+
+	// Keep is how many backups to keep, the oldest deleted first (default: 7)
+	Keep int `toml:"keep"`
+
+	// IncludeLogs adds debug.log and ~/.agent-deck/logs (default: false)
+	IncludeLogs bool `toml:"include_logs"`
+}
+
+// GetDir returns the backup directory with ~ expanded
+func (s BackupSettings) GetDir() (string, error) {
+	if s.Dir != "" {
+		return expandTilde(s.Dir), nil
+	}
+	deckDir, err := GetAgentDeckDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(deckDir, "backups"), nil
+}
+
+// GetTarball returns whether backups are written as .tar.gz files
+func (s BackupSettings) GetTarball() bool {
+	return s.Format == "tar.gz" || s.Format == "tgz"
+}
+
+// GetKeep returns how many backups to keep, defaulting to 7
+func (s BackupSettings) GetKeep() int {
+	if s.Keep <= 0 {
+		return 7
+	}
+	return s.Keep
+}
+
+// HookSettings are the deck-wide lifecycle hooks, the fallback after a
+// session's own and its tool's (see attach_hooks.go).
+//
+// Example config.toml:
+//
+//	[hooks]
+//	pre_start = "[ -f compose.yaml ] && docker compose up -d || true"
+//	post_exit = "[ -f compose.yaml ] && docker compose down || true"
+type HookSettings struct {
+	// PreStart runs before a session starts; a failure aborts the start
+	PreStart string `toml:"pre_start"`
+
+	// PreAttach and PostDetach run around attaching, like [tools.*]'s
+	PreAttach  string `toml:"pre_attach"`
+	PostDetach string `toml:"post_detach"`
+
+	// PostExit runs after a session is stopped or its tmux session ends
+	PostExit string `toml:"post_exit"`
+}
+
+// TeamDeckDef is a deck a team publishes, as a git repository or a file in
+// the format of `agent-deck export`, for members to add its sessions from
+// (see team_deck.go). It is only ever read.
+//
+// Example config.toml:
+//
+//	[[team_decks]]
+//	name = "platform"
+//	source = "git@github.com:acme/agent-decks.git"
+//	file = "platform.json"
+type TeamDeckDef struct {
+	// Name identifies the deck; its sessions are added under team/<name>
+	Name string `toml:"name"`
+
+	// Source is a git URL or a path to the deck file
+	Source string `toml:"source"`
+
+	// Branch of a git source (default: "main")
+	Branch string `toml:"branch"`
+
+	// File is the deck's path inside a git source (default: "deck.json")
+	File string `toml:"file"`
+}
+
+// GetBranch returns the branch of a git source, defaulting to "main"
+func (d TeamDeckDef) GetBranch() string {
+	if d.Branch == "" {
+		return "main"
+	}
+	return d.Branch
+}
+
+// GetFile returns the deck's path inside a git source, defaulting to "deck.json"
+func (d TeamDeckDef) GetFile() string {
+	if d.File == "" {
+		return "deck.json"
+	}
+	return d.File
+}
+
+// WebhookDef posts to a URL whenever a session changes status, for routing
+// events into your own automation (see webhooks.go). The body is the event
+// as JSON unless a template is given.
+//
+// Example config.toml:
+//
+//	[[webhooks]]
+//	url = "http://homeassistant.local:8123/api/webhook/agents"
+//	events = ["waiting", "error"]
+//	group = "work"
+//	template = '{"message": {{json .Title}}, "state": "{{.Status}}"}'
+//	headers = { Authorization = "Bearer $HA_TOKEN" }
+type WebhookDef struct {
+	// URL receives a POST per status change
+	URL string `toml:"url"`
+
+	// Events are the statuses that fire the webhook (running, waiting,
+	// idle, error); empty fires on every change
+	Events []string `toml:"events"`
+
+	// Group limits the webhook to sessions in this group and its subgroups
+	Group string `toml:"group"`
+
+	// Template is a Go text/template for the body, given the StatusEvent
+	// fields; {{json .Title}} quotes a value for JSON (default: the event
+	// as JSON)
+	Template string `toml:"template"`
+
+	// ContentType is the body's Content-Type (default: application/json)
+	ContentType string `toml:"content_type"`
+
+	// Headers are sent with each request; $VAR expands from the environment
+	Headers map[string]string `toml:"headers"`
+}
+
+// AccessibilitySettings configures the TUI for assistive technology
+type AccessibilitySettings struct {
+	// ScreenReader uses a linear plain-text layout without box drawing and
+	// prints each selection change as a line (same as --screen-reader)
+	ScreenReader bool `toml:"screen_reader"`
+
+	// Plain renders the TUI and CLI output without colors or Unicode icons
+	// (same as --plain)
+	Plain bool `toml:"plain"`
+}
+
+// SuggestionSettings configures the frequently used directories offered when
+// creating a session, after the paths of existing sessions
+type SuggestionSettings struct {
+	// Zoxide ranks directories from zoxide's database when zoxide is installed,
+	// instead of agent-deck's own recent-directories cache
+	// Default: true (pointer to distinguish "not set" from "explicitly false")
+	Zoxide *bool `toml:"zoxide"`
+
+	// Max caps how many frequent directories are suggested (default: 20, 0 = default)
+	Max int `toml:"max"`
+}
+
+// GetZoxide returns whether to use zoxide's database, defaulting to true
+func (s SuggestionSettings) GetZoxide() bool {
+	if s.Zoxide == nil {
+		return true
+	}
+	return *s.Zoxide
+}
+
+// GetMax returns the suggestion limit, defaulting to 20
+func (s SuggestionSettings) GetMax() int {
+	if s.Max <= 0 {
+		return 20
+	}
+	return s.Max
+}
+
+// TicketSettings configures ticket links (`add --ticket`, `session set <id>
+// ticket`). Credentials come from the environment: LINEAR_API_KEY for Linear,
+// JIRA_EMAIL and JIRA_API_TOKEN for Jira.
+type TicketSettings struct {
+	// Provider resolves bare IDs like ENG-123: "linear" or "jira"
+	// Default: "jira" when jira_url is set, otherwise "linear"
+	Provider string `toml:"provider"`
+
+	// JiraURL is the Jira site, e.g. "https://acme.atlassian.net"
+	JiraURL string `toml:"jira_url"`
+}
+
+// GetProvider returns the provider for bare ticket IDs
+func (s TicketSettings) GetProvider() string {
+	switch s.Provider {
+	case TicketLinear, TicketJira:
+		return s.Provider
+	}
+	if s.JiraURL != "" {
+		return TicketJira
+	}
+	return TicketLinear
+}
+
+// DaemonSettings configures `agent-deck daemon`
+type DaemonSettings struct {
+	// Listen is the address of the status page and JSON endpoints
+	// Default: "127.0.0.1:8420" (use "0.0.0.0:8420" to reach it from other devices)
+	Listen string `toml:"listen"`
+
+	// Token, when set, must be passed as ?token= or a bearer token
+	Token string `toml:"token"`
+}
+
+// GetListen returns the daemon's listen address
+func (s DaemonSettings) GetListen() string {
+	if s.Listen == "" {
+		return "127.0.0.1:8420"
+	}
+	return s.Listen
 }
 
 // InstanceSettings configures multiple agent-deck instance behavior
@@ -267,6 +831,11 @@ type InstanceSettings struct {
 	// When true (default), multiple instances can run, but only the first (primary) manages the notification bar
 	// When false, only one instance can run per profile
 	AllowMultiple *bool `toml:"allow_multiple"`
+
+	// OnConflict chooses what a second TUI does when allow_multiple is false:
+	// "ask" (default) prompts, "exit" refuses to start, "read_only" opens without
+	// saving, "takeover" closes the running instance and starts normally
+	OnConflict string `toml:"on_conflict"`
 }
 
 // GetAllowMultiple returns whether multiple instances are allowed, defaulting to true
@@ -277,6 +846,15 @@ func (i *InstanceSettings) GetAllowMultiple() bool {
 	return *i.AllowMultiple
 }
 
+// GetOnConflict returns the single-instance conflict action, defaulting to "ask"
+func (i *InstanceSettings) GetOnConflict() string {
+	switch i.OnConflict {
+	case "exit", "read_only", "takeover":
+		return i.OnConflict
+	}
+	return "ask"
+}
+
 // ShellSettings defines shell environment configuration for sessions
 type ShellSettings struct {
 	// EnvFiles is a list of .env files to source for ALL sessions
@@ -319,6 +897,26 @@ func (p *PreviewSettings) GetShowOutput() bool {
 	return *p.ShowOutput
 }
 
+// GetHighlight returns whether preview highlighting is enabled, defaulting to true
+func (p *PreviewSettings) GetHighlight() bool {
+	if p.Highlight == nil {
+		return true
+	}
+	return *p.Highlight
+}
+
+// GetHighlightRules returns the configured highlight rules, or the defaults if none are set.
+// Returns nil when highlighting is disabled.
+func (p *PreviewSettings) GetHighlightRules() []HighlightRule {
+	if !p.GetHighlight() {
+		return nil
+	}
+	if len(p.HighlightRules) > 0 {
+		return p.HighlightRules
+	}
+	return DefaultHighlightRules
+}
+
 // GetAnalyticsSettings returns the analytics display settings with defaults applied
 func (p *PreviewSettings) GetAnalyticsSettings() AnalyticsDisplaySettings {
 	return p.Analytics
@@ -526,12 +1124,28 @@ type ToolDef struct {
 	// SessionIDEnv is the tmux environment variable name storing the session ID
 	SessionIDEnv string `toml:"session_id_env"`
 
+	// PreAttach and PostDetach are shell commands run in the project directory
+	// just before attaching to and right after detaching from this tool's
+	// sessions, unless the session sets its own (session set pre-attach)
+	PreAttach  string `toml:"pre_attach"`
+	PostDetach string `toml:"post_detach"`
+
+	// PreStart and PostExit are shell commands run in the project directory
+	// before this tool's sessions start and after they exit, unless the
+	// session sets its own (session set pre-start)
+	PreStart string `toml:"pre_start"`
+	PostExit string `toml:"post_exit"`
+
 	// DangerousMode enables dangerous mode flag for this tool
 	DangerousMode bool `toml:"dangerous_mode"`
 
 	// DangerousFlag is the CLI flag for dangerous mode (e.g., "--dangerously-skip-permissions")
 	DangerousFlag string `toml:"dangerous_flag"`
 
+	// SafeFlag is the CLI flag for safe mode, added instead when dangerous
+	// mode is off (e.g., "--sandbox workspace-write")
+	SafeFlag string `toml:"safe_flag"`
+
 	// OutputFormatFlag is the CLI flag for JSON output format (e.g., "--output-format json")
 	OutputFormatFlag string `toml:"output_format_flag"`
 
@@ -563,103 +1177,653 @@ type ToolDef struct {
 	SpinnerCharsExtra []string `toml:"spinner_chars_extra"`
 }
 
-// HTTPServerConfig defines how to auto-start an HTTP MCP server
-type HTTPServerConfig struct {
-	// Command is the executable to run (e.g., "uvx", "python", "node")
-	Command string `toml:"command"`
+// HTTPServerConfig defines how to auto-start an HTTP MCP server
+type HTTPServerConfig struct {
+	// Command is the executable to run (e.g., "uvx", "python", "node")
+	Command string `toml:"command"`
+
+	// Args are command-line arguments for the server
+	Args []string `toml:"args"`
+
+	// Env is environment variables for the server process
+	Env map[string]string `toml:"env"`
+
+	// StartupTimeout is milliseconds to wait for server to become ready (default: 5000)
+	StartupTimeout int `toml:"startup_timeout"`
+
+	// HealthCheck is an optional health endpoint URL to poll (e.g., "http://localhost:30000/health")
+	// If not set, the main URL is used for health checking
+	HealthCheck string `toml:"health_check"`
+}
+
+// MCPDef defines an MCP server configuration for the MCP Manager
+type MCPDef struct {
+	// Command is the executable to run (e.g., "npx", "docker", "node")
+	// Required for stdio MCPs, optional for HTTP/SSE MCPs
+	Command string `toml:"command"`
+
+	// Args are command-line arguments
+	Args []string `toml:"args"`
+
+	// Env is optional environment variables
+	Env map[string]string `toml:"env"`
+
+	// Description is optional help text shown in the MCP Manager
+	Description string `toml:"description"`
+
+	// URL is the endpoint for HTTP/SSE MCPs (e.g., "http://localhost:8000/mcp")
+	// If set, this MCP uses HTTP or SSE transport instead of stdio
+	URL string `toml:"url"`
+
+	// Transport specifies the MCP transport type: "stdio" (default), "http", or "sse"
+	// Only needed when URL is set; defaults to "http" if URL is present
+	Transport string `toml:"transport"`
+
+	// Headers is optional HTTP headers for HTTP/SSE MCPs (e.g., for authentication)
+	// Example: { Authorization = "Bearer token123" }
+	Headers map[string]string `toml:"headers"`
+
+	// Server defines how to auto-start an HTTP MCP server process
+	// When set, agent-deck will start the server before connecting via HTTP
+	// This is optional - you can also connect to externally managed servers
+	Server *HTTPServerConfig `toml:"server"`
+}
+
+// GetStartupTimeout returns the startup timeout in milliseconds, defaulting to 5000ms
+func (c *HTTPServerConfig) GetStartupTimeout() int {
+	if c.StartupTimeout <= 0 {
+		return 5000 // Default: 5 seconds
+	}
+	return c.StartupTimeout
+}
+
+// IsHTTP returns true if this MCP uses HTTP or SSE transport
+func (m *MCPDef) IsHTTP() bool {
+	return m.URL != ""
+}
+
+// GetTransport returns the transport type, defaulting to "http" if URL is set
+func (m *MCPDef) GetTransport() string {
+	if m.URL == "" {
+		return "stdio"
+	}
+	if m.Transport == "" {
+		return "http"
+	}
+	return m.Transport
+}
+
+// HasAutoStartServer returns true if this HTTP MCP has server auto-start configured
+func (m *MCPDef) HasAutoStartServer() bool {
+	return m.IsHTTP() && m.Server != nil && m.Server.Command != ""
+}
+
+// TmuxSettings allows users to override tmux options applied to every session.
+// Options are applied AFTER agent-deck's defaults, so they take precedence.
+//
+// Example config.toml:
+//
+//	[tmux]
+//	options = { "allow-passthrough" = "all", "history-limit" = "50000" }
+//	socket_name = "agentdeck-{profile}"
+//	sync_titles = true
+type TmuxSettings struct {
+	// Options is a map of tmux option names to values.
+	// These are passed to `tmux set-option -t <session>` after defaults.
+	Options map[string]string `toml:"options"`
+
+	// SocketName runs new sessions on a separate tmux server (`tmux -L`)
+	// instead of the user's default one (default: ""). "{profile}" is
+	// replaced with the profile name, giving each profile its own server.
+	SocketName string `toml:"socket_name"`
+
+	// SyncTitles shows the title each tool sets on its pane (many agents
+	// describe their current task there) next to the session in the deck
+	// (default: false).
+	SyncTitles bool `toml:"sync_titles"`
+}
+
+// GetSocketName returns the tmux socket name for profile, "" for the default
+// server
+func (t TmuxSettings) GetSocketName(profile string) string {
+	return strings.ReplaceAll(strings.TrimSpace(t.SocketName), "{profile}", profile)
+}
+
+// TerminalSettings controls opening sessions and editors in external terminal windows/tabs.
+//
+// Example config.toml:
+//
+//	[terminal]
+//	emulator = "kitty"
+//	attach_in_new_window = true
+type TerminalSettings struct {
+	// Emulator selects the terminal adapter used for new windows and tabs
+	// (default: auto-detect). See terminal.Names() for supported values.
+	Emulator string `toml:"emulator"`
+
+	// AttachInNewWindow makes Enter open the session in a new terminal window
+	// instead of replacing the TUI (default: false). Shift+A always does this.
+	AttachInNewWindow bool `toml:"attach_in_new_window"`
+}
+
+// CheckpointSettings controls automatic git checkpoints taken when a session
+// transitions from running to waiting/idle.
+//
+// Example config.toml:
+//
+//	[checkpoint]
+//	enabled = true
+//	mode = "ref"
+type CheckpointSettings struct {
+	// Enabled turns on auto-checkpoints for all sessions (default: false).
+	// Individual sessions can override this with `session set <id> auto-checkpoint on|off`.
+	Enabled bool `toml:"enabled"`
+
+	// Mode selects where checkpoints are stored (default: "ref"):
+	//   "ref"    - hidden ref refs/agent-deck/checkpoints/<session-id>
+	//   "branch" - branch agent-deck/checkpoint/<session-id>
+	//   "stash"  - a stash entry (tracked changes only)
+	Mode string `toml:"mode"`
+}
+
+// GetMode returns the checkpoint mode, defaulting to "ref" for empty or unknown values
+func (c CheckpointSettings) GetMode() string {
+	switch c.Mode {
+	case "branch", "stash":
+		return c.Mode
+	default:
+		return "ref"
+	}
+}
+
+// ScaffoldDef is a project layout that `agent-deck new --scaffold <name> <dir>`
+// turns into a group of predefined sessions.
+//
+// Example config.toml:
+//
+//	[scaffolds.fullstack]
+//	description = "Frontend and backend agents plus a test shell"
+//	group = "projects"
+//
+//	[[scaffolds.fullstack.sessions]]
+//	title = "frontend"
+//	path = "web"
+//	command = "claude"
+type ScaffoldDef struct {
+	// Description is shown by `agent-deck new --list`
+	Description string `toml:"description"`
+
+	// Group is the parent group for the created group, which is named after
+	// the project directory (default: root level)
+	Group string `toml:"group"`
+
+	// Sessions are created in order
+	Sessions []ScaffoldSession `toml:"sessions"`
+}
+
+// ScaffoldSession is one predefined session of a scaffold
+type ScaffoldSession struct {
+	// Title of the session (required)
+	Title string `toml:"title"`
+
+	// Path is relative to the project directory ("" = the directory itself).
+	// Missing directories are created.
+	Path string `toml:"path"`
+
+	// Command is a tool name ("claude", "codex", a [tools.*] entry) or shell
+	// command to run (default: plain shell)
+	Command string `toml:"command"`
+}
+
+// Validate checks that the scaffold has sessions with titles and paths that
+// stay inside the project directory
+func (d ScaffoldDef) Validate() error {
+	if len(d.Sessions) == 0 {
+		return fmt.Errorf("scaffold has no sessions")
+	}
+	for i, s := range d.Sessions {
+		if strings.TrimSpace(s.Title) == "" {
+			return fmt.Errorf("scaffold session %d has no title", i+1)
+		}
+		clean := filepath.Clean(s.Path)
+		if filepath.IsAbs(s.Path) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("scaffold session '%s': path must be relative to the project directory", s.Title)
+		}
+	}
+	return nil
+}
+
+// GroupPath returns the group path for a project created in dir
+func (d ScaffoldDef) GroupPath(dir string) string {
+	name := filepath.Base(filepath.Clean(dir))
+	if parent := strings.Trim(d.Group, "/"); parent != "" {
+		return parent + "/" + name
+	}
+	return name
+}
+
+// SessionTemplate is a reusable session setup for
+// `agent-deck add --template <name>` and the new-session dialog's picker.
+//
+// Example config.toml:
+//
+//	[templates.claude-review]
+//	description = "Claude reviewing the branch, with a test shell"
+//	tool = "claude"
+//	command = "claude --permission-mode plan"
+//	group = "reviews"
+//	panes = ["go test ./... -count=1"]
+//	layout = "main-vertical"
+//
+//	[templates.claude-review.env]
+//	REVIEW_BASE = "main"
+type SessionTemplate struct {
+	// Description is shown in the new-session dialog's picker
+	Description string `toml:"description"`
+
+	// Tool is the session's tool ("claude", "codex", a [tools.*] entry).
+	// Default: detected from the command.
+	Tool string `toml:"tool"`
+
+	// Command to run (default: the tool)
+	Command string `toml:"command"`
+
+	// Group is used when no group is given (before [[group_rules]])
+	Group string `toml:"group"`
+
+	// Env is exported before the command, after all other env sources
+	Env map[string]string `toml:"env"`
+
+	// Panes are commands for extra tmux panes, split off the session's pane
+	// in the project directory ("" = a shell)
+	Panes []string `toml:"panes"`
+
+	// Layout is a tmux layout for the panes: even-horizontal, even-vertical,
+	// main-horizontal, main-vertical or tiled (default: tmux's split)
+	Layout string `toml:"layout"`
+}
+
+// CommandLine returns the command the template runs: Command, or Tool
+func (t SessionTemplate) CommandLine() string {
+	if t.Command != "" {
+		return t.Command
+	}
+	return t.Tool
+}
+
+// HostDef is a remote host sessions can run on over ssh.
+//
+// Example config.toml:
+//
+//	[hosts.gpu]
+//	host = "gpu-01.internal.example.com"
+//	user = "dev"
+//	proxy_jump = "bastion"
+//	mosh = true
+type HostDef struct {
+	// Host is the hostname or ~/.ssh/config alias to connect to (default: the name)
+	Host string `toml:"host"`
+
+	// User to log in as (default: ssh's choice)
+	User string `toml:"user"`
+
+	// Port to connect to (default: ssh's choice)
+	Port int `toml:"port"`
+
+	// ProxyJump is passed to ssh -J
+	ProxyJump string `toml:"proxy_jump"`
+
+	// IdentityFile is passed to ssh -i
+	IdentityFile string `toml:"identity_file"`
+
+	// Mosh connects with mosh instead of ssh, so the session survives
+	// roaming, sleep and packet loss (mosh-server must be installed remotely)
+	Mosh bool `toml:"mosh"`
+
+	// MoshPort is the UDP port or range for mosh-server, e.g. "60001:60010"
+	// (default: mosh's choice)
+	MoshPort string `toml:"mosh_port"`
+
+	// Description is shown by `agent-deck hosts`
+	Description string `toml:"description"`
+}
+
+// HostName returns the host to connect to for the definition called name
+func (d HostDef) HostName(name string) string {
+	if d.Host != "" {
+		return d.Host
+	}
+	return name
+}
+
+type StatusSettings struct {
+	// PollIntervalMs is how often busy sessions are polled, in milliseconds
+	// Default: 2000 (minimum 500)
+	PollIntervalMs int `toml:"poll_interval_ms"`
+
+	// MaxPollIntervalMs caps the adaptive backoff: sessions that stay idle,
+	// waiting or dead are polled less and less often, up to this interval
+	// Default: 30000 (set it to poll_interval_ms to poll every session equally)
+	MaxPollIntervalMs int `toml:"max_poll_interval_ms"`
+
+	// FileActivity keeps a session running while files in its project are
+	// being written, even when its terminal is quiet (Linux, inotify;
+	// default: false)
+	FileActivity bool `toml:"file_activity"`
+}
+
+// minPollInterval keeps a misconfigured interval from pinning a CPU core
+const minPollInterval = 500 * time.Millisecond
+
+// GetPollInterval returns the polling interval for busy sessions
+func (s StatusSettings) GetPollInterval() time.Duration {
+	if s.PollIntervalMs <= 0 {
+		return 2 * time.Second
+	}
+	if d := time.Duration(s.PollIntervalMs) * time.Millisecond; d > minPollInterval {
+		return d
+	}
+	return minPollInterval
+}
+
+// GetMaxPollInterval returns the backoff cap, never below the poll interval
+func (s StatusSettings) GetMaxPollInterval() time.Duration {
+	d := 30 * time.Second
+	if s.MaxPollIntervalMs > 0 {
+		d = time.Duration(s.MaxPollIntervalMs) * time.Millisecond
+	}
+	if base := s.GetPollInterval(); d < base {
+		return base
+	}
+	return d
+}
+
+// ConfirmSettings controls which destructive actions ask for confirmation,
+// and whether deleting a session also kills its tmux session. Every
+// confirmation is on by default.
+//
+// Example config.toml:
+//
+//	[confirm]
+//	delete_session = false
+//	delete_kills_tmux = false
+type ConfirmSettings struct {
+	// DeleteSession confirms deleting a session (d in the TUI)
+	DeleteSession *bool `toml:"delete_session"`
+
+	// DeleteGroup confirms deleting a group (d on a group)
+	DeleteGroup *bool `toml:"delete_group"`
+
+	// KillTmux confirms stopping a session, which kills its tmux session
+	// but keeps it in the deck (X in the TUI)
+	KillTmux *bool `toml:"kill_tmux"`
+
+	// Bulk confirms actions on many sessions at once: merging groups in the
+	// TUI and `worktree cleanup --force`
+	Bulk *bool `toml:"bulk"`
+
+	// DeleteKillsTmux makes deleting a session kill its tmux session too
+	// (default: true). When false, delete only removes the record and the
+	// tmux session keeps running.
+	DeleteKillsTmux *bool `toml:"delete_kills_tmux"`
+}
+
+// GetDeleteSession returns whether deleting a session asks first, defaulting to true
+func (c ConfirmSettings) GetDeleteSession() bool {
+	return c.DeleteSession == nil || *c.DeleteSession
+}
+
+// GetDeleteGroup returns whether deleting a group asks first, defaulting to true
+func (c ConfirmSettings) GetDeleteGroup() bool {
+	return c.DeleteGroup == nil || *c.DeleteGroup
+}
+
+// GetKillTmux returns whether stopping a session asks first, defaulting to true
+func (c ConfirmSettings) GetKillTmux() bool {
+	return c.KillTmux == nil || *c.KillTmux
+}
+
+// GetBulk returns whether bulk actions ask first, defaulting to true
+func (c ConfirmSettings) GetBulk() bool {
+	return c.Bulk == nil || *c.Bulk
+}
+
+// GetDeleteKillsTmux returns whether deleting a session kills its tmux
+// session, defaulting to true
+func (c ConfirmSettings) GetDeleteKillsTmux() bool {
+	return c.DeleteKillsTmux == nil || *c.DeleteKillsTmux
+}
+
+// HandoffSettings configures handoff notes: a one-line "where I left off"
+// asked for when detaching, kept with the session and shown when it is next
+// selected.
+//
+// Example config.toml:
+//
+//	[handoff]
+//	prompt_on_detach = true
+//	min_attach_seconds = 60
+type HandoffSettings struct {
+	// PromptOnDetach asks for a note after detaching (default: false)
+	PromptOnDetach bool `toml:"prompt_on_detach"`
+
+	// MinAttachSeconds skips the prompt after shorter attaches, so a quick
+	// look doesn't ask (default: 30)
+	MinAttachSeconds *int `toml:"min_attach_seconds"`
+}
+
+// GetMinAttach returns how long an attach must last to prompt, defaulting to 30s
+func (s HandoffSettings) GetMinAttach() time.Duration {
+	if s.MinAttachSeconds == nil || *s.MinAttachSeconds < 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(*s.MinAttachSeconds) * time.Second
+}
+
+// ShouldPrompt reports whether detaching after an attach of the given
+// length asks for a handoff note
+func (s HandoffSettings) ShouldPrompt(attached time.Duration) bool {
+	return s.PromptOnDetach && attached >= s.GetMinAttach()
+}
+
+// BulkStartSettings limits sessions started together (agent-deck run) so a
+// large group doesn't hit the provider's API and the machine all at once
+//
+//	[bulk_start]
+//	max_concurrent = 4
+//	stagger_ms = 1000
+type BulkStartSettings struct {
+	// MaxConcurrent caps how many sessions are started and working at the
+	// same time (default: 4, 0 = no limit)
+	MaxConcurrent *int `toml:"max_concurrent"`
+
+	// StaggerMs waits this long between consecutive starts (default: 1000)
+	StaggerMs *int `toml:"stagger_ms"`
+}
+
+// GetMaxConcurrent returns the concurrency limit, 0 meaning none
+func (s BulkStartSettings) GetMaxConcurrent() int {
+	if s.MaxConcurrent == nil || *s.MaxConcurrent < 0 {
+		return 4
+	}
+	return *s.MaxConcurrent
+}
+
+// GetStagger returns the delay between consecutive starts
+func (s BulkStartSettings) GetStagger() time.Duration {
+	if s.StaggerMs == nil || *s.StaggerMs < 0 {
+		return time.Second
+	}
+	return time.Duration(*s.StaggerMs) * time.Millisecond
+}
+
+// RateLimitSettings configures the handling of provider rate limits detected
+// in a session's output (see rate_limit.go)
+//
+//	[rate_limits]
+//	pause_prompts = true
+type RateLimitSettings struct {
+	// PausePrompts holds prompts agent-deck sends for you (a queued first
+	// prompt, agent-deck run) until the limit resets, and makes
+	// `session send` refuse (default: false)
+	PausePrompts bool `toml:"pause_prompts"`
+}
+
+// AutoNameSettings renames sessions that have a generated title (the folder
+// name, "project (3)", a quick-create name) after their task (see
+// auto_name.go). A session renamed by hand keeps its title.
+//
+//	[auto_name]
+//	enabled = true
+//	from_summary = true
+//	max_length = 40
+type AutoNameSettings struct {
+	// Enabled names sessions from the first prompt they're given (default: false)
+	Enabled bool `toml:"enabled"`
 
-	// Args are command-line arguments for the server
-	Args []string `toml:"args"`
+	// FromSummary renames them again with the agent's own summary of the
+	// task, for tools that record one (Claude) (default: true)
+	FromSummary *bool `toml:"from_summary"`
 
-	// Env is environment variables for the server process
-	Env map[string]string `toml:"env"`
+	// MaxLength caps the title length in characters (default: 40)
+	MaxLength int `toml:"max_length"`
+}
 
-	// StartupTimeout is milliseconds to wait for server to become ready (default: 5000)
-	StartupTimeout int `toml:"startup_timeout"`
+// GetFromSummary returns whether the agent's summary replaces the title
+func (s AutoNameSettings) GetFromSummary() bool {
+	return s.FromSummary == nil || *s.FromSummary
+}
 
-	// HealthCheck is an optional health endpoint URL to poll (e.g., "http://localhost:30000/health")
-	// If not set, the main URL is used for health checking
-	HealthCheck string `toml:"health_check"`
+// GetMaxLength returns the title length cap in characters
+func (s AutoNameSettings) GetMaxLength() int {
+	if s.MaxLength <= 0 {
+		return 40
+	}
+	return s.MaxLength
 }
 
-// MCPDef defines an MCP server configuration for the MCP Manager
-type MCPDef struct {
-	// Command is the executable to run (e.g., "npx", "docker", "node")
-	// Required for stdio MCPs, optional for HTTP/SSE MCPs
-	Command string `toml:"command"`
+// ToolVersionSettings configures the version checks of installed tools (see
+// tool_version.go): the TUI checks at startup, doctor on every run.
+//
+//	[tool_versions]
+//	check = true
+//
+//	[[tool_versions.broken]]
+//	tool = "claude"
+//	versions = "2.0.3"
+//	reason = "prompt not detected, sessions stay running"
+type ToolVersionSettings struct {
+	// Check detects tool versions when the TUI starts and warns about
+	// broken ones (default: true)
+	Check *bool `toml:"check"`
 
-	// Args are command-line arguments
-	Args []string `toml:"args"`
+	// Broken lists versions known not to work with agent-deck
+	Broken []BrokenToolVersion `toml:"broken"`
+}
 
-	// Env is optional environment variables
-	Env map[string]string `toml:"env"`
+// BrokenToolVersion is a range of a tool's versions to warn about
+type BrokenToolVersion struct {
+	// Tool is the tool name (claude, codex, a custom tool)
+	Tool string `toml:"tool"`
 
-	// Description is optional help text shown in the MCP Manager
-	Description string `toml:"description"`
+	// Versions is an exact version ("2.0.3"), a release line ("2.0" for
+	// any 2.0.x) or a bound ("<1.0.24", ">=3", "<=2.1.4", ">2")
+	Versions string `toml:"versions"`
 
-	// URL is the endpoint for HTTP/SSE MCPs (e.g., "http://localhost:8000/mcp")
-	// If set, this MCP uses HTTP or SSE transport instead of stdio
-	URL string `toml:"url"`
+	// Reason is shown with the warning
+	Reason string `toml:"reason"`
+}
 
-	// Transport specifies the MCP transport type: "stdio" (default), "http", or "sse"
-	// Only needed when URL is set; defaults to "http" if URL is present
-	Transport string `toml:"transport"`
+// GetCheck returns whether the TUI checks tool versions at startup
+func (s ToolVersionSettings) GetCheck() bool {
+	return s.Check == nil || *s.Check
+}
 
-	// Headers is optional HTTP headers for HTTP/SSE MCPs (e.g., for authentication)
-	// Example: { Authorization = "Bearer token123" }
-	Headers map[string]string `toml:"headers"`
+// YoloSandboxSettings sandboxes sessions that launch in YOLO mode (see
+// launch_mode.go): a new one is wrapped in `--container image:<image>`, a
+// throwaway container with only the project bind-mounted, so an agent that
+// skips approvals can't touch the rest of the machine.
+//
+//	[yolo_sandbox]
+//	image = "ghcr.io/me/agents:latest"  # with the agent CLIs installed
+//	mode = "ask"                        # ask, always or off
+type YoloSandboxSettings struct {
+	// Image is the image to run sandboxed sessions in; without one nothing
+	// is sandboxed
+	Image string `toml:"image"`
 
-	// Server defines how to auto-start an HTTP MCP server process
-	// When set, agent-deck will start the server before connecting via HTTP
-	// This is optional - you can also connect to externally managed servers
-	Server *HTTPServerConfig `toml:"server"`
+	// Mode is "ask" to offer the sandbox when a YOLO session is created
+	// (default), "always" to use it without asking, "off" to never offer it
+	Mode string `toml:"mode"`
 }
 
-// GetStartupTimeout returns the startup timeout in milliseconds, defaulting to 5000ms
-func (c *HTTPServerConfig) GetStartupTimeout() int {
-	if c.StartupTimeout <= 0 {
-		return 5000 // Default: 5 seconds
+// GetMode returns ask, always or off; off without an image
+func (s YoloSandboxSettings) GetMode() string {
+	if s.Image == "" {
+		return "off"
 	}
-	return c.StartupTimeout
-}
-
-// IsHTTP returns true if this MCP uses HTTP or SSE transport
-func (m *MCPDef) IsHTTP() bool {
-	return m.URL != ""
+	switch s.Mode {
+	case "always", "off":
+		return s.Mode
+	}
+	return "ask"
 }
 
-// GetTransport returns the transport type, defaulting to "http" if URL is set
-func (m *MCPDef) GetTransport() string {
-	if m.URL == "" {
-		return "stdio"
-	}
-	if m.Transport == "" {
-		return "http"
+// SandboxFor returns the container to sandbox inst in: set for a YOLO
+// session on the host when an image is configured, whatever the mode
+func (s YoloSandboxSettings) SandboxFor(inst *Instance) *ContainerSpec {
+	if s.Image == "" || inst.Container != nil || inst.LaunchMode() != LaunchModeYolo {
+		return nil
 	}
-	return m.Transport
+	return &ContainerSpec{Kind: ContainerImage, Target: s.Image}
 }
 
-// HasAutoStartServer returns true if this HTTP MCP has server auto-start configured
-func (m *MCPDef) HasAutoStartServer() bool {
-	return m.IsHTTP() && m.Server != nil && m.Server.Command != ""
+// IsSandbox reports whether spec is the sandbox container SandboxFor gives
+func (s YoloSandboxSettings) IsSandbox(spec *ContainerSpec) bool {
+	return spec != nil && s.Image != "" && spec.Kind == ContainerImage && spec.Target == s.Image
 }
 
-// TmuxSettings allows users to override tmux options applied to every session.
-// Options are applied AFTER agent-deck's defaults, so they take precedence.
+// GroupRule puts new sessions under a path glob into a group. Rules are
+// tried in order and the first match wins; without a match the group is the
+// project's parent folder. An explicit group (add -g, a group chosen in the
+// TUI) always takes precedence.
 //
 // Example config.toml:
 //
-//	[tmux]
-//	options = { "allow-passthrough" = "all", "history-limit" = "50000" }
-type TmuxSettings struct {
-	// Options is a map of tmux option names to values.
-	// These are passed to `tmux set-option -t <session>` after defaults.
-	Options map[string]string `toml:"options"`
-}
+//	[[group_rules]]
+//	path = "~/work/**"
+//	group = "work"
+//
+//	[[group_rules]]
+//	path = "~/oss/*/**"
+//	group = "oss/{owner}"
+type GroupRule struct {
+	// Path is a glob; "**" matches any number of directories and "~" is
+	// the home directory
+	Path string `toml:"path"`
+
+	// Group is the group path, which may use the [repo_labels] placeholders
+	Group string `toml:"group"`
+}
+
+// RepoLabelSettings names new sessions after their repo. Templates take
+// {repo} (owner/name from the origin remote), {owner}, {repo-name} and {dir};
+// the same placeholders work in a title or group typed when creating a
+// session.
+//
+// Example config.toml:
+//
+//	[repo_labels]
+//	title_template = "{repo-name}"
+//	group_template = "{owner}"
+type RepoLabelSettings struct {
+	// TitleTemplate is the title of a new session when none is given
+	// (default: the folder name)
+	TitleTemplate string `toml:"title_template"`
 
-type StatusSettings struct {
-	// Reserved for future status detection settings.
-	// Control mode pipes are always enabled (no longer configurable).
+	// GroupTemplate is the group of a new session when none is chosen, e.g.
+	// "{owner}" to group repos by org (default: unset)
+	GroupTemplate string `toml:"group_template"`
 }
 
 // MaintenanceSettings controls the automatic maintenance worker
@@ -856,14 +2020,9 @@ func GetCustomToolNames() []string {
 		return nil
 	}
 
-	builtins := map[string]bool{
-		"claude": true, "gemini": true, "opencode": true,
-		"codex": true, "shell": true, "cursor": true, "aider": true,
-	}
-
 	var names []string
 	for name := range config.Tools {
-		if !builtins[name] {
+		if !builtinTools[name] {
 			names = append(names, name)
 		}
 	}
@@ -1132,6 +2291,51 @@ func GetNotificationsSettings() NotificationsConfig {
 	return settings
 }
 
+// GetWebhooks returns the configured status webhooks
+func GetWebhooks() []WebhookDef {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	return config.Webhooks
+}
+
+// GetUnpushedSettings returns the unpushed work badge settings from config
+func GetUnpushedSettings() UnpushedSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return UnpushedSettings{}
+	}
+	return config.Unpushed
+}
+
+// GetBackupSettings returns the daily backup settings from config
+func GetBackupSettings() BackupSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return BackupSettings{}
+	}
+	return config.Backup
+}
+
+// GetHookSettings returns the deck-wide hooks from config
+func GetHookSettings() HookSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return HookSettings{}
+	}
+	return config.Hooks
+}
+
+// GetTeamDecks returns the configured team decks
+func GetTeamDecks() []TeamDeckDef {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	return config.TeamDecks
+}
+
 // GetMaintenanceSettings returns maintenance settings from config
 func GetMaintenanceSettings() MaintenanceSettings {
 	config, err := LoadUserConfig()
@@ -1150,6 +2354,78 @@ func GetStatusSettings() StatusSettings {
 	return config.Status
 }
 
+// GetConfirmSettings returns confirmation settings from config
+func GetConfirmSettings() ConfirmSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return ConfirmSettings{}
+	}
+	return config.Confirm
+}
+
+// GetRepoLabelSettings returns repo label settings from config
+func GetRepoLabelSettings() RepoLabelSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return RepoLabelSettings{}
+	}
+	return config.RepoLabels
+}
+
+// GetHandoffSettings returns handoff note settings from config
+func GetHandoffSettings() HandoffSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return HandoffSettings{}
+	}
+	return config.Handoff
+}
+
+// GetBulkStartSettings returns the limits for sessions started together
+func GetBulkStartSettings() BulkStartSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return BulkStartSettings{}
+	}
+	return config.BulkStart
+}
+
+// GetRateLimitSettings returns the rate limit handling settings
+func GetRateLimitSettings() RateLimitSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return RateLimitSettings{}
+	}
+	return config.RateLimits
+}
+
+// GetAutoNameSettings returns the automatic session naming settings
+func GetAutoNameSettings() AutoNameSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return AutoNameSettings{}
+	}
+	return config.AutoName
+}
+
+// GetToolVersionSettings returns the tool version check settings
+func GetToolVersionSettings() ToolVersionSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return ToolVersionSettings{}
+	}
+	return config.ToolVersions
+}
+
+// GetYoloSandboxSettings returns the YOLO sandbox settings
+func GetYoloSandboxSettings() YoloSandboxSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return YoloSandboxSettings{}
+	}
+	return config.YoloSandbox
+}
+
 // GetTmuxSettings returns tmux option overrides from config
 func GetTmuxSettings() TmuxSettings {
 	config, err := LoadUserConfig()
@@ -1159,6 +2435,159 @@ func GetTmuxSettings() TmuxSettings {
 	return config.Tmux
 }
 
+// GetCheckpointSettings returns auto-checkpoint settings from config
+func GetCheckpointSettings() CheckpointSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return CheckpointSettings{}
+	}
+	return config.Checkpoint
+}
+
+// GetTerminalSettings returns external terminal window settings from config
+func GetTerminalSettings() TerminalSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return TerminalSettings{}
+	}
+	return config.Terminal
+}
+
+// GetSessionTemplate returns a session template from config.
+// Returns nil if the template is not defined.
+func GetSessionTemplate(name string) *SessionTemplate {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	if tpl, ok := config.Templates[name]; ok {
+		return &tpl
+	}
+	return nil
+}
+
+// GetSessionTemplateNames returns sorted template names from config.toml
+func GetSessionTemplateNames() []string {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	names := make([]string, 0, len(config.Templates))
+	for name := range config.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetScaffold returns a scaffold definition from config.
+// Returns nil if the scaffold is not defined.
+func GetScaffold(name string) *ScaffoldDef {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	if def, ok := config.Scaffolds[name]; ok {
+		return &def
+	}
+	return nil
+}
+
+// GetScaffoldNames returns sorted scaffold names from config.toml
+func GetScaffoldNames() []string {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	names := make([]string, 0, len(config.Scaffolds))
+	for name := range config.Scaffolds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetHost returns a remote host definition from config.
+// Returns nil if not declared.
+func GetHost(name string) *HostDef {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	if def, ok := config.Hosts[name]; ok {
+		return &def
+	}
+	return nil
+}
+
+// GetHostNames returns sorted remote host names from config.toml
+func GetHostNames() []string {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	names := make([]string, 0, len(config.Hosts))
+	for name := range config.Hosts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetSyncSettings returns `agent-deck sync` settings
+func GetSyncSettings() SyncSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return SyncSettings{}
+	}
+	return config.Sync
+}
+
+// GetAccessibilitySettings returns accessibility settings
+func GetAccessibilitySettings() AccessibilitySettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return AccessibilitySettings{}
+	}
+	return config.Accessibility
+}
+
+// GetSuggestionSettings returns path suggestion settings
+func GetSuggestionSettings() SuggestionSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return SuggestionSettings{}
+	}
+	return config.Suggestions
+}
+
+// GetTicketSettings returns ticket link settings
+func GetTicketSettings() TicketSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return TicketSettings{}
+	}
+	return config.Tickets
+}
+
+// GetPricingOverrides returns the configured per-model prices
+func GetPricingOverrides() map[string]ModelPricing {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	return config.Pricing
+}
+
+// GetDaemonSettings returns daemon settings
+func GetDaemonSettings() DaemonSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return DaemonSettings{}
+	}
+	return config.Daemon
+}
+
 // GetInstanceSettings returns instance behavior settings
 func GetInstanceSettings() InstanceSettings {
 	config, err := LoadUserConfig()
@@ -1409,6 +2838,10 @@ auto_cleanup = true
 #   command      - The shell command to run
 #   icon         - Emoji/symbol shown in the UI
 #   busy_patterns - Strings that indicate the tool is processing
+#   pre_attach   - Shell command run in the project dir before attaching
+#   post_detach  - Shell command run in the project dir after detaching
+#   pre_start    - Shell command run in the project dir before the session starts
+#   post_exit    - Shell command run in the project dir after the session exits
 
 # Example: Add a custom AI tool
 # [tools.my-ai]
@@ -1416,6 +2849,10 @@ auto_cleanup = true
 # icon = "🧠"
 # busy_patterns = ["thinking...", "processing..."]
 
+# Example: Fetch before attaching to Claude sessions
+# [tools.claude]
+# pre_attach = "git fetch --quiet"
+
 # Example: Add GitHub Copilot CLI
 # [tools.copilot]
 # command = "gh copilot"
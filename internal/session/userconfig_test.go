@@ -374,6 +374,40 @@ show_analytics = false
 	}
 }
 
+func TestPreviewHighlightRules(t *testing.T) {
+	content := `
+[preview]
+[[preview.highlight_rules]]
+pattern = '(?i)\bdeprecated\b'
+style = "yellow,italic"
+`
+	var config UserConfig
+	if _, err := toml.Decode(content, &config); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+
+	rules := config.Preview.GetHighlightRules()
+	if len(rules) != 1 {
+		t.Fatalf("Expected 1 configured rule, got %d", len(rules))
+	}
+	if rules[0].Pattern != `(?i)\bdeprecated\b` || rules[0].Style != "yellow,italic" {
+		t.Errorf("Unexpected rule: %+v", rules[0])
+	}
+
+	// No rules configured: built-in defaults
+	empty := PreviewSettings{}
+	if got := empty.GetHighlightRules(); len(got) != len(DefaultHighlightRules) {
+		t.Errorf("Expected %d default rules, got %d", len(DefaultHighlightRules), len(got))
+	}
+
+	// Disabled: no rules at all
+	disabled := false
+	off := PreviewSettings{Highlight: &disabled, HighlightRules: rules}
+	if got := off.GetHighlightRules(); got != nil {
+		t.Errorf("Expected no rules when highlight = false, got %v", got)
+	}
+}
+
 func TestPreviewSettingsDefaults(t *testing.T) {
 	cfg := &UserConfig{}
 
@@ -635,3 +669,109 @@ enabled = true
 		t.Errorf("GetNotificationsSettings MaxShown: should default to 6, got %d", settings.MaxShown)
 	}
 }
+
+func TestScaffoldConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+	content := `
+[scaffolds.fullstack]
+description = "Web and API agents"
+group = "projects/"
+
+[[scaffolds.fullstack.sessions]]
+title = "frontend"
+path = "web"
+command = "claude"
+
+[[scaffolds.fullstack.sessions]]
+title = "tests"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	var config UserConfig
+	if _, err := toml.DecodeFile(configPath, &config); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+
+	def, ok := config.Scaffolds["fullstack"]
+	if !ok {
+		t.Fatal("Expected fullstack scaffold")
+	}
+	if len(def.Sessions) != 2 || def.Sessions[0].Path != "web" || def.Sessions[0].Command != "claude" {
+		t.Errorf("Sessions = %+v", def.Sessions)
+	}
+	if err := def.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	if got := def.GroupPath("/home/me/src/shop/"); got != "projects/shop" {
+		t.Errorf("GroupPath = %q, want projects/shop", got)
+	}
+}
+
+func TestScaffoldValidate(t *testing.T) {
+	tests := map[string]ScaffoldDef{
+		"no sessions": {},
+		"no title":    {Sessions: []ScaffoldSession{{Path: "web"}}},
+		"absolute":    {Sessions: []ScaffoldSession{{Title: "x", Path: "/etc"}}},
+		"escapes":     {Sessions: []ScaffoldSession{{Title: "x", Path: "../other"}}},
+	}
+	for name, def := range tests {
+		if err := def.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestHostConfig(t *testing.T) {
+	content := `
+[hosts.gpu]
+host = "gpu-01.example.com"
+user = "dev"
+port = 2222
+proxy_jump = "bastion"
+
+[hosts.bastion]
+`
+	var config UserConfig
+	if _, err := toml.Decode(content, &config); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	gpu := config.Hosts["gpu"]
+	if gpu.User != "dev" || gpu.Port != 2222 || gpu.ProxyJump != "bastion" {
+		t.Errorf("gpu = %+v", gpu)
+	}
+	if got := gpu.HostName("gpu"); got != "gpu-01.example.com" {
+		t.Errorf("HostName = %q", got)
+	}
+	if got := config.Hosts["bastion"].HostName("bastion"); got != "bastion" {
+		t.Errorf("HostName without host = %q, want the name", got)
+	}
+}
+
+func TestConfirmSettings(t *testing.T) {
+	var defaults ConfirmSettings
+	if !defaults.GetDeleteSession() || !defaults.GetDeleteGroup() || !defaults.GetKillTmux() ||
+		!defaults.GetBulk() || !defaults.GetDeleteKillsTmux() {
+		t.Errorf("every confirmation should default to on: %+v", defaults)
+	}
+
+	content := `
+[confirm]
+delete_session = false
+bulk = false
+delete_kills_tmux = false
+`
+	var config UserConfig
+	if _, err := toml.Decode(content, &config); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	c := config.Confirm
+	if c.GetDeleteSession() || c.GetBulk() || c.GetDeleteKillsTmux() {
+		t.Errorf("explicit false should be honoured: %+v", c)
+	}
+	if !c.GetDeleteGroup() || !c.GetKillTmux() {
+		t.Error("unset keys should keep their defaults")
+	}
+}
@@ -0,0 +1,222 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// webhookTimeout bounds one status webhook request
+const webhookTimeout = 10 * time.Second
+
+// StatusEvent is one session status change, the body of a status webhook
+// (or the data its template is given)
+type StatusEvent struct {
+	SessionID string    `json:"id"`
+	Title     string    `json:"title"`
+	Group     string    `json:"group"`
+	Tool      string    `json:"tool"`
+	Path      string    `json:"path"`
+	Status    Status    `json:"status"`
+	Previous  Status    `json:"previous"`
+	Time      time.Time `json:"time"`
+
+	// Text is a one-line description for chat webhooks that show it as is
+	Text string `json:"text"`
+}
+
+// statusWebhook is a configured webhook with its parsed template
+type statusWebhook struct {
+	def  WebhookDef
+	tmpl *template.Template // nil sends the event as JSON
+}
+
+// matches reports whether the webhook fires for the session's change
+func (w statusWebhook) matches(groupPath string, status Status) bool {
+	if g := strings.Trim(w.def.Group, "/"); g != "" && groupPath != g && !strings.HasPrefix(groupPath, g+"/") {
+		return false
+	}
+	if len(w.def.Events) == 0 {
+		return true
+	}
+	for _, e := range w.def.Events {
+		if strings.EqualFold(e, string(status)) {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one rendered request to a status webhook
+type WebhookDelivery struct {
+	URL         string
+	ContentType string
+	Headers     map[string]string
+	Body        []byte
+	Event       StatusEvent
+}
+
+// StatusWebhooks posts session status changes to the [[webhooks]] in
+// config.toml. Like the Alerter, the first check only records statuses, so
+// starting the TUI doesn't send a change for every session.
+type StatusWebhooks struct {
+	mu     sync.Mutex
+	hooks  []statusWebhook
+	last   map[string]Status
+	primed bool
+}
+
+// NewStatusWebhooks creates a dispatcher for defs. Webhooks without a URL or
+// with an invalid template are logged and skipped.
+func NewStatusWebhooks(defs []WebhookDef) *StatusWebhooks {
+	w := &StatusWebhooks{last: make(map[string]Status)}
+	w.SetWebhooks(defs)
+	return w
+}
+
+// SetWebhooks replaces the configured webhooks, keeping the statuses seen
+func (w *StatusWebhooks) SetWebhooks(defs []WebhookDef) {
+	hooks := make([]statusWebhook, 0, len(defs))
+	for _, def := range defs {
+		hook, err := parseWebhook(def)
+		if err != nil {
+			alertLog.Warn("webhook_ignored", slog.String("url", def.URL), slog.String("error", err.Error()))
+			continue
+		}
+		hooks = append(hooks, hook)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.hooks = hooks
+}
+
+// parseWebhook checks def and parses its template
+func parseWebhook(def WebhookDef) (statusWebhook, error) {
+	if def.URL == "" {
+		return statusWebhook{}, fmt.Errorf("no url")
+	}
+	hook := statusWebhook{def: def}
+	if def.Template == "" {
+		return hook, nil
+	}
+	tmpl, err := template.New("webhook").Funcs(template.FuncMap{"json": jsonValue}).Parse(def.Template)
+	if err != nil {
+		return statusWebhook{}, fmt.Errorf("template: %w", err)
+	}
+	hook.tmpl = tmpl
+	return hook, nil
+}
+
+// jsonValue encodes v for a JSON template, quoting strings
+func jsonValue(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// Check records the current statuses and returns a delivery for each
+// webhook matching a change
+func (w *StatusWebhooks) Check(instances []*Instance, now time.Time) []WebhookDelivery {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var deliveries []WebhookDelivery
+	seen := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		seen[inst.ID] = true
+		status := inst.GetStatusThreadSafe()
+		prev, known := w.last[inst.ID]
+		w.last[inst.ID] = status
+		if !w.primed || !known || prev == status {
+			continue
+		}
+		ev := StatusEvent{
+			SessionID: inst.ID,
+			Title:     inst.Title,
+			Group:     inst.GroupPath,
+			Tool:      inst.GetToolThreadSafe(),
+			Path:      inst.ProjectPath,
+			Status:    status,
+			Previous:  prev,
+			Time:      now,
+			Text:      fmt.Sprintf("%s is %s (was %s)", inst.Title, status, prev),
+		}
+		for _, hook := range w.hooks {
+			if !hook.matches(inst.GroupPath, status) {
+				continue
+			}
+			d, err := hook.render(ev)
+			if err != nil {
+				alertLog.Warn("webhook_template_failed", slog.String("url", hook.def.URL), slog.String("error", err.Error()))
+				continue
+			}
+			deliveries = append(deliveries, d)
+		}
+	}
+	for id := range w.last {
+		if !seen[id] {
+			delete(w.last, id)
+		}
+	}
+	w.primed = true
+	return deliveries
+}
+
+// render builds the webhook's request for ev
+func (w statusWebhook) render(ev StatusEvent) (WebhookDelivery, error) {
+	d := WebhookDelivery{URL: w.def.URL, ContentType: w.def.ContentType, Headers: w.def.Headers, Event: ev}
+	if d.ContentType == "" {
+		d.ContentType = "application/json"
+	}
+	if w.tmpl == nil {
+		body, err := json.Marshal(ev)
+		d.Body = body
+		return d, err
+	}
+	var buf bytes.Buffer
+	if err := w.tmpl.Execute(&buf, ev); err != nil {
+		return d, err
+	}
+	d.Body = buf.Bytes()
+	return d, nil
+}
+
+// Send POSTs the delivery, expanding $VAR in header values
+func (d WebhookDelivery) Send() error {
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewReader(d.Body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", d.ContentType)
+	for k, v := range d.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+	client := &http.Client{Timeout: webhookTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SendWebhooks delivers in the background, logging failures
+func SendWebhooks(deliveries []WebhookDelivery) {
+	for _, d := range deliveries {
+		d := d
+		go func() {
+			if err := d.Send(); err != nil {
+				alertLog.Warn("webhook_failed", slog.String("url", d.URL), slog.String("title", d.Event.Title), slog.String("status", string(d.Event.Status)), slog.String("error", err.Error()))
+			}
+		}()
+	}
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestStatusWebhooksCheck(t *testing.T) {
+	var config UserConfig
+	_, err := toml.Decode(`
+[[webhooks]]
+url = "http://all.example"
+
+[[webhooks]]
+url = "http://work-waiting.example"
+events = ["waiting"]
+group = "work"
+template = '{"msg": {{json .Title}}, "from": "{{.Previous}}"}'
+
+[[webhooks]]
+url = "http://broken.example"
+template = "{{.Nope"
+`, &config)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	w := NewStatusWebhooks(config.Webhooks)
+	if len(w.hooks) != 2 {
+		t.Fatalf("hooks = %d, want 2 (the broken template skipped)", len(w.hooks))
+	}
+
+	api := NewInstance(`api "v2"`, "/tmp/api")
+	api.GroupPath = "work/backend"
+	web := NewInstance("web", "/tmp/web")
+	instances := []*Instance{api, web}
+	now := time.Now()
+
+	api.SetStatusThreadSafe(StatusRunning)
+	web.SetStatusThreadSafe(StatusRunning)
+	if d := w.Check(instances, now); len(d) != 0 {
+		t.Fatalf("first check should only record statuses, got %+v", d)
+	}
+	if d := w.Check(instances, now); len(d) != 0 {
+		t.Errorf("no change should send nothing, got %+v", d)
+	}
+
+	api.SetStatusThreadSafe(StatusWaiting)
+	web.SetStatusThreadSafe(StatusError)
+	d := w.Check(instances, now)
+	if len(d) != 3 {
+		t.Fatalf("deliveries = %+v, want api to both webhooks and web to the first", d)
+	}
+	byURL := map[string]WebhookDelivery{}
+	for _, del := range d {
+		byURL[del.URL+" "+del.Event.Title] = del
+	}
+	var ev StatusEvent
+	if err := json.Unmarshal(byURL["http://all.example web"].Body, &ev); err != nil || ev.Status != StatusError || ev.Previous != StatusRunning {
+		t.Errorf("default body = %s (%v)", byURL["http://all.example web"].Body, err)
+	}
+	templated := byURL[`http://work-waiting.example api "v2"`]
+	if string(templated.Body) != `{"msg": "api \"v2\"", "from": "running"}` || templated.ContentType != "application/json" {
+		t.Errorf("templated body = %s (%s)", templated.Body, templated.ContentType)
+	}
+}
+
+func TestWebhookDeliverySend(t *testing.T) {
+	var body, auth, contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body, auth, contentType = string(data), r.Header.Get("Authorization"), r.Header.Get("Content-Type")
+	}))
+	defer srv.Close()
+
+	t.Setenv("HOOK_TOKEN", "s3cret")
+	d := WebhookDelivery{URL: srv.URL, ContentType: "text/plain", Headers: map[string]string{"Authorization": "Bearer $HOOK_TOKEN"}, Body: []byte("api is waiting")}
+	if err := d.Send(); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if body != "api is waiting" || auth != "Bearer s3cret" || contentType != "text/plain" {
+		t.Errorf("request = %q %q %q", body, auth, contentType)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if err := (WebhookDelivery{URL: failing.URL}).Send(); err == nil {
+		t.Error("a 500 response should fail")
+	}
+}
@@ -0,0 +1,43 @@
+package statedb
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+// writeLockTimeout matches the busy timeout: how long LockWrites waits for
+// another process to finish saving
+const writeLockTimeout = 5 * time.Second
+
+// LockWrites takes an advisory lock (flock) on <state.db>.lock that one
+// process holds at a time, and returns its release. Read-modify-write
+// sequences such as MergeInstances run under it, so no other process saves
+// between reading the current rows and writing the new ones.
+func (s *StateDB) LockWrites() (func(), error) {
+	if s.path == "" {
+		return func() {}, nil
+	}
+	f, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("statedb: open lock: %w", err)
+	}
+	deadline := time.Now().Add(writeLockTimeout)
+	for {
+		err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) || time.Now().After(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("statedb: lock %s: %w", s.path, err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
@@ -64,19 +64,42 @@ type jsonGroupData struct {
 
 // toolDataBlob is the JSON structure stored in the tool_data column.
 type toolDataBlob struct {
-	ClaudeSessionID    string          `json:"claude_session_id,omitempty"`
-	ClaudeDetectedAt   int64           `json:"claude_detected_at,omitempty"`
-	GeminiSessionID    string          `json:"gemini_session_id,omitempty"`
-	GeminiDetectedAt   int64           `json:"gemini_detected_at,omitempty"`
-	GeminiYoloMode     *bool           `json:"gemini_yolo_mode,omitempty"`
-	GeminiModel        string          `json:"gemini_model,omitempty"`
-	OpenCodeSessionID  string          `json:"opencode_session_id,omitempty"`
-	OpenCodeDetectedAt int64           `json:"opencode_detected_at,omitempty"`
-	CodexSessionID     string          `json:"codex_session_id,omitempty"`
-	CodexDetectedAt    int64           `json:"codex_detected_at,omitempty"`
-	LatestPrompt       string          `json:"latest_prompt,omitempty"`
-	LoadedMCPNames     []string        `json:"loaded_mcp_names,omitempty"`
-	ToolOptions        json.RawMessage `json:"tool_options,omitempty"`
+	ClaudeSessionID    string            `json:"claude_session_id,omitempty"`
+	ClaudeDetectedAt   int64             `json:"claude_detected_at,omitempty"`
+	GeminiSessionID    string            `json:"gemini_session_id,omitempty"`
+	GeminiDetectedAt   int64             `json:"gemini_detected_at,omitempty"`
+	GeminiYoloMode     *bool             `json:"gemini_yolo_mode,omitempty"`
+	GeminiModel        string            `json:"gemini_model,omitempty"`
+	OpenCodeSessionID  string            `json:"opencode_session_id,omitempty"`
+	OpenCodeDetectedAt int64             `json:"opencode_detected_at,omitempty"`
+	CodexSessionID     string            `json:"codex_session_id,omitempty"`
+	CodexDetectedAt    int64             `json:"codex_detected_at,omitempty"`
+	LatestPrompt       string            `json:"latest_prompt,omitempty"`
+	LoadedMCPNames     []string          `json:"loaded_mcp_names,omitempty"`
+	ToolOptions        json.RawMessage   `json:"tool_options,omitempty"`
+	AutoCheckpoint     *bool             `json:"auto_checkpoint,omitempty"`
+	StatusText         string            `json:"status_text,omitempty"`
+	StatusTextFromHook bool              `json:"status_text_hook,omitempty"`
+	Container          json.RawMessage   `json:"container,omitempty"`
+	Notes              string            `json:"notes,omitempty"`
+	PendingPrompt      string            `json:"pending_prompt,omitempty"`
+	AutoAttach         string            `json:"auto_attach,omitempty"`
+	Ticket             json.RawMessage   `json:"ticket,omitempty"`
+	TmuxSocket         string            `json:"tmux_socket,omitempty"`
+	GitRemote          string            `json:"git_remote,omitempty"`
+	PreAttachHook      string            `json:"pre_attach_hook,omitempty"`
+	PostDetachHook     string            `json:"post_detach_hook,omitempty"`
+	PreStartHook       string            `json:"pre_start_hook,omitempty"`
+	PostExitHook       string            `json:"post_exit_hook,omitempty"`
+	HandoffNote        string            `json:"handoff_note,omitempty"`
+	HandoffAt          int64             `json:"handoff_at,omitempty"`
+	ContextFiles       []string          `json:"context_files,omitempty"`
+	AutoTitle          string            `json:"auto_title,omitempty"`
+	AutoTitleFrom      string            `json:"auto_title_from,omitempty"`
+	YoloMode           *bool             `json:"yolo_mode,omitempty"`
+	Watch              json.RawMessage   `json:"watch,omitempty"`
+	Template           string            `json:"template,omitempty"`
+	Env                map[string]string `json:"env,omitempty"`
 }
 
 // MigrateFromJSON reads a sessions.json file and inserts all data into the StateDB.
@@ -170,82 +193,158 @@ func MigrateFromJSON(jsonPath string, db *StateDB) (int, int, error) {
 	return len(rows), len(groupRows), nil
 }
 
-// MarshalToolData creates a tool_data JSON blob from individual fields.
-// This is the forward path: Instance fields -> JSON blob for SQLite storage.
-func MarshalToolData(
-	claudeSessionID string, claudeDetectedAt time.Time,
-	geminiSessionID string, geminiDetectedAt time.Time,
-	geminiYoloMode *bool, geminiModel string,
-	openCodeSessionID string, openCodeDetectedAt time.Time,
-	codexSessionID string, codexDetectedAt time.Time,
-	latestPrompt string, loadedMCPNames []string,
-	toolOptionsJSON json.RawMessage,
-) json.RawMessage {
-	td := toolDataBlob{
-		ClaudeSessionID:   claudeSessionID,
-		GeminiSessionID:   geminiSessionID,
-		GeminiYoloMode:    geminiYoloMode,
-		GeminiModel:       geminiModel,
-		OpenCodeSessionID: openCodeSessionID,
-		CodexSessionID:    codexSessionID,
-		LatestPrompt:      latestPrompt,
-		LoadedMCPNames:    loadedMCPNames,
-		ToolOptions:       toolOptionsJSON,
-	}
-	if !claudeDetectedAt.IsZero() {
-		td.ClaudeDetectedAt = claudeDetectedAt.Unix()
+// ToolData holds the per-session fields persisted in the tool_data JSON blob.
+// Timestamps are stored as Unix seconds on disk (see toolDataBlob).
+type ToolData struct {
+	ClaudeSessionID    string
+	ClaudeDetectedAt   time.Time
+	GeminiSessionID    string
+	GeminiDetectedAt   time.Time
+	GeminiYoloMode     *bool
+	GeminiModel        string
+	OpenCodeSessionID  string
+	OpenCodeDetectedAt time.Time
+	CodexSessionID     string
+	CodexDetectedAt    time.Time
+	LatestPrompt       string
+	LoadedMCPNames     []string
+	ToolOptions        json.RawMessage
+	AutoCheckpoint     *bool // Per-session override (nil = use global config)
+	StatusText         string
+	StatusTextFromHook bool
+	Container          json.RawMessage
+	Notes              string
+	PendingPrompt      string
+	AutoAttach         string
+	Ticket             json.RawMessage
+	TmuxSocket         string
+	GitRemote          string
+	PreAttachHook      string
+	PostDetachHook     string
+	PreStartHook       string
+	PostExitHook       string
+	HandoffNote        string
+	HandoffAt          time.Time
+	ContextFiles       []string
+	AutoTitle          string
+	AutoTitleFrom      string
+	YoloMode           *bool
+	Watch              json.RawMessage
+	Template           string
+	Env                map[string]string
+}
+
+// unixOrZero converts a time to Unix seconds, keeping zero times as 0
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
 	}
-	if !geminiDetectedAt.IsZero() {
-		td.GeminiDetectedAt = geminiDetectedAt.Unix()
+	return t.Unix()
+}
+
+// timeOrZero converts Unix seconds back to a time, keeping 0 as the zero time
+func timeOrZero(sec int64) time.Time {
+	if sec <= 0 {
+		return time.Time{}
 	}
-	if !openCodeDetectedAt.IsZero() {
-		td.OpenCodeDetectedAt = openCodeDetectedAt.Unix()
+	return time.Unix(sec, 0)
+}
+
+// MarshalToolData creates a tool_data JSON blob from per-session fields.
+// This is the forward path: Instance fields -> JSON blob for SQLite storage.
+func MarshalToolData(td *ToolData) json.RawMessage {
+	if td == nil {
+		td = &ToolData{}
 	}
-	if !codexDetectedAt.IsZero() {
-		td.CodexDetectedAt = codexDetectedAt.Unix()
+	blob := toolDataBlob{
+		ClaudeSessionID:    td.ClaudeSessionID,
+		ClaudeDetectedAt:   unixOrZero(td.ClaudeDetectedAt),
+		GeminiSessionID:    td.GeminiSessionID,
+		GeminiDetectedAt:   unixOrZero(td.GeminiDetectedAt),
+		GeminiYoloMode:     td.GeminiYoloMode,
+		GeminiModel:        td.GeminiModel,
+		OpenCodeSessionID:  td.OpenCodeSessionID,
+		OpenCodeDetectedAt: unixOrZero(td.OpenCodeDetectedAt),
+		CodexSessionID:     td.CodexSessionID,
+		CodexDetectedAt:    unixOrZero(td.CodexDetectedAt),
+		LatestPrompt:       td.LatestPrompt,
+		LoadedMCPNames:     td.LoadedMCPNames,
+		ToolOptions:        td.ToolOptions,
+		AutoCheckpoint:     td.AutoCheckpoint,
+		StatusText:         td.StatusText,
+		StatusTextFromHook: td.StatusTextFromHook,
+		Container:          td.Container,
+		Notes:              td.Notes,
+		PendingPrompt:      td.PendingPrompt,
+		AutoAttach:         td.AutoAttach,
+		Ticket:             td.Ticket,
+		TmuxSocket:         td.TmuxSocket,
+		GitRemote:          td.GitRemote,
+		PreAttachHook:      td.PreAttachHook,
+		PostDetachHook:     td.PostDetachHook,
+		PreStartHook:       td.PreStartHook,
+		PostExitHook:       td.PostExitHook,
+		HandoffNote:        td.HandoffNote,
+		HandoffAt:          unixOrZero(td.HandoffAt),
+		ContextFiles:       td.ContextFiles,
+		AutoTitle:          td.AutoTitle,
+		AutoTitleFrom:      td.AutoTitleFrom,
+		YoloMode:           td.YoloMode,
+		Watch:              td.Watch,
+		Template:           td.Template,
+		Env:                td.Env,
 	}
-	data, _ := json.Marshal(td)
+	data, _ := json.Marshal(blob)
 	return data
 }
 
-// UnmarshalToolData extracts individual fields from the tool_data JSON blob.
-// This is the reverse path: JSON blob from SQLite -> individual Instance fields.
-func UnmarshalToolData(data json.RawMessage) (
-	claudeSessionID string, claudeDetectedAt time.Time,
-	geminiSessionID string, geminiDetectedAt time.Time,
-	geminiYoloMode *bool, geminiModel string,
-	openCodeSessionID string, openCodeDetectedAt time.Time,
-	codexSessionID string, codexDetectedAt time.Time,
-	latestPrompt string, loadedMCPNames []string,
-	toolOptionsJSON json.RawMessage,
-) {
+// UnmarshalToolData extracts per-session fields from the tool_data JSON blob.
+// This is the reverse path: JSON blob from SQLite -> Instance fields.
+// Missing or malformed data yields an empty (non-nil) ToolData.
+func UnmarshalToolData(data json.RawMessage) *ToolData {
+	td := &ToolData{}
 	if len(data) == 0 {
-		return
-	}
-	var td toolDataBlob
-	if err := json.Unmarshal(data, &td); err != nil {
-		return
-	}
-	claudeSessionID = td.ClaudeSessionID
-	if td.ClaudeDetectedAt > 0 {
-		claudeDetectedAt = time.Unix(td.ClaudeDetectedAt, 0)
-	}
-	geminiSessionID = td.GeminiSessionID
-	if td.GeminiDetectedAt > 0 {
-		geminiDetectedAt = time.Unix(td.GeminiDetectedAt, 0)
-	}
-	geminiYoloMode = td.GeminiYoloMode
-	geminiModel = td.GeminiModel
-	openCodeSessionID = td.OpenCodeSessionID
-	if td.OpenCodeDetectedAt > 0 {
-		openCodeDetectedAt = time.Unix(td.OpenCodeDetectedAt, 0)
+		return td
 	}
-	codexSessionID = td.CodexSessionID
-	if td.CodexDetectedAt > 0 {
-		codexDetectedAt = time.Unix(td.CodexDetectedAt, 0)
+	var blob toolDataBlob
+	if err := json.Unmarshal(data, &blob); err != nil {
+		return td
 	}
-	latestPrompt = td.LatestPrompt
-	loadedMCPNames = td.LoadedMCPNames
-	toolOptionsJSON = td.ToolOptions
-	return
+	td.ClaudeSessionID = blob.ClaudeSessionID
+	td.ClaudeDetectedAt = timeOrZero(blob.ClaudeDetectedAt)
+	td.GeminiSessionID = blob.GeminiSessionID
+	td.GeminiDetectedAt = timeOrZero(blob.GeminiDetectedAt)
+	td.GeminiYoloMode = blob.GeminiYoloMode
+	td.GeminiModel = blob.GeminiModel
+	td.OpenCodeSessionID = blob.OpenCodeSessionID
+	td.OpenCodeDetectedAt = timeOrZero(blob.OpenCodeDetectedAt)
+	td.CodexSessionID = blob.CodexSessionID
+	td.CodexDetectedAt = timeOrZero(blob.CodexDetectedAt)
+	td.LatestPrompt = blob.LatestPrompt
+	td.LoadedMCPNames = blob.LoadedMCPNames
+	td.ToolOptions = blob.ToolOptions
+	td.AutoCheckpoint = blob.AutoCheckpoint
+	td.StatusText = blob.StatusText
+	td.StatusTextFromHook = blob.StatusTextFromHook
+	td.Container = blob.Container
+	td.Notes = blob.Notes
+	td.PendingPrompt = blob.PendingPrompt
+	td.AutoAttach = blob.AutoAttach
+	td.Ticket = blob.Ticket
+	td.TmuxSocket = blob.TmuxSocket
+	td.GitRemote = blob.GitRemote
+	td.PreAttachHook = blob.PreAttachHook
+	td.PostDetachHook = blob.PostDetachHook
+	td.PreStartHook = blob.PreStartHook
+	td.PostExitHook = blob.PostExitHook
+	td.HandoffNote = blob.HandoffNote
+	td.HandoffAt = timeOrZero(blob.HandoffAt)
+	td.ContextFiles = blob.ContextFiles
+	td.AutoTitle = blob.AutoTitle
+	td.AutoTitleFrom = blob.AutoTitleFrom
+	td.YoloMode = blob.YoloMode
+	td.Watch = blob.Watch
+	td.Template = blob.Template
+	td.Env = blob.Env
+	return td
 }
@@ -15,14 +15,15 @@ import (
 
 // SchemaVersion tracks the current database schema version.
 // Bump this when adding migrations.
-const SchemaVersion = 1
+const SchemaVersion = 5
 
 // StateDB wraps a SQLite database for session/group persistence.
 // Thread-safe for concurrent use from multiple goroutines within one process.
 // Multiple OS processes can safely read/write via WAL mode + busy timeout.
 type StateDB struct {
-	db  *sql.DB
-	pid int
+	db   *sql.DB
+	pid  int
+	path string // database file, for the write lock next to it
 }
 
 // InstanceRow represents a session row in the database.
@@ -44,6 +45,7 @@ type InstanceRow struct {
 	WorktreeRepo    string
 	WorktreeBranch  string
 	ToolData        json.RawMessage // JSON blob for tool-specific data
+	UpdatedAt       time.Time       // Last change to synced fields (see upsertInstanceSQL)
 }
 
 // GroupRow represents a group row in the database.
@@ -53,6 +55,16 @@ type GroupRow struct {
 	Expanded    bool
 	Order       int
 	DefaultPath string
+	Hidden      bool
+}
+
+// ActivityRow is one interval of session activity: the user attached to it,
+// or its agent was running.
+type ActivityRow struct {
+	InstanceID string
+	Kind       string
+	Started    time.Time
+	Ended      time.Time
 }
 
 // StatusRow holds status + acknowledgment for a session.
@@ -112,7 +124,7 @@ func Open(dbPath string) (*StateDB, error) {
 		return nil, fmt.Errorf("statedb: foreign keys: %w", err)
 	}
 
-	return &StateDB{db: db, pid: os.Getpid()}, nil
+	return &StateDB{db: db, pid: os.Getpid(), path: dbPath}, nil
 }
 
 // Close checkpoints WAL and closes the database.
@@ -122,6 +134,15 @@ func (s *StateDB) Close() error {
 	return s.db.Close()
 }
 
+// BackupTo writes a consistent copy of the database to destPath, which must
+// not exist. Safe while other processes are writing.
+func (s *StateDB) BackupTo(destPath string) error {
+	if _, err := s.db.Exec("VACUUM INTO ?", destPath); err != nil {
+		return fmt.Errorf("statedb: backup: %w", err)
+	}
+	return nil
+}
+
 // DB returns the underlying sql.DB for advanced use cases (e.g., testing).
 func (s *StateDB) DB() *sql.DB {
 	return s.db
@@ -165,11 +186,26 @@ func (s *StateDB) Migrate() error {
 			worktree_repo     TEXT NOT NULL DEFAULT '',
 			worktree_branch   TEXT NOT NULL DEFAULT '',
 			tool_data       TEXT NOT NULL DEFAULT '{}',
-			acknowledged    INTEGER NOT NULL DEFAULT 0
+			acknowledged    INTEGER NOT NULL DEFAULT 0,
+			updated_at      INTEGER NOT NULL DEFAULT 0
 		)
 	`); err != nil {
 		return fmt.Errorf("statedb: create instances: %w", err)
 	}
+	// v3: instances.updated_at
+	if err := addColumnIfMissing(tx, "instances", "updated_at", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return fmt.Errorf("statedb: add instances.updated_at: %w", err)
+	}
+
+	// v3: tombstones for deleted sessions, so sync can tell deleted from new
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS tombstones (
+			id         TEXT PRIMARY KEY,
+			deleted_at INTEGER NOT NULL
+		)
+	`); err != nil {
+		return fmt.Errorf("statedb: create tombstones: %w", err)
+	}
 
 	// groups table
 	if _, err := tx.Exec(`
@@ -178,11 +214,16 @@ func (s *StateDB) Migrate() error {
 			name         TEXT NOT NULL,
 			expanded     INTEGER NOT NULL DEFAULT 1,
 			sort_order   INTEGER NOT NULL DEFAULT 0,
-			default_path TEXT NOT NULL DEFAULT ''
+			default_path TEXT NOT NULL DEFAULT '',
+			hidden       INTEGER NOT NULL DEFAULT 0
 		)
 	`); err != nil {
 		return fmt.Errorf("statedb: create groups: %w", err)
 	}
+	// v2: groups.hidden (tables created by v1 lack it)
+	if err := addColumnIfMissing(tx, "groups", "hidden", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return fmt.Errorf("statedb: add groups.hidden: %w", err)
+	}
 
 	// instance heartbeats
 	if _, err := tx.Exec(`
@@ -196,6 +237,29 @@ func (s *StateDB) Migrate() error {
 		return fmt.Errorf("statedb: create heartbeats: %w", err)
 	}
 
+	// v4: activity intervals (attached and running time, for reports)
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS activity (
+			instance_id TEXT NOT NULL,
+			kind        TEXT NOT NULL,
+			started     INTEGER NOT NULL,
+			ended       INTEGER NOT NULL
+		)
+	`); err != nil {
+		return fmt.Errorf("statedb: create activity: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS activity_ended ON activity (ended)`); err != nil {
+		return fmt.Errorf("statedb: index activity: %w", err)
+	}
+
+	// v5: lookups by title and group without loading every instance
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS instances_title ON instances (title)`); err != nil {
+		return fmt.Errorf("statedb: index instances.title: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS instances_group ON instances (group_path, sort_order)`); err != nil {
+		return fmt.Errorf("statedb: index instances.group_path: %w", err)
+	}
+
 	// Set schema version
 	if _, err := tx.Exec(`
 		INSERT OR REPLACE INTO metadata (key, value) VALUES ('schema_version', ?)
@@ -206,6 +270,38 @@ func (s *StateDB) Migrate() error {
 	return tx.Commit()
 }
 
+// addColumnIfMissing adds a column to a table created by an older schema version.
+func addColumnIfMissing(tx *sql.Tx, table, column, definition string) error {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	found := false
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, colType    string
+			dflt             sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		if name == column {
+			found = true
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if found {
+		return nil
+	}
+	_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
 // IsEmpty returns true if the instances table has no rows.
 func (s *StateDB) IsEmpty() (bool, error) {
 	var count int
@@ -225,61 +321,109 @@ func (s *StateDB) SaveInstance(inst *InstanceRow) error {
 		toolData = json.RawMessage("{}")
 	}
 
-	_, err := s.db.Exec(`
-		INSERT OR REPLACE INTO instances (
-			id, title, project_path, group_path, sort_order,
-			command, wrapper, tool, status, tmux_session,
-			created_at, last_accessed,
-			parent_session_id, worktree_path, worktree_repo, worktree_branch,
-			tool_data
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`,
+	_, err := s.db.Exec(upsertInstanceSQL, upsertInstanceArgs(inst, toolData, time.Now())...)
+	return err
+}
+
+// upsertInstanceSQL inserts an instance or updates it in place. updated_at
+// only moves when a field that sync merges changes (status, tmux session and
+// access time are per-machine); an incoming updated_at newer than the stored
+// one (a row merged in by sync) is kept instead of the save time.
+const upsertInstanceSQL = `
+	INSERT INTO instances (
+		id, title, project_path, group_path, sort_order,
+		command, wrapper, tool, status, tmux_session,
+		created_at, last_accessed,
+		parent_session_id, worktree_path, worktree_repo, worktree_branch,
+		tool_data, updated_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(id) DO UPDATE SET
+		title = excluded.title, project_path = excluded.project_path,
+		group_path = excluded.group_path, sort_order = excluded.sort_order,
+		command = excluded.command, wrapper = excluded.wrapper, tool = excluded.tool,
+		status = excluded.status, tmux_session = excluded.tmux_session,
+		created_at = excluded.created_at, last_accessed = excluded.last_accessed,
+		parent_session_id = excluded.parent_session_id, worktree_path = excluded.worktree_path,
+		worktree_repo = excluded.worktree_repo, worktree_branch = excluded.worktree_branch,
+		tool_data = excluded.tool_data,
+		acknowledged = 0, -- Saves clear the shared acknowledgment, as INSERT OR REPLACE did
+		updated_at = CASE
+			WHEN instances.title IS NOT excluded.title
+				OR instances.project_path IS NOT excluded.project_path
+				OR instances.group_path IS NOT excluded.group_path
+				OR instances.sort_order IS NOT excluded.sort_order
+				OR instances.command IS NOT excluded.command
+				OR instances.wrapper IS NOT excluded.wrapper
+				OR instances.tool IS NOT excluded.tool
+				OR instances.parent_session_id IS NOT excluded.parent_session_id
+				OR instances.worktree_path IS NOT excluded.worktree_path
+				OR instances.worktree_repo IS NOT excluded.worktree_repo
+				OR instances.worktree_branch IS NOT excluded.worktree_branch
+				OR instances.tool_data IS NOT excluded.tool_data
+			THEN CASE WHEN excluded.updated_at > instances.updated_at THEN excluded.updated_at ELSE ? END
+			ELSE instances.updated_at
+		END
+`
+
+// upsertInstanceArgs returns the parameters for upsertInstanceSQL
+func upsertInstanceArgs(inst *InstanceRow, toolData json.RawMessage, now time.Time) []any {
+	updatedAt := inst.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = now
+	}
+	return []any{
 		inst.ID, inst.Title, inst.ProjectPath, inst.GroupPath, inst.Order,
 		inst.Command, inst.Wrapper, inst.Tool, inst.Status, inst.TmuxSession,
 		inst.CreatedAt.Unix(), inst.LastAccessed.Unix(),
 		inst.ParentSessionID, inst.WorktreePath, inst.WorktreeRepo, inst.WorktreeBranch,
-		string(toolData),
-	)
-	return err
+		string(toolData), updatedAt.Unix(),
+		now.Unix(),
+	}
 }
 
-// SaveInstances inserts or replaces multiple instances in a single transaction.
+// TombstoneRetention bounds how long deletions are remembered for sync
+const TombstoneRetention = 90 * 24 * time.Hour
+
+// SaveInstances inserts or updates multiple instances in a single transaction.
 // It also removes any rows from the database that are not in the provided list,
-// ensuring deleted sessions don't reappear on reload.
+// ensuring deleted sessions don't reappear on reload, and leaves a tombstone
+// for each so sync doesn't bring them back from another machine.
 func (s *StateDB) SaveInstances(insts []*InstanceRow) error {
 	tx, err := s.db.Begin()
 	if err != nil {
 		return err
 	}
 	defer func() { _ = tx.Rollback() }()
+	now := time.Now()
 
 	// Delete rows not in the new list to prevent deleted sessions from reappearing.
-	if len(insts) == 0 {
-		if _, err := tx.Exec("DELETE FROM instances"); err != nil {
-			return err
-		}
-	} else {
+	notIn, args := "", []any{}
+	if len(insts) > 0 {
 		placeholders := make([]string, len(insts))
-		args := make([]any, len(insts))
 		for i, inst := range insts {
 			placeholders[i] = "?"
-			args[i] = inst.ID
+			args = append(args, inst.ID)
 		}
-		query := "DELETE FROM instances WHERE id NOT IN (" + strings.Join(placeholders, ",") + ")"
-		if _, err := tx.Exec(query, args...); err != nil {
+		list := "(" + strings.Join(placeholders, ",") + ")"
+		notIn = " WHERE id NOT IN " + list
+
+		// A saved session is alive again (e.g. restored), so it has no tombstone
+		if _, err := tx.Exec("DELETE FROM tombstones WHERE id IN "+list, args...); err != nil {
 			return err
 		}
 	}
+	if _, err := tx.Exec("INSERT OR REPLACE INTO tombstones (id, deleted_at) SELECT id, ? FROM instances"+notIn,
+		append([]any{now.Unix()}, args...)...); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM instances"+notIn, args...); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM tombstones WHERE deleted_at < ?", now.Add(-TombstoneRetention).Unix()); err != nil {
+		return err
+	}
 
-	stmt, err := tx.Prepare(`
-		INSERT OR REPLACE INTO instances (
-			id, title, project_path, group_path, sort_order,
-			command, wrapper, tool, status, tmux_session,
-			created_at, last_accessed,
-			parent_session_id, worktree_path, worktree_repo, worktree_branch,
-			tool_data
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`)
+	stmt, err := tx.Prepare(upsertInstanceSQL)
 	if err != nil {
 		return err
 	}
@@ -290,13 +434,7 @@ func (s *StateDB) SaveInstances(insts []*InstanceRow) error {
 		if len(toolData) == 0 {
 			toolData = json.RawMessage("{}")
 		}
-		if _, err := stmt.Exec(
-			inst.ID, inst.Title, inst.ProjectPath, inst.GroupPath, inst.Order,
-			inst.Command, inst.Wrapper, inst.Tool, inst.Status, inst.TmuxSession,
-			inst.CreatedAt.Unix(), inst.LastAccessed.Unix(),
-			inst.ParentSessionID, inst.WorktreePath, inst.WorktreeRepo, inst.WorktreeBranch,
-			string(toolData),
-		); err != nil {
+		if _, err := stmt.Exec(upsertInstanceArgs(inst, toolData, now)...); err != nil {
 			return err
 		}
 	}
@@ -304,16 +442,138 @@ func (s *StateDB) SaveInstances(insts []*InstanceRow) error {
 	return tx.Commit()
 }
 
+// MergeInstances saves insts for a writer that read the table at since and
+// saw the rows in known, without losing another process's changes in
+// between: of the rows missing from insts only known ones are deleted (rows
+// added since are kept), and known rows deleted since (tombstoned after
+// since) are not saved back. Returns the IDs kept and not saved back. Run
+// it under LockWrites.
+func (s *StateDB) MergeInstances(insts []*InstanceRow, known map[string]bool, since time.Time) (kept, dropped []string, err error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	now := time.Now()
+
+	current, err := queryIDs(tx, "SELECT id FROM instances")
+	if err != nil {
+		return nil, nil, err
+	}
+	deletedSince, err := queryIDs(tx, "SELECT id FROM tombstones WHERE deleted_at >= ?", since.Unix())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	saving := make(map[string]bool, len(insts))
+	for _, inst := range insts {
+		saving[inst.ID] = true
+	}
+	var deleting []string
+	for id := range current {
+		switch {
+		case saving[id]:
+		case known[id]:
+			deleting = append(deleting, id)
+		default:
+			kept = append(kept, id)
+		}
+	}
+	for _, id := range deleting {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO tombstones (id, deleted_at) VALUES (?, ?)", id, now.Unix()); err != nil {
+			return nil, nil, err
+		}
+		if _, err := tx.Exec("DELETE FROM instances WHERE id = ?", id); err != nil {
+			return nil, nil, err
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM tombstones WHERE deleted_at < ?", now.Add(-TombstoneRetention).Unix()); err != nil {
+		return nil, nil, err
+	}
+
+	stmt, err := tx.Prepare(upsertInstanceSQL)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer stmt.Close()
+
+	for _, inst := range insts {
+		if known[inst.ID] && !current[inst.ID] && deletedSince[inst.ID] {
+			dropped = append(dropped, inst.ID)
+			continue
+		}
+		// A saved session is alive again (e.g. restored), so it has no tombstone
+		if _, err := tx.Exec("DELETE FROM tombstones WHERE id = ?", inst.ID); err != nil {
+			return nil, nil, err
+		}
+		toolData := inst.ToolData
+		if len(toolData) == 0 {
+			toolData = json.RawMessage("{}")
+		}
+		if _, err := stmt.Exec(upsertInstanceArgs(inst, toolData, now)...); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return kept, dropped, tx.Commit()
+}
+
+// queryIDs returns the set of IDs a query selects
+func queryIDs(tx *sql.Tx, query string, args ...any) (map[string]bool, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids[id] = true
+	}
+	return ids, rows.Err()
+}
+
 // LoadInstances returns all instances ordered by sort_order.
 func (s *StateDB) LoadInstances() ([]*InstanceRow, error) {
+	return s.queryInstances("")
+}
+
+// LoadInstance returns the instance with the given ID, or nil if there is none.
+func (s *StateDB) LoadInstance(id string) (*InstanceRow, error) {
+	rows, err := s.queryInstances("WHERE id = ?", id)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return rows[0], nil
+}
+
+// FindInstancesByTitle returns the instances titled exactly title.
+func (s *StateDB) FindInstancesByTitle(title string) ([]*InstanceRow, error) {
+	return s.queryInstances("WHERE title = ?", title)
+}
+
+// LoadGroupInstances returns the instances in a group and its subgroups,
+// ordered by sort_order. Subgroups are the paths between "<group>/" and
+// "<group>0" ('0' follows '/'), a range the index can serve.
+func (s *StateDB) LoadGroupInstances(groupPath string) ([]*InstanceRow, error) {
+	return s.queryInstances("WHERE group_path = ? OR (group_path > ? AND group_path < ?)",
+		groupPath, groupPath+"/", groupPath+"0")
+}
+
+// queryInstances loads the instances matching a WHERE clause ("" for all),
+// ordered by sort_order.
+func (s *StateDB) queryInstances(where string, args ...any) ([]*InstanceRow, error) {
 	rows, err := s.db.Query(`
 		SELECT id, title, project_path, group_path, sort_order,
 			command, wrapper, tool, status, tmux_session,
 			created_at, last_accessed,
 			parent_session_id, worktree_path, worktree_repo, worktree_branch,
-			tool_data
-		FROM instances ORDER BY sort_order
-	`)
+			tool_data, updated_at
+		FROM instances `+where+` ORDER BY sort_order
+	`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -322,14 +582,14 @@ func (s *StateDB) LoadInstances() ([]*InstanceRow, error) {
 	var result []*InstanceRow
 	for rows.Next() {
 		r := &InstanceRow{}
-		var createdUnix, accessedUnix int64
+		var createdUnix, accessedUnix, updatedUnix int64
 		var toolDataStr string
 		if err := rows.Scan(
 			&r.ID, &r.Title, &r.ProjectPath, &r.GroupPath, &r.Order,
 			&r.Command, &r.Wrapper, &r.Tool, &r.Status, &r.TmuxSession,
 			&createdUnix, &accessedUnix,
 			&r.ParentSessionID, &r.WorktreePath, &r.WorktreeRepo, &r.WorktreeBranch,
-			&toolDataStr,
+			&toolDataStr, &updatedUnix,
 		); err != nil {
 			return nil, err
 		}
@@ -338,15 +598,65 @@ func (s *StateDB) LoadInstances() ([]*InstanceRow, error) {
 			r.LastAccessed = time.Unix(accessedUnix, 0)
 		}
 		r.ToolData = json.RawMessage(toolDataStr)
+		if updatedUnix > 0 {
+			r.UpdatedAt = time.Unix(updatedUnix, 0)
+		}
 		result = append(result, r)
 	}
 	return result, rows.Err()
 }
 
-// DeleteInstance removes an instance by ID.
+// DeleteInstance removes an instance by ID and leaves a tombstone for it.
 func (s *StateDB) DeleteInstance(id string) error {
-	_, err := s.db.Exec("DELETE FROM instances WHERE id = ?", id)
-	return err
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.Exec("INSERT OR REPLACE INTO tombstones (id, deleted_at) SELECT id, ? FROM instances WHERE id = ?",
+		time.Now().Unix(), id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM instances WHERE id = ?", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LoadTombstones returns when each remembered deleted session was deleted.
+func (s *StateDB) LoadTombstones() (map[string]time.Time, error) {
+	rows, err := s.db.Query("SELECT id, deleted_at FROM tombstones")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]time.Time)
+	for rows.Next() {
+		var id string
+		var deletedUnix int64
+		if err := rows.Scan(&id, &deletedUnix); err != nil {
+			return nil, err
+		}
+		result[id] = time.Unix(deletedUnix, 0)
+	}
+	return result, rows.Err()
+}
+
+// SaveTombstones records deletion times learned from another machine,
+// replacing the local time for IDs deleted on both.
+func (s *StateDB) SaveTombstones(tombstones map[string]time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	for id, deletedAt := range tombstones {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO tombstones (id, deleted_at) VALUES (?, ?)", id, deletedAt.Unix()); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 // UpdateInstanceField updates a single column for a given instance.
@@ -373,8 +683,8 @@ func (s *StateDB) SaveGroups(groups []*GroupRow) error {
 	}
 
 	stmt, err := tx.Prepare(`
-		INSERT INTO groups (path, name, expanded, sort_order, default_path)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO groups (path, name, expanded, sort_order, default_path, hidden)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
@@ -386,7 +696,11 @@ func (s *StateDB) SaveGroups(groups []*GroupRow) error {
 		if g.Expanded {
 			expanded = 1
 		}
-		if _, err := stmt.Exec(g.Path, g.Name, expanded, g.Order, g.DefaultPath); err != nil {
+		hidden := 0
+		if g.Hidden {
+			hidden = 1
+		}
+		if _, err := stmt.Exec(g.Path, g.Name, expanded, g.Order, g.DefaultPath, hidden); err != nil {
 			return err
 		}
 	}
@@ -397,7 +711,7 @@ func (s *StateDB) SaveGroups(groups []*GroupRow) error {
 // LoadGroups returns all groups ordered by sort_order.
 func (s *StateDB) LoadGroups() ([]*GroupRow, error) {
 	rows, err := s.db.Query(`
-		SELECT path, name, expanded, sort_order, default_path
+		SELECT path, name, expanded, sort_order, default_path, hidden
 		FROM groups ORDER BY sort_order
 	`)
 	if err != nil {
@@ -408,11 +722,12 @@ func (s *StateDB) LoadGroups() ([]*GroupRow, error) {
 	var result []*GroupRow
 	for rows.Next() {
 		g := &GroupRow{}
-		var expanded int
-		if err := rows.Scan(&g.Path, &g.Name, &expanded, &g.Order, &g.DefaultPath); err != nil {
+		var expanded, hidden int
+		if err := rows.Scan(&g.Path, &g.Name, &expanded, &g.Order, &g.DefaultPath, &hidden); err != nil {
 			return nil, err
 		}
 		g.Expanded = expanded != 0
+		g.Hidden = hidden != 0
 		result = append(result, g)
 	}
 	return result, rows.Err()
@@ -498,6 +813,13 @@ func (s *StateDB) UnregisterInstance() error {
 	return err
 }
 
+// UnregisterPID removes another process from the heartbeat table, for
+// instances known to have died without unregistering.
+func (s *StateDB) UnregisterPID(pid int) error {
+	_, err := s.db.Exec("DELETE FROM instance_heartbeats WHERE pid = ?", pid)
+	return err
+}
+
 // CleanDeadInstances removes heartbeat entries that haven't been updated within timeout.
 func (s *StateDB) CleanDeadInstances(timeout time.Duration) error {
 	cutoff := time.Now().Add(-timeout).Unix()
@@ -566,6 +888,20 @@ func (s *StateDB) ElectPrimary(timeout time.Duration) (bool, error) {
 	return true, nil
 }
 
+// PrimaryPID returns the pid of the alive primary instance, or 0 if there is none.
+func (s *StateDB) PrimaryPID(timeout time.Duration) (int, error) {
+	var pid int
+	cutoff := time.Now().Add(-timeout).Unix()
+	err := s.db.QueryRow(
+		"SELECT pid FROM instance_heartbeats WHERE is_primary = 1 AND heartbeat >= ? LIMIT 1",
+		cutoff,
+	).Scan(&pid)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return pid, err
+}
+
 // ResignPrimary clears the is_primary flag for this process.
 func (s *StateDB) ResignPrimary() error {
 	_, err := s.db.Exec(
@@ -575,6 +911,43 @@ func (s *StateDB) ResignPrimary() error {
 	return err
 }
 
+// --- Activity ---
+
+// RecordActivity stores an activity interval.
+func (s *StateDB) RecordActivity(row ActivityRow) error {
+	_, err := s.db.Exec(
+		"INSERT INTO activity (instance_id, kind, started, ended) VALUES (?, ?, ?, ?)",
+		row.InstanceID, row.Kind, row.Started.Unix(), row.Ended.Unix(),
+	)
+	return err
+}
+
+// LoadActivity returns the activity intervals that end at or after since,
+// oldest first.
+func (s *StateDB) LoadActivity(since time.Time) ([]ActivityRow, error) {
+	rows, err := s.db.Query(
+		"SELECT instance_id, kind, started, ended FROM activity WHERE ended >= ? ORDER BY started",
+		since.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []ActivityRow
+	for rows.Next() {
+		var r ActivityRow
+		var started, ended int64
+		if err := rows.Scan(&r.InstanceID, &r.Kind, &started, &ended); err != nil {
+			return nil, err
+		}
+		r.Started = time.Unix(started, 0)
+		r.Ended = time.Unix(ended, 0)
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
+
 // --- Metadata ---
 
 // SetMeta sets a key-value pair in the metadata table.
@@ -596,6 +969,34 @@ func (s *StateDB) GetMeta(key string) (string, error) {
 	return value, err
 }
 
+// exitHookRetention is how long a claimed post-exit hook is remembered
+const exitHookRetention = 7 * 24 * time.Hour
+
+// ClaimExitHook records that the end of the tmux session tmuxName has been
+// handled. Only the first caller across processes gets true, so the
+// post-exit hook runs once. Claims older than exitHookRetention are pruned.
+func (s *StateDB) ClaimExitHook(tmuxName string) (bool, error) {
+	now := time.Now()
+	if _, err := s.db.Exec("DELETE FROM metadata WHERE key GLOB 'exit_hook:*' AND CAST(value AS INTEGER) < ?",
+		now.Add(-exitHookRetention).Unix()); err != nil {
+		return false, err
+	}
+	res, err := s.db.Exec("INSERT OR IGNORE INTO metadata (key, value) VALUES (?, ?)",
+		"exit_hook:"+tmuxName, fmt.Sprintf("%d", now.Unix()))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// ReleaseExitHook drops a claim made by ClaimExitHook (e.g. the kill it was
+// made for failed, so the session has not ended)
+func (s *StateDB) ReleaseExitHook(tmuxName string) error {
+	_, err := s.db.Exec("DELETE FROM metadata WHERE key = ?", "exit_hook:"+tmuxName)
+	return err
+}
+
 // --- Change Detection (replaces fsnotify) ---
 
 // Touch updates a metadata timestamp that other instances can poll to detect changes.
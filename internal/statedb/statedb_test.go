@@ -2,6 +2,7 @@ package statedb
 
 import (
 	"encoding/json"
+	"fmt"
 	"path/filepath"
 	"sync"
 	"testing"
@@ -102,6 +103,39 @@ func TestSaveLoadInstances(t *testing.T) {
 	}
 }
 
+func TestInstanceLookups(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Now()
+	if err := db.SaveInstances([]*InstanceRow{
+		{ID: "a", Title: "Alpha", ProjectPath: "/a", GroupPath: "grp", Order: 1, Tool: "claude", CreatedAt: now},
+		{ID: "b", Title: "alpha", ProjectPath: "/b", GroupPath: "other", Order: 2, Tool: "shell", CreatedAt: now},
+		{ID: "c", Title: "Gamma", ProjectPath: "/c", GroupPath: "grp", Order: 0, Tool: "shell", CreatedAt: now},
+		{ID: "d", Title: "Delta", ProjectPath: "/d", GroupPath: "grp/sub", Order: 3, Tool: "shell", CreatedAt: now},
+		{ID: "e", Title: "Eps", ProjectPath: "/e", GroupPath: "grp-old", Order: 4, Tool: "shell", CreatedAt: now},
+	}); err != nil {
+		t.Fatalf("SaveInstances: %v", err)
+	}
+
+	inst, err := db.LoadInstance("b")
+	if err != nil || inst == nil || inst.Title != "alpha" {
+		t.Fatalf("LoadInstance(b) = %+v, %v", inst, err)
+	}
+	if inst, err := db.LoadInstance("missing"); err != nil || inst != nil {
+		t.Errorf("LoadInstance(missing) = %+v, %v", inst, err)
+	}
+
+	byTitle, err := db.FindInstancesByTitle("alpha")
+	if err != nil || len(byTitle) != 1 || byTitle[0].ID != "b" {
+		t.Errorf("FindInstancesByTitle = %v, %v", byTitle, err)
+	}
+
+	group, err := db.LoadGroupInstances("grp")
+	if err != nil || len(group) != 3 || group[0].ID != "c" || group[1].ID != "a" || group[2].ID != "d" {
+		t.Errorf("LoadGroupInstances = %v, %v", group, err)
+	}
+}
+
 func TestSaveLoadGroups(t *testing.T) {
 	db := newTestDB(t)
 
@@ -129,6 +163,53 @@ func TestSaveLoadGroups(t *testing.T) {
 	}
 }
 
+func TestGroupsHiddenColumnMigration(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "state.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	// Schema v1 groups table, without the hidden column
+	if _, err := db.DB().Exec(`
+		CREATE TABLE groups (
+			path         TEXT PRIMARY KEY,
+			name         TEXT NOT NULL,
+			expanded     INTEGER NOT NULL DEFAULT 1,
+			sort_order   INTEGER NOT NULL DEFAULT 0,
+			default_path TEXT NOT NULL DEFAULT ''
+		)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.DB().Exec(`INSERT INTO groups (path, name) VALUES ('old', 'Old')`); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Migrate (again): %v", err)
+	}
+
+	loaded, err := db.LoadGroups()
+	if err != nil {
+		t.Fatalf("LoadGroups: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].Hidden {
+		t.Fatalf("existing group = %+v, want one visible group", loaded)
+	}
+
+	if err := db.SaveGroups([]*GroupRow{{Path: "old", Name: "Old", Hidden: true}}); err != nil {
+		t.Fatalf("SaveGroups: %v", err)
+	}
+	loaded, _ = db.LoadGroups()
+	if !loaded[0].Hidden {
+		t.Error("Hidden not persisted")
+	}
+}
+
 func TestDeleteInstance(t *testing.T) {
 	db := newTestDB(t)
 
@@ -351,6 +432,54 @@ func TestToolDataJSON(t *testing.T) {
 	}
 }
 
+func TestMarshalUnmarshalToolData(t *testing.T) {
+	yolo := true
+	checkpoint := false
+	detected := time.Unix(1700000000, 0)
+
+	data := MarshalToolData(&ToolData{
+		ClaudeSessionID:  "cls-abc123",
+		ClaudeDetectedAt: detected,
+		GeminiYoloMode:   &yolo,
+		LoadedMCPNames:   []string{"github"},
+		AutoCheckpoint:   &checkpoint,
+		StatusText:       "running tests",
+		Notes:            "acme/api#12: Fix login",
+		PendingPrompt:    "Work on issue #12",
+	})
+
+	td := UnmarshalToolData(data)
+	if td.ClaudeSessionID != "cls-abc123" {
+		t.Errorf("ClaudeSessionID: %q", td.ClaudeSessionID)
+	}
+	if !td.ClaudeDetectedAt.Equal(detected) {
+		t.Errorf("ClaudeDetectedAt: %v", td.ClaudeDetectedAt)
+	}
+	if !td.GeminiDetectedAt.IsZero() {
+		t.Errorf("GeminiDetectedAt should stay zero, got %v", td.GeminiDetectedAt)
+	}
+	if td.GeminiYoloMode == nil || !*td.GeminiYoloMode {
+		t.Errorf("GeminiYoloMode: %v", td.GeminiYoloMode)
+	}
+	if td.AutoCheckpoint == nil || *td.AutoCheckpoint {
+		t.Errorf("AutoCheckpoint: %v", td.AutoCheckpoint)
+	}
+	if td.StatusText != "running tests" || td.StatusTextFromHook {
+		t.Errorf("StatusText: %q (hook=%v)", td.StatusText, td.StatusTextFromHook)
+	}
+	if td.Notes != "acme/api#12: Fix login" || td.PendingPrompt != "Work on issue #12" {
+		t.Errorf("Notes/PendingPrompt: %q / %q", td.Notes, td.PendingPrompt)
+	}
+
+	// Empty and malformed blobs yield empty data, never nil
+	if td := UnmarshalToolData(nil); td == nil || td.ClaudeSessionID != "" {
+		t.Errorf("nil blob: %+v", td)
+	}
+	if td := UnmarshalToolData(json.RawMessage(`{bad`)); td == nil || td.AutoCheckpoint != nil {
+		t.Errorf("malformed blob: %+v", td)
+	}
+}
+
 func TestConcurrentAccess(t *testing.T) {
 	db := newTestDB(t)
 
@@ -449,6 +578,37 @@ func TestMetadata(t *testing.T) {
 	}
 }
 
+func TestClaimExitHook(t *testing.T) {
+	db := newTestDB(t)
+
+	if ok, err := db.ClaimExitHook("agentdeck_a"); err != nil || !ok {
+		t.Fatalf("first claim = %v, %v; want true", ok, err)
+	}
+	if ok, _ := db.ClaimExitHook("agentdeck_a"); ok {
+		t.Error("second claim of the same session succeeded")
+	}
+	if ok, _ := db.ClaimExitHook("agentdeck_b"); !ok {
+		t.Error("claim of another session failed")
+	}
+
+	// A released claim can be made again
+	if err := db.ReleaseExitHook("agentdeck_a"); err != nil {
+		t.Fatalf("ReleaseExitHook: %v", err)
+	}
+	if ok, _ := db.ClaimExitHook("agentdeck_a"); !ok {
+		t.Error("claim after release failed")
+	}
+
+	// Old claims are pruned
+	old := fmt.Sprintf("%d", time.Now().Add(-8*24*time.Hour).Unix())
+	if err := db.SetMeta("exit_hook:agentdeck_c", old); err != nil {
+		t.Fatalf("SetMeta: %v", err)
+	}
+	if ok, _ := db.ClaimExitHook("agentdeck_c"); !ok {
+		t.Error("claim over a pruned one failed")
+	}
+}
+
 func TestElectPrimary_FirstInstance(t *testing.T) {
 	db := newTestDB(t)
 
@@ -502,6 +662,33 @@ func TestElectPrimary_SecondInstance(t *testing.T) {
 	}
 }
 
+func TestPrimaryPID(t *testing.T) {
+	db := newTestDB(t)
+
+	if pid, err := db.PrimaryPID(30 * time.Second); err != nil || pid != 0 {
+		t.Fatalf("PrimaryPID with no instances = %d, %v; want 0", pid, err)
+	}
+
+	now := time.Now().Unix()
+	if _, err := db.DB().Exec(
+		"INSERT INTO instance_heartbeats (pid, started, heartbeat, is_primary) VALUES (?, ?, ?, ?)",
+		10001, now, now, 1,
+	); err != nil {
+		t.Fatalf("Insert primary: %v", err)
+	}
+	if pid, _ := db.PrimaryPID(30 * time.Second); pid != 10001 {
+		t.Errorf("PrimaryPID = %d, want 10001", pid)
+	}
+
+	// A stale primary doesn't count
+	if _, err := db.DB().Exec("UPDATE instance_heartbeats SET heartbeat = ?", now-120); err != nil {
+		t.Fatal(err)
+	}
+	if pid, _ := db.PrimaryPID(30 * time.Second); pid != 0 {
+		t.Errorf("PrimaryPID with stale primary = %d, want 0", pid)
+	}
+}
+
 func TestElectPrimary_Failover(t *testing.T) {
 	db := newTestDB(t)
 
@@ -604,3 +791,175 @@ func TestGlobalSingleton(t *testing.T) {
 		t.Error("Expected nil after clearing")
 	}
 }
+
+func TestInstanceUpdatedAtAndTombstones(t *testing.T) {
+	db := newTestDB(t)
+	row := func(id, title, status string) *InstanceRow {
+		return &InstanceRow{
+			ID: id, Title: title, ProjectPath: "/tmp", GroupPath: "grp",
+			Tool: "shell", Status: status, CreatedAt: time.Now(), ToolData: json.RawMessage("{}"),
+		}
+	}
+
+	// Rows merged in by sync arrive with their own UpdatedAt, which is kept
+	updatedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	a := row("a", "A", "idle")
+	a.UpdatedAt = updatedAt
+	if err := db.SaveInstances([]*InstanceRow{a, row("b", "B", "idle")}); err != nil {
+		t.Fatalf("SaveInstances: %v", err)
+	}
+	first, _ := db.LoadInstances()
+	if !first[0].UpdatedAt.Equal(updatedAt) || first[1].UpdatedAt.IsZero() {
+		t.Fatalf("UpdatedAt on insert = %v, %v", first[0].UpdatedAt, first[1].UpdatedAt)
+	}
+
+	if err := db.SaveInstances([]*InstanceRow{row("a", "A", "running"), row("b", "B", "idle")}); err != nil {
+		t.Fatalf("SaveInstances: %v", err)
+	}
+	loaded, _ := db.LoadInstances()
+	if !loaded[0].UpdatedAt.Equal(updatedAt) {
+		t.Error("status change must not bump UpdatedAt")
+	}
+
+	if err := db.SaveInstances([]*InstanceRow{row("a", "A renamed", "running")}); err != nil {
+		t.Fatalf("SaveInstances: %v", err)
+	}
+	loaded, _ = db.LoadInstances()
+	if len(loaded) != 1 || !loaded[0].UpdatedAt.After(updatedAt) {
+		t.Errorf("rename must bump UpdatedAt: %+v", loaded)
+	}
+
+	tombstones, err := db.LoadTombstones()
+	if err != nil {
+		t.Fatalf("LoadTombstones: %v", err)
+	}
+	if _, ok := tombstones["b"]; !ok || len(tombstones) != 1 {
+		t.Errorf("tombstones = %v, want b", tombstones)
+	}
+
+	if err := db.DeleteInstance("a"); err != nil {
+		t.Fatalf("DeleteInstance: %v", err)
+	}
+	if err := db.SaveInstances([]*InstanceRow{row("b", "B", "idle")}); err != nil {
+		t.Fatalf("SaveInstances: %v", err)
+	}
+	tombstones, _ = db.LoadTombstones()
+	if _, ok := tombstones["a"]; !ok {
+		t.Error("DeleteInstance should leave a tombstone")
+	}
+	if _, ok := tombstones["b"]; ok {
+		t.Error("saving a session again should clear its tombstone")
+	}
+}
+
+func TestRecordLoadActivity(t *testing.T) {
+	db := newTestDB(t)
+	base := time.Unix(1_700_000_000, 0)
+
+	rows := []ActivityRow{
+		{InstanceID: "a", Kind: "attached", Started: base, Ended: base.Add(time.Hour)},
+		{InstanceID: "a", Kind: "running", Started: base.Add(3 * time.Hour), Ended: base.Add(4 * time.Hour)},
+		{InstanceID: "b", Kind: "running", Started: base.Add(2 * time.Hour), Ended: base.Add(5 * time.Hour)},
+	}
+	for _, r := range rows {
+		if err := db.RecordActivity(r); err != nil {
+			t.Fatalf("RecordActivity: %v", err)
+		}
+	}
+
+	got, err := db.LoadActivity(base.Add(90 * time.Minute))
+	if err != nil {
+		t.Fatalf("LoadActivity: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 intervals ending after the cutoff, got %d", len(got))
+	}
+	if got[0].InstanceID != "b" || !got[0].Started.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("expected b's interval first (oldest start), got %+v", got[0])
+	}
+	if got[1].Kind != "running" || !got[1].Ended.Equal(base.Add(4*time.Hour)) {
+		t.Errorf("unexpected second interval %+v", got[1])
+	}
+}
+
+func TestMergeInstances(t *testing.T) {
+	db := newTestDB(t)
+	row := func(id string) *InstanceRow {
+		return &InstanceRow{
+			ID: id, Title: id, ProjectPath: "/tmp", GroupPath: "grp",
+			Tool: "shell", Status: "idle", CreatedAt: time.Now(), ToolData: json.RawMessage("{}"),
+		}
+	}
+	if err := db.SaveInstances([]*InstanceRow{row("a"), row("b"), row("c")}); err != nil {
+		t.Fatalf("SaveInstances: %v", err)
+	}
+	since := time.Now()
+	known := map[string]bool{"a": true, "b": true, "c": true}
+
+	// Meanwhile another process adds d and deletes c
+	if err := db.SaveInstances([]*InstanceRow{row("a"), row("b"), row("d")}); err != nil {
+		t.Fatalf("SaveInstances: %v", err)
+	}
+
+	// The first writer, still seeing a, b, c, deletes b
+	kept, dropped, err := db.MergeInstances([]*InstanceRow{row("a"), row("c")}, known, since)
+	if err != nil {
+		t.Fatalf("MergeInstances: %v", err)
+	}
+	if len(kept) != 1 || kept[0] != "d" {
+		t.Errorf("kept = %v, want [d]", kept)
+	}
+	if len(dropped) != 1 || dropped[0] != "c" {
+		t.Errorf("dropped = %v, want [c]", dropped)
+	}
+
+	loaded, _ := db.LoadInstances()
+	var ids []string
+	for _, r := range loaded {
+		ids = append(ids, r.ID)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "d" {
+		t.Errorf("instances = %v, want [a d]", ids)
+	}
+	tombstones, _ := db.LoadTombstones()
+	if _, ok := tombstones["b"]; !ok {
+		t.Error("deleting b should leave a tombstone")
+	}
+}
+
+func TestLockWrites(t *testing.T) {
+	db := newTestDB(t)
+	other, err := Open(db.path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer other.Close()
+
+	unlock, err := db.LockWrites()
+	if err != nil {
+		t.Fatalf("LockWrites: %v", err)
+	}
+	acquired := make(chan struct{})
+	go func() {
+		unlockOther, err := other.LockWrites()
+		if err != nil {
+			t.Errorf("second LockWrites: %v", err)
+			close(acquired)
+			return
+		}
+		close(acquired)
+		unlockOther()
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second lock acquired while the first was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("second lock not acquired after release")
+	}
+}
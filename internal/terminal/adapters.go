@@ -0,0 +1,134 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+func init() {
+	register(iterm2{})
+	register(appleTerminal{})
+	register(kitty{})
+	register(wezterm{})
+	register(alacritty{})
+}
+
+// iterm2 drives iTerm2 through AppleScript
+type iterm2 struct{}
+
+func (iterm2) Name() string { return "iterm2" }
+
+func (iterm2) WindowCommand(argv []string, title string) (*exec.Cmd, error) {
+	script := fmt.Sprintf(`tell application "iTerm2"
+	set newWindow to (create window with default profile command %s)
+	tell current session of newWindow to set name to %s
+	activate
+end tell`, AppleScriptQuote(ShellJoin(argv)), AppleScriptQuote(title))
+	return exec.Command("osascript", "-e", script), nil
+}
+
+func (iterm2) TabCommand(argv []string, title string) (*exec.Cmd, error) {
+	script := fmt.Sprintf(`tell application "iTerm2"
+	if (count of windows) = 0 then
+		set newWindow to (create window with default profile command %[1]s)
+		tell current session of newWindow to set name to %[2]s
+	else
+		tell current window
+			set newTab to (create tab with default profile command %[1]s)
+			tell current session of newTab to set name to %[2]s
+		end tell
+	end if
+	activate
+end tell`, AppleScriptQuote(ShellJoin(argv)), AppleScriptQuote(title))
+	return exec.Command("osascript", "-e", script), nil
+}
+
+// appleTerminal drives Terminal.app through AppleScript.
+// Terminal.app has no scriptable "new tab", so tabs open as windows.
+type appleTerminal struct{}
+
+func (appleTerminal) Name() string { return "apple-terminal" }
+
+func (appleTerminal) WindowCommand(argv []string, title string) (*exec.Cmd, error) {
+	script := fmt.Sprintf(`tell application "Terminal"
+	set newTab to (do script %s)
+	set custom title of newTab to %s
+	activate
+end tell`, AppleScriptQuote(ShellJoin(argv)), AppleScriptQuote(title))
+	return exec.Command("osascript", "-e", script), nil
+}
+
+func (a appleTerminal) TabCommand(argv []string, title string) (*exec.Cmd, error) {
+	return a.WindowCommand(argv, title)
+}
+
+// kitty uses the kitty binary for windows and remote control (`kitty @`) for tabs
+type kitty struct{}
+
+func (kitty) Name() string { return "kitty" }
+
+func (kitty) WindowCommand(argv []string, title string) (*exec.Cmd, error) {
+	args := []string{"--detach"}
+	if title != "" {
+		args = append(args, "--title", title)
+	}
+	return exec.Command("kitty", append(args, argv...)...), nil
+}
+
+func (k kitty) TabCommand(argv []string, title string) (*exec.Cmd, error) {
+	// Remote control only works from inside a kitty window
+	if os.Getenv("KITTY_WINDOW_ID") == "" {
+		return k.WindowCommand(argv, title)
+	}
+	args := []string{"@", "launch", "--type=tab"}
+	if title != "" {
+		args = append(args, "--tab-title", title)
+	}
+	return exec.Command("kitty", append(args, argv...)...), nil
+}
+
+// wezterm uses `wezterm cli` when running inside WezTerm, `wezterm start`
+// otherwise. Neither takes a title, so the command sets it itself.
+type wezterm struct{}
+
+func (wezterm) Name() string { return "wezterm" }
+
+func (wezterm) WindowCommand(argv []string, title string) (*exec.Cmd, error) {
+	argv = titledArgv(argv, title)
+	if os.Getenv("WEZTERM_PANE") != "" {
+		return exec.Command("wezterm", append([]string{"cli", "spawn", "--new-window", "--"}, argv...)...), nil
+	}
+	return exec.Command("wezterm", append([]string{"start", "--"}, argv...)...), nil
+}
+
+func (w wezterm) TabCommand(argv []string, title string) (*exec.Cmd, error) {
+	if os.Getenv("WEZTERM_PANE") == "" {
+		return w.WindowCommand(argv, title)
+	}
+	return exec.Command("wezterm", append([]string{"cli", "spawn", "--"}, titledArgv(argv, title)...)...), nil
+}
+
+// alacritty has no tabs; inside Alacritty `alacritty msg` reuses the running instance
+type alacritty struct{}
+
+func (alacritty) Name() string { return "alacritty" }
+
+func (alacritty) WindowCommand(argv []string, title string) (*exec.Cmd, error) {
+	if os.Getenv("ALACRITTY_SOCKET") != "" {
+		args := []string{"msg", "create-window"}
+		if title != "" {
+			args = append(args, "--title", title)
+		}
+		return exec.Command("alacritty", append(append(args, "-e"), argv...)...), nil
+	}
+	args := []string{}
+	if title != "" {
+		args = append(args, "--title", title)
+	}
+	return exec.Command("alacritty", append(append(args, "-e"), argv...)...), nil
+}
+
+func (a alacritty) TabCommand(argv []string, title string) (*exec.Cmd, error) {
+	return a.WindowCommand(argv, title)
+}
@@ -0,0 +1,136 @@
+// Package terminal opens windows and tabs in the user's terminal emulator.
+// Each supported emulator has an Adapter that knows its CLI or AppleScript API.
+package terminal
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// Adapter builds commands that drive a specific terminal emulator
+type Adapter interface {
+	// Name returns the canonical emulator name (as used in [terminal].emulator)
+	Name() string
+	// WindowCommand returns a command that opens a new window running argv
+	WindowCommand(argv []string, title string) (*exec.Cmd, error)
+	// TabCommand returns a command that opens a new tab running argv.
+	// Emulators without scriptable tabs fall back to a new window.
+	TabCommand(argv []string, title string) (*exec.Cmd, error)
+}
+
+// adapters maps canonical names to their adapters
+var adapters = map[string]Adapter{}
+
+// aliases maps alternate spellings (e.g. TERM_PROGRAM values) to canonical names
+var aliases = map[string]string{
+	"iterm.app":    "iterm2",
+	"iterm":        "iterm2",
+	"terminal":     "apple-terminal",
+	"terminal.app": "apple-terminal",
+}
+
+// register adds an adapter to the registry
+func register(a Adapter) {
+	adapters[a.Name()] = a
+}
+
+// Names returns the supported emulator names, sorted
+func Names() []string {
+	names := make([]string, 0, len(adapters))
+	for name := range adapters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the adapter for the named emulator, auto-detecting when name is empty
+func Get(name string) (Adapter, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "auto" {
+		name = tmux.DetectTerminal()
+	}
+	if canonical, ok := aliases[name]; ok {
+		name = canonical
+	}
+	if a, ok := adapters[name]; ok {
+		return a, nil
+	}
+	return nil, fmt.Errorf("terminal %q is not supported (set [terminal].emulator to one of: %s)", name, strings.Join(Names(), ", "))
+}
+
+// OpenWindow opens a new window in the emulator running argv, without waiting for it to exit
+func OpenWindow(a Adapter, argv []string, title string) error {
+	cmd, err := a.WindowCommand(argv, title)
+	if err != nil {
+		return err
+	}
+	return start(a, cmd)
+}
+
+// OpenTab opens a new tab in the emulator running argv, without waiting for it to exit
+func OpenTab(a Adapter, argv []string, title string) error {
+	cmd, err := a.TabCommand(argv, title)
+	if err != nil {
+		return err
+	}
+	return start(a, cmd)
+}
+
+// start launches cmd and reaps it in the background
+func start(a Adapter, cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to launch %s: %w", a.Name(), err)
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// titleSequence returns the OSC 2 escape sequence that sets the window
+// title, understood by every emulator with an adapter
+func titleSequence(title string) string {
+	// Strip control characters that would terminate the sequence early
+	clean := strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, title)
+	return "\033]2;" + clean + "\007"
+}
+
+// titledArgv wraps argv in a shell that sets the window title before running
+// it, for emulators whose launch commands take no title
+func titledArgv(argv []string, title string) []string {
+	if title == "" {
+		return argv
+	}
+	return append([]string{"sh", "-c", `printf '%s' "$0"; exec "$@"`, titleSequence(title)}, argv...)
+}
+
+// ShellQuote single-quotes s for a POSIX shell if it contains special characters
+func ShellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:@") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// ShellJoin quotes and joins argv into a single shell command line
+func ShellJoin(argv []string) string {
+	quoted := make([]string, len(argv))
+	for i, arg := range argv {
+		quoted[i] = ShellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// AppleScriptQuote wraps s in double quotes for use as an AppleScript string literal
+func AppleScriptQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,108 @@
+package terminal
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	for _, name := range []string{"kitty", "KITTY", "iterm.app", "Terminal.app", "wezterm", "alacritty"} {
+		if _, err := Get(name); err != nil {
+			t.Errorf("Get(%q) failed: %v", name, err)
+		}
+	}
+	if a, _ := Get("iterm.app"); a.Name() != "iterm2" {
+		t.Errorf("alias iterm.app resolved to %q", a.Name())
+	}
+	if _, err := Get("not-a-terminal"); err == nil {
+		t.Error("expected error for unsupported terminal")
+	}
+}
+
+func TestWindowAndTabCommands(t *testing.T) {
+	t.Setenv("WEZTERM_PANE", "")
+	t.Setenv("ALACRITTY_SOCKET", "")
+	t.Setenv("KITTY_WINDOW_ID", "")
+	argv := []string{"tmux", "attach-session", "-t", "agentdeck_test"}
+
+	tests := []struct {
+		name    string
+		wantBin string
+		wantArg string
+	}{
+		{"kitty", "kitty", "--title my title tmux attach-session"},
+		{"wezterm", "wezterm", "start -- sh -c"},
+		{"alacritty", "alacritty", "--title my title -e tmux"},
+		{"iterm2", "osascript", `create window with default profile command "tmux attach-session -t agentdeck_test"`},
+		{"apple-terminal", "osascript", `set custom title of newTab to "my title"`},
+	}
+	for _, tt := range tests {
+		a, err := Get(tt.name)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", tt.name, err)
+		}
+		cmd, err := a.WindowCommand(argv, "my title")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if cmd.Args[0] != tt.wantBin {
+			t.Errorf("%s: binary = %q, want %q", tt.name, cmd.Args[0], tt.wantBin)
+		}
+		if !strings.Contains(strings.Join(cmd.Args, " "), tt.wantArg) {
+			t.Errorf("%s: args %q missing %q", tt.name, cmd.Args, tt.wantArg)
+		}
+
+		// Outside the emulator, tabs fall back to something launchable
+		tab, err := a.TabCommand(argv, "my title")
+		if err != nil || tab.Args[0] != tt.wantBin {
+			t.Errorf("%s: tab command = %v, %v", tt.name, tab, err)
+		}
+	}
+}
+
+func TestKittyTabUsesRemoteControlInsideKitty(t *testing.T) {
+	t.Setenv("KITTY_WINDOW_ID", "1")
+	a, _ := Get("kitty")
+	cmd, _ := a.TabCommand([]string{"vim"}, "edit")
+	if got := strings.Join(cmd.Args, " "); got != "kitty @ launch --type=tab --tab-title edit vim" {
+		t.Errorf("kitty tab command = %q", got)
+	}
+}
+
+func TestTitledArgv(t *testing.T) {
+	if got := titleSequence("deck\a\x1b"); got != "\033]2;deck\007" {
+		t.Errorf("titleSequence = %q", got)
+	}
+	if got := titledArgv([]string{"vim"}, ""); len(got) != 1 {
+		t.Errorf("untitled argv = %q, want it unchanged", got)
+	}
+
+	// The wrapper prints the sequence, then runs argv with its arguments intact
+	argv := titledArgv([]string{"printf", "%s|", "a b", "it's"}, "edit: api")
+	out, err := exec.Command(argv[0], argv[1:]...).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(out); got != "\033]2;edit: api\007a b|it's|" {
+		t.Errorf("titled command printed %q", got)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"agent-deck":           "agent-deck",
+		"/usr/local/bin/agent": "/usr/local/bin/agent",
+		"":                     "''",
+		"/opt/agent deck/bin":  "'/opt/agent deck/bin'",
+		"/tmp/it's/agent-deck": `'/tmp/it'\''s/agent-deck'`,
+	}
+	for in, want := range tests {
+		if got := ShellQuote(in); got != want {
+			t.Errorf("ShellQuote(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := ShellJoin([]string{"tmux", "attach-session", "-t", "my session"}); got != "tmux attach-session -t 'my session'" {
+		t.Errorf("ShellJoin = %s", got)
+	}
+}
@@ -0,0 +1,211 @@
+package tmux
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ApprovalPrompt is a permission/approval prompt parsed from pane content
+type ApprovalPrompt struct {
+	// Text is the prompt as shown to the user: tool/command context plus the question
+	Text string
+
+	// Question is the question line itself (e.g. "Do you want to proceed?")
+	Question string
+
+	// Options are the numbered choices of a selection dialog (empty for y/n prompts)
+	Options []ApprovalOption
+
+	// YesNo is set for inline "(y/n)" style prompts answered by typing y or n
+	YesNo bool
+}
+
+// ApprovalOption is one numbered choice in a selection dialog
+type ApprovalOption struct {
+	Number   string // Key that selects the option ("1", "2", ...)
+	Label    string // Option text ("Yes, allow once")
+	Selected bool   // Currently highlighted (❯)
+}
+
+var (
+	// approvalQuestionPattern matches the question line of a permission dialog
+	approvalQuestionPattern = regexp.MustCompile(`(?i)^(do you want|would you like|allow |run this command\?|execute this\?|approve this plan\?|execute plan\?|do you trust the files)`)
+
+	// approvalYesNoPattern matches inline yes/no prompts
+	approvalYesNoPattern = regexp.MustCompile(`(?i)[(\[](y/n|yes/no)[)\]]`)
+
+	// approvalOptionPattern matches "❯ 1. Yes" / "  2. No, and tell Claude..."
+	approvalOptionPattern = regexp.MustCompile(`^(❯|>)?\s*(\d)\.\s+(.+)$`)
+)
+
+// approvalContextLines caps how many lines above the question are kept as context
+const approvalContextLines = 10
+
+// ParseApprovalPrompt extracts the most recent approval prompt from pane content.
+// Returns nil if the visible screen doesn't end with an unanswered prompt.
+func ParseApprovalPrompt(content string) *ApprovalPrompt {
+	lines := strings.Split(StripANSI(content), "\n")
+	for i, line := range lines {
+		lines[i] = cleanApprovalLine(line)
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	// Only the bottom of the screen matters: older prompts have been answered
+	start := len(lines) - 30
+	if start < 0 {
+		start = 0
+	}
+	q := -1
+	for i := len(lines) - 1; i >= start; i-- {
+		if approvalQuestionPattern.MatchString(lines[i]) || approvalYesNoPattern.MatchString(lines[i]) {
+			q = i
+			break
+		}
+	}
+	if q < 0 {
+		return nil
+	}
+
+	prompt := &ApprovalPrompt{Question: lines[q]}
+	for _, line := range lines[q+1:] {
+		m := approvalOptionPattern.FindStringSubmatch(line)
+		if m == nil {
+			if len(prompt.Options) > 0 {
+				break // End of the option list
+			}
+			continue
+		}
+		prompt.Options = append(prompt.Options, ApprovalOption{
+			Number:   m[2],
+			Label:    strings.TrimSpace(m[3]),
+			Selected: m[1] != "",
+		})
+	}
+	if len(prompt.Options) == 0 {
+		if !approvalYesNoPattern.MatchString(prompt.Question) {
+			return nil // Question without choices is just agent prose
+		}
+		prompt.YesNo = true
+	}
+
+	// Context: walk up to the dialog border or a blank-line gap
+	ctxStart := q
+	blanks := 0
+	for i := q - 1; i >= 0 && q-i <= approvalContextLines; i-- {
+		if isApprovalBorder(lines[i]) {
+			break
+		}
+		if lines[i] == "" {
+			blanks++
+			if blanks >= 2 {
+				break
+			}
+			continue
+		}
+		blanks = 0
+		ctxStart = i
+	}
+	var text []string
+	for _, line := range lines[ctxStart : q+1] {
+		if line == "" && (len(text) == 0 || text[len(text)-1] == "") {
+			continue
+		}
+		text = append(text, line)
+	}
+	prompt.Text = strings.Join(text, "\n")
+	return prompt
+}
+
+// cleanApprovalLine strips dialog box edges and surrounding whitespace
+func cleanApprovalLine(line string) string {
+	line = strings.TrimSpace(strings.ReplaceAll(line, "\u00A0", " "))
+	line = strings.TrimPrefix(line, "│")
+	line = strings.TrimSuffix(line, "│")
+	return strings.TrimSpace(line)
+}
+
+// isApprovalBorder reports whether line is a box edge or horizontal rule
+func isApprovalBorder(line string) bool {
+	if line == "" {
+		return false
+	}
+	return strings.Trim(line, "╭╮╰╯─━═┌┐└┘╌- ") == ""
+}
+
+// ApproveKeys returns the keystroke that approves the prompt once:
+// the first option starting with "Yes" (or "Allow"), or "y" for y/n prompts.
+func (p *ApprovalPrompt) ApproveKeys() string {
+	if p.YesNo {
+		return "y"
+	}
+	for _, opt := range p.Options {
+		label := strings.ToLower(opt.Label)
+		if strings.HasPrefix(label, "yes") || strings.HasPrefix(label, "allow") {
+			return opt.Number
+		}
+	}
+	return ""
+}
+
+// DenyKeys returns the keystroke that rejects the prompt: the first option
+// starting with "No", or "n" for y/n prompts. Empty means use Escape.
+func (p *ApprovalPrompt) DenyKeys() string {
+	if p.YesNo {
+		return "n"
+	}
+	for _, opt := range p.Options {
+		if strings.HasPrefix(strings.ToLower(opt.Label), "no") {
+			return opt.Number
+		}
+	}
+	return ""
+}
+
+// ErrApprovalChanged is returned by AnswerApproval when the prompt on screen
+// is no longer the one being answered
+var ErrApprovalChanged = errors.New("the prompt has changed since it was shown")
+
+// SamePrompt reports whether p and other are the same prompt: same question,
+// context and choices. Which option is highlighted doesn't matter.
+func (p *ApprovalPrompt) SamePrompt(other *ApprovalPrompt) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	if p.Question != other.Question || p.Text != other.Text || p.YesNo != other.YesNo ||
+		len(p.Options) != len(other.Options) {
+		return false
+	}
+	for i, opt := range p.Options {
+		if opt.Number != other.Options[i].Number || opt.Label != other.Options[i].Label {
+			return false
+		}
+	}
+	return true
+}
+
+// AnswerApproval sends an answer to a prompt shown in the session: option numbers
+// are typed as-is (selection dialogs act on the digit), y/n answers are followed
+// by Enter, and an empty answer sends Escape. The pane is captured again first,
+// and nothing is sent (ErrApprovalChanged) unless it still shows p: keys meant
+// for an answered prompt would land in the next one or in the agent's input.
+func (s *Session) AnswerApproval(p *ApprovalPrompt, keys string) error {
+	s.invalidateCache()
+	content, err := s.CapturePane()
+	if err != nil {
+		return err
+	}
+	if !p.SamePrompt(ParseApprovalPrompt(content)) {
+		return ErrApprovalChanged
+	}
+	switch {
+	case keys == "":
+		return s.SendEscape()
+	case p.YesNo:
+		return s.SendKeysAndEnter(keys)
+	default:
+		return s.SendKeys(keys)
+	}
+}
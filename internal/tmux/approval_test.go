@@ -0,0 +1,80 @@
+package tmux
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseApprovalPromptClaudeDialog(t *testing.T) {
+	content := `⏺ I'll clean the build directory first.
+
+╭───────────────────────────────────────────────╮
+│ Bash command                                  │
+│                                               │
+│   rm -rf build                                │
+│   Remove stale build output                   │
+│                                               │
+│ Do you want to proceed?                       │
+│ ❯ 1. Yes                                      │
+│   2. Yes, and don't ask again for rm commands │
+│   3. No, and tell Claude what to do differently (esc) │
+╰───────────────────────────────────────────────╯
+`
+	p := ParseApprovalPrompt(content)
+	if p == nil {
+		t.Fatal("expected a prompt")
+	}
+	if p.Question != "Do you want to proceed?" {
+		t.Errorf("Question = %q", p.Question)
+	}
+	if !strings.Contains(p.Text, "rm -rf build") || strings.Contains(p.Text, "clean the build") {
+		t.Errorf("Text should hold the dialog context only, got %q", p.Text)
+	}
+	if len(p.Options) != 3 || !p.Options[0].Selected || p.Options[1].Selected {
+		t.Fatalf("unexpected options: %+v", p.Options)
+	}
+	if got := p.ApproveKeys(); got != "1" {
+		t.Errorf("ApproveKeys = %q, want 1", got)
+	}
+	if got := p.DenyKeys(); got != "3" {
+		t.Errorf("DenyKeys = %q, want 3", got)
+	}
+}
+
+func TestParseApprovalPromptYesNo(t *testing.T) {
+	p := ParseApprovalPrompt("Applying migration 0042\nContinue with deploy? (y/n)\n")
+	if p == nil || !p.YesNo {
+		t.Fatalf("expected a y/n prompt, got %+v", p)
+	}
+	if p.ApproveKeys() != "y" || p.DenyKeys() != "n" {
+		t.Errorf("keys = %q/%q, want y/n", p.ApproveKeys(), p.DenyKeys())
+	}
+}
+
+func TestApprovalSamePrompt(t *testing.T) {
+	dialog := "Bash command\n  rm -rf build\nDo you want to proceed?\n%s 1. Yes\n%s 2. No\n"
+	p := ParseApprovalPrompt(fmt.Sprintf(dialog, "❯", " "))
+	if !p.SamePrompt(ParseApprovalPrompt(fmt.Sprintf(dialog, " ", "❯"))) {
+		t.Error("moving the highlight should keep the prompt the same")
+	}
+	other := ParseApprovalPrompt(strings.Replace(fmt.Sprintf(dialog, "❯", " "), "rm -rf build", "rm -rf /", 1))
+	if p.SamePrompt(other) {
+		t.Error("a prompt for another command should differ")
+	}
+	if p.SamePrompt(nil) {
+		t.Error("an answered prompt should differ")
+	}
+}
+
+func TestParseApprovalPromptNone(t *testing.T) {
+	for _, content := range []string{
+		"",
+		"Refactored the handler.\n\n> ",
+		"Would you like me to also update the docs?\n\n❯ ",
+	} {
+		if p := ParseApprovalPrompt(content); p != nil {
+			t.Errorf("ParseApprovalPrompt(%q) = %+v, want nil", content, p)
+		}
+	}
+}
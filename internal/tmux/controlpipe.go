@@ -2,6 +2,7 @@ package tmux
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
@@ -56,7 +57,7 @@ type commandResponse struct {
 // Blocks until the initial handshake completes (or 2s timeout), so the pipe is
 // ready for SendCommand immediately after return.
 func NewControlPipe(sessionName string) (*ControlPipe, error) {
-	cmd := exec.Command("tmux", "-C", "attach-session", "-t", sessionName)
+	cmd := tmuxCommand(context.Background(), "-C", "attach-session", "-t", sessionName)
 	// Put in own process group so we can kill the entire group on shutdown
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
@@ -237,12 +238,14 @@ func (cp *ControlPipe) SendCommand(command string) (string, error) {
 	// Wait for response with timeout
 	select {
 	case resp := <-cp.responseCh:
+		noteResponded()
 		if resp.err != nil {
 			return "", resp.err
 		}
 		return resp.output, nil
 	case <-time.After(3 * time.Second):
-		return "", fmt.Errorf("command timed out after 3s: %s", command)
+		noteTimeout(strings.SplitN(command, " ", 2)[0])
+		return "", fmt.Errorf("%w after 3s: %s", ErrTmuxTimeout, command)
 	case <-cp.done:
 		return "", fmt.Errorf("pipe closed during command: %s", command)
 	}
@@ -224,7 +224,7 @@ func TestPipeManager_OutputCallback(t *testing.T) {
 	}
 }
 
-func TestPipeManager_RefreshAllActivities(t *testing.T) {
+func TestPipeManager_RefreshAllPanes(t *testing.T) {
 	name := createTestSession(t, "pm-refresh")
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -235,13 +235,15 @@ func TestPipeManager_RefreshAllActivities(t *testing.T) {
 
 	require.NoError(t, pm.Connect(name))
 
-	activities, err := pm.RefreshAllActivities()
+	panes, err := pm.RefreshAllPanes()
 	require.NoError(t, err)
-	assert.NotEmpty(t, activities, "should return at least one session's activity")
+	assert.NotEmpty(t, panes, "should return at least one session's panes")
 
-	// Our test session should be in the results
-	_, found := activities[name]
-	assert.True(t, found, "test session %s should appear in activities", name)
+	// Our test session should be in the results, with its pane details
+	info, found := panes[name]
+	assert.True(t, found, "test session %s should appear in panes", name)
+	assert.NotZero(t, info.PID, "pane PID should be parsed")
+	assert.NotEmpty(t, info.Path, "pane path should be parsed")
 }
 
 func TestPipeManager_ConnectIdempotent(t *testing.T) {
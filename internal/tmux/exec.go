@@ -0,0 +1,232 @@
+package tmux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrTmuxTimeout is returned when a tmux command doesn't finish in time,
+// usually because the tmux server is hung or the machine is overloaded.
+// Callers should keep their previous state rather than treating the session
+// as gone.
+var ErrTmuxTimeout = errors.New("tmux command timed out")
+
+// CommandTimeout bounds every non-interactive tmux command
+const CommandTimeout = 5 * time.Second
+
+// commandWaitDelay is how long to wait for output pipes after a timed-out
+// tmux process is killed (children may hold them open)
+const commandWaitDelay = 500 * time.Millisecond
+
+// Server health, as Unix nanoseconds of the last timed-out and last completed
+// command (a command that exits non-zero still shows the server responded)
+var (
+	lastTimeoutAt  atomic.Int64
+	lastRespondAt  atomic.Int64
+	unresponsiveOn atomic.Bool // logged once per episode
+)
+
+// Unresponsive reports whether the most recent tmux command timed out and
+// none has completed since. The TUI shows this as "tmux unresponsive".
+func Unresponsive() bool {
+	return lastTimeoutAt.Load() > lastRespondAt.Load()
+}
+
+// noteTimeout records a timed-out command
+func noteTimeout(what string) {
+	lastTimeoutAt.Store(time.Now().UnixNano())
+	if unresponsiveOn.CompareAndSwap(false, true) {
+		statusLog.Warn("tmux_unresponsive", slog.String("command", what))
+	}
+}
+
+// noteResponded records a command that completed, timed out or not
+func noteResponded() {
+	lastRespondAt.Store(time.Now().UnixNano())
+	if unresponsiveOn.CompareAndSwap(true, false) {
+		statusLog.Info("tmux_responsive_again")
+	}
+}
+
+// socketName is the tmux server (-L) that new sessions are created on and
+// that server-wide commands (session cache, status bar, key bindings) talk
+// to. Empty means the user's default server.
+var (
+	socketMu   sync.RWMutex
+	socketName string
+)
+
+// SetSocketName selects the tmux server for this process, from [tmux]
+// socket_name. Call it once at startup, before any session is created.
+func SetSocketName(name string) {
+	socketMu.Lock()
+	socketName = name
+	socketMu.Unlock()
+}
+
+// SocketName returns the tmux server selected with SetSocketName
+func SocketName() string {
+	socketMu.RLock()
+	defer socketMu.RUnlock()
+	return socketName
+}
+
+// ValidateSocketName checks a tmux -L socket name: a plain file name in
+// tmux's socket directory
+func ValidateSocketName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/ \t\n") {
+		return fmt.Errorf("invalid tmux socket name %q: use a plain name like agentdeck", name)
+	}
+	return nil
+}
+
+// socketArgs prefixes args with -L for a named tmux server
+func socketArgs(socket string, args []string) []string {
+	if socket == "" {
+		return args
+	}
+	return append([]string{"-L", socket}, args...)
+}
+
+// tmuxCommand builds a tmux command bound to ctx. Use it directly only for
+// long-running commands (attach, control mode, pipe-pane); everything else
+// goes through tmuxRun/tmuxOutput/tmuxCombinedOutput, which add a timeout.
+func tmuxCommand(ctx context.Context, args ...string) *exec.Cmd {
+	return tmuxCommandOn(ctx, SocketName(), args...)
+}
+
+// tmuxCommandOn is tmuxCommand for a specific tmux server
+func tmuxCommandOn(ctx context.Context, socket string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "tmux", socketArgs(socket, args)...)
+}
+
+// runBounded runs a tmux command on socket with a timeout using run (Run,
+// Output or CombinedOutput), translating a deadline into ErrTmuxTimeout
+func runBounded(timeout time.Duration, socket string, args []string, run func(*exec.Cmd) ([]byte, error)) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := tmuxCommandOn(ctx, socket, args...)
+	cmd.WaitDelay = commandWaitDelay
+	out, err := run(cmd)
+	if ctx.Err() == context.DeadlineExceeded {
+		what := "tmux"
+		if len(args) > 0 {
+			what = args[0]
+		}
+		noteTimeout(what)
+		return out, fmt.Errorf("%w: %s after %v", ErrTmuxTimeout, what, timeout)
+	}
+	noteResponded()
+	return out, err
+}
+
+// tmuxRun runs a tmux command, discarding its output
+func tmuxRun(args ...string) error {
+	_, err := runBounded(CommandTimeout, SocketName(), args, func(cmd *exec.Cmd) ([]byte, error) {
+		return nil, cmd.Run()
+	})
+	return err
+}
+
+// tmuxOutput runs a tmux command and returns its stdout
+func tmuxOutput(args ...string) ([]byte, error) {
+	return tmuxOutputTimeout(CommandTimeout, args...)
+}
+
+// tmuxOutputTimeout is tmuxOutput with a custom timeout, for hot paths that
+// should give up sooner
+func tmuxOutputTimeout(timeout time.Duration, args ...string) ([]byte, error) {
+	return runBounded(timeout, SocketName(), args, func(cmd *exec.Cmd) ([]byte, error) {
+		return cmd.Output()
+	})
+}
+
+// tmuxCombinedOutput runs a tmux command and returns stdout and stderr
+func tmuxCombinedOutput(args ...string) ([]byte, error) {
+	return runBounded(CommandTimeout, SocketName(), args, func(cmd *exec.Cmd) ([]byte, error) {
+		return cmd.CombinedOutput()
+	})
+}
+
+// Run runs a tmux command with the standard timeout (for callers outside
+// this package)
+func Run(args ...string) error {
+	return tmuxRun(args...)
+}
+
+// Output runs a tmux command with the standard timeout and returns its stdout
+// (for callers outside this package)
+func Output(args ...string) ([]byte, error) {
+	return tmuxOutput(args...)
+}
+
+// CurrentOutput is Output against the server of the tmux client we're
+// running in ($TMUX) rather than SocketName(), for "which session am I in"
+// queries from inside a pane
+func CurrentOutput(args ...string) ([]byte, error) {
+	return runBounded(CommandTimeout, "", args, func(cmd *exec.Cmd) ([]byte, error) {
+		return cmd.Output()
+	})
+}
+
+// The same helpers on the server a session lives on (see Session.SocketName)
+
+func (s *Session) tmuxCommand(ctx context.Context, args ...string) *exec.Cmd {
+	return tmuxCommandOn(ctx, s.SocketName, args...)
+}
+
+func (s *Session) tmuxRun(args ...string) error {
+	_, err := runBounded(CommandTimeout, s.SocketName, args, func(cmd *exec.Cmd) ([]byte, error) {
+		return nil, cmd.Run()
+	})
+	return err
+}
+
+func (s *Session) tmuxOutput(args ...string) ([]byte, error) {
+	return s.tmuxOutputTimeout(CommandTimeout, args...)
+}
+
+func (s *Session) tmuxOutputTimeout(timeout time.Duration, args ...string) ([]byte, error) {
+	return runBounded(timeout, s.SocketName, args, func(cmd *exec.Cmd) ([]byte, error) {
+		return cmd.Output()
+	})
+}
+
+func (s *Session) tmuxCombinedOutput(args ...string) ([]byte, error) {
+	return runBounded(CommandTimeout, s.SocketName, args, func(cmd *exec.Cmd) ([]byte, error) {
+		return cmd.CombinedOutput()
+	})
+}
+
+// Run runs a tmux command on the session's server with the standard timeout
+// (for callers outside this package)
+func (s *Session) Run(args ...string) error {
+	return s.tmuxRun(args...)
+}
+
+// Output is Run that returns the command's stdout
+func (s *Session) Output(args ...string) ([]byte, error) {
+	return s.tmuxOutput(args...)
+}
+
+// OnDeckServer reports whether the session lives on the server this process
+// talks to by default (SocketName()), which the session cache and control
+// pipes cover. Sessions pinned elsewhere are queried directly.
+func (s *Session) OnDeckServer() bool {
+	return s.SocketName == SocketName()
+}
+
+// pipeManager returns the control pipe manager if it covers this session
+func (s *Session) pipeManager() *PipeManager {
+	if !s.OnDeckServer() {
+		return nil
+	}
+	return GetPipeManager()
+}
@@ -0,0 +1,37 @@
+package tmux
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunBoundedTimeout(t *testing.T) {
+	t.Cleanup(noteResponded)
+
+	_, err := tmuxOutputTimeout(time.Nanosecond, "-V")
+	if !errors.Is(err, ErrTmuxTimeout) {
+		t.Fatalf("err = %v, want ErrTmuxTimeout", err)
+	}
+	if !Unresponsive() {
+		t.Error("a timed-out command should mark tmux unresponsive")
+	}
+
+	noteResponded()
+	if Unresponsive() {
+		t.Error("a completed command should clear the unresponsive state")
+	}
+}
+
+func TestValidateSocketName(t *testing.T) {
+	for _, name := range []string{"agentdeck", "agentdeck-work", "deck_2"} {
+		if err := ValidateSocketName(name); err != nil {
+			t.Errorf("%q: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "..", "a/b", "my deck"} {
+		if err := ValidateSocketName(name); err == nil {
+			t.Errorf("%q should be rejected", name)
+		}
+	}
+}
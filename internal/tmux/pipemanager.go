@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
-	"os/exec"
 	"strings"
 	"sync"
 	"time"
@@ -162,10 +161,10 @@ func (pm *PipeManager) GetWindowActivity(sessionName string) (int64, error) {
 	return ts, nil
 }
 
-// RefreshAllActivities sends a single list-windows command through any available
-// pipe to get activity timestamps for ALL sessions. This replaces the subprocess
-// call in RefreshSessionCache.
-func (pm *PipeManager) RefreshAllActivities() (map[string]int64, error) {
+// RefreshAllPanes sends a single list-panes command through any available
+// pipe to get activity timestamps and pane details for ALL sessions. This
+// replaces the subprocess call in RefreshSessionCache.
+func (pm *PipeManager) RefreshAllPanes() (map[string]PaneInfo, error) {
 	pm.mu.RLock()
 	// Find any alive pipe to send the command through
 	var pipe *ControlPipe
@@ -182,29 +181,12 @@ func (pm *PipeManager) RefreshAllActivities() (map[string]int64, error) {
 	}
 
 	// tmux control mode requires double-quoted format strings containing special chars
-	output, err := pipe.SendCommand(`list-windows -a -F "#{session_name}\t#{window_activity}"`)
+	format := strings.ReplaceAll(paneListFormat, "\t", `\t`)
+	output, err := pipe.SendCommand(`list-panes -a -F "` + format + `"`)
 	if err != nil {
-		return nil, fmt.Errorf("list-windows via pipe: %w", err)
+		return nil, fmt.Errorf("list-panes via pipe: %w", err)
 	}
-
-	result := make(map[string]int64)
-	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 2)
-		if len(parts) != 2 {
-			continue
-		}
-		name := parts[0]
-		var activity int64
-		_, _ = fmt.Sscanf(parts[1], "%d", &activity)
-		// Keep maximum activity if session has multiple windows
-		if existing, ok := result[name]; !ok || activity > existing {
-			result[name] = activity
-		}
-	}
-	return result, nil
+	return parsePaneList(output), nil
 }
 
 // LastOutputTime returns the last output time for a session from its pipe.
@@ -352,8 +334,7 @@ func (pm *PipeManager) watchPipe(sessionName string, pipe *ControlPipe) {
 
 // tmuxSessionExists checks if a tmux session exists (lightweight subprocess).
 func tmuxSessionExists(name string) bool {
-	cmd := exec.Command("tmux", "has-session", "-t", name)
-	return cmd.Run() == nil
+	return tmuxRun("has-session", "-t", name) == nil
 }
 
 // --- Global singleton ---
@@ -30,7 +30,7 @@ func (s *Session) Attach(ctx context.Context) error {
 	defer cancel()
 
 	// Start tmux attach command with PTY
-	cmd := exec.CommandContext(ctx, "tmux", "attach-session", "-t", s.Name)
+	cmd := s.tmuxCommand(ctx, "attach-session", "-t", s.Name)
 
 	// Start command with PTY
 	ptmx, err := pty.Start(cmd)
@@ -184,8 +184,7 @@ func (s *Session) Attach(ctx context.Context) error {
 // Resize changes the terminal size of the tmux session
 func (s *Session) Resize(cols, rows int) error {
 	// Resize the tmux window
-	cmd := exec.Command("tmux", "resize-window", "-t", s.Name, "-x", fmt.Sprintf("%d", cols), "-y", fmt.Sprintf("%d", rows))
-	if err := cmd.Run(); err != nil {
+	if err := s.tmuxRun("resize-window", "-t", s.Name, "-x", fmt.Sprintf("%d", cols), "-y", fmt.Sprintf("%d", rows)); err != nil {
 		return fmt.Errorf("failed to resize window: %w", err)
 	}
 	return nil
@@ -205,7 +204,7 @@ func (s *Session) AttachReadOnly(ctx context.Context) error {
 	defer func() { _ = term.Restore(int(os.Stdin.Fd()), oldState) }()
 
 	// Start tmux attach command in read-only mode
-	cmd := exec.CommandContext(ctx, "tmux", "attach-session", "-r", "-t", s.Name)
+	cmd := s.tmuxCommand(ctx, "attach-session", "-r", "-t", s.Name)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -236,7 +235,7 @@ func (s *Session) StreamOutput(ctx context.Context, w io.Writer) error {
 	}
 
 	// Use tmux pipe-pane to stream output
-	cmd := exec.CommandContext(ctx, "tmux", "pipe-pane", "-t", s.Name, "-o", "cat")
+	cmd := s.tmuxCommand(ctx, "pipe-pane", "-t", s.Name, "-o", "cat")
 	cmd.Stdout = w
 	cmd.Stderr = os.Stderr
 
@@ -258,8 +257,7 @@ func (s *Session) StreamOutput(ctx context.Context, w io.Writer) error {
 	case <-ctx.Done():
 		// Stop pipe-pane - error is intentionally ignored since we're
 		// already returning ctx.Err() and cleanup failure is non-fatal
-		stopCmd := exec.Command("tmux", "pipe-pane", "-t", s.Name)
-		_ = stopCmd.Run()
+		_ = s.tmuxRun("pipe-pane", "-t", s.Name)
 		// Wait for the goroutine to complete before returning
 		wg.Wait()
 		return ctx.Err()
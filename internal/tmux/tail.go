@@ -0,0 +1,113 @@
+package tmux
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// parseOutputLine extracts the decoded payload from a control mode `%output %<pane> <data>` line.
+// Returns ok=false for any other control mode line.
+func parseOutputLine(line string) ([]byte, bool) {
+	if !strings.HasPrefix(line, "%output ") {
+		return nil, false
+	}
+	rest := strings.TrimPrefix(line, "%output ")
+	// Skip the pane ID (e.g. "%3")
+	idx := strings.IndexByte(rest, ' ')
+	if idx < 0 {
+		return []byte{}, true
+	}
+	return unescapeControlOutput(rest[idx+1:]), true
+}
+
+// unescapeControlOutput decodes tmux control mode escaping: characters below
+// ASCII 32 and backslash are sent as a backslash followed by three octal digits.
+func unescapeControlOutput(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) && isOctal(s[i+1]) && isOctal(s[i+2]) && isOctal(s[i+3]) {
+			out = append(out, (s[i+1]-'0')<<6|(s[i+2]-'0')<<3|(s[i+3]-'0'))
+			i += 3
+			continue
+		}
+		out = append(out, s[i])
+	}
+	return out
+}
+
+func isOctal(c byte) bool {
+	return c >= '0' && c <= '7'
+}
+
+// TailOptions controls Tail output
+type TailOptions struct {
+	// Lines of existing scrollback to print before following (0 = none)
+	Lines int
+	// Plain strips ANSI escape sequences from the live stream
+	Plain bool
+}
+
+// Tail writes the last opts.Lines lines of the pane, then streams new output to w
+// in real time until ctx is cancelled or the session ends. It uses a read-only
+// control mode client, so it can never send input to the session.
+func (s *Session) Tail(ctx context.Context, w io.Writer, opts TailOptions) error {
+	if !s.Exists() {
+		return fmt.Errorf("session %s does not exist", s.Name)
+	}
+
+	if opts.Lines > 0 {
+		output, err := s.tmuxOutput("capture-pane", "-t", s.Name, "-p", "-J", "-S", fmt.Sprintf("-%d", opts.Lines))
+		if err != nil {
+			return fmt.Errorf("failed to capture pane: %w", err)
+		}
+		history := strings.TrimRight(string(output), "\n ")
+		if lines := strings.Split(history, "\n"); len(lines) > opts.Lines {
+			history = strings.Join(lines[len(lines)-opts.Lines:], "\n")
+		}
+		if history != "" {
+			fmt.Fprintln(w, history)
+		}
+	}
+
+	cmd := s.tmuxCommand(ctx, "-C", "attach-session", "-r", "-t", s.Name)
+	// Control mode exits when stdin closes; keep it open for the lifetime of the tail
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("stdin pipe: %w", err)
+	}
+	defer stdin.Close()
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start tmux -C: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 2*1024*1024), 2*1024*1024)
+	for scanner.Scan() {
+		data, ok := parseOutputLine(scanner.Text())
+		if !ok {
+			if strings.HasPrefix(scanner.Text(), "%exit") {
+				break
+			}
+			continue
+		}
+		if opts.Plain {
+			data = []byte(StripANSI(string(data)))
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	_ = cmd.Wait()
+	if ctx.Err() != nil {
+		return nil // Cancelled by caller (e.g. Ctrl+C)
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,29 @@
+package tmux
+
+import "testing"
+
+func TestParseOutputLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   string
+		wantOK bool
+	}{
+		{`%output %1 hello`, "hello", true},
+		{`%output %12 line\015\012`, "line\r\n", true},
+		{`%output %1 back\134slash`, `back\slash`, true},
+		{`%output %1 \033[32mgreen`, "\033[32mgreen", true},
+		{`%output %1 not\9octal`, `not\9octal`, true},
+		{`%begin 1 2 0`, "", false},
+		{`%exit`, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := parseOutputLine(tt.line)
+		if ok != tt.wantOK {
+			t.Errorf("parseOutputLine(%q) ok = %v, want %v", tt.line, ok, tt.wantOK)
+			continue
+		}
+		if ok && string(got) != tt.want {
+			t.Errorf("parseOutputLine(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
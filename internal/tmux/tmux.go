@@ -1,7 +1,6 @@
 package tmux
 
 import (
-	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -12,11 +11,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
+	"unicode"
 
 	"github.com/asheshgoplani/agent-deck/internal/logging"
 	"golang.org/x/sync/singleflight"
@@ -33,17 +34,70 @@ var ErrCaptureTimeout = errors.New("capture-pane timed out")
 const SessionPrefix = "agentdeck_"
 
 // Session cache - reduces subprocess spawns from O(n) to O(1) per tick
-// Instead of calling `tmux has-session` and `tmux display-message` for each session,
-// we call `tmux list-sessions` ONCE and cache both existence and activity timestamps
+// Instead of calling `tmux has-session`, `tmux display-message` etc. for each
+// session, we call `tmux list-panes -a` ONCE and cache existence, activity
+// timestamps and pane details for every session
 var (
 	sessionCacheMu   sync.RWMutex
-	sessionCacheData map[string]int64 // session_name -> activity_timestamp (0 if not in cache)
+	sessionCacheData map[string]PaneInfo // session_name -> pane info (missing = session doesn't exist)
 	sessionCacheTime time.Time
+	sessionCacheTTL  = 2 * time.Second
 )
 
-// RefreshSessionCache updates the cache of existing tmux sessions and their activity
-// Call this ONCE per tick, then use Session.Exists() and Session.GetWindowActivity()
-// which read from cache. This reduces 30+ subprocess spawns to just 1 per tick cycle.
+// PaneInfo is what the batched pane query reports about one session: its
+// first pane, and the most recent activity across all of its windows
+type PaneInfo struct {
+	Activity int64  // window_activity (Unix seconds)
+	PID      int    // pane_pid
+	Dead     bool   // pane_dead: the pane's process exited (remain-on-exit)
+	Command  string // pane_current_command
+	Title    string // pane_title, as set by the running program (tmux rejects control characters in it)
+	Path     string // pane_current_path
+}
+
+// paneListFormat is the -F format of the batched query. The path goes last
+// so a path containing a tab can't shift the other fields.
+const paneListFormat = "#{session_name}\t#{window_activity}\t#{pane_pid}\t#{pane_dead}\t#{pane_current_command}\t#{pane_title}\t#{pane_current_path}"
+
+// parsePaneList parses `list-panes -a -F paneListFormat` output into per-session info
+func parsePaneList(output string) map[string]PaneInfo {
+	result := make(map[string]PaneInfo)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "\t", 7)
+		if len(parts) < 2 {
+			continue
+		}
+		name := parts[0]
+		var activity int64
+		_, _ = fmt.Sscanf(parts[1], "%d", &activity) // ignore error, 0 is valid default
+
+		existing, seen := result[name]
+		if !seen {
+			// First pane of the session: take its details
+			if len(parts) == 7 {
+				existing.PID, _ = strconv.Atoi(parts[2])
+				existing.Dead = parts[3] == "1"
+				existing.Command = parts[4]
+				existing.Title = parts[5]
+				existing.Path = parts[6]
+			}
+		}
+		// Keep maximum activity (most recent) if session has multiple windows
+		if !seen || activity > existing.Activity {
+			existing.Activity = activity
+		}
+		result[name] = existing
+	}
+	return result
+}
+
+// RefreshSessionCache updates the cache of existing tmux sessions, their activity
+// and pane details. Call this ONCE per tick, then use Session.Exists(),
+// Session.GetWindowActivity() and Session.GetWorkDir() which read from cache.
+// This reduces 30+ subprocess spawns to just 1 per tick cycle.
 //
 // Tries PipeManager first (zero subprocess), falls back to subprocess.
 //
@@ -53,20 +107,28 @@ var (
 func RefreshSessionCache() {
 	// Try control mode pipe first (zero subprocess)
 	if pm := GetPipeManager(); pm != nil {
-		if activities, err := pm.RefreshAllActivities(); err == nil && len(activities) > 0 {
+		panes, err := pm.RefreshAllPanes()
+		if err == nil && len(panes) > 0 {
 			sessionCacheMu.Lock()
-			sessionCacheData = activities
+			sessionCacheData = panes
 			sessionCacheTime = time.Now()
 			sessionCacheMu.Unlock()
 			return
 		}
+		if errors.Is(err, ErrTmuxTimeout) {
+			keepStaleSessionCache()
+			return
+		}
 		// Pipe failed: log it so we can verify zero subprocess usage
 		statusLog.Debug("refresh_cache_subprocess_fallback")
 	}
 
-	// Subprocess fallback: list-windows -a
-	cmd := exec.Command("tmux", "list-windows", "-a", "-F", "#{session_name}\t#{window_activity}")
-	output, err := cmd.Output()
+	// Subprocess fallback: list-panes -a
+	output, err := tmuxOutput("list-panes", "-a", "-F", paneListFormat)
+	if errors.Is(err, ErrTmuxTimeout) {
+		keepStaleSessionCache()
+		return
+	}
 	if err != nil {
 		sessionCacheMu.Lock()
 		sessionCacheData = nil
@@ -75,48 +137,60 @@ func RefreshSessionCache() {
 		return
 	}
 
-	newCache := make(map[string]int64)
-	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 2)
-		if len(parts) != 2 {
-			continue
-		}
-		name := parts[0]
-		var activity int64
-		_, _ = fmt.Sscanf(parts[1], "%d", &activity) // ignore error, 0 is valid default
-		// Keep maximum activity (most recent) if session has multiple windows
-		if existing, ok := newCache[name]; !ok || activity > existing {
-			newCache[name] = activity
-		}
-	}
-
+	newCache := parsePaneList(string(output))
 	sessionCacheMu.Lock()
 	sessionCacheData = newCache
 	sessionCacheTime = time.Now()
 	sessionCacheMu.Unlock()
 }
 
+// keepStaleSessionCache extends the current cache when tmux is hung, so
+// reads keep serving the last known state instead of falling back to
+// per-session calls that would each time out too
+func keepStaleSessionCache() {
+	sessionCacheMu.Lock()
+	if sessionCacheData != nil {
+		sessionCacheTime = time.Now()
+	}
+	sessionCacheMu.Unlock()
+}
+
 // RefreshExistingSessions is an alias for RefreshSessionCache for backwards compatibility
 func RefreshExistingSessions() {
 	RefreshSessionCache()
 }
 
+// SetSessionCacheTTL matches the cache lifetime to how often the caller
+// refreshes it, so reads between ticks don't fall back to per-session
+// subprocesses. The cache stays valid for 1.5 intervals, and at least 2s.
+func SetSessionCacheTTL(refreshInterval time.Duration) {
+	ttl := refreshInterval + refreshInterval/2
+	if ttl < 2*time.Second {
+		ttl = 2 * time.Second
+	}
+	sessionCacheMu.Lock()
+	sessionCacheTTL = ttl
+	sessionCacheMu.Unlock()
+}
+
+// paneInfoFromCache returns the cached info for a session
+// Returns (info, exists, cacheValid) - if cache is stale/empty, cacheValid is false
+// Callers must hold sessionCacheMu for reading.
+func paneInfoFromCache(name string) (PaneInfo, bool, bool) {
+	if sessionCacheData == nil || time.Since(sessionCacheTime) > sessionCacheTTL {
+		return PaneInfo{}, false, false // Cache invalid
+	}
+	info, exists := sessionCacheData[name]
+	return info, exists, true
+}
+
 // sessionExistsFromCache checks if a session exists using the cached data
 // Returns (exists, cacheValid) - if cache is stale/empty, cacheValid is false
 func sessionExistsFromCache(name string) (bool, bool) {
 	sessionCacheMu.RLock()
 	defer sessionCacheMu.RUnlock()
-
-	// Cache is valid for 2 seconds (4 ticks at 500ms)
-	if sessionCacheData == nil || time.Since(sessionCacheTime) > 2*time.Second {
-		return false, false // Cache invalid
-	}
-
-	_, exists := sessionCacheData[name]
-	return exists, true
+	_, exists, valid := paneInfoFromCache(name)
+	return exists, valid
 }
 
 // registerSessionInCache adds a newly created session to the cache
@@ -128,11 +202,11 @@ func registerSessionInCache(name string) {
 
 	// Initialize cache if nil
 	if sessionCacheData == nil {
-		sessionCacheData = make(map[string]int64)
+		sessionCacheData = make(map[string]PaneInfo)
 	}
 
 	// Add session with current time as activity
-	sessionCacheData[name] = time.Now().Unix()
+	sessionCacheData[name] = PaneInfo{Activity: time.Now().Unix()}
 }
 
 // sessionActivityFromCache gets session activity timestamp from cache
@@ -140,24 +214,30 @@ func registerSessionInCache(name string) {
 func sessionActivityFromCache(name string) (int64, bool) {
 	sessionCacheMu.RLock()
 	defer sessionCacheMu.RUnlock()
-
-	// Cache is valid for 2 seconds (4 ticks at 500ms)
-	if sessionCacheData == nil || time.Since(sessionCacheTime) > 2*time.Second {
-		return 0, false // Cache invalid
+	info, exists, valid := paneInfoFromCache(name)
+	if !valid || !exists {
+		return 0, false // Cache invalid, or session not in cache (doesn't exist)
 	}
+	return info.Activity, true
+}
 
-	activity, exists := sessionCacheData[name]
-	if !exists {
-		return 0, false // Session not in cache (doesn't exist)
+// CachedPaneInfo returns the session's pane details from the last batched
+// query, without spawning a subprocess. ok is false if the cache is stale or
+// the session wasn't found.
+func (s *Session) CachedPaneInfo() (info PaneInfo, ok bool) {
+	if !s.OnDeckServer() {
+		return PaneInfo{}, false
 	}
-	return activity, true
+	sessionCacheMu.RLock()
+	defer sessionCacheMu.RUnlock()
+	info, exists, valid := paneInfoFromCache(s.Name)
+	return info, exists && valid
 }
 
 // IsTmuxAvailable checks if tmux is installed and accessible
 // Returns nil if tmux is available, otherwise returns an error with details
 func IsTmuxAvailable() error {
-	cmd := exec.Command("tmux", "-V")
-	output, err := cmd.CombinedOutput()
+	output, err := tmuxCombinedOutput("-V")
 	if err != nil {
 		return fmt.Errorf("tmux not found or not working: %w (output: %s)", err, string(output))
 	}
@@ -431,6 +511,10 @@ type Session struct {
 	Created     time.Time
 	InstanceID  string // Agent-deck instance ID for hook callbacks
 
+	// SocketName is the tmux server (-L) the session lives on, "" for the
+	// user's default server. New sessions are pinned to SocketName().
+	SocketName string
+
 	// mu protects all mutable fields below from concurrent access
 	mu sync.Mutex
 
@@ -467,6 +551,20 @@ type Session struct {
 	// Example: {"allow-passthrough": "all", "history-limit": "50000"}
 	OptionOverrides map[string]string
 
+	// ExtraPanes are commands for panes split off the session's pane in
+	// Start(), in WorkDir ("" = just a shell). The first pane keeps focus, so
+	// status detection still reads the agent.
+	ExtraPanes []string
+
+	// Layout is the tmux layout applied after the extra panes (e.g.
+	// "main-vertical"); empty keeps tmux's default split
+	Layout string
+
+	// Env is set in the session's environment when Start creates it and when
+	// RespawnPane restarts it (tmux -e), so the panes inherit it without the
+	// values appearing on a command line or in the scrollback
+	Env map[string]string
+
 	// Custom patterns for generic tool support
 	customToolName       string
 	customBusyPatterns   []string
@@ -576,6 +674,7 @@ func NewSession(name, workDir string) *Session {
 		Name:             SessionPrefix + sanitized + "_" + uniqueSuffix,
 		DisplayName:      name,
 		WorkDir:          workDir,
+		SocketName:       SocketName(),
 		Created:          time.Now(),
 		lastStableStatus: "waiting",
 		toolDetectExpiry: 30 * time.Second, // Re-detect tool every 30 seconds
@@ -734,8 +833,7 @@ func generateShortID() string {
 
 // SetEnvironment sets an environment variable for this tmux session
 func (s *Session) SetEnvironment(key, value string) error {
-	cmd := exec.Command("tmux", "set-environment", "-t", s.Name, key, value)
-	err := cmd.Run()
+	err := s.tmuxRun("set-environment", "-t", s.Name, key, value)
 	if err == nil {
 		// Invalidate cache entry so next GetEnvironment sees the new value
 		s.envCacheMu.Lock()
@@ -761,8 +859,7 @@ func (s *Session) GetEnvironment(key string) (string, error) {
 	}
 	s.envCacheMu.RUnlock()
 
-	cmd := exec.Command("tmux", "show-environment", "-t", s.Name, key)
-	output, err := cmd.Output()
+	output, err := s.tmuxOutput("show-environment", "-t", s.Name, key)
 	if err != nil {
 		return "", fmt.Errorf("variable not found or session doesn't exist: %s", key)
 	}
@@ -817,20 +914,22 @@ func (s *Session) Start(command string) error {
 	}
 
 	// Create new tmux session in detached mode
-	cmd := exec.Command("tmux", "new-session", "-d", "-s", s.Name, "-c", workDir)
-	output, err := cmd.CombinedOutput()
+	args := append([]string{"new-session", "-d", "-s", s.Name, "-c", workDir}, envArgs(s.Env)...)
+	output, err := s.tmuxCombinedOutput(args...)
 	if err != nil {
 		return fmt.Errorf("failed to create tmux session: %w (output: %s)", err, string(output))
 	}
 
 	// Register session in cache immediately to prevent race condition
 	// where Exists() returns false because cache was refreshed before session creation
-	registerSessionInCache(s.Name)
+	if s.OnDeckServer() {
+		registerSessionInCache(s.Name)
+	}
 
 	// Set default window/pane styles to prevent color issues in some terminals (Warp, etc.)
 	// This ensures no unexpected background colors are applied
-	_ = exec.Command("tmux", "set-option", "-t", s.Name, "window-style", "default").Run()
-	_ = exec.Command("tmux", "set-option", "-t", s.Name, "window-active-style", "default").Run()
+	_ = s.tmuxRun("set-option", "-t", s.Name, "window-style", "default")
+	_ = s.tmuxRun("set-option", "-t", s.Name, "window-active-style", "default")
 
 	// Enable mouse mode for proper scrolling (per-session, doesn't affect user's other sessions)
 	// This allows:
@@ -839,7 +938,7 @@ func (s *Session) Start(command string) error {
 	// - Pane resizing with mouse
 	// Non-fatal: session still works, just without mouse support
 	// This can fail on very old tmux versions
-	_ = exec.Command("tmux", "set-option", "-t", s.Name, "mouse", "on").Run()
+	_ = s.tmuxRun("set-option", "-t", s.Name, "mouse", "on")
 
 	// Enable escape sequence passthrough for modern terminal features (tmux 3.2+)
 	// This allows:
@@ -847,32 +946,32 @@ func (s *Session) Start(command string) error {
 	// - OSC 52: Clipboard integration (copy/paste from remote sessions)
 	// - Image protocols: Inline images in terminals that support it
 	// Uses -q flag to silently ignore on older tmux versions (< 3.2)
-	_ = exec.Command("tmux", "set-option", "-t", s.Name, "-q", "allow-passthrough", "on").Run()
+	_ = s.tmuxRun("set-option", "-t", s.Name, "-q", "allow-passthrough", "on")
 
 	// Enable hyperlink support in terminal features (tmux 3.4+, server-wide option)
 	// This tells tmux to track hyperlinks like it tracks colors/attributes
 	// Required for OSC 8 hyperlinks to work - passthrough alone isn't enough
 	// Uses -as to append to existing terminal-features, -q to ignore if unsupported
-	_ = exec.Command("tmux", "set", "-asq", "terminal-features", ",*:hyperlinks").Run()
+	_ = s.tmuxRun("set", "-asq", "terminal-features", ",*:hyperlinks")
 
 	// Enable OSC 52 clipboard integration for seamless copy/paste
 	// Works with: Warp, iTerm2, kitty, Alacritty, WezTerm, Windows Terminal, VS Code
 	// The 'on' value (tmux 2.6+) allows apps inside tmux to set the clipboard
-	_ = exec.Command("tmux", "set-option", "-t", s.Name, "set-clipboard", "on").Run()
+	_ = s.tmuxRun("set-option", "-t", s.Name, "set-clipboard", "on")
 
 	// Set large history buffer for AI agent sessions (default is 2000)
 	// AI agents produce extensive output, 10000 lines is a good balance
-	_ = exec.Command("tmux", "set-option", "-t", s.Name, "history-limit", "10000").Run()
+	_ = s.tmuxRun("set-option", "-t", s.Name, "history-limit", "10000")
 
 	// Reduce escape-time for responsive Vim/editor usage (default 500ms is too slow)
 	// 10ms is a good balance between responsiveness and SSH reliability
-	_ = exec.Command("tmux", "set-option", "-t", s.Name, "escape-time", "10").Run()
+	_ = s.tmuxRun("set-option", "-t", s.Name, "escape-time", "10")
 
 	// Apply user-specified tmux option overrides from config (after defaults)
 	// This allows users to override any default, e.g. allow-passthrough = "all"
 	if len(s.OptionOverrides) > 0 {
 		for key, value := range s.OptionOverrides {
-			_ = exec.Command("tmux", "set-option", "-t", s.Name, "-q", key, value).Run()
+			_ = s.tmuxRun("set-option", "-t", s.Name, "-q", key, value)
 		}
 	}
 
@@ -896,8 +995,10 @@ func (s *Session) Start(command string) error {
 		}
 	}
 
+	s.startExtraPanes(workDir)
+
 	// Connect control mode pipe for event-driven status detection
-	if pm := GetPipeManager(); pm != nil {
+	if pm := s.pipeManager(); pm != nil {
 		if err := pm.Connect(s.Name); err != nil {
 			statusLog.Debug("control_pipe_connect_failed", slog.String("session", s.Name), slog.String("error", err.Error()))
 		}
@@ -912,25 +1013,74 @@ func (s *Session) Start(command string) error {
 	return nil
 }
 
+// envArgs returns tmux -e flags setting env, sorted by name
+func envArgs(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	args := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+env[k])
+	}
+	return args
+}
+
+// startExtraPanes splits off the ExtraPanes and applies the Layout. A pane
+// that fails to open is logged and skipped; the session itself is up.
+func (s *Session) startExtraPanes(workDir string) {
+	if len(s.ExtraPanes) == 0 {
+		return
+	}
+	for _, command := range s.ExtraPanes {
+		out, err := s.tmuxOutput("split-window", "-d", "-P", "-F", "#{pane_id}", "-t", s.Name, "-c", workDir)
+		if err != nil {
+			statusLog.Warn("extra_pane_failed", slog.String("session", s.Name), slog.String("error", err.Error()))
+			continue
+		}
+		// Typed into the pane's shell rather than run by split-window, so
+		// the pane stays open when the command exits
+		if command != "" {
+			paneID := strings.TrimSpace(string(out))
+			_ = s.tmuxRun("send-keys", "-t", paneID, "-l", command)
+			_ = s.tmuxRun("send-keys", "-t", paneID, "Enter")
+		}
+	}
+	if s.Layout != "" {
+		if err := s.tmuxRun("select-layout", "-t", s.Name, s.Layout); err != nil {
+			statusLog.Warn("select_layout_failed", slog.String("session", s.Name), slog.String("layout", s.Layout), slog.String("error", err.Error()))
+		}
+	}
+}
+
 // Exists checks if the tmux session exists
 // Uses cached session list when available (refreshed by RefreshExistingSessions)
 // Falls back to direct tmux call if cache is stale
 func (s *Session) Exists() bool {
 	// Try cache first (O(1) map lookup, no subprocess)
-	if exists, cacheValid := sessionExistsFromCache(s.Name); cacheValid {
+	if exists, cacheValid := sessionExistsFromCache(s.Name); cacheValid && s.OnDeckServer() {
 		return exists
 	}
 
 	// When PipeManager is active, check if we have a connected pipe for this session.
 	// If PipeManager is running but has no pipe for us, session likely doesn't exist.
 	// This avoids subprocess fallback when control pipes handle status detection.
-	if pm := GetPipeManager(); pm != nil {
+	if pm := s.pipeManager(); pm != nil {
 		return pm.IsConnected(s.Name)
 	}
 
 	// No PipeManager: fall back to direct check (spawns subprocess)
-	cmd := exec.Command("tmux", "has-session", "-t", s.Name)
-	return cmd.Run() == nil
+	// A timeout says nothing about the session, so don't report it gone
+	err := s.tmuxRun("has-session", "-t", s.Name)
+	return err == nil || errors.Is(err, ErrTmuxTimeout)
+}
+
+// ExistsNow asks tmux whether the session exists, bypassing the session
+// cache and control pipes. A timeout counts as existing, like Exists.
+func (s *Session) ExistsNow() bool {
+	err := s.tmuxRun("has-session", "-t", s.Name)
+	return err == nil || errors.Is(err, ErrTmuxTimeout)
 }
 
 // ConfigureStatusBar sets up the tmux status bar with session info
@@ -952,13 +1102,12 @@ func (s *Session) ConfigureStatusBar() {
 	// Uses tmux command chaining with \; separator (73% reduction in subprocess calls)
 	// Before: 5 separate exec.Command calls = 5 subprocess spawns
 	// After: 1 exec.Command call = 1 subprocess spawn
-	cmd := exec.Command("tmux",
+	_ = s.tmuxRun(
 		"set-option", "-t", s.Name, "status", "on", ";",
 		"set-option", "-t", s.Name, "status-style", "bg=#1a1b26,fg=#a9b1d6", ";",
 		"set-option", "-t", s.Name, "status-left-length", "120", ";",
 		"set-option", "-t", s.Name, "status-right", rightStatus, ";",
 		"set-option", "-t", s.Name, "status-right-length", "80")
-	_ = cmd.Run()
 }
 
 // EnableMouseMode enables mouse scrolling, clipboard integration, and optimal settings
@@ -981,8 +1130,7 @@ func (s *Session) ConfigureStatusBar() {
 func (s *Session) EnableMouseMode() error {
 	// CRITICAL: Mouse mode must succeed - keep as separate call for error handling
 	// This is the only essential feature; all others are enhancements
-	mouseCmd := exec.Command("tmux", "set-option", "-t", s.Name, "mouse", "on")
-	if err := mouseCmd.Run(); err != nil {
+	if err := s.tmuxRun("set-option", "-t", s.Name, "mouse", "on"); err != nil {
 		return err
 	}
 
@@ -999,15 +1147,14 @@ func (s *Session) EnableMouseMode() error {
 	// - escape-time 10: Fast Vim/editor responsiveness (default 500ms is too slow)
 	//
 	// Uses -q flag where supported to silently ignore on older tmux versions
-	enhanceCmd := exec.Command("tmux",
+	// Ignore errors - all these are non-fatal enhancements
+	// Older tmux versions may not support some options
+	_ = s.tmuxRun(
 		"set-option", "-t", s.Name, "set-clipboard", "on", ";",
 		"set-option", "-t", s.Name, "-q", "allow-passthrough", "on", ";",
 		"set-option", "-t", s.Name, "history-limit", "10000", ";",
 		"set-option", "-t", s.Name, "escape-time", "10", ";",
 		"set", "-asq", "terminal-features", ",*:hyperlinks")
-	// Ignore errors - all these are non-fatal enhancements
-	// Older tmux versions may not support some options
-	_ = enhanceCmd.Run()
 
 	return nil
 }
@@ -1018,7 +1165,7 @@ func (s *Session) EnableMouseMode() error {
 // tools (e.g. Claude Code 2.1.27+) ignore, leaving orphan processes.
 func (s *Session) Kill() error {
 	// Disconnect control mode pipe
-	if pm := GetPipeManager(); pm != nil {
+	if pm := s.pipeManager(); pm != nil {
 		pm.Disconnect(s.Name)
 	}
 
@@ -1033,8 +1180,7 @@ func (s *Session) Kill() error {
 	}
 
 	// Kill the tmux session
-	cmd := exec.Command("tmux", "kill-session", "-t", s.Name)
-	err := cmd.Run()
+	err := s.tmuxRun("kill-session", "-t", s.Name)
 
 	// Verify old processes are dead; escalate to SIGKILL if needed
 	if len(oldPIDs) > 0 {
@@ -1048,7 +1194,7 @@ func (s *Session) Kill() error {
 // Used before respawn to track processes that must die.
 func (s *Session) getPaneProcessTree() (panePID int, allPIDs []int) {
 	target := s.Name + ":"
-	out, err := exec.Command("tmux", "list-panes", "-t", target, "-F", "#{pane_pid}").Output()
+	out, err := s.tmuxOutput("list-panes", "-t", target, "-F", "#{pane_pid}")
 	if err != nil {
 		return 0, nil
 	}
@@ -1197,8 +1343,7 @@ func (s *Session) RespawnPane(command string) error {
 	// Clear scrollback buffer BEFORE respawn to prevent stale content
 	// from previous conversation appearing when user attaches (#138).
 	clearTarget := s.Name + ":"
-	clearCmd := exec.Command("tmux", "clear-history", "-t", clearTarget)
-	if clearOut, clearErr := clearCmd.CombinedOutput(); clearErr != nil {
+	if clearOut, clearErr := s.tmuxCombinedOutput("clear-history", "-t", clearTarget); clearErr != nil {
 		respawnLog.Debug("clear_history_failed", slog.String("error", clearErr.Error()), slog.String("output", string(clearOut)))
 	} else {
 		respawnLog.Info("cleared_scrollback", slog.String("session", s.Name))
@@ -1209,7 +1354,8 @@ func (s *Session) RespawnPane(command string) error {
 	// -t: Target pane (session:window.pane format, use session: for active pane)
 	// command: New command to run
 	target := s.Name + ":" // Append colon to target the active pane
-	args := []string{"respawn-pane", "-k", "-t", target}
+	args := append([]string{"respawn-pane", "-k"}, envArgs(s.Env)...)
+	args = append(args, "-t", target)
 	if command != "" {
 		// Wrap command in interactive shell to ensure aliases and shell configs are available
 		// tmux respawn-pane runs commands directly without loading ~/.bashrc or ~/.zshrc,
@@ -1233,8 +1379,7 @@ func (s *Session) RespawnPane(command string) error {
 	}
 
 	mcpLog.Debug("respawn_pane_executing", slog.Any("args", args))
-	cmd := exec.Command("tmux", args...)
-	output, err := cmd.CombinedOutput()
+	output, err := s.tmuxCombinedOutput(args...)
 	if err != nil {
 		mcpLog.Debug("respawn_pane_error", slog.String("error", err.Error()), slog.String("output", string(output)))
 		return fmt.Errorf("failed to respawn pane: %w (output: %s)", err, string(output))
@@ -1251,7 +1396,7 @@ func (s *Session) RespawnPane(command string) error {
 	}
 
 	// Reconnect control mode pipe (respawn changes the pane process)
-	if pm := GetPipeManager(); pm != nil {
+	if pm := s.pipeManager(); pm != nil {
 		pm.Disconnect(s.Name)
 		if err := pm.Connect(s.Name); err != nil {
 			statusLog.Debug("control_pipe_reconnect_failed", slog.String("session", s.Name), slog.String("error", err.Error()))
@@ -1266,20 +1411,17 @@ func (s *Session) RespawnPane(command string) error {
 // Falls back to direct tmux call if cache is stale
 func (s *Session) GetWindowActivity() (int64, error) {
 	// Try cache first (O(1) map lookup, no subprocess)
-	if activity, cacheValid := sessionActivityFromCache(s.Name); cacheValid {
+	if activity, cacheValid := sessionActivityFromCache(s.Name); cacheValid && s.OnDeckServer() {
 		return activity, nil
 	}
 
 	// When PipeManager is active, route through pipe (zero subprocess)
-	if pm := GetPipeManager(); pm != nil {
+	if pm := s.pipeManager(); pm != nil {
 		return pm.GetWindowActivity(s.Name)
 	}
 
 	// No PipeManager: fall back to direct check (spawns subprocess)
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-	cmd := exec.CommandContext(ctx, "tmux", "display-message", "-t", s.Name, "-p", "#{window_activity}")
-	output, err := cmd.Output()
+	output, err := s.tmuxOutputTimeout(3*time.Second, "display-message", "-t", s.Name, "-p", "#{window_activity}")
 	if err != nil {
 		return 0, fmt.Errorf("failed to get window activity: %w", err)
 	}
@@ -1296,7 +1438,7 @@ func (s *Session) GetWindowActivity() (int64, error) {
 // This is used for cheap idle-session activity gating in tiered polling.
 func (s *Session) GetCachedWindowActivity() int64 {
 	activity, valid := sessionActivityFromCache(s.Name)
-	if valid {
+	if valid && s.OnDeckServer() {
 		return activity
 	}
 	return 0
@@ -1327,7 +1469,7 @@ func (s *Session) CapturePane() (string, error) {
 		s.cacheMu.RUnlock()
 
 		// Try control mode pipe first (zero subprocess)
-		if pm := GetPipeManager(); pm != nil {
+		if pm := s.pipeManager(); pm != nil {
 			if content, pipeErr := pm.CapturePane(s.Name); pipeErr == nil {
 				s.cacheMu.Lock()
 				s.cacheContent = content
@@ -1340,12 +1482,9 @@ func (s *Session) CapturePane() (string, error) {
 		}
 
 		// Subprocess fallback: -J joins wrapped lines, 3s timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		defer cancel()
-		cmd := exec.CommandContext(ctx, "tmux", "capture-pane", "-t", s.Name, "-p", "-J")
-		output, err := cmd.Output()
+		output, err := s.tmuxOutputTimeout(3*time.Second, "capture-pane", "-t", s.Name, "-p", "-J")
 		if err != nil {
-			if ctx.Err() == context.DeadlineExceeded {
+			if errors.Is(err, ErrTmuxTimeout) {
 				return "", ErrCaptureTimeout
 			}
 			return "", fmt.Errorf("failed to capture pane: %w", err)
@@ -1371,14 +1510,66 @@ func (s *Session) CaptureFullHistory() (string, error) {
 	// Limit to last 2000 lines to balance content availability with memory usage
 	// AI agent conversations can be long - 2000 lines captures ~40-80 screens of content
 	// -J joins wrapped lines and trims trailing spaces so hashes don't change on resize
-	cmd := exec.Command("tmux", "capture-pane", "-t", s.Name, "-p", "-J", "-S", "-2000")
-	output, err := cmd.Output()
+	output, err := s.tmuxOutput("capture-pane", "-t", s.Name, "-p", "-J", "-S", "-2000")
 	if err != nil {
 		return "", fmt.Errorf("failed to capture history: %w", err)
 	}
 	return string(output), nil
 }
 
+// ReadOnlyAttachCommand returns the shell command another user on this host can run
+// to watch the session read-only. socketPath is the tmux server socket (see ServerSocketPath).
+func (s *Session) ReadOnlyAttachCommand(socketPath string) string {
+	if socketPath == "" {
+		if s.SocketName != "" {
+			return fmt.Sprintf("tmux -L %s attach-session -r -t %s", s.SocketName, s.Name)
+		}
+		return fmt.Sprintf("tmux attach-session -r -t %s", s.Name)
+	}
+	return fmt.Sprintf("tmux -S %s attach-session -r -t %s", socketPath, s.Name)
+}
+
+// AttachArgv returns the command line that attaches to the session from a
+// new terminal
+func (s *Session) AttachArgv() []string {
+	return append([]string{"tmux"}, socketArgs(s.SocketName, []string{"attach-session", "-t", s.Name})...)
+}
+
+// ServerSocketPath returns the path of the socket of the tmux server the
+// session runs on
+func (s *Session) ServerSocketPath() (string, error) {
+	output, err := s.tmuxOutput("display-message", "-p", "#{socket_path}")
+	if err != nil {
+		return "", fmt.Errorf("failed to get tmux socket path: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GrantReadOnlyAccess allows another local user to attach read-only to the session's
+// tmux server. Requires tmux 3.3+ (server-access). The user also needs filesystem
+// access to the socket.
+func (s *Session) GrantReadOnlyAccess(user string) error {
+	if output, err := s.tmuxCombinedOutput("server-access", "-a", "-r", user); err != nil {
+		return fmt.Errorf("failed to grant access to %s (needs tmux 3.3+): %s: %w", user, strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// CaptureScrollback captures the pane as plain text. With fullHistory, the entire
+// scrollback buffer is included; otherwise only the visible screen.
+// Unlike CaptureFullHistory, there is no line limit (used for archiving/export).
+func (s *Session) CaptureScrollback(fullHistory bool) (string, error) {
+	args := []string{"capture-pane", "-t", s.Name, "-p", "-J"}
+	if fullHistory {
+		args = append(args, "-S", "-", "-E", "-")
+	}
+	output, err := s.tmuxOutput(args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture scrollback: %w", err)
+	}
+	return string(output), nil
+}
+
 // HasUpdated checks if the pane content has changed since last check
 func (s *Session) HasUpdated() (bool, error) {
 	content, err := s.CapturePane()
@@ -2334,15 +2525,13 @@ func (s *Session) SendKeys(keys string) error {
 	// The -l flag makes tmux treat the string as literal text, not key names
 	// This prevents issues like "Enter" being interpreted as the Enter key
 	// and provides a layer of safety against tmux special sequences
-	cmd := exec.Command("tmux", "send-keys", "-l", "-t", s.Name, keys)
-	return cmd.Run()
+	return s.tmuxRun("send-keys", "-l", "-t", s.Name, keys)
 }
 
 // SendEnter sends an Enter key to the tmux session
 func (s *Session) SendEnter() error {
 	s.invalidateCache()
-	cmd := exec.Command("tmux", "send-keys", "-t", s.Name, "Enter")
-	return cmd.Run()
+	return s.tmuxRun("send-keys", "-t", s.Name, "Enter")
 }
 
 // SendKeysAndEnter sends literal text followed by Enter atomically in a single
@@ -2351,10 +2540,9 @@ func (s *Session) SendEnter() error {
 // See: tmux#1185, tmux#1517, tmux#1778
 func (s *Session) SendKeysAndEnter(keys string) error {
 	s.invalidateCache()
-	cmd := exec.Command("tmux",
+	return s.tmuxRun(
 		"send-keys", "-l", "-t", s.Name, "--", keys, ";",
 		"send-keys", "-t", s.Name, "Enter")
-	return cmd.Run()
 }
 
 // SendKeysChunked sends large content to the tmux session in chunks to avoid
@@ -2419,15 +2607,19 @@ func splitIntoChunks(content string, maxSize int) []string {
 // SendCtrlC sends Ctrl+C (interrupt signal) to the tmux session
 func (s *Session) SendCtrlC() error {
 	s.invalidateCache()
-	cmd := exec.Command("tmux", "send-keys", "-t", s.Name, "C-c")
-	return cmd.Run()
+	return s.tmuxRun("send-keys", "-t", s.Name, "C-c")
 }
 
 // SendCtrlU sends Ctrl+U (clear line) to the tmux session
 func (s *Session) SendCtrlU() error {
 	s.invalidateCache()
-	cmd := exec.Command("tmux", "send-keys", "-t", s.Name, "C-u")
-	return cmd.Run()
+	return s.tmuxRun("send-keys", "-t", s.Name, "C-u")
+}
+
+// SendEscape sends the Escape key to the tmux session
+func (s *Session) SendEscape() error {
+	s.invalidateCache()
+	return s.tmuxRun("send-keys", "-t", s.Name, "Escape")
 }
 
 // WaitForShellPrompt polls the terminal until a shell prompt is detected
@@ -2593,22 +2785,55 @@ func (s *Session) SendCommand(command string) error {
 // GetWorkDir returns the current working directory of the tmux pane
 // This is the live directory from the pane, not the initial WorkDir
 func (s *Session) GetWorkDir() string {
+	if info, ok := s.CachedPaneInfo(); ok && info.Path != "" {
+		return info.Path
+	}
 	if !s.Exists() {
 		return ""
 	}
 
-	cmd := exec.Command("tmux", "display-message", "-t", s.Name, "-p", "#{pane_current_path}")
-	output, err := cmd.Output()
+	output, err := s.tmuxOutput("display-message", "-t", s.Name, "-p", "#{pane_current_path}")
 	if err != nil {
 		return ""
 	}
 	return strings.TrimSpace(string(output))
 }
 
+// PaneTitle returns the title the program in the session's pane has set
+// (OSC 0/2), "" if it hasn't set one. Leading spinner and emoji characters
+// are dropped. Reads the batched cache when fresh, otherwise asks tmux.
+func (s *Session) PaneTitle() string {
+	title, ok := "", false
+	if info, cached := s.CachedPaneInfo(); cached {
+		title, ok = info.Title, true
+	} else if output, err := s.tmuxOutput("display-message", "-t", s.Name, "-p", "#{pane_title}"); err == nil {
+		title, ok = strings.TrimSpace(string(output)), true
+	}
+	if !ok {
+		return ""
+	}
+	return cleanPaneTitle(title)
+}
+
+// cleanPaneTitle strips decoration from a pane title and drops tmux's
+// default (the host name), which says nothing about the session
+func cleanPaneTitle(title string) string {
+	title = strings.TrimLeftFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	title = strings.TrimSpace(title)
+	if host, err := os.Hostname(); err == nil {
+		if title == host || title == strings.SplitN(host, ".", 2)[0] {
+			return ""
+		}
+	}
+	return title
+}
+
 // ListAllSessions returns all Agent Deck tmux sessions
 func ListAllSessions() ([]*Session, error) {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
-	output, err := cmd.Output()
+	// One list-panes call gives every session's working directory
+	output, err := tmuxOutput("list-panes", "-a", "-F", paneListFormat)
 	if err != nil {
 		// No sessions exist
 		if strings.Contains(err.Error(), "no server running") ||
@@ -2618,26 +2843,23 @@ func ListAllSessions() ([]*Session, error) {
 		return nil, fmt.Errorf("failed to list sessions: %w", err)
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var sessions []*Session
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, SessionPrefix) {
-			displayName := strings.TrimPrefix(line, SessionPrefix)
-			// Get session info
-			sess := &Session{
-				Name:        line,
-				DisplayName: displayName,
-			}
-			// Try to get working directory
-			workDirCmd := exec.Command("tmux", "display-message", "-t", line, "-p", "#{pane_current_path}")
-			if workDirOutput, err := workDirCmd.Output(); err == nil {
-				sess.WorkDir = strings.TrimSpace(string(workDirOutput))
-			}
-			sessions = append(sessions, sess)
+	panes := parsePaneList(string(output))
+	names := make([]string, 0, len(panes))
+	for name := range panes {
+		if strings.HasPrefix(name, SessionPrefix) {
+			names = append(names, name)
 		}
 	}
+	sort.Strings(names)
 
+	sessions := make([]*Session, 0, len(names))
+	for _, name := range names {
+		sessions = append(sessions, &Session{
+			Name:        name,
+			DisplayName: strings.TrimPrefix(name, SessionPrefix),
+			WorkDir:     panes[name].Path,
+		})
+	}
 	return sessions, nil
 }
 
@@ -2814,8 +3036,7 @@ func RunLogMaintenance(maxSizeMB int, maxLines int, removeOrphans bool) {
 // those in the current profile. This ensures consistent notification bars
 // when users switch between sessions.
 func ListAgentDeckSessions() ([]string, error) {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
-	output, err := cmd.Output()
+	output, err := tmuxOutput("list-sessions", "-F", "#{session_name}")
 	if err != nil {
 		// No sessions exist
 		if strings.Contains(err.Error(), "no server running") ||
@@ -2842,16 +3063,14 @@ func ListAgentDeckSessions() ([]string, error) {
 func SetStatusLeft(sessionName, text string) error {
 	// Escape single quotes for tmux by replacing ' with '\''
 	escaped := strings.ReplaceAll(text, "'", "'\\''")
-	cmd := exec.Command("tmux", "set-option", "-t", sessionName, "status-left", escaped)
-	return cmd.Run()
+	return tmuxRun("set-option", "-t", sessionName, "status-left", escaped)
 }
 
 // ClearStatusLeft resets status-left to default for a session.
 // Called when notifications are cleared or acknowledged.
 func ClearStatusLeft(sessionName string) error {
 	// -u flag unsets the option, reverting to tmux default
-	cmd := exec.Command("tmux", "set-option", "-t", sessionName, "-u", "status-left")
-	return cmd.Run()
+	return tmuxRun("set-option", "-t", sessionName, "-u", "status-left")
 }
 
 // SetStatusLeftGlobal sets the left side of tmux status bar globally.
@@ -2859,14 +3078,12 @@ func ClearStatusLeft(sessionName string) error {
 // All agentdeck sessions inherit this global setting.
 func SetStatusLeftGlobal(text string) error {
 	escaped := strings.ReplaceAll(text, "'", "'\\''")
-	cmd := exec.Command("tmux", "set-option", "-g", "status-left", escaped)
-	return cmd.Run()
+	return tmuxRun("set-option", "-g", "status-left", escaped)
 }
 
 // ClearStatusLeftGlobal resets status-left to default globally.
 func ClearStatusLeftGlobal() error {
-	cmd := exec.Command("tmux", "set-option", "-gu", "status-left")
-	return cmd.Run()
+	return tmuxRun("set-option", "-gu", "status-left")
 }
 
 // InitializeStatusBarOptions sets optimal status bar options for agent-deck.
@@ -2875,7 +3092,7 @@ func ClearStatusLeftGlobal() error {
 func InitializeStatusBarOptions() error {
 	// Set adequate status-left-length globally (default is only 10 chars!)
 	// This ensures the notification bar content is not truncated
-	return exec.Command("tmux", "set-option", "-g", "status-left-length", "120").Run()
+	return tmuxRun("set-option", "-g", "status-left-length", "120")
 }
 
 // RefreshStatusBarImmediate forces an immediate status bar redraw for ALL connected clients.
@@ -2884,8 +3101,7 @@ func InitializeStatusBarOptions() error {
 // Filters out control mode clients (from PipeManager) which don't have a visible status bar.
 func RefreshStatusBarImmediate() error {
 	// Get all connected clients, filtering out control mode clients
-	cmd := exec.Command("tmux", "list-clients", "-F", "#{client_name}\t#{client_control_mode}")
-	output, err := cmd.Output()
+	output, err := tmuxOutput("list-clients", "-F", "#{client_name}\t#{client_control_mode}")
 	if err != nil {
 		return nil
 	}
@@ -2899,7 +3115,7 @@ func RefreshStatusBarImmediate() error {
 		if parts[1] == "1" {
 			continue
 		}
-		_ = exec.Command("tmux", "refresh-client", "-S", "-t", parts[0]).Run()
+		_ = tmuxRun("refresh-client", "-S", "-t", parts[0])
 	}
 	return nil
 }
@@ -2908,8 +3124,7 @@ func RefreshStatusBarImmediate() error {
 // Used to detect which session the user is currently viewing.
 // Filters out control mode clients (from PipeManager) which are not real user sessions.
 func GetAttachedSessions() ([]string, error) {
-	cmd := exec.Command("tmux", "list-clients", "-F", "#{session_name}\t#{client_control_mode}")
-	output, err := cmd.Output()
+	output, err := tmuxOutput("list-clients", "-F", "#{session_name}\t#{client_control_mode}")
 	if err != nil {
 		return nil, err
 	}
@@ -2934,8 +3149,7 @@ func GetAttachedSessions() ([]string, error) {
 // The key should be a single character like "1", "2", etc.
 // Deprecated: Use BindSwitchKeyWithAck for notification bar integration.
 func BindSwitchKey(key, targetSession string) error {
-	cmd := exec.Command("tmux", "bind-key", key, "switch-client", "-t", targetSession)
-	return cmd.Run()
+	return tmuxRun("bind-key", key, "switch-client", "-t", targetSession)
 }
 
 // BindSwitchKeyWithAck binds a number key to switch to target session AND
@@ -2954,8 +3168,7 @@ func BindSwitchKeyWithAck(key, targetSession, sessionID string) error {
 	// 2. Switches to the target session
 	script := fmt.Sprintf("echo '%s' > '%s' && tmux switch-client -t '%s'",
 		sessionID, signalFile, targetSession)
-	cmd := exec.Command("tmux", "bind-key", key, "run-shell", script)
-	return cmd.Run()
+	return tmuxRun("bind-key", key, "run-shell", script)
 }
 
 // GetAckSignalPath returns the path to the acknowledgment signal file
@@ -2992,19 +3205,18 @@ func ReadAndClearAckSignal() string {
 // without windows (e.g., CI) and agent-deck rebinds keys every 2s anyway.
 func UnbindKey(key string) error {
 	// First unbind our custom binding
-	_ = exec.Command("tmux", "unbind-key", key).Run()
+	_ = tmuxRun("unbind-key", key)
 
 	// Best-effort restore default: number keys select windows
 	// bind-key 1 select-window -t :1
-	_ = exec.Command("tmux", "bind-key", key, "select-window", "-t", ":"+key).Run()
+	_ = tmuxRun("bind-key", key, "select-window", "-t", ":"+key)
 	return nil
 }
 
 // GetActiveSession returns the session name the user is currently attached to.
 // Returns empty string and error if not attached to any session.
 func GetActiveSession() (string, error) {
-	cmd := exec.Command("tmux", "display-message", "-p", "#{client_session}")
-	out, err := cmd.Output()
+	out, err := tmuxOutput("display-message", "-p", "#{client_session}")
 	if err != nil {
 		return "", err
 	}
@@ -3015,8 +3227,7 @@ func GetActiveSession() (string, error) {
 
 // DiscoverAllTmuxSessions returns all tmux sessions (including non-Agent Deck ones)
 func DiscoverAllTmuxSessions() ([]*Session, error) {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}:#{pane_current_path}")
-	output, err := cmd.Output()
+	output, err := tmuxOutput("list-sessions", "-F", "#{session_name}:#{pane_current_path}")
 	if err != nil {
 		// No sessions exist
 		if strings.Contains(err.Error(), "no server running") ||
@@ -1896,6 +1896,33 @@ func TestGetWindowActivity(t *testing.T) {
 	assert.True(t, ts <= now+1, "timestamp should not be in the future")
 }
 
+// TestStartSetsEnvOffScreen verifies Env reaches the pane's shell through
+// the tmux environment, never through the keys typed into the pane
+func TestStartSetsEnvOffScreen(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	sess := NewSession("env-test", t.TempDir())
+	sess.Env = map[string]string{"AD_TEST_SECRET": "s3cr3t value"}
+	err := sess.Start(`[ -n "$AD_TEST_SECRET" ] && echo env-ok`)
+	assert.NoError(t, err)
+	defer func() { _ = sess.Kill() }()
+
+	value, err := sess.GetEnvironment("AD_TEST_SECRET")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t value", value)
+
+	var content string
+	for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); time.Sleep(100 * time.Millisecond) {
+		sess.invalidateCache()
+		content, _ = sess.CapturePane()
+		if strings.Contains(content, "env-ok") {
+			break
+		}
+	}
+	assert.Contains(t, content, "env-ok")
+	assert.NotContains(t, content, "s3cr3t")
+}
+
 // TestIsSustainedActivity verifies spike detection logic
 func TestIsSustainedActivity(t *testing.T) {
 	if _, err := exec.LookPath("tmux"); err != nil {
@@ -2310,3 +2337,100 @@ func TestSplitIntoChunks_SplitsAtNewlineBoundary(t *testing.T) {
 	assert.Equal(t, line+line, chunks[0])
 	assert.Equal(t, line, chunks[1])
 }
+
+func TestReadOnlyAttachCommand(t *testing.T) {
+	s := &Session{Name: "agentdeck_demo_1234"}
+	if got := s.ReadOnlyAttachCommand(""); got != "tmux attach-session -r -t agentdeck_demo_1234" {
+		t.Errorf("without socket: %q", got)
+	}
+	if got := s.ReadOnlyAttachCommand("/tmp/tmux-501/default"); got != "tmux -S /tmp/tmux-501/default attach-session -r -t agentdeck_demo_1234" {
+		t.Errorf("with socket: %q", got)
+	}
+}
+
+func TestSessionSocketName(t *testing.T) {
+	t.Cleanup(func() { SetSocketName("") })
+	SetSocketName("agentdeck-work")
+
+	s := NewSession("api", "/tmp")
+	if s.SocketName != "agentdeck-work" || !s.OnDeckServer() {
+		t.Fatalf("new sessions should be pinned to the configured socket, got %q", s.SocketName)
+	}
+	if got := strings.Join(s.AttachArgv(), " "); got != "tmux -L agentdeck-work attach-session -t "+s.Name {
+		t.Errorf("AttachArgv = %q", got)
+	}
+	if got := s.ReadOnlyAttachCommand(""); got != "tmux -L agentdeck-work attach-session -r -t "+s.Name {
+		t.Errorf("ReadOnlyAttachCommand = %q", got)
+	}
+
+	// Sessions from before socket_name was set stay on the default server
+	legacy := &Session{Name: "agentdeck_old_1234"}
+	if legacy.OnDeckServer() {
+		t.Error("a session on another server shouldn't use the session cache")
+	}
+}
+
+func TestParsePaneList(t *testing.T) {
+	output := "agentdeck_api_1\t1700000100\t4242\t0\tclaude\t✳ Fix login\t/home/me/api\n" +
+		"agentdeck_api_1\t1700000200\t4343\t0\tzsh\thost\t/tmp\n" +
+		"agentdeck_web_2\t1700000050\t5151\t1\tnode\t\t/home/me/my\tweb\n" +
+		"legacy\t1700000010\n"
+
+	panes := parsePaneList(output)
+	if len(panes) != 3 {
+		t.Fatalf("got %d sessions, want 3: %+v", len(panes), panes)
+	}
+
+	api := panes["agentdeck_api_1"]
+	if api.Activity != 1700000200 {
+		t.Errorf("activity = %d, want the most recent window's", api.Activity)
+	}
+	if api.PID != 4242 || api.Command != "claude" || api.Title != "✳ Fix login" || api.Path != "/home/me/api" || api.Dead {
+		t.Errorf("api should keep its first pane's details, got %+v", api)
+	}
+
+	web := panes["agentdeck_web_2"]
+	if !web.Dead || web.Path != "/home/me/my\tweb" {
+		t.Errorf("web = %+v, want dead pane with tab in path", web)
+	}
+	if legacy := panes["legacy"]; legacy.Activity != 1700000010 || legacy.Path != "" {
+		t.Errorf("activity-only lines should still parse, got %+v", legacy)
+	}
+}
+
+func TestCleanPaneTitle(t *testing.T) {
+	host, _ := os.Hostname()
+	tests := map[string]string{
+		"✳ Refactor auth middleware": "Refactor auth middleware",
+		"⠂ Running tests":            "Running tests",
+		"  vim main.go ":             "vim main.go",
+		host:                         "",
+		"":                           "",
+	}
+	for in, want := range tests {
+		if got := cleanPaneTitle(in); got != want {
+			t.Errorf("cleanPaneTitle(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestStartExtraPanes verifies template panes are split off with the layout
+// applied and the first pane keeping focus
+func TestStartExtraPanes(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	sess := NewSession("extra-panes-test", t.TempDir())
+	sess.ExtraPanes = []string{"echo pane-two", ""}
+	sess.Layout = "even-horizontal"
+	err := sess.Start("")
+	assert.NoError(t, err)
+	defer func() { _ = sess.Kill() }()
+
+	out, err := sess.tmuxOutput("list-panes", "-t", sess.Name, "-F", "#{pane_active}")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "0", "0"}, strings.Fields(string(out)))
+
+	layout, err := sess.tmuxOutput("display-message", "-p", "-t", sess.Name, "#{window_layout}")
+	assert.NoError(t, err)
+	assert.Contains(t, string(layout), "{", "even-horizontal lays the panes side by side")
+}
@@ -0,0 +1,306 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// approvalItem is one pending permission prompt in the inbox
+type approvalItem struct {
+	sessionID string
+	title     string
+	tool      string
+	prompt    *tmux.ApprovalPrompt
+}
+
+// approvalsCollectedMsg carries the prompts found across all waiting sessions
+type approvalsCollectedMsg struct {
+	items []approvalItem
+}
+
+// approvalAnsweredMsg is sent after keystrokes were delivered to a session
+type approvalAnsweredMsg struct {
+	title    string
+	approved bool
+	err      error
+}
+
+// ApprovalsInbox lists pending permission prompts from every session so they can
+// be answered without attaching
+type ApprovalsInbox struct {
+	visible bool
+	loading bool
+	width   int
+	height  int
+	items   []approvalItem
+	cursor  int
+}
+
+// NewApprovalsInbox creates a new approvals inbox overlay
+func NewApprovalsInbox() *ApprovalsInbox {
+	return &ApprovalsInbox{}
+}
+
+// Show displays the inbox in a loading state until items arrive
+func (a *ApprovalsInbox) Show() {
+	a.visible = true
+	a.loading = true
+}
+
+// Hide hides the inbox
+func (a *ApprovalsInbox) Hide() {
+	a.visible = false
+	a.items = nil
+	a.cursor = 0
+}
+
+// IsVisible returns whether the inbox is visible
+func (a *ApprovalsInbox) IsVisible() bool {
+	return a.visible
+}
+
+// SetSize sets the dimensions for the overlay
+func (a *ApprovalsInbox) SetSize(width, height int) {
+	a.width = width
+	a.height = height
+}
+
+// SetItems replaces the inbox contents, keeping the cursor on the same session if possible
+func (a *ApprovalsInbox) SetItems(items []approvalItem) {
+	prevID := ""
+	if sel := a.selected(); sel != nil {
+		prevID = sel.sessionID
+	}
+	a.items = items
+	a.loading = false
+	a.cursor = 0
+	for i, item := range items {
+		if item.sessionID == prevID {
+			a.cursor = i
+		}
+	}
+}
+
+// selected returns the highlighted item, or nil if the inbox is empty
+func (a *ApprovalsInbox) selected() *approvalItem {
+	if a.cursor < 0 || a.cursor >= len(a.items) {
+		return nil
+	}
+	return &a.items[a.cursor]
+}
+
+// remove drops the item for sessionID (after it has been answered)
+func (a *ApprovalsInbox) remove(sessionID string) {
+	for i, item := range a.items {
+		if item.sessionID == sessionID {
+			a.items = append(a.items[:i], a.items[i+1:]...)
+			break
+		}
+	}
+	if a.cursor >= len(a.items) && a.cursor > 0 {
+		a.cursor = len(a.items) - 1
+	}
+}
+
+// View renders the inbox overlay
+func (a *ApprovalsInbox) View() string {
+	if !a.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorYellow)
+	textStyle := lipgloss.NewStyle().Foreground(ColorText)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	selStyle := lipgloss.NewStyle().Foreground(ColorCyan).Bold(true)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+
+	dialogWidth := a.width - 8
+	if dialogWidth > 100 {
+		dialogWidth = 100
+	}
+	if dialogWidth < 40 {
+		dialogWidth = 40
+	}
+	maxLineWidth := dialogWidth - 8
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Approvals Inbox (%d)", len(a.items))))
+	b.WriteString("\n\n")
+
+	switch {
+	case a.loading && len(a.items) == 0:
+		b.WriteString(dimStyle.Render("Scanning sessions..."))
+		b.WriteString("\n")
+	case len(a.items) == 0:
+		b.WriteString(dimStyle.Render("No pending approvals"))
+		b.WriteString("\n")
+	}
+
+	// Each entry: header + up to 6 prompt lines + options; keep the selection on screen
+	budget := a.height - 12
+	if budget < 8 {
+		budget = 8
+	}
+	used := 0
+	for i, item := range a.items {
+		var entry strings.Builder
+		marker := "  "
+		if i == a.cursor {
+			marker = selStyle.Render("▶ ")
+		}
+		entry.WriteString(marker + nameStyle.Render(item.title) + dimStyle.Render(" "+item.tool))
+		entry.WriteString("\n")
+
+		textLines := strings.Split(item.prompt.Text, "\n")
+		if i != a.cursor {
+			// Collapse unselected entries to the question
+			textLines = textLines[len(textLines)-1:]
+		} else if len(textLines) > 6 {
+			textLines = append(textLines[:2], append([]string{"…"}, textLines[len(textLines)-3:]...)...)
+		}
+		for _, line := range textLines {
+			entry.WriteString("    " + textStyle.Render(runewidth.Truncate(line, maxLineWidth, "…")))
+			entry.WriteString("\n")
+		}
+		if i == a.cursor {
+			for _, opt := range item.prompt.Options {
+				entry.WriteString("    " + dimStyle.Render(runewidth.Truncate(opt.Number+". "+opt.Label, maxLineWidth, "…")))
+				entry.WriteString("\n")
+			}
+		}
+
+		lines := strings.Count(entry.String(), "\n") + 1
+		if used+lines > budget && i > a.cursor {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("  … %d more", len(a.items)-i)))
+			b.WriteString("\n")
+			break
+		}
+		used += lines
+		b.WriteString(entry.String())
+		b.WriteString("\n")
+	}
+
+	b.WriteString(footerStyle.Render("j/k select • y approve • n deny • 1-9 pick option • r refresh • esc close"))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(b.String())
+	return centerInScreen(box, a.width, a.height)
+}
+
+// collectApprovals returns a tea.Cmd that scans waiting sessions for permission prompts
+func (h *Home) collectApprovals() tea.Cmd {
+	h.instancesMu.RLock()
+	instances := make([]*session.Instance, len(h.instances))
+	copy(instances, h.instances)
+	h.instancesMu.RUnlock()
+
+	return func() tea.Msg {
+		var items []approvalItem
+		for _, inst := range instances {
+			if inst.GetStatusThreadSafe() != session.StatusWaiting {
+				continue
+			}
+			tmuxSession := inst.GetTmuxSession()
+			if tmuxSession == nil {
+				continue
+			}
+			content, err := tmuxSession.CapturePane()
+			if err != nil {
+				continue
+			}
+			if prompt := tmux.ParseApprovalPrompt(content); prompt != nil {
+				items = append(items, approvalItem{
+					sessionID: inst.ID,
+					title:     inst.Title,
+					tool:      inst.GetToolThreadSafe(),
+					prompt:    prompt,
+				})
+			}
+		}
+		return approvalsCollectedMsg{items: items}
+	}
+}
+
+// answerApproval returns a tea.Cmd that sends keys to answer the item's prompt
+func (h *Home) answerApproval(item approvalItem, keys string, approved bool) tea.Cmd {
+	inst := h.getInstanceByID(item.sessionID)
+	return func() tea.Msg {
+		msg := approvalAnsweredMsg{title: item.title, approved: approved}
+		if inst == nil || inst.GetTmuxSession() == nil {
+			msg.err = fmt.Errorf("session '%s' no longer exists", item.title)
+			return msg
+		}
+		msg.err = inst.GetTmuxSession().AnswerApproval(item.prompt, keys)
+		return msg
+	}
+}
+
+// handleApprovalsKey handles keys while the approvals inbox is open
+func (h *Home) handleApprovalsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	inbox := h.approvalsInbox
+	key := msg.String()
+	switch key {
+	case "esc", "q", "a":
+		inbox.Hide()
+		return h, nil
+	case "j", "down":
+		if inbox.cursor < len(inbox.items)-1 {
+			inbox.cursor++
+		}
+		return h, nil
+	case "k", "up":
+		if inbox.cursor > 0 {
+			inbox.cursor--
+		}
+		return h, nil
+	case "r":
+		inbox.loading = true
+		return h, h.collectApprovals()
+	}
+
+	item := inbox.selected()
+	if item == nil {
+		return h, nil
+	}
+	switch {
+	case key == "y":
+		keys := item.prompt.ApproveKeys()
+		if keys == "" {
+			h.setError(fmt.Errorf("no approve option found in '%s' prompt", item.title))
+			return h, nil
+		}
+		answered := *item
+		inbox.remove(item.sessionID)
+		return h, h.answerApproval(answered, keys, true)
+	case key == "n":
+		answered := *item
+		inbox.remove(item.sessionID)
+		return h, h.answerApproval(answered, answered.prompt.DenyKeys(), false)
+	case len(key) == 1 && key[0] >= '1' && key[0] <= '9':
+		for _, opt := range item.prompt.Options {
+			if opt.Number == key {
+				answered := *item
+				inbox.remove(item.sessionID)
+				approved := !strings.HasPrefix(strings.ToLower(opt.Label), "no")
+				return h, h.answerApproval(answered, key, approved)
+			}
+		}
+	}
+	return h, nil
+}
+
+// approvalsRefreshDelay gives the agent time to redraw before rescanning
+const approvalsRefreshDelay = 700 * time.Millisecond
+
+// approvalsRefreshMsg triggers a rescan while the inbox is open
+type approvalsRefreshMsg struct{}
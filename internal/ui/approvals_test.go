@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func testApprovalItems() []approvalItem {
+	return []approvalItem{
+		{
+			sessionID: "s1",
+			title:     "api",
+			tool:      "claude",
+			prompt: &tmux.ApprovalPrompt{
+				Text:     "Bash command\nnpm test\nDo you want to proceed?",
+				Question: "Do you want to proceed?",
+				Options: []tmux.ApprovalOption{
+					{Number: "1", Label: "Yes", Selected: true},
+					{Number: "2", Label: "No, and tell Claude what to do differently"},
+				},
+			},
+		},
+		{
+			sessionID: "s2",
+			title:     "web",
+			tool:      "codex",
+			prompt:    &tmux.ApprovalPrompt{Text: "Deploy? (y/n)", Question: "Deploy? (y/n)", YesNo: true},
+		},
+	}
+}
+
+func TestApprovalsInboxView(t *testing.T) {
+	inbox := NewApprovalsInbox()
+	inbox.SetSize(120, 40)
+	inbox.Show()
+	if !strings.Contains(inbox.View(), "Scanning sessions") {
+		t.Error("inbox should show a loading state before items arrive")
+	}
+
+	inbox.SetItems(testApprovalItems())
+	view := inbox.View()
+	for _, want := range []string{"Approvals Inbox (2)", "api", "npm test", "1. Yes", "web", "Deploy? (y/n)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	inbox.SetItems(nil)
+	if !strings.Contains(inbox.View(), "No pending approvals") {
+		t.Error("empty inbox should say so")
+	}
+}
+
+func TestApprovalsInboxKeys(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 40
+
+	home.approvalsInbox.Show()
+	home.approvalsInbox.SetItems(testApprovalItems())
+
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if sel := home.approvalsInbox.selected(); sel == nil || sel.sessionID != "s2" {
+		t.Fatalf("j should select the second item, got %+v", sel)
+	}
+
+	// Answering removes the item immediately; delivery happens in the returned command
+	_, cmd := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("y should return a command that sends the answer")
+	}
+	if len(home.approvalsInbox.items) != 1 || home.approvalsInbox.items[0].sessionID != "s1" {
+		t.Errorf("answered item should be removed, items = %+v", home.approvalsInbox.items)
+	}
+	msg, ok := cmd().(approvalAnsweredMsg)
+	if !ok || msg.err == nil {
+		t.Errorf("answering an unknown session should report an error, got %+v", msg)
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if home.approvalsInbox.IsVisible() {
+		t.Error("esc should close the inbox")
+	}
+}
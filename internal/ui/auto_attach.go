@@ -0,0 +1,102 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// queueAutoAttach records that a session with auto-attach enabled went from
+// running to waiting. Called from the background status worker; transitions
+// while attached are ignored so detaching doesn't bounce straight back in.
+func (h *Home) queueAutoAttach(inst *session.Instance, oldStatus, newStatus session.Status) {
+	if inst.AutoAttach == session.AutoAttachOff || oldStatus != session.StatusRunning ||
+		newStatus != session.StatusWaiting || h.isAttaching.Load() {
+		return
+	}
+	h.autoAttachMu.Lock()
+	h.autoAttachPending = append(h.autoAttachPending, inst.ID)
+	h.autoAttachMu.Unlock()
+}
+
+// takeAutoAttach returns the first queued session that is still waiting and
+// clears the queue. Sessions that started waiting while the deck list wasn't
+// shown are dropped rather than attached later, when it would be a surprise.
+func (h *Home) takeAutoAttach() *session.Instance {
+	h.autoAttachMu.Lock()
+	pending := h.autoAttachPending
+	h.autoAttachPending = nil
+	h.autoAttachMu.Unlock()
+
+	if len(pending) == 0 || !h.deckListFocused() {
+		return nil
+	}
+	for i, id := range pending {
+		inst := h.getInstanceByID(id)
+		if inst == nil || inst.AutoAttach == session.AutoAttachOff ||
+			inst.GetStatusThreadSafe() != session.StatusWaiting || !inst.Exists() {
+			continue
+		}
+		// Keep the rest for the next tick (e.g. after detaching from this one)
+		if rest := pending[i+1:]; len(rest) > 0 {
+			h.autoAttachMu.Lock()
+			h.autoAttachPending = append(rest, h.autoAttachPending...)
+			h.autoAttachMu.Unlock()
+		}
+		return inst
+	}
+	return nil
+}
+
+// processAutoAttach attaches to (or offers to attach to) a session that just
+// started waiting. Runs on the main goroutine from the tick handler.
+func (h *Home) processAutoAttach() tea.Cmd {
+	inst := h.takeAutoAttach()
+	if inst == nil {
+		return nil
+	}
+	h.jumpToSession(inst)
+	if inst.AutoAttach == session.AutoAttachAsk {
+		h.confirmDialog.ShowAutoAttach(inst.ID, inst.Title)
+		return nil
+	}
+	return h.autoAttach(inst)
+}
+
+// autoAttach attaches to inst the same way enter does on the deck list
+func (h *Home) autoAttach(inst *session.Instance) tea.Cmd {
+	if session.GetTerminalSettings().AttachInNewWindow {
+		return h.attachInNewWindow(inst)
+	}
+	h.isAttaching.Store(true)
+	return h.attachSession(inst)
+}
+
+// deckListFocused reports whether the main session list is showing, with no
+// dialog, overlay or text input in front of it
+func (h *Home) deckListFocused() bool {
+	if h.initialLoading || h.isQuitting || h.isAttaching.Load() || h.previewSearching || h.inlineEdit.active() {
+		return false
+	}
+	return !h.setupWizard.IsVisible() &&
+		!h.settingsPanel.IsVisible() &&
+		!h.helpOverlay.IsVisible() &&
+		!h.pagerOverlay.IsVisible() &&
+		!h.approvalsInbox.IsVisible() &&
+		!h.statsView.IsVisible() &&
+		!h.timelineView.IsVisible() &&
+		!h.search.IsVisible() &&
+		!h.globalSearch.IsVisible() &&
+		!h.newDialog.IsVisible() &&
+		!h.groupDialog.IsVisible() &&
+		!h.forkDialog.IsVisible() &&
+		!h.confirmDialog.IsVisible() &&
+		!h.mcpDialog.IsVisible() &&
+		!h.modelDialog.IsVisible() &&
+		!h.sessionPickerDialog.IsVisible() &&
+		!h.relocateDialog.IsVisible() &&
+		!h.handoffDialog.IsVisible() &&
+		!h.contextFilesDialog.IsVisible() &&
+		!h.snapshotBrowser.IsVisible() &&
+		!h.bulkMoveDialog.IsVisible()
+}
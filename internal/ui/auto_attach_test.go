@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestQueueAutoAttach(t *testing.T) {
+	home, work, other := newFocusTestHome(t)
+	work.AutoAttach = session.AutoAttachAsk
+
+	home.queueAutoAttach(work, session.StatusIdle, session.StatusWaiting)
+	home.queueAutoAttach(work, session.StatusWaiting, session.StatusIdle)
+	home.queueAutoAttach(other, session.StatusRunning, session.StatusWaiting)
+	if len(home.autoAttachPending) != 0 {
+		t.Fatalf("only running -> waiting with auto-attach on should queue, got %v", home.autoAttachPending)
+	}
+
+	home.isAttaching.Store(true)
+	home.queueAutoAttach(work, session.StatusRunning, session.StatusWaiting)
+	home.isAttaching.Store(false)
+	if len(home.autoAttachPending) != 0 {
+		t.Fatal("transitions while attached should be ignored")
+	}
+
+	home.queueAutoAttach(work, session.StatusRunning, session.StatusWaiting)
+	if len(home.autoAttachPending) != 1 || home.autoAttachPending[0] != work.ID {
+		t.Errorf("pending = %v, want [%s]", home.autoAttachPending, work.ID)
+	}
+}
+
+func TestTakeAutoAttachSkipsWhenNotOnDeckList(t *testing.T) {
+	home, work, _ := newFocusTestHome(t)
+	work.AutoAttach = session.AutoAttachAlways
+	work.SetStatusThreadSafe(session.StatusWaiting)
+
+	home.helpOverlay.Show()
+	home.queueAutoAttach(work, session.StatusRunning, session.StatusWaiting)
+	if inst := home.takeAutoAttach(); inst != nil {
+		t.Errorf("should not auto-attach behind an overlay, got %s", inst.Title)
+	}
+	if len(home.autoAttachPending) != 0 {
+		t.Error("transitions seen behind an overlay should be dropped")
+	}
+
+	// No tmux session behind it, so even on the deck list nothing is attached
+	home.helpOverlay.Hide()
+	home.queueAutoAttach(work, session.StatusRunning, session.StatusWaiting)
+	if cmd := home.processAutoAttach(); cmd != nil || home.confirmDialog.IsVisible() {
+		t.Error("sessions without a running tmux session should be skipped")
+	}
+}
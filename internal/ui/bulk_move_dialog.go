@@ -0,0 +1,307 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// bulkMoveListLimit caps the marked sessions and matching groups listed
+const bulkMoveListLimit = 6
+
+// BulkMoveDialog moves every marked session (space) to one group. The target
+// is typed with completion from existing group paths; a path that doesn't
+// exist yet is created. Opened by m while sessions are marked.
+type BulkMoveDialog struct {
+	visible       bool
+	width, height int
+	sessionIDs    []string
+	titles        []string
+	groups        []string // existing group paths
+	matches       []string // groups matching the input
+	cursor        int      // highlighted match, -1 = use the typed path
+	input         textinput.Model
+}
+
+// NewBulkMoveDialog creates a new bulk move dialog
+func NewBulkMoveDialog() *BulkMoveDialog {
+	ti := textinput.New()
+	ti.Placeholder = "group/path"
+	ti.CharLimit = 200
+	ti.Width = 40
+	ti.ShowSuggestions = true
+	return &BulkMoveDialog{input: ti}
+}
+
+// Show opens the dialog for the marked sessions
+func (d *BulkMoveDialog) Show(sessions []*session.Instance, groupPaths []string) {
+	d.visible = true
+	d.sessionIDs = d.sessionIDs[:0]
+	d.titles = d.titles[:0]
+	for _, inst := range sessions {
+		d.sessionIDs = append(d.sessionIDs, inst.ID)
+		d.titles = append(d.titles, inst.Title)
+	}
+	d.groups = groupPaths
+	d.input.SetSuggestions(groupPaths)
+	d.input.SetValue("")
+	d.input.Focus()
+	d.refreshMatches()
+}
+
+// Hide closes the dialog
+func (d *BulkMoveDialog) Hide() {
+	d.visible = false
+	d.input.Blur()
+}
+
+// IsVisible returns whether the dialog is shown
+func (d *BulkMoveDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering
+func (d *BulkMoveDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// Target returns the group path to move to: the highlighted match, else the
+// typed path
+func (d *BulkMoveDialog) Target() string {
+	if d.cursor >= 0 && d.cursor < len(d.matches) {
+		return d.matches[d.cursor]
+	}
+	return strings.Trim(strings.TrimSpace(d.input.Value()), "/")
+}
+
+// SessionIDs returns the sessions being moved
+func (d *BulkMoveDialog) SessionIDs() []string {
+	return d.sessionIDs
+}
+
+// refreshMatches lists the groups containing the typed text, prefix matches
+// first
+func (d *BulkMoveDialog) refreshMatches() {
+	query := strings.ToLower(strings.TrimSpace(d.input.Value()))
+	var prefix, contains []string
+	for _, g := range d.groups {
+		lower := strings.ToLower(g)
+		switch {
+		case strings.HasPrefix(lower, query):
+			prefix = append(prefix, g)
+		case strings.Contains(lower, query):
+			contains = append(contains, g)
+		}
+	}
+	d.matches = append(prefix, contains...)
+	d.cursor = -1
+	if query == "" && len(d.matches) > 0 {
+		d.cursor = 0
+	}
+}
+
+// isNewGroup reports whether the target doesn't exist yet
+func (d *BulkMoveDialog) isNewGroup() bool {
+	target := d.Target()
+	for _, g := range d.groups {
+		if g == target {
+			return false
+		}
+	}
+	return target != ""
+}
+
+// Update handles navigation and typing
+func (d *BulkMoveDialog) Update(msg tea.KeyMsg) (*BulkMoveDialog, tea.Cmd) {
+	switch msg.String() {
+	case "down", "ctrl+n":
+		if d.cursor < len(d.matches)-1 {
+			d.cursor++
+		}
+		return d, nil
+	case "up", "ctrl+p":
+		if d.cursor >= 0 {
+			d.cursor--
+		}
+		return d, nil
+	case "tab":
+		// Complete to the highlighted (or first) match and keep typing
+		if len(d.matches) > 0 {
+			i := d.cursor
+			if i < 0 {
+				i = 0
+			}
+			d.input.SetValue(d.matches[i])
+			d.input.CursorEnd()
+			d.refreshMatches()
+		}
+		return d, nil
+	}
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	d.refreshMatches()
+	return d, cmd
+}
+
+// View renders the dialog
+func (d *BulkMoveDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	selectedStyle := lipgloss.NewStyle().Foreground(ColorBg).Background(ColorAccent).Bold(true).Padding(0, 1)
+	normalStyle := lipgloss.NewStyle().Foreground(ColorText).Padding(0, 1)
+	newStyle := lipgloss.NewStyle().Foreground(ColorGreen)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("Move %d Sessions", len(d.sessionIDs))), ""}
+	for i, title := range d.titles {
+		if i == bulkMoveListLimit {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("  … and %d more", len(d.titles)-i)))
+			break
+		}
+		lines = append(lines, dimStyle.Render("  • "+title))
+	}
+	lines = append(lines, "", d.input.View())
+
+	for i, g := range d.matches {
+		if i == bulkMoveListLimit {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("  … %d more groups", len(d.matches)-i)))
+			break
+		}
+		if i == d.cursor {
+			lines = append(lines, selectedStyle.Render(g))
+		} else {
+			lines = append(lines, normalStyle.Render(g))
+		}
+	}
+	if d.isNewGroup() {
+		lines = append(lines, newStyle.Render("+ new group "+d.Target()))
+	}
+	lines = append(lines, "", footerStyle.Render("Enter move | Tab complete | ↑↓ pick | Esc cancel"))
+
+	dialogWidth := 56
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = d.width - 10
+		if dialogWidth < 30 {
+			dialogWidth = 30
+		}
+	}
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+	return centerInScreen(box, d.width, d.height)
+}
+
+// toggleMark marks or unmarks the selected session for bulk actions and
+// moves the cursor down, so a run of sessions can be marked by holding space
+func (h *Home) toggleMark() {
+	inst := h.getSelectedSession()
+	if inst == nil {
+		return
+	}
+	if h.markedSessions[inst.ID] {
+		delete(h.markedSessions, inst.ID)
+	} else {
+		h.markedSessions[inst.ID] = true
+	}
+	h.moveToAdjacentSession(1)
+	if n := len(h.markedSessions); n > 0 {
+		h.setError(fmt.Errorf("%d marked · m to move · Esc to clear", n))
+	} else {
+		h.clearError()
+	}
+}
+
+// markedInstances returns the marked sessions in deck order, dropping marks
+// of sessions that no longer exist
+func (h *Home) markedInstances() []*session.Instance {
+	var marked []*session.Instance
+	seen := make(map[string]bool, len(h.markedSessions))
+	for _, inst := range h.groupTree.GetAllInstances() {
+		if h.markedSessions[inst.ID] {
+			marked = append(marked, inst)
+			seen[inst.ID] = true
+		}
+	}
+	for id := range h.markedSessions {
+		if !seen[id] {
+			delete(h.markedSessions, id)
+		}
+	}
+	return marked
+}
+
+// showBulkMove opens the bulk move dialog for the marked sessions
+func (h *Home) showBulkMove() {
+	marked := h.markedInstances()
+	if len(marked) == 0 {
+		return
+	}
+	h.bulkMoveDialog.SetSize(h.width, h.height)
+	h.bulkMoveDialog.Show(marked, h.groupTree.GetGroupPaths())
+}
+
+// bulkMove moves the sessions to path, creating the group if needed, and
+// saves once
+func (h *Home) bulkMove(ids []string, path string) int {
+	if _, exists := h.groupTree.Groups[path]; !exists {
+		if group := h.groupTree.CreateGroupPath(path); group != nil {
+			path = group.Path
+		}
+	}
+	moved := 0
+	var last *session.Instance
+	for _, id := range ids {
+		inst := h.getInstanceByID(id)
+		if inst == nil {
+			continue
+		}
+		if inst.GroupPath != path {
+			h.groupTree.MoveSessionToGroup(inst, path)
+			moved++
+		}
+		last = inst
+	}
+	h.instancesMu.Lock()
+	h.instances = h.groupTree.GetAllInstances()
+	h.instancesMu.Unlock()
+	h.rebuildFlatItems()
+	if last != nil {
+		h.jumpToSession(last)
+	}
+	h.saveInstances()
+	uiLog.Info("sessions_bulk_moved", slog.Int("count", moved), slog.String("group", path))
+	return moved
+}
+
+// handleBulkMoveKey handles keys while the bulk move dialog is shown
+func (h *Home) handleBulkMoveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		path := h.bulkMoveDialog.Target()
+		if path == "" {
+			return h, nil
+		}
+		ids := h.bulkMoveDialog.SessionIDs()
+		h.bulkMoveDialog.Hide()
+		moved := h.bulkMove(ids, path)
+		h.markedSessions = make(map[string]bool)
+		h.setError(fmt.Errorf("moved %d session(s) to %s", moved, path))
+		return h, nil
+	case "esc":
+		h.bulkMoveDialog.Hide()
+		return h, nil
+	}
+	_, cmd := h.bulkMoveDialog.Update(msg)
+	return h, cmd
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBulkMove(t *testing.T) {
+	home, work, other := newFocusTestHome(t)
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	home.jumpToSession(work)
+	home.Update(space)
+	home.jumpToSession(other)
+	home.Update(space)
+	if len(home.markedSessions) != 2 {
+		t.Fatalf("marked = %v, want both sessions", home.markedSessions)
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if !home.bulkMoveDialog.IsVisible() {
+		t.Fatal("m with marked sessions should open the bulk move dialog")
+	}
+	if got := len(home.bulkMoveDialog.SessionIDs()); got != 2 {
+		t.Errorf("dialog lists %d sessions, want 2", got)
+	}
+	for _, r := range "clients/acme" {
+		home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if !home.bulkMoveDialog.isNewGroup() {
+		t.Error("a path that doesn't exist should be offered as a new group")
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if home.bulkMoveDialog.IsVisible() {
+		t.Error("enter should close the dialog")
+	}
+	if work.GroupPath != "clients/acme" || other.GroupPath != "clients/acme" {
+		t.Errorf("groups = %q, %q; want both in clients/acme", work.GroupPath, other.GroupPath)
+	}
+	if _, ok := home.groupTree.Groups["clients/acme"]; !ok {
+		t.Error("target group should have been created")
+	}
+	if len(home.markedSessions) != 0 {
+		t.Error("marks should clear after the move")
+	}
+}
+
+func TestBulkMoveDialogCompletion(t *testing.T) {
+	d := NewBulkMoveDialog()
+	d.Show(nil, []string{"work", "work/frontend", "oss"})
+	if d.Target() != "work" {
+		t.Errorf("empty input should highlight the first group, got %q", d.Target())
+	}
+	for _, r := range "front" {
+		d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(d.matches) != 1 || d.matches[0] != "work/frontend" {
+		t.Errorf("matches = %v, want [work/frontend]", d.matches)
+	}
+	if d.Target() != "front" {
+		t.Errorf("typing should target the typed path until a match is picked, got %q", d.Target())
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if d.Target() != "work/frontend" || d.isNewGroup() {
+		t.Errorf("tab should complete to work/frontend, got %q", d.Target())
+	}
+}
@@ -89,6 +89,11 @@ func (p *ClaudeOptionsPanel) AtTop() bool {
 	return p.focusIndex <= 0
 }
 
+// ToggleSkipPermissions flips the skip permissions checkbox
+func (p *ClaudeOptionsPanel) ToggleSkipPermissions() {
+	p.skipPermissions = !p.skipPermissions
+}
+
 // GetOptions returns current options as ClaudeOptions
 func (p *ClaudeOptionsPanel) GetOptions() *session.ClaudeOptions {
 	opts := &session.ClaudeOptions{
@@ -17,6 +17,10 @@ const (
 	ConfirmDeleteGroup
 	ConfirmQuitWithPool
 	ConfirmCreateDirectory
+	ConfirmAutoAttach
+	ConfirmKillTmux
+	ConfirmMergeGroup
+	ConfirmYoloSandbox
 )
 
 // ConfirmDialog handles confirmation for destructive actions
@@ -29,6 +33,17 @@ type ConfirmDialog struct {
 	height      int
 	mcpCount    int // Number of running MCPs (for quit confirmation)
 
+	// keepTmux is set when deleting a session only removes the record
+	// ([confirm] delete_kills_tmux = false)
+	keepTmux bool
+
+	// Pending group merge (for ConfirmMergeGroup)
+	mergeInto  string
+	mergeCount int
+
+	// Container offered for a YOLO session (for ConfirmYoloSandbox)
+	sandboxContainer string
+
 	// Pending session creation data (for ConfirmCreateDirectory)
 	pendingSessionName      string
 	pendingSessionPath      string
@@ -42,12 +57,14 @@ func NewConfirmDialog() *ConfirmDialog {
 	return &ConfirmDialog{}
 }
 
-// ShowDeleteSession shows confirmation for session deletion
-func (c *ConfirmDialog) ShowDeleteSession(sessionID, sessionName string) {
+// ShowDeleteSession shows confirmation for session deletion. keepTmux says
+// the tmux session will be left running.
+func (c *ConfirmDialog) ShowDeleteSession(sessionID, sessionName string, keepTmux bool) {
 	c.visible = true
 	c.confirmType = ConfirmDeleteSession
 	c.targetID = sessionID
 	c.targetName = sessionName
+	c.keepTmux = keepTmux
 }
 
 // ShowDeleteGroup shows confirmation for group deletion
@@ -80,6 +97,47 @@ func (c *ConfirmDialog) ShowCreateDirectory(path, sessionName, command, groupPat
 	c.pendingToolOptionsJSON = toolOptionsJSON
 }
 
+// ShowAutoAttach offers to attach to a session that just started waiting
+func (c *ConfirmDialog) ShowAutoAttach(sessionID, sessionName string) {
+	c.visible = true
+	c.confirmType = ConfirmAutoAttach
+	c.targetID = sessionID
+	c.targetName = sessionName
+}
+
+// ShowKillTmux shows confirmation for stopping a session (killing its tmux
+// session but keeping it in the deck)
+func (c *ConfirmDialog) ShowKillTmux(sessionID, sessionName string) {
+	c.visible = true
+	c.confirmType = ConfirmKillTmux
+	c.targetID = sessionID
+	c.targetName = sessionName
+}
+
+// ShowMergeGroup shows confirmation for merging group src into dst
+func (c *ConfirmDialog) ShowMergeGroup(src, dst string, sessions int) {
+	c.visible = true
+	c.confirmType = ConfirmMergeGroup
+	c.targetID = src
+	c.targetName = src
+	c.mergeInto = dst
+	c.mergeCount = sessions
+}
+
+// ShowYoloSandbox offers to run a YOLO session in the [yolo_sandbox] container
+func (c *ConfirmDialog) ShowYoloSandbox(sessionName, container string) {
+	c.visible = true
+	c.confirmType = ConfirmYoloSandbox
+	c.targetID = ""
+	c.targetName = sessionName
+	c.sandboxContainer = container
+}
+
+// GetMergeInto returns the destination group of a pending merge
+func (c *ConfirmDialog) GetMergeInto() string {
+	return c.mergeInto
+}
+
 // GetPendingSession returns the pending session creation data
 func (c *ConfirmDialog) GetPendingSession() (name, path, command, groupPath string, toolOptionsJSON json.RawMessage) {
 	return c.pendingSessionName, c.pendingSessionPath, c.pendingSessionCommand, c.pendingSessionGroupPath, c.pendingToolOptionsJSON
@@ -140,6 +198,10 @@ func (c *ConfirmDialog) View() string {
 		title = "⚠️  Delete Session?"
 		warning = fmt.Sprintf("This will PERMANENTLY KILL the tmux session:\n\n  \"%s\"", c.targetName)
 		details = "• The tmux session will be terminated\n• Any running processes will be killed\n• Terminal history will be lost\n• Press Ctrl+Z after deletion to undo"
+		if c.keepTmux {
+			warning = fmt.Sprintf("This will remove the session from the deck:\n\n  \"%s\"", c.targetName)
+			details = "• The tmux session keeps running\n• Reattach with tmux directly\n• Press Ctrl+Z after deletion to undo"
+		}
 		borderColor = ColorRed
 
 		buttonYes := lipgloss.NewStyle().
@@ -228,6 +290,98 @@ func (c *ConfirmDialog) View() string {
 			Foreground(ColorTextDim).
 			Render("(Esc to cancel)")
 		buttons = lipgloss.JoinHorizontal(lipgloss.Center, buttonYes, "  ", buttonNo, "  ", escHint)
+
+	case ConfirmKillTmux:
+		title = "⚠️  Stop Session?"
+		warning = fmt.Sprintf("This will kill the tmux session:\n\n  \"%s\"", c.targetName)
+		details = "• Any running processes will be killed\n• The session stays in the deck\n• Press R to start it again"
+		borderColor = ColorRed
+
+		buttonYes := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorRed).
+			Padding(0, 2).
+			Bold(true).
+			Render("y Stop")
+		buttonNo := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorAccent).
+			Padding(0, 2).
+			Bold(true).
+			Render("n Cancel")
+		escHint := lipgloss.NewStyle().
+			Foreground(ColorTextDim).
+			Render("(Esc to cancel)")
+		buttons = lipgloss.JoinHorizontal(lipgloss.Center, buttonYes, "  ", buttonNo, "  ", escHint)
+
+	case ConfirmMergeGroup:
+		title = "⚠️  Merge Groups?"
+		warning = fmt.Sprintf("Move %d sessions from \"%s\"\ninto \"%s\"?", c.mergeCount, c.targetName, c.mergeInto)
+		details = "• Subgroups move along with their sessions\n• The emptied group is deleted"
+		borderColor = ColorYellow
+
+		buttonYes := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorYellow).
+			Padding(0, 2).
+			Bold(true).
+			Render("y Merge")
+		buttonNo := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorAccent).
+			Padding(0, 2).
+			Bold(true).
+			Render("n Cancel")
+		escHint := lipgloss.NewStyle().
+			Foreground(ColorTextDim).
+			Render("(Esc to cancel)")
+		buttons = lipgloss.JoinHorizontal(lipgloss.Center, buttonYes, "  ", buttonNo, "  ", escHint)
+
+	case ConfirmYoloSandbox:
+		title = "🛡  Sandbox YOLO Session?"
+		warning = fmt.Sprintf("\"%s\" will skip approval prompts.", c.targetName)
+		details = fmt.Sprintf("Run it in a container (%s)\nwith only the project mounted?", c.sandboxContainer)
+		borderColor = ColorYellow
+
+		buttonYes := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorGreen).
+			Padding(0, 2).
+			Bold(true).
+			Render("y Sandbox")
+		buttonNo := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorYellow).
+			Padding(0, 2).
+			Bold(true).
+			Render("n Host")
+		escHint := lipgloss.NewStyle().
+			Foreground(ColorTextDim).
+			Render("(Esc to cancel)")
+		buttons = lipgloss.JoinHorizontal(lipgloss.Center, buttonYes, "  ", buttonNo, "  ", escHint)
+
+	case ConfirmAutoAttach:
+		title = "◐  Session Waiting"
+		warning = fmt.Sprintf("\"%s\" is waiting for input.", c.targetName)
+		details = "Attach now? (turn off with\n`agent-deck session set <id> auto-attach off`)"
+		borderColor = ColorYellow
+
+		buttonYes := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorGreen).
+			Padding(0, 2).
+			Bold(true).
+			Render("y Attach")
+		buttonNo := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorAccent).
+			Padding(0, 2).
+			Bold(true).
+			Render("n Not now")
+		escHint := lipgloss.NewStyle().
+			Foreground(ColorTextDim).
+			Render("(Esc to dismiss)")
+		buttons = lipgloss.JoinHorizontal(lipgloss.Center, buttonYes, "  ", buttonNo, "  ", escHint)
 	}
 
 	// Title style
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestConfirmDialogDeleteKeepTmux(t *testing.T) {
+	d := NewConfirmDialog()
+	d.ShowDeleteSession("id", "work", false)
+	if !strings.Contains(d.View(), "KILL the tmux session") {
+		t.Error("default delete should warn that tmux is killed")
+	}
+	d.ShowDeleteSession("id", "work", true)
+	if view := d.View(); strings.Contains(view, "KILL") || !strings.Contains(view, "keeps running") {
+		t.Errorf("delete that keeps tmux should say so, got:\n%s", view)
+	}
+}
+
+func TestStopKeyNeedsRunningSession(t *testing.T) {
+	home, work, _ := newFocusTestHome(t)
+	for i, item := range home.flatItems {
+		if item.Session == work {
+			home.cursor = i
+		}
+	}
+	_, cmd := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	if cmd != nil || home.confirmDialog.IsVisible() {
+		t.Error("X on a session without tmux should not stop or ask")
+	}
+}
@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// ContextFilesDialog lists a session's context files (key files and paths
+// it's about) for adding, removing and typing them into the session
+type ContextFilesDialog struct {
+	visible       bool
+	adding        bool
+	width, height int
+	cursor        int
+	sessionID     string
+	title         string
+	files         []string
+	missing       map[string]bool
+	input         textinput.Model
+}
+
+// NewContextFilesDialog creates a new context files dialog
+func NewContextFilesDialog() *ContextFilesDialog {
+	ti := textinput.New()
+	ti.Placeholder = "path, relative to the project (e.g. internal/auth/)"
+	ti.CharLimit = 512
+	ti.Width = 56
+	return &ContextFilesDialog{input: ti}
+}
+
+// Show opens the dialog for inst
+func (d *ContextFilesDialog) Show(inst *session.Instance) {
+	d.visible = true
+	d.sessionID = inst.ID
+	d.title = inst.Title
+	d.cursor = 0
+	d.refresh(inst)
+	d.adding = len(d.files) == 0
+	d.input.SetValue("")
+	if d.adding {
+		d.input.Focus()
+	}
+}
+
+// refresh reloads the list from inst, checking which files are gone
+func (d *ContextFilesDialog) refresh(inst *session.Instance) {
+	d.files = append([]string(nil), inst.ContextFiles...)
+	d.missing = make(map[string]bool)
+	for _, f := range d.files {
+		if _, err := os.Stat(inst.ContextFilePath(f)); err != nil {
+			d.missing[f] = true
+		}
+	}
+	if d.cursor >= len(d.files) {
+		d.cursor = max(len(d.files)-1, 0)
+	}
+}
+
+// Hide closes the dialog
+func (d *ContextFilesDialog) Hide() {
+	d.visible = false
+	d.adding = false
+	d.input.Blur()
+}
+
+// IsVisible returns whether the dialog is shown
+func (d *ContextFilesDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering
+func (d *ContextFilesDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// View renders the dialog
+func (d *ContextFilesDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	missingStyle := lipgloss.NewStyle().Foreground(ColorRed)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+
+	lines := []string{
+		titleStyle.Render("📎  Context files"),
+		dimStyle.Render(fmt.Sprintf("\"%s\", {files} in prompts", d.title)),
+		"",
+	}
+	if len(d.files) == 0 {
+		lines = append(lines, dimStyle.Render("  No files yet"))
+	}
+	for n, f := range d.files {
+		line := "  " + f
+		if n == d.cursor && !d.adding {
+			line = selectedStyle.Render("> " + f)
+		}
+		if d.missing[f] {
+			line += missingStyle.Render("  (missing)")
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "")
+	if d.adding {
+		lines = append(lines, d.input.View(), "", footerStyle.Render("Enter add | Esc back"))
+	} else {
+		lines = append(lines, footerStyle.Render("a add | d remove | i type into session | Esc close"))
+	}
+
+	dialogWidth := 64
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = d.width - 10
+		if dialogWidth < 30 {
+			dialogWidth = 30
+		}
+	}
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+	return centerInScreen(box, d.width, d.height)
+}
+
+// showContextFiles opens the context files dialog for the selected session
+func (h *Home) showContextFiles() tea.Cmd {
+	inst := h.getSelectedSession()
+	if inst == nil {
+		return nil
+	}
+	h.contextFilesDialog.SetSize(h.width, h.height)
+	h.contextFilesDialog.Show(inst)
+	if h.contextFilesDialog.adding {
+		return textinput.Blink
+	}
+	return nil
+}
+
+// handleContextFilesDialogKey handles keys while the context files dialog is shown
+func (h *Home) handleContextFilesDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := h.contextFilesDialog
+	inst := h.getInstanceByID(d.sessionID)
+	if inst == nil {
+		d.Hide()
+		return h, nil
+	}
+
+	if d.adding {
+		switch msg.String() {
+		case "enter":
+			if added := inst.AddContextFiles(d.input.Value()); len(added) > 0 {
+				h.invalidatePreviewCache(inst.ID)
+				h.saveInstances()
+			}
+			d.input.SetValue("")
+			d.adding = false
+			d.input.Blur()
+			d.refresh(inst)
+			d.cursor = max(len(d.files)-1, 0)
+			return h, nil
+		case "esc":
+			d.adding = false
+			d.input.Blur()
+			return h, nil
+		}
+		var cmd tea.Cmd
+		d.input, cmd = d.input.Update(msg)
+		return h, cmd
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j":
+		if d.cursor < len(d.files)-1 {
+			d.cursor++
+		}
+	case "a":
+		d.adding = true
+		return h, d.input.Focus()
+	case "d", "x":
+		if d.cursor < len(d.files) {
+			inst.RemoveContextFiles(d.files[d.cursor])
+			h.invalidatePreviewCache(inst.ID)
+			h.saveInstances()
+			d.refresh(inst)
+		}
+	case "i":
+		d.Hide()
+		return h, h.typeContextFiles(inst)
+	case "esc", "q":
+		d.Hide()
+	}
+	return h, nil
+}
+
+// typeContextFiles types the session's context files into its pane, without
+// Enter, so they can be finished into a prompt after attaching
+func (h *Home) typeContextFiles(inst *session.Instance) tea.Cmd {
+	text := inst.ContextFilesText()
+	if text == "" {
+		h.setError(fmt.Errorf("'%s' has no context files", inst.Title))
+		return nil
+	}
+	tmuxSession := inst.GetTmuxSession()
+	if tmuxSession == nil || !inst.Exists() {
+		h.setError(fmt.Errorf("'%s' isn't running", inst.Title))
+		return nil
+	}
+	if err := tmuxSession.SendKeys(text + " "); err != nil {
+		h.setError(fmt.Errorf("failed to type files into '%s': %w", inst.Title, err))
+		return nil
+	}
+	h.setError(fmt.Errorf("typed %d file(s) into '%s'; attach to finish the prompt", len(inst.ContextFiles), inst.Title))
+	return nil
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestContextFilesDialog(t *testing.T) {
+	home, work, _ := newFocusTestHome(t)
+	for i, item := range home.flatItems {
+		if item.Session == work {
+			home.cursor = i
+		}
+	}
+
+	// With no files yet it opens on the path input
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	if !home.contextFilesDialog.IsVisible() || !home.contextFilesDialog.adding {
+		t.Fatal("O should open the dialog, adding a file")
+	}
+	for _, r := range "/tmp/work/internal/auth" {
+		home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	for _, r := range "README.md" {
+		home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if want := []string{"internal/auth", "README.md"}; !reflect.DeepEqual(work.ContextFiles, want) {
+		t.Fatalf("files = %v, want %v", work.ContextFiles, want)
+	}
+
+	// d removes the selected one (the last added)
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if want := []string{"internal/auth"}; !reflect.DeepEqual(work.ContextFiles, want) {
+		t.Errorf("after d: %v, want %v", work.ContextFiles, want)
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if home.contextFilesDialog.IsVisible() {
+		t.Error("esc should close the dialog")
+	}
+
+	if view := home.renderPreviewPane(80, 20); !strings.Contains(view, "internal/auth") {
+		t.Errorf("preview should list the files, got:\n%s", view)
+	}
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// diffFetchedMsg is sent when an async git diff of a session's project completes
+type diffFetchedMsg struct {
+	sessionTitle string
+	projectPath  string
+	stat         string
+	diff         string
+	err          error
+}
+
+// fetchSessionDiff returns a tea.Cmd that collects `git diff` stat and full output for the session's project
+func (h *Home) fetchSessionDiff(inst *session.Instance) tea.Cmd {
+	title := inst.Title
+	path := inst.ProjectPath
+	return func() tea.Msg {
+		msg := diffFetchedMsg{sessionTitle: title, projectPath: path}
+		if !git.IsGitRepo(path) {
+			msg.err = fmt.Errorf("%s is not a git repository", path)
+			return msg
+		}
+		msg.stat, msg.err = git.GetDiffStat(path)
+		if msg.err != nil {
+			return msg
+		}
+		msg.diff, msg.err = git.GetDiff(path)
+		return msg
+	}
+}
+
+// formatDiffContent combines the stat summary and full diff into pager content
+func formatDiffContent(stat, diff string) string {
+	if strings.TrimSpace(stat) == "" && strings.TrimSpace(diff) == "" {
+		return "No uncommitted changes"
+	}
+	if diff == "" {
+		return stat
+	}
+	return stat + "\n\n" + diff
+}
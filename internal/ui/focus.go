@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// isInFocusGroup reports whether groupPath is the focused group or one of its subgroups
+func isInFocusGroup(groupPath, focusPath string) bool {
+	return groupPath == focusPath || strings.HasPrefix(groupPath, focusPath+"/")
+}
+
+// applyFocusFilter keeps only items belonging to the focused group (including subgroups)
+// plus pinned sessions and the groups needed to display them.
+// Parent groups of the focused group are dropped to keep the list uncluttered.
+func applyFocusFilter(items []session.Item, focusPath string, pinned map[string]bool) []session.Item {
+	// Groups that must stay visible because they contain a pinned session outside the focus
+	pinnedGroups := make(map[string]bool)
+	for _, item := range items {
+		if item.Type != session.ItemTypeSession || item.Session == nil {
+			continue
+		}
+		if !pinned[item.Session.ID] || isInFocusGroup(item.Path, focusPath) {
+			continue
+		}
+		parts := strings.Split(item.Path, "/")
+		for i := range parts {
+			pinnedGroups[strings.Join(parts[:i+1], "/")] = true
+		}
+	}
+
+	filtered := make([]session.Item, 0, len(items))
+	for _, item := range items {
+		switch item.Type {
+		case session.ItemTypeGroup:
+			if isInFocusGroup(item.Path, focusPath) || pinnedGroups[item.Path] {
+				filtered = append(filtered, item)
+			}
+		case session.ItemTypeSession:
+			if item.Session == nil {
+				continue
+			}
+			if isInFocusGroup(item.Path, focusPath) || pinned[item.Session.ID] {
+				filtered = append(filtered, item)
+			}
+		}
+	}
+	return filtered
+}
+
+// toggleFocus enters focus mode on the group under the cursor, or exits focus mode
+// if it is already active. Returns true if focus mode is now active.
+func (h *Home) toggleFocus() bool {
+	if h.focusGroupPath != "" {
+		h.focusGroupPath = ""
+		h.rebuildFlatItems()
+		return false
+	}
+	if h.cursor >= len(h.flatItems) {
+		return false
+	}
+	// Item.Path is the group path for both group headers and sessions
+	h.focusGroupPath = h.flatItems[h.cursor].Path
+	if h.focusGroupPath == "" {
+		return false
+	}
+	h.rebuildFlatItems()
+	return true
+}
+
+// togglePin pins or unpins a session. Pinned sessions stay visible in focus mode
+// even when they live outside the focused group.
+func (h *Home) togglePin(inst *session.Instance) {
+	if inst == nil {
+		return
+	}
+	if h.pinnedSessions[inst.ID] {
+		delete(h.pinnedSessions, inst.ID)
+	} else {
+		h.pinnedSessions[inst.ID] = true
+	}
+	if h.focusGroupPath != "" {
+		h.rebuildFlatItems()
+	}
+}
+
+// pinnedSessionIDs returns pinned session IDs that still exist, for persistence
+func (h *Home) pinnedSessionIDs() []string {
+	ids := make([]string, 0, len(h.pinnedSessions))
+	for id := range h.pinnedSessions {
+		if _, ok := h.instanceByID[id]; ok || len(h.instanceByID) == 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
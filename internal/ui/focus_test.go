@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func newFocusTestHome(t *testing.T) (*Home, *session.Instance, *session.Instance) {
+	t.Helper()
+	home := NewHome()
+	home.width = 100
+	home.height = 30
+	home.initialLoading = false
+
+	work := session.NewInstance("work-session", "/tmp/work")
+	work.GroupPath = "work"
+	other := session.NewInstance("other-session", "/tmp/other")
+	other.GroupPath = "other"
+
+	home.instancesMu.Lock()
+	home.instances = []*session.Instance{work, other}
+	home.instanceByID = map[string]*session.Instance{work.ID: work, other.ID: other}
+	home.instancesMu.Unlock()
+	home.groupTree = session.NewGroupTree(home.instances)
+	home.rebuildFlatItems()
+	return home, work, other
+}
+
+func flatSessionIDs(h *Home) map[string]bool {
+	ids := make(map[string]bool)
+	for _, item := range h.flatItems {
+		if item.Type == session.ItemTypeSession && item.Session != nil {
+			ids[item.Session.ID] = true
+		}
+	}
+	return ids
+}
+
+func TestFocusModeHidesOtherGroups(t *testing.T) {
+	home, work, other := newFocusTestHome(t)
+
+	// Put cursor on the work session and enter focus mode
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession && item.Session.ID == work.ID {
+			home.cursor = i
+		}
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+
+	if home.focusGroupPath != "work" {
+		t.Fatalf("focusGroupPath = %q, want %q", home.focusGroupPath, "work")
+	}
+	ids := flatSessionIDs(home)
+	if !ids[work.ID] {
+		t.Error("focused group's session should be visible")
+	}
+	if ids[other.ID] {
+		t.Error("session outside focused group should be hidden")
+	}
+	for _, item := range home.flatItems {
+		if item.Type == session.ItemTypeGroup && item.Path == "other" {
+			t.Error("unfocused group header should be hidden")
+		}
+	}
+
+	// Pressing z again exits focus mode
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	if home.focusGroupPath != "" {
+		t.Errorf("focusGroupPath = %q after second z, want empty", home.focusGroupPath)
+	}
+	if !flatSessionIDs(home)[other.ID] {
+		t.Error("all sessions should be visible after exiting focus mode")
+	}
+}
+
+func TestFocusModeKeepsPinnedSessions(t *testing.T) {
+	home, _, other := newFocusTestHome(t)
+
+	home.togglePin(other)
+	home.focusGroupPath = "work"
+	home.rebuildFlatItems()
+
+	if !flatSessionIDs(home)[other.ID] {
+		t.Error("pinned session should stay visible in focus mode")
+	}
+	foundGroup := false
+	for _, item := range home.flatItems {
+		if item.Type == session.ItemTypeGroup && item.Path == "other" {
+			foundGroup = true
+		}
+	}
+	if !foundGroup {
+		t.Error("group of pinned session should be shown so the session renders in context")
+	}
+
+	home.togglePin(other)
+	if flatSessionIDs(home)[other.ID] {
+		t.Error("unpinned session should be hidden again in focus mode")
+	}
+}
+
+func TestFocusModeSubgroups(t *testing.T) {
+	if !isInFocusGroup("work/api", "work") {
+		t.Error("subgroup should be inside focused group")
+	}
+	if isInFocusGroup("workshop", "work") {
+		t.Error("sibling with shared prefix must not match")
+	}
+}
+
+func TestFocusModeClearsWhenGroupMissing(t *testing.T) {
+	home, _, _ := newFocusTestHome(t)
+	home.focusGroupPath = "deleted-group"
+	home.rebuildFlatItems()
+	if home.focusGroupPath != "" {
+		t.Errorf("focusGroupPath = %q, want cleared for missing group", home.focusGroupPath)
+	}
+}
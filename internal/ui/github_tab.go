@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/github"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+const (
+	// githubWorkTTL is how long a repository's open PRs and issues are cached;
+	// gh calls the API, so they're refreshed far less often than output
+	githubWorkTTL = 2 * time.Minute
+	// githubWorkLimit caps each of the PR and issue lists
+	githubWorkLimit = 10
+)
+
+// githubWorkEntry caches the open work of one project directory
+type githubWorkEntry struct {
+	work      *github.OpenWork
+	err       error
+	noRemote  bool
+	fetchedAt time.Time
+}
+
+// githubWorkFetchedMsg carries the result of fetchGitHubWork
+type githubWorkFetchedMsg struct {
+	path  string
+	entry *githubWorkEntry
+}
+
+// needsGitHubWork reports whether the GitHub tab should fetch open work for
+// inst: the tab is showing and the cache for its path is missing or stale
+func (h *Home) needsGitHubWork(inst *session.Instance) bool {
+	if inst == nil || h.previewMode != PreviewModeGitHub || session.IsDemoMode() {
+		return false
+	}
+	if h.githubFetchingPath == inst.ProjectPath {
+		return false
+	}
+	entry, ok := h.githubWork[inst.ProjectPath]
+	return !ok || time.Since(entry.fetchedAt) > githubWorkTTL
+}
+
+// fetchGitHubWork returns a command listing the open PRs and issues of the
+// session's repository, or nil when they're cached or already being fetched
+func (h *Home) fetchGitHubWork(inst *session.Instance) tea.Cmd {
+	if !h.needsGitHubWork(inst) {
+		return nil
+	}
+	path := inst.ProjectPath
+	h.githubFetchingPath = path
+	return func() tea.Msg {
+		entry := &githubWorkEntry{fetchedAt: time.Now()}
+		if !github.HasGitHubRemote(path) {
+			entry.noRemote = true
+		} else {
+			entry.work, entry.err = github.ListOpenWork(path, githubWorkLimit)
+		}
+		return githubWorkFetchedMsg{path: path, entry: entry}
+	}
+}
+
+// renderGitHubTab renders the preview pane's GitHub tab: the open PRs and
+// issues of the selected session's repository
+func (h *Home) renderGitHubTab(selected *session.Instance, width int) string {
+	var b strings.Builder
+	b.WriteString(renderSectionDivider("GitHub", width-4))
+	b.WriteString("\n")
+
+	dimStyle := lipgloss.NewStyle().Foreground(ColorText).Italic(true)
+	if session.IsDemoMode() {
+		b.WriteString(dimStyle.Render("Not available in demo mode"))
+		return b.String()
+	}
+	entry, ok := h.githubWork[selected.ProjectPath]
+	switch {
+	case !ok:
+		b.WriteString(dimStyle.Render("Loading open pull requests and issues..."))
+		return b.String()
+	case entry.noRemote:
+		b.WriteString(dimStyle.Render("No GitHub remote for this project"))
+		return b.String()
+	case entry.err != nil:
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorYellow).Render("⚠ " + TruncateWidth(entry.err.Error(), width-6)))
+		return b.String()
+	}
+
+	b.WriteString(renderWorkItems("Pull requests", entry.work.PullRequests, width))
+	b.WriteString("\n")
+	b.WriteString(renderWorkItems("Issues", entry.work.Issues, width))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("agent-deck add --issue #<n> . starts a session on an issue"))
+	return b.String()
+}
+
+// renderWorkItems renders one titled list of the GitHub tab
+func renderWorkItems(label string, items []github.WorkItem, width int) string {
+	var b strings.Builder
+	headerStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+	numberStyle := lipgloss.NewStyle().Foreground(ColorCyan)
+	authorStyle := lipgloss.NewStyle().Foreground(ColorComment)
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("%s (%d)", label, len(items))))
+	b.WriteString("\n")
+	if len(items) == 0 {
+		b.WriteString(authorStyle.Render("  none open"))
+		b.WriteString("\n")
+		return b.String()
+	}
+	for _, item := range items {
+		number := fmt.Sprintf("#%-5d", item.Number)
+		author := ""
+		if item.Author.Login != "" {
+			author = " @" + item.Author.Login
+		}
+		titleWidth := width - 4 - 2 - len(number) - 1 - len(author)
+		b.WriteString("  ")
+		b.WriteString(numberStyle.Render(number))
+		b.WriteString(" ")
+		b.WriteString(TruncateWidth(item.Title, titleWidth))
+		b.WriteString(authorStyle.Render(author))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/github"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func TestGitHubTabRendersOpenWork(t *testing.T) {
+	home := NewHome()
+	inst := session.NewInstance("api", "/tmp/api")
+
+	out := tmux.StripANSI(home.renderGitHubTab(inst, 80))
+	if !strings.Contains(out, "Loading") {
+		t.Errorf("uncached repo should show loading, got:\n%s", out)
+	}
+
+	work := &github.OpenWork{
+		PullRequests: []github.WorkItem{{Number: 41, Title: "Add retries"}},
+		Issues:       []github.WorkItem{{Number: 7, Title: "Crash on start"}},
+	}
+	work.PullRequests[0].Author.Login = "dana"
+	home.githubWork[inst.ProjectPath] = &githubWorkEntry{work: work, fetchedAt: time.Now()}
+	out = tmux.StripANSI(home.renderGitHubTab(inst, 80))
+	for _, want := range []string{"Pull requests (1)", "#41", "Add retries", "@dana", "Issues (1)", "#7", "Crash on start"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("GitHub tab missing %q:\n%s", want, out)
+		}
+	}
+
+	home.githubWork[inst.ProjectPath] = &githubWorkEntry{noRemote: true, fetchedAt: time.Now()}
+	if out := tmux.StripANSI(home.renderGitHubTab(inst, 80)); !strings.Contains(out, "No GitHub remote") {
+		t.Errorf("expected no-remote message, got:\n%s", out)
+	}
+
+	home.githubWork[inst.ProjectPath] = &githubWorkEntry{err: errors.New("gh is not installed"), fetchedAt: time.Now()}
+	if out := tmux.StripANSI(home.renderGitHubTab(inst, 80)); !strings.Contains(out, "gh is not installed") {
+		t.Errorf("expected error, got:\n%s", out)
+	}
+}
+
+func TestNeedsGitHubWork(t *testing.T) {
+	home := NewHome()
+	inst := session.NewInstance("api", "/tmp/api")
+
+	if home.needsGitHubWork(inst) {
+		t.Error("should not fetch while the GitHub tab is hidden")
+	}
+	home.previewMode = PreviewModeGitHub
+	if !home.needsGitHubWork(inst) {
+		t.Error("should fetch an uncached repo when the tab is showing")
+	}
+	home.githubWork[inst.ProjectPath] = &githubWorkEntry{fetchedAt: time.Now()}
+	if home.needsGitHubWork(inst) {
+		t.Error("should not refetch a fresh cache entry")
+	}
+	home.githubWork[inst.ProjectPath].fetchedAt = time.Now().Add(-githubWorkTTL - time.Second)
+	if !home.needsGitHubWork(inst) {
+		t.Error("should refetch a stale cache entry")
+	}
+}
@@ -392,9 +392,7 @@ func (gs *GlobalSearch) View() string {
 			if maxTitleLen < 20 {
 				maxTitleLen = 20
 			}
-			if len(title) > maxTitleLen {
-				title = title[:maxTitleLen] + "..."
-			}
+			title = TruncateWidth(title, maxTitleLen+3)
 
 			// Format date
 			dateStr := gs.formatRelativeTime(result.ModTime)
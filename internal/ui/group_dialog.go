@@ -17,6 +17,9 @@ const (
 	GroupDialogRename
 	GroupDialogMove
 	GroupDialogRenameSession
+	GroupDialogStatusText
+	GroupDialogMerge
+	GroupDialogSnapshot
 )
 
 // GroupDialog handles group creation, renaming, and moving sessions
@@ -155,6 +158,17 @@ func (g *GroupDialog) ShowMove(groups []string) {
 	g.selected = 0
 }
 
+// ShowMerge shows the dialog for merging the group at sourcePath into one of targets (paths)
+func (g *GroupDialog) ShowMerge(sourcePath, sourceName string, targets []string) {
+	g.visible = true
+	g.mode = GroupDialogMerge
+	g.groupPath = sourcePath
+	g.parentName = sourceName
+	g.validationErr = ""
+	g.groupNames = targets
+	g.selected = 0
+}
+
 // ShowRenameSession shows the dialog for renaming a session
 func (g *GroupDialog) ShowRenameSession(sessionID, currentName string) {
 	g.visible = true
@@ -165,6 +179,27 @@ func (g *GroupDialog) ShowRenameSession(sessionID, currentName string) {
 	g.nameInput.Focus()
 }
 
+// ShowStatusText shows the dialog for editing a session's custom status text
+func (g *GroupDialog) ShowStatusText(sessionID, currentText string) {
+	g.visible = true
+	g.mode = GroupDialogStatusText
+	g.sessionID = sessionID
+	g.validationErr = ""
+	g.nameInput.SetValue(currentText)
+	g.nameInput.CursorEnd()
+	g.nameInput.Focus()
+}
+
+// ShowSnapshotName shows the dialog for naming a snapshot of a session's pane
+func (g *GroupDialog) ShowSnapshotName(sessionID string) {
+	g.visible = true
+	g.mode = GroupDialogSnapshot
+	g.sessionID = sessionID
+	g.validationErr = ""
+	g.nameInput.SetValue("")
+	g.nameInput.Focus()
+}
+
 // GetSessionID returns the session ID being renamed
 func (g *GroupDialog) GetSessionID() string {
 	return g.sessionID
@@ -196,9 +231,20 @@ func (g *GroupDialog) Validate() string {
 	if g.mode == GroupDialogMove {
 		return "" // Move mode doesn't need validation
 	}
+	if g.mode == GroupDialogMerge {
+		if len(g.groupNames) == 0 {
+			return "No other group to merge into"
+		}
+		return ""
+	}
 
 	name := strings.TrimSpace(g.nameInput.Value())
 
+	// Empty status text clears it; an unnamed snapshot is named by its time
+	if g.mode == GroupDialogStatusText || g.mode == GroupDialogSnapshot {
+		return ""
+	}
+
 	// Check for empty name
 	if name == "" {
 		if g.mode == GroupDialogRenameSession {
@@ -263,7 +309,7 @@ func (g *GroupDialog) SetSize(width, height int) {
 
 // Update handles input
 func (g *GroupDialog) Update(msg tea.KeyMsg) (*GroupDialog, tea.Cmd) {
-	if g.mode == GroupDialogMove {
+	if g.mode == GroupDialogMove || g.mode == GroupDialogMerge {
 		switch msg.String() {
 		case "up", "k":
 			if g.selected > 0 {
@@ -330,8 +376,11 @@ func (g *GroupDialog) View() string {
 	case GroupDialogRename:
 		title = "Rename Group"
 		content = g.nameInput.View()
-	case GroupDialogMove:
+	case GroupDialogMove, GroupDialogMerge:
 		title = "Move to Group"
+		if g.mode == GroupDialogMerge {
+			title = "Merge '" + g.parentName + "' into"
+		}
 		var items []string
 		for i, name := range g.groupNames {
 			if i == g.selected {
@@ -352,6 +401,14 @@ func (g *GroupDialog) View() string {
 	case GroupDialogRenameSession:
 		title = "Rename Session"
 		content = g.nameInput.View()
+	case GroupDialogStatusText:
+		title = "Session Status Text"
+		content = g.nameInput.View() + "\n" +
+			lipgloss.NewStyle().Foreground(ColorComment).Render("Leave empty to clear")
+	case GroupDialogSnapshot:
+		title = "Snapshot Pane"
+		content = g.nameInput.View() + "\n" +
+			lipgloss.NewStyle().Foreground(ColorComment).Render("Name (empty = time) · B to browse")
 	}
 
 	// Responsive dialog width
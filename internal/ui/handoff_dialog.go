@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// HandoffDialog asks for a one-line "where I left off" note after detaching
+// from a session ([handoff] prompt_on_detach)
+type HandoffDialog struct {
+	visible       bool
+	width, height int
+	sessionID     string
+	title         string
+	input         textinput.Model
+}
+
+// NewHandoffDialog creates a new handoff dialog
+func NewHandoffDialog() *HandoffDialog {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. tests pass, next: wire up the retry flag"
+	ti.CharLimit = 200
+	ti.Width = 56
+	return &HandoffDialog{input: ti}
+}
+
+// Show opens the dialog for inst, starting from its current note
+func (d *HandoffDialog) Show(inst *session.Instance) tea.Cmd {
+	d.visible = true
+	d.sessionID = inst.ID
+	d.title = inst.Title
+	d.input.SetValue(inst.HandoffNote)
+	d.input.CursorEnd()
+	return d.input.Focus()
+}
+
+// Hide closes the dialog
+func (d *HandoffDialog) Hide() {
+	d.visible = false
+	d.input.Blur()
+}
+
+// IsVisible returns whether the dialog is shown
+func (d *HandoffDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering
+func (d *HandoffDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// View renders the dialog
+func (d *HandoffDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+
+	lines := []string{
+		titleStyle.Render("📝  Where did you leave off?"),
+		dimStyle.Render(fmt.Sprintf("\"%s\", shown next time you select it", d.title)),
+		"",
+		d.input.View(),
+		"",
+		footerStyle.Render("Enter save (empty clears) | Esc skip"),
+	}
+
+	dialogWidth := 64
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = d.width - 10
+		if dialogWidth < 30 {
+			dialogWidth = 30
+		}
+	}
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+	return centerInScreen(box, d.width, d.height)
+}
+
+// showHandoffPrompt opens the handoff dialog for the session just detached from
+func (h *Home) showHandoffPrompt(sessionID string) tea.Cmd {
+	inst := h.getInstanceByID(sessionID)
+	if inst == nil || h.handoffDialog.IsVisible() {
+		return nil
+	}
+	h.handoffDialog.SetSize(h.width, h.height)
+	return h.handoffDialog.Show(inst)
+}
+
+// handleHandoffDialogKey handles keys while the handoff dialog is shown
+func (h *Home) handleHandoffDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		id, note := h.handoffDialog.sessionID, h.handoffDialog.input.Value()
+		h.handoffDialog.Hide()
+		if inst := h.getInstanceByID(id); inst != nil {
+			inst.SetHandoffNote(note)
+			h.invalidatePreviewCache(inst.ID)
+			h.saveInstances()
+		}
+		return h, nil
+	case "esc":
+		h.handoffDialog.Hide()
+		return h, nil
+	}
+	var cmd tea.Cmd
+	h.handoffDialog.input, cmd = h.handoffDialog.input.Update(msg)
+	return h, cmd
+}
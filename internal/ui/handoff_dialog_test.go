@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHandoffPromptAfterDetach(t *testing.T) {
+	home, work, _ := newFocusTestHome(t)
+
+	home.Update(statusUpdateMsg{})
+	if home.handoffDialog.IsVisible() {
+		t.Fatal("a plain status update should not ask for a note")
+	}
+
+	home.Update(statusUpdateMsg{handoffID: work.ID})
+	if !home.handoffDialog.IsVisible() || home.deckListFocused() {
+		t.Fatal("returning from an attach with handoffID should open the prompt")
+	}
+	for _, r := range "next: fix flaky test" {
+		home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if home.handoffDialog.IsVisible() || work.HandoffNote != "next: fix flaky test" || work.HandoffAt.IsZero() {
+		t.Errorf("enter should save the note, got %q at %v", work.HandoffNote, work.HandoffAt)
+	}
+
+	for i, item := range home.flatItems {
+		if item.Session == work {
+			home.cursor = i
+		}
+	}
+	if view := home.renderPreviewPane(80, 20); !strings.Contains(view, "Left off") {
+		t.Errorf("preview should show the handoff note, got:\n%s", view)
+	}
+
+	home.Update(statusUpdateMsg{handoffID: work.ID})
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if work.HandoffNote != "next: fix flaky test" {
+		t.Error("esc should keep the old note")
+	}
+}
@@ -105,6 +105,7 @@ func (h *HelpOverlay) View() string {
 				{"l / Right", "Expand / toggle"},
 				{"1-9", "Jump to group"},
 				{"Enter", "Attach / toggle"},
+				{"Shift+A", "Attach in new terminal window"},
 			},
 		},
 		{
@@ -113,18 +114,36 @@ func (h *HelpOverlay) View() string {
 				{"n", "New session"},
 				{"N", "Quick create (auto name, smart defaults)"},
 				{"r", "Rename session"},
+				{"e", "Edit title/group/command in the row"},
+				{"t", "Set status text (empty clears)"},
 				{"Shift+R", "Restart session"},
+				{"Ctrl+G", "Switch model (restarts the session)"},
+				{"y", "Toggle safe/YOLO launch mode (restarts)"},
+				{"Shift+O", "Context files (key files, {files} in prompts)"},
+				{"Shift+X", "Stop session (kill tmux, keep in deck)"},
 				{"d", "Delete session"},
 				{"Ctrl+Z", "Undo delete"},
-				{"m", "Move to group"},
+				{"Space", "Mark session (m moves all marked)"},
+				{"m", "Move to group (on a group: merge into another)"},
 				{"Shift+M", "MCP Manager (Claude)"},
-				{"v", "Toggle preview mode (output/stats/both)"},
+				{"v", "Toggle preview mode (both/output/stats/GitHub)"},
+				{"s", "Mark as split preview (shown below selection)"},
+				{"p", "Toggle preview follow (auto-scroll)"},
+				{"PgUp/PgDn", "Scroll preview history"},
 				{"u", "Mark unread"},
 				{"K / J", "Reorder up/down"},
 				{"f", "Quick fork (Claude only)"},
 				{"F", "Fork with options (Claude only)"},
 				{"c", "Copy output to clipboard"},
+				{"Shift+E", "Export scrollback to file"},
+				{"Shift+L", "View full scrollback (log viewer)"},
+				{"b / B", "Snapshot pane / browse snapshots"},
 				{"x", "Send output to session"},
+				{"a", "Approvals inbox (answer prompts)"},
+				{"C", "Stats: estimated API spend"},
+				{"T", "Timeline: session status over the last day"},
+				{"D", "Show git diff of project"},
+				{"o", "Open project in $EDITOR (new tab)"},
 			},
 		},
 		{
@@ -133,6 +152,10 @@ func (h *HelpOverlay) View() string {
 				{"g", "New group"},
 				{"e", "Rename group"},
 				{"Tab", "Toggle expand"},
+				{"z", "Focus on group (z again to exit)"},
+				{"Z", "Pin session (stays visible in focus)"},
+				{"H", "Hide/unhide group"},
+				{".", "Show hidden groups"},
 			},
 		},
 		{
@@ -142,6 +165,8 @@ func (h *HelpOverlay) View() string {
 				{"/waiting", "Filter waiting"},
 				{"/running", "Filter running"},
 				{"/idle", "Filter idle"},
+				{"Shift+P", "Search preview output (/ is session search)"},
+				{"n / N", "Next/prev preview match while searching"},
 			},
 		},
 		{
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// applyHiddenFilter drops hidden groups, their subgroups and their sessions
+func applyHiddenFilter(items []session.Item, tree *session.GroupTree) []session.Item {
+	filtered := make([]session.Item, 0, len(items))
+	for _, item := range items {
+		// Item.Path is the group path for both group headers and sessions
+		if tree.IsGroupHidden(item.Path) {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+	return filtered
+}
+
+// toggleGroupHidden hides or unhides the group under the cursor (a session row
+// acts on its group). Returns an error to show when nothing can be toggled.
+func (h *Home) toggleGroupHidden() error {
+	if h.cursor >= len(h.flatItems) {
+		return fmt.Errorf("no group selected")
+	}
+	path := h.flatItems[h.cursor].Path
+	group, exists := h.groupTree.Groups[path]
+	if !exists {
+		return fmt.Errorf("no group selected")
+	}
+	if path == session.DefaultGroupPath {
+		return fmt.Errorf("the default group cannot be hidden")
+	}
+	h.groupTree.SetGroupHidden(path, !group.Hidden)
+	h.rebuildFlatItems()
+	return nil
+}
+
+// toggleShowHidden switches between hiding and showing hidden groups
+func (h *Home) toggleShowHidden() {
+	h.showHidden = !h.showHidden
+	h.rebuildFlatItems()
+}
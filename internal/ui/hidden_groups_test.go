@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestHiddenGroupsLeaveDefaultView(t *testing.T) {
+	home, work, other := newFocusTestHome(t)
+
+	// Cursor on the work session hides its group
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession && item.Session.ID == work.ID {
+			home.cursor = i
+		}
+	}
+	if err := home.toggleGroupHidden(); err != nil {
+		t.Fatalf("toggleGroupHidden: %v", err)
+	}
+	ids := flatSessionIDs(home)
+	if ids[work.ID] || !ids[other.ID] {
+		t.Fatalf("hidden group still visible: %v", ids)
+	}
+	for _, item := range home.flatItems {
+		if item.Path == "work" {
+			t.Fatalf("hidden group header still listed")
+		}
+	}
+
+	home.toggleShowHidden()
+	if ids := flatSessionIDs(home); !ids[work.ID] {
+		t.Fatal("show hidden should reveal the hidden group")
+	}
+
+	// Unhide from the revealed view, then stop showing hidden groups
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeGroup && item.Path == "work" {
+			home.cursor = i
+		}
+	}
+	if err := home.toggleGroupHidden(); err != nil {
+		t.Fatalf("toggleGroupHidden (unhide): %v", err)
+	}
+	home.toggleShowHidden()
+	if ids := flatSessionIDs(home); !ids[work.ID] {
+		t.Fatal("unhidden group should be visible")
+	}
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// highlightRule is a compiled session.HighlightRule
+type highlightRule struct {
+	re    *regexp.Regexp
+	style lipgloss.Style
+}
+
+// highlighter applies regex highlight rules to plain-text output lines.
+// A nil highlighter renders lines with the base style only.
+type highlighter struct {
+	rules []highlightRule
+}
+
+// newHighlighter compiles highlight rules. Invalid rules are skipped and reported
+// in the returned error; the highlighter is still usable with the remaining rules.
+func newHighlighter(rules []session.HighlightRule) (*highlighter, error) {
+	hl := &highlighter{}
+	var errs []error
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid highlight pattern %q: %w", rule.Pattern, err))
+			continue
+		}
+		style, err := parseHighlightStyle(rule.Style)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		hl.rules = append(hl.rules, highlightRule{re: re, style: style})
+	}
+	return hl, errors.Join(errs...)
+}
+
+// loadHighlighter builds the highlighter from [preview] config, logging invalid rules
+func loadHighlighter() *highlighter {
+	settings := session.GetPreviewSettings()
+	hl, err := newHighlighter(settings.GetHighlightRules())
+	if err != nil {
+		uiLog.Warn("highlight_rules_invalid", slog.String("error", err.Error()))
+	}
+	return hl
+}
+
+// parseHighlightStyle turns a spec like "red,bold" into a lipgloss style
+func parseHighlightStyle(spec string) (lipgloss.Style, error) {
+	style := lipgloss.NewStyle()
+	for _, token := range strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == ' ' }) {
+		switch strings.ToLower(token) {
+		case "bold":
+			style = style.Bold(true)
+		case "italic":
+			style = style.Italic(true)
+		case "underline":
+			style = style.Underline(true)
+		case "faint":
+			style = style.Faint(true)
+		case "reverse":
+			style = style.Reverse(true)
+		case "red":
+			style = style.Foreground(ColorRed)
+		case "green":
+			style = style.Foreground(ColorGreen)
+		case "yellow":
+			style = style.Foreground(ColorYellow)
+		case "cyan":
+			style = style.Foreground(ColorCyan)
+		case "purple":
+			style = style.Foreground(ColorPurple)
+		case "orange":
+			style = style.Foreground(ColorOrange)
+		case "accent":
+			style = style.Foreground(ColorAccent)
+		case "dim":
+			style = style.Foreground(ColorTextDim)
+		default:
+			if strings.HasPrefix(token, "#") || isANSIColorNumber(token) {
+				style = style.Foreground(lipgloss.Color(token))
+				continue
+			}
+			return style, fmt.Errorf("unknown highlight style %q in %q", token, spec)
+		}
+	}
+	return style, nil
+}
+
+// isANSIColorNumber reports whether s is a 0-255 ANSI color index
+func isANSIColorNumber(s string) bool {
+	if s == "" || len(s) > 3 {
+		return false
+	}
+	n := 0
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n <= 255
+}
+
+// render styles line with base, overlaying rule styles on matching spans.
+// When rules overlap, the earlier rule wins.
+func (hl *highlighter) render(line string, base lipgloss.Style) string {
+	if hl == nil || len(hl.rules) == 0 || line == "" {
+		return base.Render(line)
+	}
+
+	// owner[i] is the index+1 of the rule styling byte i (0 = unstyled)
+	var owner []int
+	for ri, rule := range hl.rules {
+		for _, m := range rule.re.FindAllStringIndex(line, -1) {
+			if m[0] == m[1] {
+				continue
+			}
+			if owner == nil {
+				owner = make([]int, len(line))
+			}
+			for i := m[0]; i < m[1]; i++ {
+				if owner[i] == 0 {
+					owner[i] = ri + 1
+				}
+			}
+		}
+	}
+	if owner == nil {
+		return base.Render(line)
+	}
+
+	var b strings.Builder
+	start := 0
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && owner[i] == owner[start] {
+			continue
+		}
+		segment := line[start:i]
+		if owner[start] == 0 {
+			b.WriteString(base.Render(segment))
+		} else {
+			b.WriteString(hl.rules[owner[start]-1].style.Inherit(base).Render(segment))
+		}
+		start = i
+	}
+	return b.String()
+}
+
+// styler adapts the highlighter to PagerOverlay's per-line styler
+func (hl *highlighter) styler() func(string) string {
+	base := lipgloss.NewStyle().Foreground(ColorText)
+	return func(line string) string {
+		return hl.render(line, base)
+	}
+}
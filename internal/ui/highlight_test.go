@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func TestDefaultHighlightRulesMatch(t *testing.T) {
+	hl, err := newHighlighter(session.DefaultHighlightRules)
+	if err != nil {
+		t.Fatalf("default rules should compile: %v", err)
+	}
+
+	tests := []struct {
+		line string
+		want string // substring that must be matched by some rule
+	}{
+		{"Error: connection refused", "Error"},
+		{"build failed after 3s", "failed"},
+		{"see internal/ui/home.go:120 for details", "internal/ui/home.go:120"},
+		{"opened /tmp/project/main.go", "/tmp/project/main.go"},
+		{"Waiting for approval to run rm -rf", "Waiting for approval"},
+		{"Do you want to proceed?", "Do you want to proceed"},
+	}
+	for _, tt := range tests {
+		matched := false
+		for _, rule := range hl.rules {
+			for _, m := range rule.re.FindAllString(tt.line, -1) {
+				if m == tt.want {
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			t.Errorf("%q: expected a rule to match %q", tt.line, tt.want)
+		}
+	}
+}
+
+func TestHighlighterRenderPreservesText(t *testing.T) {
+	hl, err := newHighlighter([]session.HighlightRule{
+		{Pattern: `(?i)error`, Style: "red"},
+		{Pattern: `err\w+ here`, Style: "underline"},
+	})
+	if err != nil {
+		t.Fatalf("newHighlighter: %v", err)
+	}
+	line := "an error here — ünïcode ok"
+	out := hl.render(line, lipgloss.NewStyle())
+	if got := tmux.StripANSI(out); got != line {
+		t.Errorf("render changed visible text: %q, want %q", got, line)
+	}
+
+	var nilHL *highlighter
+	if got := nilHL.render("plain", lipgloss.NewStyle()); !strings.Contains(got, "plain") {
+		t.Errorf("nil highlighter should render plain text, got %q", got)
+	}
+}
+
+func TestNewHighlighterSkipsInvalidRules(t *testing.T) {
+	hl, err := newHighlighter([]session.HighlightRule{
+		{Pattern: `(unclosed`, Style: "red"},
+		{Pattern: `ok`, Style: "sparkly"},
+		{Pattern: `fine`, Style: "#ff0000,bold"},
+		{Pattern: `also`, Style: "208"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for invalid rules")
+	}
+	if len(hl.rules) != 2 {
+		t.Errorf("expected 2 valid rules, got %d", len(hl.rules))
+	}
+}
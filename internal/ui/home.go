@@ -16,6 +16,7 @@ import (
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
@@ -101,6 +102,7 @@ const (
 	PreviewModeBoth      PreviewMode = iota // Show both analytics and output (default)
 	PreviewModeOutput                       // Show output only (content preview)
 	PreviewModeAnalytics                    // Show analytics only
+	PreviewModeGitHub                       // Show the repository's open PRs and issues
 )
 
 // Responsive breakpoints for empty state content tiers
@@ -143,12 +145,21 @@ type Home struct {
 	forkDialog          *ForkDialog          // For forking sessions
 	confirmDialog       *ConfirmDialog       // For confirming destructive actions
 	helpOverlay         *HelpOverlay         // For showing keyboard shortcuts
+	pagerOverlay        *PagerOverlay        // For scrollable read-only text (git diff)
+	approvalsInbox      *ApprovalsInbox      // Pending permission prompts across sessions
+	statsView           *StatsView           // Estimated API spend across sessions
+	timelineView        *TimelineView        // Status swimlanes of the last day (T)
 	mcpDialog           *MCPDialog           // For managing MCPs
 	setupWizard         *SetupWizard         // For first-run setup
 	settingsPanel       *SettingsPanel       // For editing settings
 	analyticsPanel      *AnalyticsPanel      // For displaying session analytics
-	geminiModelDialog   *GeminiModelDialog   // For selecting Gemini model
+	modelDialog         *ModelDialog         // For switching a session's model
 	sessionPickerDialog *SessionPickerDialog // For sending output to another session
+	relocateDialog      *RelocateDialog      // For sessions whose project directory moved
+	handoffDialog       *HandoffDialog       // "Where I left off" note after detaching
+	contextFilesDialog  *ContextFilesDialog  // Key files of a session (O)
+	snapshotBrowser     *SnapshotBrowser     // Saved pane snapshots of a session (B)
+	bulkMoveDialog      *BulkMoveDialog      // Move marked sessions to a group (space, m)
 
 	// Analytics cache (async fetching with TTL)
 	currentAnalytics       *session.SessionAnalytics                  // Current analytics for selected session (Claude)
@@ -159,20 +170,45 @@ type Home struct {
 	geminiAnalyticsCache   map[string]*session.GeminiSessionAnalytics // TTL cache: sessionID -> analytics (Gemini)
 	analyticsCacheTime     map[string]time.Time                       // TTL cache: sessionID -> cache timestamp
 
+	// GitHub tab cache (open PRs/issues per project path, see github_tab.go)
+	githubWork         map[string]*githubWorkEntry
+	githubFetchingPath string // Path currently being fetched (prevents duplicates)
+
 	// State
-	cursor         int            // Selected item index in flatItems
-	viewOffset     int            // First visible item index (for scrolling)
-	isAttaching    atomic.Bool    // Prevents View() output during attach (fixes Bubble Tea Issue #431) - atomic for thread safety
-	statusFilter   session.Status // Filter sessions by status ("" = all, or specific status)
-	previewMode    PreviewMode    // What to show in preview pane (both, output-only, analytics-only)
-	err            error
-	errTime        time.Time  // When error occurred (for auto-dismiss)
-	isReloading    bool       // Visual feedback during auto-reload
-	initialLoading bool       // True until first loadSessionsMsg received (shows splash screen)
-	isQuitting     bool       // True when user pressed q, shows quitting splash
-	reloadVersion  uint64     // Incremented on each reload to prevent stale background saves
-	reloadMu       sync.Mutex // Protects reloadVersion, isReloading, and lastLoadMtime for thread-safe access
-	lastLoadMtime  time.Time  // File mtime when we last loaded (for external change detection)
+	cursor         int             // Selected item index in flatItems
+	viewOffset     int             // First visible item index (for scrolling)
+	isAttaching    atomic.Bool     // Prevents View() output during attach (fixes Bubble Tea Issue #431) - atomic for thread safety
+	statusFilter   session.Status  // Filter sessions by status ("" = all, or specific status)
+	focusGroupPath string          // Focus mode: only this group (and pinned sessions) is shown ("" = off)
+	pinnedSessions map[string]bool // Session IDs that stay visible in focus mode
+	markedSessions map[string]bool // Session IDs marked with space for bulk moves
+	showHidden     bool            // Show groups marked hidden (off by default)
+	splitSessionID string          // Secondary session shown below the selection in the preview ("" = no split)
+	previewFollow  bool            // Preview auto-scrolls with new output (off = frozen/manually scrolled)
+	previewScroll  previewScrollState
+	highlighter    *highlighter // Regex highlight rules for preview and log viewer ([preview] config)
+
+	// Auto-attach (see auto_attach.go): sessions that just started waiting, queued by the background worker
+	autoAttachMu      sync.Mutex
+	autoAttachPending []string
+
+	// In-place editing of a session row (e, see inline_edit.go)
+	inlineEdit inlineEdit
+
+	// Preview search (P): query input and matches in the selected session's output
+	previewSearch          textSearch
+	previewSearchInput     textinput.Model
+	previewSearching       bool        // Typing a preview search query
+	previewSearchSessionID string      // Session the active search applies to
+	previewMode            PreviewMode // What to show in preview pane (both, output-only, analytics-only)
+	err                    error
+	errTime                time.Time  // When error occurred (for auto-dismiss)
+	isReloading            bool       // Visual feedback during auto-reload
+	initialLoading         bool       // True until first loadSessionsMsg received (shows splash screen)
+	isQuitting             bool       // True when user pressed q, shows quitting splash
+	reloadVersion          uint64     // Incremented on each reload to prevent stale background saves
+	reloadMu               sync.Mutex // Protects reloadVersion, isReloading, and lastLoadMtime for thread-safe access
+	lastLoadMtime          time.Time  // File mtime when we last loaded (for external change detection)
 
 	// Preview cache (async fetching - View() must be pure, no blocking I/O)
 	previewCache      map[string]string    // sessionID -> cached preview content
@@ -180,6 +216,8 @@ type Home struct {
 	previewCacheMu    sync.RWMutex         // Protects previewCache for thread-safety
 	previewFetchingID string               // ID currently being fetched (prevents duplicate fetches)
 
+	splitPreviewFetching bool // True while the split session's preview is being fetched
+
 	// Preview debouncing (PERFORMANCE: prevents subprocess spawn on every keystroke)
 	// During rapid navigation, we delay preview fetch by 150ms to let navigation settle
 	pendingPreviewID  string     // Session ID waiting for debounced fetch
@@ -194,6 +232,7 @@ type Home struct {
 	// Moves status updates to a separate goroutine, completely decoupling from UI
 	statusTrigger    chan statusUpdateRequest // Triggers background status update
 	statusWorkerDone chan struct{}            // Signals worker has stopped
+	pollBackoff      *pollBackoff             // Polls quiet sessions less often ([status] config)
 
 	// PERFORMANCE: Worker pool for output-driven status updates (Priority 2)
 	// Caps the number of goroutines spawned for %output events from control pipes
@@ -209,9 +248,11 @@ type Home struct {
 
 	// File watcher for external changes (auto-reload)
 	storageWatcher *StorageWatcher
+	notifyListener *NotifyListener // Status reports from `agent-deck notify`
 
 	// Storage warning (shown if storage initialization failed)
-	storageWarning string
+	storageWarning   string
+	lastAnnouncement string // Last selection line printed in screen reader mode
 
 	// Watcher warning (shown if fsnotify may not work, e.g., on 9p/NFS)
 	watcherWarning string
@@ -219,6 +260,9 @@ type Home struct {
 	// Update notification (async check on startup)
 	updateInfo *update.UpdateInfo
 
+	// Answer to a [yolo_sandbox] offer (ConfirmYoloSandbox)
+	pendingSandbox func(sandbox bool) tea.Cmd
+
 	// Launching animation state (for newly created sessions)
 	launchingSessions  map[string]time.Time // sessionID -> creation time
 	resumingSessions   map[string]time.Time // sessionID -> resume time (for restart/resume)
@@ -261,8 +305,23 @@ type Home struct {
 	// Reusable string builder for View() to reduce allocations
 	viewBuilder strings.Builder
 
+	// File watch triggers for sessions with a watch, and [[webhooks]] for
+	// status changes (both nil when read-only)
+	fileWatcher    *session.FileWatcher
+	statusWebhooks *session.StatusWebhooks
+
+	// Badge data for work only in the sessions' working copies ([unpushed])
+	unpushedWork *session.UnpushedWork
+
+	// Branch checked out in each session's project, shown in its row
+	gitBranches *session.GitBranches
+
+	// Linked tickets as last fetched, shown in the preview
+	ticketStatuses *session.TicketStatuses
+
 	// Notification bar (tmux status-left for waiting sessions)
 	notificationManager  *session.NotificationManager
+	alerter              *session.Alerter // Per-group alerts for waiting sessions (nil when read-only)
 	notificationsEnabled bool
 	boundKeys            map[string]string // Track which key is bound (key -> "sessionID:tmuxName")
 	boundKeysMu          sync.Mutex        // Protects boundKeys for background worker access
@@ -302,10 +361,14 @@ type reloadState struct {
 
 // uiState persists cursor, preview mode, and status filter across restarts
 type uiState struct {
-	CursorSessionID string `json:"cursor_session_id,omitempty"`
-	CursorGroupPath string `json:"cursor_group_path,omitempty"`
-	PreviewMode     int    `json:"preview_mode"`
-	StatusFilter    string `json:"status_filter,omitempty"`
+	CursorSessionID string   `json:"cursor_session_id,omitempty"`
+	CursorGroupPath string   `json:"cursor_group_path,omitempty"`
+	PreviewMode     int      `json:"preview_mode"`
+	StatusFilter    string   `json:"status_filter,omitempty"`
+	FocusGroupPath  string   `json:"focus_group_path,omitempty"`
+	PinnedSessions  []string `json:"pinned_sessions,omitempty"`
+	PreviewNoFollow bool     `json:"preview_no_follow,omitempty"`
+	ShowHidden      bool     `json:"show_hidden,omitempty"`
 }
 
 // deletedSessionEntry holds a deleted session for undo restore
@@ -350,7 +413,9 @@ type sessionForkedMsg struct {
 
 type refreshMsg struct{}
 
-type statusUpdateMsg struct{} // Triggers immediate status update without reloading
+// statusUpdateMsg triggers an immediate status update without reloading.
+// handoffID, set on return from an attach, asks for that session's handoff note.
+type statusUpdateMsg struct{ handoffID string }
 
 // storageChangedMsg signals that state.db was modified externally
 type storageChangedMsg struct{}
@@ -366,6 +431,11 @@ type updateCheckMsg struct {
 	info *update.UpdateInfo
 }
 
+// toolVersionsMsg carries the installed tool versions detected at startup
+type toolVersionsMsg struct {
+	versions []session.ToolVersion
+}
+
 type tickMsg time.Time
 type quitMsg bool
 
@@ -395,6 +465,10 @@ type MaintenanceCompleteMsg struct {
 	Result session.MaintenanceResult
 }
 
+// TakeoverMsg is sent from main.go when another instance takes over this
+// profile; Home quits normally (saving first) so the new instance starts clean
+type TakeoverMsg struct{}
+
 // maintenanceCompleteMsg is the internal message handled in Update()
 type maintenanceCompleteMsg struct {
 	result session.MaintenanceResult
@@ -410,6 +484,14 @@ type copyResultMsg struct {
 	err          error
 }
 
+// exportResultMsg is sent when async scrollback export completes
+type exportResultMsg struct {
+	sessionTitle string
+	path         string
+	lineCount    int
+	err          error
+}
+
 // sendOutputResultMsg is sent when async inter-session send completes
 type sendOutputResultMsg struct {
 	sourceTitle string
@@ -459,6 +541,10 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		}
 	}
 
+	if storageWarning == "" && session.IsReadOnly() {
+		storageWarning = "⚠ Read-only: another agent-deck instance owns this profile (changes won't be saved)"
+	}
+
 	// Get the actual profile name (could be resolved from env var or config)
 	actualProfile := session.DefaultProfile
 	if storage != nil {
@@ -475,12 +561,21 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		forkDialog:           NewForkDialog(),
 		confirmDialog:        NewConfirmDialog(),
 		helpOverlay:          NewHelpOverlay(),
+		pagerOverlay:         NewPagerOverlay(),
+		approvalsInbox:       NewApprovalsInbox(),
+		statsView:            NewStatsView(),
+		timelineView:         NewTimelineView(),
 		mcpDialog:            NewMCPDialog(),
 		setupWizard:          NewSetupWizard(),
 		settingsPanel:        NewSettingsPanel(),
 		analyticsPanel:       NewAnalyticsPanel(),
-		geminiModelDialog:    NewGeminiModelDialog(),
+		modelDialog:          NewModelDialog(),
 		sessionPickerDialog:  NewSessionPickerDialog(),
+		relocateDialog:       NewRelocateDialog(),
+		handoffDialog:        NewHandoffDialog(),
+		contextFilesDialog:   NewContextFilesDialog(),
+		snapshotBrowser:      NewSnapshotBrowser(),
+		bulkMoveDialog:       NewBulkMoveDialog(),
 		cursor:               0,
 		initialLoading:       true, // Show splash until sessions load
 		ctx:                  ctx,
@@ -494,6 +589,7 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		analyticsCache:       make(map[string]*session.SessionAnalytics),
 		geminiAnalyticsCache: make(map[string]*session.GeminiSessionAnalytics),
 		analyticsCacheTime:   make(map[string]time.Time),
+		githubWork:           make(map[string]*githubWorkEntry),
 		launchingSessions:    make(map[string]time.Time),
 		resumingSessions:     make(map[string]time.Time),
 		mcpLoadingSessions:   make(map[string]time.Time),
@@ -501,10 +597,17 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		lastLogActivity:      make(map[string]time.Time),
 		statusTrigger:        make(chan statusUpdateRequest, 1), // Buffered to avoid blocking
 		statusWorkerDone:     make(chan struct{}),
+		pollBackoff:          loadPollBackoff(),
 		logUpdateChan:        make(chan *session.Instance, 100), // Buffered to absorb bursts
 		boundKeys:            make(map[string]string),
 		undoStack:            make([]deletedSessionEntry, 0, 10),
 		pendingTitleChanges:  make(map[string]string),
+		pinnedSessions:       make(map[string]bool),
+		markedSessions:       make(map[string]bool),
+		previewFollow:        true,
+		highlighter:          loadHighlighter(),
+		previewSearchInput:   newPreviewSearchInput(),
+		inlineEdit:           newInlineEdit(),
 	}
 
 	// Restore persisted UI state (preview mode, status filter, cursor position)
@@ -521,6 +624,21 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		// Fixes truncation (default status-left-length is only 10 chars)
 		_ = tmux.InitializeStatusBarOptions()
 	}
+	h.unpushedWork = session.NewUnpushedWork(session.GetUnpushedSettings())
+	h.gitBranches = session.NewGitBranches()
+	h.ticketStatuses = session.NewTicketStatuses()
+	// Read-only instances leave alerts to the primary so they aren't sent twice
+	if !session.IsReadOnly() {
+		h.alerter = session.NewAlerter(notifSettings)
+		h.fileWatcher = session.NewFileWatcher()
+		h.statusWebhooks = session.NewStatusWebhooks(session.GetWebhooks())
+		session.WatchSessionExits()
+		if session.GetStatusSettings().FileActivity {
+			if err := session.StartFileActivity(); err != nil {
+				uiLog.Warn("file_activity_unavailable", slog.String("error", err.Error()))
+			}
+		}
+	}
 
 	// Initialize event-driven status detection
 	// Output callback: invoked when PipeManager detects %output from a session
@@ -560,7 +678,7 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		h.instancesMu.RUnlock()
 
 		for _, inst := range instances {
-			if ts := inst.GetTmuxSession(); ts != nil && ts.Exists() {
+			if ts := inst.GetTmuxSession(); ts != nil && ts.OnDeckServer() && ts.Exists() {
 				if err := pm.Connect(ts.Name); err != nil {
 					pipeUILog.Debug("startup_pipe_connect_failed",
 						slog.String("session", ts.Name),
@@ -609,6 +727,13 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 			h.storageWatcher = watcher
 			watcher.Start()
 		}
+
+		// Listen for status reports pushed by hooks and `agent-deck notify`
+		if listener, err := NewNotifyListener(h.profile); err != nil {
+			uiLog.Warn("notify_listener_init_failed", slog.String("error", err.Error()))
+		} else {
+			h.notifyListener = listener
+		}
 	}
 
 	// Run log maintenance at startup (non-blocking)
@@ -720,6 +845,9 @@ func (h *Home) restoreState(state reloadState) {
 // rebuildFlatItems rebuilds the flattened view from group tree
 func (h *Home) rebuildFlatItems() {
 	allItems := h.groupTree.Flatten()
+	if !h.showHidden {
+		allItems = applyHiddenFilter(allItems, h.groupTree)
+	}
 
 	// Apply status filter if active
 	if h.statusFilter != "" {
@@ -760,6 +888,16 @@ func (h *Home) rebuildFlatItems() {
 		h.flatItems = allItems
 	}
 
+	// Apply focus mode if active (hides everything outside the focused group except pinned sessions)
+	if h.focusGroupPath != "" {
+		if _, exists := h.groupTree.Groups[h.focusGroupPath]; exists {
+			h.flatItems = applyFocusFilter(h.flatItems, h.focusGroupPath, h.pinnedSessions)
+		} else if !h.initialLoading {
+			// Focused group was deleted or renamed - fall back to the full view
+			h.focusGroupPath = ""
+		}
+	}
+
 	// Pre-compute root group numbers for O(1) hotkey lookup (replaces O(n) loop in renderGroupItem)
 	rootNum := 0
 	for i := range h.flatItems {
@@ -840,20 +978,19 @@ func (h *Home) syncViewport() {
 		panelContentHeight = contentHeight - panelTitleLines
 	}
 
-	// maxVisible = how many items can be shown (reserving 1 for "more below" indicator)
+	// maxVisible = how many list lines can be shown (reserving 1 for "more below" indicator)
 	maxVisible := panelContentHeight - 1
 	if maxVisible < 1 {
 		maxVisible = 1
 	}
 
-	// Account for "more above" indicator (takes 1 line when scrolled down)
-	// This is the key fix: when we're scrolled down, we have 1 less visible line
-	effectiveMaxVisible := maxVisible
-	if h.viewOffset > 0 {
-		effectiveMaxVisible-- // "more above" indicator takes 1 line
-	}
-	if effectiveMaxVisible < 1 {
-		effectiveMaxVisible = 1
+	// linesAt is how many lines are left for items when the list starts at offset:
+	// "more above" takes 1 line when scrolled down
+	linesAt := func(offset int) int {
+		if offset > 0 {
+			return max(1, maxVisible-1)
+		}
+		return maxVisible
 	}
 
 	// If cursor is above viewport, scroll up
@@ -861,34 +998,31 @@ func (h *Home) syncViewport() {
 		h.viewOffset = h.cursor
 	}
 
-	// If cursor is below viewport, scroll down
-	if h.cursor >= h.viewOffset+effectiveMaxVisible {
-		// When scrolling down, we need to account for the "more above" indicator
-		// that will appear once viewOffset > 0
-		if h.viewOffset == 0 {
-			// First scroll down: "more above" will appear, reducing visible by 1
-			h.viewOffset = h.cursor - (maxVisible - 1) + 1
-		} else {
-			// Already scrolled: "more above" already showing
-			h.viewOffset = h.cursor - effectiveMaxVisible + 1
-		}
+	// If cursor is below viewport, scroll down until it fits.
+	// Count lines rather than items: sessions with a summary take two.
+	used := 0
+	for i := h.viewOffset; i <= h.cursor && i < len(h.flatItems); i++ {
+		used += itemLines(h.flatItems[i])
+	}
+	for h.viewOffset < h.cursor && used > linesAt(h.viewOffset) {
+		used -= itemLines(h.flatItems[h.viewOffset])
+		h.viewOffset++
 	}
 
-	// Clamp viewOffset to valid range
-	// When scrolled down, "more above" takes 1 line, so we can show fewer items
-	finalMaxVisible := maxVisible
-	if h.viewOffset > 0 {
-		finalMaxVisible--
+	// Clamp viewOffset so the tail of the list fills the panel
+	if h.viewOffset < 0 {
+		h.viewOffset = 0
 	}
-	maxOffset := len(h.flatItems) - finalMaxVisible
-	if maxOffset < 0 {
-		maxOffset = 0
+	if h.viewOffset >= len(h.flatItems) {
+		h.viewOffset = len(h.flatItems) - 1
 	}
-	if h.viewOffset > maxOffset {
-		h.viewOffset = maxOffset
+	tail := 0
+	for i := h.viewOffset; i < len(h.flatItems); i++ {
+		tail += itemLines(h.flatItems[i])
 	}
-	if h.viewOffset < 0 {
-		h.viewOffset = 0
+	for h.viewOffset > 0 && tail+itemLines(h.flatItems[h.viewOffset-1]) <= linesAt(h.viewOffset-1) {
+		h.viewOffset--
+		tail += itemLines(h.flatItems[h.viewOffset])
 	}
 }
 
@@ -1015,11 +1149,17 @@ func (h *Home) Init() tea.Cmd {
 		h.tick(),
 		h.checkForUpdate(),
 	}
+	if session.GetToolVersionSettings().GetCheck() {
+		cmds = append(cmds, checkToolVersions)
+	}
 
 	// Start listening for storage changes
 	if h.storageWatcher != nil {
 		cmds = append(cmds, listenForReloads(h.storageWatcher))
 	}
+	if h.notifyListener != nil {
+		cmds = append(cmds, listenForStatusReports(h.notifyListener))
+	}
 
 	return tea.Batch(cmds...)
 }
@@ -1032,6 +1172,12 @@ func (h *Home) checkForUpdate() tea.Cmd {
 	}
 }
 
+// checkToolVersions detects the installed tools' versions in the
+// background, for the preview and broken version warnings
+func checkToolVersions() tea.Msg {
+	return toolVersionsMsg{versions: session.DetectToolVersions(session.VersionCheckedTools(nil))}
+}
+
 // listenForReloads waits for storage change notification
 func listenForReloads(sw *StorageWatcher) tea.Cmd {
 	return func() tea.Msg {
@@ -1408,10 +1554,19 @@ func (h *Home) pushUndoStack(inst *session.Instance) {
 	}
 	h.undoStack = append(h.undoStack, entry)
 	if len(h.undoStack) > 10 {
+		dropUndoEntries(h.undoStack[:len(h.undoStack)-10])
 		h.undoStack = h.undoStack[len(h.undoStack)-10:]
 	}
 }
 
+// dropUndoEntries deletes what a session kept on disk for undo (its
+// snapshots) once its delete can no longer be undone
+func dropUndoEntries(entries []deletedSessionEntry) {
+	for _, entry := range entries {
+		_ = session.DeleteSnapshots(entry.instance.ID)
+	}
+}
+
 // getDefaultPathForGroup returns the default path for a group
 // Returns empty string if group not found or no default path set
 func (h *Home) getDefaultPathForGroup(groupPath string) string {
@@ -1432,7 +1587,8 @@ func (h *Home) statusWorker() {
 	// Internal ticker - independent of Bubble Tea event loop
 	// This is the key insight: when tea.Exec suspends the TUI (user attaches to session),
 	// the Bubble Tea tick messages stop firing, but this goroutine keeps running
-	ticker := time.NewTicker(2 * time.Second)
+	tmux.SetSessionCacheTTL(h.pollBackoff.base)
+	ticker := time.NewTicker(h.pollBackoff.base)
 	defer ticker.Stop()
 
 	for {
@@ -1488,6 +1644,8 @@ func (h *Home) logWorker() {
 					}
 				}()
 				_ = inst.UpdateStatus()
+				// New output: poll it at full rate again
+				h.pollBackoff.reset(inst.ID)
 			}()
 		}
 	}
@@ -1543,7 +1701,8 @@ func (h *Home) backgroundStatusUpdate() {
 	var slowMu sync.Mutex
 	var slowSessions []string
 	pm := tmux.GetPipeManager()
-	var skipped int
+	var skipped, backedOff int
+	now := time.Now()
 
 	g := new(errgroup.Group)
 	g.SetLimit(10) // Pool of 10 workers (tmux server serializes, more doesn't help)
@@ -1551,6 +1710,12 @@ func (h *Home) backgroundStatusUpdate() {
 	for _, inst := range instances {
 		inst := inst // capture loop variable
 
+		// Quiet sessions back off to polling every few ticks
+		if !h.pollBackoff.due(inst.ID, now) {
+			backedOff++
+			continue
+		}
+
 		// Skip idle sessions when PipeManager knows they haven't produced output.
 		// Only skip if pipe is alive (otherwise we need UpdateStatus for Error detection).
 		if pm != nil {
@@ -1575,8 +1740,10 @@ func (h *Home) backgroundStatusUpdate() {
 				slowMu.Unlock()
 			}
 			newStatus := inst.GetStatusThreadSafe()
+			h.pollBackoff.record(inst.ID, newStatus, newStatus != oldStatus, now)
 			if newStatus != oldStatus {
 				statusChanged.Store(true)
+				h.queueAutoAttach(inst, oldStatus, newStatus)
 				notifLog.Debug("status_changed", slog.String("title", inst.Title), slog.String("old", string(oldStatus)), slog.String("new", string(newStatus)))
 			}
 			return nil
@@ -1584,9 +1751,29 @@ func (h *Home) backgroundStatusUpdate() {
 	}
 	_ = g.Wait() // Errors are logged within each goroutine
 
+	if h.alerter != nil {
+		session.SendAlerts(h.alerter.Check(instances, now))
+	}
+	if h.fileWatcher != nil {
+		session.RunFileWatchEvents(h.fileWatcher.Check(instances, now))
+	}
+	if h.statusWebhooks != nil {
+		session.SendWebhooks(h.statusWebhooks.Check(instances, now))
+	}
+	if h.unpushedWork != nil {
+		h.unpushedWork.Check(instances, now)
+	}
+	if h.gitBranches != nil {
+		h.gitBranches.Check(instances, now)
+	}
+	if h.ticketStatuses != nil {
+		h.ticketStatuses.Check(instances, now)
+	}
+
 	statusDur := time.Since(statusStart)
-	if skipped > 0 {
-		perfLog.Debug("idle_sessions_skipped", slog.Int("skipped", skipped), slog.Int("checked", len(instances)-skipped))
+	if skipped > 0 || backedOff > 0 {
+		perfLog.Debug("idle_sessions_skipped", slog.Int("skipped", skipped), slog.Int("backed_off", backedOff),
+			slog.Int("checked", len(instances)-skipped-backedOff))
 	}
 	if statusDur > 500*time.Millisecond {
 		perfLog.Info("slow_status_loop", slog.Duration("duration", statusDur), slog.Int("sessions", len(instances)))
@@ -1918,6 +2105,15 @@ func (h *Home) processStatusUpdate(req statusUpdateRequest) {
 
 // Update handles messages
 func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := h.update(msg)
+	if announce := h.announceSelection(); announce != nil {
+		return model, tea.Batch(cmd, announce)
+	}
+	return model, cmd
+}
+
+// update handles messages (Update adds screen reader announcements)
+func (h *Home) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
@@ -1932,7 +2128,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.syncViewport() // Recalculate viewport when window size changes
 		h.setupWizard.SetSize(msg.Width, msg.Height)
 		h.settingsPanel.SetSize(msg.Width, msg.Height)
-		h.geminiModelDialog.SetSize(msg.Width, msg.Height)
+		h.modelDialog.SetSize(msg.Width, msg.Height)
 		return h, nil
 
 	case loadSessionsMsg:
@@ -2397,6 +2593,23 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// or until the timeout expires (handled by cleanup logic in tickMsg handler)
 		return h, nil
 
+	case relocationsFoundMsg:
+		h.handleRelocationsFound(msg)
+		return h, nil
+
+	case sessionStoppedMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("failed to stop session: %w", msg.err))
+			return h, nil
+		}
+		h.cachedStatusCounts.valid.Store(false)
+		h.invalidatePreviewCache(msg.sessionID)
+		h.saveInstances()
+		if inst := h.getInstanceByID(msg.sessionID); inst != nil {
+			h.setError(fmt.Errorf("stopped '%s'. R to start it again", inst.Title))
+		}
+		return h, nil
+
 	case mcpRestartedMsg:
 		if msg.err != nil {
 			h.setError(fmt.Errorf("failed to restart session for MCP changes: %w", msg.err))
@@ -2417,6 +2630,22 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.updateInfo = msg.info
 		return h, nil
 
+	case toolVersionsMsg:
+		var broken []string
+		for _, v := range msg.versions {
+			if v.Installed() && v.Broken != "" {
+				broken = append(broken, fmt.Sprintf("%s: %s", v, v.Broken))
+			}
+		}
+		if len(broken) > 0 {
+			h.setError(fmt.Errorf("known broken: %s (agent-deck doctor)", strings.Join(broken, "; ")))
+		}
+		return h, nil
+
+	case TakeoverMsg:
+		uiLog.Info("taken_over_by_another_instance")
+		return h, h.performQuit(false)
+
 	case MaintenanceCompleteMsg:
 		return h, func() tea.Msg {
 			return maintenanceCompleteMsg{result: msg.Result}
@@ -2450,8 +2679,8 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return h, nil
 
 	case modelsFetchedMsg:
-		if h.geminiModelDialog != nil && h.geminiModelDialog.IsVisible() {
-			h.geminiModelDialog.HandleModelsFetched(msg)
+		if h.modelDialog != nil && h.modelDialog.IsVisible() {
+			h.modelDialog.HandleModelsFetched(msg)
 		}
 		return h, nil
 
@@ -2460,14 +2689,28 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.instancesMu.RLock()
 		inst := h.instanceByID[msg.instanceID]
 		h.instancesMu.RUnlock()
-		if inst != nil {
-			if err := inst.SetGeminiModel(msg.model); err != nil {
-				h.err = fmt.Errorf("failed to set model: %w", err)
-				h.errTime = time.Now()
-			}
-			// Force save to persist the model change
-			h.forceSaveInstances()
+		if inst == nil {
+			return h, nil
 		}
+		// SetModel restarts a running session, so it's claimed like one and
+		// runs off the UI goroutine
+		if !h.claimOperation(inst, session.OpRestarting) {
+			return h, nil
+		}
+		model := msg.model
+		return h, func() tea.Msg {
+			err := inst.SetModel(model)
+			inst.ReleaseOperation(session.OpRestarting)
+			return modelSwitchedMsg{err: err}
+		}
+
+	case modelSwitchedMsg:
+		if msg.err != nil {
+			h.err = fmt.Errorf("failed to set model: %w", msg.err)
+			h.errTime = time.Now()
+		}
+		// Force save to persist the model change
+		h.forceSaveInstances()
 		return h, nil
 
 	case refreshMsg:
@@ -2503,6 +2746,18 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Continue listening for next change
 		return h, tea.Batch(cmd, listenForReloads(h.storageWatcher))
 
+	case statusReportMsg:
+		if inst := h.getInstanceByID(msg.report.SessionID); inst != nil {
+			textChanged := inst.ApplyStatusReport(msg.report)
+			uiLog.Debug("status_report_applied",
+				slog.String("session", inst.ID),
+				slog.String("status", string(msg.report.Status)))
+			if textChanged {
+				h.saveInstances()
+			}
+		}
+		return h, listenForStatusReports(h.notifyListener)
+
 	case statusUpdateMsg:
 		// Clear attach flag - we've returned from the attached session
 		h.isAttaching.Store(false) // Atomic store for thread safety
@@ -2512,6 +2767,11 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// so this just refreshes the display with current busy indicator state.
 		h.triggerStatusUpdate()
 
+		var handoffCmd tea.Cmd
+		if msg.handoffID != "" {
+			handoffCmd = h.showHandoffPrompt(msg.handoffID)
+		}
+
 		// Cursor sync: if user switched sessions via notification bar during attach,
 		// move cursor to the session they were last viewing
 		h.lastNotifSwitchMu.Lock()
@@ -2553,7 +2813,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		reloading := h.isReloading
 		h.reloadMu.Unlock()
 		if reloading {
-			return h, nil
+			return h, handoffCmd
 		}
 
 		// PERFORMANCE FIX: Skip save on attach return for 10 seconds
@@ -2561,7 +2821,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Combine with periodic save instead of saving on every attach/detach.
 		// We'll let the next tickMsg handle background save if needed.
 
-		return h, nil
+		return h, handoffCmd
 
 	case previewDebounceMsg:
 		// PERFORMANCE: Debounce period elapsed - check if this fetch is still relevant
@@ -2646,12 +2906,21 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+			if cmd := h.fetchGitHubWork(inst); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
 			if len(cmds) > 0 {
 				return h, tea.Batch(cmds...)
 			}
 		}
 		return h, nil
 
+	case githubWorkFetchedMsg:
+		h.githubFetchingPath = ""
+		h.githubWork[msg.path] = msg.entry
+		return h, nil
+
 	case previewFetchedMsg:
 		// Async preview content received - update cache with timestamp
 		// Protect both previewFetchingID and previewCache with the same mutex
@@ -2664,6 +2933,16 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.previewCacheMu.Unlock()
 		return h, nil
 
+	case splitPreviewFetchedMsg:
+		h.splitPreviewFetching = false
+		if msg.err == nil {
+			h.previewCacheMu.Lock()
+			h.previewCache[msg.sessionID] = msg.content
+			h.previewCacheTime[msg.sessionID] = time.Now()
+			h.previewCacheMu.Unlock()
+		}
+		return h, nil
+
 	case analyticsFetchedMsg:
 		// Async analytics parsing complete - update TTL cache
 		h.analyticsFetchingID = ""
@@ -2708,6 +2987,130 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case terminalActionMsg:
+		if msg.err != nil {
+			h.setError(msg.err)
+		} else {
+			h.setError(fmt.Errorf("%s", msg.action))
+		}
+		return h, nil
+
+	case diffFetchedMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("git diff: %w", msg.err))
+			return h, nil
+		}
+		h.pagerOverlay.SetSize(h.width, h.height)
+		h.pagerOverlay.Show(
+			fmt.Sprintf("git diff: %s (%s)", msg.sessionTitle, msg.projectPath),
+			formatDiffContent(msg.stat, msg.diff),
+			styleDiffLine,
+		)
+		return h, nil
+
+	case approvalsCollectedMsg:
+		if h.approvalsInbox.IsVisible() {
+			h.approvalsInbox.SetItems(msg.items)
+		}
+		return h, nil
+
+	case directorySuggestionsMsg:
+		if h.newDialog.IsVisible() {
+			h.newDialog.SetPathSuggestions(msg.paths)
+		}
+		return h, nil
+
+	case newSessionLabeledMsg:
+		// Dismissed while being named
+		if !h.newDialog.IsVisible() {
+			return h, nil
+		}
+		return h.createFromNewDialog(msg.req)
+
+	case approvalAnsweredMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("failed to answer '%s': %w", msg.title, msg.err))
+		} else if msg.approved {
+			h.setError(fmt.Errorf("Approved prompt in '%s'", msg.title))
+		} else {
+			h.setError(fmt.Errorf("Denied prompt in '%s'", msg.title))
+		}
+		// Rescan once the agent has redrawn (it may ask again right away)
+		return h, tea.Tick(approvalsRefreshDelay, func(time.Time) tea.Msg { return approvalsRefreshMsg{} })
+
+	case costsCollectedMsg:
+		if h.statsView.IsVisible() {
+			h.statsView.SetSummary(msg.summary)
+		}
+		return h, nil
+
+	case timelineLoadedMsg:
+		if h.timelineView.IsVisible() {
+			h.timelineView.SetActivity(msg)
+		}
+		return h, nil
+
+	case approvalsRefreshMsg:
+		if h.approvalsInbox.IsVisible() {
+			return h, h.collectApprovals()
+		}
+		return h, nil
+
+	case snapshotSavedMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("snapshot: %w", msg.err))
+		} else {
+			h.setError(fmt.Errorf("Saved snapshot '%s' of '%s' (B to browse)", msg.snapshot.Name, msg.sessionTitle))
+		}
+		return h, nil
+
+	case snapshotDiffMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("snapshot diff: %w", msg.err))
+			return h, nil
+		}
+		h.pagerOverlay.SetSize(h.width, h.height)
+		h.pagerOverlay.Show(
+			fmt.Sprintf("Since snapshot '%s': %s (+%d lines)", msg.snapshotName, msg.sessionTitle, msg.added),
+			strings.TrimRight(msg.diff, "\n"),
+			styleDiffLine,
+		)
+		return h, nil
+
+	case snapshotsListedMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("snapshots: %w", msg.err))
+			return h, nil
+		}
+		if inst := h.getInstanceByID(msg.sessionID); inst != nil {
+			h.snapshotBrowser.SetSize(h.width, h.height)
+			h.snapshotBrowser.Show(inst, msg.snapshots)
+		}
+		return h, nil
+
+	case scrollbackFetchedMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("scrollback: %w", msg.err))
+			return h, nil
+		}
+		h.pagerOverlay.SetSize(h.width, h.height)
+		h.pagerOverlay.Show(
+			fmt.Sprintf("Scrollback: %s", msg.sessionTitle),
+			strings.TrimRight(msg.content, "\n"),
+			h.highlighter.styler(),
+		)
+		// Open at the newest output, like the preview
+		h.pagerOverlay.ScrollToBottom()
+		return h, nil
+
+	case exportResultMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("export failed: %w", msg.err))
+		} else {
+			h.setError(fmt.Errorf("Exported %d lines from '%s' to %s", msg.lineCount, msg.sessionTitle, msg.path))
+		}
+		return h, nil
+
 	case sendOutputResultMsg:
 		if msg.err != nil {
 			h.setError(fmt.Errorf("failed to send to %s: %v", msg.targetTitle, msg.err))
@@ -2771,11 +3174,19 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			h.lastCachePrune = time.Now()
 			h.pruneAnalyticsCache()
 
+			h.instancesMu.RLock()
+			live := make(map[string]bool, len(h.instances))
+			for _, inst := range h.instances {
+				live[inst.ID] = true
+			}
+			h.instancesMu.RUnlock()
+			h.pollBackoff.prune(live)
+
 			// Prune dead pipes and connect new sessions
 			if pm := tmux.GetPipeManager(); pm != nil {
 				h.instancesMu.RLock()
 				for _, inst := range h.instances {
-					if ts := inst.GetTmuxSession(); ts != nil && ts.Exists() {
+					if ts := inst.GetTmuxSession(); ts != nil && ts.OnDeckServer() && ts.Exists() {
 						if !pm.IsConnected(ts.Name) {
 							go func(name string) {
 								_ = pm.Connect(name)
@@ -2830,7 +3241,19 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			h.previewCacheMu.Unlock()
 		}
-		return h, tea.Batch(h.tick(), previewCmd)
+
+		// Keep the split (secondary) preview fresh on the same TTL
+		var splitCmd tea.Cmd
+		if split := h.getSplitSession(); split != nil && !h.splitPreviewFetching {
+			h.previewCacheMu.RLock()
+			cachedTime, hasCached := h.previewCacheTime[split.ID]
+			h.previewCacheMu.RUnlock()
+			if !hasCached || time.Since(cachedTime) > previewCacheTTL {
+				h.splitPreviewFetching = true
+				splitCmd = h.fetchSplitPreview(split)
+			}
+		}
+		return h, tea.Batch(h.tick(), previewCmd, splitCmd, h.processAutoAttach())
 
 	case globalSearchDebounceMsg, globalSearchResultsMsg:
 		// Route async global search messages to the global search component
@@ -2881,6 +3304,16 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					h.errTime = time.Now()
 				}
 				_, _ = session.ReloadUserConfig()
+				h.highlighter = loadHighlighter()
+				if h.alerter != nil {
+					h.alerter.SetSettings(session.GetNotificationsSettings())
+				}
+				if h.statusWebhooks != nil {
+					h.statusWebhooks.SetWebhooks(session.GetWebhooks())
+				}
+				if h.unpushedWork != nil {
+					h.unpushedWork.SetSettings(session.GetUnpushedSettings())
+				}
 				// Apply default tool to new dialog
 				if defaultTool := session.GetDefaultTool(); defaultTool != "" {
 					h.newDialog.SetDefaultTool(defaultTool)
@@ -2895,6 +3328,26 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			h.helpOverlay, _ = h.helpOverlay.Update(msg)
 			return h, nil
 		}
+		if h.pagerOverlay.IsVisible() {
+			var cmd tea.Cmd
+			h.pagerOverlay, cmd = h.pagerOverlay.Update(msg)
+			return h, cmd
+		}
+		if h.approvalsInbox.IsVisible() {
+			return h.handleApprovalsKey(msg)
+		}
+		if h.statsView.IsVisible() {
+			return h.handleStatsKey(msg)
+		}
+		if h.timelineView.IsVisible() {
+			return h.handleTimelineKey(msg)
+		}
+		if h.previewSearching {
+			return h.handlePreviewSearchKey(msg)
+		}
+		if h.inlineEdit.active() {
+			return h.handleInlineEditKey(msg)
+		}
 		if h.search.IsVisible() {
 			return h.handleSearchKey(msg)
 		}
@@ -2916,14 +3369,29 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if h.mcpDialog.IsVisible() {
 			return h.handleMCPDialogKey(msg)
 		}
-		if h.geminiModelDialog.IsVisible() {
-			d, cmd := h.geminiModelDialog.Update(msg)
-			h.geminiModelDialog = d
+		if h.modelDialog.IsVisible() {
+			d, cmd := h.modelDialog.Update(msg)
+			h.modelDialog = d
 			return h, cmd
 		}
 		if h.sessionPickerDialog.IsVisible() {
 			return h.handleSessionPickerDialogKey(msg)
 		}
+		if h.relocateDialog.IsVisible() {
+			return h.handleRelocateDialogKey(msg)
+		}
+		if h.handoffDialog.IsVisible() {
+			return h.handleHandoffDialogKey(msg)
+		}
+		if h.contextFilesDialog.IsVisible() {
+			return h.handleContextFilesDialogKey(msg)
+		}
+		if h.snapshotBrowser.IsVisible() {
+			return h.handleSnapshotBrowserKey(msg)
+		}
+		if h.bulkMoveDialog.IsVisible() {
+			return h.handleBulkMoveKey(msg)
+		}
 
 		// Main view keys
 		return h.handleMainKey(msg)
@@ -3104,86 +3572,177 @@ func (h *Home) getCurrentGroupPath() string {
 	return ""
 }
 
-// handleNewDialogKey handles keys when new dialog is visible
-func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
-		// Validate before creating session
-		if validationErr := h.newDialog.Validate(); validationErr != "" {
-			h.newDialog.SetError(validationErr)
-			return h, nil
-		}
+// newSessionRequest is what the new session dialog asked for
+type newSessionRequest struct {
+	name, path, command, branch, group, template string
+	worktree                                     bool
+	claudeOpts                                   *session.ClaudeOptions
+	codexYolo, geminiYolo                        bool
+	customYolo                                   *bool
+}
 
-		// Get values including worktree settings
-		name, path, command, branchName, worktreeEnabled := h.newDialog.GetValuesWithWorktree()
-		groupPath := h.newDialog.GetSelectedGroup()
-		claudeOpts := h.newDialog.GetClaudeOptions() // Get Claude options if applicable
+// directorySuggestionsMsg carries the new session dialog's path suggestions
+// with frequently used directories added
+type directorySuggestionsMsg struct {
+	paths []string
+}
 
-		// Handle worktree creation if enabled
-		var worktreePath, worktreeRepoRoot string
-		if worktreeEnabled && branchName != "" {
-			// Validate path is a git repo
-			if !git.IsGitRepo(path) {
-				h.newDialog.SetError("Path is not a git repository")
-				return h, nil
+// suggestDirectories appends frequently used directories (zoxide or the
+// recent-directories cache) that aren't among sessionPaths yet. zoxide and
+// the existence checks stay off the UI goroutine.
+func suggestDirectories(sessionPaths []string) tea.Cmd {
+	return func() tea.Msg {
+		known := make(map[string]bool, len(sessionPaths))
+		for _, p := range sessionPaths {
+			known[session.PathKey(p)] = true
+		}
+		paths := append([]string(nil), sessionPaths...)
+		for _, dir := range session.SuggestDirectories(session.GetSuggestionSettings()) {
+			if !known[session.PathKey(dir)] {
+				paths = append(paths, dir)
 			}
+		}
+		return directorySuggestionsMsg{paths: paths}
+	}
+}
 
-			repoRoot, err := git.GetRepoRoot(path)
-			if err != nil {
-				h.newDialog.SetError(fmt.Sprintf("Failed to get repo root: %v", err))
-				return h, nil
-			}
+// newSessionLabeledMsg carries a dialog request once LabelNewSession has
+// named and grouped it
+type newSessionLabeledMsg struct {
+	req newSessionRequest
+}
 
-			// Generate worktree path using configured location/template
-			wtSettings := session.GetWorktreeSettings()
-			worktreePath = git.WorktreePath(git.WorktreePathOptions{
-				Branch:    branchName,
-				Location:  wtSettings.DefaultLocation,
-				RepoDir:   repoRoot,
-				SessionID: git.GeneratePathID(),
-				Template:  wtSettings.Template(),
-			})
+// labelNewSession fills in the request's title and group (see
+// session.LabelNewSession). The default group counts as none chosen.
+func labelNewSession(req newSessionRequest) tea.Cmd {
+	return func() tea.Msg {
+		group := req.group
+		if group == session.DefaultGroupPath {
+			group = ""
+		}
+		var g string
+		req.name, g, _ = session.LabelNewSession(req.name, group, req.path)
+		if g != "" {
+			req.group = g
+		}
+		return newSessionLabeledMsg{req: req}
+	}
+}
 
-			// Ensure parent directory exists (needed for subdirectory mode)
-			if err := os.MkdirAll(filepath.Dir(worktreePath), 0755); err != nil {
-				h.newDialog.SetError(fmt.Sprintf("Failed to create parent directory: %v", err))
-				return h, nil
-			}
+// createFromNewDialog creates the session the dialog asked for, once named
+func (h *Home) createFromNewDialog(req newSessionRequest) (tea.Model, tea.Cmd) {
+	name, path, command, branchName := req.name, req.path, req.command, req.branch
+	groupPath, templateName, claudeOpts := req.group, req.template, req.claudeOpts
+	if name == "" {
+		h.newDialog.SetError("Session name cannot be empty")
+		return h, nil
+	}
+	if len(name) > MaxNameLength {
+		h.newDialog.SetError(fmt.Sprintf("Session name too long (max %d characters)", MaxNameLength))
+		return h, nil
+	}
 
-			// Create worktree
-			if err := git.CreateWorktree(repoRoot, worktreePath, branchName); err != nil {
-				h.newDialog.SetError(fmt.Sprintf("Failed to create worktree: %v", err))
-				return h, nil
-			}
+	// Handle worktree creation if enabled
+	var worktreePath, worktreeRepoRoot string
+	if req.worktree && branchName != "" {
+		// Validate path is a git repo
+		if !git.IsGitRepo(path) {
+			h.newDialog.SetError("Path is not a git repository")
+			return h, nil
+		}
 
-			// Store repo root for later use
-			worktreeRepoRoot = repoRoot
-			// Update path to worktree for session creation
-			path = worktreePath
+		repoRoot, err := git.GetRepoRoot(path)
+		if err != nil {
+			h.newDialog.SetError(fmt.Sprintf("Failed to get repo root: %v", err))
+			return h, nil
 		}
 
-		// Build generic toolOptionsJSON from tool-specific options
-		var toolOptionsJSON json.RawMessage
-		if command == "claude" && claudeOpts != nil {
-			toolOptionsJSON, _ = session.MarshalToolOptions(claudeOpts)
-		} else if command == "codex" {
-			yolo := h.newDialog.GetCodexYoloMode()
-			codexOpts := &session.CodexOptions{YoloMode: &yolo}
-			toolOptionsJSON, _ = session.MarshalToolOptions(codexOpts)
+		// Generate worktree path using configured location/template
+		wtSettings := session.GetWorktreeSettings()
+		worktreePath = git.WorktreePath(git.WorktreePathOptions{
+			Branch:    branchName,
+			Location:  wtSettings.DefaultLocation,
+			RepoDir:   repoRoot,
+			SessionID: git.GeneratePathID(),
+			Template:  wtSettings.Template(),
+		})
+
+		// Ensure parent directory exists (needed for subdirectory mode)
+		if err := os.MkdirAll(filepath.Dir(worktreePath), 0755); err != nil {
+			h.newDialog.SetError(fmt.Sprintf("Failed to create parent directory: %v", err))
+			return h, nil
 		}
 
-		if _, err := os.Stat(path); os.IsNotExist(err) {
-			h.newDialog.Hide()
-			h.confirmDialog.ShowCreateDirectory(path, name, command, groupPath, toolOptionsJSON)
+		// Create worktree
+		if err := git.CreateWorktree(repoRoot, worktreePath, branchName); err != nil {
+			h.newDialog.SetError(fmt.Sprintf("Failed to create worktree: %v", err))
 			return h, nil
 		}
 
+		// Store repo root for later use
+		worktreeRepoRoot = repoRoot
+		// Update path to worktree for session creation
+		path = worktreePath
+	}
+
+	// Build generic toolOptionsJSON from tool-specific options
+	var toolOptionsJSON json.RawMessage
+	if command == "claude" && claudeOpts != nil {
+		toolOptionsJSON, _ = session.MarshalToolOptions(claudeOpts)
+	} else if command == "codex" {
+		codexOpts := &session.CodexOptions{YoloMode: &req.codexYolo}
+		toolOptionsJSON, _ = session.MarshalToolOptions(codexOpts)
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
 		h.newDialog.Hide()
-		h.clearError()
+		h.confirmDialog.ShowCreateDirectory(path, name, command, groupPath, toolOptionsJSON)
+		return h, nil
+	}
+
+	h.newDialog.Hide()
+	h.clearError()
+
+	// Gemini and custom tools keep the YOLO choice on the instance
+	yoloMode := req.customYolo
+	if command == "gemini" {
+		yoloMode = &req.geminiYolo
+	}
+
+	// [yolo_sandbox] mode = "ask" offers the sandbox before a YOLO session starts
+	if settings := session.GetYoloSandboxSettings(); settings.GetMode() == "ask" {
+		inst := newSessionInstance(name, path, command, groupPath, worktreePath, worktreeRepoRoot, branchName, yoloMode, toolOptionsJSON, templateName)
+		if spec := settings.SandboxFor(inst); spec != nil {
+			h.offerYoloSandbox(inst.Title, spec, func(sandbox bool) tea.Cmd {
+				if sandbox {
+					inst.Container = spec
+				}
+				return startNewSession(inst)
+			})
+			return h, nil
+		}
+		return h, startNewSession(inst)
+	}
+	return h, h.createSessionInGroupWithWorktreeAndOptions(name, path, command, groupPath, worktreePath, worktreeRepoRoot, branchName, yoloMode, toolOptionsJSON, templateName)
+}
 
-		geminiYoloMode := h.newDialog.IsGeminiYoloMode()
+// handleNewDialogKey handles keys when new dialog is visible
+func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		// Validate before creating session
+		if validationErr := h.newDialog.Validate(); validationErr != "" {
+			h.newDialog.SetError(validationErr)
+			return h, nil
+		}
 
-		return h, h.createSessionInGroupWithWorktreeAndOptions(name, path, command, groupPath, worktreePath, worktreeRepoRoot, branchName, geminiYoloMode, toolOptionsJSON)
+		// Naming and grouping read the git remote, so they run in a tea.Cmd
+		// and creation goes on at newSessionLabeledMsg
+		req := newSessionRequest{group: h.newDialog.GetSelectedGroup(), template: h.newDialog.GetTemplate(),
+			claudeOpts: h.newDialog.GetClaudeOptions(), codexYolo: h.newDialog.GetCodexYoloMode(),
+			customYolo: h.newDialog.GetCustomYoloMode(), geminiYolo: h.newDialog.IsGeminiYoloMode()}
+		req.name, req.path, req.command, req.branch, req.worktree = h.newDialog.GetValuesWithWorktree()
+		return h, labelNewSession(req)
 
 	case "esc":
 		h.newDialog.Hide()
@@ -3198,6 +3757,10 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleMainKey handles keys in main view
 func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := h.handlePreviewFocusKey(msg); handled {
+		return model, cmd
+	}
+
 	switch msg.String() {
 	case "q", "ctrl+c":
 		return h.tryQuit()
@@ -3208,6 +3771,12 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			h.maintenanceMsg = ""
 			return h, nil
 		}
+		// Clear marks before counting towards the double-ESC quit
+		if len(h.markedSessions) > 0 {
+			h.markedSessions = make(map[string]bool)
+			h.clearError()
+			return h, nil
+		}
 		// Double ESC to quit (#28) - for non-English keyboard users
 		// If ESC pressed twice within 500ms, quit the application
 		if time.Since(h.lastEscTime) < 500*time.Millisecond {
@@ -3341,9 +3910,16 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					return h, nil
 				}
 				if item.Session.Exists() {
+					if session.GetTerminalSettings().AttachInNewWindow {
+						return h, h.attachInNewWindow(item.Session)
+					}
 					h.isAttaching.Store(true) // Prevent View() output during transition (atomic)
 					return h, h.attachSession(item.Session)
 				}
+				// Offer to relocate a session whose repo was moved or renamed
+				if item.Session.PathMissing() {
+					return h, h.findRelocations(item.Session)
+				}
 			} else if item.Type == session.ItemTypeGroup {
 				// Toggle group on enter
 				groupPath := item.Path
@@ -3448,12 +4024,32 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case " ":
+		// Mark the session for a bulk move
+		h.toggleMark()
+		return h, nil
+
 	case "m":
-		// Move session to different group
+		// Move the marked sessions, or this one, to a different group
+		if len(h.markedSessions) > 0 {
+			h.showBulkMove()
+			if h.bulkMoveDialog.IsVisible() {
+				return h, nil
+			}
+		}
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
 			if item.Type == session.ItemTypeSession {
 				h.groupDialog.ShowMove(h.groupTree.GetGroupNames())
+			} else if item.Type == session.ItemTypeGroup && item.Group != nil {
+				// Merge this group (sessions and subgroups) into another one
+				var targets []string
+				for _, g := range h.groupTree.GroupList {
+					if g.Path != item.Path && !strings.HasPrefix(g.Path, item.Path+"/") {
+						targets = append(targets, g.Path)
+					}
+				}
+				h.groupDialog.ShowMerge(item.Path, item.Group.Name, targets)
 			}
 		}
 		return h, nil
@@ -3564,6 +4160,43 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case "e":
+		// Edit a session's title, group and command in its row; rename a group
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeGroup {
+				h.groupDialog.ShowRename(item.Path, item.Group.Name)
+			} else if item.Type == session.ItemTypeSession && item.Session != nil {
+				return h, h.startInlineEdit(inlineTitle)
+			}
+		}
+		return h, nil
+
+	case "t":
+		// Set custom status text for the selected session
+		if inst := h.getSelectedSession(); inst != nil {
+			h.groupDialog.ShowStatusText(inst.ID, inst.GetStatusText())
+		}
+		return h, nil
+
+	case "b":
+		// Snapshot the selected session's pane under a name
+		if inst := h.getSelectedSession(); inst != nil {
+			if !inst.Exists() {
+				h.setError(fmt.Errorf("'%s' is not running, nothing to snapshot", inst.Title))
+				return h, nil
+			}
+			h.groupDialog.ShowSnapshotName(inst.ID)
+		}
+		return h, nil
+
+	case "B":
+		// Browse the selected session's snapshots
+		if inst := h.getSelectedSession(); inst != nil {
+			return h, h.listSnapshots(inst)
+		}
+		return h, nil
+
 	case "/":
 		// Open global search first if available, otherwise local search
 		if h.globalSearchIndex != nil {
@@ -3596,14 +4229,16 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if inst.ProjectPath == "" {
 				continue
 			}
-			existing, ok := pathMap[inst.ProjectPath]
+			// Keyed canonically so ~/code/foo and a symlink to it are one entry
+			key := session.PathKey(inst.ProjectPath)
+			existing, ok := pathMap[key]
 			if !ok {
 				// First time seeing this path
 				accessTime := inst.LastAccessedAt
 				if accessTime.IsZero() {
 					accessTime = inst.CreatedAt // Fall back to creation time
 				}
-				pathMap[inst.ProjectPath] = &pathInfo{
+				pathMap[key] = &pathInfo{
 					path:           inst.ProjectPath,
 					lastAccessedAt: accessTime,
 				}
@@ -3628,7 +4263,8 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return pathInfos[i].lastAccessedAt.After(pathInfos[j].lastAccessedAt)
 		})
 
-		// Extract sorted paths
+		// Extract sorted paths; frequently used directories follow once
+		// suggestDirectories has looked them up
 		paths := make([]string, len(pathInfos))
 		for i, info := range pathInfos {
 			paths[i] = info.path
@@ -3656,7 +4292,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		defaultPath := h.getDefaultPathForGroup(groupPath)
 		h.newDialog.ShowInGroup(groupPath, groupName, defaultPath)
-		return h, nil
+		return h, suggestDirectories(paths)
 
 	case "N":
 		// Quick create: auto-generated name, smart defaults from group context
@@ -3664,16 +4300,133 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "d":
 		// Show confirmation dialog before deletion (prevents accidental deletion)
+		// unless [confirm] turns it off
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
+			confirm := session.GetConfirmSettings()
 			if item.Type == session.ItemTypeSession && item.Session != nil {
-				h.confirmDialog.ShowDeleteSession(item.Session.ID, item.Session.Title)
+				if !confirm.GetDeleteSession() {
+					return h, h.deleteSession(item.Session)
+				}
+				h.confirmDialog.ShowDeleteSession(item.Session.ID, item.Session.Title, !confirm.GetDeleteKillsTmux())
 			} else if item.Type == session.ItemTypeGroup && item.Path != session.DefaultGroupPath {
+				if !confirm.GetDeleteGroup() {
+					h.deleteGroup(item.Path)
+					return h, nil
+				}
 				h.confirmDialog.ShowDeleteGroup(item.Path, item.Group.Name)
 			}
 		}
 		return h, nil
 
+	case "X":
+		// Stop the session: kill its tmux session but keep it in the deck
+		if inst := h.getSelectedSession(); inst != nil {
+			if !inst.Exists() {
+				h.setError(fmt.Errorf("session '%s' is not running", inst.Title))
+				return h, nil
+			}
+			if !session.GetConfirmSettings().GetKillTmux() {
+				return h, h.stopSession(inst)
+			}
+			h.confirmDialog.ShowKillTmux(inst.ID, inst.Title)
+		}
+		return h, nil
+
+	case "A":
+		// Attach in a new terminal window, keeping the deck open
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				if h.hasActiveAnimation(item.Session.ID) {
+					h.setError(fmt.Errorf("session is starting, please wait..."))
+					return h, nil
+				}
+				if item.Session.Exists() {
+					return h, h.attachInNewWindow(item.Session)
+				}
+			}
+		}
+		return h, nil
+
+	case "E":
+		// Export the session's full scrollback to a file
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				return h, h.exportSessionScrollback(item.Session)
+			}
+		}
+		return h, nil
+
+	case "p":
+		// Toggle preview follow mode (auto-scroll with new output)
+		h.toggleFollow()
+		h.saveUIState()
+		return h, nil
+
+	case "pgup":
+		// Scroll the preview back into history (pauses follow)
+		h.scrollPreview(h.previewPageSize())
+		return h, nil
+
+	case "pgdown":
+		// Scroll the preview toward the newest output (resumes follow at the bottom)
+		h.scrollPreview(-h.previewPageSize())
+		return h, nil
+
+	case "a":
+		// Open the approvals inbox (pending permission prompts across all sessions)
+		h.approvalsInbox.SetSize(h.width, h.height)
+		h.approvalsInbox.Show()
+		return h, h.collectApprovals()
+
+	case "C":
+		// Open the stats overlay (estimated API spend across sessions)
+		h.statsView.SetSize(h.width, h.height)
+		h.statsView.Show()
+		return h, h.collectCosts()
+
+	case "T":
+		// Open the activity timeline (every session's status over the last day)
+		h.timelineView.SetSize(h.width, h.height)
+		h.timelineView.Show()
+		return h, h.loadTimeline()
+
+	case "P":
+		// Search the selected session's preview output
+		return h, h.startPreviewSearch()
+
+	case "L":
+		// Open the session's full scrollback in the log viewer
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				return h, h.fetchSessionScrollback(item.Session)
+			}
+		}
+		return h, nil
+
+	case "o":
+		// Open the project in $EDITOR in a new terminal tab
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				return h, h.openEditorInNewTab(item.Session)
+			}
+		}
+		return h, nil
+
+	case "D":
+		// Show git diff of the selected session's project
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				return h, h.fetchSessionDiff(item.Session)
+			}
+		}
+		return h, nil
+
 	case "i":
 		return h, h.importSessions
 
@@ -3693,36 +4446,34 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return h, nil
 
 	case "v":
-		// Toggle preview mode (cycle: both → output-only → analytics-only → both)
-		h.previewMode = (h.previewMode + 1) % 3
-		return h, nil
+		// Toggle preview mode (cycle: both → output-only → analytics-only → GitHub → both)
+		h.previewMode = (h.previewMode + 1) % 4
+		return h, h.fetchGitHubWork(h.getSelectedSession())
 
 	case "y":
-		// Toggle Gemini YOLO mode (requires restart)
+		// Toggle the launch mode between safe and YOLO (requires restart)
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
-			if item.Type == session.ItemTypeSession && item.Session != nil && item.Session.Tool == "gemini" {
+			if item.Type == session.ItemTypeSession && item.Session != nil && session.SupportsLaunchMode(item.Session.Tool) {
 				inst := item.Session
-				// Determine current YOLO state
-				currentYolo := false
-				if inst.GeminiYoloMode != nil {
-					currentYolo = *inst.GeminiYoloMode
-				} else {
-					// Fall back to global config
-					userConfig, _ := session.LoadUserConfig()
-					if userConfig != nil {
-						currentYolo = userConfig.Gemini.YoloMode
-					}
+				wasYolo := inst.LaunchMode() == session.LaunchModeYolo
+				if err := inst.SetLaunchMode(!wasYolo); err != nil {
+					h.setError(err)
+					return h, nil
+				}
+				// The [yolo_sandbox] container is only offered when a session is
+				// created; leaving YOLO takes the session out of it
+				if wasYolo && session.GetYoloSandboxSettings().IsSandbox(inst.Container) {
+					inst.Container = nil
 				}
-				// Toggle: set per-session override to opposite of current
-				newYolo := !currentYolo
-				inst.GeminiYoloMode = &newYolo
 				h.saveInstances()
+				h.invalidatePreviewCache(inst.ID)
 				// If session is running, it needs restart to apply
 				if inst.GetStatusThreadSafe() == session.StatusRunning || inst.GetStatusThreadSafe() == session.StatusWaiting {
 					h.resumingSessions[inst.ID] = time.Now()
 					return h, h.restartSession(inst)
 				}
+				return h, nil
 			}
 		}
 		return h, nil
@@ -3737,6 +4488,9 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					h.setError(fmt.Errorf("session is starting, please wait..."))
 					return h, nil
 				}
+				if !item.Session.Exists() && item.Session.PathMissing() {
+					return h, h.findRelocations(item.Session)
+				}
 				if item.Session.CanRestart() {
 					// Track as resuming for animation (before async call starts)
 					h.resumingSessions[item.Session.ID] = time.Now()
@@ -3772,10 +4526,18 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case "O":
+		// Key files of the session: add, remove, type into it
+		return h, h.showContextFiles()
+
 	case "ctrl+g":
-		// Open Gemini model selection dialog (only for Gemini sessions)
-		if inst := h.getSelectedSession(); inst != nil && inst.Tool == "gemini" {
-			cmd := h.geminiModelDialog.Show(inst.ID, inst.GeminiModel)
+		// Open the model switcher (restarts the session with the chosen model)
+		if inst := h.getSelectedSession(); inst != nil {
+			if !session.SupportsModelSwitch(inst.Tool) {
+				h.setError(fmt.Errorf("switching models isn't supported for %s sessions", inst.Tool))
+				return h, nil
+			}
+			cmd := h.modelDialog.Show(inst.ID, inst.Tool, inst.Model())
 			return h, cmd
 		}
 		return h, nil
@@ -3787,9 +4549,17 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return h, nil
 		}
 		entry := h.undoStack[len(h.undoStack)-1]
-		h.undoStack = h.undoStack[:len(h.undoStack)-1]
 		inst := entry.instance
+		if !h.claimOperation(inst, session.OpRestarting) {
+			return h, nil
+		}
+		h.undoStack = h.undoStack[:len(h.undoStack)-1]
 		return h, func() tea.Msg {
+			// A delete that kept the tmux session only needs the record back
+			if inst.Exists() {
+				inst.ReleaseOperation(session.OpRestarting)
+				return sessionRestoredMsg{instance: inst}
+			}
 			err := inst.Restart()
 			return sessionRestoredMsg{instance: inst, err: err}
 		}
@@ -3822,6 +4592,51 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		h.rebuildFlatItems()
 		return h, nil
 
+	case "z":
+		// Toggle focus mode on the current group (pinned sessions stay visible)
+		h.toggleFocus()
+		h.saveUIState()
+		if selected := h.getSelectedSession(); selected != nil {
+			return h, h.fetchPreviewDebounced(selected.ID)
+		}
+		return h, nil
+
+	case "s":
+		// Mark/unmark session as split preview (shown below the selected session)
+		if inst := h.getSelectedSession(); inst != nil {
+			if h.toggleSplitMark(inst) {
+				h.splitPreviewFetching = true
+				return h, h.fetchSplitPreview(inst)
+			}
+		}
+		return h, nil
+
+	case "H":
+		// Hide/unhide the current group (stays in storage, "." shows hidden groups)
+		if err := h.toggleGroupHidden(); err != nil {
+			h.setError(err)
+			return h, nil
+		}
+		h.saveInstances()
+		if selected := h.getSelectedSession(); selected != nil {
+			return h, h.fetchPreviewDebounced(selected.ID)
+		}
+		return h, nil
+
+	case ".":
+		// Show/hide groups marked hidden
+		h.toggleShowHidden()
+		h.saveUIState()
+		return h, nil
+
+	case "Z":
+		// Pin/unpin session (pinned sessions are always shown in focus mode)
+		if inst := h.getSelectedSession(); inst != nil {
+			h.togglePin(inst)
+			h.saveUIState()
+		}
+		return h, nil
+
 	case "!", "shift+1":
 		// Filter to running sessions only
 		if h.statusFilter == session.StatusRunning {
@@ -3886,6 +4701,39 @@ func (h *Home) handleConfirmDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case ConfirmAutoAttach:
+		switch msg.String() {
+		case "y", "Y", "enter":
+			inst := h.getInstanceByID(h.confirmDialog.GetTargetID())
+			h.confirmDialog.Hide()
+			if inst != nil && inst.Exists() {
+				return h, h.autoAttach(inst)
+			}
+			return h, nil
+		case "n", "N", "esc":
+			h.confirmDialog.Hide()
+			return h, nil
+		}
+		return h, nil
+
+	case ConfirmYoloSandbox:
+		switch msg.String() {
+		case "y", "Y", "enter", "n", "N":
+			apply := h.pendingSandbox
+			sandbox := strings.ToLower(msg.String()) != "n"
+			h.pendingSandbox = nil
+			h.confirmDialog.Hide()
+			if apply != nil {
+				return h, apply(sandbox)
+			}
+			return h, nil
+		case "esc":
+			h.pendingSandbox = nil
+			h.confirmDialog.Hide()
+			return h, nil
+		}
+		return h, nil
+
 	case ConfirmCreateDirectory:
 		switch msg.String() {
 		case "y", "Y":
@@ -3895,7 +4743,7 @@ func (h *Home) handleConfirmDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				h.setError(fmt.Errorf("failed to create directory: %w", err))
 				return h, nil
 			}
-			return h, h.createSessionInGroupWithWorktreeAndOptions(name, path, command, groupPath, "", "", "", false, pendingToolOpts)
+			return h, h.createSessionInGroupWithWorktreeAndOptions(name, path, command, groupPath, "", "", "", nil, pendingToolOpts, "")
 		case "n", "N", "esc":
 			h.confirmDialog.Hide()
 			return h, nil
@@ -3903,7 +4751,7 @@ func (h *Home) handleConfirmDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return h, nil
 
 	default:
-		// Handle delete confirmations (session/group)
+		// Handle delete, stop and merge confirmations
 		switch msg.String() {
 		case "y", "Y":
 			// User confirmed - perform the deletion
@@ -3915,13 +4763,14 @@ func (h *Home) handleConfirmDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					return h, h.deleteSession(inst)
 				}
 			case ConfirmDeleteGroup:
-				groupPath := h.confirmDialog.GetTargetID()
-				h.groupTree.DeleteGroup(groupPath)
-				h.instancesMu.Lock()
-				h.instances = h.groupTree.GetAllInstances()
-				h.instancesMu.Unlock()
-				h.rebuildFlatItems()
-				h.saveInstances()
+				h.deleteGroup(h.confirmDialog.GetTargetID())
+			case ConfirmKillTmux:
+				if inst := h.getInstanceByID(h.confirmDialog.GetTargetID()); inst != nil {
+					h.confirmDialog.Hide()
+					return h, h.stopSession(inst)
+				}
+			case ConfirmMergeGroup:
+				h.mergeGroups(h.confirmDialog.GetTargetID(), h.confirmDialog.GetMergeInto())
 			}
 			h.confirmDialog.Hide()
 			return h, nil
@@ -3996,6 +4845,10 @@ func (h *Home) performFinalShutdown(shutdownPool bool) tea.Cmd {
 		if h.storageWatcher != nil {
 			h.storageWatcher.Close()
 		}
+		session.StopFileActivity()
+		if h.notifyListener != nil {
+			h.notifyListener.Close()
+		}
 		// Close global search index
 		if h.globalSearchIndex != nil {
 			h.globalSearchIndex.Close()
@@ -4011,7 +4864,15 @@ func (h *Home) performFinalShutdown(shutdownPool bool) tea.Cmd {
 		}
 		// Clean up notification bar (clear tmux status bars and unbind keys)
 		h.cleanupNotifications()
-		// Save UI state (cursor, preview mode, filter) before saving instances
+		// Deletes can't be undone after exit
+		dropUndoEntries(h.undoStack)
+		// Record running time in progress, then save UI state (cursor,
+		// preview mode, filter) before saving instances
+		h.instancesMu.RLock()
+		for _, inst := range h.instances {
+			inst.FlushRunning()
+		}
+		h.instancesMu.RUnlock()
 		h.saveUIState()
 		// Save both instances AND groups on quit (critical fix: was losing groups!)
 		h.saveInstances()
@@ -4152,6 +5013,24 @@ func (h *Home) handleGroupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				h.rebuildFlatItems()
 				h.saveInstances()
 			}
+		case GroupDialogMerge:
+			src, dst := h.groupDialog.GetGroupPath(), h.groupDialog.GetSelectedGroup()
+			if n := h.groupTree.SessionCountForGroup(src); n > 0 && session.GetConfirmSettings().GetBulk() {
+				h.groupDialog.Hide()
+				h.confirmDialog.ShowMergeGroup(src, dst, n)
+				return h, nil
+			}
+			h.mergeGroups(src, dst)
+		case GroupDialogStatusText:
+			if inst := h.getInstanceByID(h.groupDialog.GetSessionID()); inst != nil {
+				inst.SetStatusText(h.groupDialog.GetValue())
+				h.saveInstances()
+			}
+		case GroupDialogSnapshot:
+			if inst := h.getInstanceByID(h.groupDialog.GetSessionID()); inst != nil {
+				h.groupDialog.Hide()
+				return h, h.takeSnapshot(inst, h.groupDialog.GetValue())
+			}
 		}
 		h.groupDialog.Hide()
 		return h, nil
@@ -4384,8 +5263,12 @@ func (h *Home) saveUIState() {
 	}
 
 	state := uiState{
-		PreviewMode:  int(h.previewMode),
-		StatusFilter: string(h.statusFilter),
+		PreviewMode:     int(h.previewMode),
+		StatusFilter:    string(h.statusFilter),
+		FocusGroupPath:  h.focusGroupPath,
+		PinnedSessions:  h.pinnedSessionIDs(),
+		PreviewNoFollow: !h.previewFollow,
+		ShowHidden:      h.showHidden,
 	}
 
 	// Capture cursor position
@@ -4437,6 +5320,12 @@ func (h *Home) loadUIState() {
 	// Apply preview mode and status filter immediately
 	h.previewMode = PreviewMode(state.PreviewMode)
 	h.statusFilter = session.Status(state.StatusFilter)
+	h.focusGroupPath = state.FocusGroupPath
+	for _, id := range state.PinnedSessions {
+		h.pinnedSessions[id] = true
+	}
+	h.previewFollow = !state.PreviewNoFollow
+	h.showHidden = state.ShowHidden
 
 	// Defer cursor restoration until flatItems are populated
 	h.pendingCursorRestore = &state
@@ -4457,67 +5346,113 @@ func (h *Home) getUsedClaudeSessionIDs() map[string]bool {
 	return usedIDs
 }
 
-// createSessionInGroupWithWorktreeAndOptions creates a new session with full options including YOLO mode and tool options
-func (h *Home) createSessionInGroupWithWorktreeAndOptions(name, path, command, groupPath, worktreePath, worktreeRepoRoot, worktreeBranch string, geminiYoloMode bool, toolOptionsJSON json.RawMessage) tea.Cmd {
-	return func() tea.Msg {
-		// Check tmux availability before creating session
-		if err := tmux.IsTmuxAvailable(); err != nil {
-			return sessionCreatedMsg{err: fmt.Errorf("cannot create session: %w", err)}
+// createSessionInGroupWithWorktreeAndOptions creates a new session with full options including YOLO mode and tool options.
+// yoloMode is the launch mode for Gemini and custom tools (nil = the tool's default); Claude and Codex keep theirs in toolOptionsJSON.
+// templateName is the [templates.*] entry picked in the dialog ("" = none).
+func (h *Home) createSessionInGroupWithWorktreeAndOptions(name, path, command, groupPath, worktreePath, worktreeRepoRoot, worktreeBranch string, yoloMode *bool, toolOptionsJSON json.RawMessage, templateName string) tea.Cmd {
+	inst := newSessionInstance(name, path, command, groupPath, worktreePath, worktreeRepoRoot, worktreeBranch, yoloMode, toolOptionsJSON, templateName)
+	// [yolo_sandbox] mode = "always" sandboxes YOLO sessions without asking
+	if settings := session.GetYoloSandboxSettings(); settings.GetMode() == "always" {
+		if spec := settings.SandboxFor(inst); spec != nil {
+			inst.Container = spec
 		}
+	}
+	return startNewSession(inst)
+}
 
-		tool := "shell"
-		switch command {
-		case "claude":
-			tool = "claude"
-		case "gemini":
-			tool = "gemini"
-		case "aider":
-			tool = "aider"
-		case "codex":
-			tool = "codex"
-		case "opencode":
-			tool = "opencode"
-		default:
-			// Check custom tools: tool identity stays as the custom name (e.g. "glm")
-			// so config lookup works, but command resolves to the actual binary (e.g. "claude")
-			if toolDef := session.GetToolDef(command); toolDef != nil {
-				tool = command
-				command = toolDef.Command
-			}
-		}
+// newSessionInstance builds a new session from the create options, without
+// starting it
+func newSessionInstance(name, path, command, groupPath, worktreePath, worktreeRepoRoot, worktreeBranch string, yoloMode *bool, toolOptionsJSON json.RawMessage, templateName string) *session.Instance {
+	tpl := session.GetSessionTemplate(templateName)
+	// The template's tool applies while its command is kept
+	templateTool := ""
+	if tpl != nil && tpl.Tool != "" && command == tpl.CommandLine() {
+		templateTool = tpl.Tool
+	}
 
-		var inst *session.Instance
-		if groupPath != "" {
-			inst = session.NewInstanceWithGroupAndTool(name, path, groupPath, tool)
-		} else {
-			inst = session.NewInstanceWithTool(name, path, tool)
+	tool := "shell"
+	switch command {
+	case "claude":
+		tool = "claude"
+	case "gemini":
+		tool = "gemini"
+	case "aider":
+		tool = "aider"
+	case "codex":
+		tool = "codex"
+	case "opencode":
+		tool = "opencode"
+	default:
+		// Check custom tools: tool identity stays as the custom name (e.g. "glm")
+		// so config lookup works, but command resolves to the actual binary (e.g. "claude")
+		if toolDef := session.GetToolDef(command); toolDef != nil {
+			tool = command
+			command = toolDef.Command
 		}
-		inst.Command = command
+	}
 
-		// Set worktree fields if provided
-		if worktreePath != "" {
-			inst.WorktreePath = worktreePath
-			inst.WorktreeRepoRoot = worktreeRepoRoot
-			inst.WorktreeBranch = worktreeBranch
-		}
+	var inst *session.Instance
+	if groupPath != "" {
+		inst = session.NewInstanceWithGroupAndTool(name, path, groupPath, tool)
+	} else {
+		inst = session.NewInstanceWithTool(name, path, tool)
+	}
+	inst.Command = command
 
-		// Set Gemini YOLO mode if enabled (per-session override)
-		if geminiYoloMode && tool == "gemini" {
-			inst.GeminiYoloMode = &geminiYoloMode
+	// Set worktree fields if provided
+	if worktreePath != "" {
+		inst.WorktreePath = worktreePath
+		inst.WorktreeRepoRoot = worktreeRepoRoot
+		inst.WorktreeBranch = worktreeBranch
+	}
+
+	// Set the YOLO mode chosen for Gemini or a custom tool (per-session override)
+	if yoloMode != nil {
+		if tool == "gemini" {
+			if *yoloMode {
+				inst.GeminiYoloMode = yoloMode
+			}
+		} else if session.GetToolDef(tool) != nil {
+			inst.YoloMode = yoloMode
 		}
+	}
 
-		// Apply generic tool options (claude, codex, etc.)
-		if len(toolOptionsJSON) > 0 {
-			inst.ToolOptionsJSON = toolOptionsJSON
+	// Apply generic tool options (claude, codex, etc.)
+	if len(toolOptionsJSON) > 0 {
+		inst.ToolOptionsJSON = toolOptionsJSON
+	}
+
+	if tpl != nil {
+		inst.Template = templateName
+		if templateTool != "" {
+			inst.Tool = templateTool
 		}
+	}
+	return inst
+}
 
+// startNewSession starts a session built by newSessionInstance
+func startNewSession(inst *session.Instance) tea.Cmd {
+	return func() tea.Msg {
+		// Check tmux availability before creating session
+		if err := tmux.IsTmuxAvailable(); err != nil {
+			return sessionCreatedMsg{err: fmt.Errorf("cannot create session: %w", err)}
+		}
 		if err := inst.Start(); err != nil {
 			return sessionCreatedMsg{err: err}
 		}
+		_ = session.RecordRecentDirectory(inst.ProjectPath)
 		return sessionCreatedMsg{instance: inst}
 	}
 }
 
+// offerYoloSandbox asks whether to run a new YOLO session in the
+// [yolo_sandbox] container; apply gets the answer
+func (h *Home) offerYoloSandbox(title string, spec *session.ContainerSpec, apply func(sandbox bool) tea.Cmd) {
+	h.pendingSandbox = apply
+	h.confirmDialog.ShowYoloSandbox(title, spec.String())
+}
+
 // quickForkSession performs a quick fork with default title suffix " (fork)"
 func (h *Home) quickForkSession(source *session.Instance) tea.Cmd {
 	if source == nil {
@@ -4557,7 +5492,7 @@ func (h *Home) quickCreateSession() tea.Cmd {
 	tool := ""
 	command := ""
 	var toolOptionsJSON json.RawMessage
-	geminiYoloMode := false
+	var yoloMode *bool
 
 	h.instancesMu.RLock()
 	var mostRecent *session.Instance
@@ -4574,8 +5509,9 @@ func (h *Home) quickCreateSession() tea.Cmd {
 		if len(mostRecent.ToolOptionsJSON) > 0 {
 			toolOptionsJSON = mostRecent.ToolOptionsJSON
 		}
+		yoloMode = mostRecent.YoloMode
 		if mostRecent.GeminiYoloMode != nil && *mostRecent.GeminiYoloMode {
-			geminiYoloMode = true
+			yoloMode = mostRecent.GeminiYoloMode
 		}
 	}
 	h.instancesMu.RUnlock()
@@ -4597,11 +5533,19 @@ func (h *Home) quickCreateSession() tea.Cmd {
 	name := session.GenerateUniqueSessionName(h.instances, groupPath)
 	h.instancesMu.RUnlock()
 
-	return h.createSessionInGroupWithWorktreeAndOptions(
+	create := h.createSessionInGroupWithWorktreeAndOptions(
 		name, projectPath, command, groupPath,
 		"", "", "", // no worktree
-		geminiYoloMode, toolOptionsJSON,
+		yoloMode, toolOptionsJSON, "",
 	)
+	return func() tea.Msg {
+		msg := create()
+		if created, ok := msg.(sessionCreatedMsg); ok && created.instance != nil {
+			// [auto_name] may title it after its task
+			created.instance.AutoTitle = name
+		}
+		return msg
+	}
 }
 
 // mostRecentPathInGroup returns the project path of the most recently created
@@ -4707,7 +5651,16 @@ func (h *Home) deleteSession(inst *session.Instance) tea.Cmd {
 	isWorktree := inst.IsWorktree()
 	worktreePath := inst.WorktreePath
 	worktreeRepoRoot := inst.WorktreeRepoRoot
+	// With [confirm] delete_kills_tmux = false only the record goes; the
+	// tmux session (and the worktree it runs in) are left alone
+	killTmux := session.GetConfirmSettings().GetDeleteKillsTmux()
+	if killTmux && !h.claimOperation(inst, session.OpKilling) {
+		return nil
+	}
 	return func() tea.Msg {
+		if !killTmux {
+			return sessionDeletedMsg{deletedID: id}
+		}
 		killErr := inst.Kill()
 		if isWorktree {
 			_ = git.RemoveWorktree(worktreeRepoRoot, worktreePath, false)
@@ -4717,6 +5670,48 @@ func (h *Home) deleteSession(inst *session.Instance) tea.Cmd {
 	}
 }
 
+// sessionStoppedMsg signals that a session's tmux session was killed
+type sessionStoppedMsg struct {
+	sessionID string
+	err       error
+}
+
+// stopSession kills the session's tmux session, keeping it in the deck
+func (h *Home) stopSession(inst *session.Instance) tea.Cmd {
+	id := inst.ID
+	if !h.claimOperation(inst, session.OpKilling) {
+		return nil
+	}
+	return func() tea.Msg {
+		return sessionStoppedMsg{sessionID: id, err: inst.Kill()}
+	}
+}
+
+// deleteGroup deletes a group, moving its sessions to the default group
+func (h *Home) deleteGroup(groupPath string) {
+	h.groupTree.DeleteGroup(groupPath)
+	h.instancesMu.Lock()
+	h.instances = h.groupTree.GetAllInstances()
+	h.instancesMu.Unlock()
+	h.rebuildFlatItems()
+	h.saveInstances()
+}
+
+// mergeGroups moves every session and subgroup of src into dst
+func (h *Home) mergeGroups(src, dst string) {
+	moved, err := h.groupTree.MergeGroup(src, dst)
+	if err != nil {
+		h.setError(err)
+		return
+	}
+	h.instancesMu.Lock()
+	h.instances = h.groupTree.GetAllInstances()
+	h.instancesMu.Unlock()
+	h.rebuildFlatItems()
+	h.saveInstances()
+	h.setError(fmt.Errorf("merged %s into %s (%d sessions)", src, dst, moved))
+}
+
 // sessionRestartedMsg signals that a session was restarted
 type sessionRestartedMsg struct {
 	sessionID string
@@ -4733,6 +5728,9 @@ type mcpRestartedMsg struct {
 func (h *Home) restartSession(inst *session.Instance) tea.Cmd {
 	id := inst.ID
 	mcpUILog.Debug("restart_session_called", slog.String("id", inst.ID), slog.String("title", inst.Title), slog.String("tool", inst.Tool))
+	if !h.claimOperation(inst, session.OpRestarting) {
+		return nil
+	}
 	return func() tea.Msg {
 		mcpUILog.Debug("restart_session_executing", slog.String("id", id))
 		err := inst.Restart()
@@ -4741,8 +5739,23 @@ func (h *Home) restartSession(inst *session.Instance) tea.Cmd {
 	}
 }
 
+// claimOperation marks op as in progress on inst before its command is
+// dispatched, refusing it while another start/kill/restart is in flight
+func (h *Home) claimOperation(inst *session.Instance, op string) bool {
+	if inst.ClaimOperation(op) {
+		return true
+	}
+	h.setError(fmt.Errorf("'%s' is already %s, please wait...", inst.Title, inst.Operation()))
+	return false
+}
+
 // attachSession attaches to a session using custom PTY with Ctrl+Q detection
 func (h *Home) attachSession(inst *session.Instance) tea.Cmd {
+	if session.IsDemoMode() {
+		h.isAttaching.Store(false)
+		h.setError(errDemoAttach)
+		return nil
+	}
 	tmuxSess := inst.GetTmuxSession()
 	if tmuxSess == nil {
 		return nil
@@ -4809,7 +5822,8 @@ skipSave:
 	// Use tea.Exec with a custom command that runs our Attach method
 	// On return, immediately update all session statuses (don't reload from storage
 	// which would lose the tmux session state)
-	return tea.Exec(attachCmd{session: tmuxSess}, func(err error) tea.Msg {
+	attachedAt := time.Now()
+	return tea.Exec(attachCmd{session: tmuxSess, inst: inst}, func(err error) tea.Msg {
 		// CRITICAL: Set isAttaching to false BEFORE returning the message
 		// This prevents a race condition where View() could be called with
 		// isAttaching=true before Update() processes statusUpdateMsg,
@@ -4822,6 +5836,16 @@ skipSave:
 
 		// Update last accessed time to detach time (more accurate than attach time)
 		inst.MarkAccessed()
+		h.pollBackoff.reset(inst.ID)
+		session.RecordActivity(inst.ID, session.ActivityAttached, attachedAt, time.Now())
+
+		// Post-detach hook runs in the background: its output would draw over
+		// the TUI, and a slow sync shouldn't hold up the return to the list
+		go func() { _ = inst.RunPostDetachHook(io.Discard) }()
+
+		if session.GetHandoffSettings().ShouldPrompt(time.Since(attachedAt)) {
+			return statusUpdateMsg{handoffID: inst.ID}
+		}
 
 		// NOTE: We don't acknowledge on detach anymore.
 		// Acknowledgment happens on ATTACH (only if session was waiting/yellow).
@@ -4831,15 +5855,27 @@ skipSave:
 	})
 }
 
+// errDemoAttach is shown when attaching in --demo mode, where there is no tmux
+var errDemoAttach = fmt.Errorf("demo mode: sessions are simulated and can't be attached")
+
 // attachCmd implements tea.ExecCommand for custom PTY attach
 type attachCmd struct {
 	session *tmux.Session
+	inst    *session.Instance // for the pre-attach hook
 }
 
 func (a attachCmd) Run() error {
 	// NOTE: Screen clearing is ONLY done in the tea.Exec callback (after Attach returns)
 	// Removing clear screen here prevents double-clearing which corrupts terminal state
 
+	// The terminal is ours here, so the pre-attach hook's output is visible.
+	// A failing hook is reported but doesn't block the attach.
+	if a.inst != nil {
+		if err := a.inst.RunPreAttachHook(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+
 	ctx := context.Background()
 	return a.session.Attach(ctx)
 }
@@ -5012,9 +6048,54 @@ func (h *Home) renderFilterBar() string {
 		}
 	}
 
+	// Focus pill (shown while focus mode is active)
+	if h.focusGroupPath != "" {
+		focusLabel := "◎ " + h.focusGroupPath
+		if group, ok := h.groupTree.Groups[h.focusGroupPath]; ok {
+			focusLabel = "◎ " + group.Name
+		}
+		pills = append(pills, lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorCyan).
+			Bold(true).
+			Padding(0, 1).Render(focusLabel))
+	}
+
+	// Hidden groups pill (shown while hidden groups are revealed)
+	if h.showHidden {
+		pills = append(pills, lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorComment).
+			Bold(true).
+			Padding(0, 1).Render(fmt.Sprintf("◌ hidden %d", h.groupTree.HiddenGroupCount())))
+	}
+
+	// Quiet hours pill (shown while alerts are being held for the digest)
+	if h.alerter != nil {
+		if held := h.alerter.Held(); held > 0 {
+			pills = append(pills, lipgloss.NewStyle().
+				Foreground(ColorBg).
+				Background(ColorComment).
+				Bold(true).
+				Padding(0, 1).Render(fmt.Sprintf("☾ quiet %d", held)))
+		}
+	}
+
+	// tmux pill (shown while tmux commands time out; statuses are the last known ones)
+	if tmux.Unresponsive() {
+		pills = append(pills, lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorRed).
+			Bold(true).
+			Padding(0, 1).Render("⚠ tmux unresponsive"))
+	}
+
 	// Hint for keyboard shortcuts (shift+number to filter, 0 to clear)
 	hintStyle := lipgloss.NewStyle().Foreground(ColorComment).Faint(true)
 	hint := hintStyle.Render("  !@#$ filter • 0 all")
+	if h.focusGroupPath != "" {
+		hint = hintStyle.Render("  z exit focus")
+	}
 
 	// Join pills with spaces (leading space replaces Padding)
 	filterRow := " " + strings.Join(pills, " ") + hint
@@ -5030,11 +6111,26 @@ func (h *Home) updateSizes() {
 	h.newDialog.SetSize(h.width, h.height)
 	h.groupDialog.SetSize(h.width, h.height)
 	h.confirmDialog.SetSize(h.width, h.height)
-	h.geminiModelDialog.SetSize(h.width, h.height)
+	h.modelDialog.SetSize(h.width, h.height)
+	h.pagerOverlay.SetSize(h.width, h.height)
+	h.approvalsInbox.SetSize(h.width, h.height)
+	h.statsView.SetSize(h.width, h.height)
+	h.timelineView.SetSize(h.width, h.height)
 }
 
 // View renders the UI
 func (h *Home) View() string {
+	switch {
+	case plainMode:
+		return PlainText(h.renderView())
+	case screenReaderMode:
+		return stripBoxDrawing(h.renderView())
+	}
+	return h.renderView()
+}
+
+// renderView renders the current screen (View post-processes it)
+func (h *Home) renderView() string {
 	// CRITICAL: Return empty during attach to prevent View() output leakage
 	// (Bubble Tea Issue #431 - View gets printed to stdout during tea.Exec)
 	if h.isAttaching.Load() { // Atomic read for thread safety
@@ -5084,6 +6180,18 @@ func (h *Home) View() string {
 	if h.helpOverlay.IsVisible() {
 		return h.helpOverlay.View()
 	}
+	if h.pagerOverlay.IsVisible() {
+		return h.pagerOverlay.View()
+	}
+	if h.approvalsInbox.IsVisible() {
+		return h.approvalsInbox.View()
+	}
+	if h.statsView.IsVisible() {
+		return h.statsView.View()
+	}
+	if h.timelineView.IsVisible() {
+		return h.timelineView.View()
+	}
 	if h.search.IsVisible() {
 		return h.search.View()
 	}
@@ -5105,12 +6213,30 @@ func (h *Home) View() string {
 	if h.mcpDialog.IsVisible() {
 		return h.mcpDialog.View()
 	}
-	if h.geminiModelDialog.IsVisible() {
-		return h.geminiModelDialog.View()
+	if h.modelDialog.IsVisible() {
+		return h.modelDialog.View()
 	}
 	if h.sessionPickerDialog.IsVisible() {
 		return h.sessionPickerDialog.View()
 	}
+	if h.relocateDialog.IsVisible() {
+		return h.relocateDialog.View()
+	}
+	if h.handoffDialog.IsVisible() {
+		return h.handoffDialog.View()
+	}
+	if h.contextFilesDialog.IsVisible() {
+		return h.contextFilesDialog.View()
+	}
+	if h.snapshotBrowser.IsVisible() {
+		return h.snapshotBrowser.View()
+	}
+	if h.bulkMoveDialog.IsVisible() {
+		return h.bulkMoveDialog.View()
+	}
+	if screenReaderMode {
+		return h.renderScreenReaderView()
+	}
 
 	// Reuse viewBuilder to reduce allocations (reset and pre-allocate)
 	h.viewBuilder.Reset()
@@ -5136,6 +6262,9 @@ func (h *Home) View() string {
 			Bold(true)
 		titleText = "Agent Deck " + profileStyle.Render("["+h.profile+"]")
 	}
+	if session.IsDemoMode() {
+		titleText += " " + lipgloss.NewStyle().Foreground(ColorYellow).Bold(true).Render("[demo]")
+	}
 	title := titleStyle.Render(titleText)
 
 	// Status-based stats (more useful than group/session counts)
@@ -5306,13 +6435,7 @@ func (h *Home) View() string {
 // renderPanelTitle creates a styled section title with underline
 func (h *Home) renderPanelTitle(title string, width int) string {
 	// Truncate title if it exceeds width
-	if len(title) > width {
-		if width > 3 {
-			title = title[:width-3] + "..."
-		} else {
-			title = title[:width]
-		}
-	}
+	title = TruncateWidth(title, width)
 
 	titleStyle := lipgloss.NewStyle().
 		Foreground(ColorCyan).
@@ -5545,8 +6668,8 @@ func renderEmptyStateResponsive(config EmptyStateConfig, width, height int) stri
 		// Truncate subtitle if width is tight
 		subtitle := config.Subtitle
 		maxSubtitleWidth := width - hPad*2 - 4 // Account for padding and margins
-		if maxSubtitleWidth > 0 && len(subtitle) > maxSubtitleWidth {
-			subtitle = subtitle[:maxSubtitleWidth-3] + "..."
+		if maxSubtitleWidth > 0 {
+			subtitle = TruncateWidth(subtitle, maxSubtitleWidth)
 		}
 		content.WriteString(subtitleStyle.Render(subtitle))
 	}
@@ -5579,8 +6702,8 @@ func renderEmptyStateResponsive(config EmptyStateConfig, width, height int) stri
 			// Truncate hint if width is tight
 			displayHint := hint
 			maxHintWidth := width - hPad*2 - 6 // Account for "• " prefix and margins
-			if maxHintWidth > 0 && len(displayHint) > maxHintWidth {
-				displayHint = displayHint[:maxHintWidth-3] + "..."
+			if maxHintWidth > 0 {
+				displayHint = TruncateWidth(displayHint, maxHintWidth)
 			}
 			content.WriteString(hintStyle.Render("• " + displayHint))
 			if i < len(hintsToShow)-1 {
@@ -6123,6 +7246,8 @@ func (h *Home) previewModeShort() string {
 		return "Out"
 	case PreviewModeAnalytics:
 		return "Stats"
+	case PreviewModeGitHub:
+		return "GitHub"
 	default:
 		return "Both"
 	}
@@ -6180,6 +7305,7 @@ func (h *Home) renderHelpBarFull() string {
 			primaryHints = append(primaryHints, h.helpKey("x", "Send"))
 			secondaryHints = []string{
 				h.helpKey("r", "Rename"),
+				h.helpKey("e", "Edit"),
 				h.helpKey("m", "Move"),
 				h.helpKey("d", "Delete"),
 			}
@@ -6190,6 +7316,16 @@ func (h *Home) renderHelpBarFull() string {
 	if len(h.undoStack) > 0 {
 		secondaryHints = append(secondaryHints, h.helpKey("^Z", "Undo"))
 	}
+	if h.inlineEdit.active() {
+		contextTitle = "Edit"
+		primaryHints = []string{
+			h.helpKey("Enter", "Save"),
+			h.helpKey("Tab", "Next field"),
+			h.helpKey("↑↓", "Next session"),
+			h.helpKey("Esc", "Cancel"),
+		}
+		secondaryHints = nil
+	}
 
 	// Top border
 	borderStyle := lipgloss.NewStyle().Foreground(ColorBorder)
@@ -6303,10 +7439,17 @@ func (h *Home) renderSessionList(width, height int) string {
 		maxVisible-- // Account for the indicator line
 	}
 
-	for i := h.viewOffset; i < len(h.flatItems) && visibleCount < maxVisible; i++ {
+	// Sessions with a summary take two lines
+	visibleLines := 0
+	for i := h.viewOffset; i < len(h.flatItems); i++ {
 		item := h.flatItems[i]
-		h.renderItem(&b, item, i == h.cursor, i)
+		lines := itemLines(item)
+		if visibleCount > 0 && visibleLines+lines > maxVisible {
+			break
+		}
+		h.renderItem(&b, item, i == h.cursor, i, width)
 		visibleCount++
+		visibleLines += lines
 	}
 
 	// Show "more below" indicator if there are more items
@@ -6320,11 +7463,11 @@ func (h *Home) renderSessionList(width, height int) string {
 }
 
 // renderItem renders a single item (group or session) for the left panel
-func (h *Home) renderItem(b *strings.Builder, item session.Item, selected bool, itemIndex int) {
+func (h *Home) renderItem(b *strings.Builder, item session.Item, selected bool, itemIndex int, width int) {
 	if item.Type == session.ItemTypeGroup {
 		h.renderGroupItem(b, item, selected, itemIndex)
 	} else {
-		h.renderSessionItem(b, item, selected)
+		h.renderSessionItem(b, item, selected, width)
 	}
 }
 
@@ -6373,6 +7516,9 @@ func (h *Home) renderGroupItem(b *strings.Builder, item session.Item, selected b
 	// Use recursive count to include sessions in subgroups (Issue #48)
 	sessionCount := h.groupTree.SessionCountForGroup(group.Path)
 	countStr := countStyle.Render(fmt.Sprintf(" (%d)", sessionCount))
+	if group.Hidden {
+		countStr += DimStyle.Render(" ◌ hidden")
+	}
 
 	// Status indicators (compact, on same line) using cached styles
 	// Also count recursively for subgroups
@@ -6416,9 +7562,12 @@ const (
 	subLast   = "└─" // Last sub-session
 )
 
+// statusTextMaxWidth caps the custom status text shown next to the status icon
+const statusTextMaxWidth = 24
+
 // renderSessionItem renders a single session item for the left panel
 // PERFORMANCE: Uses cached styles from styles.go to avoid allocations
-func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected bool) {
+func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected bool, width int) {
 	inst := item.Session
 
 	// Snapshot status and tool under read lock to avoid races with background worker
@@ -6485,6 +7634,15 @@ func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected
 
 	status := statusStyle.Render(statusIcon)
 
+	// Custom status text sits right after the icon, e.g. "◐ [blocked on API key]"
+	statusText := ""
+	if op := inst.Operation(); op != "" {
+		// A start/kill/restart in flight replaces it, e.g. "● restarting…"
+		statusText = " " + op + "…"
+	} else if text := inst.GetStatusText(); text != "" {
+		statusText = " [" + runewidth.Truncate(text, statusTextMaxWidth, "…") + "]"
+	}
+
 	// Title styling - add bold/underline for accessibility (colorblind users)
 	var titleStyle lipgloss.Style
 	switch instStatus {
@@ -6520,11 +7678,37 @@ func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected
 	}
 
 	title := titleStyle.Render(inst.Title)
+	if h.pinnedSessions[inst.ID] {
+		title = titleStyle.Render("📌 " + inst.Title)
+	}
+	if h.markedSessions[inst.ID] {
+		markStyle := lipgloss.NewStyle().Foreground(ColorCyan).Bold(true)
+		if selected {
+			markStyle = SessionStatusSelStyle
+		}
+		title = markStyle.Render("✓ ") + title
+	}
 	tool := toolStyle.Render(" " + instTool)
+	if model := inst.Model(); model != "" {
+		modelStyle := lipgloss.NewStyle().Foreground(ColorComment)
+		if selected {
+			modelStyle = SessionStatusSelStyle
+		}
+		tool += modelStyle.Render(" " + session.ShortModelName(model))
+	}
+	if h.gitBranches != nil {
+		if branch := h.gitBranches.Branch(inst); branch != "" {
+			branchStyle := lipgloss.NewStyle().Foreground(ColorComment)
+			if selected {
+				branchStyle = SessionStatusSelStyle
+			}
+			tool += branchStyle.Render(" ⎇ " + runewidth.Truncate(branch, 24, "…"))
+		}
+	}
 
-	// YOLO badge for Gemini sessions with YOLO mode enabled
+	// YOLO badge for sessions launched with approvals skipped
 	yoloBadge := ""
-	if instTool == "gemini" && inst.GeminiYoloMode != nil && *inst.GeminiYoloMode {
+	if inst.LaunchMode() == session.LaunchModeYolo {
 		yoloStyle := lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
 		if selected {
 			yoloStyle = SessionStatusSelStyle
@@ -6532,12 +7716,92 @@ func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected
 		yoloBadge = yoloStyle.Render(" [YOLO]")
 	}
 
+	// Rate limit badge while the provider has the session waiting
+	if until, limited := inst.RateLimitedUntil(); limited {
+		limitStyle := lipgloss.NewStyle().Foreground(ColorOrange)
+		if selected {
+			limitStyle = SessionStatusSelStyle
+		}
+		yoloBadge += limitStyle.Render(" [rate limited until ~" + session.RateLimitResetLabel(until, time.Now()) + "]")
+	}
+
+	// Unpushed badge for agent work not yet pushed or committed
+	if h.unpushedWork != nil {
+		if label := h.unpushedWork.Badge(inst, time.Now()); label != "" {
+			unpushedStyle := lipgloss.NewStyle().Foreground(ColorPurple)
+			if selected {
+				unpushedStyle = SessionStatusSelStyle
+			}
+			yoloBadge += unpushedStyle.Render(" [" + label + "]")
+		}
+	}
+
+	// Split badge for the session marked as secondary preview
+	if h.splitSessionID == inst.ID {
+		splitStyle := lipgloss.NewStyle().Foreground(ColorCyan)
+		if selected {
+			splitStyle = SessionStatusSelStyle
+		}
+		yoloBadge += splitStyle.Render(" [split]")
+	}
+
 	// Build row: [baseIndent][selection][tree][status] [title] [tool] [yolo]
 	// Format: " ├─ ● session-name tool" or "▶└─ ● session-name tool"
 	// Sub-sessions get extra indent: "   ├─◐ sub-session tool"
+	if statusText != "" {
+		status += statusStyle.Italic(true).Render(statusText)
+	}
+	if h.inlineEdit.sessionID == inst.ID {
+		prefix := fmt.Sprintf("%s%s%s %s ", baseIndent, selectionPrefix, treeStyle.Render(treeConnector), status)
+		h.inlineEdit.input.Width = max(10, width-lipgloss.Width(prefix)-len(h.inlineEdit.input.Prompt)-2)
+		b.WriteString(prefix + h.inlineEdit.input.View())
+		b.WriteString("\n")
+		return
+	}
 	row := fmt.Sprintf("%s%s%s %s %s%s%s", baseIndent, selectionPrefix, treeStyle.Render(treeConnector), status, title, tool, yoloBadge)
+
+	// The rest of the row: the title the tool set on its pane ([tmux] sync_titles)
+	subtitleStyle := DimStyle
+	if selected {
+		subtitleStyle = SessionStatusSelStyle
+	}
+	if subtitle := inst.GetPaneTitle(); subtitle != "" && subtitle != inst.Title {
+		room := width - runewidth.StringWidth(tmux.StripANSI(row)) - 4
+		if room >= 12 {
+			row += subtitleStyle.Render(" · " + runewidth.Truncate(subtitle, room, "…"))
+		}
+	}
 	b.WriteString(row)
 	b.WriteString("\n")
+
+	// Last response summary on its own line under the title, continuing the tree
+	if summary := sessionListSummary(inst); summary != "" {
+		treeCont := treeLine
+		if (item.IsSubSession && item.IsLastSubSession) || (!item.IsSubSession && item.IsLastInGroup) {
+			treeCont = treeEmpty
+		}
+		prefix := baseIndent + " " + treeStyle.Render(treeCont) + "   "
+		room := max(1, width-lipgloss.Width(prefix)-2)
+		b.WriteString(prefix + subtitleStyle.Render(runewidth.Truncate(summary, room, "…")))
+		b.WriteString("\n")
+	}
+}
+
+// sessionListSummary returns the last response summary shown under a session
+// in the list, or "" while it is running or has none
+func sessionListSummary(inst *session.Instance) string {
+	if inst.GetStatusThreadSafe() == session.StatusRunning {
+		return ""
+	}
+	return inst.GetLastSummary()
+}
+
+// itemLines returns how many list lines an item renders as
+func itemLines(item session.Item) int {
+	if item.Type == session.ItemTypeSession && item.Session != nil && sessionListSummary(item.Session) != "" {
+		return 2
+	}
+	return 1
 }
 
 // renderLaunchingState renders the animated launching/resuming indicator for sessions
@@ -6809,6 +8073,9 @@ func (h *Home) renderSessionInfoCard(inst *session.Instance, width, height int)
 
 	// Tool
 	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Tool:"), valueStyle.Render(cardTool)))
+	if model := inst.Model(); model != "" {
+		b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Model:"), valueStyle.Render(model)))
+	}
 
 	// Session ID (if available) - Claude, Gemini, or OpenCode
 	sessionID := inst.ClaudeSessionID
@@ -6832,8 +8099,8 @@ func (h *Home) renderSessionInfoCard(inst *session.Instance, width, height int)
 	return b.String()
 }
 
-// renderPreviewPane renders the right panel with live preview
-func (h *Home) renderPreviewPane(width, height int) string {
+// renderSelectedPreview renders the preview for the item under the cursor
+func (h *Home) renderSelectedPreview(width, height int) string {
 	var b strings.Builder
 
 	if len(h.flatItems) == 0 || h.cursor >= len(h.flatItems) {
@@ -6890,11 +8157,29 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	b.WriteString(statusBadge)
 	b.WriteString("\n")
 
+	// Handoff note right under the title, so it's the first thing seen
+	if selected.HandoffNote != "" {
+		prefix := "↪ Left off " + formatRelativeTime(selected.HandoffAt) + ": "
+		handoffStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorCyan)
+		b.WriteString(handoffStyle.Render(prefix + runewidth.Truncate(selected.HandoffNote, width-runewidth.StringWidth(prefix)-2, "…")))
+		b.WriteString("\n")
+	}
+
 	// Info lines: path and activity time
 	infoStyle := lipgloss.NewStyle().Foreground(ColorText)
-	pathStr := truncatePath(selected.ProjectPath, width-4)
+	pathStr := ShortenPath(selected.ProjectPath, width-4)
+	if session.GetPreviewSettings().FullPaths {
+		pathStr = selected.ProjectPath
+	}
+	if repo := selected.RepoLabel(); repo != "" && runewidth.StringWidth(pathStr)+runewidth.StringWidth(repo)+6 <= width {
+		pathStr += "  " + lipgloss.NewStyle().Foreground(ColorCyan).Render("⎇ "+repo)
+	}
 	b.WriteString(infoStyle.Render("📁 " + pathStr))
 	b.WriteString("\n")
+	if selected.Status == session.StatusError && selected.PathMissing() {
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorYellow).Render("⚠ path not found · Enter to relocate"))
+		b.WriteString("\n")
+	}
 
 	// Activity time - shows when session was last active
 	activityTime := selected.GetLastActivityTime()
@@ -6904,20 +8189,91 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	}
 	b.WriteString(infoStyle.Render("⏱ " + activityStr))
 	b.WriteString("\n")
+	if paneTitle := selected.GetPaneTitle(); paneTitle != "" && paneTitle != selected.Title {
+		b.WriteString(infoStyle.Render("🏷 " + runewidth.Truncate(paneTitle, width-7, "…")))
+		b.WriteString("\n")
+	}
+	if summary := selected.GetLastSummary(); summary != "" {
+		b.WriteString(infoStyle.Render("💬 " + runewidth.Truncate(summary, width-7, "…")))
+		b.WriteString("\n")
+	}
+	if text := selected.GetStatusText(); text != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorYellow).Render("🔔 " + runewidth.Truncate(text, width-7, "…")))
+		b.WriteString("\n")
+	}
+	if ticket := selected.Ticket; ticket != nil {
+		if h.ticketStatuses != nil {
+			ticket = h.ticketStatuses.Current(ticket)
+		}
+		ticketLine := ticket.String()
+		if ticket.Title != "" {
+			ticketLine += " · " + ticket.Title
+		}
+		b.WriteString(infoStyle.Render("🎫 " + runewidth.Truncate(ticketLine, width-7, "…")))
+		b.WriteString("\n")
+	}
+	if selected.Notes != "" {
+		for _, line := range strings.Split(selected.Notes, "\n") {
+			b.WriteString(infoStyle.Render("📝 " + runewidth.Truncate(line, width-7, "…")))
+			b.WriteString("\n")
+		}
+	}
+	for _, f := range selected.ContextFiles {
+		b.WriteString(infoStyle.Render("📎 " + runewidth.Truncate(f, width-7, "…")))
+		b.WriteString("\n")
+	}
+
+	// The local CLI's version, once detected; containers run their own
+	toolLabel := selected.Tool
+	if v, ok := session.CachedToolVersion(selected.Tool); ok && selected.Container == nil && v.Installed() {
+		if v.Version != "" {
+			release, _, _ := strings.Cut(v.Version, "-")
+			toolLabel += " " + release
+		}
+		if v.Broken != "" {
+			b.WriteString(lipgloss.NewStyle().Foreground(ColorYellow).Render("⚠ " + runewidth.Truncate(v.String()+" is known broken: "+v.Broken, width-7, "…")))
+			b.WriteString("\n")
+		}
+	}
 
 	toolBadge := lipgloss.NewStyle().
 		Foreground(ColorBg).
 		Background(ColorPurple).
 		Padding(0, 1).
-		Render(selected.Tool)
+		Render(toolLabel)
 	groupBadge := lipgloss.NewStyle().
 		Foreground(ColorBg).
 		Background(ColorCyan).
 		Padding(0, 1).
 		Render(selected.GroupPath)
 	b.WriteString(toolBadge)
+	if model := selected.Model(); model != "" {
+		b.WriteString(" ")
+		b.WriteString(lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorAccent).
+			Padding(0, 1).
+			Render(model))
+	}
+	// Launch mode: approvals on (safe) or skipped (yolo)
+	switch selected.LaunchMode() {
+	case session.LaunchModeYolo:
+		b.WriteString(" ")
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorBg).Background(ColorYellow).Padding(0, 1).Render("YOLO"))
+	case session.LaunchModeSafe:
+		b.WriteString(" ")
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorBg).Background(ColorGreen).Padding(0, 1).Render("safe"))
+	}
 	b.WriteString(" ")
 	b.WriteString(groupBadge)
+	if selected.Container != nil {
+		b.WriteString(" ")
+		b.WriteString(lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorOrange).
+			Padding(0, 1).
+			Render("📦 " + selected.Container.String()))
+	}
 	b.WriteString("\n")
 
 	// Claude-specific info (session ID and MCPs)
@@ -7252,6 +8608,12 @@ func (h *Home) renderPreviewPane(width, height int) string {
 
 	b.WriteString("\n")
 
+	// GitHub tab replaces the output and analytics (v cycles to it)
+	if h.previewMode == PreviewModeGitHub {
+		b.WriteString(h.renderGitHubTab(selected, width))
+		return ensureExactHeight(b.String(), height)
+	}
+
 	// Special handling for error state - show guidance instead of output
 	if selected.Status == session.StatusError {
 		errorHeader := renderSectionDivider("Session Inactive", width-4)
@@ -7380,9 +8742,22 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	}
 
 	// Terminal output header
-	termHeader := renderSectionDivider("Output", width-4)
+	outputLabel := "Output"
+	if !h.previewFollow {
+		outputLabel = "Output ⏸ follow off"
+	}
+	termHeader := renderSectionDivider(outputLabel, width-4)
 	b.WriteString(termHeader)
 	b.WriteString("\n")
+	previewSearch := h.previewSearchFor(selected.ID)
+	if h.previewSearching && h.previewSearchSessionID == selected.ID {
+		b.WriteString(h.previewSearchInput.View())
+		b.WriteString("\n")
+	} else if previewSearch != nil {
+		searchStatus := previewSearch.status() + " • n/N next/prev • / edit • esc done"
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorComment).Italic(true).Render(searchStatus))
+		b.WriteString("\n")
+	}
 
 	// Check if this session is launching (newly created), resuming (restarted), or forking
 	launchTime, isLaunching := h.launchingSessions[selected.ID]
@@ -7526,18 +8901,15 @@ func (h *Home) renderPreviewPane(width, height int) string {
 			maxLines = 1
 		}
 
-		// Track if we're truncating from the top (for indicator)
-		truncatedFromTop := len(lines) > maxLines
-		truncatedCount := 0
-		if truncatedFromTop {
-			// Reserve one line for the truncation indicator
+		// Reserve one line each for the "more above" / "more below" indicators
+		offset := h.previewOffsetFor(selected.ID, len(lines))
+		if len(lines) > maxLines {
 			maxLines--
-			if maxLines < 1 {
-				maxLines = 1
+			if offset > 0 {
+				maxLines--
 			}
-			truncatedCount = len(lines) - maxLines
-			lines = lines[len(lines)-maxLines:]
 		}
+		lines, truncatedCount, hiddenBelow := previewWindow(lines, maxLines, offset)
 
 		previewStyle := lipgloss.NewStyle().Foreground(ColorText)
 		maxWidth := width - 4
@@ -7545,21 +8917,20 @@ func (h *Home) renderPreviewPane(width, height int) string {
 			maxWidth = 10
 		}
 
+		indicatorStyle := lipgloss.NewStyle().
+			Foreground(ColorText).
+			Italic(true)
 		// Show truncation indicator if content was cut from top
-		if truncatedFromTop {
-			truncIndicator := lipgloss.NewStyle().
-				Foreground(ColorText).
-				Italic(true).
-				Render(fmt.Sprintf("⋮ %d more lines above", truncatedCount))
-			b.WriteString(truncIndicator)
+		if truncatedCount > 0 {
+			b.WriteString(indicatorStyle.Render(fmt.Sprintf("⋮ %d more lines above", truncatedCount)))
 			b.WriteString("\n")
 		}
-
 		// Track consecutive empty lines to preserve some spacing
 		consecutiveEmpty := 0
 		const maxConsecutiveEmpty = 2 // Allow up to 2 consecutive empty lines
 
-		for _, line := range lines {
+		matchStyle, currentMatchStyle := searchMatchStyles()
+		for i, line := range lines {
 			// Strip ANSI codes for accurate width measurement
 			cleanLine := tmux.StripANSI(line)
 
@@ -7580,7 +8951,21 @@ func (h *Home) renderPreviewPane(width, height int) string {
 				cleanLine = runewidth.Truncate(cleanLine, maxWidth-3, "...")
 			}
 
-			b.WriteString(previewStyle.Render(cleanLine))
+			if previewSearch != nil && previewSearch.re.MatchString(cleanLine) {
+				style := matchStyle
+				if truncatedCount+i == previewSearch.currentLine() {
+					style = currentMatchStyle
+				}
+				b.WriteString(previewSearch.render(cleanLine, previewStyle, style))
+			} else {
+				b.WriteString(h.highlighter.render(cleanLine, previewStyle))
+			}
+			b.WriteString("\n")
+		}
+
+		// Follow paused and scrolled up: show how much newer output is hidden
+		if hiddenBelow > 0 {
+			b.WriteString(indicatorStyle.Render(fmt.Sprintf("⋮ %d newer lines below (p to follow)", hiddenBelow)))
 			b.WriteString("\n")
 		}
 	}
@@ -7613,27 +8998,6 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	return strings.Join(truncatedLines, "\n")
 }
 
-// truncatePath shortens a path to fit within maxLen display width
-func truncatePath(path string, maxLen int) string {
-	pathWidth := runewidth.StringWidth(path)
-	if pathWidth <= maxLen {
-		return path
-	}
-	if maxLen < 10 {
-		maxLen = 10
-	}
-	// Show beginning and end: /Users/.../project
-	// Use rune-based slicing for proper Unicode handling
-	runes := []rune(path)
-	startLen := maxLen / 3
-	endLen := maxLen*2/3 - 3
-	if startLen+endLen+3 > len(runes) {
-		// Path is short in runes but wide in display - use simple truncation
-		return runewidth.Truncate(path, maxLen-3, "...")
-	}
-	return string(runes[:startLen]) + "..." + string(runes[len(runes)-endLen:])
-}
-
 // formatRelativeTime formats a time as a human-readable relative string
 // Examples: "just now", "2m ago", "1h ago", "3h ago", "1d ago"
 func formatRelativeTime(t time.Time) string {
@@ -7812,6 +9176,29 @@ func (h *Home) copySessionOutput(inst *session.Instance) tea.Cmd {
 	}
 }
 
+// exportSessionScrollback returns a tea.Cmd that saves the session's full scrollback
+// to ~/.agent-deck/exports/.
+func (h *Home) exportSessionScrollback(inst *session.Instance) tea.Cmd {
+	return func() tea.Msg {
+		content, err := inst.CaptureScrollback(true)
+		if err != nil {
+			return exportResultMsg{err: err}
+		}
+		path, err := session.DefaultExportPath(inst, time.Now())
+		if err != nil {
+			return exportResultMsg{err: err}
+		}
+		if err := session.WriteExport(path, content); err != nil {
+			return exportResultMsg{err: err}
+		}
+		return exportResultMsg{
+			sessionTitle: inst.Title,
+			path:         path,
+			lineCount:    strings.Count(content, "\n"),
+		}
+	}
+}
+
 // sendOutputToSession returns a tea.Cmd that sends the source session's output to the target.
 func (h *Home) sendOutputToSession(source, target *session.Instance) tea.Cmd {
 	return func() tea.Msg {
@@ -787,6 +787,29 @@ func TestCtrlZEmptyStack(t *testing.T) {
 	}
 }
 
+func TestRestartRefusedWhileOperationInFlight(t *testing.T) {
+	home := NewHome()
+	inst := session.NewInstance("work-session", "/tmp/work")
+	home.pushUndoStack(inst)
+	if !inst.ClaimOperation(session.OpKilling) {
+		t.Fatal("claim refused")
+	}
+	if cmd := home.restartSession(inst); cmd != nil {
+		t.Error("restart dispatched while the session is being killed")
+	}
+	if home.err == nil || !strings.Contains(home.err.Error(), "already killing") {
+		t.Errorf("err = %v, want an already-killing notice", home.err)
+	}
+
+	// Undo restarts too, so it waits as well and keeps its entry
+	if _, cmd := home.Update(tea.KeyMsg{Type: tea.KeyCtrlZ}); cmd != nil {
+		t.Error("undo dispatched while the session is being killed")
+	}
+	if len(home.undoStack) != 1 {
+		t.Errorf("undoStack length = %d, want 1 (entry kept)", len(home.undoStack))
+	}
+}
+
 func TestUndoHintInHelpBar(t *testing.T) {
 	home := NewHome()
 	home.width = 200 // Wide terminal to fit all hints including Undo
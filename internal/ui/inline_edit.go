@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// inlineField is a session field that can be edited in its list row
+type inlineField int
+
+const (
+	inlineTitle inlineField = iota
+	inlineGroup
+	inlineCommand
+	inlineFieldCount
+)
+
+// label is the prompt shown in front of the input
+func (f inlineField) label() string {
+	switch f {
+	case inlineGroup:
+		return "group: "
+	case inlineCommand:
+		return "cmd: "
+	default:
+		return "title: "
+	}
+}
+
+// inlineEdit is the in-place editor for a session row (e). Tab switches
+// field and up/down moves to the neighbouring session, saving on the way, so
+// a messy deck can be cleaned up without opening a dialog per change.
+type inlineEdit struct {
+	sessionID string // "" when not editing
+	field     inlineField
+	input     textinput.Model
+}
+
+func newInlineEdit() inlineEdit {
+	ti := textinput.New()
+	ti.CharLimit = 200
+	return inlineEdit{input: ti}
+}
+
+// active reports whether a row is being edited
+func (e *inlineEdit) active() bool {
+	return e.sessionID != ""
+}
+
+// startInlineEdit starts editing field of the selected session
+func (h *Home) startInlineEdit(field inlineField) tea.Cmd {
+	inst := h.getSelectedSession()
+	if inst == nil {
+		return nil
+	}
+	h.loadInlineEdit(inst, field)
+	return h.inlineEdit.input.Focus()
+}
+
+// loadInlineEdit points the editor at inst's field
+func (h *Home) loadInlineEdit(inst *session.Instance, field inlineField) {
+	e := &h.inlineEdit
+	e.sessionID = inst.ID
+	e.field = field
+	e.input.Prompt = field.label()
+	switch field {
+	case inlineGroup:
+		e.input.SetValue(inst.GroupPath)
+	case inlineCommand:
+		e.input.SetValue(inst.Command)
+	default:
+		e.input.SetValue(inst.Title)
+	}
+	e.input.CursorEnd()
+}
+
+// stopInlineEdit closes the editor without saving
+func (h *Home) stopInlineEdit() {
+	h.inlineEdit.sessionID = ""
+	h.inlineEdit.input.Blur()
+}
+
+// handleInlineEditKey handles keys while a row is being edited
+func (h *Home) handleInlineEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		h.commitInlineEdit()
+		h.stopInlineEdit()
+		return h, nil
+	case "esc":
+		h.stopInlineEdit()
+		return h, nil
+	case "tab", "shift+tab":
+		h.commitInlineEdit()
+		next := h.inlineEdit.field + 1
+		if msg.String() == "shift+tab" {
+			next = h.inlineEdit.field + inlineFieldCount - 1
+		}
+		if inst := h.getInstanceByID(h.inlineEdit.sessionID); inst != nil {
+			h.loadInlineEdit(inst, next%inlineFieldCount)
+		}
+		return h, nil
+	case "up", "down":
+		h.commitInlineEdit()
+		dir := 1
+		if msg.String() == "up" {
+			dir = -1
+		}
+		if inst := h.moveToAdjacentSession(dir); inst != nil {
+			h.loadInlineEdit(inst, h.inlineEdit.field)
+		}
+		return h, nil
+	}
+	var cmd tea.Cmd
+	h.inlineEdit.input, cmd = h.inlineEdit.input.Update(msg)
+	return h, cmd
+}
+
+// moveToAdjacentSession moves the cursor to the next (dir 1) or previous
+// (dir -1) session row, skipping groups. Returns nil at either end.
+func (h *Home) moveToAdjacentSession(dir int) *session.Instance {
+	for i := h.cursor + dir; i >= 0 && i < len(h.flatItems); i += dir {
+		if item := h.flatItems[i]; item.Type == session.ItemTypeSession && item.Session != nil {
+			h.cursor = i
+			h.syncViewport()
+			return item.Session
+		}
+	}
+	return nil
+}
+
+// commitInlineEdit applies the edited value to the session and saves.
+// An empty title is ignored; an empty group moves the session to the
+// default group.
+func (h *Home) commitInlineEdit() {
+	inst := h.getInstanceByID(h.inlineEdit.sessionID)
+	if inst == nil {
+		return
+	}
+	value := strings.TrimSpace(h.inlineEdit.input.Value())
+
+	switch h.inlineEdit.field {
+	case inlineTitle:
+		if value == "" || value == inst.Title {
+			return
+		}
+		inst.Title = value
+		inst.SyncTmuxDisplayName()
+		// Survives a reload that skips this save, like the rename dialog
+		h.pendingTitleChanges[inst.ID] = value
+		h.invalidatePreviewCache(inst.ID)
+	case inlineGroup:
+		path := strings.Trim(value, "/")
+		if path == "" {
+			path = session.DefaultGroupPath
+		}
+		if path == inst.GroupPath {
+			return
+		}
+		if _, exists := h.groupTree.Groups[path]; !exists {
+			if group := h.groupTree.CreateGroupPath(path); group != nil {
+				path = group.Path
+			}
+		}
+		h.groupTree.MoveSessionToGroup(inst, path)
+		h.instancesMu.Lock()
+		h.instances = h.groupTree.GetAllInstances()
+		h.instancesMu.Unlock()
+	case inlineCommand:
+		if value == inst.Command {
+			return
+		}
+		inst.Command = value
+	}
+
+	// Keep the cursor on the session (a group move changes its row)
+	h.jumpToSession(inst)
+	h.saveInstances()
+}
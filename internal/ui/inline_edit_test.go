@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestInlineEditTitleAndGroup(t *testing.T) {
+	home, work, other := newFocusTestHome(t)
+	home.jumpToSession(work)
+
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if !home.inlineEdit.active() || home.inlineEdit.field != inlineTitle {
+		t.Fatal("e on a session should start editing its title")
+	}
+	if got := home.inlineEdit.input.Value(); got != work.Title {
+		t.Errorf("input = %q, want the current title", got)
+	}
+
+	home.inlineEdit.input.SetValue("api")
+	home.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if work.Title != "api" {
+		t.Errorf("tab should save the title, got %q", work.Title)
+	}
+	if home.inlineEdit.field != inlineGroup || home.inlineEdit.input.Value() != "work" {
+		t.Fatalf("tab should move to the group field, got %v %q", home.inlineEdit.field, home.inlineEdit.input.Value())
+	}
+
+	home.inlineEdit.input.SetValue("clients/acme")
+	home.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if home.inlineEdit.active() {
+		t.Error("enter should stop editing")
+	}
+	if work.GroupPath != "clients/acme" {
+		t.Errorf("group = %q, want clients/acme", work.GroupPath)
+	}
+	if _, ok := home.groupTree.Groups["clients"]; !ok {
+		t.Error("missing parent groups should be created")
+	}
+	if inst := home.getSelectedSession(); inst == nil || inst.ID != work.ID {
+		t.Error("cursor should follow the moved session")
+	}
+
+	// Esc discards the field being edited
+	home.jumpToSession(other)
+	home.startInlineEdit(inlineCommand)
+	home.inlineEdit.input.SetValue("claude --resume")
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if other.Command != "" || home.inlineEdit.active() {
+		t.Errorf("esc should cancel without saving, command = %q", other.Command)
+	}
+}
+
+func TestInlineEditMovesBetweenSessions(t *testing.T) {
+	home, _, _ := newFocusTestHome(t)
+	var first *session.Instance
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession {
+			home.cursor = i
+			first = item.Session
+			break
+		}
+	}
+
+	home.startInlineEdit(inlineCommand)
+	home.inlineEdit.input.SetValue("bash")
+	home.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if first.Command != "bash" {
+		t.Errorf("down should save the command, got %q", first.Command)
+	}
+	next := home.getSelectedSession()
+	if next == nil || next.ID == first.ID || home.inlineEdit.sessionID != next.ID {
+		t.Fatal("down should move the editor to the next session")
+	}
+	if home.inlineEdit.field != inlineCommand {
+		t.Error("the edited field should carry over to the next session")
+	}
+}
@@ -0,0 +1,23 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// scrollbackFetchedMsg is sent when a session's full scrollback has been captured for the log viewer
+type scrollbackFetchedMsg struct {
+	sessionTitle string
+	content      string
+	err          error
+}
+
+// fetchSessionScrollback returns a tea.Cmd that captures the session's entire tmux history
+func (h *Home) fetchSessionScrollback(inst *session.Instance) tea.Cmd {
+	title := inst.Title
+	return func() tea.Msg {
+		content, err := inst.CaptureScrollback(true)
+		return scrollbackFetchedMsg{sessionTitle: title, content: content, err: err}
+	}
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
@@ -20,8 +21,14 @@ type modelSelectedMsg struct {
 	instanceID string
 }
 
-// GeminiModelDialog allows selecting a Gemini model for the current session
-type GeminiModelDialog struct {
+// modelSwitchedMsg is sent once SetModel (and the restart it may do) is done
+type modelSwitchedMsg struct {
+	err error
+}
+
+// ModelDialog allows switching the model of the current session. The
+// choices come from [models] for its tool (Gemini: its API by default).
+type ModelDialog struct {
 	visible    bool
 	width      int
 	height     int
@@ -30,49 +37,51 @@ type GeminiModelDialog struct {
 	loading    bool
 	err        error
 	instanceID string // ID of the session to change model for
+	tool       string // Tool of that session
 	current    string // Currently active model
 }
 
-// NewGeminiModelDialog creates a new model selection dialog
-func NewGeminiModelDialog() *GeminiModelDialog {
-	return &GeminiModelDialog{}
+// NewModelDialog creates a new model selection dialog
+func NewModelDialog() *ModelDialog {
+	return &ModelDialog{}
 }
 
 // Show opens the dialog and triggers async model fetching
-func (d *GeminiModelDialog) Show(instanceID, currentModel string) tea.Cmd {
+func (d *ModelDialog) Show(instanceID, tool, currentModel string) tea.Cmd {
 	d.visible = true
 	d.cursor = 0
 	d.models = nil
 	d.loading = true
 	d.err = nil
 	d.instanceID = instanceID
+	d.tool = tool
 	d.current = currentModel
 
 	return func() tea.Msg {
-		models, err := session.GetAvailableGeminiModels()
+		models, err := session.GetModelChoices(tool)
 		return modelsFetchedMsg{models: models, err: err}
 	}
 }
 
 // Hide closes the dialog
-func (d *GeminiModelDialog) Hide() {
+func (d *ModelDialog) Hide() {
 	d.visible = false
 	d.loading = false
 }
 
 // IsVisible returns whether the dialog is visible
-func (d *GeminiModelDialog) IsVisible() bool {
+func (d *ModelDialog) IsVisible() bool {
 	return d.visible
 }
 
 // SetSize updates the dialog dimensions
-func (d *GeminiModelDialog) SetSize(width, height int) {
+func (d *ModelDialog) SetSize(width, height int) {
 	d.width = width
 	d.height = height
 }
 
 // HandleModelsFetched processes the async model fetch result
-func (d *GeminiModelDialog) HandleModelsFetched(msg modelsFetchedMsg) {
+func (d *ModelDialog) HandleModelsFetched(msg modelsFetchedMsg) {
 	d.loading = false
 	d.err = msg.err
 	d.models = msg.models
@@ -87,7 +96,7 @@ func (d *GeminiModelDialog) HandleModelsFetched(msg modelsFetchedMsg) {
 }
 
 // Update handles input for the dialog
-func (d *GeminiModelDialog) Update(msg tea.KeyMsg) (*GeminiModelDialog, tea.Cmd) {
+func (d *ModelDialog) Update(msg tea.KeyMsg) (*ModelDialog, tea.Cmd) {
 	if !d.visible {
 		return d, nil
 	}
@@ -123,7 +132,7 @@ func (d *GeminiModelDialog) Update(msg tea.KeyMsg) (*GeminiModelDialog, tea.Cmd)
 }
 
 // View renders the dialog
-func (d *GeminiModelDialog) View() string {
+func (d *ModelDialog) View() string {
 	if !d.visible {
 		return ""
 	}
@@ -153,8 +162,8 @@ func (d *GeminiModelDialog) View() string {
 	var content strings.Builder
 
 	// Title
-	content.WriteString(titleStyle.Render("Select Gemini Model"))
-	content.WriteString(dimStyle.Render("            [Esc] Cancel"))
+	content.WriteString(titleStyle.Render("Switch Model"))
+	content.WriteString(dimStyle.Render(" (" + d.tool + ")            [Esc] Cancel"))
 	content.WriteString("\n")
 	content.WriteString(strings.Repeat("-", dialogWidth-4))
 	content.WriteString("\n\n")
@@ -162,6 +171,11 @@ func (d *GeminiModelDialog) View() string {
 	if d.loading {
 		content.WriteString(dimStyle.Render("  Loading models..."))
 		content.WriteString("\n")
+	} else if len(d.models) == 0 && d.err == nil {
+		content.WriteString(dimStyle.Render("  No models to offer. List them in config.toml:"))
+		content.WriteString("\n")
+		content.WriteString(dimStyle.Render(fmt.Sprintf("  [models] %s = [\"...\"]", d.tool)))
+		content.WriteString("\n")
 	} else if d.err != nil {
 		content.WriteString(errorStyle.Render("  Error: " + d.err.Error()))
 		content.WriteString("\n\n")
@@ -21,6 +21,8 @@ type NewDialog struct {
 	claudeOptions        *ClaudeOptionsPanel // Claude-specific options (concrete for value extraction)
 	geminiOptions        *YoloOptionsPanel   // Gemini YOLO panel (concrete for value extraction)
 	codexOptions         *YoloOptionsPanel   // Codex YOLO panel (concrete for value extraction)
+	customOptions        *YoloOptionsPanel   // YOLO panel for the selected custom tool (nil if it has no dangerous_flag)
+	customOptionsTool    string              // Custom tool customOptions was made for
 	toolOptions          OptionsPanel        // Currently active tool options panel (nil if none)
 	focusIndex           int                 // 0=name, 1=path, 2=command, 3+=options
 	width                int
@@ -39,6 +41,9 @@ type NewDialog struct {
 	// Inline validation error displayed inside the dialog
 	validationErr string
 	pathCycler    session.CompletionCycler // Path autocomplete state
+	// Session templates from config.toml ([templates.*]), cycled with Ctrl+T
+	templateNames []string
+	templateIndex int // 0 = none, else templateNames[templateIndex-1]
 }
 
 // buildPresetCommands returns the list of commands for the picker,
@@ -126,10 +131,14 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string) {
 	d.geminiOptions.Blur()
 	d.codexOptions.Blur()
 	// Keep commandCursor at previously set default (don't reset to 0)
+	d.customOptionsTool = "" // re-read the custom tool's default
 	d.updateToolOptions()
 	// Reset worktree fields
 	d.worktreeEnabled = false
 	d.branchInput.SetValue("")
+	// Reset the template choice, picking up templates added since
+	d.templateNames = session.GetSessionTemplateNames()
+	d.templateIndex = 0
 	// Set path input to group's default path if provided, otherwise use current working directory
 	if defaultPath != "" {
 		d.pathInput.SetValue(defaultPath)
@@ -171,11 +180,56 @@ func (d *NewDialog) SetDefaultTool(tool string) {
 	d.updateToolOptions()
 }
 
-// GetSelectedGroup returns the parent group path
+// GetSelectedGroup returns the parent group path, or the selected
+// template's group when it sets one
 func (d *NewDialog) GetSelectedGroup() string {
+	if tpl := d.selectedTemplate(); tpl != nil && tpl.Group != "" {
+		return tpl.Group
+	}
 	return d.parentGroupPath
 }
 
+// GetTemplate returns the selected session template's name ("" = none)
+func (d *NewDialog) GetTemplate() string {
+	if d.templateIndex <= 0 || d.templateIndex > len(d.templateNames) {
+		return ""
+	}
+	return d.templateNames[d.templateIndex-1]
+}
+
+// selectedTemplate returns the selected session template, nil for none
+func (d *NewDialog) selectedTemplate() *session.SessionTemplate {
+	if name := d.GetTemplate(); name != "" {
+		return session.GetSessionTemplate(name)
+	}
+	return nil
+}
+
+// cycleTemplate selects the next session template (after the last: none)
+// and its command: the tool's pill when it just runs a preset tool, else
+// the shell pill with the command as the custom command
+func (d *NewDialog) cycleTemplate() {
+	if len(d.templateNames) == 0 {
+		return
+	}
+	d.templateIndex = (d.templateIndex + 1) % (len(d.templateNames) + 1)
+	tpl := d.selectedTemplate()
+	if tpl == nil || tpl.CommandLine() == "" {
+		return
+	}
+	d.commandCursor = 0
+	d.commandInput.SetValue(tpl.CommandLine())
+	for i, cmd := range d.presetCommands {
+		if cmd != "" && cmd == tpl.CommandLine() {
+			d.commandCursor = i
+			d.commandInput.SetValue("")
+			break
+		}
+	}
+	d.updateToolOptions()
+	d.updateFocus()
+}
+
 // SetSize sets the dialog dimensions
 func (d *NewDialog) SetSize(width, height int) {
 	d.width = width
@@ -296,8 +350,8 @@ func (d *NewDialog) Validate() string {
 	// Fix: sanitize input to remove surrounding quotes that cause path issues
 	path := strings.Trim(strings.TrimSpace(d.pathInput.Value()), "'\"")
 
-	// Check for empty name
-	if name == "" {
+	// Check for empty name ([repo_labels] title_template may name it)
+	if name == "" && session.GetRepoLabelSettings().TitleTemplate == "" {
 		return "Session name cannot be empty"
 	}
 
@@ -354,9 +408,40 @@ func (d *NewDialog) updateToolOptions() {
 		d.toolOptions = d.codexOptions
 	default:
 		d.toolOptions = nil
+		d.updateCustomOptions()
+		if d.customOptions != nil {
+			d.toolOptions = d.customOptions
+		}
 	}
 }
 
+// updateCustomOptions makes the YOLO panel for a custom tool with a
+// dangerous_flag, defaulting to its dangerous_mode
+func (d *NewDialog) updateCustomOptions() {
+	tool := d.GetSelectedCommand()
+	if tool == d.customOptionsTool {
+		return
+	}
+	d.customOptionsTool = tool
+	d.customOptions = nil
+	def := session.GetToolDef(tool)
+	if def == nil || def.DangerousFlag == "" {
+		return
+	}
+	d.customOptions = NewYoloOptionsPanel(tool, "YOLO mode - "+def.DangerousFlag)
+	d.customOptions.SetDefaults(def.DangerousMode)
+}
+
+// GetCustomYoloMode returns the YOLO choice for a custom tool, nil when the
+// selected command isn't one with launch modes
+func (d *NewDialog) GetCustomYoloMode() *bool {
+	if d.customOptions == nil || d.customOptionsTool != d.GetSelectedCommand() {
+		return nil
+	}
+	yolo := d.customOptions.GetYoloMode()
+	return &yolo
+}
+
 func (d *NewDialog) updateFocus() {
 	d.nameInput.Blur()
 	d.pathInput.Blur()
@@ -365,6 +450,9 @@ func (d *NewDialog) updateFocus() {
 	d.claudeOptions.Blur()
 	d.geminiOptions.Blur()
 	d.codexOptions.Blur()
+	if d.customOptions != nil {
+		d.customOptions.Blur()
+	}
 
 	switch d.focusIndex {
 	case 0:
@@ -506,6 +594,12 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 			d.Hide()
 			return d, nil
 
+		case "ctrl+t":
+			if len(d.templateNames) > 0 {
+				d.cycleTemplate()
+				return d, nil
+			}
+
 		case "enter":
 			// Let parent handle enter (create session)
 			return d, nil
@@ -548,10 +642,14 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 			}
 
 		case "y":
-			// 'y' shortcut from command field (gemini/codex only)
+			// 'y' shortcut from command field toggles YOLO for tools with launch modes
 			selectedCmd := d.GetSelectedCommand()
-			if d.focusIndex == 2 && (selectedCmd == "gemini" || selectedCmd == "codex") && d.toolOptions != nil {
-				d.toolOptions.Update(msg)
+			if d.focusIndex == 2 && session.SupportsLaunchMode(selectedCmd) && d.toolOptions != nil {
+				if selectedCmd == "claude" {
+					d.claudeOptions.ToggleSkipPermissions()
+				} else {
+					d.toolOptions.Update(msg)
+				}
 				return d, nil
 			}
 			// 'y' from within tool options panel
@@ -643,7 +741,23 @@ func (d *NewDialog) View() string {
 	content.WriteString(titleStyle.Render("New Session"))
 	content.WriteString("\n")
 	groupInfoStyle := lipgloss.NewStyle().Foreground(ColorPurple) // Purple for group context
-	content.WriteString(groupInfoStyle.Render("  in group: " + d.parentGroupName))
+	groupName := d.parentGroupName
+	tpl := d.selectedTemplate()
+	if tpl != nil && tpl.Group != "" {
+		groupName = tpl.Group
+	}
+	content.WriteString(groupInfoStyle.Render("  in group: " + groupName))
+	if len(d.templateNames) > 0 {
+		templateLine := "  template: none (Ctrl+T to pick)"
+		if tpl != nil {
+			templateLine = "  template: " + d.GetTemplate()
+			if tpl.Description != "" {
+				templateLine += " - " + tpl.Description
+			}
+		}
+		content.WriteString("\n")
+		content.WriteString(groupInfoStyle.Render(templateLine))
+	}
 	content.WriteString("\n\n")
 
 	// Name input
@@ -826,12 +940,14 @@ func (d *NewDialog) View() string {
 	if d.focusIndex == 1 {
 		helpText = "Tab autocomplete │ ^N/^P recent │ ↑↓ navigate │ Enter create │ Esc cancel"
 	} else if d.focusIndex == 2 {
-		selectedCmd := d.GetSelectedCommand()
-		if selectedCmd == "gemini" || selectedCmd == "codex" {
-			helpText = "←→ command │ w worktree │ y yolo │ Tab next │ Enter create │ Esc cancel"
-		} else {
-			helpText = "←→ command │ w worktree │ Tab next │ Enter create │ Esc cancel"
+		helpText = "←→ command │ w worktree"
+		if session.SupportsLaunchMode(d.GetSelectedCommand()) {
+			helpText += " │ y yolo"
+		}
+		if len(d.templateNames) > 0 {
+			helpText += " │ ^T template"
 		}
+		helpText += " │ Tab next │ Enter create │ Esc cancel"
 	} else if d.toolOptions != nil && d.focusIndex >= d.optionsStartIndex() {
 		helpText = "Space/y toggle │ ↑↓ navigate │ Enter create │ Esc cancel"
 	}
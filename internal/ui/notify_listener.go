@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// NotifyListener receives status reports from `agent-deck notify` over a
+// per-process unix socket, so hook-driven state changes show up instantly.
+type NotifyListener struct {
+	path      string
+	listener  net.Listener
+	reportCh  chan session.StatusReport
+	closeOnce sync.Once
+}
+
+// NewNotifyListener starts listening on this process's notify socket for profile.
+func NewNotifyListener(profile string) (*NotifyListener, error) {
+	path, err := session.NotifySocketPath(profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	_ = os.Remove(path) // Left over from a crashed process with the same PID
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	nl := &NotifyListener{
+		path:     path,
+		listener: listener,
+		reportCh: make(chan session.StatusReport, 32),
+	}
+	go nl.acceptLoop()
+	return nl, nil
+}
+
+// acceptLoop reads one JSON report per line from each connection
+func (nl *NotifyListener) acceptLoop() {
+	for {
+		conn, err := nl.listener.Accept()
+		if err != nil {
+			return // Listener closed
+		}
+		go nl.handleConn(conn)
+	}
+}
+
+// handleConn decodes reports from a single client connection
+func (nl *NotifyListener) handleConn(conn net.Conn) {
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var report session.StatusReport
+		if err := json.Unmarshal(scanner.Bytes(), &report); err != nil {
+			uiLog.Debug("notify_report_invalid", slog.String("error", err.Error()))
+			continue
+		}
+		// Non-blocking send (drop if TUI is backed up)
+		select {
+		case nl.reportCh <- report:
+		default:
+			uiLog.Debug("notify_report_dropped", slog.String("session", report.SessionID))
+		}
+	}
+}
+
+// Reports returns the channel of received status reports.
+func (nl *NotifyListener) Reports() <-chan session.StatusReport {
+	return nl.reportCh
+}
+
+// Close stops listening and removes the socket file.
+func (nl *NotifyListener) Close() {
+	nl.closeOnce.Do(func() {
+		_ = nl.listener.Close()
+		_ = os.Remove(nl.path)
+	})
+}
+
+// statusReportMsg delivers a status report to the Update loop
+type statusReportMsg struct {
+	report session.StatusReport
+}
+
+// listenForStatusReports waits for the next status report
+func listenForStatusReports(nl *NotifyListener) tea.Cmd {
+	return func() tea.Msg {
+		if nl == nil {
+			return nil
+		}
+		return statusReportMsg{report: <-nl.Reports()}
+	}
+}
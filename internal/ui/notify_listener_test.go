@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestNotifyListenerReceivesReports(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	nl, err := NewNotifyListener("_test")
+	if err != nil {
+		t.Fatalf("NewNotifyListener: %v", err)
+	}
+	defer nl.Close()
+
+	want := session.StatusReport{SessionID: "abc123", Status: session.StatusWaiting, Message: "needs approval"}
+	delivered, err := session.SendStatusReport("_test", want)
+	if err != nil {
+		t.Fatalf("SendStatusReport: %v", err)
+	}
+	if delivered != 1 {
+		t.Fatalf("delivered = %d, want 1", delivered)
+	}
+
+	select {
+	case got := <-nl.Reports():
+		if got != want {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for report")
+	}
+
+	// After Close the socket is gone and nothing is delivered
+	nl.Close()
+	if delivered, _ := session.SendStatusReport("_test", want); delivered != 0 {
+		t.Errorf("delivered after close = %d, want 0", delivered)
+	}
+}
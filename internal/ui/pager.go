@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// PagerOverlay shows long read-only text (e.g. a git diff) in a scrollable modal
+type PagerOverlay struct {
+	visible bool
+	width   int
+	height  int
+	title   string
+	lines   []string
+	offset  int
+	styler  func(line string) string // Optional per-line styling, applied after truncation
+
+	search      textSearch
+	searchInput textinput.Model
+	searching   bool // Typing a search query
+}
+
+// NewPagerOverlay creates a new pager overlay
+func NewPagerOverlay() *PagerOverlay {
+	ti := textinput.New()
+	ti.Placeholder = "search"
+	ti.Prompt = "/"
+	ti.CharLimit = 100
+	return &PagerOverlay{searchInput: ti}
+}
+
+// Show displays content in the pager. styler may be nil for plain text.
+func (p *PagerOverlay) Show(title, content string, styler func(string) string) {
+	p.visible = true
+	p.title = title
+	p.lines = strings.Split(strings.ReplaceAll(content, "\t", "    "), "\n")
+	p.offset = 0
+	p.styler = styler
+	p.search = textSearch{}
+	p.searching = false
+}
+
+// Hide hides the pager overlay
+func (p *PagerOverlay) Hide() {
+	p.visible = false
+	p.lines = nil
+}
+
+// ScrollToBottom jumps to the end of the content (e.g. newest log output)
+func (p *PagerOverlay) ScrollToBottom() {
+	p.offset = p.maxOffset()
+}
+
+// IsVisible returns whether the pager overlay is visible
+func (p *PagerOverlay) IsVisible() bool {
+	return p.visible
+}
+
+// SetSize sets the dimensions for the overlay
+func (p *PagerOverlay) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// pageSize returns how many content lines fit on screen
+func (p *PagerOverlay) pageSize() int {
+	// Border (2) + padding (2) + title (2) + footer (2)
+	size := p.height - 8
+	if size < 5 {
+		size = 5
+	}
+	return size
+}
+
+// maxOffset returns the largest valid scroll offset
+func (p *PagerOverlay) maxOffset() int {
+	m := len(p.lines) - p.pageSize()
+	if m < 0 {
+		return 0
+	}
+	return m
+}
+
+// scroll moves the view by delta lines, clamped to the content
+func (p *PagerOverlay) scroll(delta int) {
+	p.offset += delta
+	if p.offset > p.maxOffset() {
+		p.offset = p.maxOffset()
+	}
+	if p.offset < 0 {
+		p.offset = 0
+	}
+}
+
+// jumpTo scrolls so line is visible about a third of the way down the page
+func (p *PagerOverlay) jumpTo(line int) {
+	if line < 0 {
+		return
+	}
+	p.offset = line - p.pageSize()/3
+	p.scroll(0)
+}
+
+// updateSearchInput handles keys while the search query is being typed
+func (p *PagerOverlay) updateSearchInput(msg tea.KeyMsg) (*PagerOverlay, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		p.searching = false
+		p.searchInput.Blur()
+		p.search.run(p.lines, strings.TrimSpace(p.searchInput.Value()))
+		p.jumpTo(p.search.nearest(p.offset))
+		return p, nil
+	case "esc":
+		p.searching = false
+		p.searchInput.Blur()
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.searchInput, cmd = p.searchInput.Update(msg)
+	return p, cmd
+}
+
+// Update handles messages for the pager overlay
+func (p *PagerOverlay) Update(msg tea.Msg) (*PagerOverlay, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		if p.searching {
+			return p.updateSearchInput(key)
+		}
+		page := p.pageSize()
+		switch key.String() {
+		case "/":
+			p.searching = true
+			p.searchInput.SetValue(p.search.query)
+			p.searchInput.CursorEnd()
+			return p, p.searchInput.Focus()
+		case "n":
+			p.jumpTo(p.search.next())
+		case "N":
+			p.jumpTo(p.search.prev())
+		case "esc":
+			// First esc clears an active search, second closes
+			if p.search.active() {
+				p.search = textSearch{}
+			} else {
+				p.Hide()
+			}
+		case "j", "down":
+			p.scroll(1)
+		case "k", "up":
+			p.scroll(-1)
+		case "ctrl+d":
+			p.scroll(page / 2)
+		case "ctrl+u":
+			p.scroll(-page / 2)
+		case "ctrl+f", "pgdown", " ":
+			p.scroll(page)
+		case "ctrl+b", "pgup":
+			p.scroll(-page)
+		case "g", "home":
+			p.offset = 0
+		case "G", "end":
+			p.offset = p.maxOffset()
+		case "q", "enter":
+			p.Hide()
+		}
+	}
+	return p, nil
+}
+
+// View renders the pager overlay
+func (p *PagerOverlay) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+	textStyle := lipgloss.NewStyle().Foreground(ColorText)
+
+	dialogWidth := p.width - 4
+	if dialogWidth < 40 {
+		dialogWidth = 40
+	}
+	// Border (2) + horizontal padding (4)
+	maxLineWidth := dialogWidth - 6
+	if maxLineWidth < 10 {
+		maxLineWidth = 10
+	}
+
+	page := p.pageSize()
+	if p.offset > p.maxOffset() {
+		p.offset = p.maxOffset()
+	}
+	end := p.offset + page
+	if end > len(p.lines) {
+		end = len(p.lines)
+	}
+
+	matchStyle, currentStyle := searchMatchStyles()
+	currentLine := p.search.currentLine()
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(p.title))
+	content.WriteString("\n\n")
+	for i := p.offset; i < end; i++ {
+		line := p.lines[i]
+		if runewidth.StringWidth(line) > maxLineWidth {
+			line = runewidth.Truncate(line, maxLineWidth-3, "...")
+		}
+		if p.search.active() && p.search.re.MatchString(line) {
+			style := matchStyle
+			if i == currentLine {
+				style = currentStyle
+			}
+			content.WriteString(p.search.render(line, textStyle, style))
+		} else if p.styler != nil {
+			content.WriteString(p.styler(line))
+		} else {
+			content.WriteString(textStyle.Render(line))
+		}
+		if i < end-1 {
+			content.WriteString("\n")
+		}
+	}
+	// Pad short content so the box keeps a stable height while scrolling
+	for i := end - p.offset; i < page; i++ {
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n\n")
+	position := fmt.Sprintf("%d-%d of %d", p.offset+1, end, len(p.lines))
+	if len(p.lines) == 0 {
+		position = "empty"
+	}
+	switch {
+	case p.searching:
+		content.WriteString(p.searchInput.View())
+	case p.search.active():
+		content.WriteString(footerStyle.Render(position + " • " + p.search.status() + " • n/N next/prev • esc clear • q close"))
+	default:
+		content.WriteString(footerStyle.Render(position + " • j/k scroll • space/ctrl+d page • g/G top/bottom • / search • q close"))
+	}
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(content.String())
+
+	return centerInScreen(box, p.width, p.height)
+}
+
+// styleDiffLine colors a unified diff line (additions, removals, hunk headers, file headers)
+func styleDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"), strings.HasPrefix(line, "diff --git"):
+		return lipgloss.NewStyle().Foreground(ColorText).Bold(true).Render(line)
+	case strings.HasPrefix(line, "@@"):
+		return lipgloss.NewStyle().Foreground(ColorCyan).Render(line)
+	case strings.HasPrefix(line, "+"):
+		return lipgloss.NewStyle().Foreground(ColorGreen).Render(line)
+	case strings.HasPrefix(line, "-"):
+		return lipgloss.NewStyle().Foreground(ColorRed).Render(line)
+	case strings.HasPrefix(line, "  ?? "):
+		return lipgloss.NewStyle().Foreground(ColorYellow).Render(line)
+	default:
+		return lipgloss.NewStyle().Foreground(ColorText).Render(line)
+	}
+}
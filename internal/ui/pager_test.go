@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPagerOverlayScrollAndClose(t *testing.T) {
+	p := NewPagerOverlay()
+	p.SetSize(100, 30)
+
+	var lines []string
+	for i := 0; i < 100; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	p.Show("test", strings.Join(lines, "\n"), nil)
+
+	if !p.IsVisible() {
+		t.Fatal("pager should be visible after Show")
+	}
+	if !strings.Contains(p.View(), "line 0") {
+		t.Error("first page should show line 0")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if p.offset != 1 {
+		t.Errorf("offset after j = %d, want 1", p.offset)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if p.offset != p.maxOffset() {
+		t.Errorf("offset after G = %d, want %d", p.offset, p.maxOffset())
+	}
+	if !strings.Contains(p.View(), "line 99") {
+		t.Error("last page should show line 99")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if p.offset != 0 {
+		t.Errorf("offset after g = %d, want 0", p.offset)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if p.IsVisible() {
+		t.Error("pager should close on q")
+	}
+}
+
+func TestFormatDiffContent(t *testing.T) {
+	if got := formatDiffContent("", ""); got != "No uncommitted changes" {
+		t.Errorf("empty diff = %q", got)
+	}
+	got := formatDiffContent(" a.go | 1 +", "+added")
+	if !strings.Contains(got, "a.go") || !strings.Contains(got, "+added") {
+		t.Errorf("combined content missing parts: %q", got)
+	}
+}
+
+func TestPagerOverlaySearch(t *testing.T) {
+	p := NewPagerOverlay()
+	p.SetSize(100, 30)
+
+	var lines []string
+	for i := 0; i < 100; i++ {
+		line := fmt.Sprintf("line %d", i)
+		if i == 10 || i == 60 || i == 90 {
+			line += " ERROR boom"
+		}
+		lines = append(lines, line)
+	}
+	p.Show("log", strings.Join(lines, "\n"), nil)
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if !p.searching {
+		t.Fatal("/ should open the search input")
+	}
+	for _, r := range "error" {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.searching {
+		t.Fatal("enter should close the search input")
+	}
+	if !p.IsVisible() {
+		t.Fatal("enter while searching must not close the pager")
+	}
+	if got := len(p.search.matches); got != 3 {
+		t.Fatalf("matches = %d, want 3", got)
+	}
+	if got := p.search.currentLine(); got != 10 {
+		t.Errorf("first match from top = %d, want 10", got)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if got := p.search.currentLine(); got != 60 {
+		t.Errorf("after n = %d, want 60", got)
+	}
+	if !strings.Contains(p.View(), "line 60 ERROR") {
+		t.Error("view should scroll to the focused match")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if got := p.search.currentLine(); got != 90 {
+		t.Errorf("N should wrap to the last match, got %d", got)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.search.active() || !p.IsVisible() {
+		t.Error("first esc should clear the search and keep the pager open")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.IsVisible() {
+		t.Error("second esc should close the pager")
+	}
+}
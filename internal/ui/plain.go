@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// plainMode renders without colors or Unicode icons (see SetPlainMode)
+var plainMode bool
+
+// SetPlainMode makes the TUI render ASCII-only output without ANSI styling,
+// for dumb terminals and logging contexts (--plain)
+func SetPlainMode(on bool) {
+	plainMode = on
+}
+
+// PlainMode reports whether plain rendering is active
+func PlainMode() bool {
+	return plainMode
+}
+
+// asciiGlyphs maps the glyphs the TUI and CLI draw with to ASCII stand-ins,
+// one column each so layouts keep their width
+var asciiGlyphs = strings.NewReplacer(
+	// Box drawing
+	"─", "-", "━", "-", "═", "=", "│", "|", "┃", "|", "║", "|",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+", "╔", "+", "╗", "+", "╚", "+", "╝", "+",
+	// Tree and selection markers
+	"▾", "v", "▸", ">", "▶", ">", "❯", ">", "⟨", "<", "⟩", ">",
+	// Status icons
+	"●", "*", "◐", "~", "○", "o", "✕", "x", "✓", "+", "✗", "x",
+	// Punctuation and arrows
+	"•", "-", "·", "-", "…", ".", "→", ">", "←", "<", "↑", "^", "↓", "v", "⬆", "^",
+	"⚠", "!", "⎇", "@", "“", `"`, "”", `"`, "‘", "'", "’", "'", "—", "-", "–", "-",
+)
+
+// PlainText strips ANSI escapes from s and replaces icons with ASCII. Other
+// symbols and emoji become spaces of the same width; letters (session titles
+// in any script) stay.
+func PlainText(s string) string {
+	s = asciiGlyphs.Replace(tmux.StripANSI(s))
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case r < 0x80 || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsSpace(r),
+			unicode.Is(unicode.Mn, r) || unicode.IsPunct(r):
+			b.WriteRune(r)
+		default: // Symbols, emoji, variation selectors
+			b.WriteString(strings.Repeat(" ", runewidth.RuneWidth(r)))
+		}
+	}
+	return b.String()
+}
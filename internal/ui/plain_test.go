@@ -0,0 +1,19 @@
+package ui
+
+import "testing"
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"\x1b[1;32m● running\x1b[0m", "* running"},
+		{"├─ ◐ café ✕", "+- ~ café x"},
+		{"📁 Work…", "   Work."},
+		{"plain ascii", "plain ascii"},
+	}
+	for _, tt := range tests {
+		if got := PlainText(tt.in); got != tt.want {
+			t.Errorf("PlainText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// pollBackoff decides which sessions the background worker polls on each
+// tick. Busy sessions are polled every tick; sessions whose status stays
+// idle, waiting or error are polled half as often after each unchanged poll,
+// up to a cap ([status] poll_interval_ms / max_poll_interval_ms).
+type pollBackoff struct {
+	base time.Duration
+	max  time.Duration
+
+	mu    sync.Mutex
+	state map[string]pollState
+}
+
+// pollState is one session's schedule
+type pollState struct {
+	interval time.Duration
+	next     time.Time
+}
+
+// newPollBackoff creates a schedule polling busy sessions every base and
+// quiet ones at most every max
+func newPollBackoff(base, max time.Duration) *pollBackoff {
+	return &pollBackoff{base: base, max: max, state: make(map[string]pollState)}
+}
+
+// due reports whether the session should be polled now. Unknown sessions are
+// always due.
+func (p *pollBackoff) due(id string, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st, ok := p.state[id]
+	return !ok || !now.Before(st.next)
+}
+
+// record schedules the next poll after one at now. A status change or a busy
+// status resets to the base interval; otherwise the interval doubles.
+func (p *pollBackoff) record(id string, status session.Status, changed bool, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st := p.state[id]
+	switch {
+	case changed || status == session.StatusRunning || status == session.StatusStarting || st.interval == 0:
+		st.interval = p.base
+	default:
+		st.interval *= 2
+		if st.interval > p.max {
+			st.interval = p.max
+		}
+	}
+	// Due slightly early so jitter in the ticker doesn't skip a whole tick
+	st.next = now.Add(st.interval - p.base/4)
+	p.state[id] = st
+}
+
+// reset makes a session due on the next tick at the base interval, e.g.
+// after the user interacted with it
+func (p *pollBackoff) reset(id string) {
+	p.mu.Lock()
+	delete(p.state, id)
+	p.mu.Unlock()
+}
+
+// prune forgets sessions that no longer exist
+func (p *pollBackoff) prune(keep map[string]bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id := range p.state {
+		if !keep[id] {
+			delete(p.state, id)
+		}
+	}
+}
+
+// loadPollBackoff builds the schedule from [status] config
+func loadPollBackoff() *pollBackoff {
+	settings := session.GetStatusSettings()
+	return newPollBackoff(settings.GetPollInterval(), settings.GetMaxPollInterval())
+}
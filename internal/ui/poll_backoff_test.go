@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestPollBackoff(t *testing.T) {
+	p := newPollBackoff(2*time.Second, 10*time.Second)
+	start := time.Now()
+	if !p.due("a", start) {
+		t.Fatal("unknown sessions should be due")
+	}
+
+	// Count polls over a minute of 2s ticks for an idle session
+	polls := 0
+	for tick := start; tick.Before(start.Add(time.Minute)); tick = tick.Add(2 * time.Second) {
+		if p.due("a", tick) {
+			polls++
+			p.record("a", session.StatusIdle, false, tick)
+		}
+	}
+	// 0, 2, 6, 14 (interval 2, 4, 8), then every 10s up to 60s
+	if polls < 7 || polls > 9 {
+		t.Errorf("idle session polled %d times in a minute, want ~8", polls)
+	}
+	if got := p.state["a"].interval; got != 10*time.Second {
+		t.Errorf("interval = %v, want capped at 10s", got)
+	}
+
+	// Running or changed sessions go back to every tick
+	now := start.Add(time.Minute)
+	p.record("a", session.StatusWaiting, true, now)
+	if !p.due("a", now.Add(2*time.Second)) {
+		t.Error("a status change should reset to the base interval")
+	}
+	p.record("a", session.StatusRunning, false, now)
+	if p.state["a"].interval != 2*time.Second {
+		t.Error("running sessions should poll at the base interval")
+	}
+
+	p.record("a", session.StatusIdle, false, now)
+	p.reset("a")
+	if !p.due("a", now) {
+		t.Error("reset should make the session due")
+	}
+
+	p.record("b", session.StatusIdle, false, now)
+	p.prune(map[string]bool{"a": true})
+	if _, ok := p.state["b"]; ok {
+		t.Error("prune should drop sessions that no longer exist")
+	}
+}
@@ -68,13 +68,8 @@ func (p *Preview) View() string {
 		for _, line := range lines {
 			// Truncate long lines
 			maxWidth := p.width - 4
-			if maxWidth > 0 && len(line) > maxWidth {
-				truncateAt := maxWidth - 3
-				if truncateAt > 0 {
-					line = line[:truncateAt] + "..."
-				} else {
-					line = "..."
-				}
+			if maxWidth > 0 {
+				line = TruncateWidth(line, maxWidth)
 			}
 			b.WriteString(contentStyle.Render(line))
 			b.WriteString("\n")
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+)
+
+// previewScrollState tracks manual preview scrolling when follow mode is off
+type previewScrollState struct {
+	sessionID string // Session the offset applies to (other sessions show the tail)
+	offset    int    // Lines scrolled up from the bottom when base was recorded
+	base      int    // Total line count when offset was set
+}
+
+// effectiveOffset returns how many lines above the bottom the view starts, keeping
+// the same lines on screen as new output arrives below them.
+func (s previewScrollState) effectiveOffset(total int) int {
+	offset := s.offset + (total - s.base)
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+// previewWindow selects the visible preview lines: the tail when offset is 0,
+// otherwise a window ending offset lines above the bottom.
+// Returns the visible lines and how many lines are hidden above and below.
+func previewWindow(lines []string, maxLines, offset int) (visible []string, above, below int) {
+	if maxLines < 1 {
+		maxLines = 1
+	}
+	total := len(lines)
+	if total <= maxLines {
+		return lines, 0, 0
+	}
+	maxOffset := total - maxLines
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	end := total - offset
+	start := end - maxLines
+	return lines[start:end], start, offset
+}
+
+// previewLines returns the cached preview lines without trailing blank lines,
+// matching what renderSelectedPreview displays
+func (h *Home) previewLines(sessionID string) []string {
+	h.previewCacheMu.RLock()
+	preview := h.previewCache[sessionID]
+	h.previewCacheMu.RUnlock()
+	lines := strings.Split(preview, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// previewLineCount returns the number of non-trailing-blank lines in the cached preview
+func (h *Home) previewLineCount(sessionID string) int {
+	return len(h.previewLines(sessionID))
+}
+
+// previewOffsetFor returns the scroll offset to render for a session (0 = tail)
+func (h *Home) previewOffsetFor(sessionID string, total int) int {
+	if h.previewFollow || h.previewScroll.sessionID != sessionID {
+		return 0
+	}
+	return h.previewScroll.effectiveOffset(total)
+}
+
+// scrollPreview scrolls the selected session's preview by delta lines (positive = up, into history).
+// Scrolling up pauses follow mode; scrolling back to the bottom resumes it.
+func (h *Home) scrollPreview(delta int) {
+	inst := h.getSelectedSession()
+	if inst == nil {
+		return
+	}
+	total := h.previewLineCount(inst.ID)
+	current := h.previewOffsetFor(inst.ID, total)
+
+	offset := current + delta
+	if offset > total-1 {
+		offset = total - 1
+	}
+	if offset <= 0 {
+		h.previewFollow = true
+		h.previewScroll = previewScrollState{}
+		return
+	}
+	h.previewFollow = false
+	h.previewScroll = previewScrollState{sessionID: inst.ID, offset: offset, base: total}
+}
+
+// toggleFollow turns preview follow mode on or off. Turning it off freezes the
+// current view of the selected session so new output doesn't scroll it.
+func (h *Home) toggleFollow() {
+	if h.previewFollow {
+		h.previewFollow = false
+		if inst := h.getSelectedSession(); inst != nil {
+			h.previewScroll = previewScrollState{sessionID: inst.ID, base: h.previewLineCount(inst.ID)}
+		}
+		return
+	}
+	h.previewFollow = true
+	h.previewScroll = previewScrollState{}
+}
+
+// previewPageSize returns how many lines a page key scrolls the preview
+func (h *Home) previewPageSize() int {
+	page := (h.height - 10) / 2
+	if page < 5 {
+		page = 5
+	}
+	return page
+}
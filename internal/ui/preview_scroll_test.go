@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestPreviewWindow(t *testing.T) {
+	lines := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+
+	tests := []struct {
+		name      string
+		maxLines  int
+		offset    int
+		wantFirst string
+		wantLast  string
+		wantAbove int
+		wantBelow int
+	}{
+		{"tail", 4, 0, "7", "10", 6, 0},
+		{"scrolled", 4, 3, "4", "7", 3, 3},
+		{"clamped to top", 4, 50, "1", "4", 0, 6},
+		{"fits", 20, 5, "1", "10", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visible, above, below := previewWindow(lines, tt.maxLines, tt.offset)
+			if visible[0] != tt.wantFirst || visible[len(visible)-1] != tt.wantLast {
+				t.Errorf("visible = %v, want %s..%s", visible, tt.wantFirst, tt.wantLast)
+			}
+			if above != tt.wantAbove || below != tt.wantBelow {
+				t.Errorf("above/below = %d/%d, want %d/%d", above, below, tt.wantAbove, tt.wantBelow)
+			}
+		})
+	}
+}
+
+func TestPreviewScrollStateKeepsViewStable(t *testing.T) {
+	s := previewScrollState{sessionID: "a", offset: 5, base: 100}
+	if got := s.effectiveOffset(100); got != 5 {
+		t.Errorf("effectiveOffset(100) = %d, want 5", got)
+	}
+	// Ten new lines arrived: the same content is now 15 lines above the bottom
+	if got := s.effectiveOffset(110); got != 15 {
+		t.Errorf("effectiveOffset(110) = %d, want 15", got)
+	}
+}
+
+func TestPreviewFollowAndScroll(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 30
+
+	inst := session.NewInstance("worker", "/tmp/worker")
+	home.instancesMu.Lock()
+	home.instances = []*session.Instance{inst}
+	home.instanceByID = map[string]*session.Instance{inst.ID: inst}
+	home.instancesMu.Unlock()
+	home.groupTree = session.NewGroupTree(home.instances)
+	home.rebuildFlatItems()
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession {
+			home.cursor = i
+		}
+	}
+
+	var out []string
+	for i := 1; i <= 200; i++ {
+		out = append(out, fmt.Sprintf("line-%03d", i))
+	}
+	home.previewCache[inst.ID] = strings.Join(out, "\n")
+	home.previewCacheTime[inst.ID] = time.Now()
+
+	if !home.previewFollow {
+		t.Fatal("follow should be on by default")
+	}
+	if view := home.renderSelectedPreview(80, 30); !strings.Contains(view, "line-200") {
+		t.Error("following preview should show the newest line")
+	}
+
+	home.scrollPreview(50)
+	if home.previewFollow {
+		t.Fatal("scrolling up should pause follow")
+	}
+	view := home.renderSelectedPreview(80, 30)
+	if strings.Contains(view, "line-200") {
+		t.Error("scrolled preview should not show the newest line")
+	}
+	if !strings.Contains(view, "newer lines below") {
+		t.Error("scrolled preview should show the hidden-below indicator")
+	}
+
+	home.scrollPreview(-1000)
+	if !home.previewFollow {
+		t.Error("scrolling back to the bottom should resume follow")
+	}
+
+	home.toggleFollow()
+	if home.previewFollow {
+		t.Error("toggleFollow should turn follow off")
+	}
+	home.toggleFollow()
+	if !home.previewFollow {
+		t.Error("toggleFollow should turn follow back on")
+	}
+}
+
+func TestPreviewSearch(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 30
+
+	inst := session.NewInstance("worker", "/tmp/worker")
+	home.instancesMu.Lock()
+	home.instances = []*session.Instance{inst}
+	home.instanceByID = map[string]*session.Instance{inst.ID: inst}
+	home.instancesMu.Unlock()
+	home.groupTree = session.NewGroupTree(home.instances)
+	home.rebuildFlatItems()
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession {
+			home.cursor = i
+		}
+	}
+
+	var out []string
+	for i := 1; i <= 200; i++ {
+		line := fmt.Sprintf("line-%03d", i)
+		if i == 20 || i == 120 {
+			line += " panic: nil map"
+		}
+		out = append(out, line)
+	}
+	home.previewCache[inst.ID] = strings.Join(out, "\n")
+	home.previewCacheTime[inst.ID] = time.Now()
+
+	home.startPreviewSearch()
+	if !home.previewSearching {
+		t.Fatal("startPreviewSearch should open the input")
+	}
+	home.previewSearchInput.SetValue("PANIC")
+	home.handlePreviewSearchKey(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if got := home.previewSearch.currentLine(); got != 119 {
+		t.Fatalf("search should focus the newest match (index 119), got %d", got)
+	}
+	if home.previewFollow {
+		t.Error("jumping to a match should pause follow")
+	}
+	if view := home.renderSelectedPreview(80, 30); !strings.Contains(view, "line-120 panic") {
+		t.Error("preview should scroll to show the newest match")
+	}
+
+	home.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if home.newDialog.IsVisible() || home.previewSearch.currentLine() != 19 {
+		t.Fatalf("N should step to the older match, got %d", home.previewSearch.currentLine())
+	}
+	if view := home.renderSelectedPreview(80, 30); !strings.Contains(view, "line-020 panic") {
+		t.Error("older match should be visible after N")
+	}
+	home.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if home.newDialog.IsVisible() || home.previewSearch.currentLine() != 119 {
+		t.Errorf("n should wrap to the newer match, got %d", home.previewSearch.currentLine())
+	}
+	home.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if !home.previewSearching || home.search.IsVisible() {
+		t.Error("/ should edit the preview query while a preview search is active")
+	}
+	home.handlePreviewSearchKey(tea.KeyMsg{Type: tea.KeyEsc})
+	home.handleMainKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if home.previewSearch.active() || !home.previewFollow {
+		t.Error("esc should end the preview search and resume follow")
+	}
+
+	// Submitting an empty query clears the search and resumes follow
+	home.startPreviewSearch()
+	home.previewSearchInput.SetValue("")
+	home.handlePreviewSearchKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if home.previewSearch.active() || !home.previewFollow {
+		t.Error("empty query should clear the search and resume follow")
+	}
+}
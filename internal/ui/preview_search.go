@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newPreviewSearchInput creates the query input shown in the preview header
+func newPreviewSearchInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "search preview"
+	ti.Prompt = "/"
+	ti.CharLimit = 100
+	return ti
+}
+
+// startPreviewSearch opens the query input for the selected session's preview
+func (h *Home) startPreviewSearch() tea.Cmd {
+	inst := h.getSelectedSession()
+	if inst == nil {
+		return nil
+	}
+	if h.previewSearchSessionID != inst.ID {
+		h.previewSearch = textSearch{}
+	}
+	h.previewSearchSessionID = inst.ID
+	h.previewSearching = true
+	h.previewSearchInput.SetValue(h.previewSearch.query)
+	h.previewSearchInput.CursorEnd()
+	return h.previewSearchInput.Focus()
+}
+
+// handlePreviewSearchKey handles keys while the preview search query is being typed
+func (h *Home) handlePreviewSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		h.previewSearching = false
+		h.previewSearchInput.Blur()
+		query := strings.TrimSpace(h.previewSearchInput.Value())
+		h.previewSearch.run(h.previewLines(h.previewSearchSessionID), query)
+		if !h.previewSearch.active() {
+			h.clearPreviewSearch()
+			return h, nil
+		}
+		h.scrollPreviewToLine(h.previewSearch.currentLine())
+		return h, nil
+	case "esc":
+		h.previewSearching = false
+		h.previewSearchInput.Blur()
+		return h, nil
+	}
+	var cmd tea.Cmd
+	h.previewSearchInput, cmd = h.previewSearchInput.Update(msg)
+	return h, cmd
+}
+
+// handlePreviewFocusKey gives the preview the log viewer's search keys while a
+// search is active for the selected session: / edits the query, n/N step through
+// matches and esc ends the search. Other keys fall through to the deck.
+func (h *Home) handlePreviewFocusKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	inst := h.getSelectedSession()
+	if inst == nil || h.previewSearchFor(inst.ID) == nil {
+		return h, nil, false
+	}
+	switch msg.String() {
+	case "/":
+		return h, h.startPreviewSearch(), true
+	case "n":
+		h.previewSearchStep(1)
+		return h, nil, true
+	case "N":
+		h.previewSearchStep(-1)
+		return h, nil, true
+	case "esc":
+		h.clearPreviewSearch()
+		return h, nil, true
+	}
+	return h, nil, false
+}
+
+// previewSearchStep moves to the next (+1, newer) or previous (-1, older) match
+// in the selected session's preview. Returns false if no search is active for it.
+func (h *Home) previewSearchStep(dir int) bool {
+	inst := h.getSelectedSession()
+	if inst == nil || !h.previewSearch.active() || h.previewSearchSessionID != inst.ID {
+		return false
+	}
+	// Output may have grown since the search ran
+	h.previewSearch.rerun(h.previewLines(inst.ID))
+	if dir > 0 {
+		h.scrollPreviewToLine(h.previewSearch.next())
+	} else {
+		h.scrollPreviewToLine(h.previewSearch.prev())
+	}
+	return true
+}
+
+// clearPreviewSearch drops the preview search and resumes following output
+func (h *Home) clearPreviewSearch() {
+	h.previewSearch = textSearch{}
+	h.previewSearchSessionID = ""
+	h.previewFollow = true
+	h.previewScroll = previewScrollState{}
+}
+
+// scrollPreviewToLine pauses follow and scrolls the selected preview so line is
+// roughly centered (line indexes are into previewLines).
+func (h *Home) scrollPreviewToLine(line int) {
+	inst := h.getSelectedSession()
+	if inst == nil || line < 0 {
+		return
+	}
+	total := h.previewLineCount(inst.ID)
+	offset := total - 1 - line - h.previewPageSize()
+	if offset < 0 {
+		offset = 0
+	}
+	h.previewFollow = false
+	h.previewScroll = previewScrollState{sessionID: inst.ID, offset: offset, base: total}
+}
+
+// previewSearchFor returns the active search for sessionID, or nil
+func (h *Home) previewSearchFor(sessionID string) *textSearch {
+	if !h.previewSearch.active() || h.previewSearchSessionID != sessionID {
+		return nil
+	}
+	return &h.previewSearch
+}
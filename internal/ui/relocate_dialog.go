@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// RelocateDialog offers new locations for a session whose project directory
+// no longer exists (moved or renamed repo). Opened by Enter or R on such a
+// session.
+type RelocateDialog struct {
+	visible       bool
+	width, height int
+	sessionID     string
+	title         string
+	oldPath       string
+	candidates    []session.Relocation
+	cursor        int
+}
+
+// NewRelocateDialog creates a new relocate dialog
+func NewRelocateDialog() *RelocateDialog {
+	return &RelocateDialog{}
+}
+
+// Show opens the dialog for inst with the directories found for it
+func (d *RelocateDialog) Show(inst *session.Instance, candidates []session.Relocation) {
+	d.visible = true
+	d.sessionID = inst.ID
+	d.title = inst.Title
+	d.oldPath = inst.ProjectPath
+	d.candidates = candidates
+	d.cursor = 0
+}
+
+// Hide closes the dialog
+func (d *RelocateDialog) Hide() {
+	d.visible = false
+	d.candidates = nil
+}
+
+// IsVisible returns whether the dialog is shown
+func (d *RelocateDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering
+func (d *RelocateDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// Selected returns the session ID and the highlighted path
+func (d *RelocateDialog) Selected() (sessionID, path string) {
+	if d.cursor >= len(d.candidates) {
+		return d.sessionID, ""
+	}
+	return d.sessionID, d.candidates[d.cursor].Path
+}
+
+// Update handles navigation keys
+func (d *RelocateDialog) Update(msg tea.KeyMsg) (*RelocateDialog, tea.Cmd) {
+	if n := len(d.candidates); n > 0 {
+		switch msg.String() {
+		case "j", "down":
+			d.cursor = (d.cursor + 1) % n
+		case "k", "up":
+			d.cursor = (d.cursor - 1 + n) % n
+		}
+	}
+	return d, nil
+}
+
+// View renders the dialog
+func (d *RelocateDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorYellow)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	selectedStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(ColorText)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+
+	lines := []string{
+		titleStyle.Render("📁  Project Moved?"),
+		dimStyle.Render(fmt.Sprintf("\"%s\": %s no longer exists", d.title, tildePath(d.oldPath))),
+		"",
+	}
+	for i, c := range d.candidates {
+		reason := dimStyle.Render("  " + c.Reason)
+		if i == d.cursor {
+			lines = append(lines, "> "+selectedStyle.Render(tildePath(c.Path))+reason)
+		} else {
+			lines = append(lines, "  "+normalStyle.Render(tildePath(c.Path))+reason)
+		}
+	}
+	lines = append(lines, "", footerStyle.Render("Enter relocate | Esc cancel | j/k navigate"))
+
+	dialogWidth := 64
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = d.width - 10
+		if dialogWidth < 30 {
+			dialogWidth = 30
+		}
+	}
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+	return centerInScreen(box, d.width, d.height)
+}
+
+// relocationsFoundMsg carries the directories found for a session whose
+// project path is missing
+type relocationsFoundMsg struct {
+	sessionID  string
+	candidates []session.Relocation
+}
+
+// findRelocations searches for a missing project directory in the background
+func (h *Home) findRelocations(inst *session.Instance) tea.Cmd {
+	h.instancesMu.RLock()
+	others := make([]*session.Instance, len(h.instances))
+	copy(others, h.instances)
+	h.instancesMu.RUnlock()
+	h.setError(fmt.Errorf("%s no longer exists, looking for it...", tildePath(inst.ProjectPath)))
+	return func() tea.Msg {
+		return relocationsFoundMsg{sessionID: inst.ID, candidates: session.FindRelocations(inst, others)}
+	}
+}
+
+// handleRelocationsFound opens the relocate dialog, or explains that nothing
+// was found
+func (h *Home) handleRelocationsFound(msg relocationsFoundMsg) {
+	inst := h.getInstanceByID(msg.sessionID)
+	if inst == nil {
+		return
+	}
+	if len(msg.candidates) == 0 {
+		h.setError(fmt.Errorf("%s no longer exists and no match was found; use 'agent-deck session relocate %q <path>'",
+			tildePath(inst.ProjectPath), inst.Title))
+		return
+	}
+	h.clearError()
+	h.relocateDialog.SetSize(h.width, h.height)
+	h.relocateDialog.Show(inst, msg.candidates)
+}
+
+// handleRelocateDialogKey handles keys while the relocate dialog is shown
+func (h *Home) handleRelocateDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		id, path := h.relocateDialog.Selected()
+		h.relocateDialog.Hide()
+		inst := h.getInstanceByID(id)
+		if inst == nil || path == "" {
+			return h, nil
+		}
+		if err := inst.Relocate(path); err != nil {
+			h.setError(err)
+			return h, nil
+		}
+		uiLog.Info("session_relocated", slog.String("id", inst.ID), slog.String("path", path))
+		h.invalidatePreviewCache(inst.ID)
+		h.rebuildFlatItems()
+		h.saveInstances()
+		h.setError(fmt.Errorf("relocated '%s' to %s. R to start it there", inst.Title, tildePath(path)))
+		return h, nil
+	case "esc":
+		h.relocateDialog.Hide()
+		return h, nil
+	}
+	h.relocateDialog.Update(msg)
+	return h, nil
+}
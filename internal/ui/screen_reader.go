@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// screenReaderMode renders a linear, glyph-free view and announces selection
+// changes as plain lines (see SetScreenReaderMode)
+var screenReaderMode bool
+
+// SetScreenReaderMode switches the TUI to its screen reader layout. Call
+// before creating Home; main.go also drops the alternate screen, so
+// announcements printed above the view stay in the terminal's history where
+// a screen reader reads them.
+func SetScreenReaderMode(on bool) {
+	screenReaderMode = on
+}
+
+// ScreenReaderMode reports whether the screen reader layout is active
+func ScreenReaderMode() bool {
+	return screenReaderMode
+}
+
+// describeItem returns a one-line spoken description of a list item,
+// e.g. "Session api-refactor, claude, waiting, in Work/Backend. 3 of 14"
+func (h *Home) describeItem(idx int) string {
+	if idx < 0 || idx >= len(h.flatItems) {
+		return "No sessions. Press n to create one."
+	}
+	item := h.flatItems[idx]
+	position := fmt.Sprintf("%d of %d", idx+1, len(h.flatItems))
+	switch item.Type {
+	case session.ItemTypeGroup:
+		if item.Group == nil {
+			break
+		}
+		state := "collapsed"
+		if item.Group.Expanded {
+			state = "expanded"
+		}
+		count := h.groupTree.SessionCountForGroup(item.Path)
+		return fmt.Sprintf("Group %s, %d sessions, %s. %s", item.Group.Name, count, state, position)
+	case session.ItemTypeSession:
+		inst := item.Session
+		if inst == nil {
+			break
+		}
+		parts := []string{"Session " + inst.Title}
+		if inst.Tool != "" {
+			parts = append(parts, inst.Tool)
+		}
+		parts = append(parts, string(inst.GetStatusThreadSafe()))
+		if inst.GroupPath != "" {
+			parts = append(parts, "in "+h.groupDisplayPath(inst.GroupPath))
+		}
+		return strings.Join(parts, ", ") + ". " + position
+	}
+	return position
+}
+
+// groupDisplayPath returns a group path using group names, e.g. "Work/Backend"
+func (h *Home) groupDisplayPath(path string) string {
+	segments := strings.Split(path, "/")
+	names := make([]string, len(segments))
+	for i := range segments {
+		prefix := strings.Join(segments[:i+1], "/")
+		names[i] = segments[i]
+		if g, ok := h.groupTree.Groups[prefix]; ok && g.Name != "" {
+			names[i] = g.Name
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+// renderScreenReaderView is the main screen in screen reader mode: a few
+// plain lines in reading order instead of panes, borders and icons
+func (h *Home) renderScreenReaderView() string {
+	running, waiting, idle, errored := h.countSessionStatuses()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Agent Deck, profile %s: %d running, %d waiting, %d idle, %d error.\n",
+		h.profile, running, waiting, idle, errored)
+	b.WriteString("Selected: " + h.describeItem(h.cursor) + "\n")
+	if inst := h.getSelectedSession(); inst != nil {
+		if inst.LatestPrompt != "" {
+			b.WriteString("Last prompt: " + inst.LatestPrompt + "\n")
+		}
+		b.WriteString("Path: " + inst.ProjectPath + "\n")
+	}
+	if h.err != nil {
+		b.WriteString("Error: " + h.err.Error() + "\n")
+	}
+	if tmux.Unresponsive() {
+		b.WriteString("Warning: tmux is not responding, statuses may be out of date.\n")
+	}
+	b.WriteString("Keys: up and down move, enter attach or toggle group, slash search, n new, question mark help, q quit")
+	return b.String()
+}
+
+// announceSelection returns a command printing the selection when it
+// changed since the last announcement
+func (h *Home) announceSelection() tea.Cmd {
+	if !screenReaderMode || h.initialLoading || h.isQuitting {
+		return nil
+	}
+	line := h.describeItem(h.cursor)
+	if line == h.lastAnnouncement {
+		return nil
+	}
+	h.lastAnnouncement = line
+	return tea.Println(line)
+}
+
+// stripBoxDrawing replaces box-drawing and icon glyphs in rendered output
+// with ASCII so screen readers don't read out "box drawings light horizontal"
+func stripBoxDrawing(s string) string {
+	return asciiGlyphs.Replace(s)
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestScreenReaderView(t *testing.T) {
+	SetScreenReaderMode(true)
+	t.Cleanup(func() { SetScreenReaderMode(false) })
+	home, work, _ := newFocusTestHome(t)
+
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession && item.Session.ID == work.ID {
+			home.cursor = i
+		}
+	}
+	desc := home.describeItem(home.cursor)
+	if !strings.HasPrefix(desc, "Session work-session,") || !strings.Contains(desc, "of 4") {
+		t.Errorf("describeItem = %q", desc)
+	}
+
+	view := home.View()
+	if !strings.Contains(view, "Selected: Session work-session") {
+		t.Errorf("view lacks the selection:\n%s", view)
+	}
+	for _, glyph := range []string{"─", "│", "╭", "▾"} {
+		if strings.Contains(view, glyph) {
+			t.Errorf("screen reader view contains %q", glyph)
+		}
+	}
+}
+
+func TestScreenReaderAnnouncesSelection(t *testing.T) {
+	SetScreenReaderMode(true)
+	t.Cleanup(func() { SetScreenReaderMode(false) })
+	home, _, _ := newFocusTestHome(t)
+	home.cursor = 0
+	home.lastAnnouncement = home.describeItem(0)
+
+	if _, cmd := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}); cmd == nil {
+		t.Fatal("moving the selection should announce it")
+	}
+	if home.lastAnnouncement != home.describeItem(1) {
+		t.Errorf("lastAnnouncement = %q", home.lastAnnouncement)
+	}
+	if cmd := home.announceSelection(); cmd != nil {
+		t.Error("an unchanged selection should not be announced again")
+	}
+}
+
+func TestStripBoxDrawing(t *testing.T) {
+	if got := stripBoxDrawing("╭──╮\n│ ▾ a • b │\n╰──╯"); got != "+--+\n| v a - b |\n+--+" {
+		t.Errorf("stripBoxDrawing = %q", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 var (
@@ -188,12 +189,22 @@ func (s *Search) View() string {
 		s.results = s.results[:maxResults]
 	}
 
+	// Wrap in overlay box - responsive width
+	overlayWidth := 60
+	if s.width > 0 && s.width < overlayWidth+10 {
+		overlayWidth = s.width - 10
+		if overlayWidth < 30 {
+			overlayWidth = 30
+		}
+	}
+
+	query := strings.ToLower(strings.TrimSpace(s.input.Value()))
 	for i, item := range s.results {
 		var line string
 		if i == s.cursor {
-			line = selectedResultStyle.Render("› " + item.Title + " (" + item.Tool + ")")
+			line = selectedResultStyle.Render(renderSearchResult(item, query, "› ", true, overlayWidth-8))
 		} else {
-			line = resultItemStyle.Render("  " + item.Title + " (" + item.Tool + ")")
+			line = resultItemStyle.Render(renderSearchResult(item, query, "  ", false, overlayWidth-8))
 		}
 		resultsStr.WriteString(line)
 		if i < len(s.results)-1 {
@@ -228,20 +239,92 @@ func (s *Search) View() string {
 		content = header + "\n\n" + searchBox + "\n\n" + resultsStr.String() + "\n" + countStr + "\n" + keysHint
 	}
 
-	// Wrap in overlay box - responsive width
-	overlayWidth := 60
-	if s.width > 0 && s.width < overlayWidth+10 {
-		overlayWidth = s.width - 10
-		if overlayWidth < 30 {
-			overlayWidth = 30
-		}
-	}
 	overlay := overlayStyle.Width(overlayWidth).Render(content)
 
 	// Center in the screen
 	return centerInScreen(overlay, s.width, s.height)
 }
 
+// renderSearchResult renders one result line with the matched text emphasized.
+// When the match isn't in the title, the matching field (path, tmux name or
+// ID) is shown after it, so it's clear why the session is listed.
+func renderSearchResult(item *session.Instance, query, prefix string, selected bool, width int) string {
+	base := lipgloss.NewStyle()
+	if selected {
+		base = base.Background(ColorAccent).Foreground(ColorBg)
+	}
+	field := session.MatchNone
+	if query != "" {
+		field = session.MatchQuery(item, query)
+	}
+
+	title := TruncateWidth(item.Title, width/2)
+	line := base.Render(prefix)
+	if field == session.MatchTitle {
+		line += highlightMatch(title, query, base)
+	} else {
+		line += base.Render(title)
+	}
+	line += base.Render(" (" + item.Tool + ")")
+
+	var label, value string
+	switch field {
+	case session.MatchPath:
+		label, value = "path", item.ProjectPath
+	case session.MatchTmux:
+		label, value = "tmux", item.TmuxName()
+	case session.MatchID:
+		label, value = "id", item.ID
+		if len(value) > 12 {
+			value = value[:12]
+		}
+	}
+	if value != "" {
+		room := width - runewidth.StringWidth(prefix+title+" ("+item.Tool+")  "+label+": ")
+		if room < 10 {
+			room = 10
+		}
+		if field == session.MatchPath {
+			value = shortenPathKeeping(value, query, room)
+		} else {
+			value = TruncateWidth(value, room)
+		}
+		labelStyle := base
+		if !selected {
+			labelStyle = labelStyle.Foreground(ColorComment)
+		}
+		line += base.Render("  ") + labelStyle.Render(label+": ") + highlightMatch(value, query, base)
+	}
+	return line
+}
+
+// highlightMatch renders text with base, emphasizing the first
+// case-insensitive occurrence of query
+func highlightMatch(text, query string, base lipgloss.Style) string {
+	idx := strings.Index(strings.ToLower(text), query)
+	// Lowercasing can change byte lengths for some scripts; skip the emphasis
+	// rather than slice mid-rune
+	if query == "" || idx < 0 || len(strings.ToLower(text)) != len(text) {
+		return base.Render(text)
+	}
+	end := idx + len(query)
+	emphasis := base.Bold(true).Underline(true)
+	if _, plain := base.GetBackground().(lipgloss.NoColor); plain {
+		emphasis = emphasis.Foreground(ColorYellow)
+	}
+	return base.Render(text[:idx]) + emphasis.Render(text[idx:end]) + base.Render(text[end:])
+}
+
+// shortenPathKeeping shortens path to width like ShortenPath, but falls back
+// to the plain ~ form when shortening would elide the matched part
+func shortenPathKeeping(path, query string, width int) string {
+	short := ShortenPath(path, width)
+	if strings.Contains(strings.ToLower(short), query) {
+		return short
+	}
+	return TruncateWidth(tildePath(path), width)
+}
+
 // formatCount formats the result count
 func formatCount(count int) string {
 	if count == 0 {
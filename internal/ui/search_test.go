@@ -1,9 +1,11 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
 )
 
 func TestNewSearch(t *testing.T) {
@@ -95,3 +97,30 @@ func TestSearchView(t *testing.T) {
 		t.Error("View should not be empty when visible")
 	}
 }
+
+func TestSearchShowsMatchedField(t *testing.T) {
+	s := NewSearch()
+	s.SetItems([]*session.Instance{
+		{ID: "3f9a2c10-0000", Title: "api", ProjectPath: "/srv/billing-service", Tool: "claude"},
+		{ID: "77aa0000-0000", Title: "web", ProjectPath: "/srv/frontend", Tool: "claude"},
+	})
+	s.SetSize(100, 40)
+	s.Show()
+	s.input.SetValue("billing")
+	s.updateResults()
+
+	if len(s.results) != 1 || s.results[0].Title != "api" {
+		t.Fatalf("results = %v, want only the session whose path matches", s.results)
+	}
+	view := tmux.StripANSI(s.View())
+	if !strings.Contains(view, "path: /srv/billing-service") {
+		t.Errorf("view should show the matched path:\n%s", view)
+	}
+
+	s.input.SetValue("77aa")
+	s.updateResults()
+	view = tmux.StripANSI(s.View())
+	if len(s.results) != 1 || !strings.Contains(view, "id: 77aa0000-000") {
+		t.Errorf("ID prefix search should list web with its ID:\n%s", view)
+	}
+}
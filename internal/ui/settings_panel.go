@@ -30,10 +30,11 @@ const (
 	SettingShowOutput
 	SettingShowAnalytics
 	SettingMaintenanceEnabled
+	SettingDesktopNotifications
 )
 
 // Total number of navigable settings
-const settingsCount = 17
+const settingsCount = 18
 
 // SettingsPanel displays and edits user configuration
 type SettingsPanel struct {
@@ -61,6 +62,7 @@ type SettingsPanel struct {
 	showOutput          bool
 	showAnalytics       bool
 	maintenanceEnabled  bool
+	desktopAlerts       bool
 
 	// Text input state
 	editingText bool
@@ -201,6 +203,9 @@ func (s *SettingsPanel) LoadConfig(config *session.UserConfig) {
 
 	// Maintenance settings
 	s.maintenanceEnabled = config.Maintenance.Enabled
+
+	// Notification settings
+	s.desktopAlerts = config.Notifications.GetChannel() == session.NotifyDesktop
 }
 
 // GetConfig returns a UserConfig with current panel values
@@ -262,6 +267,22 @@ func (s *SettingsPanel) GetConfig() *session.UserConfig {
 		config.MCPs = s.originalConfig.MCPs
 		config.Tools = s.originalConfig.Tools
 		config.MCPPool = s.originalConfig.MCPPool
+		config.Notifications = s.originalConfig.Notifications
+		config.Webhooks = s.originalConfig.Webhooks
+		config.Templates = s.originalConfig.Templates
+		config.Unpushed = s.originalConfig.Unpushed
+		config.Backup = s.originalConfig.Backup
+		config.Hooks = s.originalConfig.Hooks
+		config.TeamDecks = s.originalConfig.TeamDecks
+	}
+
+	// Notification settings: the toggle only switches the default channel
+	// between desktop and none, keeping webhook setups and group overrides
+	wasDesktop := config.Notifications.GetChannel() == session.NotifyDesktop
+	if s.desktopAlerts {
+		config.Notifications.Channel = session.NotifyDesktop
+	} else if wasDesktop {
+		config.Notifications.Channel = session.NotifyNone
 	}
 
 	return config
@@ -418,6 +439,10 @@ func (s *SettingsPanel) toggleValue() bool {
 	case SettingMaintenanceEnabled:
 		s.maintenanceEnabled = !s.maintenanceEnabled
 		return true
+
+	case SettingDesktopNotifications:
+		s.desktopAlerts = !s.desktopAlerts
+		return true
 	}
 
 	return false
@@ -674,6 +699,16 @@ func (s *SettingsPanel) View() string {
 	}
 	content.WriteString("  " + labelStyle.Render(line) + "\n\n")
 
+	// NOTIFICATIONS
+	content.WriteString(sectionStyle.Render("NOTIFICATIONS"))
+	content.WriteString("\n")
+
+	line = s.renderCheckbox("Desktop alerts", s.desktopAlerts) + " - Notify when a session starts waiting"
+	if s.cursor == int(SettingDesktopNotifications) {
+		line = highlightStyle.Render(line)
+	}
+	content.WriteString("  " + labelStyle.Render(line) + "\n\n")
+
 	// MCP & TOOLS
 	content.WriteString(sectionStyle.Render("MCP SERVERS & CUSTOM TOOLS"))
 	content.WriteString("\n")
@@ -718,6 +753,7 @@ func (s *SettingsPanel) View() string {
 			34, // SettingShowOutput
 			35, // SettingShowAnalytics
 			38, // SettingMaintenanceEnabled
+			41, // SettingDesktopNotifications
 		}
 		cursorLine := cursorToLine[s.cursor]
 
@@ -727,3 +727,44 @@ func TestSettingsPanel_PreviewSettings_ViewContains(t *testing.T) {
 		}
 	}
 }
+
+func TestSettingsPanel_DesktopNotifications(t *testing.T) {
+	panel := NewSettingsPanel()
+	original := &session.UserConfig{
+		Notifications: session.NotificationsConfig{
+			MaxShown:           4,
+			NotificationPolicy: session.NotificationPolicy{EscalateAfterMinutes: 10},
+		},
+	}
+	panel.LoadConfig(original)
+	panel.originalConfig = original
+	if panel.desktopAlerts {
+		t.Fatal("desktopAlerts should be false without channel = desktop")
+	}
+
+	panel.cursor = int(SettingDesktopNotifications)
+	if !panel.toggleValue() {
+		t.Fatal("toggleValue should report a change")
+	}
+	config := panel.GetConfig()
+	if config.Notifications.Channel != session.NotifyDesktop {
+		t.Errorf("Channel = %q, want desktop", config.Notifications.Channel)
+	}
+	if config.Notifications.MaxShown != 4 || config.Notifications.EscalateAfterMinutes != 10 {
+		t.Errorf("other [notifications] keys should be kept, got %+v", config.Notifications)
+	}
+
+	panel.toggleValue()
+	if got := panel.GetConfig().Notifications.GetChannel(); got != session.NotifyNone {
+		t.Errorf("Channel after toggling off = %q, want none", got)
+	}
+
+	webhook := &session.UserConfig{
+		Notifications: session.NotificationsConfig{NotificationPolicy: session.NotificationPolicy{Channel: session.NotifyWebhook}},
+	}
+	panel.LoadConfig(webhook)
+	panel.originalConfig = webhook
+	if got := panel.GetConfig().Notifications.Channel; got != session.NotifyWebhook {
+		t.Errorf("webhook channel should be left alone, got %q", got)
+	}
+}
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// SnapshotBrowser lists a session's saved pane snapshots (B). Enter opens
+// one in the pager, D diffs it against the pane now, d deletes it.
+type SnapshotBrowser struct {
+	visible       bool
+	width, height int
+	sessionID     string
+	title         string
+	snapshots     []session.Snapshot
+	cursor        int
+}
+
+// NewSnapshotBrowser creates a new snapshot browser
+func NewSnapshotBrowser() *SnapshotBrowser {
+	return &SnapshotBrowser{}
+}
+
+// Show opens the browser for inst with its snapshots, newest first
+func (b *SnapshotBrowser) Show(inst *session.Instance, snapshots []session.Snapshot) {
+	b.visible = true
+	b.sessionID = inst.ID
+	b.title = inst.Title
+	b.snapshots = snapshots
+	b.cursor = 0
+}
+
+// Hide closes the browser
+func (b *SnapshotBrowser) Hide() {
+	b.visible = false
+	b.snapshots = nil
+}
+
+// IsVisible returns whether the browser is shown
+func (b *SnapshotBrowser) IsVisible() bool {
+	return b.visible
+}
+
+// SetSize updates the browser dimensions for centering
+func (b *SnapshotBrowser) SetSize(w, h int) {
+	b.width = w
+	b.height = h
+}
+
+// Selected returns the highlighted snapshot, or nil when there are none
+func (b *SnapshotBrowser) Selected() *session.Snapshot {
+	if b.cursor >= len(b.snapshots) {
+		return nil
+	}
+	return &b.snapshots[b.cursor]
+}
+
+// remove drops the highlighted snapshot from the list
+func (b *SnapshotBrowser) remove() {
+	if b.cursor >= len(b.snapshots) {
+		return
+	}
+	b.snapshots = append(b.snapshots[:b.cursor], b.snapshots[b.cursor+1:]...)
+	if b.cursor > 0 && b.cursor >= len(b.snapshots) {
+		b.cursor--
+	}
+}
+
+// Update handles navigation keys
+func (b *SnapshotBrowser) Update(msg tea.KeyMsg) (*SnapshotBrowser, tea.Cmd) {
+	if n := len(b.snapshots); n > 0 {
+		switch msg.String() {
+		case "j", "down":
+			b.cursor = (b.cursor + 1) % n
+		case "k", "up":
+			b.cursor = (b.cursor - 1 + n) % n
+		}
+	}
+	return b, nil
+}
+
+// View renders the browser
+func (b *SnapshotBrowser) View() string {
+	if !b.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	selectedStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(ColorText)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+
+	lines := []string{titleStyle.Render("📸  Snapshots: " + b.title), ""}
+	if len(b.snapshots) == 0 {
+		lines = append(lines, dimStyle.Render("No snapshots yet. Press b on the session to take one."))
+	}
+	// Keep the list inside the screen; the cursor stays visible
+	maxRows := b.height - 10
+	if maxRows < 3 {
+		maxRows = 3
+	}
+	start := 0
+	if b.cursor >= maxRows {
+		start = b.cursor - maxRows + 1
+	}
+	for i := start; i < len(b.snapshots) && i < start+maxRows; i++ {
+		s := b.snapshots[i]
+		meta := dimStyle.Render(fmt.Sprintf("  %s · %d KB", s.CreatedAt.Format("Jan 2 15:04"), (s.Size+1023)/1024))
+		if i == b.cursor {
+			lines = append(lines, "> "+selectedStyle.Render(s.Name)+meta)
+		} else {
+			lines = append(lines, "  "+normalStyle.Render(s.Name)+meta)
+		}
+	}
+	lines = append(lines, "", footerStyle.Render("Enter view | D diff with now | d delete | Esc close"))
+
+	dialogWidth := 64
+	if b.width > 0 && b.width < dialogWidth+10 {
+		dialogWidth = b.width - 10
+		if dialogWidth < 30 {
+			dialogWidth = 30
+		}
+	}
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+	return centerInScreen(box, b.width, b.height)
+}
+
+// snapshotSavedMsg is sent when a pane snapshot has been written
+type snapshotSavedMsg struct {
+	sessionTitle string
+	snapshot     *session.Snapshot
+	err          error
+}
+
+// snapshotsListedMsg carries a session's snapshots for the browser
+type snapshotsListedMsg struct {
+	sessionID string
+	snapshots []session.Snapshot
+	err       error
+}
+
+// snapshotDiffMsg carries the diff of a snapshot against the current pane
+type snapshotDiffMsg struct {
+	sessionTitle string
+	snapshotName string
+	diff         string
+	added        int
+	err          error
+}
+
+// diffSnapshot compares a snapshot with the session's pane now
+func (h *Home) diffSnapshot(inst *session.Instance, snap session.Snapshot) tea.Cmd {
+	title := inst.Title
+	return func() tea.Msg {
+		diff, added, err := session.DiffSnapshot(inst, &snap)
+		return snapshotDiffMsg{sessionTitle: title, snapshotName: snap.Name, diff: diff, added: added, err: err}
+	}
+}
+
+// takeSnapshot saves the session's visible pane as a named snapshot
+func (h *Home) takeSnapshot(inst *session.Instance, name string) tea.Cmd {
+	title := inst.Title
+	return func() tea.Msg {
+		snap, err := session.SaveSnapshot(inst, name, false, time.Now())
+		return snapshotSavedMsg{sessionTitle: title, snapshot: snap, err: err}
+	}
+}
+
+// listSnapshots loads the session's snapshots for the browser
+func (h *Home) listSnapshots(inst *session.Instance) tea.Cmd {
+	id := inst.ID
+	return func() tea.Msg {
+		snaps, err := session.ListSnapshots(id)
+		return snapshotsListedMsg{sessionID: id, snapshots: snaps, err: err}
+	}
+}
+
+// handleSnapshotBrowserKey handles keys while the snapshot browser is shown
+func (h *Home) handleSnapshotBrowserKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		snap := h.snapshotBrowser.Selected()
+		if snap == nil {
+			return h, nil
+		}
+		content, err := snap.Content()
+		if err != nil {
+			h.setError(fmt.Errorf("snapshot: %w", err))
+			return h, nil
+		}
+		title := fmt.Sprintf("Snapshot: %s · %s (%s)", h.snapshotBrowser.title, snap.Name, snap.CreatedAt.Format("Jan 2 15:04"))
+		h.snapshotBrowser.Hide()
+		h.pagerOverlay.SetSize(h.width, h.height)
+		h.pagerOverlay.Show(
+			title,
+			strings.TrimRight(content, "\n"),
+			h.highlighter.styler(),
+		)
+		return h, nil
+	case "D", "=":
+		snap := h.snapshotBrowser.Selected()
+		inst := h.getInstanceByID(h.snapshotBrowser.sessionID)
+		if snap == nil || inst == nil {
+			return h, nil
+		}
+		if !inst.Exists() {
+			h.setError(fmt.Errorf("'%s' is not running, nothing to compare with", inst.Title))
+			return h, nil
+		}
+		h.snapshotBrowser.Hide()
+		return h, h.diffSnapshot(inst, *snap)
+	case "d":
+		if snap := h.snapshotBrowser.Selected(); snap != nil {
+			if err := snap.Delete(); err != nil {
+				h.setError(fmt.Errorf("snapshot: %w", err))
+				return h, nil
+			}
+			h.snapshotBrowser.remove()
+		}
+		return h, nil
+	case "esc", "q", "B":
+		h.snapshotBrowser.Hide()
+		return h, nil
+	}
+	h.snapshotBrowser.Update(msg)
+	return h, nil
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// splitPreviewMinHeight is the smallest preview height that still fits two stacked panes
+const splitPreviewMinHeight = 12
+
+// splitPreviewFetchedMsg carries async preview content for the split (secondary) session.
+// Kept separate from previewFetchedMsg so it doesn't clear the primary in-flight marker.
+type splitPreviewFetchedMsg struct {
+	sessionID string
+	content   string
+	err       error
+}
+
+// toggleSplitMark marks the session as the secondary preview, or clears the mark
+// if it is already marked. Returns true if a split session is now marked.
+func (h *Home) toggleSplitMark(inst *session.Instance) bool {
+	if inst == nil {
+		return false
+	}
+	if h.splitSessionID == inst.ID {
+		h.splitSessionID = ""
+		return false
+	}
+	h.splitSessionID = inst.ID
+	return true
+}
+
+// getSplitSession returns the marked secondary session, or nil if none is marked
+// or the marked session no longer exists.
+func (h *Home) getSplitSession() *session.Instance {
+	if h.splitSessionID == "" {
+		return nil
+	}
+	return h.getInstanceByID(h.splitSessionID)
+}
+
+// fetchSplitPreview returns a command that asynchronously captures the split session's pane
+func (h *Home) fetchSplitPreview(inst *session.Instance) tea.Cmd {
+	if inst == nil {
+		return nil
+	}
+	sessionID := inst.ID
+	return func() tea.Msg {
+		content, err := inst.PreviewFull()
+		return splitPreviewFetchedMsg{
+			sessionID: sessionID,
+			content:   content,
+			err:       err,
+		}
+	}
+}
+
+// renderPreviewPane renders the preview area. When a split session is marked and differs
+// from the selection, the selected session is shown on top and the marked one below.
+func (h *Home) renderPreviewPane(width, height int) string {
+	split := h.getSplitSession()
+	selected := h.getSelectedSession()
+	if split == nil || selected == nil || selected.ID == split.ID || height < splitPreviewMinHeight {
+		return h.renderSelectedPreview(width, height)
+	}
+
+	topHeight := height / 2
+	bottomHeight := height - topHeight
+	top := ensureExactHeight(h.renderSelectedPreview(width, topHeight), topHeight)
+	bottom := ensureExactHeight(h.renderSplitPane(split, width, bottomHeight), bottomHeight)
+	return top + "\n" + bottom
+}
+
+// renderSplitPane renders the compact output view for the marked secondary session
+func (h *Home) renderSplitPane(inst *session.Instance, width, height int) string {
+	var b strings.Builder
+
+	statusIcon := "○"
+	statusColor := ColorTextDim
+	switch inst.GetStatusThreadSafe() {
+	case session.StatusRunning:
+		statusIcon = "●"
+		statusColor = ColorGreen
+	case session.StatusWaiting:
+		statusIcon = "◐"
+		statusColor = ColorYellow
+	case session.StatusError:
+		statusIcon = "✕"
+		statusColor = ColorRed
+	}
+	label := fmt.Sprintf("Split: %s", inst.Title)
+	b.WriteString(renderSectionDivider(label, width-4))
+	b.WriteString(" ")
+	b.WriteString(lipgloss.NewStyle().Foreground(statusColor).Render(statusIcon))
+	b.WriteString("\n")
+
+	h.previewCacheMu.RLock()
+	preview, hasCached := h.previewCache[inst.ID]
+	h.previewCacheMu.RUnlock()
+
+	dimStyle := lipgloss.NewStyle().Foreground(ColorText).Italic(true)
+	if !hasCached {
+		b.WriteString(dimStyle.Render("Loading preview..."))
+		return b.String()
+	}
+
+	lines := strings.Split(preview, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		b.WriteString(dimStyle.Render("(terminal is empty)"))
+		return b.String()
+	}
+
+	// Show the most recent output (tail), one line reserved for the header
+	maxLines := height - 1
+	if maxLines < 1 {
+		maxLines = 1
+	}
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+
+	maxWidth := width - 4
+	if maxWidth < 10 {
+		maxWidth = 10
+	}
+	previewStyle := lipgloss.NewStyle().Foreground(ColorText)
+	for i, line := range lines {
+		cleanLine := tmux.StripANSI(line)
+		if runewidth.StringWidth(cleanLine) > maxWidth {
+			cleanLine = runewidth.Truncate(cleanLine, maxWidth-3, "...")
+		}
+		b.WriteString(previewStyle.Render(cleanLine))
+		if i < len(lines)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
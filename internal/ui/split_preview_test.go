@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestSplitPreviewToggle(t *testing.T) {
+	home := NewHome()
+	inst := session.NewInstance("build", "/tmp/build")
+
+	if !home.toggleSplitMark(inst) {
+		t.Fatal("first toggle should mark the session")
+	}
+	if home.splitSessionID != inst.ID {
+		t.Errorf("splitSessionID = %q, want %q", home.splitSessionID, inst.ID)
+	}
+	if home.toggleSplitMark(inst) {
+		t.Fatal("second toggle should clear the mark")
+	}
+	if home.splitSessionID != "" {
+		t.Errorf("splitSessionID = %q, want empty", home.splitSessionID)
+	}
+}
+
+func TestSplitPreviewRendersBothSessions(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 40
+
+	chat := session.NewInstance("chat-agent", "/tmp/chat")
+	build := session.NewInstance("build-watch", "/tmp/build")
+	home.instancesMu.Lock()
+	home.instances = []*session.Instance{chat, build}
+	home.instanceByID = map[string]*session.Instance{chat.ID: chat, build.ID: build}
+	home.instancesMu.Unlock()
+	home.groupTree = session.NewGroupTree(home.instances)
+	home.rebuildFlatItems()
+
+	home.previewCache[build.ID] = "compiling...\nBUILD OK"
+	home.previewCacheTime[build.ID] = time.Now()
+
+	// Mark build as split, then select chat
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession && item.Session.ID == build.ID {
+			home.cursor = i
+		}
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession && item.Session.ID == chat.ID {
+			home.cursor = i
+		}
+	}
+
+	out := home.renderPreviewPane(80, 30)
+	if !strings.Contains(out, "chat-agent") {
+		t.Error("split preview should show the selected session on top")
+	}
+	if !strings.Contains(out, "Split: build-watch") || !strings.Contains(out, "BUILD OK") {
+		t.Error("split preview should show the marked session's output below")
+	}
+	if got := strings.Count(out, "\n") + 1; got != 30 {
+		t.Errorf("split preview height = %d lines, want 30", got)
+	}
+
+	// Selecting the marked session itself falls back to the single preview
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession && item.Session.ID == build.ID {
+			home.cursor = i
+		}
+	}
+	if out := home.renderPreviewPane(80, 30); strings.Contains(out, "Split:") {
+		t.Error("split pane should not be shown when the marked session is selected")
+	}
+}
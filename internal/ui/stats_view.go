@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+const (
+	// statsDays and statsWeeks are how far back the daily and weekly breakdowns go
+	statsDays  = 14
+	statsWeeks = 8
+	// statsListLimit caps the group and model breakdowns
+	statsListLimit = 12
+)
+
+// statsBreakdowns are the views the stats overlay cycles through with tab
+var statsBreakdowns = []string{"Daily", "Weekly", "By group", "By model"}
+
+// costsCollectedMsg carries the cost summary computed by collectCosts
+type costsCollectedMsg struct {
+	summary session.CostSummary
+}
+
+// StatsView shows estimated API spend across all claude and aider sessions
+type StatsView struct {
+	visible   bool
+	loading   bool
+	width     int
+	height    int
+	summary   session.CostSummary
+	breakdown int // index into statsBreakdowns
+}
+
+// NewStatsView creates a new stats overlay
+func NewStatsView() *StatsView {
+	return &StatsView{}
+}
+
+// Show displays the overlay in a loading state until the summary arrives
+func (s *StatsView) Show() {
+	s.visible = true
+	s.loading = true
+}
+
+// Hide hides the overlay
+func (s *StatsView) Hide() {
+	s.visible = false
+}
+
+// IsVisible returns whether the overlay is visible
+func (s *StatsView) IsVisible() bool {
+	return s.visible
+}
+
+// SetSize sets the dimensions for the overlay
+func (s *StatsView) SetSize(width, height int) {
+	s.width = width
+	s.height = height
+}
+
+// SetSummary replaces the overlay's figures
+func (s *StatsView) SetSummary(summary session.CostSummary) {
+	s.summary = summary
+	s.loading = false
+}
+
+// View renders the stats overlay
+func (s *StatsView) View() string {
+	if !s.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	labelStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	valueStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorGreen)
+	tabStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	activeTabStyle := lipgloss.NewStyle().Foreground(ColorCyan).Bold(true).Underline(true)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+
+	dialogWidth := s.width - 8
+	if dialogWidth > 90 {
+		dialogWidth = 90
+	}
+	if dialogWidth < 40 {
+		dialogWidth = 40
+	}
+	contentWidth := dialogWidth - 6
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Estimated API Spend"))
+	b.WriteString("\n\n")
+
+	if s.loading {
+		b.WriteString(labelStyle.Render("Reading session transcripts..."))
+		b.WriteString("\n\n")
+	} else {
+		totals := []struct {
+			label string
+			cost  float64
+		}{
+			{"Today", s.summary.Today},
+			{"This week", s.summary.Week},
+			{"30 days", s.summary.Month},
+			{"All time", s.summary.Total},
+		}
+		for i, t := range totals {
+			if i > 0 {
+				b.WriteString("   ")
+			}
+			b.WriteString(labelStyle.Render(t.label + " "))
+			b.WriteString(valueStyle.Render(formatCost(t.cost)))
+		}
+		b.WriteString("\n")
+		b.WriteString(labelStyle.Render(fmt.Sprintf("%d sessions with spend", s.summary.Sessions)))
+		b.WriteString("\n\n")
+
+		for i, name := range statsBreakdowns {
+			if i > 0 {
+				b.WriteString(tabStyle.Render(" │ "))
+			}
+			if i == s.breakdown {
+				b.WriteString(activeTabStyle.Render(name))
+			} else {
+				b.WriteString(tabStyle.Render(name))
+			}
+		}
+		b.WriteString("\n\n")
+		b.WriteString(renderCostBars(s.currentBuckets(), contentWidth))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(footerStyle.Render("tab/←→ breakdown • r refresh • esc close • prices: [pricing] in config.toml"))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(b.String())
+	return centerInScreen(box, s.width, s.height)
+}
+
+// currentBuckets returns the selected breakdown's rows
+func (s *StatsView) currentBuckets() []session.CostBucket {
+	var buckets []session.CostBucket
+	switch s.breakdown {
+	case 0:
+		buckets = s.summary.ByDay
+	case 1:
+		buckets = s.summary.ByWeek
+	case 2:
+		buckets = s.summary.ByGroup
+	case 3:
+		buckets = s.summary.ByModel
+	}
+	if len(buckets) > statsListLimit && s.breakdown >= 2 {
+		buckets = buckets[:statsListLimit]
+	}
+	return buckets
+}
+
+// renderCostBars renders buckets as labelled horizontal bars scaled to the largest
+func renderCostBars(buckets []session.CostBucket, width int) string {
+	dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	barStyle := lipgloss.NewStyle().Foreground(ColorAccent)
+	if len(buckets) == 0 {
+		return dimStyle.Render("No claude or aider spend recorded") + "\n"
+	}
+
+	labelWidth := 0
+	maxCost := 0.0
+	for _, bucket := range buckets {
+		if w := lipgloss.Width(bucket.Label); w > labelWidth {
+			labelWidth = w
+		}
+		if bucket.Cost > maxCost {
+			maxCost = bucket.Cost
+		}
+	}
+	if labelWidth > width/2 {
+		labelWidth = width / 2
+	}
+	const costWidth = 10
+	barWidth := width - labelWidth - costWidth - 2
+	if barWidth < 5 {
+		barWidth = 5
+	}
+
+	var b strings.Builder
+	for _, bucket := range buckets {
+		n := 0
+		if maxCost > 0 {
+			n = int(bucket.Cost / maxCost * float64(barWidth))
+		}
+		if n == 0 && bucket.Cost > 0 {
+			n = 1
+		}
+		b.WriteString(PadWidth(TruncateWidth(bucket.Label, labelWidth), labelWidth))
+		b.WriteString(" ")
+		b.WriteString(barStyle.Render(strings.Repeat("█", n)))
+		b.WriteString(strings.Repeat(" ", barWidth-n))
+		b.WriteString(" ")
+		b.WriteString(fmt.Sprintf("%*s", costWidth-1, formatCost(bucket.Cost)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// formatCost formats USD, with cents below $1000
+func formatCost(cost float64) string {
+	if cost >= 1000 {
+		return fmt.Sprintf("$%.0f", cost)
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
+
+// collectCosts returns a tea.Cmd that reads every session's transcript and
+// summarizes the estimated spend
+func (h *Home) collectCosts() tea.Cmd {
+	h.instancesMu.RLock()
+	instances := make([]*session.Instance, len(h.instances))
+	copy(instances, h.instances)
+	h.instancesMu.RUnlock()
+
+	return func() tea.Msg {
+		entries := session.CollectCosts(instances, session.GetPricingOverrides())
+		return costsCollectedMsg{summary: session.SummarizeCosts(entries, time.Now(), statsDays, statsWeeks)}
+	}
+}
+
+// handleStatsKey handles keys while the stats overlay is open
+func (h *Home) handleStatsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	stats := h.statsView
+	switch msg.String() {
+	case "esc", "q", "C":
+		stats.Hide()
+	case "tab", "right", "l":
+		stats.breakdown = (stats.breakdown + 1) % len(statsBreakdowns)
+	case "shift+tab", "left", "h":
+		stats.breakdown = (stats.breakdown + len(statsBreakdowns) - 1) % len(statsBreakdowns)
+	case "r":
+		stats.loading = true
+		return h, h.collectCosts()
+	}
+	return h, nil
+}
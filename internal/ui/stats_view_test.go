@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestStatsView(t *testing.T) {
+	stats := NewStatsView()
+	stats.SetSize(120, 40)
+	stats.Show()
+	if !strings.Contains(stats.View(), "Reading session transcripts") {
+		t.Error("stats should show a loading state before the summary arrives")
+	}
+
+	stats.SetSummary(session.CostSummary{
+		Today: 1.5, Week: 4, Month: 12.25, Total: 1234,
+		ByDay:   []session.CostBucket{{Label: "Mon Jun 10", Cost: 2.5}, {Label: "Tue Jun 11", Cost: 0}},
+		ByGroup: []session.CostBucket{{Label: "clients/acme", Cost: 9}},
+	})
+	view := stats.View()
+	for _, want := range []string{"Today", "$1.50", "$12.25", "$1234", "Mon Jun 10", "$2.50", "█"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	stats.breakdown = 2
+	if !strings.Contains(stats.View(), "clients/acme") {
+		t.Error("group breakdown should list groups")
+	}
+	stats.breakdown = 3
+	if !strings.Contains(stats.View(), "No claude or aider spend") {
+		t.Error("empty breakdown should say so")
+	}
+}
+
+func TestStatsViewKeys(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 40
+
+	_, cmd := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if !home.statsView.IsVisible() || cmd == nil {
+		t.Fatal("C should open the stats view and collect costs")
+	}
+	home.Update(cmd())
+	if home.statsView.loading {
+		t.Error("collected costs should end the loading state")
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if home.statsView.breakdown != 1 {
+		t.Errorf("tab should select the next breakdown, got %d", home.statsView.breakdown)
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	home.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if home.statsView.breakdown != len(statsBreakdowns)-1 {
+		t.Errorf("shift+tab should wrap to the last breakdown, got %d", home.statsView.breakdown)
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if home.statsView.IsVisible() {
+		t.Error("esc should close the stats view")
+	}
+}
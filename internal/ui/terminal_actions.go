@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+	"github.com/asheshgoplani/agent-deck/internal/terminal"
+)
+
+// terminalActionMsg is sent after trying to open a new terminal window or tab
+type terminalActionMsg struct {
+	action   string // Human-readable description, e.g. "Opened 'api' in a new kitty window"
+	emulator string
+	err      error
+}
+
+// editorArgv returns the argv for opening dir in the user's editor ($VISUAL, $EDITOR, then vi).
+// The editor variable may contain flags, e.g. "code --wait".
+func editorArgv(dir string) []string {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	return append(strings.Fields(editor), dir)
+}
+
+// attachInNewWindow opens the session in a new terminal window, leaving the TUI running
+func (h *Home) attachInNewWindow(inst *session.Instance) tea.Cmd {
+	if session.IsDemoMode() {
+		h.setError(errDemoAttach)
+		return nil
+	}
+	tmuxSess := inst.GetTmuxSession()
+	if tmuxSess == nil {
+		return nil
+	}
+	adapter, err := terminal.Get(session.GetTerminalSettings().Emulator)
+	if err != nil {
+		h.setError(err)
+		return nil
+	}
+	title := inst.Title
+
+	// Same bookkeeping as an in-place attach
+	tmuxSess.EnsureConfigured()
+	inst.SyncSessionIDsToTmux()
+	inst.MarkAccessed()
+	if inst.GetStatusThreadSafe() == session.StatusWaiting {
+		tmuxSess.Acknowledge()
+		if db := statedb.GetGlobal(); db != nil {
+			_ = db.SetAcknowledged(inst.ID, true)
+		}
+	}
+
+	argv := tmuxSess.AttachArgv()
+	return func() tea.Msg {
+		msg := terminalActionMsg{emulator: adapter.Name()}
+		// The new window's detach isn't seen here, so only the pre-attach hook
+		// runs; like an in-place attach, a failure is logged and doesn't block
+		_ = inst.RunPreAttachHook(io.Discard)
+		if msg.err = terminal.OpenWindow(adapter, argv, title); msg.err == nil {
+			msg.action = fmt.Sprintf("Opened '%s' in a new %s window", title, adapter.Name())
+			uiLog.Debug("attach_new_window", slog.String("title", title), slog.String("emulator", adapter.Name()))
+		}
+		return msg
+	}
+}
+
+// openEditorInNewTab opens the session's project directory in the user's editor in a new terminal tab
+func (h *Home) openEditorInNewTab(inst *session.Instance) tea.Cmd {
+	adapter, err := terminal.Get(session.GetTerminalSettings().Emulator)
+	if err != nil {
+		h.setError(err)
+		return nil
+	}
+	title := inst.Title
+	argv := editorArgv(inst.ProjectPath)
+	return func() tea.Msg {
+		msg := terminalActionMsg{emulator: adapter.Name()}
+		if msg.err = terminal.OpenTab(adapter, argv, "edit: "+title); msg.err == nil {
+			msg.action = fmt.Sprintf("Opened editor for '%s' in %s", title, adapter.Name())
+		}
+		return msg
+	}
+}
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditorArgv(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := editorArgv("/proj"); !reflect.DeepEqual(got, []string{"vi", "/proj"}) {
+		t.Errorf("default editor = %v", got)
+	}
+
+	t.Setenv("EDITOR", "code --wait")
+	if got := editorArgv("/proj"); !reflect.DeepEqual(got, []string{"code", "--wait", "/proj"}) {
+		t.Errorf("EDITOR with flags = %v", got)
+	}
+
+	t.Setenv("VISUAL", "nvim")
+	if got := editorArgv("/proj"); !reflect.DeepEqual(got, []string{"nvim", "/proj"}) {
+		t.Errorf("VISUAL should win over EDITOR, got %v", got)
+	}
+}
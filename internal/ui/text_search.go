@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// textSearch finds case-insensitive substring matches in captured output lines.
+// Shared by the log viewer (pager) and the preview pane.
+type textSearch struct {
+	query   string
+	re      *regexp.Regexp
+	matches []int // Line indexes containing the query, ascending
+	current int   // Index into matches of the focused match
+}
+
+// run searches lines for query and focuses the last (newest) match.
+// An empty query clears the search.
+func (s *textSearch) run(lines []string, query string) {
+	*s = textSearch{}
+	if query == "" {
+		return
+	}
+	s.query = query
+	s.re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+	s.find(lines)
+	s.current = len(s.matches) - 1
+}
+
+// rerun refreshes matches against updated lines, keeping the focused match's
+// position counted from the newest match (new output only appears at the bottom).
+func (s *textSearch) rerun(lines []string) {
+	if !s.active() {
+		return
+	}
+	fromEnd := len(s.matches) - 1 - s.current
+	s.find(lines)
+	s.current = len(s.matches) - 1 - fromEnd
+	if s.current < 0 {
+		s.current = 0
+	}
+}
+
+func (s *textSearch) find(lines []string) {
+	s.matches = s.matches[:0]
+	for i, line := range lines {
+		if s.re.MatchString(line) {
+			s.matches = append(s.matches, i)
+		}
+	}
+}
+
+// active reports whether a search query is set
+func (s *textSearch) active() bool {
+	return s.re != nil
+}
+
+// currentLine returns the line index of the focused match, or -1 if none
+func (s *textSearch) currentLine() int {
+	if len(s.matches) == 0 || s.current < 0 || s.current >= len(s.matches) {
+		return -1
+	}
+	return s.matches[s.current]
+}
+
+// next focuses the following match (wrapping) and returns its line index
+func (s *textSearch) next() int {
+	if len(s.matches) == 0 {
+		return -1
+	}
+	s.current = (s.current + 1) % len(s.matches)
+	return s.matches[s.current]
+}
+
+// prev focuses the preceding match (wrapping) and returns its line index
+func (s *textSearch) prev() int {
+	if len(s.matches) == 0 {
+		return -1
+	}
+	s.current = (s.current - 1 + len(s.matches)) % len(s.matches)
+	return s.matches[s.current]
+}
+
+// nearest focuses the first match at or after line (wrapping to the first match)
+func (s *textSearch) nearest(line int) int {
+	for i, m := range s.matches {
+		if m >= line {
+			s.current = i
+			return m
+		}
+	}
+	if len(s.matches) == 0 {
+		return -1
+	}
+	s.current = 0
+	return s.matches[0]
+}
+
+// status describes the search position, e.g. `"build" 3/12`
+func (s *textSearch) status() string {
+	if len(s.matches) == 0 {
+		return fmt.Sprintf("%q no matches", s.query)
+	}
+	return fmt.Sprintf("%q %d/%d", s.query, s.current+1, len(s.matches))
+}
+
+// render styles line with base, marking query occurrences with matchStyle
+func (s *textSearch) render(line string, base, matchStyle lipgloss.Style) string {
+	if !s.active() {
+		return base.Render(line)
+	}
+	locs := s.re.FindAllStringIndex(line, -1)
+	if len(locs) == 0 {
+		return base.Render(line)
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range locs {
+		if loc[0] > last {
+			b.WriteString(base.Render(line[last:loc[0]]))
+		}
+		b.WriteString(matchStyle.Render(line[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	if last < len(line) {
+		b.WriteString(base.Render(line[last:]))
+	}
+	return b.String()
+}
+
+// searchMatchStyles returns styles for ordinary and focused search matches
+func searchMatchStyles() (match, current lipgloss.Style) {
+	match = lipgloss.NewStyle().Reverse(true)
+	current = lipgloss.NewStyle().Background(ColorYellow).Foreground(ColorBg).Bold(true)
+	return match, current
+}
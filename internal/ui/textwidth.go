@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// TruncateWidth shortens s to at most width terminal columns, ending in "..."
+// when there is room for it. Width is measured per grapheme cluster, so CJK
+// (two columns) and emoji sequences are never split or miscounted the way
+// byte slicing does.
+func TruncateWidth(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+	if width <= 3 {
+		return runewidth.Truncate(s, width, "")
+	}
+	return runewidth.Truncate(s, width, "...")
+}
+
+// PadWidth right-pads s with spaces to width terminal columns. Use it instead
+// of fmt's %-*s, which pads by bytes and misaligns columns after wide text.
+func PadWidth(s string, width int) string {
+	if gap := width - runewidth.StringWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// ShortenPath fits a filesystem path in width columns by eliding the middle:
+// /Users/me/code/org/service/api becomes ~/code/…/service/api. The home
+// directory is shown as ~, and the head segment plus as many trailing
+// segments as fit are kept, since the end of a path is what tells projects
+// apart.
+func ShortenPath(path string, width int) string {
+	path = tildePath(path)
+	if runewidth.StringWidth(path) <= width {
+		return path
+	}
+
+	segments := strings.Split(path, "/")
+	headLen := 1
+	if segments[0] == "" || segments[0] == "~" {
+		headLen = 2 // Keep "/Users" or "~/code" rather than a bare root
+	}
+	if len(segments) > headLen+1 {
+		head := strings.Join(segments[:headLen], "/") + "/…"
+		var tail string
+		for i := len(segments) - 1; i > headLen; i-- {
+			candidate := "/" + segments[i] + tail
+			if runewidth.StringWidth(head+candidate) > width {
+				break
+			}
+			tail = candidate
+		}
+		if tail != "" {
+			return head + tail
+		}
+	}
+
+	// Even the last segment doesn't fit next to the head: keep its end
+	return truncateLeft(path, width)
+}
+
+// tildePath replaces the home directory prefix of path with ~
+func tildePath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" || home == "/" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if strings.HasPrefix(path, home+"/") {
+		return "~" + path[len(home):]
+	}
+	return path
+}
+
+// truncateLeft keeps the end of s, prefixed with "…", within width columns
+func truncateLeft(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for i := 1; i < len(runes); i++ {
+		if rest := string(runes[i:]); runewidth.StringWidth(rest) <= width-1 {
+			return "…" + rest
+		}
+	}
+	return "…"
+}
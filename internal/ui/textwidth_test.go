@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestTruncateWidth(t *testing.T) {
+	tests := []struct {
+		input string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"a longer title", 8, "a lon..."},
+		{"中文标题很长", 7, "中文..."},
+		{"🔥🔥🔥🔥", 5, "🔥..."},
+		{"abc", 0, ""},
+	}
+	for _, tt := range tests {
+		got := TruncateWidth(tt.input, tt.width)
+		if got != tt.want {
+			t.Errorf("TruncateWidth(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+		}
+		if w := runewidth.StringWidth(got); w > tt.width {
+			t.Errorf("TruncateWidth(%q, %d) is %d columns wide", tt.input, tt.width, w)
+		}
+	}
+}
+
+func TestPadWidth(t *testing.T) {
+	if got := PadWidth("日本", 6); got != "日本  " {
+		t.Errorf("PadWidth pads by bytes: %q", got)
+	}
+	if got := PadWidth("too wide", 3); got != "too wide" {
+		t.Errorf("PadWidth must not cut: %q", got)
+	}
+}
+
+func TestShortenPath(t *testing.T) {
+	t.Setenv("HOME", "/home/me")
+	tests := []struct {
+		path  string
+		width int
+		want  string
+	}{
+		{"/home/me/code/api", 40, "~/code/api"},
+		{"/home/me/code/acme/platform/service/api", 24, "~/code/…/service/api"},
+		{"/home/me/code/acme/platform/service/api", 16, "~/code/…/api"},
+		{"/opt/src/acme/platform/service/api", 20, "/opt/…/service/api"},
+		{"/home/me/code/a-very-long-project-name", 20, "…y-long-project-name"},
+		{"/home/me/项目/客户端/界面/前端", 16, "~/项目/…/前端"},
+	}
+	for _, tt := range tests {
+		got := ShortenPath(tt.path, tt.width)
+		if got != tt.want {
+			t.Errorf("ShortenPath(%q, %d) = %q, want %q", tt.path, tt.width, got, tt.want)
+		}
+		if w := runewidth.StringWidth(got); w > tt.width {
+			t.Errorf("ShortenPath(%q, %d) is %d columns wide", tt.path, tt.width, w)
+		}
+	}
+}
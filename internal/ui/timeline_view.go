@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// timelineSpan is how far back the timeline goes
+const timelineSpan = 24 * time.Hour
+
+// timelineSession is a session shown in the timeline, in deck order
+type timelineSession struct {
+	id    string
+	title string
+}
+
+// timelineLoadedMsg carries the activity collected by loadTimeline
+type timelineLoadedMsg struct {
+	sessions []timelineSession
+	rows     []statedb.ActivityRow
+	from, to time.Time
+	err      error
+}
+
+// TimelineView plots every session's running, waiting and attached time over
+// the last day, one swimlane per session, so stretches where agents sat
+// blocked on the user stand out. Opened by T.
+type TimelineView struct {
+	visible  bool
+	loading  bool
+	width    int
+	height   int
+	sessions []timelineSession
+	rows     []statedb.ActivityRow
+	from, to time.Time
+	err      error
+	offset   int // first lane shown
+}
+
+// NewTimelineView creates a new timeline overlay
+func NewTimelineView() *TimelineView {
+	return &TimelineView{}
+}
+
+// Show displays the overlay in a loading state until the activity arrives
+func (t *TimelineView) Show() {
+	t.visible = true
+	t.loading = true
+	t.offset = 0
+}
+
+// Hide hides the overlay
+func (t *TimelineView) Hide() {
+	t.visible = false
+	t.rows = nil
+}
+
+// IsVisible returns whether the overlay is visible
+func (t *TimelineView) IsVisible() bool {
+	return t.visible
+}
+
+// SetSize sets the dimensions for the overlay
+func (t *TimelineView) SetSize(width, height int) {
+	t.width = width
+	t.height = height
+}
+
+// SetActivity replaces the overlay's data
+func (t *TimelineView) SetActivity(msg timelineLoadedMsg) {
+	t.sessions = msg.sessions
+	t.rows = msg.rows
+	t.from, t.to = msg.from, msg.to
+	t.err = msg.err
+	t.loading = false
+}
+
+// visibleLanes is how many swimlanes fit on screen
+func (t *TimelineView) visibleLanes() int {
+	n := t.height - 14
+	if n < 3 {
+		n = 3
+	}
+	return n
+}
+
+// View renders the timeline overlay
+func (t *TimelineView) View() string {
+	if !t.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	labelStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	waitStyle := lipgloss.NewStyle().Foreground(ColorYellow)
+	runStyle := lipgloss.NewStyle().Foreground(ColorGreen)
+	attachStyle := lipgloss.NewStyle().Foreground(ColorAccent)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+
+	dialogWidth := t.width - 4
+	if dialogWidth > 160 {
+		dialogWidth = 160
+	}
+	if dialogWidth < 50 {
+		dialogWidth = 50
+	}
+	contentWidth := dialogWidth - 6
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Activity Timeline · last 24h"))
+	b.WriteString("\n\n")
+
+	switch {
+	case t.loading:
+		b.WriteString(labelStyle.Render("Loading activity..."))
+		b.WriteString("\n\n")
+	case t.err != nil:
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorRed).Render(t.err.Error()))
+		b.WriteString("\n\n")
+	default:
+		const waitWidth = 16
+		labelWidth := 18
+		if contentWidth < 80 {
+			labelWidth = 12
+		}
+		slots := contentWidth - labelWidth - waitWidth - 2
+		lanes := session.BuildTimeline(t.rows, t.from, t.to, slots)
+
+		var shown []timelineSession
+		for _, s := range t.sessions {
+			if lanes[s.id] != nil {
+				shown = append(shown, s)
+			}
+		}
+		if len(shown) == 0 {
+			b.WriteString(labelStyle.Render("No activity recorded in the last 24 hours"))
+			b.WriteString("\n\n")
+			break
+		}
+
+		b.WriteString(strings.Repeat(" ", labelWidth+1))
+		b.WriteString(labelStyle.Render(timelineAxis(t.from, t.to, slots)))
+		b.WriteString(labelStyle.Render(fmt.Sprintf(" %*s", waitWidth, "waiting (max)")))
+		b.WriteString("\n")
+
+		if t.offset > len(shown)-1 {
+			t.offset = len(shown) - 1
+		}
+		end := t.offset + t.visibleLanes()
+		if end > len(shown) {
+			end = len(shown)
+		}
+		for _, s := range shown[t.offset:end] {
+			lane := lanes[s.id]
+			b.WriteString(PadWidth(TruncateWidth(s.title, labelWidth), labelWidth))
+			b.WriteString(" ")
+			for _, kind := range lane.Slots {
+				switch kind {
+				case session.ActivityWaiting:
+					b.WriteString(waitStyle.Render("█"))
+				case session.ActivityRunning:
+					b.WriteString(runStyle.Render("█"))
+				case session.ActivityAttached:
+					b.WriteString(attachStyle.Render("▒"))
+				default:
+					b.WriteString(labelStyle.Render("·"))
+				}
+			}
+			wait := ""
+			if lane.Waiting > 0 {
+				wait = fmt.Sprintf("%s (%s)", shortDuration(lane.Waiting), shortDuration(lane.LongestWait))
+			}
+			b.WriteString(waitStyle.Render(fmt.Sprintf(" %*s", waitWidth, wait)))
+			b.WriteString("\n")
+		}
+		if len(shown) > end-t.offset {
+			b.WriteString(labelStyle.Render(fmt.Sprintf("%d-%d of %d sessions", t.offset+1, end, len(shown))))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(runStyle.Render("█") + labelStyle.Render(" running  "))
+		b.WriteString(waitStyle.Render("█") + labelStyle.Render(" waiting on you  "))
+		b.WriteString(attachStyle.Render("▒") + labelStyle.Render(" attached  "))
+		b.WriteString(labelStyle.Render("· idle"))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(footerStyle.Render("j/k scroll • r refresh • esc close"))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(b.String())
+	return centerInScreen(box, t.width, t.height)
+}
+
+// timelineAxis labels the slots with hours, spaced so the labels don't run
+// into each other
+func timelineAxis(from, to time.Time, slots int) string {
+	axis := []rune(strings.Repeat(" ", slots))
+	if slots <= 0 {
+		return ""
+	}
+	slotLen := to.Sub(from) / time.Duration(slots)
+	step := 1
+	for _, h := range []int{1, 2, 3, 4, 6, 12} {
+		step = h
+		if time.Duration(h)*time.Hour/slotLen >= 6 {
+			break
+		}
+	}
+	hour := from.Truncate(time.Hour).Add(time.Hour)
+	for ; hour.Before(to); hour = hour.Add(time.Hour) {
+		if hour.Hour()%step != 0 {
+			continue
+		}
+		pos := int(hour.Sub(from) / slotLen)
+		label := hour.Format("15h")
+		if pos+len(label) > slots {
+			break
+		}
+		copy(axis[pos:], []rune(label))
+	}
+	return string(axis)
+}
+
+// shortDuration formats d as e.g. 2h05m or 12m
+func shortDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// loadTimeline returns a tea.Cmd that reads the last day's activity,
+// including intervals still in progress
+func (h *Home) loadTimeline() tea.Cmd {
+	instances := h.groupTree.GetAllInstances()
+	return func() tea.Msg {
+		to := time.Now()
+		from := to.Add(-timelineSpan)
+		msg := timelineLoadedMsg{from: from, to: to}
+		db := statedb.GetGlobal()
+		if db == nil {
+			msg.err = fmt.Errorf("no state database for this profile")
+			return msg
+		}
+		rows, err := db.LoadActivity(from)
+		if err != nil {
+			msg.err = fmt.Errorf("failed to load activity: %w", err)
+			return msg
+		}
+		for _, inst := range instances {
+			msg.sessions = append(msg.sessions, timelineSession{id: inst.ID, title: inst.Title})
+			rows = append(rows, inst.OpenActivity(to)...)
+		}
+		msg.rows = rows
+		return msg
+	}
+}
+
+// handleTimelineKey handles keys while the timeline overlay is open
+func (h *Home) handleTimelineKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tl := h.timelineView
+	switch msg.String() {
+	case "esc", "q", "T":
+		tl.Hide()
+	case "j", "down":
+		tl.offset++
+	case "k", "up":
+		if tl.offset > 0 {
+			tl.offset--
+		}
+	case "r":
+		tl.loading = true
+		return h, h.loadTimeline()
+	}
+	return h, nil
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+func TestTimelineView(t *testing.T) {
+	tl := NewTimelineView()
+	tl.SetSize(140, 40)
+	tl.Show()
+	if !strings.Contains(tl.View(), "Loading activity") {
+		t.Error("timeline should show a loading state before the activity arrives")
+	}
+
+	to := time.Date(2024, 6, 1, 18, 0, 0, 0, time.Local)
+	from := to.Add(-timelineSpan)
+	tl.SetActivity(timelineLoadedMsg{
+		sessions: []timelineSession{{id: "a", title: "api-server"}, {id: "b", title: "quiet"}},
+		rows: []statedb.ActivityRow{
+			{InstanceID: "a", Kind: session.ActivityRunning, Started: to.Add(-5 * time.Hour), Ended: to.Add(-4 * time.Hour)},
+			{InstanceID: "a", Kind: session.ActivityWaiting, Started: to.Add(-4 * time.Hour), Ended: to.Add(-2 * time.Hour)},
+		},
+		from: from,
+		to:   to,
+	})
+	view := tl.View()
+	for _, want := range []string{"api-server", "2h00m (2h00m)", "█", "waiting on you"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+	if strings.Contains(view, "quiet") {
+		t.Error("sessions without activity should have no lane")
+	}
+
+	tl.SetActivity(timelineLoadedMsg{sessions: tl.sessions, from: from, to: to})
+	if !strings.Contains(tl.View(), "No activity recorded") {
+		t.Error("empty timeline should say so")
+	}
+}
+
+func TestTimelineViewKeys(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 40
+
+	_, cmd := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	if !home.timelineView.IsVisible() || cmd == nil {
+		t.Fatal("T should open the timeline and load activity")
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if home.timelineView.IsVisible() {
+		t.Error("esc should close the timeline")
+	}
+}